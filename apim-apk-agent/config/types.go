@@ -118,7 +118,9 @@ type controlPlane struct {
 
 // Dataplane struct contains the configurations related to the APK
 type dataPlane struct {
-	Enabled            bool
+	Enabled bool
+	// K8ResourceEndpoint is the config deployer endpoint used to generate the CRs of an API. The CRs are
+	// generated within the agent when it is empty.
 	K8ResourceEndpoint string
 	Namespace          string
 }
@@ -9,6 +9,7 @@ require (
 	github.com/sirupsen/logrus v1.9.3
 	github.com/stretchr/testify v1.10.0
 	github.com/wso2/apk/common-go-libs v0.0.0-20250301092338-35fc1435165d
+	github.com/wso2/product-apim-tooling/apk-transformer v0.0.0
 	google.golang.org/grpc v1.70.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 	gopkg.in/yaml.v2 v2.4.0
@@ -110,3 +111,5 @@ require (
 	github.com/wso2/apk/adapter v0.0.0-20250301092338-35fc1435165d
 	golang.org/x/sys v0.30.0 // indirect
 )
+
+replace github.com/wso2/product-apim-tooling/apk-transformer => ../apk-transformer
@@ -27,8 +27,6 @@ const (
 	// Http protocol related constants
 	postHTTPMethod    = "POST"
 	contentTypeHeader = "Content-Type"
-	internalKeyHeader = "internal-key"
-	apiKeyHeader      = "apikey"
 
 	// K8s CRD fields
 	k8sKindField                = "kind"
@@ -47,63 +45,4 @@ const (
 	k8sKindAPI         = "API"
 	k8sKindTokenIssuer = "TokenIssuer"
 	apkCRDAPIVersion   = "dp.wso2.com/v1alpha1"
-
-	// Auth Types
-	mTLS   = "mTLS"
-	jwt    = "JWT"
-	oAuth2 = "OAuth2"
-	apiKey = "APIKey"
-
-	// Security Scheme values
-	oAuth2SecScheme              = "oauth2"
-	applicationSecurityMandatory = "oauth_basic_auth_api_key_mandatory"
-	applicationSecurityOptional  = "oauth_basic_auth_api_key_optional"
-	mutualSSL                    = "mutualssl"
-	mutualSSLMandatory           = "mutualssl_mandatory"
-	apiKeySecScheme              = "api_key"
-
-	// Optionality constants
-	mandatory = "mandatory"
-	optional  = "optional"
-
-	// Interceptor constants
-	requestHeader                 = "request_header"
-	requestBody                   = "request_body"
-	requestTrailers               = "request_trailers"
-	requestContext                = "request_context"
-	includes                      = "includes"
-	interceptorServiceURL         = "interceptorServiceURL"
-	https                         = "https"
-	requestInterceptorSecretName  = "request-interceptor-tls-secret"
-	responseInterceptorSecretName = "response-interceptor-tls-secret"
-	tlsKey                        = "tls.crt"
-
-	// BackendJWT constants
-	encoding         = "encoding"
-	header           = "header"
-	signingAlgorithm = "signingAlgorithm"
-	tokenTTL         = "tokenTTL"
-	base64Url        = "Base64Url"
-
-	// APK Operation Policy constants
-	interceptorPolicy     = "Interceptor"
-	backendJWTPolicy      = "BackendJwt"
-	addHeaderPolicy       = "AddHeader"
-	removeHeaderPolicy    = "RemoveHeader"
-	requestRedirectPolicy = "RequestRedirect"
-	requestMirrorPolicy   = "RequestMirror"
-	modelBasedRoundRobin  = "ModelBasedRoundRobin"
-
-	// APK BackendJWT parameter constants
-	base64url = "Base64url"
-
-	// APK header modification parameter constants
-	url         = "url"
-	statusCode  = "statusCode"
-	headerName  = "headerName"
-	headerValue = "headerValue"
-
-	// Version constants
-	v1 = "v1"
-	v2 = "v2"
 )
@@ -1,31 +0,0 @@
-/*
- *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
- *
- *  Licensed under the Apache License, Version 2.0 (the "License");
- *  you may not use this file except in compliance with the License.
- *  You may obtain a copy of the License at
- *
- *  http://www.apache.org/licenses/LICENSE-2.0
- *
- *  Unless required by applicable law or agreed to in writing, software
- *  distributed under the License is distributed on an "AS IS" BASIS,
- *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- *  See the License for the specific language governing permissions and
- *  limitations under the License.
- *
- */
-
-package transformer
-
-// EndpointCert holds the data belongs to a single endpoint certificate configuration
-type EndpointCert struct {
-	Alias       string `json:"alias"`
-	Endpoint    string `json:"endpoint"`
-	Certificate string `json:"certificate"`
-	TenantID    int    `json:"tenantId"`
-}
-
-// EndpointCertDescriptor contains data related to one or more endpoint certificates for an API
-type EndpointCertDescriptor struct {
-	EndpointCertData []EndpointCert `json:"data"`
-}
@@ -23,11 +23,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	apktransformer "github.com/wso2/product-apim-tooling/apk-transformer"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -152,12 +154,48 @@ func TestAPKConfGeneration(t *testing.T) {
 				assert.NotEqual(t, "null", apiUUID)
 				assert.NotEqual(t, uint32(0), revisionID)
 				assert.NotNil(t, configuredRateLimitPoliciesMap)
-				assert.IsType(t, []EndpointSecurityConfig{}, endpointSecurityData) // Need to be refined maybe
+				assert.IsType(t, []apktransformer.EndpointSecurityConfig{}, endpointSecurityData) // Need to be refined maybe
 			}
 		}
 	}
 }
 
+func TestGenerateCRsWithoutConfigDeployer(t *testing.T) {
+	zipFileBytes, err := os.ReadFile("../../resources/test-resources/Base/Test_Payload.zip")
+	assert.NoError(t, err)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipFileBytes), int64(len(zipFileBytes)))
+	assert.NoError(t, err)
+
+	for _, zipFile := range zipReader.File {
+		apiArtifact, err := DecodeAPIArtifact(zipFile)
+		if err != nil {
+			continue
+		}
+		apkConf, apiUUID, revisionID, rateLimitPolicies, _, apk, _, _, err := GenerateAPKConf(apiArtifact.APIJson, apiArtifact.CertArtifact, apiArtifact.Endpoints, "default")
+		assert.NoError(t, err)
+		if apk.Type != "REST" {
+			continue
+		}
+
+		// an empty config deployer endpoint generates the CRs within the agent
+		crs, err := GenerateCRs(apkConf, apiArtifact.Schema, CertContainer{}, "", "default")
+		assert.NoError(t, err)
+		assert.Equal(t, GetUniqueIDForAPI(apk.Name, apk.Version, "default"), crs.API.Name)
+		assert.Equal(t, apk.Name, crs.API.Spec.APIName)
+		assert.NotEmpty(t, crs.HTTPRoutes)
+		assert.NotEmpty(t, crs.Backends)
+		assert.NotEmpty(t, crs.Authentication)
+		assert.NotEmpty(t, crs.ConfigMaps)
+
+		environments := []Environment{{Name: "Default", Vhost: "gw.example.com", Type: "hybrid"}}
+		UpdateCRS(crs, &environments, "default", apiUUID, strconv.FormatUint(uint64(revisionID), 10), "apk", rateLimitPolicies)
+		assert.Equal(t, apiUUID, crs.API.ObjectMeta.Labels[k8APIUuidField])
+		for _, routeName := range crs.API.Spec.Production[0].RouteRefs {
+			assert.Equal(t, []gwapiv1.Hostname{"gw.example.com"}, crs.HTTPRoutes[routeName].Spec.Hostnames)
+		}
+	}
+}
+
 func TestAddRevisionAndAPIUUID(t *testing.T) {
 	for _, k8Json := range sampleK8Artifacts {
 		var k8sArtifact K8sArtifacts
@@ -368,7 +406,7 @@ func TestBrokenZipHandlingFlow(t *testing.T) {
 					assert.Equal(t, uint32(0), revisionID)
 					assert.Error(t, apkErr)
 					assert.NotNil(t, configuredRateLimitPoliciesMap)
-					assert.IsType(t, []EndpointSecurityConfig{}, endpointSecurityData) // Need to be refined maybe
+					assert.IsType(t, []apktransformer.EndpointSecurityConfig{}, endpointSecurityData) // Need to be refined maybe
 				}
 				// If API_Json is broken then the generate conf is invalid hence it will be failed in CR generation
 				if strings.Contains(zipFile.Name, "Empty_Definition") {
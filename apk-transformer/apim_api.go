@@ -0,0 +1,281 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// APIYaml is the api.yaml or the api.json of an APIM API project
+type APIYaml struct {
+	Type    string  `json:"type" yaml:"type"`
+	Version string  `json:"version" yaml:"version"`
+	Data    APIMApi `json:"data" yaml:"data"`
+}
+
+// APIMApi represents an APIM API along with its basic information and the operations
+type APIMApi struct {
+	ID                          string                   `json:"id" yaml:"id"`
+	Name                        string                   `json:"name" yaml:"name"`
+	Version                     string                   `json:"version" yaml:"version"`
+	Context                     string                   `json:"context" yaml:"context"`
+	DefaultVersion              bool                     `json:"isDefaultVersion" yaml:"isDefaultVersion"`
+	Type                        string                   `json:"type" yaml:"type"`
+	AuthorizationHeader         string                   `json:"authorizationHeader" yaml:"authorizationHeader"`
+	APIKeyHeader                string                   `json:"apiKeyHeader" yaml:"apiKeyHeader"`
+	SecuritySchemes             []string                 `json:"securityScheme" yaml:"securityScheme"`
+	AdditionalProperties        []APIMAdditionalProperty `json:"additionalProperties" yaml:"additionalProperties"`
+	CORSConfiguration           CORSConfiguration        `json:"corsConfiguration" yaml:"corsConfiguration"`
+	EndpointConfig              EndpointConfig           `json:"endpointConfig" yaml:"endpointConfig"`
+	PrimaryProductionEndpointID string                   `json:"primaryProductionEndpointId" yaml:"primaryProductionEndpointId"`
+	PrimarySandboxEndpointID    string                   `json:"primarySandboxEndpointId" yaml:"primarySandboxEndpointId"`
+	Operations                  []APIMOperation          `json:"operations" yaml:"operations"`
+	OrganizationID              string                   `json:"organizationId" yaml:"organizationId"`
+	RevisionID                  uint32                   `json:"revisionId" yaml:"revisionId"`
+	RevisionedAPIID             string                   `json:"revisionedApiId" yaml:"revisionedApiId"`
+	APIThrottlingPolicy         string                   `json:"apiThrottlingPolicy" yaml:"apiThrottlingPolicy"`
+	APIPolicies                 APIMOperationPolicies    `json:"apiPolicies" yaml:"apiPolicies"`
+	SubtypeConfiguration        SubtypeConfiguration     `json:"subtypeConfiguration" yaml:"subtypeConfiguration"`
+	MaxTps                      *MaxTps                  `json:"maxTps" yaml:"maxTps"`
+}
+
+// APIMAdditionalProperty represents a custom property of an APIM API
+type APIMAdditionalProperty struct {
+	Name               string `json:"name" yaml:"name"`
+	Value              string `json:"value" yaml:"value"`
+	DisplayInDevPortal bool   `json:"display" yaml:"display"`
+}
+
+// APIMOperation represents an operation of an APIM API with its target, verb, scopes and policies
+type APIMOperation struct {
+	Target            string                 `json:"target" yaml:"target"`
+	Verb              string                 `json:"verb" yaml:"verb"`
+	Scopes            []string               `json:"scopes" yaml:"scopes"`
+	OperationPolicies *APIMOperationPolicies `json:"operationPolicies" yaml:"operationPolicies"`
+	ThrottlingPolicy  string                 `json:"throttlingPolicy" yaml:"throttlingPolicy"`
+	AuthType          string                 `json:"authType" yaml:"authType"`
+}
+
+// APIMOperationPolicies organizes the request, response and fault policies of an operation
+type APIMOperationPolicies struct {
+	Request  []APIMOperationPolicy `json:"request" yaml:"request"`
+	Response []APIMOperationPolicy `json:"response" yaml:"response"`
+	Fault    []APIMOperationPolicy `json:"fault" yaml:"fault"`
+}
+
+// APIMOperationPolicy represents a policy attached to an operation along with its parameters
+type APIMOperationPolicy struct {
+	PolicyName    string                 `json:"policyName" yaml:"policyName"`
+	PolicyVersion string                 `json:"policyVersion" yaml:"policyVersion"`
+	PolicyID      string                 `json:"policyId" yaml:"policyId"`
+	Parameters    map[string]interface{} `json:"parameters" yaml:"parameters"`
+}
+
+// EndpointConfig represents the endpoints of an APIM API along with their security
+type EndpointConfig struct {
+	EndpointType        string                 `json:"endpoint_type" yaml:"endpoint_type"`
+	SandboxEndpoints    EndpointDetails        `json:"sandbox_endpoints" yaml:"sandbox_endpoints"`
+	ProductionEndpoints EndpointDetails        `json:"production_endpoints" yaml:"production_endpoints"`
+	EndpointSecurity    EndpointSecurityConfig `json:"endpoint_security" yaml:"endpoint_security"`
+}
+
+// EndpointDetails holds the URL of an endpoint. The endpoints of the load balanced endpoint type are a list,
+// in which case the first endpoint of the list is read.
+type EndpointDetails struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// endpointDetails has the fields of EndpointDetails without its unmarshalling methods
+type endpointDetails EndpointDetails
+
+// UnmarshalJSON reads an endpoint or the first endpoint of a list of endpoints
+func (details *EndpointDetails) UnmarshalJSON(content []byte) error {
+	var endpoints []endpointDetails
+	if err := json.Unmarshal(content, &endpoints); err == nil {
+		if len(endpoints) > 0 {
+			*details = EndpointDetails(endpoints[0])
+		}
+		return nil
+	}
+	var endpoint endpointDetails
+	if err := json.Unmarshal(content, &endpoint); err != nil {
+		return err
+	}
+	*details = EndpointDetails(endpoint)
+	return nil
+}
+
+// UnmarshalYAML reads an endpoint or the first endpoint of a list of endpoints
+func (details *EndpointDetails) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var endpoints []endpointDetails
+	if err := unmarshal(&endpoints); err == nil {
+		if len(endpoints) > 0 {
+			*details = EndpointDetails(endpoints[0])
+		}
+		return nil
+	}
+	var endpoint endpointDetails
+	if err := unmarshal(&endpoint); err != nil {
+		return err
+	}
+	*details = EndpointDetails(endpoint)
+	return nil
+}
+
+// EndpointSecurityConfig holds the security of the production and the sandbox endpoints of an API
+type EndpointSecurityConfig struct {
+	Production SecurityObj `json:"production" yaml:"production"`
+	Sandbox    SecurityObj `json:"sandbox" yaml:"sandbox"`
+}
+
+// SecurityObj holds the security of an endpoint
+type SecurityObj struct {
+	Enabled          bool            `json:"enabled" yaml:"enabled"`
+	EndpointUUID     string          `json:"endpointUUID" yaml:"endpointUUID"`
+	Type             string          `json:"type" yaml:"type"`
+	APIKeyValue      string          `json:"apiKeyValue" yaml:"apiKeyValue"`
+	APIKeyIdentifier string          `json:"apiKeyIdentifier" yaml:"apiKeyIdentifier"`
+	Username         string          `json:"username" yaml:"username"`
+	Password         string          `json:"password" yaml:"password"`
+	GrantType        string          `json:"grantType" yaml:"grantType"`
+	TokenURL         string          `json:"tokenUrl" yaml:"tokenUrl"`
+	ClientID         string          `json:"clientId" yaml:"clientId"`
+	ClientSecret     string          `json:"clientSecret" yaml:"clientSecret"`
+	CustomParameters json.RawMessage `json:"customParameters" yaml:"-"`
+}
+
+// SubtypeConfiguration holds the subtype of an API, such as AIAPI, and its configuration
+type SubtypeConfiguration struct {
+	Subtype       string `json:"subtype" yaml:"subtype"`
+	Configuration string `json:"_configuration" yaml:"_configuration"`
+}
+
+// subtypeConfig holds the configuration of an AI API
+type subtypeConfig struct {
+	LLMProviderID string `json:"llmProviderId"`
+}
+
+// MaxTps holds the maximum number of requests per a time unit allowed to the production and the sandbox
+// endpoints of an API, along with the token based throttling of an AI API
+type MaxTps struct {
+	Production                        *int                        `json:"production" yaml:"production"`
+	ProductionTimeUnit                *string                     `json:"productionTimeUnit" yaml:"productionTimeUnit"`
+	Sandbox                           *int                        `json:"sandbox" yaml:"sandbox"`
+	SandboxTimeUnit                   *string                     `json:"sandboxTimeUnit" yaml:"sandboxTimeUnit"`
+	TokenBasedThrottlingConfiguration *TokenBasedThrottlingConfig `json:"tokenBasedThrottlingConfiguration" yaml:"tokenBasedThrottlingConfiguration"`
+}
+
+// TokenBasedThrottlingConfig holds the limits of the prompt and the completion tokens of the production and
+// the sandbox endpoints of an AI API
+type TokenBasedThrottlingConfig struct {
+	ProductionMaxPromptTokenCount     *int  `json:"productionMaxPromptTokenCount" yaml:"productionMaxPromptTokenCount"`
+	ProductionMaxCompletionTokenCount *int  `json:"productionMaxCompletionTokenCount" yaml:"productionMaxCompletionTokenCount"`
+	ProductionMaxTotalTokenCount      *int  `json:"productionMaxTotalTokenCount" yaml:"productionMaxTotalTokenCount"`
+	SandboxMaxPromptTokenCount        *int  `json:"sandboxMaxPromptTokenCount" yaml:"sandboxMaxPromptTokenCount"`
+	SandboxMaxCompletionTokenCount    *int  `json:"sandboxMaxCompletionTokenCount" yaml:"sandboxMaxCompletionTokenCount"`
+	SandboxMaxTotalTokenCount         *int  `json:"sandboxMaxTotalTokenCount" yaml:"sandboxMaxTotalTokenCount"`
+	IsTokenBasedThrottlingEnabled     *bool `json:"isTokenBasedThrottlingEnabled" yaml:"isTokenBasedThrottlingEnabled"`
+}
+
+// EndpointsYaml is the endpoints.yaml or the endpoints.json of an APIM API project holding the endpoints
+// of an API with multiple endpoints
+type EndpointsYaml struct {
+	Type    string     `json:"type" yaml:"type"`
+	Version string     `json:"version" yaml:"version"`
+	Data    []Endpoint `json:"data" yaml:"data"`
+}
+
+// Endpoint represents an endpoint of an API with its UUID, name, configuration and deployment stage
+type Endpoint struct {
+	ID              string         `json:"id" yaml:"id"`
+	Name            string         `json:"name" yaml:"name"`
+	EndpointConfig  EndpointConfig `json:"endpointConfig" yaml:"endpointConfig"`
+	DeploymentStage string         `json:"deploymentStage" yaml:"deploymentStage"`
+}
+
+// CertificateArtifact holds the content of the client and the endpoint certificate descriptors of an API project
+type CertificateArtifact struct {
+	ClientCerts   string `json:"clientCert"`
+	EndpointCerts string `json:"endpointCert"`
+}
+
+// CertDescriptor holds the client certificates of an API
+type CertDescriptor struct {
+	CertData []ClientCert `json:"data" yaml:"data"`
+}
+
+// ClientCert represents a client certificate used for the mutual SSL of an API
+type ClientCert struct {
+	Alias         string        `json:"alias" yaml:"alias"`
+	Certificate   string        `json:"certificate" yaml:"certificate"`
+	TierName      string        `json:"tierName" yaml:"tierName"`
+	APIIdentifier APIIdentifier `json:"apiIdentifier" yaml:"apiIdentifier"`
+}
+
+// APIIdentifier identifies the API a client certificate belongs to
+type APIIdentifier struct {
+	ProviderName string `json:"providerName" yaml:"providerName"`
+	APIName      string `json:"apiName" yaml:"apiName"`
+	Version      string `json:"version" yaml:"version"`
+	UUID         string `json:"uuid" yaml:"uuid"`
+	ID           int    `json:"id" yaml:"id"`
+}
+
+// EndpointCertDescriptor holds the endpoint certificates of an API
+type EndpointCertDescriptor struct {
+	EndpointCertData []EndpointCert `json:"data" yaml:"data"`
+}
+
+// EndpointCert represents a certificate trusted for an endpoint of an API
+type EndpointCert struct {
+	Alias       string `json:"alias" yaml:"alias"`
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+	Certificate string `json:"certificate" yaml:"certificate"`
+	TenantID    int    `json:"tenantId" yaml:"tenantId"`
+}
+
+// ParseAPI parses the content of the api.yaml or the api.json of an APIM API project
+func ParseAPI(content []byte) (*APIMApi, error) {
+	var apiYaml APIYaml
+	if err := unmarshalJSONOrYAML(content, &apiYaml); err != nil {
+		return nil, err
+	}
+	return &apiYaml.Data, nil
+}
+
+// ParseEndpoints parses the content of the endpoints.yaml or the endpoints.json of an APIM API project
+func ParseEndpoints(content []byte) ([]Endpoint, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var endpointsYaml EndpointsYaml
+	if err := unmarshalJSONOrYAML(content, &endpointsYaml); err != nil {
+		return nil, err
+	}
+	return endpointsYaml.Data, nil
+}
+
+// unmarshalJSONOrYAML unmarshals the content as JSON, and as YAML if it is not JSON
+func unmarshalJSONOrYAML(content []byte, out interface{}) error {
+	if err := json.Unmarshal(content, out); err != nil {
+		return yaml.Unmarshal(content, out)
+	}
+	return nil
+}
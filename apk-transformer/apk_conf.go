@@ -0,0 +1,243 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"errors"
+
+	"gopkg.in/yaml.v2"
+)
+
+// APKConf represents the apk-conf definition of an API. The yaml tags follow the apk-conf
+// format accepted by the APK config deployer.
+type APKConf struct {
+	Name                   string                  `yaml:"name,omitempty"`
+	ID                     string                  `yaml:"id,omitempty"`
+	Version                string                  `yaml:"version,omitempty"`
+	BasePath               string                  `yaml:"basePath,omitempty"`
+	Type                   string                  `yaml:"type,omitempty"`
+	DefaultVersion         bool                    `yaml:"defaultVersion"`
+	DefinitionPath         string                  `yaml:"definitionPath,omitempty"`
+	SubscriptionValidation bool                    `yaml:"subscriptionValidation,omitempty"`
+	EndpointConfigurations *EndpointConfigurations `yaml:"endpointConfigurations,omitempty"`
+	Operations             []Operation             `yaml:"operations,omitempty"`
+	Authentication         []AuthConfiguration     `yaml:"authentication,omitempty"`
+	CorsConfig             *CORSConfiguration      `yaml:"corsConfiguration,omitempty"`
+	RateLimit              *RateLimit              `yaml:"rateLimit,omitempty"`
+	AdditionalProperties   []AdditionalProperty    `yaml:"additionalProperties,omitempty"`
+	APIPolicies            *OperationPolicies      `yaml:"apiPolicies,omitempty"`
+	AIProvider             *AIProvider             `yaml:"aiProvider,omitempty"`
+}
+
+// EndpointConfigurations holds the production and sandbox endpoints of an API
+type EndpointConfigurations struct {
+	Production []EndpointConfiguration `yaml:"production,omitempty"`
+	Sandbox    []EndpointConfiguration `yaml:"sandbox,omitempty"`
+}
+
+// EndpointConfiguration represents a single backend endpoint of an API
+type EndpointConfiguration struct {
+	Endpoint    string               `yaml:"endpoint,omitempty"`
+	Certificate *EndpointCertificate `yaml:"certificate,omitempty"`
+	Security    *EndpointSecurity    `yaml:"endpointSecurity,omitempty"`
+	AIRatelimit *AIRatelimit         `yaml:"aiRatelimit,omitempty"`
+}
+
+// EndpointCertificate refers to the secret holding the certificate of a backend
+type EndpointCertificate struct {
+	SecretName string `yaml:"secretName"`
+	SecretKey  string `yaml:"secretKey"`
+}
+
+// EndpointSecurity refers to the secret holding the credentials of a backend
+type EndpointSecurity struct {
+	Enabled      bool       `yaml:"enabled,omitempty"`
+	SecurityType SecretInfo `yaml:"securityType,omitempty"`
+}
+
+// SecretInfo holds the keys of the secret used for the backend security
+type SecretInfo struct {
+	SecretName     string `yaml:"secretName,omitempty"`
+	UsernameKey    string `yaml:"userNameKey,omitempty"`
+	PasswordKey    string `yaml:"passwordKey,omitempty"`
+	In             string `yaml:"in,omitempty"`
+	APIKeyNameKey  string `yaml:"apiKeyNameKey,omitempty"`
+	APIKeyValueKey string `yaml:"apiKeyValueKey,omitempty"`
+}
+
+// AIRatelimit represents the token and the request count based rate limits of an endpoint of an AI API
+type AIRatelimit struct {
+	Enabled bool        `yaml:"enabled"`
+	Token   TokenAIRL   `yaml:"token"`
+	Request RequestAIRL `yaml:"request"`
+}
+
+// TokenAIRL represents the limits of the prompt, the completion and the total tokens per a time unit
+type TokenAIRL struct {
+	PromptLimit     int    `yaml:"promptLimit"`
+	CompletionLimit int    `yaml:"completionLimit"`
+	TotalLimit      int    `yaml:"totalLimit"`
+	Unit            string `yaml:"unit"`
+}
+
+// RequestAIRL represents the limit of the requests per a time unit
+type RequestAIRL struct {
+	RequestLimit int    `yaml:"requestLimit"`
+	Unit         string `yaml:"unit"`
+}
+
+// AIProvider refers to the AI provider of an AI API
+type AIProvider struct {
+	Name       string `yaml:"name,omitempty"`
+	APIVersion string `yaml:"apiVersion,omitempty"`
+}
+
+// Operation represents a resource of an API
+type Operation struct {
+	Target            string             `yaml:"target,omitempty"`
+	Verb              string             `yaml:"verb,omitempty"`
+	Scopes            []string           `yaml:"scopes"`
+	Secured           bool               `yaml:"secured"`
+	OperationPolicies *OperationPolicies `yaml:"operationPolicies,omitempty"`
+	RateLimit         *RateLimit         `yaml:"rateLimit,omitempty"`
+}
+
+// OperationPolicies holds the request and the response policies of an API or an operation
+type OperationPolicies struct {
+	Request  []OperationPolicy `yaml:"request,omitempty"`
+	Response []OperationPolicy `yaml:"response,omitempty"`
+}
+
+// OperationPolicy represents a policy of an API or an operation. Parameters holds one of InterceptorService,
+// BackendJWT, Header, RedirectPolicy, URLList and ModelBasedRoundRobin when the apk-conf is generated.
+type OperationPolicy struct {
+	PolicyName    string      `yaml:"policyName,omitempty"`
+	PolicyVersion string      `yaml:"policyVersion,omitempty"`
+	PolicyID      string      `yaml:"policyId,omitempty"`
+	Parameters    interface{} `yaml:"parameters,omitempty"`
+}
+
+// InterceptorService holds the parameters of a policy calling an interceptor service for the requests or
+// the responses
+type InterceptorService struct {
+	BackendURL      string `yaml:"backendUrl,omitempty"`
+	HeadersEnabled  bool   `yaml:"headersEnabled,omitempty"`
+	BodyEnabled     bool   `yaml:"bodyEnabled,omitempty"`
+	TrailersEnabled bool   `yaml:"trailersEnabled,omitempty"`
+	ContextEnabled  bool   `yaml:"contextEnabled,omitempty"`
+	TLSSecretName   string `yaml:"tlsSecretName,omitempty"`
+	TLSSecretKey    string `yaml:"tlsSecretKey,omitempty"`
+}
+
+// BackendJWT holds the parameters of a policy sending a JWT to the backend
+type BackendJWT struct {
+	Encoding         string `yaml:"encoding,omitempty"`
+	Header           string `yaml:"header,omitempty"`
+	SigningAlgorithm string `yaml:"signingAlgorithm,omitempty"`
+	TokenTTL         int    `yaml:"tokenTTL,omitempty"`
+}
+
+// Header holds the parameters of a policy adding or removing a header
+type Header struct {
+	HeaderName  string `yaml:"headerName"`
+	HeaderValue string `yaml:"headerValue,omitempty"`
+}
+
+// RedirectPolicy holds the parameters of a policy redirecting the requests
+type RedirectPolicy struct {
+	URL        string `yaml:"url,omitempty"`
+	StatusCode int    `yaml:"statusCode,omitempty"`
+}
+
+// URLList holds the URLs the requests are mirrored to
+type URLList struct {
+	URLs []string `yaml:"urls,omitempty"`
+}
+
+// ModelBasedRoundRobin holds the parameters of a policy distributing the requests of an AI API among models
+type ModelBasedRoundRobin struct {
+	OnQuotaExceedSuspendDuration int              `yaml:"onQuotaExceedSuspendDuration"`
+	ProductionModels             []ModelEndpoints `yaml:"productionModels"`
+	SandboxModels                []ModelEndpoints `yaml:"sandboxModels"`
+}
+
+// ModelEndpoints refers to a model and the endpoint serving it along with its weight
+type ModelEndpoints struct {
+	Model    string `yaml:"model"`
+	Endpoint string `yaml:"endpoint"`
+	Weight   int    `yaml:"weight"`
+}
+
+// RateLimit represents a request count based rate limit
+type RateLimit struct {
+	RequestsPerUnit int    `yaml:"requestsPerUnit,omitempty"`
+	Unit            string `yaml:"unit,omitempty"`
+}
+
+// AuthConfiguration represents a single authentication type enabled for an API
+type AuthConfiguration struct {
+	Required          string        `yaml:"required,omitempty"`
+	AuthType          string        `yaml:"authType,omitempty"`
+	HeaderName        string        `yaml:"headerName,omitempty"`
+	SendTokenUpStream bool          `yaml:"sendTokenToUpstream,omitempty"`
+	Enabled           bool          `yaml:"enabled"`
+	QueryParamName    string        `yaml:"queryParamName,omitempty"`
+	HeaderEnabled     bool          `yaml:"headerEnable,omitempty"`
+	QueryParamEnable  bool          `yaml:"queryParamEnable,omitempty"`
+	Certificates      []Certificate `yaml:"certificates,omitempty"`
+	Audience          []string      `yaml:"audience,omitempty"`
+}
+
+// Certificate refers to the config map holding a client certificate used for mTLS
+type Certificate struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+// CORSConfiguration represents the CORS configuration of an API
+type CORSConfiguration struct {
+	CORSConfigurationEnabled      bool     `yaml:"corsConfigurationEnabled"`
+	AccessControlAllowOrigins     []string `yaml:"accessControlAllowOrigins,omitempty"`
+	AccessControlAllowCredentials bool     `yaml:"accessControlAllowCredentials,omitempty"`
+	AccessControlAllowHeaders     []string `yaml:"accessControlAllowHeaders,omitempty"`
+	AccessControlAllowMethods     []string `yaml:"accessControlAllowMethods,omitempty"`
+	AccessControlExposeHeaders    []string `yaml:"accessControlExposeHeaders,omitempty"`
+}
+
+// AdditionalProperty represents a custom property of an API
+type AdditionalProperty struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// ParseAPKConf parses the content of an apk-conf file
+func ParseAPKConf(content []byte) (*APKConf, error) {
+	var conf APKConf
+	if err := yaml.Unmarshal(content, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Name == "" || conf.Version == "" {
+		return nil, errors.New("apk-conf should contain the name and the version of the API")
+	}
+	return &conf, nil
+}
+
+// Marshal returns the apk-conf content of the API
+func (conf *APKConf) Marshal() ([]byte, error) {
+	return yaml.Marshal(conf)
+}
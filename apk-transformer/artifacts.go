@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// KustomizationFile is the name of the kustomization written along with the resources
+const KustomizationFile = "kustomization.yaml"
+
+type kustomization struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Resources  []string `yaml:"resources"`
+}
+
+// FileName returns the name of the file the resource is written to
+func (resource *Resource) FileName() string {
+	return strings.ToLower(resource.Kind) + "-" + resource.Metadata.Name + ".yaml"
+}
+
+// Manifests returns the YAML manifest of each resource
+func (artifacts *Artifacts) Manifests() ([][]byte, error) {
+	manifests := make([][]byte, 0, len(artifacts.Resources))
+	for i := range artifacts.Resources {
+		manifest, err := yaml.Marshal(&artifacts.Resources[i])
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling %s %s: %v", artifacts.Resources[i].Kind,
+				artifacts.Resources[i].Metadata.Name, err)
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// WriteToDir writes each resource to a separate file in the given directory along with a kustomization
+// listing them, so that the directory can be applied with kubectl or synced by GitOps tools as it is
+func (artifacts *Artifacts) WriteToDir(dir string) error {
+	manifests, err := artifacts.Manifests()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	k := kustomization{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization"}
+	for i, manifest := range manifests {
+		fileName := artifacts.Resources[i].FileName()
+		if err := os.WriteFile(filepath.Join(dir, fileName), manifest, 0644); err != nil {
+			return err
+		}
+		k.Resources = append(k.Resources, fileName)
+	}
+	content, err := yaml.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, KustomizationFile), content, 0644)
+}
@@ -0,0 +1,516 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	restAPIType       = "REST"
+	graphQLAPIType    = "GRAPHQL"
+	defaultAPIKeyName = "apikey"
+	defaultAuthHeader = "Authorization"
+
+	// DefaultOrganization is the organization of the generated resources if none is given
+	DefaultOrganization = "default"
+	// DefaultGatewayName is the name of the gateway the routes are attached to if none is given
+	DefaultGatewayName = "wso2-apk-default"
+	// DefaultGatewayListener is the listener of the gateway the routes are attached to if none is given
+	DefaultGatewayListener = "httpslistener"
+
+	// maxRulesPerRoute is the maximum number of rules allowed in a single HTTPRoute by the Gateway API
+	maxRulesPerRoute = 16
+
+	productionEnv = "production"
+	sandboxEnv    = "sandbox"
+
+	definitionKey = "definition"
+)
+
+// API groups and versions of the generated resources
+const (
+	dpGroup          = "dp.wso2.com"
+	gatewayGroup     = "gateway.networking.k8s.io"
+	apiVersionV1     = "v1"
+	dpV1alpha1       = dpGroup + "/v1alpha1"
+	dpV1alpha2       = dpGroup + "/v1alpha2"
+	dpV1alpha3       = dpGroup + "/v1alpha3"
+	dpV1alpha4       = dpGroup + "/v1alpha4"
+	gatewayV1        = gatewayGroup + "/v1"
+	KindAPI          = "API"
+	KindHTTPRoute    = "HTTPRoute"
+	KindBackend      = "Backend"
+	KindConfigMap    = "ConfigMap"
+	KindScope        = "Scope"
+	KindAuth         = "Authentication"
+	KindAPIPolicy    = "APIPolicy"
+	KindRateLimit    = "RateLimitPolicy"
+	kindGateway      = "Gateway"
+	kindResource     = "Resource"
+	extensionRefType = "ExtensionRef"
+	regexPathType    = "RegularExpression"
+)
+
+var pathParamRegex = regexp.MustCompile(`{[^}]*}`)
+
+// Options holds the deployment specific values of the generated resources
+type Options struct {
+	// Organization of the API, DefaultOrganization is used if empty
+	Organization string
+	// Namespace of the resources, the resources are not bound to a namespace if empty
+	Namespace string
+	// ProductionVhost is the hostname of the production routes, "<organization>.gw.wso2.com" is used if empty
+	ProductionVhost string
+	// SandboxVhost is the hostname of the sandbox routes, "<organization>.sandbox.gw.wso2.com" is used if empty
+	SandboxVhost string
+	// GatewayName is the gateway the routes are attached to, DefaultGatewayName is used if empty
+	GatewayName string
+	// GatewayListener is the listener of the gateway, DefaultGatewayListener is used if empty
+	GatewayListener string
+}
+
+// Artifacts holds the resources generated for an API
+type Artifacts struct {
+	// UniqueID is the name of the API resource and the prefix of the other resources
+	UniqueID  string
+	Resources []Resource
+}
+
+// generator holds the state used while generating the resources of a single API
+type generator struct {
+	conf      *APKConf
+	options   Options
+	uniqueID  string
+	labels    map[string]string
+	artifacts *Artifacts
+}
+
+// GetUniqueIDForAPI returns the unique id of an API which is used as the name of its API resource
+func GetUniqueIDForAPI(name, version, organization string) string {
+	return sha1Hash(strings.Join([]string{organization, name, version}, "-"))
+}
+
+// Generate generates the APK resources of the API described by the apk-conf and the definition.
+// The resources are generated the same way as the APK config deployer does, without contacting it.
+func Generate(conf *APKConf, definition []byte, options Options) (*Artifacts, error) {
+	if conf == nil {
+		return nil, errors.New("apk-conf can't be empty")
+	}
+	if len(definition) == 0 {
+		return nil, errors.New("API definition can't be empty")
+	}
+	if conf.Type != "" && conf.Type != restAPIType {
+		return nil, fmt.Errorf("generating resources for %s APIs is not supported", conf.Type)
+	}
+	if len(conf.Operations) == 0 {
+		return nil, errors.New("API should contain at least one operation")
+	}
+	if conf.EndpointConfigurations == nil ||
+		(len(conf.EndpointConfigurations.Production) == 0 && len(conf.EndpointConfigurations.Sandbox) == 0) {
+		return nil, errors.New("API should contain a production or a sandbox endpoint")
+	}
+	options = withDefaults(options)
+
+	uniqueID := GetUniqueIDForAPI(conf.Name, conf.Version, options.Organization)
+	g := &generator{
+		conf:     conf,
+		options:  options,
+		uniqueID: uniqueID,
+		labels: map[string]string{
+			"api-name":     sha1Hash(conf.Name),
+			"api-version":  sha1Hash(conf.Version),
+			"organization": sha1Hash(options.Organization),
+			"managed-by":   "apk",
+		},
+		artifacts: &Artifacts{UniqueID: uniqueID},
+	}
+	if err := g.generate(definition); err != nil {
+		return nil, err
+	}
+	return g.artifacts, nil
+}
+
+func withDefaults(options Options) Options {
+	if options.Organization == "" {
+		options.Organization = DefaultOrganization
+	}
+	if options.ProductionVhost == "" {
+		options.ProductionVhost = options.Organization + ".gw.wso2.com"
+	}
+	if options.SandboxVhost == "" {
+		options.SandboxVhost = options.Organization + ".sandbox.gw.wso2.com"
+	}
+	if options.GatewayName == "" {
+		options.GatewayName = DefaultGatewayName
+	}
+	if options.GatewayListener == "" {
+		options.GatewayListener = DefaultGatewayListener
+	}
+	return options
+}
+
+func (g *generator) generate(definition []byte) error {
+	definitionRef, err := g.addDefinition(definition)
+	if err != nil {
+		return err
+	}
+	routeFilters := g.addOperationPolicies()
+	g.addAuthentication()
+	g.addAPIPolicy()
+	g.addAPIRateLimit()
+
+	spec := apiSpec{
+		APIName:           g.conf.Name,
+		APIVersion:        g.conf.Version,
+		IsDefaultVersion:  g.conf.DefaultVersion,
+		DefinitionFileRef: definitionRef,
+		DefinitionPath:    g.conf.DefinitionPath,
+		APIType:           restAPIType,
+		BasePath:          fullContext(g.conf.BasePath, g.conf.Version),
+		Organization:      g.options.Organization,
+	}
+	for _, property := range g.conf.AdditionalProperties {
+		spec.APIProperties = append(spec.APIProperties, apiProperty{Name: property.Name, Value: property.Value})
+	}
+
+	endpoints := g.conf.EndpointConfigurations
+	if len(endpoints.Production) > 0 {
+		routes, err := g.addRoutes(productionEnv, g.options.ProductionVhost, endpoints.Production[0], routeFilters)
+		if err != nil {
+			return err
+		}
+		spec.Production = []envConfig{{RouteRefs: routes}}
+	}
+	if len(endpoints.Sandbox) > 0 {
+		routes, err := g.addRoutes(sandboxEnv, g.options.SandboxVhost, endpoints.Sandbox[0], routeFilters)
+		if err != nil {
+			return err
+		}
+		spec.Sandbox = []envConfig{{RouteRefs: routes}}
+	}
+
+	g.add(dpV1alpha3, KindAPI, g.uniqueID, spec)
+	return nil
+}
+
+// add appends a resource carrying the common metadata of the API
+func (g *generator) add(apiVersion, kind, name string, spec interface{}) *Resource {
+	labels := make(map[string]string, len(g.labels))
+	for key, value := range g.labels {
+		labels[key] = value
+	}
+	g.artifacts.Resources = append(g.artifacts.Resources, Resource{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Metadata:   ObjectMeta{Name: name, Namespace: g.options.Namespace, Labels: labels},
+		Spec:       spec,
+	})
+	return &g.artifacts.Resources[len(g.artifacts.Resources)-1]
+}
+
+// apiTargetRef returns the reference used by the policies to attach to the API
+func (g *generator) apiTargetRef() policyTargetReference {
+	return policyTargetReference{Group: gatewayGroup, Kind: KindAPI, Name: g.uniqueID}
+}
+
+// addDefinition stores the gzipped definition in a config map and returns its name
+func (g *generator) addDefinition(definition []byte) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(definition); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	name := g.uniqueID + "-definition"
+	configMap := g.add(apiVersionV1, KindConfigMap, name, nil)
+	configMap.BinaryData = map[string]string{definitionKey: base64.StdEncoding.EncodeToString(buf.Bytes())}
+	return name, nil
+}
+
+// addOperationPolicies adds the scopes, the resource level rate limits and the authentication of the
+// unsecured operations, and returns the route filters of each operation referring to them
+func (g *generator) addOperationPolicies() [][]httpRouteFilter {
+	filters := make([][]httpRouteFilter, len(g.conf.Operations))
+	scopes := make(map[string]string)
+	disabledAuthName := ""
+	for i, operation := range g.conf.Operations {
+		for _, scope := range operation.Scopes {
+			name, ok := scopes[scope]
+			if !ok {
+				name = g.uniqueID + "-scope-" + sha1Hash(scope)[:8]
+				scopes[scope] = name
+				g.add(dpV1alpha1, KindScope, name, scopeSpec{Names: []string{scope}})
+			}
+			filters[i] = append(filters[i], extensionFilter(KindScope, name))
+		}
+		if !operation.Secured {
+			if disabledAuthName == "" {
+				disabledAuthName = g.uniqueID + "-resource-authentication-disabled"
+				disabled := true
+				g.add(dpV1alpha2, KindAuth, disabledAuthName, authenticationSpec{
+					Override:  &authSpec{Disabled: &disabled},
+					TargetRef: policyTargetReference{Group: gatewayGroup, Kind: kindResource, Name: g.uniqueID},
+				})
+			}
+			filters[i] = append(filters[i], extensionFilter(KindAuth, disabledAuthName))
+		}
+		if operation.RateLimit != nil {
+			name := g.uniqueID + "-resource-ratelimit-" + strconv.Itoa(i+1)
+			g.add(dpV1alpha1, KindRateLimit, name, rateLimitPolicySpec{
+				Default: &rateLimitAPIPolicy{API: &apiRateLimitPolicy{
+					RequestsPerUnit: operation.RateLimit.RequestsPerUnit,
+					Unit:            operation.RateLimit.Unit,
+				}},
+				TargetRef: policyTargetReference{Group: dpGroup, Kind: kindResource, Name: g.uniqueID},
+			})
+			filters[i] = append(filters[i], extensionFilter(KindRateLimit, name))
+		}
+	}
+	return filters
+}
+
+// addAuthentication adds the API level authentication. OAuth2 is enabled and mandatory by default as
+// in APK when the apk-conf does not configure the authentication.
+func (g *generator) addAuthentication() {
+	auth := &apiAuth{OAuth2: oauth2Auth{Required: mandatory, Header: defaultAuthHeader}}
+	for _, config := range g.conf.Authentication {
+		switch config.AuthType {
+		case AuthTypeOAuth2:
+			if !config.Enabled {
+				auth.OAuth2 = oauth2Auth{Disabled: true}
+				continue
+			}
+			auth.OAuth2 = oauth2Auth{
+				Required:            requiredOrDefault(config.Required),
+				Header:              headerOrDefault(config.HeaderName, defaultAuthHeader),
+				SendTokenToUpstream: config.SendTokenUpStream,
+			}
+		case AuthTypeAPIKey:
+			if !config.Enabled {
+				continue
+			}
+			keys := []apiKey{}
+			if config.HeaderEnabled || !config.QueryParamEnable {
+				keys = append(keys, apiKey{In: "Header", Name: headerOrDefault(config.HeaderName, defaultAPIKeyName),
+					SendTokenToUpstream: config.SendTokenUpStream})
+			}
+			if config.QueryParamEnable {
+				keys = append(keys, apiKey{In: "Query", Name: headerOrDefault(config.QueryParamName, defaultAPIKeyName),
+					SendTokenToUpstream: config.SendTokenUpStream})
+			}
+			auth.APIKey = &apiKeyAuth{Required: requiredOrDefault(config.Required), Keys: keys}
+		case AuthTypeJWT:
+			auth.JWT = &jwtAuth{
+				Disabled:            !config.Enabled,
+				Header:              config.HeaderName,
+				SendTokenToUpstream: config.SendTokenUpStream,
+				Audience:            config.Audience,
+			}
+		case AuthTypeMTLS:
+			if !config.Enabled {
+				continue
+			}
+			mtls := &mutualSSL{Required: requiredOrDefault(config.Required)}
+			for _, cert := range config.Certificates {
+				mtls.ConfigMapRefs = append(mtls.ConfigMapRefs, refConfig{Name: cert.Name, Key: cert.Key})
+			}
+			auth.MutualSSL = mtls
+		}
+	}
+	g.add(dpV1alpha2, KindAuth, g.uniqueID+"-authentication", authenticationSpec{
+		Override:  &authSpec{AuthTypes: auth},
+		TargetRef: g.apiTargetRef(),
+	})
+}
+
+// addAPIPolicy adds the CORS and the subscription validation policies of the API
+func (g *generator) addAPIPolicy() {
+	cors := g.conf.CorsConfig
+	if !g.conf.SubscriptionValidation && (cors == nil || !cors.CORSConfigurationEnabled) {
+		return
+	}
+	policy := &policySpec{SubscriptionValidation: g.conf.SubscriptionValidation}
+	if cors != nil && cors.CORSConfigurationEnabled {
+		policy.CORSPolicy = &corsPolicy{
+			Enabled:                       true,
+			AccessControlAllowCredentials: cors.AccessControlAllowCredentials,
+			AccessControlAllowHeaders:     cors.AccessControlAllowHeaders,
+			AccessControlAllowMethods:     cors.AccessControlAllowMethods,
+			AccessControlAllowOrigins:     cors.AccessControlAllowOrigins,
+			AccessControlExposeHeaders:    cors.AccessControlExposeHeaders,
+		}
+	}
+	g.add(dpV1alpha4, KindAPIPolicy, g.uniqueID+"-api-policy", apiPolicySpec{Default: policy, TargetRef: g.apiTargetRef()})
+}
+
+// addAPIRateLimit adds the API level rate limit
+func (g *generator) addAPIRateLimit() {
+	if g.conf.RateLimit == nil {
+		return
+	}
+	g.add(dpV1alpha1, KindRateLimit, g.uniqueID+"-api-ratelimit", rateLimitPolicySpec{
+		Default: &rateLimitAPIPolicy{API: &apiRateLimitPolicy{
+			RequestsPerUnit: g.conf.RateLimit.RequestsPerUnit,
+			Unit:            g.conf.RateLimit.Unit,
+		}},
+		TargetRef: g.apiTargetRef(),
+	})
+}
+
+// addRoutes adds the backend and the routes of an environment and returns the names of the routes.
+// The operations are split into several routes as the Gateway API limits the rules of a route.
+func (g *generator) addRoutes(env string, vhost string, endpoint EndpointConfiguration,
+	filters [][]httpRouteFilter) ([]string, error) {
+	backendName := g.uniqueID + "-" + env + "-backend"
+	spec, err := backendSpecFor(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s endpoint: %v", env, err)
+	}
+	g.add(dpV1alpha2, KindBackend, backendName, spec)
+
+	var routeNames []string
+	operations := g.conf.Operations
+	for start := 0; start < len(operations); start += maxRulesPerRoute {
+		end := start + maxRulesPerRoute
+		if end > len(operations) {
+			end = len(operations)
+		}
+		var rules []httpRouteRule
+		for i := start; i < end; i++ {
+			rules = append(rules, httpRouteRule{
+				Matches: []httpRouteMatch{{
+					Path:   httpPathMatch{Type: regexPathType, Value: targetPathRegex(operations[i].Target)},
+					Method: strings.ToUpper(operations[i].Verb),
+				}},
+				Filters:     filters[i],
+				BackendRefs: []backendRef{{Group: dpGroup, Kind: KindBackend, Name: backendName}},
+			})
+		}
+		name := fmt.Sprintf("%s-%s-httproute-%d", g.uniqueID, env, len(routeNames)+1)
+		g.add(gatewayV1, KindHTTPRoute, name, httpRouteSpec{
+			ParentRefs: []parentReference{{
+				Group:       gatewayGroup,
+				Kind:        kindGateway,
+				Name:        g.options.GatewayName,
+				SectionName: g.options.GatewayListener,
+			}},
+			Hostnames: []string{vhost},
+			Rules:     rules,
+		})
+		routeNames = append(routeNames, name)
+	}
+	return routeNames, nil
+}
+
+// backendSpecFor maps an endpoint of the apk-conf to a backend
+func backendSpecFor(endpoint EndpointConfiguration) (backendSpec, error) {
+	endpointURL, err := url.Parse(endpoint.Endpoint)
+	if err != nil {
+		return backendSpec{}, err
+	}
+	if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		return backendSpec{}, fmt.Errorf("%s should be an http or an https URL", endpoint.Endpoint)
+	}
+	port := uint64(80)
+	if endpointURL.Scheme == "https" {
+		port = 443
+	}
+	if endpointURL.Port() != "" {
+		if port, err = strconv.ParseUint(endpointURL.Port(), 10, 32); err != nil {
+			return backendSpec{}, fmt.Errorf("invalid port in %s", endpoint.Endpoint)
+		}
+	}
+	spec := backendSpec{
+		Services: []backendService{{Host: endpointURL.Hostname(), Port: uint32(port)}},
+		Protocol: endpointURL.Scheme,
+		BasePath: strings.TrimSuffix(endpointURL.Path, "/"),
+	}
+	if endpoint.Certificate != nil && endpoint.Certificate.SecretName != "" {
+		spec.TLS = &backendTLS{SecretRef: refConfig{Name: endpoint.Certificate.SecretName, Key: endpoint.Certificate.SecretKey}}
+	}
+	if endpoint.Security != nil && endpoint.Security.Enabled {
+		secret := endpoint.Security.SecurityType
+		if secret.APIKeyValueKey != "" {
+			spec.Security = &backendSecurity{APIKey: &apiKeySecurity{
+				In:        secret.In,
+				Name:      secret.APIKeyNameKey,
+				ValueFrom: valueRef{Name: secret.SecretName, ValueKey: secret.APIKeyValueKey},
+			}}
+		} else {
+			spec.Security = &backendSecurity{Basic: &basicSecurity{SecretRef: basicSecretRef{
+				Name:        secret.SecretName,
+				UsernameKey: secret.UsernameKey,
+				PasswordKey: secret.PasswordKey,
+			}}}
+		}
+	}
+	return spec, nil
+}
+
+// targetPathRegex converts the target of an operation to the path regex of a route rule
+func targetPathRegex(target string) string {
+	path := pathParamRegex.ReplaceAllString(target, "([^/]+)")
+	if strings.HasSuffix(path, "/*") {
+		path = strings.TrimSuffix(path, "*") + "(.*)"
+	}
+	return path
+}
+
+// fullContext returns the base path of the API including its version
+func fullContext(basePath, version string) string {
+	if strings.Contains(basePath, "{version}") {
+		return strings.ReplaceAll(basePath, "{version}", version)
+	}
+	return strings.TrimSuffix(basePath, "/") + "/" + version
+}
+
+func extensionFilter(kind, name string) httpRouteFilter {
+	return httpRouteFilter{Type: extensionRefType, ExtensionRef: extensionRef{Group: dpGroup, Kind: kind, Name: name}}
+}
+
+func requiredOrDefault(required string) string {
+	if required == "" {
+		return mandatory
+	}
+	return required
+}
+
+func headerOrDefault(header, defaultHeader string) string {
+	if header == "" {
+		return defaultHeader
+	}
+	return header
+}
+
+func sha1Hash(input string) string {
+	hash := sha1.Sum([]byte(input))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,169 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func generateTestArtifacts(t *testing.T, options Options) *Artifacts {
+	project, err := LoadProject(testProject)
+	require.NoError(t, err)
+	conf, _, err := project.APKConf(nil)
+	require.NoError(t, err)
+	artifacts, err := Generate(conf, project.Definition, options)
+	require.NoError(t, err)
+	return artifacts
+}
+
+func findResource(t *testing.T, artifacts *Artifacts, kind, name string) *Resource {
+	for i := range artifacts.Resources {
+		if artifacts.Resources[i].Kind == kind && artifacts.Resources[i].Metadata.Name == name {
+			return &artifacts.Resources[i]
+		}
+	}
+	t.Fatalf("%s %s not found", kind, name)
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	artifacts := generateTestArtifacts(t, Options{Namespace: "apk"})
+	id := GetUniqueIDForAPI("PizzaShackAPI", "1.0.0", DefaultOrganization)
+	assert.Equal(t, id, artifacts.UniqueID)
+
+	api := findResource(t, artifacts, KindAPI, id)
+	assert.Equal(t, "dp.wso2.com/v1alpha3", api.APIVersion)
+	assert.Equal(t, "apk", api.Metadata.Namespace)
+	assert.NotEmpty(t, api.Metadata.Labels)
+	spec := api.Spec.(apiSpec)
+	assert.Equal(t, "/pizzashack/1.0.0", spec.BasePath)
+	assert.Equal(t, id+"-definition", spec.DefinitionFileRef)
+	assert.Equal(t, []envConfig{{RouteRefs: []string{id + "-production-httproute-1"}}}, spec.Production)
+	assert.Equal(t, []envConfig{{RouteRefs: []string{id + "-sandbox-httproute-1"}}}, spec.Sandbox)
+
+	route := findResource(t, artifacts, KindHTTPRoute, id+"-production-httproute-1").Spec.(httpRouteSpec)
+	assert.Equal(t, []string{"default.gw.wso2.com"}, route.Hostnames)
+	assert.Equal(t, DefaultGatewayName, route.ParentRefs[0].Name)
+	require.Len(t, route.Rules, 3)
+	assert.Equal(t, httpRouteMatch{Path: httpPathMatch{Type: regexPathType, Value: "/order/([^/]+)"}, Method: "GET"},
+		route.Rules[2].Matches[0])
+	assert.Equal(t, id+"-production-backend", route.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, KindScope, route.Rules[0].Filters[0].ExtensionRef.Kind)
+	assert.Equal(t, KindRateLimit, route.Rules[0].Filters[1].ExtensionRef.Kind)
+	assert.Equal(t, KindAuth, route.Rules[1].Filters[0].ExtensionRef.Kind)
+
+	sandboxRoute := findResource(t, artifacts, KindHTTPRoute, id+"-sandbox-httproute-1").Spec.(httpRouteSpec)
+	assert.Equal(t, []string{"default.sandbox.gw.wso2.com"}, sandboxRoute.Hostnames)
+
+	backend := findResource(t, artifacts, KindBackend, id+"-production-backend").Spec.(backendSpec)
+	assert.Equal(t, []backendService{{Host: "pizzashack.example.com", Port: 443}}, backend.Services)
+	assert.Equal(t, "https", backend.Protocol)
+	assert.Equal(t, "/am/sample/pizzashack/v1/api", backend.BasePath)
+	sandboxBackend := findResource(t, artifacts, KindBackend, id+"-sandbox-backend").Spec.(backendSpec)
+	assert.Equal(t, []backendService{{Host: "pizzashack-sandbox", Port: 8080}}, sandboxBackend.Services)
+
+	auth := findResource(t, artifacts, KindAuth, id+"-authentication").Spec.(authenticationSpec)
+	assert.False(t, auth.Override.AuthTypes.OAuth2.Disabled)
+	assert.Equal(t, []apiKey{{In: "Header", Name: "ApiKey"}}, auth.Override.AuthTypes.APIKey.Keys)
+
+	policy := findResource(t, artifacts, KindAPIPolicy, id+"-api-policy").Spec.(apiPolicySpec)
+	assert.True(t, policy.Default.SubscriptionValidation)
+	assert.True(t, policy.Default.CORSPolicy.Enabled)
+	assert.Equal(t, KindAPI, policy.TargetRef.Kind)
+}
+
+func TestGenerateWithOptions(t *testing.T) {
+	artifacts := generateTestArtifacts(t, Options{Organization: "finance", ProductionVhost: "api.example.com",
+		GatewayName: "internal", GatewayListener: "http"})
+	id := GetUniqueIDForAPI("PizzaShackAPI", "1.0.0", "finance")
+
+	assert.Equal(t, "finance", findResource(t, artifacts, KindAPI, id).Spec.(apiSpec).Organization)
+	route := findResource(t, artifacts, KindHTTPRoute, id+"-production-httproute-1").Spec.(httpRouteSpec)
+	assert.Equal(t, []string{"api.example.com"}, route.Hostnames)
+	assert.Equal(t, parentReference{Group: gatewayGroup, Kind: kindGateway, Name: "internal", SectionName: "http"},
+		route.ParentRefs[0])
+	sandboxRoute := findResource(t, artifacts, KindHTTPRoute, id+"-sandbox-httproute-1").Spec.(httpRouteSpec)
+	assert.Equal(t, []string{"finance.sandbox.gw.wso2.com"}, sandboxRoute.Hostnames)
+}
+
+func TestGenerateSplitsRoutes(t *testing.T) {
+	conf := &APKConf{
+		Name:                   "Many",
+		Version:                "v1",
+		BasePath:               "/many",
+		EndpointConfigurations: &EndpointConfigurations{Production: []EndpointConfiguration{{Endpoint: "http://backend"}}},
+	}
+	for i := 0; i < maxRulesPerRoute+1; i++ {
+		conf.Operations = append(conf.Operations, Operation{Target: "/resource", Verb: "get", Secured: true})
+	}
+	artifacts, err := Generate(conf, []byte("openapi: 3.0.1"), Options{})
+	require.NoError(t, err)
+
+	spec := findResource(t, artifacts, KindAPI, artifacts.UniqueID).Spec.(apiSpec)
+	require.Len(t, spec.Production[0].RouteRefs, 2)
+	assert.Empty(t, spec.Sandbox)
+	second := findResource(t, artifacts, KindHTTPRoute, spec.Production[0].RouteRefs[1]).Spec.(httpRouteSpec)
+	assert.Len(t, second.Rules, 1)
+}
+
+func TestGenerateInvalidInput(t *testing.T) {
+	conf := &APKConf{
+		Name:                   "Invalid",
+		Version:                "v1",
+		Operations:             []Operation{{Target: "/*", Verb: "GET"}},
+		EndpointConfigurations: &EndpointConfigurations{Production: []EndpointConfiguration{{Endpoint: "ftp://backend"}}},
+	}
+	_, err := Generate(conf, nil, Options{})
+	assert.Error(t, err, "empty definition")
+	_, err = Generate(conf, []byte("openapi: 3.0.1"), Options{})
+	assert.Error(t, err, "unsupported endpoint protocol")
+	conf.Type = "GRAPHQL"
+	_, err = Generate(conf, []byte("schema"), Options{})
+	assert.Error(t, err, "unsupported API type")
+}
+
+func TestTargetPathRegex(t *testing.T) {
+	assert.Equal(t, "/menu", targetPathRegex("/menu"))
+	assert.Equal(t, "/(.*)", targetPathRegex("/*"))
+	assert.Equal(t, "/orders/([^/]+)/items/(.*)", targetPathRegex("/orders/{orderId}/items/*"))
+}
+
+func TestWriteToDir(t *testing.T) {
+	artifacts := generateTestArtifacts(t, Options{})
+	dir := t.TempDir()
+	require.NoError(t, artifacts.WriteToDir(dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, KustomizationFile))
+	require.NoError(t, err)
+	var k kustomization
+	require.NoError(t, yaml.Unmarshal(content, &k))
+	assert.Len(t, k.Resources, len(artifacts.Resources))
+
+	content, err = os.ReadFile(filepath.Join(dir, "api-"+artifacts.UniqueID+".yaml"))
+	require.NoError(t, err)
+	var api map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(content, &api))
+	assert.Equal(t, "dp.wso2.com/v1alpha3", api["apiVersion"])
+	assert.Equal(t, KindAPI, api["kind"])
+}
@@ -0,0 +1,710 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Security scheme values of an APIM API
+const (
+	oAuth2SecScheme              = "oauth2"
+	apiKeySecScheme              = "api_key"
+	mutualSSLSecScheme           = "mutualssl"
+	mutualSSLMandatory           = "mutualssl_mandatory"
+	applicationSecurityMandatory = "oauth_basic_auth_api_key_mandatory"
+	applicationSecurityOptional  = "oauth_basic_auth_api_key_optional"
+)
+
+// Authentication types of an apk-conf
+const (
+	AuthTypeOAuth2 = "OAuth2"
+	AuthTypeAPIKey = "APIKey"
+	AuthTypeJWT    = "JWT"
+	AuthTypeMTLS   = "mTLS"
+)
+
+// Optionality values of an apk-conf authentication
+const (
+	mandatory = "mandatory"
+	optional  = "optional"
+)
+
+// Names of the APIM operation policies mapped to the apk-conf
+const (
+	apimInterceptorService      = "CallInterceptorService"
+	apimBackendJWT              = "backEndJWT"
+	apimAddHeader               = "apkAddHeader"
+	apimRemoveHeader            = "apkRemoveHeader"
+	apimMirrorRequest           = "apkMirrorRequest"
+	apimRedirectRequest         = "apkRedirectRequest"
+	apimModelRoundRobin         = "modelRoundRobin"
+	apimModelWeightedRoundRobin = "modelWeightedRoundRobin"
+)
+
+// Names and versions of the apk-conf operation policies
+const (
+	interceptorPolicy     = "Interceptor"
+	backendJWTPolicy      = "BackendJwt"
+	addHeaderPolicy       = "AddHeader"
+	removeHeaderPolicy    = "RemoveHeader"
+	requestRedirectPolicy = "RequestRedirect"
+	requestMirrorPolicy   = "RequestMirror"
+	modelBasedRoundRobin  = "ModelBasedRoundRobin"
+	policyVersionV1       = "v1"
+	policyVersionV2       = "v2"
+)
+
+const (
+	unlimitedPolicy       = "Unlimited"
+	noneAuthType          = "None"
+	aiAPISubtype          = "AIAPI"
+	defaultDefinitionPath = "/definition"
+	internalKeyHeader     = "internal-key"
+	primaryEndpointUUID   = "primary"
+
+	requestInterceptorSecretName  = "request-interceptor-tls-secret"
+	responseInterceptorSecretName = "response-interceptor-tls-secret"
+	interceptorTLSKey             = "tls.crt"
+
+	// RateLimitLevelAPI is the level of the throttling policy applied to the whole API
+	RateLimitLevelAPI = "API"
+	// RateLimitLevelResource is the level of the throttling policies applied to the operations of the API
+	RateLimitLevelResource = "Resource"
+)
+
+// BuiltInRateLimitPolicies holds the request count of the default advanced throttling policies of APIM,
+// so that an API using them can be rate limited without contacting the control plane
+var BuiltInRateLimitPolicies = map[string]RateLimit{
+	"10KPerMin": {RequestsPerUnit: 10000, Unit: "Minute"},
+	"20KPerMin": {RequestsPerUnit: 20000, Unit: "Minute"},
+	"50KPerMin": {RequestsPerUnit: 50000, Unit: "Minute"},
+}
+
+// RateLimitResolver returns the request count based rate limit of a throttling policy, or nil if the policy
+// can not be resolved
+type RateLimitResolver func(policy string) *RateLimit
+
+// RateLimitsResolver returns a RateLimitResolver looking up the policies in the given rate limits
+func RateLimitsResolver(rateLimits map[string]RateLimit) RateLimitResolver {
+	return func(policy string) *RateLimit {
+		if rateLimit, ok := rateLimits[policy]; ok {
+			return &rateLimit
+		}
+		return nil
+	}
+}
+
+// APKConfResult holds the apk-conf mapped from an APIM API project along with the details of the project
+// needed to deploy it
+type APKConfResult struct {
+	Conf *APKConf
+	// API is the data of the api.yaml of the project
+	API *APIMApi
+	// EndpointSecurity holds the security of each endpoint, including the credentials which are referred
+	// by the endpoint configurations of the apk-conf
+	EndpointSecurity []EndpointSecurityConfig
+	// ProductionAIRatelimit and SandboxAIRatelimit are the token based rate limits of an AI API
+	ProductionAIRatelimit *AIRatelimit
+	SandboxAIRatelimit    *AIRatelimit
+	// RateLimitPolicies maps RateLimitLevelAPI or RateLimitLevelResource to the throttling policy applied
+	RateLimitPolicies map[string]string
+	// UnresolvedPolicies holds the throttling policies which could not be resolved and are not applied
+	UnresolvedPolicies []string
+}
+
+// GenerateAPKConf maps an APIM API project, given by the content of its api.yaml, endpoints.yaml and
+// certificate descriptors, to an apk-conf. The throttling policies are resolved with rateLimits.
+func GenerateAPKConf(apiContent []byte, endpoints []byte, certArtifact CertificateArtifact,
+	rateLimits RateLimitResolver) (*APKConfResult, error) {
+	api, err := ParseAPI(apiContent)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the api.yaml: %v", err)
+	}
+	endpointList, err := ParseEndpoints(endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the endpoints.yaml: %v", err)
+	}
+	return mapAPKConf(api, endpointList, certArtifact, rateLimits)
+}
+
+// mapAPKConf maps an APIM API along with its endpoints and certificates to an apk-conf
+func mapAPKConf(api *APIMApi, endpointList []Endpoint, certArtifact CertificateArtifact,
+	rateLimits RateLimitResolver) (*APKConfResult, error) {
+	apiType, err := getAPIType(api.Type)
+	if err != nil {
+		return nil, err
+	}
+	result := &APKConfResult{API: api, RateLimitPolicies: make(map[string]string)}
+	unresolved := make(map[string]bool)
+	resolveRateLimit := func(level, policy string) *RateLimit {
+		if policy == "" || policy == unlimitedPolicy {
+			return nil
+		}
+		rateLimit := rateLimits(policy)
+		if rateLimit == nil {
+			unresolved[policy] = true
+			return nil
+		}
+		result.RateLimitPolicies[level] = policy
+		return rateLimit
+	}
+
+	conf := &APKConf{
+		Name:                   api.Name,
+		Version:                api.Version,
+		BasePath:               api.Context,
+		Type:                   apiType,
+		DefaultVersion:         api.DefaultVersion,
+		DefinitionPath:         defaultDefinitionPath,
+		SubscriptionValidation: true,
+		RateLimit:              resolveRateLimit(RateLimitLevelAPI, api.APIThrottlingPolicy),
+	}
+	result.Conf = conf
+
+	if api.SubtypeConfiguration.Subtype == aiAPISubtype && api.SubtypeConfiguration.Configuration != "" {
+		var config subtypeConfig
+		if err := json.Unmarshal([]byte(api.SubtypeConfiguration.Configuration), &config); err != nil {
+			return nil, fmt.Errorf("error parsing the subtype configuration of the API: %v", err)
+		}
+		conf.AIProvider = &AIProvider{Name: config.LLMProviderID, APIVersion: "1"}
+	}
+
+	// the primary endpoints of the API are used along with the endpoints of an API with multiple endpoints
+	defaultEndpointList := []Endpoint{
+		{
+			ID:   api.PrimaryProductionEndpointID,
+			Name: "Primary Production Endpoint",
+			EndpointConfig: EndpointConfig{
+				ProductionEndpoints: api.EndpointConfig.ProductionEndpoints,
+				EndpointType:        api.EndpointConfig.EndpointType,
+				EndpointSecurity:    api.EndpointConfig.EndpointSecurity,
+			},
+			DeploymentStage: "PRODUCTION",
+		},
+		{
+			ID:   api.PrimarySandboxEndpointID,
+			Name: "Primary Sandbox Endpoint",
+			EndpointConfig: EndpointConfig{
+				SandboxEndpoints: api.EndpointConfig.SandboxEndpoints,
+				EndpointType:     api.EndpointConfig.EndpointType,
+				EndpointSecurity: api.EndpointConfig.EndpointSecurity,
+			},
+			DeploymentStage: "SANDBOX",
+		},
+	}
+	endpointURLs := make(map[string]string)
+	for _, endpoint := range append(endpointList, defaultEndpointList...) {
+		if endpoint.EndpointConfig.ProductionEndpoints.URL != "" {
+			endpointURLs[endpoint.ID] = endpoint.EndpointConfig.ProductionEndpoints.URL
+		}
+		if endpoint.EndpointConfig.SandboxEndpoints.URL != "" {
+			endpointURLs[endpoint.ID] = endpoint.EndpointConfig.SandboxEndpoints.URL
+		}
+	}
+
+	for _, operation := range api.Operations {
+		op := Operation{
+			Target:  operation.Target,
+			Verb:    strings.ToUpper(operation.Verb),
+			Scopes:  operation.Scopes,
+			Secured: operation.AuthType != noneAuthType,
+		}
+		if operation.OperationPolicies != nil {
+			if op.OperationPolicies, err = mapOperationPolicies(*operation.OperationPolicies, endpointURLs); err != nil {
+				return nil, err
+			}
+		}
+		// resource level rate limits are applied only if there is no API level rate limit
+		if api.APIThrottlingPolicy == "" {
+			op.RateLimit = resolveRateLimit(RateLimitLevelResource, operation.ThrottlingPolicy)
+		}
+		conf.Operations = append(conf.Operations, op)
+	}
+	if conf.APIPolicies, err = mapOperationPolicies(api.APIPolicies, endpointURLs); err != nil {
+		return nil, err
+	}
+
+	var endpointCertList EndpointCertDescriptor
+	if certArtifact.EndpointCerts != "" {
+		if err := unmarshalJSONOrYAML([]byte(certArtifact.EndpointCerts), &endpointCertList); err != nil {
+			return nil, fmt.Errorf("error parsing the endpoint certificates: %v", err)
+		}
+	}
+	var clientCertList CertDescriptor
+	clientCertsAvailable := false
+	if certArtifact.ClientCerts != "" {
+		if err := unmarshalJSONOrYAML([]byte(certArtifact.ClientCerts), &clientCertList); err != nil {
+			return nil, fmt.Errorf("error parsing the client certificates: %v", err)
+		}
+		clientCertsAvailable = true
+	}
+
+	apiUniqueID := GetUniqueIDForAPI(api.Name, api.Version, api.OrganizationID)
+	result.ProductionAIRatelimit, result.SandboxAIRatelimit = prepareAIRatelimit(api.MaxTps)
+	if len(endpointList) == 0 {
+		var endpointSecurity EndpointSecurityConfig
+		conf.EndpointConfigurations, endpointSecurity = getEndpointConfigs(api.EndpointConfig, endpointCertList,
+			apiUniqueID, result.ProductionAIRatelimit, result.SandboxAIRatelimit)
+		result.EndpointSecurity = []EndpointSecurityConfig{endpointSecurity}
+	} else {
+		conf.EndpointConfigurations, result.EndpointSecurity = getMultiEndpointConfigs(
+			append(endpointList, defaultEndpointList...), endpointCertList, apiUniqueID,
+			result.ProductionAIRatelimit, result.SandboxAIRatelimit)
+	}
+
+	conf.Authentication = mapAuthConfigs(api.ID, api.AuthorizationHeader, api.APIKeyHeader, api.SecuritySchemes,
+		clientCertsAvailable, clientCertList, apiUniqueID)
+	if api.CORSConfiguration.CORSConfigurationEnabled {
+		cors := api.CORSConfiguration
+		conf.CorsConfig = &cors
+	}
+	for _, property := range api.AdditionalProperties {
+		conf.AdditionalProperties = append(conf.AdditionalProperties,
+			AdditionalProperty{Name: property.Name, Value: property.Value})
+	}
+
+	for policy := range unresolved {
+		result.UnresolvedPolicies = append(result.UnresolvedPolicies, policy)
+	}
+	sort.Strings(result.UnresolvedPolicies)
+	return result, nil
+}
+
+// getAPIType maps the type of an APIM API to the type of an apk-conf
+func getAPIType(protocolType string) (string, error) {
+	switch protocolType {
+	case "HTTP", "HTTPS", "":
+		return restAPIType, nil
+	case "GRAPHQL":
+		return graphQLAPIType, nil
+	}
+	return "", fmt.Errorf("API type %s is not supported", protocolType)
+}
+
+// mapOperationPolicies maps the request and the response policies of an APIM API or operation to the policies
+// of an apk-conf. The policies which are not supported by APK are skipped and nil is returned if none is mapped.
+func mapOperationPolicies(policies APIMOperationPolicies, endpointURLs map[string]string) (*OperationPolicies,
+	error) {
+	var requestPolicies, responsePolicies []OperationPolicy
+	var requestInterceptor, responseInterceptor, backendJWT *OperationPolicy
+	var mirrorURLs []string
+
+	for _, policy := range policies.Request {
+		switch {
+		case strings.HasSuffix(policy.PolicyName, apimInterceptorService):
+			interceptor := mapInterceptorPolicy(policy, requestInterceptorSecretName)
+			requestInterceptor = &interceptor
+		case policy.PolicyName == apimBackendJWT:
+			jwtPolicy, err := mapBackendJWTPolicy(policy)
+			if err != nil {
+				return nil, err
+			}
+			backendJWT = &jwtPolicy
+		case policy.PolicyName == apimAddHeader:
+			requestPolicies = append(requestPolicies, OperationPolicy{
+				PolicyName:    addHeaderPolicy,
+				PolicyVersion: policyVersionV1,
+				Parameters: Header{
+					HeaderName:  stringParameter(policy, "headerName"),
+					HeaderValue: stringParameter(policy, "headerValue"),
+				},
+			})
+		case policy.PolicyName == apimRemoveHeader:
+			requestPolicies = append(requestPolicies, OperationPolicy{
+				PolicyName:    removeHeaderPolicy,
+				PolicyVersion: policyVersionV1,
+				Parameters:    Header{HeaderName: stringParameter(policy, "headerName")},
+			})
+		case policy.PolicyName == apimRedirectRequest:
+			redirect := RedirectPolicy{URL: stringParameter(policy, "url"), StatusCode: 302}
+			switch statusCode := policy.Parameters["statusCode"].(type) {
+			case int:
+				redirect.StatusCode = statusCode
+			case float64:
+				redirect.StatusCode = int(statusCode)
+			case string:
+				code, err := strconv.Atoi(statusCode)
+				if err != nil {
+					return nil, fmt.Errorf("invalid status code %s of the %s policy", statusCode, policy.PolicyName)
+				}
+				redirect.StatusCode = code
+			}
+			requestPolicies = append(requestPolicies, OperationPolicy{
+				PolicyName:    requestRedirectPolicy,
+				PolicyVersion: policyVersionV1,
+				Parameters:    redirect,
+			})
+		case policy.PolicyName == apimMirrorRequest:
+			// the mirror policies are merged into a single policy mirroring to all the URLs
+			if mirrorURLs == nil {
+				mirrorURLs = []string{}
+			}
+			if url := stringParameter(policy, "url"); url != "" {
+				mirrorURLs = append(mirrorURLs, url)
+			}
+		case policy.PolicyName == apimModelRoundRobin || policy.PolicyName == apimModelWeightedRoundRobin:
+			roundRobin, err := mapModelRoundRobinPolicy(policy, endpointURLs)
+			if err != nil {
+				return nil, err
+			}
+			requestPolicies = append(requestPolicies, roundRobin)
+		}
+	}
+
+	for _, policy := range policies.Response {
+		switch policy.PolicyName {
+		case apimInterceptorService:
+			interceptor := mapInterceptorPolicy(policy, responseInterceptorSecretName)
+			responseInterceptor = &interceptor
+		case apimAddHeader:
+			responsePolicies = append(responsePolicies, OperationPolicy{
+				PolicyName:    addHeaderPolicy,
+				PolicyVersion: policyVersionV2,
+				Parameters: Header{
+					HeaderName:  stringParameter(policy, "headerName"),
+					HeaderValue: stringParameter(policy, "headerValue"),
+				},
+			})
+		case apimRemoveHeader:
+			responsePolicies = append(responsePolicies, OperationPolicy{
+				PolicyName:    removeHeaderPolicy,
+				PolicyVersion: policyVersionV1,
+				Parameters:    Header{HeaderName: stringParameter(policy, "headerName")},
+			})
+		}
+	}
+
+	// the interceptor, the backend JWT and the mirror policies follow the other policies
+	if requestInterceptor != nil {
+		requestPolicies = append(requestPolicies, *requestInterceptor)
+	}
+	if backendJWT != nil {
+		requestPolicies = append(requestPolicies, *backendJWT)
+	}
+	if mirrorURLs != nil {
+		requestPolicies = append(requestPolicies, OperationPolicy{
+			PolicyName:    requestMirrorPolicy,
+			PolicyVersion: policyVersionV1,
+			Parameters:    URLList{URLs: mirrorURLs},
+		})
+	}
+	if responseInterceptor != nil {
+		responsePolicies = append(responsePolicies, *responseInterceptor)
+	}
+	if len(requestPolicies) == 0 && len(responsePolicies) == 0 {
+		return nil, nil
+	}
+	return &OperationPolicies{Request: requestPolicies, Response: responsePolicies}, nil
+}
+
+// mapInterceptorPolicy maps an interceptor service policy. The TLS secret of the interceptor service is
+// named after the policy if the service is called over https.
+func mapInterceptorPolicy(policy APIMOperationPolicy, tlsSecretName string) OperationPolicy {
+	interceptor := &InterceptorService{BackendURL: stringParameter(policy, "interceptorServiceURL")}
+	for _, include := range strings.Split(stringParameter(policy, "includes"), ",") {
+		switch {
+		case strings.Contains(include, "request_header"):
+			interceptor.HeadersEnabled = true
+		case strings.Contains(include, "request_body"):
+			interceptor.BodyEnabled = true
+		case strings.Contains(include, "request_trailers"):
+			interceptor.TrailersEnabled = true
+		case strings.Contains(include, "request_context"):
+			interceptor.ContextEnabled = true
+		}
+	}
+	if strings.Contains(interceptor.BackendURL, "https") {
+		interceptor.TLSSecretName = policy.PolicyID + tlsSecretName
+		interceptor.TLSSecretKey = interceptorTLSKey
+	}
+	return OperationPolicy{PolicyName: interceptorPolicy, PolicyVersion: policyVersionV1, Parameters: interceptor}
+}
+
+// mapBackendJWTPolicy maps a backend JWT policy
+func mapBackendJWTPolicy(policy APIMOperationPolicy) (OperationPolicy, error) {
+	backendJWT := &BackendJWT{
+		Encoding:         stringParameter(policy, "encoding"),
+		Header:           stringParameter(policy, "header"),
+		SigningAlgorithm: stringParameter(policy, "signingAlgorithm"),
+	}
+	if backendJWT.Encoding == "Base64Url" {
+		backendJWT.Encoding = "Base64url"
+	}
+	if tokenTTL := stringParameter(policy, "tokenTTL"); tokenTTL != "" {
+		ttl, err := strconv.Atoi(tokenTTL)
+		if err != nil {
+			return OperationPolicy{}, fmt.Errorf("invalid token TTL %s of the %s policy", tokenTTL, policy.PolicyName)
+		}
+		backendJWT.TokenTTL = ttl
+	}
+	return OperationPolicy{PolicyName: backendJWTPolicy, PolicyVersion: policyVersionV1, Parameters: backendJWT}, nil
+}
+
+// roundRobinConfig holds the models of the round robin policies of an AI API
+type roundRobinConfig struct {
+	Production      []modelConfig `json:"production"`
+	Sandbox         []modelConfig `json:"sandbox"`
+	SuspendDuration string        `json:"suspendDuration"`
+}
+
+type modelConfig struct {
+	Model      string `json:"model"`
+	EndpointID string `json:"endpointId"`
+	Weight     int    `json:"weight"`
+}
+
+// mapModelRoundRobinPolicy maps a round robin or a weighted round robin policy of an AI API, resolving the
+// endpoints of the models from endpointURLs
+func mapModelRoundRobinPolicy(policy APIMOperationPolicy, endpointURLs map[string]string) (OperationPolicy, error) {
+	configKey := "roundRobinConfigs"
+	if policy.PolicyName == apimModelWeightedRoundRobin {
+		configKey = "weightedRoundRobinConfigs"
+	}
+	// the configs are given as a JSON string quoted with single quotes
+	configs := strings.ReplaceAll(stringParameter(policy, configKey), "'", "\"")
+	var config roundRobinConfig
+	if err := json.Unmarshal([]byte(configs), &config); err != nil {
+		return OperationPolicy{}, fmt.Errorf("invalid configs of the %s policy: %v", policy.PolicyName, err)
+	}
+	parameters := ModelBasedRoundRobin{}
+	if config.SuspendDuration != "" {
+		duration, err := strconv.Atoi(config.SuspendDuration)
+		if err != nil {
+			return OperationPolicy{}, fmt.Errorf("invalid suspend duration %s of the %s policy",
+				config.SuspendDuration, policy.PolicyName)
+		}
+		parameters.OnQuotaExceedSuspendDuration = duration
+	}
+	modelEndpoints := func(models []modelConfig) []ModelEndpoints {
+		var endpoints []ModelEndpoints
+		for _, model := range models {
+			weight := model.Weight
+			if weight == 0 {
+				weight = 1
+			}
+			endpoints = append(endpoints, ModelEndpoints{Model: model.Model, Weight: weight,
+				Endpoint: endpointURLs[model.EndpointID]})
+		}
+		return endpoints
+	}
+	parameters.ProductionModels = modelEndpoints(config.Production)
+	parameters.SandboxModels = modelEndpoints(config.Sandbox)
+	return OperationPolicy{PolicyName: modelBasedRoundRobin, PolicyVersion: policyVersionV1,
+		Parameters: parameters}, nil
+}
+
+// stringParameter returns a parameter of a policy as a string
+func stringParameter(policy APIMOperationPolicy, name string) string {
+	value, ok := policy.Parameters[name]
+	if !ok || value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprint(value)
+}
+
+// mapAuthConfigs maps the security schemes of an APIM API to the authentication of an apk-conf. The API
+// accepts the internal key of APIM in addition to the configured schemes.
+func mapAuthConfigs(apiUUID string, authHeader string, apiKeyHeader string, securitySchemes []string,
+	clientCertsAvailable bool, clientCerts CertDescriptor, apiUniqueID string) []AuthConfiguration {
+	var authConfigs []AuthConfiguration
+	if stringExists(oAuth2SecScheme, securitySchemes) {
+		oauth2Config := AuthConfiguration{
+			AuthType:   AuthTypeOAuth2,
+			Enabled:    true,
+			HeaderName: authHeader,
+			Required:   optional,
+		}
+		if stringExists(applicationSecurityMandatory, securitySchemes) {
+			oauth2Config.Required = mandatory
+		}
+		authConfigs = append(authConfigs, oauth2Config)
+	} else {
+		authConfigs = append(authConfigs, AuthConfiguration{AuthType: AuthTypeOAuth2, Enabled: false})
+	}
+
+	if stringExists(mutualSSLSecScheme, securitySchemes) && clientCertsAvailable {
+		mtlsConfig := AuthConfiguration{AuthType: AuthTypeMTLS, Enabled: true, Required: optional}
+		if stringExists(mutualSSLMandatory, securitySchemes) {
+			mtlsConfig.Required = mandatory
+		}
+		for _, cert := range clientCerts.CertData {
+			mtlsConfig.Certificates = append(mtlsConfig.Certificates,
+				Certificate{Name: apiUniqueID + "-" + cert.Alias, Key: cert.Certificate})
+		}
+		authConfigs = append(authConfigs, mtlsConfig)
+	}
+
+	authConfigs = append(authConfigs, AuthConfiguration{
+		AuthType:   AuthTypeJWT,
+		Enabled:    true,
+		Audience:   []string{apiUUID},
+		HeaderName: internalKeyHeader,
+	})
+
+	if stringExists(apiKeySecScheme, securitySchemes) {
+		apiKeyConfig := AuthConfiguration{
+			AuthType:       AuthTypeAPIKey,
+			Enabled:        true,
+			HeaderName:     apiKeyHeader,
+			HeaderEnabled:  true,
+			QueryParamName: defaultAPIKeyName,
+		}
+		if stringExists(applicationSecurityMandatory, securitySchemes) {
+			apiKeyConfig.Required = mandatory
+		} else if stringExists(applicationSecurityOptional, securitySchemes) {
+			apiKeyConfig.Required = optional
+		}
+		authConfigs = append(authConfigs, apiKeyConfig)
+	}
+	return authConfigs
+}
+
+// getEndpointConfigs maps the production and the sandbox endpoints of an API along with their certificates and
+// security. The security of the endpoints is returned with the UUID of the primary endpoints.
+// TODO: The apk-conf does not support multiple certificates for an endpoint, so only the last matching
+// certificate is mapped.
+func getEndpointConfigs(endpointConfig EndpointConfig, endpointCerts EndpointCertDescriptor, apiUniqueID string,
+	prodAIRatelimit *AIRatelimit, sandAIRatelimit *AIRatelimit) (*EndpointConfigurations, EndpointSecurityConfig) {
+	endpointSecurity := endpointConfig.EndpointSecurity
+	endpointConfigs := &EndpointConfigurations{}
+	if url := endpointConfig.ProductionEndpoints.URL; url != "" {
+		if endpointSecurity.Production.Enabled {
+			endpointSecurity.Production.EndpointUUID = primaryEndpointUUID
+		}
+		endpointConfigs.Production = []EndpointConfiguration{mapEndpoint(url, productionEnv,
+			endpointSecurity.Production, primaryEndpointUUID, endpointCerts, apiUniqueID, prodAIRatelimit)}
+	}
+	if url := endpointConfig.SandboxEndpoints.URL; url != "" {
+		if endpointSecurity.Sandbox.Enabled {
+			endpointSecurity.Sandbox.EndpointUUID = primaryEndpointUUID
+		}
+		endpointConfigs.Sandbox = []EndpointConfiguration{mapEndpoint(url, sandboxEnv,
+			endpointSecurity.Sandbox, primaryEndpointUUID, endpointCerts, apiUniqueID, sandAIRatelimit)}
+	}
+	return endpointConfigs, endpointSecurity
+}
+
+// getMultiEndpointConfigs maps the endpoints of an API with multiple endpoints along with their certificates and
+// security, and returns the security of each endpoint with its UUID
+func getMultiEndpointConfigs(endpointList []Endpoint, endpointCerts EndpointCertDescriptor, apiUniqueID string,
+	prodAIRatelimit *AIRatelimit, sandAIRatelimit *AIRatelimit) (*EndpointConfigurations, []EndpointSecurityConfig) {
+	endpointConfigs := &EndpointConfigurations{Production: []EndpointConfiguration{},
+		Sandbox: []EndpointConfiguration{}}
+	var endpointSecurityConfigs []EndpointSecurityConfig
+	for _, endpoint := range endpointList {
+		endpointSecurity := endpoint.EndpointConfig.EndpointSecurity
+		endpointSecurity.Production.EndpointUUID = endpoint.ID
+		endpointSecurity.Sandbox.EndpointUUID = endpoint.ID
+		endpointSecurityConfigs = append(endpointSecurityConfigs, endpointSecurity)
+		if url := endpoint.EndpointConfig.ProductionEndpoints.URL; url != "" {
+			endpointConfigs.Production = append(endpointConfigs.Production, mapEndpoint(url, productionEnv,
+				endpointSecurity.Production, endpoint.ID, endpointCerts, apiUniqueID, prodAIRatelimit))
+		}
+		if url := endpoint.EndpointConfig.SandboxEndpoints.URL; url != "" {
+			endpointConfigs.Sandbox = append(endpointConfigs.Sandbox, mapEndpoint(url, sandboxEnv,
+				endpointSecurity.Sandbox, endpoint.ID, endpointCerts, apiUniqueID, sandAIRatelimit))
+		}
+	}
+	return endpointConfigs, endpointSecurityConfigs
+}
+
+// mapEndpoint maps an endpoint of an environment along with its certificate, security and AI rate limit. The
+// secret holding the credentials of the endpoint is named after the API, the endpoint and the environment.
+func mapEndpoint(url string, env string, security SecurityObj, endpointUUID string,
+	endpointCerts EndpointCertDescriptor, apiUniqueID string, aiRatelimit *AIRatelimit) EndpointConfiguration {
+	endpoint := EndpointConfiguration{Endpoint: url, AIRatelimit: aiRatelimit}
+	for _, cert := range endpointCerts.EndpointCertData {
+		if cert.Endpoint == url {
+			endpoint.Certificate = &EndpointCertificate{SecretName: cert.Alias, SecretKey: cert.Certificate}
+		}
+	}
+	if security.Enabled {
+		secretName := strings.Join([]string{apiUniqueID, sha1Hash(endpointUUID), env, "secret"}, "-")
+		endpoint.Security = &EndpointSecurity{Enabled: true}
+		if strings.EqualFold(security.Type, "apikey") {
+			endpoint.Security.SecurityType = SecretInfo{
+				SecretName:     secretName,
+				In:             "Header",
+				APIKeyNameKey:  security.APIKeyIdentifier,
+				APIKeyValueKey: "apiKey",
+			}
+		} else {
+			endpoint.Security.SecurityType = SecretInfo{
+				SecretName:  secretName,
+				UsernameKey: "username",
+				PasswordKey: "password",
+			}
+		}
+	}
+	return endpoint
+}
+
+// prepareAIRatelimit maps the token based throttling of an AI API to the AI rate limits of the production and
+// the sandbox endpoints. A rate limit is nil if the throttling of the environment is not configured.
+func prepareAIRatelimit(maxTps *MaxTps) (*AIRatelimit, *AIRatelimit) {
+	if maxTps == nil || maxTps.TokenBasedThrottlingConfiguration == nil ||
+		maxTps.TokenBasedThrottlingConfiguration.IsTokenBasedThrottlingEnabled == nil {
+		return nil, nil
+	}
+	config := maxTps.TokenBasedThrottlingConfiguration
+	aiRatelimit := func(requests *int, timeUnit *string, promptLimit, completionLimit, totalLimit *int) *AIRatelimit {
+		if requests == nil || timeUnit == nil || promptLimit == nil || completionLimit == nil || totalLimit == nil {
+			return nil
+		}
+		unit := capitalizeFirstLetter(*timeUnit)
+		return &AIRatelimit{
+			Enabled: *config.IsTokenBasedThrottlingEnabled,
+			Token: TokenAIRL{
+				PromptLimit:     *promptLimit,
+				CompletionLimit: *completionLimit,
+				TotalLimit:      *totalLimit,
+				Unit:            unit,
+			},
+			Request: RequestAIRL{RequestLimit: *requests, Unit: unit},
+		}
+	}
+	return aiRatelimit(maxTps.Production, maxTps.ProductionTimeUnit, config.ProductionMaxPromptTokenCount,
+			config.ProductionMaxCompletionTokenCount, config.ProductionMaxTotalTokenCount),
+		aiRatelimit(maxTps.Sandbox, maxTps.SandboxTimeUnit, config.SandboxMaxPromptTokenCount,
+			config.SandboxMaxCompletionTokenCount, config.SandboxMaxTotalTokenCount)
+}
+
+// capitalizeFirstLetter returns the input with the first letter in uppercase and the rest in lowercase
+func capitalizeFirstLetter(input string) string {
+	if input == "" {
+		return input
+	}
+	return strings.ToUpper(input[:1]) + strings.ToLower(input[1:])
+}
+
+func stringExists(value string, list []string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIJSON = `{
+  "type": "api",
+  "version": "v4.5.0",
+  "data": {
+    "id": "c4d6a7c5-f5e4-4b5a-9f4e-3c1b2a0d9e8f",
+    "name": "Books",
+    "version": "1.0.0",
+    "context": "/books",
+    "type": "HTTP",
+    "organizationId": "carbon.super",
+    "revisionedApiId": "5e2f4c1a-7b3d-4e9f-8a6c-1d0b9e8f7a6b",
+    "revisionId": 2,
+    "apiThrottlingPolicy": "Gold",
+    "securityScheme": ["oauth2", "mutualssl", "mutualssl_mandatory"],
+    "endpointConfig": {
+      "endpoint_type": "http",
+      "production_endpoints": {"url": "https://books.example.com"},
+      "endpoint_security": {
+        "production": {"enabled": true, "type": "BASIC", "username": "admin", "password": "admin"}
+      }
+    },
+    "operations": [
+      {
+        "target": "/books",
+        "verb": "GET",
+        "authType": "Application & Application User",
+        "operationPolicies": {
+          "request": [
+            {"policyName": "apkAddHeader", "parameters": {"headerName": "x-team", "headerValue": "books"}},
+            {"policyName": "apkRedirectRequest", "parameters": {"url": "https://new.example.com", "statusCode": 301}}
+          ],
+          "response": [
+            {"policyName": "apkRemoveHeader", "parameters": {"headerName": "x-internal"}}
+          ],
+          "fault": []
+        }
+      }
+    ],
+    "apiPolicies": {"request": [], "response": [], "fault": []}
+  }
+}`
+
+func TestGenerateAPKConf(t *testing.T) {
+	certs := CertificateArtifact{
+		ClientCerts:   `{"data": [{"alias": "client", "certificate": "client.crt"}]}`,
+		EndpointCerts: `{"data": [{"alias": "books-cert", "endpoint": "https://books.example.com", "certificate": "books.crt"}]}`,
+	}
+	gold := RateLimitsResolver(map[string]RateLimit{"Gold": {RequestsPerUnit: 5000, Unit: "Minute"}})
+
+	result, err := GenerateAPKConf([]byte(testAPIJSON), nil, certs, gold)
+	require.NoError(t, err)
+	conf := result.Conf
+	assert.Equal(t, "5e2f4c1a-7b3d-4e9f-8a6c-1d0b9e8f7a6b", result.API.RevisionedAPIID)
+	assert.Equal(t, uint32(2), result.API.RevisionID)
+	assert.Equal(t, &RateLimit{RequestsPerUnit: 5000, Unit: "Minute"}, conf.RateLimit)
+	assert.Equal(t, map[string]string{RateLimitLevelAPI: "Gold"}, result.RateLimitPolicies)
+	assert.Empty(t, result.UnresolvedPolicies)
+
+	require.Len(t, conf.Operations, 1)
+	policies := conf.Operations[0].OperationPolicies
+	require.NotNil(t, policies)
+	assert.Equal(t, []OperationPolicy{
+		{PolicyName: addHeaderPolicy, PolicyVersion: policyVersionV1,
+			Parameters: Header{HeaderName: "x-team", HeaderValue: "books"}},
+		{PolicyName: requestRedirectPolicy, PolicyVersion: policyVersionV1,
+			Parameters: RedirectPolicy{URL: "https://new.example.com", StatusCode: 301}},
+	}, policies.Request)
+	assert.Equal(t, []OperationPolicy{{PolicyName: removeHeaderPolicy, PolicyVersion: policyVersionV1,
+		Parameters: Header{HeaderName: "x-internal"}}}, policies.Response)
+	assert.Nil(t, conf.APIPolicies)
+
+	uniqueID := GetUniqueIDForAPI("Books", "1.0.0", "carbon.super")
+	require.Len(t, conf.EndpointConfigurations.Production, 1)
+	assert.Empty(t, conf.EndpointConfigurations.Sandbox)
+	endpoint := conf.EndpointConfigurations.Production[0]
+	assert.Equal(t, &EndpointCertificate{SecretName: "books-cert", SecretKey: "books.crt"}, endpoint.Certificate)
+	assert.Equal(t, &EndpointSecurity{Enabled: true, SecurityType: SecretInfo{
+		SecretName:  uniqueID + "-" + sha1Hash(primaryEndpointUUID) + "-production-secret",
+		UsernameKey: "username",
+		PasswordKey: "password",
+	}}, endpoint.Security)
+	require.Len(t, result.EndpointSecurity, 1)
+	assert.Equal(t, primaryEndpointUUID, result.EndpointSecurity[0].Production.EndpointUUID)
+	assert.Equal(t, "admin", result.EndpointSecurity[0].Production.Username)
+
+	require.Len(t, conf.Authentication, 3)
+	assert.Equal(t, AuthConfiguration{AuthType: AuthTypeMTLS, Enabled: true, Required: mandatory,
+		Certificates: []Certificate{{Name: uniqueID + "-client", Key: "client.crt"}}}, conf.Authentication[1])
+	assert.Equal(t, AuthTypeJWT, conf.Authentication[2].AuthType)
+}
+
+func TestGenerateAPKConfWithMultipleEndpoints(t *testing.T) {
+	endpoints := `data:
+ - id: 0b4c2f1e-8d7a-4c6b-9e5f-3a2d1c0b9a8e
+   name: Secondary
+   endpointConfig:
+     endpoint_type: http
+     production_endpoints:
+       url: https://secondary.example.com
+     endpoint_security:
+       production:
+         enabled: true
+         type: apikey
+         apiKeyIdentifier: x-api-key
+         apiKeyValue: secret
+   deploymentStage: PRODUCTION
+`
+	result, err := GenerateAPKConf([]byte(testAPIJSON), []byte(endpoints), CertificateArtifact{},
+		RateLimitsResolver(nil))
+	require.NoError(t, err)
+	assert.Nil(t, result.Conf.RateLimit)
+	assert.Equal(t, []string{"Gold"}, result.UnresolvedPolicies)
+
+	production := result.Conf.EndpointConfigurations.Production
+	require.Len(t, production, 2)
+	assert.Equal(t, "https://secondary.example.com", production[0].Endpoint)
+	assert.Equal(t, SecretInfo{
+		SecretName: GetUniqueIDForAPI("Books", "1.0.0", "carbon.super") + "-" +
+			sha1Hash("0b4c2f1e-8d7a-4c6b-9e5f-3a2d1c0b9a8e") + "-production-secret",
+		In:             "Header",
+		APIKeyNameKey:  "x-api-key",
+		APIKeyValueKey: "apiKey",
+	}, production[0].Security.SecurityType)
+	assert.Equal(t, "https://books.example.com", production[1].Endpoint)
+	require.Len(t, result.EndpointSecurity, 3)
+	assert.Equal(t, "0b4c2f1e-8d7a-4c6b-9e5f-3a2d1c0b9a8e", result.EndpointSecurity[0].Production.EndpointUUID)
+}
+
+func TestGenerateAPKConfWithAIRatelimit(t *testing.T) {
+	requests, promptTokens, completionTokens, totalTokens, enabled := 10, 100, 200, 300, true
+	unit := "MINUTE"
+	api := &APIMApi{Name: "Chat", Version: "1.0.0", MaxTps: &MaxTps{
+		Production:         &requests,
+		ProductionTimeUnit: &unit,
+		TokenBasedThrottlingConfiguration: &TokenBasedThrottlingConfig{
+			ProductionMaxPromptTokenCount:     &promptTokens,
+			ProductionMaxCompletionTokenCount: &completionTokens,
+			ProductionMaxTotalTokenCount:      &totalTokens,
+			IsTokenBasedThrottlingEnabled:     &enabled,
+		},
+	}}
+	api.EndpointConfig.ProductionEndpoints.URL = "https://chat.example.com"
+
+	result, err := mapAPKConf(api, nil, CertificateArtifact{}, RateLimitsResolver(nil))
+	require.NoError(t, err)
+	expected := &AIRatelimit{
+		Enabled: true,
+		Token:   TokenAIRL{PromptLimit: 100, CompletionLimit: 200, TotalLimit: 300, Unit: "Minute"},
+		Request: RequestAIRL{RequestLimit: 10, Unit: "Minute"},
+	}
+	assert.Equal(t, expected, result.ProductionAIRatelimit)
+	assert.Nil(t, result.SandboxAIRatelimit)
+	assert.Equal(t, expected, result.Conf.EndpointConfigurations.Production[0].AIRatelimit)
+}
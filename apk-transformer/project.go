@@ -0,0 +1,87 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	apiYamlFile           = "api.yaml"
+	endpointsYamlFile     = "endpoints.yaml"
+	definitionsDir        = "Definitions"
+	swaggerDefinitionFile = "swagger.yaml"
+)
+
+// Project represents an API project created or exported by apictl
+type Project struct {
+	Path string
+	API  *APIMApi
+	// Endpoints holds the endpoints of an API with multiple endpoints
+	Endpoints []Endpoint
+	// Certificates holds the client and the endpoint certificates of the API
+	Certificates CertificateArtifact
+	Definition   []byte
+}
+
+// LoadProject reads the api.yaml, the endpoints.yaml if any and the OpenAPI definition of the API project in
+// the given directory
+func LoadProject(projectDir string) (*Project, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, apiYamlFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s of the project: %v", apiYamlFile, err)
+	}
+	api, err := ParseAPI(content)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s of the project: %v", apiYamlFile, err)
+	}
+	if api.Name == "" || api.Version == "" {
+		return nil, fmt.Errorf("%s of the project should contain the name and the version of the API", apiYamlFile)
+	}
+
+	var endpoints []Endpoint
+	if content, err := os.ReadFile(filepath.Join(projectDir, endpointsYamlFile)); err == nil {
+		if endpoints, err = ParseEndpoints(content); err != nil {
+			return nil, fmt.Errorf("error parsing %s of the project: %v", endpointsYamlFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading %s of the project: %v", endpointsYamlFile, err)
+	}
+
+	definition, err := os.ReadFile(filepath.Join(projectDir, definitionsDir, swaggerDefinitionFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading the API definition of the project: %v", err)
+	}
+	return &Project{Path: projectDir, API: api, Endpoints: endpoints, Definition: definition}, nil
+}
+
+// APKConf maps the API project to an apk-conf the same way the APK agent maps the APIs deployed from APIM.
+// Throttling policies are resolved from the given rate limits (BuiltInRateLimitPolicies is used if nil) and
+// the names of the policies which could not be resolved are returned, as those are not applied to the API.
+func (project *Project) APKConf(rateLimits map[string]RateLimit) (*APKConf, []string, error) {
+	if rateLimits == nil {
+		rateLimits = BuiltInRateLimitPolicies
+	}
+	result, err := mapAPKConf(project.API, project.Endpoints, project.Certificates, RateLimitsResolver(rateLimits))
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Conf, result.UnresolvedPolicies, nil
+}
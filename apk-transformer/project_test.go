@@ -0,0 +1,102 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProject = "testdata/PizzaShackAPI-1.0.0"
+
+func TestLoadProject(t *testing.T) {
+	project, err := LoadProject(testProject)
+	require.NoError(t, err)
+	assert.Equal(t, "PizzaShackAPI", project.API.Name)
+	assert.Equal(t, "https://pizzashack.example.com/am/sample/pizzashack/v1/api/",
+		project.API.EndpointConfig.ProductionEndpoints.URL)
+	assert.Equal(t, "http://pizzashack-sandbox:8080/api/", project.API.EndpointConfig.SandboxEndpoints.URL)
+	assert.Empty(t, project.Endpoints)
+	assert.NotEmpty(t, project.Definition)
+}
+
+func TestLoadProjectWithoutAPIYaml(t *testing.T) {
+	_, err := LoadProject("testdata")
+	assert.Error(t, err)
+}
+
+func TestProjectAPKConf(t *testing.T) {
+	project, err := LoadProject(testProject)
+	require.NoError(t, err)
+
+	conf, unresolved, err := project.APKConf(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Gold"}, unresolved)
+	assert.Equal(t, restAPIType, conf.Type)
+	assert.Equal(t, "/pizzashack", conf.BasePath)
+	assert.True(t, conf.DefaultVersion)
+	require.Len(t, conf.Operations, 3)
+	assert.Equal(t, &RateLimit{RequestsPerUnit: 10000, Unit: "Minute"}, conf.Operations[0].RateLimit)
+	assert.False(t, conf.Operations[1].Secured)
+	assert.Nil(t, conf.Operations[2].RateLimit)
+	require.Len(t, conf.Authentication, 3)
+	assert.Equal(t, AuthConfiguration{AuthType: AuthTypeOAuth2, Enabled: true, HeaderName: "Authorization",
+		Required: mandatory}, conf.Authentication[0])
+	assert.Equal(t, AuthConfiguration{AuthType: AuthTypeJWT, Enabled: true, HeaderName: "internal-key",
+		Audience: []string{"39325037-1508-4398-a358-e551927ff075"}}, conf.Authentication[1])
+	assert.Equal(t, AuthTypeAPIKey, conf.Authentication[2].AuthType)
+	assert.Equal(t, "ApiKey", conf.Authentication[2].HeaderName)
+	assert.NotNil(t, conf.CorsConfig)
+	assert.Equal(t, []AdditionalProperty{{Name: "team", Value: "pizza"}}, conf.AdditionalProperties)
+}
+
+func TestProjectAPKConfWithOverriddenEndpoints(t *testing.T) {
+	project, err := LoadProject(testProject)
+	require.NoError(t, err)
+	project.API.EndpointConfig.ProductionEndpoints = EndpointDetails{URL: "https://prod.example.com"}
+	project.API.EndpointConfig.SandboxEndpoints = EndpointDetails{}
+
+	conf, _, err := project.APKConf(map[string]RateLimit{})
+	require.NoError(t, err)
+	assert.Equal(t, []EndpointConfiguration{{Endpoint: "https://prod.example.com"}}, conf.EndpointConfigurations.Production)
+	assert.Empty(t, conf.EndpointConfigurations.Sandbox)
+}
+
+func TestProjectAPKConfUnsupportedType(t *testing.T) {
+	project := &Project{API: &APIMApi{Name: "Books", Version: "1.0.0", Type: "WS"}}
+	_, _, err := project.APKConf(nil)
+	assert.Error(t, err)
+}
+
+func TestParseAPIWithLoadBalancedEndpoints(t *testing.T) {
+	content := []byte(`data:
+  name: Books
+  version: 1.0.0
+  endpointConfig:
+    endpoint_type: load_balance
+    production_endpoints:
+     - url: http://first
+     - url: http://second
+`)
+	api, err := ParseAPI(content)
+	require.NoError(t, err)
+	assert.Equal(t, "http://first", api.EndpointConfig.ProductionEndpoints.URL)
+	assert.Empty(t, api.EndpointConfig.SandboxEndpoints.URL)
+}
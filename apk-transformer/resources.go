@@ -0,0 +1,243 @@
+/*
+ *  Copyright (c) 2026, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package apktransformer
+
+// Resource represents a Kubernetes resource generated for an API
+type Resource struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   ObjectMeta        `yaml:"metadata"`
+	Spec       interface{}       `yaml:"spec,omitempty"`
+	BinaryData map[string]string `yaml:"binaryData,omitempty"`
+}
+
+// ObjectMeta holds the metadata of a generated resource
+type ObjectMeta struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type apiSpec struct {
+	APIName           string        `yaml:"apiName"`
+	APIVersion        string        `yaml:"apiVersion"`
+	IsDefaultVersion  bool          `yaml:"isDefaultVersion"`
+	DefinitionFileRef string        `yaml:"definitionFileRef,omitempty"`
+	DefinitionPath    string        `yaml:"definitionPath,omitempty"`
+	Production        []envConfig   `yaml:"production,omitempty"`
+	Sandbox           []envConfig   `yaml:"sandbox,omitempty"`
+	APIType           string        `yaml:"apiType"`
+	BasePath          string        `yaml:"basePath"`
+	Organization      string        `yaml:"organization"`
+	SystemAPI         bool          `yaml:"systemAPI"`
+	APIProperties     []apiProperty `yaml:"apiProperties,omitempty"`
+}
+
+type envConfig struct {
+	RouteRefs []string `yaml:"routeRefs"`
+}
+
+type apiProperty struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type httpRouteSpec struct {
+	ParentRefs []parentReference `yaml:"parentRefs"`
+	Hostnames  []string          `yaml:"hostnames"`
+	Rules      []httpRouteRule   `yaml:"rules"`
+}
+
+type parentReference struct {
+	Group       string `yaml:"group"`
+	Kind        string `yaml:"kind"`
+	Name        string `yaml:"name"`
+	SectionName string `yaml:"sectionName,omitempty"`
+}
+
+type httpRouteRule struct {
+	Matches     []httpRouteMatch  `yaml:"matches"`
+	Filters     []httpRouteFilter `yaml:"filters,omitempty"`
+	BackendRefs []backendRef      `yaml:"backendRefs"`
+}
+
+type httpRouteMatch struct {
+	Path   httpPathMatch `yaml:"path"`
+	Method string        `yaml:"method,omitempty"`
+}
+
+type httpPathMatch struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+type httpRouteFilter struct {
+	Type         string       `yaml:"type"`
+	ExtensionRef extensionRef `yaml:"extensionRef"`
+}
+
+type extensionRef struct {
+	Group string `yaml:"group"`
+	Kind  string `yaml:"kind"`
+	Name  string `yaml:"name"`
+}
+
+type backendRef struct {
+	Group string `yaml:"group"`
+	Kind  string `yaml:"kind"`
+	Name  string `yaml:"name"`
+}
+
+type backendSpec struct {
+	Services []backendService `yaml:"services"`
+	Protocol string           `yaml:"protocol"`
+	BasePath string           `yaml:"basePath"`
+	TLS      *backendTLS      `yaml:"tls,omitempty"`
+	Security *backendSecurity `yaml:"security,omitempty"`
+}
+
+type backendService struct {
+	Host string `yaml:"host"`
+	Port uint32 `yaml:"port"`
+}
+
+type backendTLS struct {
+	SecretRef refConfig `yaml:"secretRef"`
+}
+
+type backendSecurity struct {
+	Basic  *basicSecurity  `yaml:"basic,omitempty"`
+	APIKey *apiKeySecurity `yaml:"apiKey,omitempty"`
+}
+
+type basicSecurity struct {
+	SecretRef basicSecretRef `yaml:"secretRef"`
+}
+
+type basicSecretRef struct {
+	Name        string `yaml:"name"`
+	UsernameKey string `yaml:"usernameKey"`
+	PasswordKey string `yaml:"passwordKey"`
+}
+
+type apiKeySecurity struct {
+	In        string   `yaml:"in,omitempty"`
+	Name      string   `yaml:"name,omitempty"`
+	ValueFrom valueRef `yaml:"valueFrom"`
+}
+
+type valueRef struct {
+	Name     string `yaml:"name"`
+	ValueKey string `yaml:"valueKey"`
+}
+
+type refConfig struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type policyTargetReference struct {
+	Group string `yaml:"group"`
+	Kind  string `yaml:"kind"`
+	Name  string `yaml:"name"`
+}
+
+type authenticationSpec struct {
+	Default   *authSpec             `yaml:"default,omitempty"`
+	Override  *authSpec             `yaml:"override,omitempty"`
+	TargetRef policyTargetReference `yaml:"targetRef"`
+}
+
+type authSpec struct {
+	Disabled  *bool    `yaml:"disabled,omitempty"`
+	AuthTypes *apiAuth `yaml:"authTypes,omitempty"`
+}
+
+type apiAuth struct {
+	OAuth2    oauth2Auth  `yaml:"oauth2"`
+	APIKey    *apiKeyAuth `yaml:"apiKey,omitempty"`
+	JWT       *jwtAuth    `yaml:"jwt,omitempty"`
+	MutualSSL *mutualSSL  `yaml:"mtls,omitempty"`
+}
+
+type oauth2Auth struct {
+	Required            string `yaml:"required,omitempty"`
+	Disabled            bool   `yaml:"disabled"`
+	Header              string `yaml:"header,omitempty"`
+	SendTokenToUpstream bool   `yaml:"sendTokenToUpstream,omitempty"`
+}
+
+type apiKeyAuth struct {
+	Required string   `yaml:"required,omitempty"`
+	Keys     []apiKey `yaml:"keys,omitempty"`
+}
+
+type apiKey struct {
+	In                  string `yaml:"in"`
+	Name                string `yaml:"name"`
+	SendTokenToUpstream bool   `yaml:"sendTokenToUpstream,omitempty"`
+}
+
+type jwtAuth struct {
+	Disabled            bool     `yaml:"disabled"`
+	Header              string   `yaml:"header,omitempty"`
+	SendTokenToUpstream bool     `yaml:"sendTokenToUpstream,omitempty"`
+	Audience            []string `yaml:"audience,omitempty"`
+}
+
+type mutualSSL struct {
+	Required      string      `yaml:"required"`
+	ConfigMapRefs []refConfig `yaml:"configMapRefs,omitempty"`
+}
+
+type apiPolicySpec struct {
+	Default   *policySpec           `yaml:"default,omitempty"`
+	TargetRef policyTargetReference `yaml:"targetRef"`
+}
+
+type policySpec struct {
+	CORSPolicy             *corsPolicy `yaml:"cORSPolicy,omitempty"`
+	SubscriptionValidation bool        `yaml:"subscriptionValidation"`
+}
+
+type corsPolicy struct {
+	Enabled                       bool     `yaml:"enabled"`
+	AccessControlAllowCredentials bool     `yaml:"accessControlAllowCredentials,omitempty"`
+	AccessControlAllowHeaders     []string `yaml:"accessControlAllowHeaders,omitempty"`
+	AccessControlAllowMethods     []string `yaml:"accessControlAllowMethods,omitempty"`
+	AccessControlAllowOrigins     []string `yaml:"accessControlAllowOrigins,omitempty"`
+	AccessControlExposeHeaders    []string `yaml:"accessControlExposeHeaders,omitempty"`
+}
+
+type scopeSpec struct {
+	Names []string `yaml:"names"`
+}
+
+type rateLimitPolicySpec struct {
+	Default   *rateLimitAPIPolicy   `yaml:"default,omitempty"`
+	TargetRef policyTargetReference `yaml:"targetRef"`
+}
+
+type rateLimitAPIPolicy struct {
+	API *apiRateLimitPolicy `yaml:"api"`
+}
+
+type apiRateLimitPolicy struct {
+	RequestsPerUnit int    `yaml:"requestsPerUnit"`
+	Unit            string `yaml:"unit"`
+}
@@ -33,6 +33,7 @@ var flagRegistrationEndpoint string // registration endpoint of the environment
 var flagApiManagerEndpoint string   // api manager endpoint of the environment to be added
 var flagAdminEndpoint string        // admin endpoint of the environment to be added
 var flagMiManagementEndpoint string // mi management endpoint of the environment to be added
+var flagMiNodeEndpoints []string    // management endpoints of the mi nodes of the environment to be added
 var flagAIServiceEndpoint string // ai service endpoint of the environment to be added
 var flagAITokenServiceEndpoint string // ai token service endpoint of the environment to be added
 var flagAIKey string // base-64 encoded client_id and client_secret of the environment to be added
@@ -52,6 +53,11 @@ const addEnvCmdExamples = utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnv
 --apim  https://apim.com:9443 \
 --mi https://localhost:9164
 
+` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` cluster \
+--mi  https://mi-lb.com:9164 \
+--mi-node https://mi-0.com:9164 \
+--mi-node https://mi-1.com:9164
+
 ` + utils.ProjectName + ` ` + AddCmdLiteral + ` ` + AddEnvCmdLiteralTrimmed + ` test \
 --registration https://idp.com:9443 \
 --publisher https://apim.com:9443 \
@@ -110,6 +116,7 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints.AdminEndpoint = flagAdminEndpoint
 	envEndpoints.TokenEndpoint = flagTokenEndpoint
 	envEndpoints.MiManagementEndpoint = flagMiManagementEndpoint
+	envEndpoints.MiNodeEndpoints = flagMiNodeEndpoints
 	envEndpoints.AIServiceEndpoint = flagAIServiceEndpoint
 	envEndpoints.AITokenServiceEndpoint = flagAITokenServiceEndpoint
 	envEndpoints.AIKey = flagAIKey
@@ -131,6 +138,8 @@ func init() {
 		"Registration endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAdminEndpoint, "admin", "", "Admin endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagMiManagementEndpoint, "mi", "", "Micro Integrator Management endpoint for the environment")
+	addEnvCmd.Flags().StringSliceVar(&flagMiNodeEndpoints, "mi-node", []string{},
+		"Management endpoint of a Micro Integrator node of the environment, repeat for each node of a cluster")
 	addEnvCmd.Flags().StringVar(&flagAIServiceEndpoint, "ai-service", "", "AI service endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAITokenServiceEndpoint, "ai-token-endpoint", "", "AI token service endpoint for the environment")
 	addEnvCmd.Flags().StringVar(&flagAIKey, "ai-key", "", "Base64 encoded client_id and client_secret for the environment")
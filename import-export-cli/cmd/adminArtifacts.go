@@ -0,0 +1,80 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// getAdminArtifactAccessToken returns an access token of the environment to export or import the admin artifacts
+func getAdminArtifactAccessToken(environment string) string {
+	cred, err := GetCredentials(environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens of "+environment, err)
+	}
+	return accessToken
+}
+
+// executeExportAdminArtifactCmd exports the admin artifact of artifactType (named name, if it has a name) from the
+// environment to the exportDirName directory of the export directory
+func executeExportAdminArtifactCmd(artifactType, name, environment, exportDirName, exportFormat, output string) {
+	accessToken := getAdminArtifactAccessToken(environment)
+	result := &impl.ActionResult{Type: impl.GetAdminArtifactProjectType(artifactType), Name: name,
+		Environment: environment, Action: impl.ActionExport}
+	artifact, err := impl.ExportAdminArtifactFromEnv(accessToken, environment, artifactType, name)
+	if err == nil {
+		result.Path, err = impl.WriteAdminArtifactToFile(filepath.Join(utils.ExportDirectory, exportDirName,
+			environment), artifact, exportFormat)
+	}
+	if err != nil {
+		impl.PrintActionResult(result.Fail(err.Error()), output)
+		utils.HandleErrorAndExit("Error exporting "+result.Type, err)
+	}
+	if !impl.PrintActionResult(result.Succeed(), output) {
+		fmt.Println("Successfully exported " + result.Type + "!")
+		fmt.Println("Find the exported " + result.Type + " at " + result.Path)
+	}
+}
+
+// executeImportAdminArtifactCmd imports the admin artifact of artifactType in the file at filePath to the environment
+func executeImportAdminArtifactCmd(artifactType, filePath, environment string, update bool, output string) {
+	accessToken := getAdminArtifactAccessToken(environment)
+	result := impl.NewImportResult(impl.GetAdminArtifactProjectType(artifactType), environment, filePath, "")
+	artifact, err := impl.LoadAdminArtifactFromFile(filePath, artifactType)
+	if err == nil {
+		result.Name = impl.GetAdminArtifactName(artifact)
+		err = impl.ImportAdminArtifactToEnv(accessToken, environment, artifact, update)
+	}
+	if err != nil {
+		impl.PrintActionResult(result.Fail(err.Error()), output)
+		utils.HandleErrorAndExit("Error importing "+result.Type, err)
+	}
+	if !impl.PrintActionResult(result.Succeed(), output) {
+		fmt.Println("Successfully imported " + result.Type + "!")
+	}
+}
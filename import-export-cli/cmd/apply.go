@@ -0,0 +1,101 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var applyFile string
+var applyEnvironment string
+var applyDryRun bool
+var applyPrune bool
+var applyOutput string
+
+// apply command related usage Info
+const applyCmdLiteral = "apply"
+const applyCmdShortDesc = "Apply a directory of artifacts to an environment"
+const applyCmdLongDesc = `Create or update all the artifacts found in the directory specified by --file (-f) in the environment specified by --environment (-e).
+API, API Product and Application projects are detected using their meta files, as in the vcs commands. Rate limiting policies exported using "export policy rate-limiting"
+and API policy directories are detected as well. The artifacts are applied in the order of their dependencies: policies, APIs, API Products and then Applications.
+Use --dry-run to list the actions without changing the environment and --prune to delete the artifacts which were applied previously from the same directory
+but have been removed from it since.
+NOTE: Both the flags --file (-f) and --environment (-e) are mandatory`
+
+const applyCmdExamples = utils.ProjectName + ` ` + applyCmdLiteral + ` -f ./artifacts -e dev
+` + utils.ProjectName + ` ` + applyCmdLiteral + ` -f ./artifacts -e dev --dry-run
+` + utils.ProjectName + ` ` + applyCmdLiteral + ` -f ./artifacts -e production --prune
+` + utils.ProjectName + ` ` + applyCmdLiteral + ` -f ./artifacts -e production --prune -o json`
+
+// ApplyCmd represents the apply command
+var ApplyCmd = &cobra.Command{
+	Use:     applyCmdLiteral + " (--file <directory-of-artifacts> --environment <environment-to-which-the-artifacts-should-be-applied>)",
+	Short:   applyCmdShortDesc,
+	Long:    applyCmdLongDesc,
+	Example: applyCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + applyCmdLiteral + " called")
+		if dirExists, _ := utils.IsDirExists(applyFile); !dirExists {
+			utils.HandleErrorAndExit("Error applying artifacts", errors.New(applyFile+" is not a directory"))
+		}
+		// a dry run still looks up the artifacts in the environment to decide between create and update
+		cred, err := GetCredentials(applyEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessToken, err := credentials.GetOAuthAccessToken(cred, applyEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for applying the artifacts", err)
+		}
+		results, err := git.Apply(accessToken, applyEnvironment, applyFile, applyDryRun, applyPrune)
+		git.PrintApplyResults(results, applyOutput)
+		if err != nil {
+			utils.HandleErrorAndExit("Error applying artifacts", err)
+		}
+		if git.HasApplyFailures(results) {
+			utils.HandleErrorAndExit("Some artifacts could not be applied", nil)
+		}
+		if applyDryRun {
+			fmt.Fprintln(formatter.MessageWriter(applyOutput),
+				"Dry run completed. No changes were made to "+applyEnvironment)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(ApplyCmd)
+	ApplyCmd.Flags().StringVarP(&applyFile, "file", "f", "",
+		"Directory containing the artifacts to be applied")
+	ApplyCmd.Flags().StringVarP(&applyEnvironment, "environment", "e",
+		"", "Environment to which the artifacts should be applied")
+	ApplyCmd.Flags().BoolVarP(&applyDryRun, "dry-run", "", false,
+		"List the actions to be taken without applying the artifacts")
+	ApplyCmd.Flags().BoolVarP(&applyPrune, "prune", "", false,
+		"Delete the artifacts applied previously from the directory which have been removed from it")
+	formatter.AddOutputFlag(ApplyCmd.Flags(), &applyOutput)
+	_ = ApplyCmd.MarkFlagRequired("file")
+	_ = ApplyCmd.MarkFlagRequired("environment")
+}
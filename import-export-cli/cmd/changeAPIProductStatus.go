@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -33,6 +34,7 @@ var apiProductNameForStateChange string
 var apiProductVersionForStateChange string
 var apiProductProviderForStateChange string
 var apiProductStateChangeAction string
+var changeAPIProductStatusOutput string
 
 // ChangeAPIProductStatus command related usage info
 const changeAPIProductStatusCmdLiteral = "api-product"
@@ -62,6 +64,10 @@ var ChangeAPIProductStatusCmd = &cobra.Command{
 
 // executeChangeAPIProductStatusCmd executes the change api product status command
 func executeChangeAPIProductStatusCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(changeAPIProductStatusOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeApiProduct, Name: apiProductNameForStateChange,
+		Version: apiProductVersionForStateChange, Owner: apiProductProviderForStateChange,
+		Environment: apiProductStateChangeEnvironment, Action: impl.ActionChangeStatus}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, apiProductStateChangeEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.ChangeAPIProductStatusInEnv(accessToken, apiProductStateChangeEnvironment, apiProductStateChangeAction,
@@ -73,17 +79,23 @@ func executeChangeAPIProductStatusCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(apiNameForStateChange + " API Product state changed successfully!")
+			fmt.Fprintln(out, apiNameForStateChange+" API Product state changed successfully!")
+			result.State = impl.GetLifecycleState(resp.Body())
+			impl.PrintActionResult(result.Succeed(), changeAPIProductStatusOutput)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
+			impl.PrintActionResult(result.Fail(string(resp.Body())), changeAPIProductStatusOutput)
 		} else {
 			// Neither 200 nor 500
-			fmt.Println("Error while changing API Product Status: ", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error while changing API Product Status: ", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), changeAPIProductStatusOutput)
 		}
 	} else {
 		// Error changing the API Product status
-		fmt.Println("Error getting OAuth tokens while changing status of the API Product : " + preCommandErr.Error())
+		fmt.Fprintln(out,
+			"Error getting OAuth tokens while changing status of the API Product : "+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), changeAPIProductStatusOutput)
 	}
 }
 
@@ -104,4 +116,5 @@ func init() {
 	_ = ChangeAPIProductStatusCmd.MarkFlagRequired("name")
 	_ = ChangeAPIProductStatusCmd.MarkFlagRequired("version")
 	_ = ChangeAPIProductStatusCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ChangeAPIProductStatusCmd.Flags(), &changeAPIProductStatusOutput)
 }
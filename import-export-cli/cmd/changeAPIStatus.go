@@ -25,6 +25,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -34,6 +35,7 @@ var apiNameForStateChange string
 var apiVersionForStateChange string
 var apiProviderForStateChange string
 var apiStateChangeAction string
+var changeAPIStatusOutput string
 
 // ChangeAPIStatus command related usage info
 const changeAPIStatusCmdLiteral = "api"
@@ -63,6 +65,10 @@ var ChangeAPIStatusCmd = &cobra.Command{
 
 // executeChangeAPIStatusCmd executes the change api status command
 func executeChangeAPIStatusCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(changeAPIStatusOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeApi, Name: apiNameForStateChange,
+		Version: apiVersionForStateChange, Owner: apiProviderForStateChange, Environment: apiStateChangeEnvironment,
+		Action: impl.ActionChangeStatus}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, apiStateChangeEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.ChangeAPIStatusInEnv(accessToken, apiStateChangeEnvironment, apiStateChangeAction,
@@ -74,17 +80,22 @@ func executeChangeAPIStatusCmd(credential credentials.Credential) {
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
 			// 200 OK
-			fmt.Println(apiNameForStateChange + " API state changed successfully!")
+			fmt.Fprintln(out, apiNameForStateChange+" API state changed successfully!")
+			result.State = impl.GetLifecycleState(resp.Body())
+			impl.PrintActionResult(result.Succeed(), changeAPIStatusOutput)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
+			impl.PrintActionResult(result.Fail(string(resp.Body())), changeAPIStatusOutput)
 		} else {
 			// Neither 200 nor 500
-			fmt.Println("Error while changing API Status: ", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error while changing API Status: ", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), changeAPIStatusOutput)
 		}
 	} else {
 		// Error changing the API status
-		fmt.Println("Error getting OAuth tokens while changing status of the API:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while changing status of the API:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), changeAPIStatusOutput)
 	}
 }
 
@@ -105,4 +116,5 @@ func init() {
 	_ = ChangeAPIStatusCmd.MarkFlagRequired("name")
 	_ = ChangeAPIStatusCmd.MarkFlagRequired("version")
 	_ = ChangeAPIStatusCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ChangeAPIStatusCmd.Flags(), &changeAPIStatusOutput)
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
@@ -32,6 +33,7 @@ var deleteAPIEnvironment string
 var deleteAPIName string
 var deleteAPIVersion string
 var deleteAPIProvider string
+var deleteAPIOutput string
 
 // DeleteAPI command related usage info
 const deleteAPICmdLiteral = "api"
@@ -69,16 +71,21 @@ var DeleteAPICmd = &cobra.Command{
 
 // executeDeleteAPICmd executes the delete api command
 func executeDeleteAPICmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(deleteAPIOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeApi, Name: deleteAPIName, Version: deleteAPIVersion,
+		Owner: deleteAPIProvider, Environment: deleteAPIEnvironment, Action: impl.ActionDelete}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAPIEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.DeleteAPI(accessToken, deleteAPIEnvironment, deleteAPIName, deleteAPIVersion, deleteAPIProvider)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting API ", err)
 		}
-		impl.PrintDeleteAPIResponse(resp, err)
+		impl.PrintDeleteAPIResponse(out, resp, err)
+		impl.PrintActionResult(result.Succeed(), deleteAPIOutput)
 	} else {
 		// Error deleting API
-		fmt.Println("Error getting OAuth tokens while deleting API:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while deleting API:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), deleteAPIOutput)
 	}
 }
 
@@ -103,4 +110,5 @@ func init() {
 		_ = DeleteAPICmd.MarkFlagRequired("version")
 		_ = DeleteAPICmd.MarkFlagRequired("environment")
 	}
+	formatter.AddOutputFlag(DeleteAPICmd.Flags(), &deleteAPIOutput)
 }
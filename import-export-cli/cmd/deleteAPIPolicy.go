@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -30,6 +31,7 @@ import (
 var deleteAPIPolicyEnvironment string
 var deleteAPIPolicyName string
 var deleteAPIPolicyVersion string
+var deleteAPIPolicyOutput string
 
 // DeleteAPIPolicy command related usage info
 const DeleteAPIPolicyCmdLiteral = "api"
@@ -61,16 +63,21 @@ var DeleteAPIPolicyCmd = &cobra.Command{
 
 // executeDeleteAPIPolicyCmd executes the delete api policy command
 func executeDeleteAPIPolicyCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(deleteAPIPolicyOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeAPIPolicy, Name: deleteAPIPolicyName, Version: deleteAPIPolicyVersion,
+		Environment: deleteAPIPolicyEnvironment, Action: impl.ActionDelete}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAPIPolicyEnvironment)
 	if preCommandErr == nil {
 		_, err := impl.DeleteAPIPolicy(accessToken, deleteAPIPolicyName, deleteAPIPolicyVersion, deleteAPIPolicyEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting API Policy ", err)
 		}
-		impl.PrintDeleteAPIPolicyResponse(deleteAPIPolicyName, deleteAPIPolicyVersion, err)
+		impl.PrintDeleteAPIPolicyResponse(out, deleteAPIPolicyName, deleteAPIPolicyVersion, err)
+		impl.PrintActionResult(result.Succeed(), deleteAPIPolicyOutput)
 	} else {
 		// Error deleting API Policy
-		fmt.Println("Error getting OAuth tokens while deleting API Policy:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while deleting API Policy:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), deleteAPIPolicyOutput)
 	}
 }
 
@@ -87,4 +94,5 @@ func init() {
 	_ = DeleteAPIPolicyCmd.MarkFlagRequired("name")
 	_ = DeleteAPIPolicyCmd.MarkFlagRequired("version")
 	_ = DeleteAPIPolicyCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(DeleteAPIPolicyCmd.Flags(), &deleteAPIPolicyOutput)
 }
@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -32,6 +33,7 @@ var deleteAPIProductEnvironment string
 var deleteAPIProductName string
 var deleteAPIProductVersion string
 var deleteAPIProductProvider string
+var deleteAPIProductOutput string
 
 // DeleteAPIProduct command related usage info
 const deleteAPIProductCmdLiteral = "api-product"
@@ -61,16 +63,21 @@ var DeleteAPIProductCmd = &cobra.Command{
 
 // executeDeleteAPIProductCmd executes the delete api command
 func executeDeleteAPIProductCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(deleteAPIProductOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeApiProduct, Name: deleteAPIProductName, Version: deleteAPIProductVersion,
+		Owner: deleteAPIProductProvider, Environment: deleteAPIProductEnvironment, Action: impl.ActionDelete}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAPIProductEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.DeleteAPIProduct(accessToken, deleteAPIProductEnvironment, deleteAPIProductName, deleteAPIProductVersion, deleteAPIProductProvider)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting API Product", err)
 		}
-		impl.PrintDeleteAPIProductResponse(resp, err)
+		impl.PrintDeleteAPIProductResponse(out, resp, err)
+		impl.PrintActionResult(result.Succeed(), deleteAPIProductOutput)
 	} else {
 		// Error deleting API Product
-		fmt.Println("Error getting OAuth tokens while deleting API Product:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while deleting API Product:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), deleteAPIProductOutput)
 	}
 }
 
@@ -89,4 +96,5 @@ func init() {
 	_ = DeleteAPIProductCmd.MarkFlagRequired("name")
 	_ = DeleteAPIProductCmd.MarkFlagRequired("version")
 	_ = DeleteAPIProductCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(DeleteAPIProductCmd.Flags(), &deleteAPIProductOutput)
 }
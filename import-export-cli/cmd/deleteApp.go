@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 
@@ -31,6 +32,7 @@ import (
 var deleteAppEnvironment string
 var deleteAppName string
 var deleteAppOwner string
+var deleteAppOutput string
 
 // DeleteApp command related usage info
 const deleteAppCmdLiteral = "app"
@@ -60,19 +62,25 @@ var DeleteAppCmd = &cobra.Command{
 
 // executeDeleteAppCmd executes the delete app command
 func executeDeleteAppCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(deleteAppOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypeApplication, Name: deleteAppName,
+		Environment: deleteAppEnvironment, Action: impl.ActionDelete}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteAppEnvironment)
 	if preCommandErr == nil {
 		if deleteAppOwner == "" {
 			deleteAppOwner = credential.Username
 		}
+		result.Owner = deleteAppOwner
 		resp, err := impl.DeleteApplication(accessToken, deleteAppEnvironment, deleteAppName, deleteAppOwner)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting Application ", err)
 		}
-		impl.PrintDeleteAppResponse(resp, err)
+		impl.PrintDeleteAppResponse(out, resp, err)
+		impl.PrintActionResult(result.Succeed(), deleteAppOutput)
 	} else {
 		// Error deleting Application
-		fmt.Println("Error getting OAuth tokens while deleting Application:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while deleting Application:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), deleteAppOutput)
 	}
 }
 
@@ -88,4 +96,5 @@ func init() {
 	// Mark required flags
 	_ = DeleteAppCmd.MarkFlagRequired("name")
 	_ = DeleteAppCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(DeleteAppCmd.Flags(), &deleteAppOutput)
 }
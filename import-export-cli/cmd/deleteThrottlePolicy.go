@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -30,6 +31,7 @@ import (
 var deleteThrottlingPolicyEnvironment string
 var deleteThrottlingPolicyName string
 var deleteThrottlingPolicyType string
+var deleteThrottlePolicyOutput string
 
 // DeleteThrottlingPolicy command related usage info
 const DeleteThrottlingPolicyCmdLiteral = "rate-limiting"
@@ -63,16 +65,21 @@ var DeleteThrottlingPolicyCmd = &cobra.Command{
 
 // executeDeleteThrottlingPolicyCmd executes the delete Throttling policy command
 func executeDeleteThrottlingPolicyCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(deleteThrottlePolicyOutput)
+	result := &impl.ActionResult{Type: utils.ProjectTypePolicy, Name: deleteThrottlingPolicyName,
+		Environment: deleteThrottlingPolicyEnvironment, Action: impl.ActionDelete}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, deleteThrottlingPolicyEnvironment)
 	if preCommandErr == nil {
 		_, err := impl.DeleteThrottlingPolicy(accessToken, deleteThrottlingPolicyName, deleteThrottlingPolicyType, deleteThrottlingPolicyEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while deleting Throttling Policy ", err)
 		}
-		impl.PrintDeleteThrottlingPolicyResponse(deleteThrottlingPolicyName, deleteThrottlingPolicyType, err)
+		impl.PrintDeleteThrottlingPolicyResponse(out, deleteThrottlingPolicyName, deleteThrottlingPolicyType, err)
+		impl.PrintActionResult(result.Succeed(), deleteThrottlePolicyOutput)
 	} else {
 		// Error deleting Throttling Policy
-		fmt.Println("Error getting OAuth tokens while deleting Throttling Policy:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while deleting Throttling Policy:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), deleteThrottlePolicyOutput)
 	}
 }
 
@@ -88,4 +95,5 @@ func init() {
 	_ = DeleteThrottlingPolicyCmd.MarkFlagRequired("name")
 	_ = DeleteThrottlingPolicyCmd.MarkFlagRequired("environment")
 	_ = DeleteThrottlingPolicyCmd.MarkFlagRequired("type")
+	formatter.AddOutputFlag(DeleteThrottlingPolicyCmd.Flags(), &deleteThrottlePolicyOutput)
 }
@@ -21,6 +21,7 @@ package deprecated
 import (
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -84,7 +85,10 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiZipLocationPath := filepath.Join(exportDirectory, cmd.CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteToZip(exportAPIName, exportAPIVersion, "", apiZipLocationPath, runningExportApiCommand, resp)
+			_, err = impl.WriteToZip(os.Stdout, exportAPIName, exportAPIVersion, "", apiZipLocationPath, resp)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while exporting", err)
+			}
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
 			fmt.Println(string(resp.Body()))
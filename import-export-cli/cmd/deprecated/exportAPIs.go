@@ -20,6 +20,7 @@ package deprecated
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
@@ -80,14 +81,16 @@ func executeExportAPIsCmd(credential credentials.Credential, exportDirectory str
 		startFromBeginning = true
 	}
 
-	if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName))) && !startFromBeginning {
-		impl.PrepareResumption(credential, exportRelatedFilesPath, cmd.CmdResourceTenantDomain, cmd.CmdUsername, cmd.CmdExportEnvironment)
+	if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportLedgerFileName)) ||
+		utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName))) && !startFromBeginning {
+		impl.PrepareResumption(os.Stdout, credential, exportRelatedFilesPath, cmd.CmdResourceTenantDomain, cmd.CmdUsername, cmd.CmdExportEnvironment)
 	} else {
-		impl.PrepareStartFromBeginning(credential, exportRelatedFilesPath, cmd.CmdResourceTenantDomain, cmd.CmdUsername, cmd.CmdExportEnvironment)
+		impl.PrepareStartFromBeginning(os.Stdout, credential, exportRelatedFilesPath, cmd.CmdResourceTenantDomain, cmd.CmdUsername, cmd.CmdExportEnvironment)
 	}
 
 	impl.ExportAPIs(credential, exportRelatedFilesPath, cmd.CmdExportEnvironment, cmd.CmdResourceTenantDomain, exportAPIsFormat, cmd.CmdUsername,
-		apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, false, false, false)
+		apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, false, false, false,
+		utils.DefaultBulkOperationOptions)
 }
 
 func init() {
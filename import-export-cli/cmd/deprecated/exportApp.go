@@ -21,6 +21,7 @@ package deprecated
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
@@ -79,7 +80,10 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
+			_, err = impl.WriteApplicationToZip(os.Stdout, exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
+			if err != nil {
+				utils.HandleErrorAndExit("Error exporting Application: "+exportAppName, err)
+			}
 		} else {
 			fmt.Println("Error " + string(resp.Body()))
 		}
@@ -62,7 +62,7 @@ var getKeysCmdDeprecated = &cobra.Command{
 			utils.HandleErrorAndExit("Internal error occurred", err)
 		}
 		utils.Logln(utils.LogPrefixInfo + "Called DCR endpoint successfully")
-		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint)
+		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint, "")
 	},
 }
 
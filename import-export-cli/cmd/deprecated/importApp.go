@@ -19,6 +19,8 @@
 package deprecated
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/cmd"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -69,7 +71,7 @@ func executeImportAppCmd(credential credentials.Credential) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
-	_, err = impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
+	_, err = impl.ImportApplicationToEnv(os.Stdout, accessToken, importAppEnvironment, importAppFile, importAppOwner,
 		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup)
 	if err != nil {
 		utils.HandleErrorAndExit("Error importing Application", err)
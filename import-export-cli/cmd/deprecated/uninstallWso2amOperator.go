@@ -75,10 +75,10 @@ var uninstallWso2amOperatorCmdDeprecated = &cobra.Command{
 			fmt.Printf("Removing namespace: %s\nThis operation will take some minutes...\n", k8sUtils.ApiOpWso2Namespace)
 
 			deleteErrors := []error{
-				k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sDelete, k8sUtils.Namespace, k8sUtils.ApiOpWso2Namespace),
-				k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sDelete, k8sUtils.ClusterRole, k8sUtils.Wso2amRole),
-				k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sDelete, k8sUtils.ClusterRoleBinding, k8sUtils.Wso2amRoleBinding),
-				k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sDelete, k8sUtils.CrdKind, k8sUtils.Wso2amOpCrdApimanager),
+				k8sUtils.K8sDeleteClusterResource(k8sUtils.Namespace, k8sUtils.ApiOpWso2Namespace),
+				k8sUtils.K8sDeleteClusterResource(k8sUtils.ClusterRole, k8sUtils.Wso2amRole),
+				k8sUtils.K8sDeleteClusterResource(k8sUtils.ClusterRoleBinding, k8sUtils.Wso2amRoleBinding),
+				k8sUtils.K8sDeleteClusterResource(k8sUtils.CrdKind, k8sUtils.Wso2amOpCrdApimanager),
 			}
 
 			for _, err := range deleteErrors {
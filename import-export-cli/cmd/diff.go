@@ -0,0 +1,51 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Diff command related usage Info
+const DiffCmdLiteral = "diff"
+const diffCmdShortDesc = "Compare an API between two environments or a project against an environment"
+
+const diffCmdLongDesc = `Compare an API deployed in the environment specified by flag (--from) with the one in the environment specified by flag (--to)
+Compare an API project specified by flag (--file, -f) with the API deployed in the environment specified by flag (--to)`
+
+const diffCmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from dev --to prod
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -f ./PizzaShackAPI --to prod`
+
+// DiffCmd represents the diff command
+var DiffCmd = &cobra.Command{
+	Use:     DiffCmdLiteral,
+	Short:   diffCmdShortDesc,
+	Long:    diffCmdLongDesc,
+	Example: diffCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(DiffCmd)
+}
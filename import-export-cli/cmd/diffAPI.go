@@ -0,0 +1,155 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var diffAPIName string
+var diffAPIVersion string
+var diffAPIProvider string
+var diffAPIFromEnvironment string
+var diffAPIToEnvironment string
+var diffAPIProjectPath string
+var diffAPIRevisionNum string
+var diffAPILatestRevision bool
+var diffAPIOutput string
+var diffAPIPatch bool
+var diffAPINoColor bool
+
+// DiffAPI command related usage info
+const DiffAPICmdLiteral = "api"
+const diffAPICmdShortDesc = "Compare an API between environments"
+
+const diffAPICmdLongDesc = `Compare an API deployed in one environment with the same API in another environment, or an API project
+with the API deployed in an environment. api.yaml, the API definition, endpoints, policies and deployment environments
+are compared field by field. The result can be printed as colored text, a unified patch or in a structured output format.`
+
+const diffAPICmdExamples = utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from dev --to prod
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 -r admin --from dev --to prod --latest -o json
+` + utils.ProjectName + ` ` + DiffCmdLiteral + ` ` + DiffAPICmdLiteral + ` -f ./PizzaShackAPI --to prod --patch
+NOTE: The flag (--to) is mandatory. Either the flags (--name (-n), --version (-v) and --from) or the flag (--file (-f)) should be provided.`
+
+// DiffAPICmd represents the diff api command
+var DiffAPICmd = &cobra.Command{
+	Use: DiffAPICmdLiteral + " ((--name <name-of-the-api> --version <version-of-the-api> --from <source-environment>) | " +
+		"--file <path-to-api-project>) --to <target-environment>",
+	Short:   diffAPICmdShortDesc,
+	Long:    diffAPICmdLongDesc,
+	Example: diffAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + DiffCmdLiteral + " " + DiffAPICmdLiteral + " called")
+		err := executeDiffAPICmd()
+		if err != nil {
+			utils.HandleErrorAndExit("Error while comparing API", err)
+		}
+	},
+}
+
+func executeDiffAPICmd() error {
+	if diffAPIPatch && diffAPIOutput != "" {
+		return errors.New("--patch and --output are mutually exclusive")
+	}
+	var from *impl.APIDiffSnapshot
+	var fromLabel string
+	var err error
+	if diffAPIProjectPath != "" {
+		if diffAPIFromEnvironment != "" {
+			return errors.New("--from and --file are mutually exclusive")
+		}
+		from, err = impl.LoadAPIDiffSnapshotFromProject(diffAPIProjectPath)
+		if err != nil {
+			return err
+		}
+		fromLabel = diffAPIProjectPath
+		if diffAPIName == "" {
+			diffAPIName = from.Name
+		}
+		if diffAPIVersion == "" {
+			diffAPIVersion = from.Version
+		}
+	} else {
+		if diffAPIName == "" || diffAPIVersion == "" || diffAPIFromEnvironment == "" {
+			return errors.New("--name, --version and --from are required when --file is not provided")
+		}
+		from, err = getAPIDiffSnapshotFromEnv(diffAPIFromEnvironment)
+		if err != nil {
+			return err
+		}
+		fromLabel = diffAPIFromEnvironment
+	}
+
+	to, err := getAPIDiffSnapshotFromEnv(diffAPIToEnvironment)
+	if err != nil {
+		return err
+	}
+
+	result := impl.DiffAPISnapshots(fromLabel, diffAPIToEnvironment, from, to)
+	colored := !diffAPINoColor && terminal.IsTerminal(int(os.Stdout.Fd()))
+	return impl.PrintAPIDiff(os.Stdout, result, diffAPIOutput, diffAPIPatch, colored)
+}
+
+func getAPIDiffSnapshotFromEnv(environment string) (*impl.APIDiffSnapshot, error) {
+	cred, err := GetCredentials(environment)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+	if err != nil {
+		return nil, err
+	}
+	return impl.LoadAPIDiffSnapshotFromEnv(accessToken, environment, diffAPIName, diffAPIVersion, diffAPIProvider,
+		diffAPIRevisionNum, diffAPILatestRevision)
+}
+
+// init using Cobra
+func init() {
+	DiffCmd.AddCommand(DiffAPICmd)
+	DiffAPICmd.Flags().StringVarP(&diffAPIName, "name", "n", "",
+		"Name of the API to be compared")
+	DiffAPICmd.Flags().StringVarP(&diffAPIVersion, "version", "v", "",
+		"Version of the API to be compared")
+	DiffAPICmd.Flags().StringVarP(&diffAPIProvider, "provider", "r", "",
+		"Provider of the API")
+	DiffAPICmd.Flags().StringVarP(&diffAPIFromEnvironment, "from", "", "",
+		"Environment to compare from")
+	DiffAPICmd.Flags().StringVarP(&diffAPIToEnvironment, "to", "", "",
+		"Environment to compare to")
+	DiffAPICmd.Flags().StringVarP(&diffAPIProjectPath, "file", "f", "",
+		"API project directory or archive to compare with the API in the target environment")
+	DiffAPICmd.Flags().StringVarP(&diffAPIRevisionNum, "rev", "", "",
+		"Revision number of the API to be compared in the environments")
+	DiffAPICmd.Flags().BoolVarP(&diffAPILatestRevision, "latest", "", false,
+		"Compare the latest revision of the API in the environments")
+	DiffAPICmd.Flags().BoolVarP(&diffAPIPatch, "patch", "", false,
+		"Print the differences as a unified patch")
+	DiffAPICmd.Flags().BoolVarP(&diffAPINoColor, "no-color", "", false,
+		"Disable colors in the text output")
+	formatter.AddOutputFlag(DiffAPICmd.Flags(), &diffAPIOutput)
+	_ = DiffAPICmd.MarkFlagRequired("to")
+}
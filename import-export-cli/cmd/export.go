@@ -30,7 +30,8 @@ const exportCmdShortDesc = "Export an API/API Product/Application/Policy in an e
 const exportCmdLongDesc = `Export an API available in the environment specified by flag (--environment, -e)
 Export APIs available in the environment specified by flag (--environment, -e)
 Export an API Product available in the environment specified by flag (--environment, -e)
-Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)`
+Export an Application of a specific user (--owner, -o) in the environment specified by flag (--environment, -e)
+Export a Key Manager, Gateway Environment, Shared Scope, the System Scopes, Tenant Config or Tenant Theme of the environment specified by flag (--environment, -e)`
 
 const exportCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPICmdLiteral + ` -n TwitterAPI -v 1.0.0 -r admin -e dev
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e dev
@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -41,6 +42,7 @@ var exportAPIFormat string
 var runningExportApiCommand bool
 var exportAPILatestRevision bool
 var exportAPIPreserveCredentials bool
+var exportAPIOutput string
 
 // ExportAPI command related usage info
 const ExportAPICmdLiteral = "api"
@@ -75,7 +77,10 @@ var ExportAPICmd = &cobra.Command{
 }
 
 func executeExportAPICmd(credential credentials.Credential, exportDirectory string) {
+	out := formatter.MessageWriter(exportAPIOutput)
 	runningExportApiCommand = true
+	result := &impl.ActionResult{Type: utils.ProjectTypeApi, Name: exportAPIName, Version: exportAPIVersion,
+		Owner: exportProvider, Revision: exportRevisionNum, Environment: CmdExportEnvironment, Action: impl.ActionExport}
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 
 	if preCommandErr == nil {
@@ -89,17 +94,25 @@ func executeExportAPICmd(credential credentials.Credential, exportDirectory stri
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteToZip(exportAPIName, exportAPIVersion, "", apiZipLocationPath, runningExportApiCommand, resp)
+			result.Path, err = impl.WriteToZip(out, exportAPIName, exportAPIVersion, "",
+				apiZipLocationPath, resp)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while exporting", err)
+			}
+			impl.PrintActionResult(result.Succeed(), exportAPIOutput)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
+			impl.PrintActionResult(result.Fail(string(resp.Body())), exportAPIOutput)
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error exporting API:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error exporting API:", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), exportAPIOutput)
 		}
 	} else {
 		// error exporting Api
-		fmt.Println("Error getting OAuth tokens while exporting API:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while exporting API:"+preCommandErr.Error())
+		impl.PrintActionResult(result.Fail(preCommandErr.Error()), exportAPIOutput)
 	}
 }
 
@@ -126,4 +139,5 @@ func init() {
 	_ = ExportAPICmd.MarkFlagRequired("name")
 	_ = ExportAPICmd.MarkFlagRequired("version")
 	_ = ExportAPICmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportAPICmd.Flags(), &exportAPIOutput)
 }
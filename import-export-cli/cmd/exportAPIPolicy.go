@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -35,6 +36,7 @@ import (
 var exportAPIPolicyName string
 var exportAPIPolicyVersion string
 var exportAPIPolicyFormat string
+var exportAPIPolicyOutput string
 
 // ExportAPIPolicy command related usage info
 const ExportAPIPolicyCmdLiteral = "api"
@@ -65,8 +67,11 @@ var ExportAPIPolicyCmd = &cobra.Command{
 }
 
 func executeExportAPIPolicyCmd(credential credentials.Credential, exportDirectory, exportAPIPolicyName string) {
+	out := formatter.MessageWriter(exportAPIPolicyOutput)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 	if preCommandErr == nil {
+		result := &impl.ActionResult{Type: utils.ProjectTypeAPIPolicy, Name: exportAPIPolicyName,
+			Version: exportAPIPolicyVersion, Environment: CmdExportEnvironment, Action: impl.ActionExport}
 		resp, err := impl.ExportAPIPolicyFromEnv(accessToken, CmdExportEnvironment, exportAPIPolicyName, exportAPIPolicyVersion, exportAPIPolicyFormat)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while exporting", err)
@@ -75,13 +80,16 @@ func executeExportAPIPolicyCmd(credential credentials.Credential, exportDirector
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiPolicyZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteAPIPolicyToFile(apiPolicyZipLocationPath, resp, exportAPIPolicyVersion, exportAPIPolicyName)
+			result.Path = impl.WriteAPIPolicyToFile(out, apiPolicyZipLocationPath, resp, exportAPIPolicyVersion,
+				exportAPIPolicyName)
+			impl.PrintActionResult(result.Succeed(), exportAPIPolicyOutput)
 		} else {
-			fmt.Println("Error exporting the API Policy:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error exporting the API Policy:", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), exportAPIPolicyOutput)
 		}
 	} else {
 		// error exporting API Policy
-		fmt.Println("Error getting OAuth tokens while exporting the API Policy:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while exporting the API Policy:"+preCommandErr.Error())
 	}
 }
 
@@ -99,4 +107,5 @@ func init() {
 	_ = ExportAPIPolicyCmd.MarkFlagRequired("name")
 	_ = ExportAPIPolicyCmd.MarkFlagRequired("version")
 	_ = ExportAPIPolicyCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportAPIPolicyCmd.Flags(), &exportAPIPolicyOutput)
 }
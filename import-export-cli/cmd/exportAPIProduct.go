@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -36,9 +37,9 @@ var exportAPIProductVersion string
 var exportAPIProductRevisionNum string
 var exportAPIProductProvider string
 var exportAPIProductFormat string
-var runningExportAPIProductCommand bool
 var exportAPIProductLatestRevision bool
 var exportAPIProductPreserveStatus bool
+var exportAPIProductOutput string
 
 // ExportAPIProduct command related usage info
 const ExportAPIProductCmdLiteral = "api-product"
@@ -71,13 +72,16 @@ var ExportAPIProductCmd = &cobra.Command{
 }
 
 func executeExportAPIProductCmd(credential credentials.Credential, exportDirectory string) {
-	runningExportAPIProductCommand = true
+	out := formatter.MessageWriter(exportAPIProductOutput)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 
 	if preCommandErr == nil {
 		if exportAPIProductVersion == "" {
 			exportAPIProductVersion = utils.DefaultApiProductVersion
 		}
+		result := &impl.ActionResult{Type: utils.ProjectTypeApiProduct, Name: exportAPIProductName,
+			Version: exportAPIProductVersion, Owner: exportAPIProductProvider, Revision: exportAPIProductRevisionNum,
+			Environment: CmdExportEnvironment, Action: impl.ActionExport}
 		resp, err := impl.ExportAPIProductFromEnv(accessToken, exportAPIProductName, exportAPIProductVersion,
 			exportAPIProductRevisionNum, exportAPIProductProvider, exportAPIProductFormat, CmdExportEnvironment,
 			exportAPIProductLatestRevision, exportAPIProductPreserveStatus)
@@ -88,17 +92,24 @@ func executeExportAPIProductCmd(credential credentials.Credential, exportDirecto
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		apiProductZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, apiProductZipLocationPath, runningExportAPIProductCommand, resp)
+			result.Path, err = impl.WriteAPIProductToZip(out,
+				exportAPIProductName, exportAPIProductVersion, apiProductZipLocationPath, resp)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while exporting", err)
+			}
+			impl.PrintActionResult(result.Succeed(), exportAPIProductOutput)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
+			impl.PrintActionResult(result.Fail(string(resp.Body())), exportAPIProductOutput)
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error exporting API Product:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error exporting API Product:", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), exportAPIProductOutput)
 		}
 	} else {
 		// error exporting API Product
-		fmt.Println("Error getting OAuth tokens while exporting API Product:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while exporting API Product:"+preCommandErr.Error())
 	}
 }
 
@@ -123,4 +134,5 @@ func init() {
 	_ = ExportAPIProductCmd.MarkFlagRequired("name")
 	_ = ExportAPIProductCmd.MarkFlagRequired("version")
 	_ = ExportAPIProductCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportAPIProductCmd.Flags(), &exportAPIProductOutput)
 }
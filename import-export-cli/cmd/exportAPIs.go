@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -35,10 +36,12 @@ const exportAPIsCmdLongDesc = "Export all the APIs of a tenant from one environm
 	"into another environment"
 const exportAPIsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAPIsCmdLiteral + ` -e production --parallel 8 --rate-limit 20
 NOTE: The flag (--environment (-e)) is mandatory`
 
 var exportAPIsFormat string
 var exportAPIsAllRevisions bool
+var exportAPIsBulkOptions utils.BulkOperationOptions
 
 //e.g. /home/samithac/.wso2apictl/exported/migration/production-2.5/wso2-dot-org
 var startFromBeginning bool
@@ -75,20 +78,22 @@ func executeExportAPIsCmd(credential credentials.Credential, exportDirectory str
 	startFromBeginning = false
 	isProcessCompleted = false
 
-	fmt.Println("\nExporting APIs for the migration...")
+	out := exportAPIsBulkOptions.MessageWriter()
+	fmt.Fprintln(out, "\nExporting APIs for the migration...")
 	if CmdForceStartFromBegin {
 		startFromBeginning = true
 	}
 
-	if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName))) && !startFromBeginning {
-		impl.PrepareResumption(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
+	if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportLedgerFileName)) ||
+		utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededApiFileName))) && !startFromBeginning {
+		impl.PrepareResumption(out, credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
 	} else {
-		impl.PrepareStartFromBeginning(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
+		impl.PrepareStartFromBeginning(out, credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
 	}
 
 	impl.ExportAPIs(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAPIsFormat,
 		CmdUsername, apiExportDir, exportAPIPreserveStatus, runningExportApiCommand, exportAPIsAllRevisions, false,
-		exportAPIPreserveCredentials)
+		exportAPIPreserveCredentials, exportAPIsBulkOptions)
 }
 
 func init() {
@@ -105,5 +110,12 @@ func init() {
 	ExportAPIsCmd.Flags().BoolVarP(&exportAPIsAllRevisions, "all", "", false,
 		"Export working copy and all revisions for the APIs in the environments ")
 	ExportAPIsCmd.Flags().StringVarP(&exportAPIsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archives(json or yaml)")
+	ExportAPIsCmd.Flags().IntVarP(&exportAPIsBulkOptions.Parallel, "parallel", "", 1,
+		"Number of APIs exported in parallel")
+	ExportAPIsCmd.Flags().Float64VarP(&exportAPIsBulkOptions.RateLimit, "rate-limit", "", 0,
+		"Maximum number of requests sent to the environment per second (0 for unlimited)")
+	ExportAPIsCmd.Flags().IntVarP(&exportAPIsBulkOptions.MaxRetries, "retries", "", utils.DefaultBulkMaxRetries,
+		"Number of times a failed export is retried")
 	_ = ExportAPIsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportAPIsCmd.Flags(), &exportAPIsBulkOptions.Output)
 }
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"path/filepath"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -34,6 +35,7 @@ var exportAppName string
 var exportAppOwner string
 var exportAppWithKeys bool
 var exportAppFormat string
+var exportAppOutput string
 
 // ExportApp command related usage info
 const ExportAppCmdLiteral = "app"
@@ -65,9 +67,12 @@ var ExportAppCmd = &cobra.Command{
 }
 
 func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryPath string) {
+	out := formatter.MessageWriter(exportAppOutput)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 
 	if preCommandErr == nil {
+		result := &impl.ActionResult{Type: utils.ProjectTypeApplication, Name: exportAppName, Owner: exportAppOwner,
+			Environment: CmdExportEnvironment, Action: impl.ActionExport}
 		resp, err := impl.ExportAppFromEnv(accessToken, exportAppName, exportAppOwner, exportAppFormat,
 			CmdExportEnvironment, exportAppWithKeys)
 		if err != nil {
@@ -77,13 +82,19 @@ func executeExportAppCmd(credential credentials.Credential, appsExportDirectoryP
 		// Print info on response
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteApplicationToZip(exportAppName, exportAppOwner, appsExportDirectoryPath, resp)
+			result.Path, err = impl.WriteApplicationToZip(out, exportAppName, exportAppOwner, appsExportDirectoryPath,
+				resp)
+			if err != nil {
+				utils.HandleErrorAndExit("Error exporting Application: "+exportAppName, err)
+			}
+			impl.PrintActionResult(result.Succeed(), exportAppOutput)
 		} else {
-			fmt.Println("Error " + string(resp.Body()))
+			fmt.Fprintln(out, "Error "+string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), exportAppOutput)
 		}
 	} else {
 		// error exporting Application
-		fmt.Println("Error exporting Application:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error exporting Application:"+preCommandErr.Error())
 	}
 }
 
@@ -102,4 +113,5 @@ func init() {
 	_ = ExportAppCmd.MarkFlagRequired("environment")
 	_ = ExportAppCmd.MarkFlagRequired("owner")
 	_ = ExportAppCmd.MarkFlagRequired("name")
+	formatter.AddOutputFlag(ExportAppCmd.Flags(), &exportAppOutput)
 }
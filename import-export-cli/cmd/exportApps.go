@@ -26,11 +26,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var exportAppsWithKeys bool
 var exportAppsFormat string
+var exportAppsBulkOptions utils.BulkOperationOptions
 var startFromBeginningForApps bool
 var isProcessCompletedForApps bool
 
@@ -42,6 +44,7 @@ const exportAppsCmdLongDesc = "Export Applications of a given tenant from a spec
 
 const exportAppsCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e dev --force
 ` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e prod
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportAppsCmdLiteral + ` -e prod --parallel 8 --rate-limit 20
 NOTE: The flag (--environment (-e)) is mandatory`
 
 // ExportAppsCmd represents the exportApps command
@@ -73,19 +76,21 @@ func executeExportAppsCmd(credential credentials.Credential, appsExportDirectory
     startFromBeginningForApps = false
     isProcessCompletedForApps = false
 
-    fmt.Println("\nExporting Applications...")
+    out := exportAppsBulkOptions.MessageWriter()
+    fmt.Fprintln(out, "\nExporting Applications...")
     if CmdForceStartFromBegin {
         startFromBeginningForApps = true
     }
 
-    if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededAppFileName))) && !startFromBeginningForApps {
-        impl.PrepareResumptionForApps(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
+    if (utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.MigrationAppsExportLedgerFileName)) ||
+        utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.LastSucceededAppFileName))) && !startFromBeginningForApps {
+        impl.PrepareResumptionForApps(out, credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
     } else {
-        impl.PrepareStartAppsFromBeginning(credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
+        impl.PrepareStartAppsFromBeginning(out, credential, exportRelatedFilesPath, CmdResourceTenantDomain, CmdUsername, CmdExportEnvironment)
     }
 
     impl.ExportApps(credential, exportRelatedFilesPath, CmdExportEnvironment, CmdResourceTenantDomain, exportAppsFormat,
-     CmdUsername, appExportDir, exportAppsWithKeys)
+     CmdUsername, appExportDir, exportAppsWithKeys, exportAppsBulkOptions)
 }
 
 // Init using Cobra
@@ -99,5 +104,12 @@ func init() {
 	ExportAppsCmd.Flags().BoolVarP(&exportAppsWithKeys, "with-keys", "",
 		false, "Export keys for the applications")
 	ExportAppsCmd.Flags().StringVarP(&exportAppsFormat, "format", "", utils.DefaultExportFormat, "File format of exported archive (json or yaml)")
+	ExportAppsCmd.Flags().IntVarP(&exportAppsBulkOptions.Parallel, "parallel", "", 1,
+		"Number of Apps exported in parallel")
+	ExportAppsCmd.Flags().Float64VarP(&exportAppsBulkOptions.RateLimit, "rate-limit", "", 0,
+		"Maximum number of requests sent to the environment per second (0 for unlimited)")
+	ExportAppsCmd.Flags().IntVarP(&exportAppsBulkOptions.MaxRetries, "retries", "", utils.DefaultBulkMaxRetries,
+		"Number of times a failed export is retried")
 	_ = ExportAppCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportAppsCmd.Flags(), &exportAppsBulkOptions.Output)
 }
@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	exportGatewayEnvironmentName   string
+	exportGatewayEnvironmentFormat string
+	exportGatewayEnvironmentOutput string
+)
+
+const (
+	// ExportGatewayEnvironmentCmdLiteral command related usage info
+	ExportGatewayEnvironmentCmdLiteral   = "gateway-environment"
+	exportGatewayEnvironmentCmdShortDesc = "Export Gateway Environment"
+	exportGatewayEnvironmentCmdLongDesc  = `Export a Gateway Environment from an environment. Read only Gateway Environments (the ones defined in the
+deployment.toml of the API Manager) are exported, but cannot be imported`
+)
+
+const exportGatewayEnvironmentCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportGatewayEnvironmentCmdLiteral + ` -n us-region -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportGatewayEnvironmentCmdLiteral + ` -n us-region -e prod --format JSON
+NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.`
+
+// ExportGatewayEnvironmentCmd represents the export gateway-environment command
+var ExportGatewayEnvironmentCmd = &cobra.Command{
+	Use:     "gateway-environment (--name <name-of-the-gateway-environment> --environment <environment-from-which-the-gateway-environment-should-be-exported>)",
+	Short:   exportGatewayEnvironmentCmdShortDesc,
+	Long:    exportGatewayEnvironmentCmdLongDesc,
+	Example: exportGatewayEnvironmentCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportGatewayEnvironmentCmdLiteral + " called")
+		executeExportAdminArtifactCmd(impl.AdminArtifactTypeGatewayEnvironment, exportGatewayEnvironmentName, CmdExportEnvironment,
+			utils.ExportedGatewayEnvironmentsDirName, exportGatewayEnvironmentFormat, exportGatewayEnvironmentOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportGatewayEnvironmentCmd)
+	ExportGatewayEnvironmentCmd.Flags().StringVarP(&exportGatewayEnvironmentName, "name", "n", "",
+		"Name of the Gateway Environment to be exported")
+	ExportGatewayEnvironmentCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e", "",
+		"Environment from which the Gateway Environment should be exported")
+	ExportGatewayEnvironmentCmd.Flags().StringVarP(&exportGatewayEnvironmentFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the exported Gateway Environment (JSON or YAML)")
+	_ = ExportGatewayEnvironmentCmd.MarkFlagRequired("name")
+	_ = ExportGatewayEnvironmentCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportGatewayEnvironmentCmd.Flags(), &exportGatewayEnvironmentOutput)
+}
@@ -0,0 +1,70 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	exportSharedScopeName   string
+	exportSharedScopeFormat string
+	exportSharedScopeOutput string
+)
+
+const (
+	// ExportSharedScopeCmdLiteral command related usage info
+	ExportSharedScopeCmdLiteral   = "shared-scope"
+	exportSharedScopeCmdShortDesc = "Export Shared Scope"
+	exportSharedScopeCmdLongDesc  = `Export a Shared Scope from an environment`
+)
+
+const exportSharedScopeCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportSharedScopeCmdLiteral + ` -n read_orders -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportSharedScopeCmdLiteral + ` -n read_orders -e prod --format JSON
+NOTE: Both the flags (--name (-n) and --environment (-e)) are mandatory.`
+
+// ExportSharedScopeCmd represents the export shared-scope command
+var ExportSharedScopeCmd = &cobra.Command{
+	Use:     "shared-scope (--name <name-of-the-shared-scope> --environment <environment-from-which-the-shared-scope-should-be-exported>)",
+	Short:   exportSharedScopeCmdShortDesc,
+	Long:    exportSharedScopeCmdLongDesc,
+	Example: exportSharedScopeCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportSharedScopeCmdLiteral + " called")
+		executeExportAdminArtifactCmd(impl.AdminArtifactTypeSharedScope, exportSharedScopeName, CmdExportEnvironment,
+			utils.ExportedScopesDirName, exportSharedScopeFormat, exportSharedScopeOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportSharedScopeCmd)
+	ExportSharedScopeCmd.Flags().StringVarP(&exportSharedScopeName, "name", "n", "",
+		"Name of the Shared Scope to be exported")
+	ExportSharedScopeCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e", "",
+		"Environment from which the Shared Scope should be exported")
+	ExportSharedScopeCmd.Flags().StringVarP(&exportSharedScopeFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the exported Shared Scope (JSON or YAML)")
+	_ = ExportSharedScopeCmd.MarkFlagRequired("name")
+	_ = ExportSharedScopeCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportSharedScopeCmd.Flags(), &exportSharedScopeOutput)
+}
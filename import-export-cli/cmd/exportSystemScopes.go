@@ -0,0 +1,66 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	exportSystemScopesFormat string
+	exportSystemScopesOutput string
+)
+
+const (
+	// ExportSystemScopesCmdLiteral command related usage info
+	ExportSystemScopesCmdLiteral   = "system-scopes"
+	exportSystemScopesCmdShortDesc = "Export System Scopes"
+	exportSystemScopesCmdLongDesc  = `Export the role mappings of the system scopes and the role aliases of an environment`
+)
+
+const exportSystemScopesCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportSystemScopesCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportSystemScopesCmdLiteral + ` -e prod --format JSON
+NOTE: The flag (--environment (-e)) is mandatory.`
+
+// ExportSystemScopesCmd represents the export system-scopes command
+var ExportSystemScopesCmd = &cobra.Command{
+	Use:     "system-scopes (--environment <environment-from-which-the-system-scopes-should-be-exported>)",
+	Short:   exportSystemScopesCmdShortDesc,
+	Long:    exportSystemScopesCmdLongDesc,
+	Example: exportSystemScopesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportSystemScopesCmdLiteral + " called")
+		executeExportAdminArtifactCmd(impl.AdminArtifactTypeSystemScopes, "", CmdExportEnvironment,
+			utils.ExportedScopesDirName, exportSystemScopesFormat, exportSystemScopesOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportSystemScopesCmd)
+	ExportSystemScopesCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e", "",
+		"Environment from which the System Scopes should be exported")
+	ExportSystemScopesCmd.Flags().StringVarP(&exportSystemScopesFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the exported System Scopes (JSON or YAML)")
+	_ = ExportSystemScopesCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportSystemScopesCmd.Flags(), &exportSystemScopesOutput)
+}
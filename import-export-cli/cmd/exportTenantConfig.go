@@ -0,0 +1,66 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	exportTenantConfigFormat string
+	exportTenantConfigOutput string
+)
+
+const (
+	// ExportTenantConfigCmdLiteral command related usage info
+	ExportTenantConfigCmdLiteral   = "tenant-config"
+	exportTenantConfigCmdShortDesc = "Export Tenant Config"
+	exportTenantConfigCmdLongDesc  = `Export the advanced tenant configuration (tenant-conf.json) of an environment`
+)
+
+const exportTenantConfigCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportTenantConfigCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportTenantConfigCmdLiteral + ` -e prod --format JSON
+NOTE: The flag (--environment (-e)) is mandatory.`
+
+// ExportTenantConfigCmd represents the export tenant-config command
+var ExportTenantConfigCmd = &cobra.Command{
+	Use:     "tenant-config (--environment <environment-from-which-the-tenant-config-should-be-exported>)",
+	Short:   exportTenantConfigCmdShortDesc,
+	Long:    exportTenantConfigCmdLongDesc,
+	Example: exportTenantConfigCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportTenantConfigCmdLiteral + " called")
+		executeExportAdminArtifactCmd(impl.AdminArtifactTypeTenantConfig, "", CmdExportEnvironment,
+			utils.ExportedTenantConfigDirName, exportTenantConfigFormat, exportTenantConfigOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportTenantConfigCmd)
+	ExportTenantConfigCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e", "",
+		"Environment from which the Tenant Config should be exported")
+	ExportTenantConfigCmd.Flags().StringVarP(&exportTenantConfigFormat, "format", "", utils.DefaultExportFormat,
+		"File format of the exported Tenant Config (JSON or YAML)")
+	_ = ExportTenantConfigCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportTenantConfigCmd.Flags(), &exportTenantConfigOutput)
+}
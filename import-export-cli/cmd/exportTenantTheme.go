@@ -0,0 +1,75 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var exportTenantThemeOutput string
+
+const (
+	// ExportTenantThemeCmdLiteral command related usage info
+	ExportTenantThemeCmdLiteral   = "tenant-theme"
+	exportTenantThemeCmdShortDesc = "Export Tenant Theme"
+	exportTenantThemeCmdLongDesc  = "Export the Developer Portal theme of the tenant of an environment as a zip archive"
+)
+
+const exportTenantThemeCmdExamples = utils.ProjectName + ` ` + ExportCmdLiteral + ` ` + ExportTenantThemeCmdLiteral + ` -e dev
+NOTE: The flag (--environment (-e)) is mandatory.`
+
+// ExportTenantThemeCmd represents the export tenant-theme command
+var ExportTenantThemeCmd = &cobra.Command{
+	Use:     ExportTenantThemeCmdLiteral + " (--environment <environment-from-which-the-tenant-theme-should-be-exported>)",
+	Short:   exportTenantThemeCmdShortDesc,
+	Long:    exportTenantThemeCmdLongDesc,
+	Example: exportTenantThemeCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ExportTenantThemeCmdLiteral + " called")
+		accessToken := getAdminArtifactAccessToken(CmdExportEnvironment)
+		result := &impl.ActionResult{Type: utils.ProjectTypeTenantTheme, Environment: CmdExportEnvironment,
+			Action: impl.ActionExport}
+		var err error
+		result.Path, err = impl.ExportTenantThemeFromEnv(accessToken, CmdExportEnvironment,
+			filepath.Join(utils.ExportDirectory, utils.ExportedTenantConfigDirName, CmdExportEnvironment))
+		if err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), exportTenantThemeOutput)
+			utils.HandleErrorAndExit("Error exporting "+result.Type, err)
+		}
+		if !impl.PrintActionResult(result.Succeed(), exportTenantThemeOutput) {
+			fmt.Println("Successfully exported " + result.Type + "!")
+			fmt.Println("Find the exported " + result.Type + " at " + result.Path)
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ExportCmd.AddCommand(ExportTenantThemeCmd)
+	ExportTenantThemeCmd.Flags().StringVarP(&CmdExportEnvironment, "environment", "e", "",
+		"Environment from which the Tenant Theme should be exported")
+	_ = ExportTenantThemeCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ExportTenantThemeCmd.Flags(), &exportTenantThemeOutput)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -35,7 +36,7 @@ import (
 var exportThrottlePolicyType string
 var exportThrottlePolicyName string
 var exportThrottlePolicyFormat string
-var runningExportThrottlePolicyCommand bool
+var exportThrottlePolicyOutput string
 
 // ExportThrottlePolicy command related usage info
 const ExportThrottlePolicyCmdLiteral = "rate-limiting"
@@ -69,9 +70,11 @@ var ExportThrottlePolicyCmd = &cobra.Command{
 }
 
 func executeExportThrottlePolicyCmd(credential credentials.Credential, exportDirectory string) {
-	runningExportThrottlePolicyCommand = true
+	out := formatter.MessageWriter(exportThrottlePolicyOutput)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, CmdExportEnvironment)
 	if preCommandErr == nil {
+		result := &impl.ActionResult{Type: utils.ProjectTypePolicy, Name: exportThrottlePolicyName,
+			Environment: CmdExportEnvironment, Action: impl.ActionExport}
 		resp, err := impl.ExportThrottlingPolicyFromEnv(accessToken, CmdExportEnvironment, exportThrottlePolicyName, exportThrottlePolicyType, exportThrottlePolicyFormat)
 		if err != nil {
 			utils.HandleErrorAndExit("Error while exporting", err)
@@ -80,17 +83,21 @@ func executeExportThrottlePolicyCmd(credential credentials.Credential, exportDir
 		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
 		throttlePolicyZipLocationPath := filepath.Join(exportDirectory, CmdExportEnvironment)
 		if resp.StatusCode() == http.StatusOK {
-			impl.WriteThrottlePolicyToFile(throttlePolicyZipLocationPath, resp, exportThrottlePolicyFormat, runningExportThrottlePolicyCommand)
+			result.Path = impl.WriteThrottlePolicyToFile(out,
+				throttlePolicyZipLocationPath, resp, exportThrottlePolicyFormat)
+			impl.PrintActionResult(result.Succeed(), exportThrottlePolicyOutput)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
+			impl.PrintActionResult(result.Fail(string(resp.Body())), exportThrottlePolicyOutput)
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error exporting Throttling Policies:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error exporting Throttling Policies:", resp.Status(), "\n", string(resp.Body()))
+			impl.PrintActionResult(result.Fail(resp.Status()), exportThrottlePolicyOutput)
 		}
 	} else {
 		// error exporting Throttling Policy
-		fmt.Println("Error getting OAuth tokens while exporting Throttling Policies:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while exporting Throttling Policies:"+preCommandErr.Error())
 	}
 }
 
@@ -107,4 +114,5 @@ func init() {
 	_ = ExportThrottlePolicyCmd.MarkFlagRequired("name")
 	_ = ExportThrottlePolicyCmd.MarkFlagRequired("environment")
 
+	formatter.AddOutputFlag(ExportThrottlePolicyCmd.Flags(), &exportThrottlePolicyOutput)
 }
@@ -25,12 +25,13 @@ import (
 
 // Get command related usage Info
 const GenCmdLiteral = "gen"
-const GenCmdShortDesc = "Generate deployment directory for VM and K8S operator"
+const GenCmdShortDesc = "Generate deployment directory for VM and K8S operator, or Kubernetes resources for APK"
 
 const GenCmdLongDesc = `Generate sample directory with all the contents to use as the deployment directory` +
 	`  when performing CI/CD pipeline tasks `
 
-const GenCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenDeploymentDirCmdLiteral
+const GenCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenDeploymentDirCmdLiteral + `
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenK8sArtifactsCmdLiteral + ` -s ~/PizzaShackAPI-1.0.0`
 
 // ListCmd represents the list command
 var GenCmd = &cobra.Command{
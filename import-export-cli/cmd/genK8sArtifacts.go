@@ -0,0 +1,87 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var genK8sArtifactsOptions impl.GenK8sArtifactsOptions
+
+const GenK8sArtifactsCmdLiteral = "k8s-artifacts"
+const GenK8sArtifactsCmdShortDesc = "Generate the Kubernetes resources of an API for APK"
+
+const GenK8sArtifactsCmdLongDesc = `Generate the Kubernetes resources (API, HTTPRoute, Backend, Authentication, APIPolicy, ` +
+	`Scope, RateLimitPolicy and ConfigMap) of an API project for WSO2 APK without connecting to APIM, APK or a ` +
+	`Kubernetes cluster. Each resource is written to a separate file along with a kustomization.yaml, so that the ` +
+	`resources can be reviewed in pull requests and deployed with kubectl or GitOps tools such as Argo CD.`
+
+const GenK8sArtifactsCmdExamples = utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenK8sArtifactsCmdLiteral + ` ` +
+	`-s ~/PizzaShackAPI-1.0.0
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenK8sArtifactsCmdLiteral + ` ` +
+	`-s ~/PizzaShackAPI_1.0.0.zip -d /home/gitops/apis/pizzashack --namespace apk
+` + utils.ProjectName + ` ` + GenCmdLiteral + ` ` + GenK8sArtifactsCmdLiteral + ` ` +
+	`-s ~/PizzaShackAPI-1.0.0 --params /home/deployment_repo/dev -e dev --vhost dev.gw.example.com`
+
+// genK8sArtifactsCmd represents the gen k8s-artifacts command
+var genK8sArtifactsCmd = &cobra.Command{
+	Use:     GenK8sArtifactsCmdLiteral,
+	Short:   GenK8sArtifactsCmdShortDesc,
+	Long:    GenK8sArtifactsCmdLongDesc,
+	Example: GenK8sArtifactsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GenK8sArtifactsCmdLiteral + " called")
+
+		artifacts, destination, err := impl.GenerateK8sArtifacts(genK8sArtifactsOptions)
+		if err != nil {
+			utils.HandleErrorAndExit("Error generating the Kubernetes resources", err)
+		}
+		fmt.Printf("Generated %d Kubernetes resources of the API in %s\n", len(artifacts.Resources), destination)
+	},
+}
+
+func init() {
+	GenCmd.AddCommand(genK8sArtifactsCmd)
+	flags := genK8sArtifactsCmd.Flags()
+	flags.StringVarP(&genK8sArtifactsOptions.Source, "source", "s", "",
+		"Path of the API project directory or archive")
+	flags.StringVarP(&genK8sArtifactsOptions.Destination, "destination", "d", "",
+		"Path of the directory where the resources should be generated (default \"<API name>-<version>-k8s\")")
+	flags.StringVarP(&genK8sArtifactsOptions.ParamsFile, "params", "", "",
+		"Params file or deployment directory applied to the API as it is applied when importing the API")
+	flags.StringVarP(&genK8sArtifactsOptions.Environment, "environment", "e", "",
+		"Environment of the params file to apply")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.Organization, "organization", "", "",
+		"Organization of the API (default \"default\")")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.Namespace, "namespace", "n", "",
+		"Namespace of the resources")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.ProductionVhost, "vhost", "", "",
+		"Hostname of the production routes (default \"<organization>.gw.wso2.com\")")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.SandboxVhost, "sandbox-vhost", "", "",
+		"Hostname of the sandbox routes (default \"<organization>.sandbox.gw.wso2.com\")")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.GatewayName, "gateway", "", "",
+		"Name of the gateway the routes are attached to (default \"wso2-apk-default\")")
+	flags.StringVarP(&genK8sArtifactsOptions.Resources.GatewayListener, "gateway-listener", "", "",
+		"Listener of the gateway the routes are attached to (default \"httpslistener\")")
+	_ = genK8sArtifactsCmd.MarkFlagRequired("source")
+}
@@ -21,6 +21,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -84,4 +85,5 @@ func init() {
 	getApiLoggingCmd.Flags().StringVarP(&getAPILoggingCmdFormat, "format", "", "", "Pretty-print API loggers "+
 		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getApiLoggingCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getApiLoggingCmd.Flags(), &getAPILoggingCmdFormat)
 }
@@ -24,6 +24,7 @@ import (
 	"strconv"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -55,6 +56,7 @@ var getAPIPoliciesCmd = &cobra.Command{
 	Example: getAPIPoliciesCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + GetAPIPoliciesCmdLiteral + " called")
+		getAPIPoliciesCmdFormat = resolvePoliciesFormat(getAPIPoliciesCmdFormat)
 		cred, err := GetCredentials(getAPIPoliciesCmdEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -65,7 +67,7 @@ var getAPIPoliciesCmd = &cobra.Command{
 		} else {
 			limit, err := utils.ValidateFlagWithIntegerValues(getAPIPolicyListCmdLimit)
 			if limit < 0 {
-				fmt.Println("Limit value should be greater than 0")
+				fmt.Fprintln(formatter.MessageWriter(getAPIPoliciesCmdFormat), "Limit value should be greater than 0")
 			} else if err != nil {
 				utils.HandleErrorAndExit("Error converting limit value", err)
 			}
@@ -76,6 +78,7 @@ var getAPIPoliciesCmd = &cobra.Command{
 }
 
 func executeGetAPIPoliciesCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(getAPIPoliciesCmdFormat)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, getAPIPoliciesCmdEnvironment)
 
 	if preCommandErr == nil {
@@ -89,10 +92,10 @@ func executeGetAPIPoliciesCmd(credential credentials.Credential) {
 			impl.PrintAPIPolicies(resp, getAPIPoliciesCmdFormat)
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error getting API Policies:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error getting API Policies:", resp.Status(), "\n", string(resp.Body()))
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens while getting API Policies:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while getting API Policies:"+preCommandErr.Error())
 	}
 }
 
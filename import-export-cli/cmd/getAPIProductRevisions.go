@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -96,4 +97,5 @@ func init() {
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("name")
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("version")
 	_ = getAPIProductRevisionsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getAPIProductRevisionsCmd.Flags(), &getAPIProductRevisionsCmdFormat)
 }
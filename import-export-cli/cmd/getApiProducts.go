@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -95,4 +96,5 @@ func init() {
 	getApiProductsCmd.Flags().StringVarP(&getApiProductsCmdFormat, "format", "", "", "Pretty-print API Products "+
 		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getApiProductsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getApiProductsCmd.Flags(), &getApiProductsCmdFormat)
 }
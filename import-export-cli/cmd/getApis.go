@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/spf13/cobra"
@@ -45,6 +46,8 @@ var getApisCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApis
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -q provider:admin -q version:1.0.0
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e prod -l 100
 ` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e staging
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev -o json
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetApisCmdLiteral + ` -e dev -o jsonpath='{range [*]}{.id}{"\t"}{.name}{"\n"}{end}'
 NOTE: The flag (--environment (-e)) is mandatory`
 
 // getApisCmd represents the apis command
@@ -92,4 +95,5 @@ func init() {
 	getApisCmd.Flags().StringVarP(&getApisCmdFormat, "format", "", "", "Pretty-print apis "+
 		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getApisCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getApisCmd.Flags(), &getApisCmdFormat)
 }
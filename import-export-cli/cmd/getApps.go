@@ -21,6 +21,7 @@ package cmd
 import (
 	"strconv"
 
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -95,4 +96,5 @@ func init() {
 	getAppsCmd.Flags().StringVarP(&getAppsCmdFormat, "format", "", "", "Pretty-print output"+
 		"using Go templates. Use \"{{jsonPretty .}}\" to list all fields")
 	_ = getAppsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getAppsCmd.Flags(), &getAppsCmdFormat)
 }
@@ -21,6 +21,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -69,4 +70,5 @@ func init() {
 	getCorrelationLoggingCmd.Flags().StringVarP(&getCorrelationLoggingCmdFormat, "format", "", "",
 		"Pretty-print correlation logging components using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
 	_ = getCorrelationLoggingCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getCorrelationLoggingCmd.Flags(), &getCorrelationLoggingCmdFormat)
 }
@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getDeploymentsAPIName string
+var getDeploymentsAPIVersion string
+var getDeploymentsAPIProvider string
+var getDeploymentsCmdEnvironment string
+var getDeploymentsCmdFormat string
+
+// GetDeploymentsCmd related info
+const GetDeploymentsCmdLiteral = "deployments"
+const getDeploymentsCmdShortDesc = "Display the gateway deployments of the revisions of an API"
+
+const getDeploymentsCmdLongDesc = `Display the gateway environments each revision of the API specified by the flag --api
+is deployed to, in the environment specified by the flag --environment, -e`
+
+var getDeploymentsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetDeploymentsCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetDeploymentsCmdLiteral + ` --api TwitterAPI -v 1.0.0 -r admin -e dev -o yaml
+NOTE: All the 3 flags (--api, --version (-v) and --environment (-e)) are mandatory.`
+
+// getDeploymentsCmd represents the deployments command
+var getDeploymentsCmd = &cobra.Command{
+	Use:     GetDeploymentsCmdLiteral,
+	Short:   getDeploymentsCmdShortDesc,
+	Long:    getDeploymentsCmdLongDesc,
+	Example: getDeploymentsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetDeploymentsCmdLiteral + " called")
+		cred, err := GetCredentials(getDeploymentsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetDeploymentsCmd(cred)
+	},
+}
+
+func executeGetDeploymentsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getDeploymentsCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get deployments' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetDeploymentsCmdLiteral+"'", err)
+	}
+
+	deployments, err := impl.GetDeploymentListFromEnv(accessToken, getDeploymentsCmdEnvironment,
+		getDeploymentsAPIName, getDeploymentsAPIVersion, getDeploymentsAPIProvider)
+	if err == nil {
+		impl.PrintDeployments(deployments, getDeploymentsCmdFormat)
+	} else {
+		utils.Logln(utils.LogPrefixError+"Getting List of Deployments", err)
+		utils.HandleErrorAndExit("Error getting the list of deployments.", err)
+	}
+}
+
+func init() {
+	GetCmd.AddCommand(getDeploymentsCmd)
+
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIName, "api", "",
+		"", "Name of the API to list the deployments of")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIVersion, "version", "v",
+		"", "Version of the API")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsAPIProvider, "provider", "r",
+		"", "Provider of the API")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getDeploymentsCmd.Flags().StringVarP(&getDeploymentsCmdFormat, "format", "", "", "Pretty-print deployments "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = getDeploymentsCmd.MarkFlagRequired("api")
+	_ = getDeploymentsCmd.MarkFlagRequired("version")
+	_ = getDeploymentsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getDeploymentsCmd.Flags(), &getDeploymentsCmdFormat)
+}
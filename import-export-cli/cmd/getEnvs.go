@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -53,4 +54,5 @@ func init() {
 	GetCmd.AddCommand(getEnvsCmd)
 	getEnvsCmd.Flags().StringVarP(&envsCmdFormat, "format", "", defaulEnvsTableFormat, "Pretty-print "+
 		"environments using go templates")
+	formatter.AddOutputFlag(getEnvsCmd.Flags(), &envsCmdFormat)
 }
@@ -0,0 +1,86 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getGatewayEnvironmentsCmdEnvironment string
+var getGatewayEnvironmentsCmdFormat string
+
+// GetGatewayEnvironmentsCmd related info
+const GetGatewayEnvironmentsCmdLiteral = "gateway-environments"
+const getGatewayEnvironmentsCmdShortDesc = "Display a list of gateway environments in an environment"
+
+const getGatewayEnvironmentsCmdLongDesc = `Display a list of gateway environments, including the read-only ones
+defined in deployment.toml, in the environment specified by the flag --environment, -e`
+
+var getGatewayEnvironmentsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetGatewayEnvironmentsCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetGatewayEnvironmentsCmdLiteral + ` -e dev -o jsonpath='{range [*]}{.name}{"\n"}{end}'
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// getGatewayEnvironmentsCmd represents the gateway-environments command
+var getGatewayEnvironmentsCmd = &cobra.Command{
+	Use:     GetGatewayEnvironmentsCmdLiteral,
+	Short:   getGatewayEnvironmentsCmdShortDesc,
+	Long:    getGatewayEnvironmentsCmdLongDesc,
+	Example: getGatewayEnvironmentsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetGatewayEnvironmentsCmdLiteral + " called")
+		cred, err := GetCredentials(getGatewayEnvironmentsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetGatewayEnvironmentsCmd(cred)
+	},
+}
+
+func executeGetGatewayEnvironmentsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getGatewayEnvironmentsCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get gateway-environments' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetGatewayEnvironmentsCmdLiteral+"'", err)
+	}
+
+	_, gatewayEnvironments, err := impl.GetGatewayEnvironmentListFromEnv(accessToken,
+		getGatewayEnvironmentsCmdEnvironment)
+	if err == nil {
+		impl.PrintGatewayEnvironments(gatewayEnvironments, getGatewayEnvironmentsCmdFormat)
+	} else {
+		utils.Logln(utils.LogPrefixError+"Getting List of Gateway Environments", err)
+		utils.HandleErrorAndExit("Error getting the list of gateway environments.", err)
+	}
+}
+
+func init() {
+	GetCmd.AddCommand(getGatewayEnvironmentsCmd)
+
+	getGatewayEnvironmentsCmd.Flags().StringVarP(&getGatewayEnvironmentsCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getGatewayEnvironmentsCmd.Flags().StringVarP(&getGatewayEnvironmentsCmdFormat, "format", "", "",
+		"Pretty-print gateway environments using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = getGatewayEnvironmentsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getGatewayEnvironmentsCmd.Flags(), &getGatewayEnvironmentsCmdFormat)
+}
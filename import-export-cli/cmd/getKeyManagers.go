@@ -0,0 +1,84 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getKeyManagersCmdEnvironment string
+var getKeyManagersCmdFormat string
+
+// GetKeyManagersCmd related info
+const GetKeyManagersCmdLiteral = "key-managers"
+const getKeyManagersCmdShortDesc = "Display a list of key managers in an environment"
+
+const getKeyManagersCmdLongDesc = `Display a list of key managers in the environment specified by the flag --environment, -e`
+
+var getKeyManagersCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetKeyManagersCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetKeyManagersCmdLiteral + ` -e dev -o json
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// getKeyManagersCmd represents the key-managers command
+var getKeyManagersCmd = &cobra.Command{
+	Use:     GetKeyManagersCmdLiteral,
+	Short:   getKeyManagersCmdShortDesc,
+	Long:    getKeyManagersCmdLongDesc,
+	Example: getKeyManagersCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetKeyManagersCmdLiteral + " called")
+		cred, err := GetCredentials(getKeyManagersCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetKeyManagersCmd(cred)
+	},
+}
+
+func executeGetKeyManagersCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getKeyManagersCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get key-managers' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetKeyManagersCmdLiteral+"'", err)
+	}
+
+	_, keyManagers, err := impl.GetKeyManagerListFromEnv(accessToken, getKeyManagersCmdEnvironment)
+	if err == nil {
+		impl.PrintKeyManagers(keyManagers, getKeyManagersCmdFormat)
+	} else {
+		utils.Logln(utils.LogPrefixError+"Getting List of Key Managers", err)
+		utils.HandleErrorAndExit("Error getting the list of key managers.", err)
+	}
+}
+
+func init() {
+	GetCmd.AddCommand(getKeyManagersCmd)
+
+	getKeyManagersCmd.Flags().StringVarP(&getKeyManagersCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getKeyManagersCmd.Flags().StringVarP(&getKeyManagersCmdFormat, "format", "", "", "Pretty-print key managers "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = getKeyManagersCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getKeyManagersCmd.Flags(), &getKeyManagersCmdFormat)
+}
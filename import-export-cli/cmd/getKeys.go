@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -37,6 +38,7 @@ var apiName string
 var apiVersion string
 var apiProvider string
 var keyGenTokenEndpoint string
+var getKeysOutput string
 
 var getKeysCmd = &cobra.Command{
 	Use:     GetKeysCmdLiteral,
@@ -57,7 +59,7 @@ var getKeysCmd = &cobra.Command{
 			utils.HandleErrorAndExit("Internal error occurred", err)
 		}
 		utils.Logln(utils.LogPrefixInfo + "Called DCR endpoint successfully")
-		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint)
+		impl.GetKeys(cred, keyGenEnv, apiName, apiVersion, apiProvider, keyGenTokenEndpoint, getKeysOutput)
 	},
 }
 
@@ -71,4 +73,5 @@ func init() {
 	getKeysCmd.Flags().StringVarP(&keyGenTokenEndpoint, "token", "t", "", "Token endpoint URL of Environment")
 	_ = getKeysCmd.MarkFlagRequired("name")
 	_ = getKeysCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getKeysCmd.Flags(), &getKeysOutput)
 }
@@ -20,6 +20,7 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -29,6 +30,9 @@ const GetPoliciesCmdLongDesc = "Get a list of Policies in an environment"
 const GetPoliciesCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetPoliciesCmdLiteral + ` ` + GetThrottlePoliciesCmdLiteral + ` -e production -q type:sub`
 const GetAPIPoliciesCmdExample = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetPoliciesCmdLiteral + ` ` + GetAPIPoliciesCmdLiteral + ` -e production`
 
+// getPoliciesOutput is the --output flag shared by the get policies commands
+var getPoliciesOutput string
+
 // GetPoliciesCmd  represents the get command for policies
 var GetPoliciesCmd = &cobra.Command{
 	Use:     GetPoliciesCmdLiteral,
@@ -43,4 +47,14 @@ var GetPoliciesCmd = &cobra.Command{
 // init using Cobra
 func init() {
 	GetCmd.AddCommand(GetPoliciesCmd)
+	formatter.AddOutputFlag(GetPoliciesCmd.PersistentFlags(), &getPoliciesOutput)
+}
+
+// resolvePoliciesFormat returns the format a get policies command prints the policies in, which is the --output
+// format if given and format (the --format of the command) otherwise
+func resolvePoliciesFormat(format string) string {
+	if getPoliciesOutput != "" {
+		return getPoliciesOutput
+	}
+	return format
 }
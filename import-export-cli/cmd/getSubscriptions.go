@@ -0,0 +1,126 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getSubscriptionsCmdEnvironment string
+var getSubscriptionsCmdFormat string
+var getSubscriptionsCmdLimit string
+var getSubscriptionsCmdOffset string
+var getSubscriptionsAPIName string
+var getSubscriptionsAPIVersion string
+var getSubscriptionsAPIProvider string
+var getSubscriptionsAppName string
+var getSubscriptionsAppOwner string
+
+// GetSubscriptionsCmd related info
+const GetSubscriptionsCmdLiteral = "subscriptions"
+const getSubscriptionsCmdShortDesc = "Display a list of subscriptions of an API or an Application"
+
+const getSubscriptionsCmdLongDesc = `Display a list of subscriptions of the API specified by the flag --api, or of the
+Application specified by the flag --app, in the environment specified by the flag --environment, -e`
+
+var getSubscriptionsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -r admin -e dev -l 50 --offset 50
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --app SampleApp -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetSubscriptionsCmdLiteral + ` --app SampleApp --owner sampleUser -e dev -o json
+NOTE: The flag (--environment (-e)) and exactly one of the flags (--api or --app) are mandatory.
+The flag (--version (-v)) is mandatory with --api.`
+
+// getSubscriptionsCmd represents the subscriptions command
+var getSubscriptionsCmd = &cobra.Command{
+	Use:     GetSubscriptionsCmdLiteral,
+	Short:   getSubscriptionsCmdShortDesc,
+	Long:    getSubscriptionsCmdLongDesc,
+	Example: getSubscriptionsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetSubscriptionsCmdLiteral + " called")
+		if (getSubscriptionsAPIName == "") == (getSubscriptionsAppName == "") {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("exactly one of the flags --api or --app is required"))
+		}
+		if getSubscriptionsAPIName != "" && getSubscriptionsAPIVersion == "" {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("the flag --version is required with --api"))
+		}
+		cred, err := GetCredentials(getSubscriptionsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetSubscriptionsCmd(cred)
+	},
+}
+
+func executeGetSubscriptionsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getSubscriptionsCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get subscriptions' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetSubscriptionsCmdLiteral+"'", err)
+	}
+
+	var subscriptions []utils.SubscriptionEntry
+	if getSubscriptionsAPIName != "" {
+		_, subscriptions, err = impl.GetAPISubscriptionListFromEnv(accessToken, getSubscriptionsCmdEnvironment,
+			getSubscriptionsAPIName, getSubscriptionsAPIVersion, getSubscriptionsAPIProvider,
+			getSubscriptionsCmdLimit, getSubscriptionsCmdOffset)
+	} else {
+		_, subscriptions, err = impl.GetApplicationSubscriptionListFromEnv(accessToken, getSubscriptionsCmdEnvironment,
+			getSubscriptionsAppName, getSubscriptionsAppOwner, getSubscriptionsCmdLimit, getSubscriptionsCmdOffset)
+	}
+	if err == nil {
+		impl.PrintSubscriptions(subscriptions, getSubscriptionsCmdFormat)
+	} else {
+		utils.Logln(utils.LogPrefixError+"Getting List of Subscriptions", err)
+		utils.HandleErrorAndExit("Error getting the list of subscriptions.", err)
+	}
+}
+
+func init() {
+	GetCmd.AddCommand(getSubscriptionsCmd)
+
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsAPIName, "api", "",
+		"", "Name of the API to list the subscriptions of")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsAPIVersion, "version", "v",
+		"", "Version of the API")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsAPIProvider, "provider", "r",
+		"", "Provider of the API")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsAppName, "app", "",
+		"", "Name of the Application to list the subscriptions of")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsAppOwner, "owner", "",
+		"", "Owner of the Application")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdLimit, "limit", "l",
+		strconv.Itoa(utils.DefaultSubscriptionsDisplayLimit), "Maximum number of subscriptions to return")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdOffset, "offset", "",
+		"", "Index of the first subscription to return")
+	getSubscriptionsCmd.Flags().StringVarP(&getSubscriptionsCmdFormat, "format", "", "", "Pretty-print subscriptions "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = getSubscriptionsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getSubscriptionsCmd.Flags(), &getSubscriptionsCmdFormat)
+}
@@ -0,0 +1,98 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var getTenantsCmdEnvironment string
+var getTenantsCmdFormat string
+var getTenantsCmdState string
+var getTenantsCmdLimit string
+var getTenantsCmdOffset string
+
+// GetTenantsCmd related info
+const GetTenantsCmdLiteral = "tenants"
+const getTenantsCmdShortDesc = "Display a list of tenants in an environment"
+
+const getTenantsCmdLongDesc = `Display a list of tenants in the environment specified by the flag --environment, -e.
+Listing tenants requires a super tenant admin user.`
+
+var getTenantsCmdExamples = utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetTenantsCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetTenantsCmdLiteral + ` -e dev --state inactive
+` + utils.ProjectName + ` ` + GetCmdLiteral + ` ` + GetTenantsCmdLiteral + ` -e prod -l 100 --offset 100
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// getTenantsCmd represents the tenants command
+var getTenantsCmd = &cobra.Command{
+	Use:     GetTenantsCmdLiteral,
+	Short:   getTenantsCmdShortDesc,
+	Long:    getTenantsCmdLongDesc,
+	Example: getTenantsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + GetTenantsCmdLiteral + " called")
+		cred, err := GetCredentials(getTenantsCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeGetTenantsCmd(cred)
+	},
+}
+
+func executeGetTenantsCmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, getTenantsCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'get tenants' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+GetTenantsCmdLiteral+"'", err)
+	}
+
+	_, tenants, err := impl.GetTenantListFromEnv(accessToken, getTenantsCmdEnvironment, getTenantsCmdState,
+		getTenantsCmdLimit, getTenantsCmdOffset)
+	if err == nil {
+		impl.PrintTenants(tenants, getTenantsCmdFormat)
+	} else {
+		utils.Logln(utils.LogPrefixError+"Getting List of Tenants", err)
+		utils.HandleErrorAndExit("Error getting the list of tenants.", err)
+	}
+}
+
+func init() {
+	GetCmd.AddCommand(getTenantsCmd)
+
+	getTenantsCmd.Flags().StringVarP(&getTenantsCmdEnvironment, "environment", "e",
+		"", "Environment to be searched")
+	getTenantsCmd.Flags().StringVarP(&getTenantsCmdState, "state", "",
+		"active", "State of the tenants to list (active or inactive)")
+	getTenantsCmd.Flags().StringVarP(&getTenantsCmdLimit, "limit", "l",
+		strconv.Itoa(utils.DefaultTenantsDisplayLimit), "Maximum number of tenants to return")
+	getTenantsCmd.Flags().StringVarP(&getTenantsCmdOffset, "offset", "",
+		"", "Index of the first tenant to return")
+	getTenantsCmd.Flags().StringVarP(&getTenantsCmdFormat, "format", "", "", "Pretty-print tenants "+
+		"using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	_ = getTenantsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(getTenantsCmd.Flags(), &getTenantsCmdFormat)
+}
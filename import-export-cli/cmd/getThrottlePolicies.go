@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"net/http"
 	"strings"
@@ -53,6 +54,7 @@ var getThrottlePoliciesCmd = &cobra.Command{
 	Example: getThrottlePoliciesCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + GetThrottlePoliciesCmdLiteral + " called")
+		getThrottlePoliciesCmdFormat = resolvePoliciesFormat(getThrottlePoliciesCmdFormat)
 		cred, err := GetCredentials(getThrottlePoliciesCmdEnvironment)
 		if err != nil {
 			utils.HandleErrorAndExit("Error getting credentials", err)
@@ -62,6 +64,7 @@ var getThrottlePoliciesCmd = &cobra.Command{
 }
 
 func executeGetThrottlePoliciesCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(getThrottlePoliciesCmdFormat)
 	accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, getThrottlePoliciesCmdEnvironment)
 	if preCommandErr == nil {
 		resp, err := impl.GetThrottlePolicyListFromEnv(accessToken, getThrottlePoliciesCmdEnvironment,
@@ -75,13 +78,13 @@ func executeGetThrottlePoliciesCmd(credential credentials.Credential) {
 			impl.PrintThrottlePolicies(resp, getThrottlePoliciesCmdFormat)
 		} else if resp.StatusCode() == http.StatusInternalServerError {
 			// 500 Internal Server Error
-			fmt.Println(string(resp.Body()))
+			fmt.Fprintln(out, string(resp.Body()))
 		} else {
 			// neither 200 nor 500
-			fmt.Println("Error getting Throttling Policies:", resp.Status(), "\n", string(resp.Body()))
+			fmt.Fprintln(out, "Error getting Throttling Policies:", resp.Status(), "\n", string(resp.Body()))
 		}
 	} else {
-		fmt.Println("Error getting OAuth tokens while getting Throttling Policies:" + preCommandErr.Error())
+		fmt.Fprintln(out, "Error getting OAuth tokens while getting Throttling Policies:"+preCommandErr.Error())
 	}
 }
 
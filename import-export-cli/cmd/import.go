@@ -29,7 +29,9 @@ const importCmdShortDesc = "Import an API/API Product/Application to an environm
 
 const importCmdLongDesc = `Import an API to the environment specified by flag (--environment, -e)
 Import an API Product to the environment specified by flag (--environment, -e)
-Import an Application to the environment specified by flag (--environment, -e)`
+Import an Application to the environment specified by flag (--environment, -e)
+Import a Key Manager, Gateway Environment, Shared Scope, the System Scopes, Tenant Config or Tenant Theme to the environment specified by flag (--environment, -e)
+Create the deferred subscriptions of the Applications imported to the environment specified by flag (--environment, -e)`
 
 const importCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPICmdLiteral + ` -f qa/TwitterAPI.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + importAPIProductCmdLiteral + ` -f qa/LeasingAPIProduct.zip -e dev
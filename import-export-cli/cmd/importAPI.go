@@ -21,6 +21,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -36,6 +37,7 @@ var (
 	importAPISkipDeployments     bool
 	dryRun                       bool
 	apiLoggingCmdFormat          string
+	importAPIOutput              string
 )
 
 const (
@@ -68,13 +70,16 @@ var ImportAPICmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API", err)
 		}
-		err = impl.ImportAPIToEnv(accessOAuthToken, importEnvironment, importAPIFile, importAPIParamsFile, importAPIUpdate,
-			importAPICmdPreserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments, dryRun,
-			apiLoggingCmdFormat)
+		err = impl.ImportAPIToEnv(formatter.MessageWriter(importAPIOutput), accessOAuthToken, importEnvironment,
+			importAPIFile, importAPIParamsFile, importAPIUpdate, importAPICmdPreserveProvider, importAPISkipCleanup,
+			importAPIRotateRevision, importAPISkipDeployments, dryRun, apiLoggingCmdFormat)
+		result := impl.NewImportResult(utils.ProjectTypeApi, importEnvironment, importAPIFile, utils.MetaFileAPI)
 		if err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), importAPIOutput)
 			utils.HandleErrorAndExit("Error importing API", err)
 			return
 		}
+		impl.PrintActionResult(result.Succeed(), importAPIOutput)
 	},
 }
 
@@ -104,4 +109,5 @@ func init() {
 	// Mark required flags
 	_ = ImportAPICmd.MarkFlagRequired("environment")
 	_ = ImportAPICmd.MarkFlagRequired("file")
+	formatter.AddOutputFlag(ImportAPICmd.Flags(), &importAPIOutput)
 }
@@ -21,12 +21,14 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var (
-	importAPIPolicyFile string
+	importAPIPolicyFile   string
+	importAPIPolicyOutput string
 )
 
 const (
@@ -56,10 +58,14 @@ var ImportAPIPolicyCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API Policy", err)
 		}
-		err = impl.ImportAPIPolicyToEnv(accessOAuthToken, importEnvironment, importAPIPolicyFile)
+		err = impl.ImportAPIPolicyToEnv(formatter.MessageWriter(importAPIPolicyOutput), accessOAuthToken,
+			importEnvironment, importAPIPolicyFile)
+		result := impl.NewImportResult(utils.ProjectTypeAPIPolicy, importEnvironment, importAPIPolicyFile, "")
 		if err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), importAPIPolicyOutput)
 			utils.HandleErrorAndExit("Error importing the API Policy", err)
 		}
+		impl.PrintActionResult(result.Succeed(), importAPIPolicyOutput)
 	},
 }
 
@@ -73,4 +79,5 @@ func init() {
 	// Mark required flags
 	_ = ImportAPIPolicyCmd.MarkFlagRequired("environment")
 	_ = ImportAPIPolicyCmd.MarkFlagRequired("file")
+	formatter.AddOutputFlag(ImportAPIPolicyCmd.Flags(), &importAPIPolicyOutput)
 }
@@ -21,6 +21,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -36,6 +37,7 @@ var (
 	importAPIProductSkipCleanup         bool
 	importAPIProductRotateRevision      bool
 	importAPIProductSkipDeployments     bool
+	importAPIProductOutput              string
 )
 
 const (
@@ -69,13 +71,18 @@ var ImportAPIProductCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing API Product", err)
 		}
-		err = impl.ImportAPIProductToEnv(accessOAuthToken, importAPIProductEnvironment, importAPIProductFile, importAPIProductParamsFile,
-			importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductCmdPreserveProvider, importAPIProductSkipCleanup,
+		err = impl.ImportAPIProductToEnv(formatter.MessageWriter(importAPIProductOutput), accessOAuthToken,
+			importAPIProductEnvironment, importAPIProductFile, importAPIProductParamsFile, importAPIs, importAPIsUpdate,
+			importAPIProductUpdate, importAPIProductCmdPreserveProvider, importAPIProductSkipCleanup,
 			importAPIProductRotateRevision, importAPIProductSkipDeployments)
+		result := impl.NewImportResult(utils.ProjectTypeApiProduct, importAPIProductEnvironment, importAPIProductFile,
+			utils.MetaFileAPIProduct)
 		if err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), importAPIProductOutput)
 			utils.HandleErrorAndExit("Error importing API Product", err)
 			return
 		}
+		impl.PrintActionResult(result.Succeed(), importAPIProductOutput)
 	},
 }
 
@@ -105,4 +112,5 @@ func init() {
 	// Mark required flags
 	_ = ImportAPIProductCmd.MarkFlagRequired("environment")
 	_ = ImportAPIProductCmd.MarkFlagRequired("file")
+	formatter.AddOutputFlag(ImportAPIProductCmd.Flags(), &importAPIProductOutput)
 }
@@ -0,0 +1,101 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAPIsSource      string
+	importAPIsEnvironment string
+	importAPIsForce       bool
+	importAPIsOptions     impl.BulkImportAPIsOptions
+	importAPIsBulkOptions utils.BulkOperationOptions
+)
+
+const (
+	// ImportAPIs command related usage info
+	ImportAPIsCmdLiteral   = "apis"
+	importAPIsCmdShortDesc = "Import APIs for migration"
+	importAPIsCmdLongDesc  = "Import all the APIs exported using the \"export apis\" command into an environment. " +
+		"The source is the tenant directory of the migration artifacts which contains the apis directory and the " +
+		"migration metadata file. If the import fails, run the command again to continue from the APIs which " +
+		"were not imported"
+)
+
+const importAPIsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/dev/tenant-default -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/dev/wso2-dot-com -e prod --params ~/params --parallel 4
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAPIsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/dev/tenant-default -e prod --force
+NOTE: Both the flags (--source and --environment (-e)) are mandatory`
+
+// ImportAPIsCmd represents the import apis command
+var ImportAPIsCmd = &cobra.Command{
+	Use: ImportAPIsCmdLiteral + " --source <migration-directory> --environment " +
+		"<environment>",
+	Short:   importAPIsCmdShortDesc,
+	Long:    importAPIsCmdLongDesc,
+	Example: importAPIsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAPIsCmdLiteral + " called")
+		cred, err := GetCredentials(importAPIsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		fmt.Fprintln(importAPIsBulkOptions.MessageWriter(), "\nImporting APIs for the migration...")
+		impl.ImportAPIs(cred, importAPIsSource, importAPIsEnvironment, importAPIsOptions, importAPIsBulkOptions,
+			importAPIsForce)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAPIsCmd)
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsSource, "source", "", "",
+		"Migration directory of a tenant created by the export apis command")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsEnvironment, "environment", "e",
+		"", "Environment to which the APIs should be imported")
+	ImportAPIsCmd.Flags().StringVarP(&importAPIsOptions.ParamsDir, "params", "", "", "Directory containing "+
+		"the params of the APIs, as <API-name>_<version>.yaml files or directories generated using \"gen deployment-dir\"")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsOptions.Update, "update", true, "Update the "+
+		"existing APIs or create new APIs")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsOptions.PreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of APIs after importing")
+	ImportAPIsCmd.Flags().BoolVar(&importAPIsOptions.RotateRevision, "rotate-revision", false, "Rotate the "+
+		"revisions with each update")
+	ImportAPIsCmd.Flags().BoolVarP(&importAPIsOptions.SkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAPIsCmd.Flags().BoolVarP(&importAPIsForce, "force", "", false,
+		"Ignore the status of the previous import and import all the APIs from the beginning")
+	ImportAPIsCmd.Flags().IntVarP(&importAPIsBulkOptions.Parallel, "parallel", "", 1,
+		"Number of APIs imported in parallel")
+	ImportAPIsCmd.Flags().Float64VarP(&importAPIsBulkOptions.RateLimit, "rate-limit", "", 0,
+		"Maximum number of requests sent to the environment per second (0 for unlimited)")
+	ImportAPIsCmd.Flags().IntVarP(&importAPIsBulkOptions.MaxRetries, "retries", "", utils.DefaultBulkMaxRetries,
+		"Number of times a failed import is retried")
+	// Mark required flags
+	_ = ImportAPIsCmd.MarkFlagRequired("environment")
+	_ = ImportAPIsCmd.MarkFlagRequired("source")
+	formatter.AddOutputFlag(ImportAPIsCmd.Flags(), &importAPIsBulkOptions.Output)
+}
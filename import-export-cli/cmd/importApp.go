@@ -19,8 +19,15 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -33,16 +40,34 @@ var skipSubscriptions bool
 var importAppSkipKeys bool
 var importAppUpdateApplication bool
 var importAppSkipCleanup bool
+var importAppOutput string
+var importAppMappingFile string
+var importAppPreflight bool
+var importAppDeferSubscriptions bool
 
 // ImportApp command related usage info
 const ImportAppCmdLiteral = "app"
 const importAppCmdShortDesc = "Import App"
 
-const importAppCmdLongDesc = "Import an Application to an environment"
+const importAppCmdLongDesc = `Import an Application to an environment.
+The API identifiers, throttling tiers, owners and key manager names which differ between the environments can be
+rewritten with a mapping file given by the flag --mapping. The flag --preflight prints the changes made by the
+mapping and what happens to each subscription, without importing the Application. The subscriptions whose APIs are
+not found in the environment are dropped, unless the flag --defer-subscriptions is given to keep them until they are
+created with "` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportDeferredSubscriptionsCmdLiteral + `".
+A mapping file is in the following format, where an empty version or provider in "from" matches any:
+  apis:
+    - from: {name: PizzaShackAPI, version: 1.0.0, provider: admin}
+      to: {name: PizzaAPI, version: 2.0.0}
+  throttlingTiers: {Gold: Unlimited}
+  owners: {qauser: produser}
+  keyManagers: {QA Key Manager: Prod Key Manager}`
 
 const importAppCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip -e dev
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f staging/apps/sampleApp.zip -e prod -o testUser
 ` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip --preserve-owner --skip-subscriptions -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip --mapping prod/app_mapping.yaml --preflight -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` -f qa/apps/sampleApp.zip --mapping prod/app_mapping.yaml --defer-subscriptions -e prod
 NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory`
 
 // importAppCmd represents the importApp command
@@ -63,15 +88,79 @@ var ImportAppCmd = &cobra.Command{
 }
 
 func executeImportAppCmd(credential credentials.Credential) {
+	out := formatter.MessageWriter(importAppOutput)
 	accessToken, err := credentials.GetOAuthAccessToken(credential, importAppEnvironment)
 	if err != nil {
 		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
 	}
-	_, err = impl.ImportApplicationToEnv(accessToken, importAppEnvironment, importAppFile, importAppOwner,
+	importPath := importAppFile
+	var report *impl.ApplicationImportReport
+	if importAppMappingFile != "" || importAppPreflight || importAppDeferSubscriptions {
+		if importAppDeferSubscriptions && skipSubscriptions {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("--defer-subscriptions cannot be used with "+
+				"--skip-subscriptions"))
+		}
+		importPath, report = prepareApplicationImport(accessToken)
+		defer removeApplicationImportWorkspace(importPath)
+		if importAppPreflight {
+			impl.PrintApplicationImportReport(os.Stdout, report, importAppOutput)
+			return
+		}
+		impl.PrintApplicationImportReport(out, report, "")
+	}
+
+	_, err = impl.ImportApplicationToEnv(out, accessToken, importAppEnvironment, importPath, importAppOwner,
 		importAppUpdateApplication, preserveOwner, skipSubscriptions, importAppSkipKeys, importAppSkipCleanup)
+	result := impl.NewImportResult(utils.ProjectTypeApplication, importAppEnvironment, importAppFile,
+		utils.MetaFileApplication)
 	if err != nil {
+		impl.PrintActionResult(result.Fail(err.Error()), importAppOutput)
+		removeApplicationImportWorkspace(importPath)
 		utils.HandleErrorAndExit("Error importing Application", err)
 	}
+	if importAppDeferSubscriptions {
+		dir, err := impl.SaveDeferredSubscriptions(importPath, report, importAppOwner, preserveOwner)
+		if err != nil {
+			utils.HandleErrorAndExit("Error saving the deferred subscriptions of the Application", err)
+		}
+		if dir != "" {
+			fmt.Fprintln(out, strconv.Itoa(len(report.Missing()))+" subscriptions are deferred until their APIs exist. "+
+				"Run \""+utils.ProjectName+" "+ImportCmdLiteral+" "+ImportDeferredSubscriptionsCmdLiteral+
+				" -e "+importAppEnvironment+"\" to create them.")
+		}
+	}
+	impl.PrintActionResult(result.Succeed(), importAppOutput)
+}
+
+// prepareApplicationImport applies the mapping file, if given, to a copy of the Application and returns its path
+// along with the pre-flight report
+func prepareApplicationImport(accessToken string) (string, *impl.ApplicationImportReport) {
+	var mapping *impl.ApplicationImportMapping
+	if importAppMappingFile != "" {
+		var err error
+		mapping, err = impl.LoadApplicationImportMapping(importAppMappingFile)
+		if err != nil {
+			utils.HandleErrorAndExit("Error loading the mapping file", err)
+		}
+	}
+	projectPath, report, err := impl.PrepareApplicationImport(accessToken, importAppEnvironment, importAppFile,
+		mapping, importAppDeferSubscriptions)
+	if err != nil {
+		utils.HandleErrorAndExit("Error preparing the Application to import", err)
+	}
+	return projectPath, report
+}
+
+// removeApplicationImportWorkspace removes the temporary directory of the prepared Application
+func removeApplicationImportWorkspace(projectPath string) {
+	if importAppSkipCleanup {
+		utils.Logln(utils.LogPrefixInfo+"Leaving", projectPath)
+		return
+	}
+	utils.Logln(utils.LogPrefixInfo+"Deleting", filepath.Dir(projectPath))
+	if err := os.RemoveAll(filepath.Dir(projectPath)); err != nil {
+		utils.Logln(utils.LogPrefixError + err.Error())
+	}
 }
 
 func init() {
@@ -92,6 +181,16 @@ func init() {
 		"Update the Application if it is already imported")
 	ImportAppCmd.Flags().BoolVarP(&importAppSkipCleanup, "skip-cleanup", "", false, "Leave "+
 		"all temporary files created during import process")
+	ImportAppCmd.Flags().StringVarP(&importAppMappingFile, "mapping", "", "",
+		"Mapping file to rewrite the API identifiers, throttling tiers, owners and key manager names of the "+
+			"Application")
+	ImportAppCmd.Flags().BoolVarP(&importAppPreflight, "preflight", "", false,
+		"Print the changes made by the mapping and the subscriptions which are dropped or remapped, "+
+			"without importing the Application")
+	ImportAppCmd.Flags().BoolVarP(&importAppDeferSubscriptions, "defer-subscriptions", "", false,
+		"Keep the subscriptions whose APIs are not found in the environment, to be created later with \""+
+			ImportDeferredSubscriptionsCmdLiteral+"\"")
 	_ = ImportAppCmd.MarkFlagRequired("file")
 	_ = ImportAppCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportAppCmd.Flags(), &importAppOutput)
 }
@@ -0,0 +1,99 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importAppsSource      string
+	importAppsEnvironment string
+	importAppsForce       bool
+	importAppsOptions     impl.BulkImportAppsOptions
+	importAppsBulkOptions utils.BulkOperationOptions
+)
+
+const (
+	// ImportApps command related usage info
+	ImportAppsCmdLiteral   = "apps"
+	importAppsCmdShortDesc = "Import Applications for migration"
+	importAppsCmdLongDesc  = "Import all the Applications exported using the \"export apps\" command into an " +
+		"environment. The source is the tenant directory of the migration artifacts which contains the apps " +
+		"directory and the migration metadata file. If the import fails, run the command again to continue from " +
+		"the Applications which were not imported"
+)
+
+const importAppsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/dev/tenant-default -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppsCmdLiteral + ` --source ~/.wso2apictl/exported/migration/dev/wso2-dot-com -e prod --skip-keys --parallel 4
+NOTE: Both the flags (--source and --environment (-e)) are mandatory`
+
+// ImportAppsCmd represents the import apps command
+var ImportAppsCmd = &cobra.Command{
+	Use: ImportAppsCmdLiteral + " --source <migration-directory> --environment " +
+		"<environment>",
+	Short:   importAppsCmdShortDesc,
+	Long:    importAppsCmdLongDesc,
+	Example: importAppsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportAppsCmdLiteral + " called")
+		cred, err := GetCredentials(importAppsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		fmt.Fprintln(importAppsBulkOptions.MessageWriter(), "\nImporting Applications for the migration...")
+		impl.ImportApps(cred, importAppsSource, importAppsEnvironment, importAppsOptions, importAppsBulkOptions,
+			importAppsForce)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportAppsCmd)
+	ImportAppsCmd.Flags().StringVarP(&importAppsSource, "source", "", "",
+		"Migration directory of a tenant created by the export apps command")
+	ImportAppsCmd.Flags().StringVarP(&importAppsEnvironment, "environment", "e",
+		"", "Environment to which the Applications should be imported")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsOptions.Update, "update", "", true,
+		"Update the Applications if they are already imported")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsOptions.PreserveOwner, "preserve-owner", "", true,
+		"Preserves the owners of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsOptions.SkipSubscriptions, "skip-subscriptions", "s", false,
+		"Skip subscriptions of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsOptions.SkipKeys, "skip-keys", "", false,
+		"Skip importing keys of the Applications")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsOptions.SkipCleanup, "skip-cleanup", "", false, "Leave "+
+		"all temporary files created during import process")
+	ImportAppsCmd.Flags().BoolVarP(&importAppsForce, "force", "", false,
+		"Ignore the status of the previous import and import all the Applications from the beginning")
+	ImportAppsCmd.Flags().IntVarP(&importAppsBulkOptions.Parallel, "parallel", "", 1,
+		"Number of Applications imported in parallel")
+	ImportAppsCmd.Flags().Float64VarP(&importAppsBulkOptions.RateLimit, "rate-limit", "", 0,
+		"Maximum number of requests sent to the environment per second (0 for unlimited)")
+	ImportAppsCmd.Flags().IntVarP(&importAppsBulkOptions.MaxRetries, "retries", "", utils.DefaultBulkMaxRetries,
+		"Number of times a failed import is retried")
+	_ = ImportAppsCmd.MarkFlagRequired("environment")
+	_ = ImportAppsCmd.MarkFlagRequired("source")
+	formatter.AddOutputFlag(ImportAppsCmd.Flags(), &importAppsBulkOptions.Output)
+}
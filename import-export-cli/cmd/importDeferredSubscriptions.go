@@ -0,0 +1,109 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var importDeferredSubscriptionsEnvironment string
+var importDeferredSubscriptionsAppName string
+var importDeferredSubscriptionsOutput string
+
+// ImportDeferredSubscriptions command related usage info
+const ImportDeferredSubscriptionsCmdLiteral = "deferred-subscriptions"
+const importDeferredSubscriptionsCmdShortDesc = "Create the deferred subscriptions of imported Applications"
+
+const importDeferredSubscriptionsCmdLongDesc = `Create the subscriptions deferred by "` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportAppCmdLiteral + ` --defer-subscriptions", whose APIs
+are found in the environment now. The Application kept with the deferred subscriptions is imported again with its
+subscriptions and without its keys. The subscriptions whose APIs are still not found stay deferred`
+
+const importDeferredSubscriptionsCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportDeferredSubscriptionsCmdLiteral + ` -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportDeferredSubscriptionsCmdLiteral + ` --app SampleApp -e prod
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// ImportDeferredSubscriptionsCmd represents the import deferred-subscriptions command
+var ImportDeferredSubscriptionsCmd = &cobra.Command{
+	Use:     ImportDeferredSubscriptionsCmdLiteral + " (--environment <environment-of-the-imported-apps>)",
+	Short:   importDeferredSubscriptionsCmdShortDesc,
+	Long:    importDeferredSubscriptionsCmdLongDesc,
+	Example: importDeferredSubscriptionsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportDeferredSubscriptionsCmdLiteral + " called")
+		cred, err := GetCredentials(importDeferredSubscriptionsEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeImportDeferredSubscriptionsCmd(cred)
+	},
+}
+
+func executeImportDeferredSubscriptionsCmd(credential credentials.Credential) {
+	deferredList, err := impl.GetDeferredSubscriptionsOfEnv(importDeferredSubscriptionsEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the deferred subscriptions", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(credential, importDeferredSubscriptionsEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth Tokens", err)
+	}
+
+	results := []impl.DeferredSubscriptionResult{}
+	failed := false
+	for _, deferred := range deferredList {
+		if importDeferredSubscriptionsAppName != "" && deferred.Application != importDeferredSubscriptionsAppName {
+			continue
+		}
+		deferredResults, err := impl.ResumeDeferredSubscriptions(accessToken, deferred)
+		if err != nil {
+			utils.Logln(utils.LogPrefixError+"Creating the deferred subscriptions of "+deferred.Application+":", err)
+			failed = true
+		}
+		results = append(results, deferredResults...)
+	}
+
+	if !utils.PrintStructuredOutput(results, importDeferredSubscriptionsOutput) {
+		if len(results) == 0 {
+			fmt.Println("No deferred subscriptions in " + importDeferredSubscriptionsEnvironment)
+		}
+		for _, result := range results {
+			fmt.Println(result.Application + " (" + result.Owner + ") -> " + result.API + ": " + result.Status +
+				" " + result.Message)
+		}
+	}
+	if failed {
+		utils.HandleErrorAndExit("Error creating the deferred subscriptions", nil)
+	}
+}
+
+func init() {
+	ImportCmd.AddCommand(ImportDeferredSubscriptionsCmd)
+	ImportDeferredSubscriptionsCmd.Flags().StringVarP(&importDeferredSubscriptionsEnvironment, "environment", "e",
+		"", "Environment the Applications are imported to")
+	ImportDeferredSubscriptionsCmd.Flags().StringVarP(&importDeferredSubscriptionsAppName, "app", "", "",
+		"Name of the Application to create the deferred subscriptions of. All the Applications if not given")
+	_ = ImportDeferredSubscriptionsCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportDeferredSubscriptionsCmd.Flags(), &importDeferredSubscriptionsOutput)
+}
@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importGatewayEnvironmentFile   string
+	importGatewayEnvironmentUpdate bool
+	importGatewayEnvironmentOutput string
+)
+
+const (
+	// ImportGatewayEnvironmentCmdLiteral command related usage info
+	ImportGatewayEnvironmentCmdLiteral   = "gateway-environment"
+	importGatewayEnvironmentCmdShortDesc = "Import Gateway Environment"
+	importGatewayEnvironmentCmdLongDesc  = `Import a Gateway Environment to an environment. Environment variables in ${VAR} format in the file are
+substituted before importing. An existing Gateway Environment with the same name is updated only if --update is given`
+)
+
+const importGatewayEnvironmentCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportGatewayEnvironmentCmdLiteral + ` -f ~/.wso2apictl/exported/gateway-environments/dev/GatewayEnvironment-us-region.yaml -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportGatewayEnvironmentCmdLiteral + ` -f GatewayEnvironment-us-region.yaml -e prod --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportGatewayEnvironmentCmd represents the import gateway-environment command
+var ImportGatewayEnvironmentCmd = &cobra.Command{
+	Use:     ImportGatewayEnvironmentCmdLiteral + " --file <path-to-the-gateway-environment-file> --environment <environment>",
+	Short:   importGatewayEnvironmentCmdShortDesc,
+	Long:    importGatewayEnvironmentCmdLongDesc,
+	Example: importGatewayEnvironmentCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportGatewayEnvironmentCmdLiteral + " called")
+		executeImportAdminArtifactCmd(impl.AdminArtifactTypeGatewayEnvironment, importGatewayEnvironmentFile,
+			importEnvironment, importGatewayEnvironmentUpdate, importGatewayEnvironmentOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportGatewayEnvironmentCmd)
+	ImportGatewayEnvironmentCmd.Flags().StringVarP(&importGatewayEnvironmentFile, "file", "f", "",
+		"File path of the Gateway Environment to be imported")
+	ImportGatewayEnvironmentCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the Gateway Environment should be imported")
+	ImportGatewayEnvironmentCmd.Flags().BoolVarP(&importGatewayEnvironmentUpdate, "update", "u", false,
+		"Update the Gateway Environment if it already exists in the environment")
+	_ = ImportGatewayEnvironmentCmd.MarkFlagRequired("file")
+	_ = ImportGatewayEnvironmentCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportGatewayEnvironmentCmd.Flags(), &importGatewayEnvironmentOutput)
+}
@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importKeyManagerFile   string
+	importKeyManagerUpdate bool
+	importKeyManagerOutput string
+)
+
+const (
+	// ImportKeyManagerCmdLiteral command related usage info
+	ImportKeyManagerCmdLiteral   = "key-manager"
+	importKeyManagerCmdShortDesc = "Import Key Manager"
+	importKeyManagerCmdLongDesc  = `Import a Key Manager to an environment. Environment variables in ${VAR} format in the file are substituted
+before importing. An existing Key Manager with the same name is updated only if --update is given`
+)
+
+const importKeyManagerCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagerCmdLiteral + ` -f ~/.wso2apictl/exported/key-managers/dev/KeyManager-Okta.yaml -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportKeyManagerCmdLiteral + ` -f KeyManager-Okta.yaml -e prod --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportKeyManagerCmd represents the import key-manager command
+var ImportKeyManagerCmd = &cobra.Command{
+	Use:     ImportKeyManagerCmdLiteral + " --file <path-to-the-key-manager-file> --environment <environment>",
+	Short:   importKeyManagerCmdShortDesc,
+	Long:    importKeyManagerCmdLongDesc,
+	Example: importKeyManagerCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportKeyManagerCmdLiteral + " called")
+		executeImportAdminArtifactCmd(impl.AdminArtifactTypeKeyManager, importKeyManagerFile,
+			importEnvironment, importKeyManagerUpdate, importKeyManagerOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportKeyManagerCmd)
+	ImportKeyManagerCmd.Flags().StringVarP(&importKeyManagerFile, "file", "f", "",
+		"File path of the Key Manager to be imported")
+	ImportKeyManagerCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the Key Manager should be imported")
+	ImportKeyManagerCmd.Flags().BoolVarP(&importKeyManagerUpdate, "update", "u", false,
+		"Update the Key Manager if it already exists in the environment")
+	_ = ImportKeyManagerCmd.MarkFlagRequired("file")
+	_ = ImportKeyManagerCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportKeyManagerCmd.Flags(), &importKeyManagerOutput)
+}
@@ -0,0 +1,71 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importSharedScopeFile   string
+	importSharedScopeUpdate bool
+	importSharedScopeOutput string
+)
+
+const (
+	// ImportSharedScopeCmdLiteral command related usage info
+	ImportSharedScopeCmdLiteral   = "shared-scope"
+	importSharedScopeCmdShortDesc = "Import Shared Scope"
+	importSharedScopeCmdLongDesc  = `Import a Shared Scope to an environment. Environment variables in ${VAR} format in the file are substituted
+before importing. An existing Shared Scope with the same name is updated only if --update is given`
+)
+
+const importSharedScopeCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportSharedScopeCmdLiteral + ` -f ~/.wso2apictl/exported/scopes/dev/SharedScope-read_orders.yaml -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportSharedScopeCmdLiteral + ` -f SharedScope-read_orders.yaml -e prod --update
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportSharedScopeCmd represents the import shared-scope command
+var ImportSharedScopeCmd = &cobra.Command{
+	Use:     ImportSharedScopeCmdLiteral + " --file <path-to-the-shared-scope-file> --environment <environment>",
+	Short:   importSharedScopeCmdShortDesc,
+	Long:    importSharedScopeCmdLongDesc,
+	Example: importSharedScopeCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportSharedScopeCmdLiteral + " called")
+		executeImportAdminArtifactCmd(impl.AdminArtifactTypeSharedScope, importSharedScopeFile,
+			importEnvironment, importSharedScopeUpdate, importSharedScopeOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportSharedScopeCmd)
+	ImportSharedScopeCmd.Flags().StringVarP(&importSharedScopeFile, "file", "f", "",
+		"File path of the Shared Scope to be imported")
+	ImportSharedScopeCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the Shared Scope should be imported")
+	ImportSharedScopeCmd.Flags().BoolVarP(&importSharedScopeUpdate, "update", "u", false,
+		"Update the Shared Scope if it already exists in the environment")
+	_ = ImportSharedScopeCmd.MarkFlagRequired("file")
+	_ = ImportSharedScopeCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportSharedScopeCmd.Flags(), &importSharedScopeOutput)
+}
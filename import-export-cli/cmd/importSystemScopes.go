@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importSystemScopesFile   string
+	importSystemScopesOutput string
+)
+
+const (
+	// ImportSystemScopesCmdLiteral command related usage info
+	ImportSystemScopesCmdLiteral   = "system-scopes"
+	importSystemScopesCmdShortDesc = "Import System Scopes"
+	importSystemScopesCmdLongDesc  = `Import the role mappings of the system scopes and the role aliases to an environment. The existing ones of the
+environment are replaced. Environment variables in ${VAR} format in the file are substituted before importing`
+)
+
+const importSystemScopesCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportSystemScopesCmdLiteral + ` -f ~/.wso2apictl/exported/scopes/dev/SystemScopes.yaml -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportSystemScopesCmdLiteral + ` -f SystemScopes.yaml -e prod -o json
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportSystemScopesCmd represents the import system-scopes command
+var ImportSystemScopesCmd = &cobra.Command{
+	Use:     ImportSystemScopesCmdLiteral + " --file <path-to-the-system-scopes-file> --environment <environment>",
+	Short:   importSystemScopesCmdShortDesc,
+	Long:    importSystemScopesCmdLongDesc,
+	Example: importSystemScopesCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportSystemScopesCmdLiteral + " called")
+		executeImportAdminArtifactCmd(impl.AdminArtifactTypeSystemScopes, importSystemScopesFile,
+			importEnvironment, false, importSystemScopesOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportSystemScopesCmd)
+	ImportSystemScopesCmd.Flags().StringVarP(&importSystemScopesFile, "file", "f", "",
+		"File path of the System Scopes to be imported")
+	ImportSystemScopesCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the System Scopes should be imported")
+	_ = ImportSystemScopesCmd.MarkFlagRequired("file")
+	_ = ImportSystemScopesCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportSystemScopesCmd.Flags(), &importSystemScopesOutput)
+}
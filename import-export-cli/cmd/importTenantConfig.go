@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importTenantConfigFile   string
+	importTenantConfigOutput string
+)
+
+const (
+	// ImportTenantConfigCmdLiteral command related usage info
+	ImportTenantConfigCmdLiteral   = "tenant-config"
+	importTenantConfigCmdShortDesc = "Import Tenant Config"
+	importTenantConfigCmdLongDesc  = `Import the advanced tenant configuration to an environment. The existing configuration of the environment is
+replaced. Environment variables in ${VAR} format in the file are substituted before importing`
+)
+
+const importTenantConfigCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportTenantConfigCmdLiteral + ` -f ~/.wso2apictl/exported/tenant-config/dev/TenantConfig.yaml -e prod
+` + utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportTenantConfigCmdLiteral + ` -f TenantConfig.yaml -e prod -o json
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportTenantConfigCmd represents the import tenant-config command
+var ImportTenantConfigCmd = &cobra.Command{
+	Use:     ImportTenantConfigCmdLiteral + " --file <path-to-the-tenant-config-file> --environment <environment>",
+	Short:   importTenantConfigCmdShortDesc,
+	Long:    importTenantConfigCmdLongDesc,
+	Example: importTenantConfigCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportTenantConfigCmdLiteral + " called")
+		executeImportAdminArtifactCmd(impl.AdminArtifactTypeTenantConfig, importTenantConfigFile,
+			importEnvironment, false, importTenantConfigOutput)
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportTenantConfigCmd)
+	ImportTenantConfigCmd.Flags().StringVarP(&importTenantConfigFile, "file", "f", "",
+		"File path of the Tenant Config to be imported")
+	ImportTenantConfigCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the Tenant Config should be imported")
+	_ = ImportTenantConfigCmd.MarkFlagRequired("file")
+	_ = ImportTenantConfigCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportTenantConfigCmd.Flags(), &importTenantConfigOutput)
+}
@@ -0,0 +1,75 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var (
+	importTenantThemeFile   string
+	importTenantThemeOutput string
+)
+
+const (
+	// ImportTenantThemeCmdLiteral command related usage info
+	ImportTenantThemeCmdLiteral   = "tenant-theme"
+	importTenantThemeCmdShortDesc = "Import Tenant Theme"
+	importTenantThemeCmdLongDesc  = "Import a Developer Portal theme archive to the tenant of an environment, replacing the existing theme"
+)
+
+const importTenantThemeCmdExamples = utils.ProjectName + ` ` + ImportCmdLiteral + ` ` + ImportTenantThemeCmdLiteral + ` -f ~/.wso2apictl/exported/tenant-config/dev/tenant-theme.zip -e prod
+NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory.`
+
+// ImportTenantThemeCmd represents the import tenant-theme command
+var ImportTenantThemeCmd = &cobra.Command{
+	Use:     ImportTenantThemeCmdLiteral + " --file <path-to-the-theme-archive> --environment <environment>",
+	Short:   importTenantThemeCmdShortDesc,
+	Long:    importTenantThemeCmdLongDesc,
+	Example: importTenantThemeCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ImportTenantThemeCmdLiteral + " called")
+		accessToken := getAdminArtifactAccessToken(importEnvironment)
+		result := impl.NewImportResult(utils.ProjectTypeTenantTheme, importEnvironment, importTenantThemeFile, "")
+		if err := impl.ImportTenantThemeToEnv(accessToken, importEnvironment, importTenantThemeFile); err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), importTenantThemeOutput)
+			utils.HandleErrorAndExit("Error importing "+result.Type, err)
+		}
+		if !impl.PrintActionResult(result.Succeed(), importTenantThemeOutput) {
+			fmt.Println("Successfully imported " + result.Type + "!")
+		}
+	},
+}
+
+// init using Cobra
+func init() {
+	ImportCmd.AddCommand(ImportTenantThemeCmd)
+	ImportTenantThemeCmd.Flags().StringVarP(&importTenantThemeFile, "file", "f", "",
+		"File path of the Tenant Theme archive to be imported")
+	ImportTenantThemeCmd.Flags().StringVarP(&importEnvironment, "environment", "e", "",
+		"Environment to which the Tenant Theme should be imported")
+	_ = ImportTenantThemeCmd.MarkFlagRequired("file")
+	_ = ImportTenantThemeCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(ImportTenantThemeCmd.Flags(), &importTenantThemeOutput)
+}
@@ -21,6 +21,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -28,6 +29,7 @@ import (
 var (
 	importThrottlingPolicyFile string
 	importThrottlePolicyUpdate bool
+	importThrottlePolicyOutput string
 )
 
 const (
@@ -59,10 +61,14 @@ var ImportThrottlingPolicyCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for importing Throttling Policy", err)
 		}
-		err = impl.ImportThrottlingPolicyToEnv(accessOAuthToken, importEnvironment, importThrottlingPolicyFile, importThrottlePolicyUpdate)
+		err = impl.ImportThrottlingPolicyToEnv(formatter.MessageWriter(importThrottlePolicyOutput), accessOAuthToken,
+			importEnvironment, importThrottlingPolicyFile, importThrottlePolicyUpdate)
+		result := impl.NewImportResult(utils.ProjectTypePolicy, importEnvironment, importThrottlingPolicyFile, "")
 		if err != nil {
+			impl.PrintActionResult(result.Fail(err.Error()), importThrottlePolicyOutput)
 			utils.HandleErrorAndExit("Error importing throttling Policy", err)
 		}
+		impl.PrintActionResult(result.Succeed(), importThrottlePolicyOutput)
 	},
 }
 
@@ -78,4 +84,5 @@ func init() {
 	// Mark required flags
 	_ = ImportThrottlingPolicyCmd.MarkFlagRequired("environment")
 	_ = ImportThrottlingPolicyCmd.MarkFlagRequired("file")
+	formatter.AddOutputFlag(ImportThrottlingPolicyCmd.Flags(), &importThrottlePolicyOutput)
 }
@@ -19,27 +19,28 @@
 package k8s
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"io"
-	"io/ioutil"
-	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/box"
-	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/operator/k8sclient"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var flagApiName string
 var flagSwaggerFilePath string
 var flagNamespace string
+var flagWaitForApi bool
+var flagWaitTimeout time.Duration
 
 const AddApiCmdLiteral = "api"
 const addApiCmdShortDesc = "Handle APIs in kubernetes cluster "
@@ -47,7 +48,9 @@ const addApiLongDesc = `Add, Update and Delete APIs in kubernetes cluster. JSON
 available modes are as follows
 * kubernetes`
 const addApiExamples = utils.ProjectName + " " + K8sCmdLiteral + " add/update " + AddApiCmdLiteral +
-	` -n petstore -f Swagger.json --namespace=wso2`
+	` -n petstore -f Swagger.json --namespace=wso2
+` + utils.ProjectName + " " + K8sCmdLiteral + " add " + AddApiCmdLiteral +
+	` -n petstore -f Swagger.json --context=prod-cluster --namespace=wso2 --wait --timeout=10m`
 
 // addApiCmd represents the api command
 var addApiCmd = &cobra.Command{
@@ -63,6 +66,7 @@ var addApiCmd = &cobra.Command{
 
 func handleAddApi(nameSuffix string) {
 	validateAddApiCommand()
+	k8sClient := getK8sClient()
 
 	// log processing only if there are more projects
 	utils.Logln(fmt.Sprintf("%sProcessing swagger  %v", utils.LogPrefixInfo, flagSwaggerFilePath))
@@ -70,33 +74,40 @@ func handleAddApi(nameSuffix string) {
 	flagApiName = strings.ToLower(flagApiName)
 	swaggerCmName := fmt.Sprintf("%v-swagger%v", flagApiName, nameSuffix)
 
+	// APIs are added only if they do not exist and updated with the update command
+	if nameSuffix == "" {
+		_, err := k8sClient.GetAPI(context.Background(), flagApiName)
+		if err == nil {
+			utils.HandleErrorAndExit(fmt.Sprintf("API \"%s\" already exists in the namespace \"%s\", use the %s command",
+				flagApiName, k8sClient.Namespace, K8sUpdateCmdLiteral), nil)
+		}
+		if !apierrors.IsNotFound(err) {
+			utils.HandleErrorAndExit("Error getting API: "+flagApiName, err)
+		}
+	}
+
+	swaggerPath := flagSwaggerFilePath
 	fi, _ := os.Stat(flagSwaggerFilePath) // error already handled and ignore error
-	switch mode := fi.Mode(); {
 	//check if the swagger path is a Dir
-	case mode.IsDir():
+	if fi.Mode().IsDir() {
 		//get swagger definition
-		swaggerPath := filepath.Join(flagSwaggerFilePath, filepath.FromSlash("Definitions/swagger.yaml"))
-		//creating kubernetes configmap with swagger definition
-		fmt.Println("creating configmap with swagger definition")
-		errConf := createConfigMapWithNamespace(swaggerCmName, swaggerPath, flagNamespace, k8sUtils.K8sCreate)
-		if errConf != nil {
-			utils.HandleErrorAndExit("Error creating configmap", errConf)
-		}
+		swaggerPath = filepath.Join(flagSwaggerFilePath, filepath.FromSlash("Definitions/swagger.yaml"))
+	}
 
-	//check if the swagger path is a file
-	case mode.IsRegular():
-		//creating kubernetes configmap with swagger definition
-		fmt.Println("creating configmap with swagger definition")
-		errConf := createConfigMapWithNamespace(swaggerCmName, flagSwaggerFilePath, flagNamespace,
-			k8sUtils.K8sCreate)
-		if errConf != nil {
-			utils.HandleErrorAndExit("Error creating configmap", errConf)
-		}
+	//creating kubernetes configmap with swagger definition
+	fmt.Println("creating configmap with swagger definition")
+	errConf := createConfigMap(k8sClient, swaggerCmName, swaggerPath)
+	if errConf != nil {
+		utils.HandleErrorAndExit("Error creating configmap", errConf)
 	}
 
 	//create API
 	fmt.Println("creating API definition")
-	createAPI(swaggerCmName, nameSuffix)
+	updateTimeStamp := createAPI(k8sClient, swaggerCmName, nameSuffix)
+
+	if flagWaitForApi {
+		waitForAPIReady(k8sClient, updateTimeStamp)
+	}
 }
 
 // validateAddApiCommand validates for required flags and if invalid print error and exit
@@ -108,28 +119,17 @@ func validateAddApiCommand() {
 }
 
 //create configmap with swagger definition
-func createConfigMapWithNamespace(configMapName, filePath, namespace, operation string) error {
-	cmd := exec.Command(
-		k8sUtils.Kubectl,
-		operation,
-		"configmap",
-		configMapName,
-		"--from-file",
-		filePath,
-		"-n", namespace,
-	)
-	//print kubernetes error commands
-	var errBuf, outBuf bytes.Buffer
-	cmd.Stderr = io.MultiWriter(os.Stderr, &errBuf)
-	cmd.Stdout = io.MultiWriter(os.Stdout, &outBuf)
-	err := cmd.Run()
+func createConfigMap(k8sClient *k8sclient.Client, configMapName, filePath string) error {
+	configMap, err := k8sClient.ConfigMapFromFile(configMapName, filePath)
 	if err != nil {
 		return err
 	}
-	return nil
+	return k8sClient.Apply(context.Background(), configMap)
 }
 
-func createAPI(configMapName, timestamp string) {
+// createAPI creates or updates the API custom resource and returns the update timestamp set in it, which is empty
+// when the API is added
+func createAPI(k8sClient *k8sclient.Client, configMapName, timestamp string) string {
 	//get API definition from file
 	apiConfigMapData, _ := box.Get("/kubernetes_resources/api_cr.yaml")
 	apiCrd := &wso2v1alpha2.API{}
@@ -139,78 +139,66 @@ func createAPI(configMapName, timestamp string) {
 	}
 
 	apiCrd.Name = flagApiName
-	apiCrd.Namespace = flagNamespace
+	apiCrd.Namespace = k8sClient.Namespace
 	apiCrd.Spec.SwaggerConfigMapName = configMapName
 
-	k8sOperation := k8sUtils.K8sCreate
-	k8sSaveConfig := true
 	if timestamp != "" {
 		//set update timestamp
 		apiCrd.Spec.UpdateTimeStamp = strings.Split(timestamp, "-")[1]
-		k8sOperation = k8sUtils.K8sApply
-		k8sSaveConfig = false
-	}
-
-	byteVal, errMarshal := yaml.Marshal(apiCrd)
-	if errMarshal != nil {
-		utils.HandleErrorAndExit("Error marshal api configmap ", errMarshal)
-	}
-	//write configmap to a temp file
-	tmpFile, err := ioutil.TempFile(os.TempDir(), "apicr-*.yaml")
-	if err != nil {
-		log.Fatal("Cannot create temporary file", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	if _, err = tmpFile.Write(byteVal); err != nil {
-		log.Fatal("Failed to write to temporary file", err)
-	}
-	// Close the file
-	if err := tmpFile.Close(); err != nil {
-		log.Fatal(err)
-	}
-
-	k8sArgs := []string{k8sOperation, "-f", tmpFile.Name(), "-n", flagNamespace}
-	if k8sSaveConfig {
-		k8sArgs = append(k8sArgs, "--save-config")
 	}
 
-	//execute kubernetes command to create or update api from file
-	errAddApi := k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sArgs...)
+	//create or update api with server-side apply
+	errAddApi := k8sClient.Apply(context.Background(), apiCrd)
 	if errAddApi != nil {
-		fmt.Println("error configuring API")
+		fmt.Println("error configuring API: " + errAddApi.Error())
 		// delete all configs if any error
-		rollbackConfigs(apiCrd)
+		rollbackConfigs(k8sClient, apiCrd)
+		utils.HandleErrorAndExit("Error configuring API: "+apiCrd.Name, errAddApi)
+	}
+	return apiCrd.Spec.UpdateTimeStamp
+}
+
+// waitForAPIReady waits until the deployment of the API is rolled out or the timeout given with the flags is reached.
+// When the API is updated, the wait is for the deployment of the update with updateTimeStamp
+func waitForAPIReady(k8sClient *k8sclient.Client, updateTimeStamp string) {
+	fmt.Println("waiting for the API to be ready")
+	ctx, cancel := context.WithTimeout(context.Background(), flagWaitTimeout)
+	defer cancel()
+	if err := k8sClient.WaitForAPIReady(ctx, flagApiName, updateTimeStamp, 2*time.Second); err != nil {
+		utils.HandleErrorAndExit("Error waiting for the API to be ready", err)
 	}
 }
 
 // rollbackConfigs deletes configs defined in the API CR given
-func rollbackConfigs(apiCr *wso2v1alpha2.API) {
+func rollbackConfigs(k8sClient *k8sclient.Client, apiCr *wso2v1alpha2.API) {
 	var rollbackConfMaps []string // configmap names to be deleted
 
 	// swagger configmaps
 	rollbackConfMaps = append(rollbackConfMaps, apiCr.Spec.SwaggerConfigMapName)
 
-	if len(rollbackConfMaps) == 0 {
-		return
-	}
-
-	// execute kubernetes command to delete
+	// delete the configs
 	fmt.Println("Deleting created configs")
-	k8sArgs := []string{k8sUtils.K8sDelete, "cm"}
-	k8sArgs = append(k8sArgs, rollbackConfMaps...)
-
-	delConfErr := k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sArgs...)
-	if delConfErr != nil {
-		utils.HandleErrorAndExit("error deleting configmaps of the API: "+apiCr.Name, delConfErr)
+	for _, configMap := range rollbackConfMaps {
+		delConfErr := k8sClient.DeleteConfigMap(context.Background(), configMap)
+		if client.IgnoreNotFound(delConfErr) != nil {
+			utils.HandleErrorAndExit("error deleting configmaps of the API: "+apiCr.Name, delConfErr)
+		}
 	}
 }
 
+// addWaitFlags adds the flags to wait until the API is ready
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&flagWaitForApi, "wait", false,
+		"Wait until the deployment of the API created by the API Operator is rolled out")
+	cmd.Flags().DurationVar(&flagWaitTimeout, "timeout", 5*time.Minute, "Maximum time to wait for the API to be ready")
+}
+
 func init() {
 	AddCmd.AddCommand(addApiCmd)
 	addApiCmd.Flags().StringVarP(&flagApiName, "name", "n", "", "Name of the API")
 	addApiCmd.Flags().StringVarP(&flagSwaggerFilePath, "file", "f", "",
 		"Path to swagger, zip file or API Project")
-	addApiCmd.Flags().StringVar(&flagNamespace, "namespace", "", "namespace of API")
+	addWaitFlags(addApiCmd)
 	_ = addApiCmd.MarkFlagRequired("name")
 	_ = addApiCmd.MarkFlagRequired("file")
 }
@@ -19,10 +19,11 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"github.com/spf13/cobra"
-	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"strings"
 )
 
@@ -46,19 +47,17 @@ var deleteAPICmd = &cobra.Command{
 }
 
 func handleDeleteApi() {
+	k8sClient := getK8sClient()
 	flagApiName = strings.ToLower(flagApiName)
-	var errMsg string
-	deleteApiErr := k8sUtils.ExecuteCommand(
-		k8sUtils.Kubectl, k8sUtils.K8sDelete, k8sUtils.ApiOpCrdApi, flagApiName, "-n", flagNamespace)
+	deleteApiErr := k8sClient.DeleteAPI(context.Background(), flagApiName)
+	if apierrors.IsNotFound(deleteApiErr) {
+		utils.HandleErrorAndExit(fmt.Sprintf("Could not find the API \"%s\" in the namespace \"%s\"",
+			flagApiName, k8sClient.Namespace), nil)
+	}
 	if deleteApiErr != nil {
-		if flagNamespace != "" {
-			errMsg = fmt.Sprintf("Could not find the API \"%s\" in the namespace \"%s\"",
-				flagApiName, flagNamespace)
-		} else {
-			errMsg = fmt.Sprintf("Could not find the API \"%s\"", flagApiName)
-		}
-		utils.HandleErrorAndExit(errMsg, nil)
+		utils.HandleErrorAndExit("Error deleting API: "+flagApiName, deleteApiErr)
 	}
+	fmt.Printf("API \"%s\" deleted from the namespace \"%s\"\n", flagApiName, k8sClient.Namespace)
 }
 
 // Init using Cobra
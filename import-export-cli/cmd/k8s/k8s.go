@@ -21,6 +21,7 @@ package k8s
 import (
 	"bytes"
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/operator/k8sclient"
 	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"io"
@@ -28,11 +29,17 @@ import (
 	"os/exec"
 )
 
+var flagKubeconfig string
+var flagKubeContext string
+
 // K8s command related usage Info
 const K8sCmdLiteral = "k8s"
 const k8sCmdShortDesc = "Kubernetes mode based commands"
 
-const k8sCmdLongDesc = `Kubernetes mode based commands such as add, update and delete API`
+const k8sCmdLongDesc = `Kubernetes mode based commands such as add, update and delete API.
+Resources are managed with the Kubernetes API directly, so kubectl is only required when passing commands to kubectl.
+The cluster is selected with the kubeconfig, context and namespace flags, which default to the current kubeconfig
+context in the same way as kubectl.`
 
 const k8sCmdExamples = utils.ProjectName + ` ` + K8sCmdLiteral + ` ` + K8sAddCmdLiteral + ` ` + AddApiCmdLiteral + ` ` +
 	`-n petstore -f Swagger.json --namespace=wso2
@@ -47,14 +54,40 @@ var Cmd = &cobra.Command{
 	Short:   k8sCmdShortDesc,
 	Long:    k8sCmdLongDesc,
 	Example: k8sCmdExamples,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		k8sclient.SetDefaultOptions(k8sclient.Options{
+			Kubeconfig: flagKubeconfig,
+			Context:    flagKubeContext,
+			Namespace:  flagNamespace,
+		})
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + K8sCmdLiteral + " called")
 		ExecuteKubernetes(args...)
 	},
 }
 
+// getK8sClient returns the kubernetes client for the kubeconfig, context and namespace given with the flags
+func getK8sClient() *k8sclient.Client {
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		utils.HandleErrorAndExit("Error connecting to the kubernetes cluster", err)
+	}
+	return k8sClient
+}
+
 //execute kubernetes commands
 func ExecuteKubernetes(arg ...string) {
+	// pass the cluster selection flags to kubectl
+	if flagKubeconfig != "" {
+		arg = append(arg, "--kubeconfig", flagKubeconfig)
+	}
+	if flagKubeContext != "" {
+		arg = append(arg, "--context", flagKubeContext)
+	}
+	if flagNamespace != "" {
+		arg = append(arg, "--namespace", flagNamespace)
+	}
 	cmd := exec.Command(
 		k8sUtils.Kubectl,
 		arg...,
@@ -74,4 +107,9 @@ func init() {
 	Cmd.AddCommand(GenCmd)
 	Cmd.AddCommand(DeleteCmd)
 	Cmd.AddCommand(UpdateCmd)
+	Cmd.PersistentFlags().StringVar(&flagKubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file. Defaults to $KUBECONFIG or ~/.kube/config")
+	Cmd.PersistentFlags().StringVar(&flagKubeContext, "context", "", "Name of the kubeconfig context to use")
+	Cmd.PersistentFlags().StringVar(&flagNamespace, "namespace", "",
+		"Namespace of the resources. Defaults to the namespace of the kubeconfig context")
 }
@@ -19,10 +19,10 @@
 package k8s
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"strings"
 	"time"
 
@@ -61,37 +61,27 @@ var updateApiCmd = &cobra.Command{
 }
 
 func handleUpdateApi() {
-	var errMsg string
+	k8sClient := getK8sClient()
 	flagApiName = strings.ToLower(flagApiName)
-	getApiCr, getApiErr:= k8sUtils.GetCommandOutput(
-		k8sUtils.Kubectl, k8sUtils.K8sGet, k8sUtils.ApiOpCrdApi, flagApiName, "-n", flagNamespace, "-o", "json")
+	apiCr, getApiErr := k8sClient.GetAPI(context.Background(), flagApiName)
+	if apierrors.IsNotFound(getApiErr) {
+		utils.HandleErrorAndExit(fmt.Sprintf("Could not find the API \"%s\" in the namespace \"%s\"",
+			flagApiName, k8sClient.Namespace), nil)
+	}
 	if getApiErr != nil {
-
-		if flagNamespace != "" {
-			errMsg = fmt.Sprintf("Could not find the API \"%s\" in the namespace \"%s\"",
-				flagApiName, flagNamespace)
-		} else {
-			errMsg = fmt.Sprintf("Could not find the API \"%s\"", flagApiName)
-		}
-		utils.HandleErrorAndExit(errMsg, nil)
+		utils.HandleErrorAndExit("Error getting API: "+flagApiName, getApiErr)
 	}
-	var apiCr map[string]interface{}
-	_ = json.Unmarshal([]byte(getApiCr), &apiCr)
-	swaggerCmName := apiCr["spec"].(map[string]interface{})["swaggerConfigMapName"].(string)
+	swaggerCmName := apiCr.Spec.SwaggerConfigMapName
 	timestampSuffix := fmt.Sprint(time.Now().Unix())
 	handleAddApi("-" + strings.ToLower(timestampSuffix))
-	deleteApiErr := k8sUtils.ExecuteCommand(
-		k8sUtils.Kubectl, k8sUtils.K8sDelete, "cm", swaggerCmName, "-n", flagNamespace)
-	if deleteApiErr != nil {
-		if flagNamespace != "" {
-			errMsg = fmt.Sprintf("Could not find the config map \"%s\" in the namespace \"%s\"",
-				flagApiName, flagNamespace)
-		} else {
-			errMsg = fmt.Sprintf("Could not find the config map \"%s\"", flagApiName)
-		}
-		utils.HandleErrorAndExit(errMsg, nil)
+	deleteCmErr := k8sClient.DeleteConfigMap(context.Background(), swaggerCmName)
+	if apierrors.IsNotFound(deleteCmErr) {
+		utils.HandleErrorAndExit(fmt.Sprintf("Could not find the config map \"%s\" in the namespace \"%s\"",
+			swaggerCmName, k8sClient.Namespace), nil)
+	}
+	if deleteCmErr != nil {
+		utils.HandleErrorAndExit("Error deleting config map: "+swaggerCmName, deleteCmErr)
 	}
-
 }
 
 func init() {
@@ -99,7 +89,7 @@ func init() {
 	updateApiCmd.Flags().StringVarP(&flagApiName, "name", "n", "", "Name of the API")
 	updateApiCmd.Flags().StringVarP(&flagSwaggerFilePath, "file", "f", "",
 		"Path to swagger, zip file or API project")
-	updateApiCmd.Flags().StringVar(&flagNamespace, "namespace", "", "namespace of API")
+	addWaitFlags(updateApiCmd)
 	_ = updateApiCmd.MarkFlagRequired("name")
 	_ = updateApiCmd.MarkFlagRequired("file")
 }
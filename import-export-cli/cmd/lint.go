@@ -0,0 +1,103 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var lintRulesets []string
+var lintParamsFile string
+var lintProductionEnvs []string
+var lintSkipBuiltInRules bool
+var lintFailSeverity string
+var lintOutput string
+
+// lint command related usage Info
+const lintCmdLiteral = "lint"
+const lintCmdShortDesc = "Check an API project against rulesets without an environment"
+const lintCmdLongDesc = `Check the api.yaml and the OpenAPI, GraphQL or AsyncAPI definition of an API project against the built-in rules
+and the Spectral compatible rulesets given using --ruleset, without connecting to an environment.
+The built-in rules check for operations without security or scopes, http production endpoints in the params given using --params,
+and for versions and contexts which do not match between api.yaml, api_meta.yaml and the definition.
+A ruleset is evaluated against the API definition, unless it sets "ruleType: API_METADATA" in which case it is evaluated against api.yaml.
+The command fails if there are violations of the severity given using --fail-severity or a higher severity.`
+
+const lintCmdExamples = utils.ProjectName + ` ` + lintCmdLiteral + ` PizzaShackAPI
+` + utils.ProjectName + ` ` + lintCmdLiteral + ` PizzaShackAPI --ruleset api-design-rules.yaml --ruleset owasp-rules.yaml
+` + utils.ProjectName + ` ` + lintCmdLiteral + ` PizzaShackAPI_1.0.0.zip --params prod_params.yaml --fail-severity warn
+` + utils.ProjectName + ` ` + lintCmdLiteral + ` PizzaShackAPI --ruleset api-design-rules.yaml --skip-built-in-rules -o json`
+
+// LintCmd represents the lint command
+var LintCmd = &cobra.Command{
+	Use:     lintCmdLiteral + " <path-to-api-project>",
+	Short:   lintCmdShortDesc,
+	Long:    lintCmdLongDesc,
+	Example: lintCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + lintCmdLiteral + " called")
+		if impl.LintSeverityRank(lintFailSeverity) > impl.LintSeverityRank(impl.LintSeverityHint) {
+			utils.HandleErrorAndExit("Invalid fail severity "+lintFailSeverity+". Supported severities are error, "+
+				"warn, info and hint", nil)
+		}
+		violations, err := impl.LintAPIProject(args[0], impl.LintOptions{
+			RulesetPaths:           lintRulesets,
+			ParamsPath:             lintParamsFile,
+			ProductionEnvironments: lintProductionEnvs,
+			SkipBuiltInRules:       lintSkipBuiltInRules,
+		})
+		if err != nil {
+			utils.HandleErrorAndExit("Error linting the API project "+args[0], err)
+		}
+		if !utils.PrintStructuredOutput(violations, lintOutput) {
+			impl.PrintViolations(violations, "table")
+		}
+		highestSeverity := impl.GetHighestLintSeverity(violations)
+		if highestSeverity == "" {
+			if lintOutput == "" {
+				fmt.Println("No violations found in " + args[0])
+			}
+			return
+		}
+		if impl.LintSeverityRank(highestSeverity) <= impl.LintSeverityRank(lintFailSeverity) {
+			utils.HandleErrorAndExit("Linting failed. Found violations of severity "+highestSeverity, nil)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(LintCmd)
+	LintCmd.Flags().StringSliceVarP(&lintRulesets, "ruleset", "r", []string{},
+		"Spectral compatible ruleset to be evaluated. Can be provided multiple times")
+	LintCmd.Flags().StringVarP(&lintParamsFile, "params", "", "",
+		"Params file or deployment directory whose production endpoints should be checked")
+	LintCmd.Flags().StringSliceVarP(&lintProductionEnvs, "production-envs", "", []string{"production", "prod"},
+		"Environments of the params file treated as production environments")
+	LintCmd.Flags().BoolVarP(&lintSkipBuiltInRules, "skip-built-in-rules", "", false,
+		"Evaluate only the rulesets given using --ruleset")
+	LintCmd.Flags().StringVarP(&lintFailSeverity, "fail-severity", "", impl.LintSeverityError,
+		"Lowest severity of the violations which fail the command (error, warn, info or hint)")
+	formatter.AddOutputFlag(LintCmd.Flags(), &lintOutput)
+}
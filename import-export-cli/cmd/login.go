@@ -20,9 +20,11 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 
@@ -38,14 +40,27 @@ var loginPasswordStdin bool
 var clientId string
 var clientSecret string
 var personalAccessToken string
+var loginMigrateStore bool
+var loginGrantType string
+var loginAssertionFile string
 
 const loginCmdLiteral = "login [environment] [flags]"
 const loginCmdShortDesc = "Login to an API Manager"
-const loginCmdLongDesc = `Login to an API Manager using credentials or set token for authentication`
+const loginCmdLongDesc = `Login to an API Manager using credentials or set token for authentication.
+Use --grant to login without a username and password:
+  client_credentials: get tokens for the application given by --client-id and --client-secret
+  device_code: authorize the login in a browser using the verification URL printed by the command
+  jwt-bearer: exchange the JWT in --assertion-file for tokens. The file is read again whenever a new token is needed
+Use --migrate-store to move the credentials saved in keys.json to the credential store configured in main_config.yaml`
 const loginCmdExamples = utils.ProjectName + " login dev -u admin -p admin\n" +
 	utils.ProjectName + " login dev -u admin\n" +
 	"cat ~/.mypassword | " + utils.ProjectName + " login dev -u admin\n" +
-	utils.ProjectName + " login dev --token e79bda48-3406-3178-acce-f6e4dbdcbb12"
+	utils.ProjectName + " login dev --token e79bda48-3406-3178-acce-f6e4dbdcbb12\n" +
+	utils.ProjectName + " login dev --grant client_credentials --client-id <client id> --client-secret <client secret>\n" +
+	utils.ProjectName + " login dev --grant device_code --client-id <client id> --client-secret <client secret>\n" +
+	utils.ProjectName + " login dev --grant jwt-bearer --client-id <client id> --client-secret <client secret> " +
+	"--assertion-file /var/run/secrets/ci/token\n" +
+	utils.ProjectName + " login --migrate-store"
 
 // loginCmd represents the login command
 var loginCmd = &cobra.Command{
@@ -53,15 +68,34 @@ var loginCmd = &cobra.Command{
 	Short:   loginCmdShortDesc,
 	Long:    loginCmdLongDesc,
 	Example: loginCmdExamples,
-	Args:    cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if loginMigrateStore {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if loginMigrateStore {
+			err := runMigrateStore()
+			if err != nil {
+				fmt.Println("Error occurred while migrating the credential store : ", err)
+				os.Exit(1)
+			}
+			return
+		}
 		environment := args[0]
 		store, err := credentials.GetDefaultCredentialStore()
 		if err != nil {
 			fmt.Println("Error occurred while loading credential store : ", err)
 			os.Exit(1)
 		}
-		if personalAccessToken != "" {
+		if loginGrantType != "" && loginGrantType != utils.LoginGrantPassword {
+			err = runGrantLogin(store, environment, loginGrantType, clientId, clientSecret, loginAssertionFile)
+			if err != nil {
+				fmt.Println("Error occurred while login using the "+loginGrantType+" grant : ", err)
+				os.Exit(1)
+			}
+		} else if personalAccessToken != "" {
 			err = runLogin(store, environment, loginUsername, loginPassword, personalAccessToken)
 			if err != nil {
 				fmt.Println("Error occurred while login using the token : ", err)
@@ -142,6 +176,120 @@ func runLogin(store credentials.Store, environment, username, password, personal
 	return nil
 }
 
+// runGrantLogin gets tokens for env using a grant other than the password grant and saves the client credentials
+// in the store, so that new tokens can be requested with the same grant once the tokens expire
+func runGrantLogin(store credentials.Store, environment, grantType, clientId, clientSecret, assertionFile string) error {
+	if !utils.APIMExistsInEnv(environment, utils.MainConfigFilePath) {
+		fmt.Println("APIM does not exists in", environment, "Add it using add env")
+		os.Exit(1)
+	}
+	if clientId == "" || clientSecret == "" {
+		return errors.New("--client-id and --client-secret are required to login using the " + grantType + " grant")
+	}
+	if assertionFile != "" && grantType != utils.LoginGrantJWTBearer {
+		return errors.New("--assertion-file can only be used with the " + utils.LoginGrantJWTBearer + " grant")
+	}
+
+	b64EncodedClientIDClientSecret := utils.GetBase64EncodedCredentials(clientId, clientSecret)
+	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(environment, utils.MainConfigFilePath)
+	var tokenResponse *utils.OAuthTokenResponse
+	var err error
+	switch grantType {
+	case utils.LoginGrantClientCredentials:
+		tokenResponse, err = utils.GetOAuthTokensWithClientCredentialsGrant(utils.DefaultOAuthScopes,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+	case utils.LoginGrantJWTBearer:
+		if assertionFile == "" {
+			return errors.New("--assertion-file is required to login using the " + grantType + " grant")
+		}
+		// the file is read again on each token request, so the path should not depend on the working directory
+		assertionFile, err = filepath.Abs(assertionFile)
+		if err != nil {
+			return err
+		}
+		var assertion string
+		assertion, err = credentials.ReadAssertionFile(assertionFile)
+		if err != nil {
+			return err
+		}
+		tokenResponse, err = utils.GetOAuthTokensWithJWTBearerGrant(assertion, utils.DefaultOAuthScopes,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+	case utils.LoginGrantDeviceCode:
+		tokenResponse, err = runDeviceAuthorization(environment, clientId, b64EncodedClientIDClientSecret,
+			tokenEndpoint)
+	default:
+		return fmt.Errorf("unsupported grant type %q, supported grant types are %s, %s, %s and %s", grantType,
+			utils.LoginGrantPassword, utils.LoginGrantClientCredentials, utils.LoginGrantDeviceCode,
+			utils.LoginGrantJWTBearer)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = store.SetAPIMGrant(environment, grantType, clientId, clientSecret, assertionFile)
+	if err != nil {
+		return err
+	}
+	credential, err := store.GetAPIMCredentials(environment)
+	if err != nil {
+		return err
+	}
+	err = credentials.CacheOAuthTokens(store, credential, environment, tokenResponse)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Logged into APIM in ", environment, "environment")
+	return nil
+}
+
+// runDeviceAuthorization prints the verification URL for the user and waits until the login is authorized
+func runDeviceAuthorization(environment, clientId, b64EncodedClientIDClientSecret,
+	tokenEndpoint string) (*utils.OAuthTokenResponse, error) {
+	deviceAuthorizeEndpoint := utils.GetDeviceAuthorizeEndpoint(environment, utils.MainConfigFilePath)
+	deviceAuthorization, err := utils.RequestDeviceAuthorization(clientId, utils.DefaultOAuthScopes,
+		b64EncodedClientIDClientSecret, deviceAuthorizeEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	verificationURL := deviceAuthorization.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = deviceAuthorization.VerificationURI
+	}
+	fmt.Println("Open the following URL in a browser to authorize the login:")
+	fmt.Println("  " + verificationURL)
+	fmt.Println("Code: " + deviceAuthorization.UserCode)
+	fmt.Println("Waiting for the authorization...")
+	return utils.WaitForDeviceAuthorization(deviceAuthorization, clientId, b64EncodedClientIDClientSecret,
+		tokenEndpoint)
+}
+
+// runMigrateStore moves the credentials in keys.json to the store configured in main_config.yaml
+func runMigrateStore() error {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	storeConfig := mainConfig.Config.CredentialStore
+	if storeConfig.Type == "" || storeConfig.Type == credentials.JsonStoreType {
+		return errors.New("credential store in " + utils.MainConfigFilePath + " is the json store, " +
+			"set config.credential_store.type to the store the credentials should be migrated to")
+	}
+	source, err := credentials.GetDefaultJsonStore()
+	if err != nil {
+		return err
+	}
+	target, err := credentials.NewCredentialStore(storeConfig)
+	if err != nil {
+		return err
+	}
+	envs, err := credentials.MigrateJsonStore(source, target, storeConfig.Type)
+	if err != nil {
+		return err
+	}
+	for _, env := range envs {
+		fmt.Println("Migrated credentials of", env, "environment to the", storeConfig.Type, "store")
+	}
+	fmt.Println("Credential store migration completed. Migrated", len(envs), "environment(s)")
+	return nil
+}
+
 // GetCredentials function gets the credentials for the specified environment
 func GetCredentials(env string) (credentials.Credential, error) {
 	// get tokens or login
@@ -179,4 +327,13 @@ func init() {
 	loginCmd.Flags().StringVarP(&loginPassword, "password", "p", "", "Password for login")
 	loginCmd.Flags().BoolVarP(&loginPasswordStdin, "password-stdin", "", false, "Get password from stdin")
 	loginCmd.Flags().StringVarP(&personalAccessToken, "token", "", "", "Personal access token")
+	loginCmd.Flags().StringVarP(&loginGrantType, "grant", "", utils.LoginGrantPassword,
+		"Grant type used to get tokens (password|client_credentials|device_code|jwt-bearer)")
+	loginCmd.Flags().StringVarP(&clientId, "client-id", "", "", "Client ID of the application used to login")
+	loginCmd.Flags().StringVarP(&clientSecret, "client-secret", "", "",
+		"Client secret of the application used to login")
+	loginCmd.Flags().StringVarP(&loginAssertionFile, "assertion-file", "", "",
+		"File holding the JWT exchanged for tokens using the jwt-bearer grant")
+	loginCmd.Flags().BoolVarP(&loginMigrateStore, "migrate-store", "", false,
+		"Move the credentials in keys.json to the credential store configured in main_config.yaml")
 }
@@ -29,7 +29,8 @@ import (
 
 const logoutCmdLiteral = "logout [environment]"
 const logoutCmdShortDesc = "Logout to from an API Manager"
-const logoutCmdLongDesc = `Logout from an API Manager environment`
+const logoutCmdLongDesc = `Logout from an API Manager environment. The cached access and refresh tokens of the environment are
+revoked and removed along with the credentials`
 const logoutCmdExamples = utils.ProjectName + " logout dev"
 
 // logoutCmd represents the logout command
@@ -49,17 +50,21 @@ var logoutCmd = &cobra.Command{
 }
 
 func runLogout(environment string) error {
-	cred, err := GetCredentials(environment)
-	//Get current access token for
-	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
-	error := credentials.RevokeAccessToken(cred, environment, accessToken)
-	if error != nil {
+	store, err := credentials.GetDefaultCredentialStore()
+	if err != nil {
 		return err
 	}
-	store, err := credentials.GetDefaultCredentialStore()
+	cred, err := store.GetAPIMCredentials(environment)
 	if err != nil {
 		return err
 	}
+	// revoke the cached access and refresh tokens, the credentials are removed even if the revocation fails
+	if cred.PersonalAccessToken == "" {
+		err = credentials.RevokeCachedTokens(store, cred, environment)
+		if err != nil {
+			utils.HandleErrorAndContinue("Error while revoking the tokens of "+environment, err)
+		}
+	}
 	fmt.Println("Logged out from APIM in ", environment, " environment")
 	return store.EraseAPIM(environment)
 }
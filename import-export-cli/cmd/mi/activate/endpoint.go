@@ -56,6 +56,15 @@ func handleActivateEndpointCmdArguments(args []string) {
 }
 
 func executeActivateEndpoint(endpointName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(activateEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateEndpoint(nodeEnv, endpointName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactEndpoint, endpointName, err)
+		}
+		return
+	}
 	resp, err := impl.ActivateEndpoint(activateEndpointCmdEnvironment, endpointName)
 	if err != nil {
 		printErrorForArtifact(artifactEndpoint, endpointName, err)
@@ -56,6 +56,15 @@ func handleActivateMessageProcessorCmdArguments(args []string) {
 }
 
 func executeActivateMessageProcessor(messageProcessorName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(activateMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateMessageProcessor(nodeEnv, messageProcessorName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
+		}
+		return
+	}
 	resp, err := impl.ActivateMessageProcessor(activateMessageProcessorCmdEnvironment, messageProcessorName)
 	if err != nil {
 		printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
@@ -56,6 +56,15 @@ func handleActivateProxyCmdArguments(args []string) {
 }
 
 func executeActivateProxy(proxyName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(activateProxyCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.ActivateProxy(nodeEnv, proxyName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactProxy, proxyName, err)
+		}
+		return
+	}
 	resp, err := impl.ActivateProxy(activateProxyCmdEnvironment, proxyName)
 	if err != nil {
 		printErrorForArtifact(artifactProxy, proxyName, err)
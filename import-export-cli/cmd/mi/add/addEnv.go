@@ -26,6 +26,7 @@ import (
 
 var envToBeAdded string         // Name of the environment to be added
 var miManagementEndpoint string // mi management endpoint of the environment to be added
+var miNodeEndpoints []string    // management endpoints of the nodes of the mi cluster of the environment
 
 // AddEnv command related Info
 const AddEnvCmdLiteral = "env [environment] [mi_management_url]"
@@ -56,6 +57,7 @@ var addEnvCmd = &cobra.Command{
 func executeAddEnvCmd(mainConfigFilePath string) {
 	envEndpoints := new(utils.EnvEndpoints)
 	envEndpoints.MiManagementEndpoint = miManagementEndpoint
+	envEndpoints.MiNodeEndpoints = miNodeEndpoints
 	err := impl.AddMIEnv(envToBeAdded, envEndpoints, mainConfigFilePath, AddEnvCmdLiteral)
 	if err != nil {
 		utils.HandleErrorAndExit("Error adding environment", err)
@@ -66,5 +68,7 @@ func executeAddEnvCmd(mainConfigFilePath string) {
 func init() {
 	if utils.GetMICmdName() == "" {
 		AddCmd.AddCommand(addEnvCmd)
+		addEnvCmd.Flags().StringSliceVar(&miNodeEndpoints, "node", []string{},
+			"Management endpoint of a node of the Micro Integrator cluster, repeat for each node")
 	}
 }
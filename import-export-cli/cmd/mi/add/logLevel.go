@@ -64,6 +64,15 @@ func handleAddLogLevelCmdArguments(args []string) {
 }
 
 func executeAddNewLogger(loggerName, logClass, logLevel string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(addLogLevelCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.AddMILogger(nodeEnv, loggerName, logClass, logLevel)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"Adding new logger [ "+loggerName+" ] ", err)
+		}
+		return
+	}
 	resp, err := impl.AddMILogger(addLogLevelCmdEnvironment, loggerName, logClass, logLevel)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"Adding new logger [ "+loggerName+" ] ", err)
@@ -77,6 +77,15 @@ func startConsoleToAddRole(roleName string) {
 }
 
 func executeAddNewRole(roleName, domain string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(addRoleCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.AddMIRole(nodeEnv, roleName, domain)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"Adding new role [ "+roleName+" ]", err)
+		}
+		return
+	}
 	resp, err := impl.AddMIRole(addRoleCmdEnvironment, roleName, domain)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"Adding new role [ "+roleName+" ]", err)
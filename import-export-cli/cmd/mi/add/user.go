@@ -96,6 +96,15 @@ func startConsoleToAddUser(userName string) {
 }
 
 func executeAddNewUser(userName, userPassword, isAdmin, domain string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(addUserCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.AddMIUser(nodeEnv, userName, userPassword, isAdmin, domain)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"Adding new user [ "+userName+" ]", err)
+		}
+		return
+	}
 	resp, err := impl.AddMIUser(addUserCmdEnvironment, userName, userPassword, isAdmin, domain)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"Adding new user [ "+userName+" ]", err)
@@ -56,6 +56,15 @@ func handleDeactivateEndpointCmdArguments(args []string) {
 }
 
 func executeDeactivateEndpoint(endpointName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deactivateEndpointCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateEndpoint(nodeEnv, endpointName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactEndpoint, endpointName, err)
+		}
+		return
+	}
 	resp, err := impl.DeactivateEndpoint(deactivateEndpointCmdEnvironment, endpointName)
 	if err != nil {
 		printErrorForArtifact(artifactEndpoint, endpointName, err)
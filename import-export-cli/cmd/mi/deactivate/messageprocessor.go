@@ -56,6 +56,15 @@ func handleDeactivateMessageProcessorCmdArguments(args []string) {
 }
 
 func executeDeactivateMessageProcessor(messageProcessorName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deactivateMessageProcessorCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateMessageProcessor(nodeEnv, messageProcessorName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
+		}
+		return
+	}
 	resp, err := impl.DeactivateMessageProcessor(deactivateMessageProcessorCmdEnvironment, messageProcessorName)
 	if err != nil {
 		printErrorForArtifact(artifactMessageProcessor, messageProcessorName, err)
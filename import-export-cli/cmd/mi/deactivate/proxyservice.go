@@ -56,6 +56,15 @@ func handleDeactivateProxyCmdArguments(args []string) {
 }
 
 func executeDeactivateProxy(proxyName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deactivateProxyCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeactivateProxy(nodeEnv, proxyName)
+		})
+		if err != nil {
+			printErrorForArtifact(artifactProxy, proxyName, err)
+		}
+		return
+	}
 	resp, err := impl.DeactivateProxy(deactivateProxyCmdEnvironment, proxyName)
 	if err != nil {
 		printErrorForArtifact(artifactProxy, proxyName, err)
@@ -68,6 +68,15 @@ func handledeleteRoleCmdArguments(args []string) {
 }
 
 func executeDeleteRole(roleName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deleteRoleCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeleteMIRole(nodeEnv, roleName, deleteRoleCmdDomain)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError + "deleting role [ "+roleName+" ]", err)
+		}
+		return
+	}
 	resp, err := impl.DeleteMIRole(deleteRoleCmdEnvironment, roleName, deleteRoleCmdDomain)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError + "deleting role [ "+roleName+" ]", err)
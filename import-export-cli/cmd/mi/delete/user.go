@@ -68,6 +68,15 @@ func handledeleteUserCmdArguments(args []string) {
 }
 
 func executeDeleteUser(userName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deleteUserCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.DeleteMIUser(nodeEnv, userName, deleteUserCmdDomain)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"deleting user [ "+userName+" ]", err)
+		}
+		return
+	}
 	resp, err := impl.DeleteMIUser(deleteUserCmdEnvironment, userName, deleteUserCmdDomain)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"deleting user [ "+userName+" ]", err)
@@ -0,0 +1,92 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var deployCarCmdEnvironment string
+var deployCarCmdFile string
+var deployCarCmdReplace bool
+
+const deployCarCmdLiteral = "car"
+const deployCarCmdShortDesc = "Deploy a composite app to a Micro Integrator"
+
+const deployCarCmdLongDesc = "Deploy the composite app (.car file) specified by the flag --file, -f to a Micro Integrator in the environment specified by the flag --environment, -e"
+
+var deployCarCmdExamples = "To deploy a composite app\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + deployCmdLiteral + " " + deployCarCmdLiteral + " -f SampleApp_1.0.0.car -e dev\n" +
+	"To replace a composite app which is already deployed\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + deployCmdLiteral + " " + deployCarCmdLiteral + " -f SampleApp_1.0.1.car -e dev --replace\n" +
+	"To wait until the artifacts of the composite app are deployed\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " get composite-apps --watch-deploy SampleApp_1.0.0.car -e dev\n" +
+	"NOTE: Both the flags (--file (-f) and --environment (-e)) are mandatory"
+
+var deployCarCmd = &cobra.Command{
+	Use:     deployCarCmdLiteral,
+	Short:   deployCarCmdShortDesc,
+	Long:    deployCarCmdLongDesc,
+	Example: deployCarCmdExamples,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		handleDeployCarCmdArguments()
+	},
+}
+
+func init() {
+	DeployCmd.AddCommand(deployCarCmd)
+	deployCarCmd.Flags().StringVarP(&deployCarCmdFile, "file", "f", "", "Path of the composite app (.car file) to be deployed")
+	deployCarCmd.Flags().BoolVar(&deployCarCmdReplace, "replace", false,
+		"Undeploy the composite app with the same name first if it is already deployed")
+	deployCarCmd.Flags().StringVarP(&deployCarCmdEnvironment, "environment", "e", "", "Environment of the micro integrator to which the composite app should be deployed")
+	deployCarCmd.MarkFlagRequired("file")
+	deployCarCmd.MarkFlagRequired("environment")
+}
+
+func handleDeployCarCmdArguments() {
+	utils.Logln(utils.LogPrefixInfo + deployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(deployCarCmdLiteral) + " called")
+	credentials.HandleMissingCredentials(deployCarCmdEnvironment)
+	executeDeployCar()
+}
+
+func executeDeployCar() {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(deployCarCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			_, resp, err := impl.DeployCompositeApp(nodeEnv, deployCarCmdFile, deployCarCmdReplace)
+			return resp, err
+		})
+		if err != nil {
+			utils.HandleErrorAndExit("Error deploying composite app "+deployCarCmdFile, err)
+		}
+		return
+	}
+	car, resp, err := impl.DeployCompositeApp(deployCarCmdEnvironment, deployCarCmdFile, deployCarCmdReplace)
+	if err != nil {
+		utils.HandleErrorAndExit("Error deploying composite app "+deployCarCmdFile+". Use --replace to "+
+			"replace a composite app which is already deployed", err)
+	}
+	fmt.Println("Deploying composite app [ "+car.Name+" ] version [ "+car.Version+" ] status:", resp)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetIntegrationAPICmdArguments(args []string) {
 }
 
 func executeListIntegrationAPIs() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getIntegrationAPICmdEnvironment, artifactAPIs, getIntegrationAPICmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetIntegrationAPIList(nodeEnv)
+		})
+		return
+	}
 	apiList, err := impl.GetIntegrationAPIList(getIntegrationAPICmdEnvironment)
 	if err == nil {
 		impl.PrintIntegrationAPIList(apiList, getIntegrationAPICmdFormat)
@@ -70,6 +77,14 @@ func executeListIntegrationAPIs() {
 }
 
 func executeShowIntegrationAPI(apiName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getIntegrationAPICmdEnvironment, artifactAPIs, apiName, getIntegrationAPICmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetIntegrationAPI(nodeEnv, apiName)
+		}, func(details interface{}) {
+			impl.PrintIntegrationAPIDetails(details.(*artifactutils.IntegrationAPI), getIntegrationAPICmdFormat)
+		})
+		return
+	}
 	integrationAPI, err := impl.GetIntegrationAPI(getIntegrationAPICmdEnvironment, apiName)
 	if err == nil {
 		impl.PrintIntegrationAPIDetails(integrationAPI, getIntegrationAPICmdFormat)
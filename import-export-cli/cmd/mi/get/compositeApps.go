@@ -19,23 +19,42 @@
 package get
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var getApplicationCmdEnvironment string
 var getApplicationCmdFormat string
+var getApplicationCmdWatchDeploy string
+var getApplicationCmdWatchTimeout time.Duration
+
+// compositeAppWatchInterval is the time between two polls of the composite apps when watching a deployment
+const compositeAppWatchInterval = 5 * time.Second
 
 const artifactCompositeApps = "composite apps"
 const getApplicationCmdLiteral = "composite-apps [app-name]"
 
+var getApplicationCmdExamples = "To list all the " + artifactCompositeApps + "\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getApplicationCmdLiteral) + " -e dev\n" +
+	"To get details about a specific " + artifactCompositeApps + "\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getApplicationCmdLiteral) + " SampleApp -e dev\n" +
+	"To wait until all the artifacts of a composite app are deployed\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + GetCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(getApplicationCmdLiteral) + " --watch-deploy SampleApp_1.0.0.car -e dev\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
 var getApplicationCmd = &cobra.Command{
 	Use:     getApplicationCmdLiteral,
 	Short:   generateGetCmdShortDescForArtifact(artifactCompositeApps),
 	Long:    generateGetCmdLongDescForArtifact(artifactCompositeApps, "app-name"),
-	Example: generateGetCmdExamplesForArtifact(artifactCompositeApps, miUtils.GetTrimmedCmdLiteral(getApplicationCmdLiteral), "SampleApp"),
+	Example: getApplicationCmdExamples,
 	Args:    cobra.MaximumNArgs(1),
 	Deprecated: "instead refer to https://mi.docs.wso2.com/en/latest/observe-and-manage/managing-integrations-with-micli/ for updated usage.",
 	Run: func(cmd *cobra.Command, args []string) {
@@ -47,12 +66,21 @@ func init() {
 	GetCmd.AddCommand(getApplicationCmd)
 	setEnvFlag(getApplicationCmd, &getApplicationCmdEnvironment)
 	setFormatFlag(getApplicationCmd, &getApplicationCmdFormat)
+	getApplicationCmd.Flags().StringVar(&getApplicationCmdWatchDeploy, "watch-deploy", "",
+		"Path of a composite app (.car file). Wait until all its artifacts are reported as deployed")
+	getApplicationCmd.Flags().DurationVar(&getApplicationCmdWatchTimeout, "watch-timeout", 5*time.Minute,
+		"Maximum time to wait for the artifacts with --watch-deploy")
 }
 
 func handleGetApplicationCmdArguments(args []string) {
 	printGetCmdVerboseLogForArtifact(miUtils.GetTrimmedCmdLiteral(getApplicationCmdLiteral))
 	credentials.HandleMissingCredentials(getApplicationCmdEnvironment)
-	if len(args) == 1 {
+	if getApplicationCmdWatchDeploy != "" {
+		if len(args) == 1 {
+			utils.HandleErrorAndExit("The flag --watch-deploy cannot be used with an app name", nil)
+		}
+		executeWatchCarbonAppDeployment(getApplicationCmdWatchDeploy)
+	} else if len(args) == 1 {
 		var appName = args[0]
 		executeShowCarbonApp(appName)
 	} else {
@@ -61,6 +89,12 @@ func handleGetApplicationCmdArguments(args []string) {
 }
 
 func executeListCarbonApps() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getApplicationCmdEnvironment, artifactCompositeApps, getApplicationCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetCompositeAppList(nodeEnv)
+		})
+		return
+	}
 	appList, err := impl.GetCompositeAppList(getApplicationCmdEnvironment)
 	if err == nil {
 		impl.PrintCompositeAppList(appList, getApplicationCmdFormat)
@@ -70,6 +104,14 @@ func executeListCarbonApps() {
 }
 
 func executeShowCarbonApp(appname string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getApplicationCmdEnvironment, artifactCompositeApps, appname, getApplicationCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetCompositeApp(nodeEnv, appname)
+		}, func(details interface{}) {
+			impl.PrintCompositeAppDetails(details.(*artifactutils.CompositeApp), getApplicationCmdFormat)
+		})
+		return
+	}
 	app, err := impl.GetCompositeApp(getApplicationCmdEnvironment, appname)
 	if err == nil {
 		impl.PrintCompositeAppDetails(app, getApplicationCmdFormat)
@@ -77,3 +119,35 @@ func executeShowCarbonApp(appname string) {
 		printErrorForArtifact(artifactCompositeApps, appname, err)
 	}
 }
+
+func executeWatchCarbonAppDeployment(carPath string) {
+	car, err := impl.ReadCarbonApplication(carPath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading composite app "+carPath, err)
+	}
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(getApplicationCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			deployment, err := impl.WatchCompositeAppDeployment(nodeEnv, car, compositeAppWatchInterval,
+				getApplicationCmdWatchTimeout)
+			if err != nil && deployment != nil && len(deployment.Failed) > 0 {
+				return nil, fmt.Errorf("%v, failed artifacts: %s", err, strings.Join(deployment.FailedArtifactNames(), ", "))
+			}
+			if err != nil {
+				return nil, err
+			}
+			return fmt.Sprintf("%d artifact(s) deployed", len(deployment.Deployed)), nil
+		})
+		if err != nil {
+			utils.HandleErrorAndExit("Error watching the deployment of composite app "+car.Name, err)
+		}
+		return
+	}
+	deployment, err := impl.WatchCompositeAppDeployment(getApplicationCmdEnvironment, car,
+		compositeAppWatchInterval, getApplicationCmdWatchTimeout)
+	if deployment != nil {
+		impl.PrintCompositeAppDeployment(deployment, getApplicationCmdFormat)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error watching the deployment of composite app "+car.Name, err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -62,6 +63,12 @@ func handleGetConnectorCmdArguments(args []string) {
 }
 
 func executeListConnectors() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getConnectorCmdEnvironment, getConnectorCmdLiteral, getConnectorCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetConnectorList(nodeEnv)
+		})
+		return
+	}
 	connectorList, err := impl.GetConnectorList(getConnectorCmdEnvironment)
 	if err == nil {
 		impl.PrintConnectorList(connectorList, getConnectorCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetDataServiceCmdArguments(args []string) {
 }
 
 func executeListDataServices() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getDataServiceCmdEnvironment, artifactDataServices, getDataServiceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetDataServiceList(nodeEnv)
+		})
+		return
+	}
 	dataServiceList, err := impl.GetDataServiceList(getDataServiceCmdEnvironment)
 	if err == nil {
 		impl.PrintDataServiceList(dataServiceList, getDataServiceCmdFormat)
@@ -70,6 +77,14 @@ func executeListDataServices() {
 }
 
 func executeShowDataService(dataserviceName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getDataServiceCmdEnvironment, artifactDataServices, dataserviceName, getDataServiceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetDataService(nodeEnv, dataserviceName)
+		}, func(details interface{}) {
+			impl.PrintDataServiceDetails(details.(*artifactutils.DataServiceInfo), getDataServiceCmdFormat)
+		})
+		return
+	}
 	dataservice, err := impl.GetDataService(getDataServiceCmdEnvironment, dataserviceName)
 	if err == nil {
 		impl.PrintDataServiceDetails(dataservice, getDataServiceCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetEndpointCmdArguments(args []string) {
 }
 
 func executeListEndpoints() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getEndpointCmdEnvironment, artifactEndpoints, getEndpointCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetEndpointList(nodeEnv)
+		})
+		return
+	}
 	epList, err := impl.GetEndpointList(getEndpointCmdEnvironment)
 	if err == nil {
 		impl.PrintEndpointList(epList, getEndpointCmdFormat)
@@ -70,6 +77,14 @@ func executeListEndpoints() {
 }
 
 func executeShowEndpoint(epName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getEndpointCmdEnvironment, artifactEndpoints, epName, getEndpointCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetEndpoint(nodeEnv, epName)
+		}, func(details interface{}) {
+			impl.PrintEndpointDetails(details.(*artifactutils.Endpoint), getEndpointCmdFormat)
+		})
+		return
+	}
 	endpoint, err := impl.GetEndpoint(getEndpointCmdEnvironment, epName)
 	if err == nil {
 		impl.PrintEndpointDetails(endpoint, getEndpointCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetInboundEndpointCmdArguments(args []string) {
 }
 
 func executeListInboundEndpoints() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getInboundEndpointCmdEnvironment, artifactInboundEndpoints, getInboundEndpointCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetInboundEndpointList(nodeEnv)
+		})
+		return
+	}
 	inboundEpList, err := impl.GetInboundEndpointList(getInboundEndpointCmdEnvironment)
 	if err == nil {
 		impl.PrintInboundEndpointList(inboundEpList, getInboundEndpointCmdFormat)
@@ -70,6 +77,14 @@ func executeListInboundEndpoints() {
 }
 
 func executeShowInboundEndpoint(inboundEpName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getInboundEndpointCmdEnvironment, artifactInboundEndpoints, inboundEpName, getInboundEndpointCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetInboundEndpoint(nodeEnv, inboundEpName)
+		}, func(details interface{}) {
+			impl.PrintInboundEndpointDetails(details.(*artifactutils.InboundEndpoint), getInboundEndpointCmdFormat)
+		})
+		return
+	}
 	inboundEndpoint, err := impl.GetInboundEndpoint(getInboundEndpointCmdEnvironment, inboundEpName)
 	if err == nil {
 		impl.PrintInboundEndpointDetails(inboundEndpoint, getInboundEndpointCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetLocalEntryCmdArguments(args []string) {
 }
 
 func executeListLocalEntrys() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getLocalEntryCmdEnvironment, artifactLocalEntries, getLocalEntryCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLocalEntryList(nodeEnv)
+		})
+		return
+	}
 	localEntryList, err := impl.GetLocalEntryList(getLocalEntryCmdEnvironment)
 	if err == nil {
 		impl.PrintLocalEntryList(localEntryList, getLocalEntryCmdFormat)
@@ -70,6 +77,14 @@ func executeListLocalEntrys() {
 }
 
 func executeShowLocalEntry(localEntryName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getLocalEntryCmdEnvironment, artifactLocalEntries, localEntryName, getLocalEntryCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLocalEntry(nodeEnv, localEntryName)
+		}, func(details interface{}) {
+			impl.PrintLocalEntryDetails(details.(*artifactutils.LocalEntryData), getLocalEntryCmdFormat)
+		})
+		return
+	}
 	localEntry, err := impl.GetLocalEntry(getLocalEntryCmdEnvironment, localEntryName)
 	if err == nil {
 		impl.PrintLocalEntryDetails(localEntry, getLocalEntryCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -64,6 +65,14 @@ func handleGetLogLevelCmdArguments(args []string) {
 }
 
 func executeShowLogLevel(loggerName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getLogLevelCmdEnvironment, "logger", loggerName, getLogLevelCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetLoggerInfo(nodeEnv, loggerName)
+		}, func(details interface{}) {
+			impl.PrintLoggerInfo(details.(*artifactutils.Logger), getLogLevelCmdFormat)
+		})
+		return
+	}
 	LogLevelList, err := impl.GetLoggerInfo(getLogLevelCmdEnvironment, loggerName)
 	if err == nil {
 		impl.PrintLoggerInfo(LogLevelList, getLogLevelCmdFormat)
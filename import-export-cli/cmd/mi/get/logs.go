@@ -20,6 +20,7 @@ package get
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -79,6 +80,16 @@ func handleGetLogCmdArguments(args []string) {
 }
 
 func executeListLogFiles() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getLogCmdEnvironment, "log files", getLogCmdFormat, func(nodeEnv string) (interface{}, error) {
+			fileList, err := impl.GetLogFileList(nodeEnv)
+			if err != nil {
+				return nil, err
+			}
+			return impl.FilterOnlyLogFiles(fileList), nil
+		})
+		return
+	}
 	fileList, err := impl.GetLogFileList(getLogCmdEnvironment)
 	if err == nil {
 		logFileList := impl.FilterOnlyLogFiles(fileList)
@@ -89,6 +100,27 @@ func executeListLogFiles() {
 }
 
 func executeDownloadLogFile(targetDirectory, logFileName string) {
+	if miUtils.AllNodes {
+		result, err := impl.RunOnAllNodes(getLogCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			logFile, err := impl.GetLogFile(nodeEnv, logFileName)
+			if err != nil {
+				return nil, err
+			}
+			_, node := utils.SplitMINodeEnv(nodeEnv)
+			nodeDirectory, err := impl.GetNodeDirectory(targetDirectory, node)
+			if err != nil {
+				return nil, err
+			}
+			filePath := filepath.Join(nodeDirectory, logFileName)
+			return filePath, os.WriteFile(filePath, logFile, 0644)
+		})
+		if err != nil {
+			printErrorForArtifact("log file", logFileName, err)
+			return
+		}
+		impl.PrintClusterStateChanges(result, "")
+		return
+	}
 	logFile, err := impl.GetLogFile(getLogCmdEnvironment, logFileName)
 	if err == nil {
 		impl.WriteLogFile(logFile, targetDirectory+"/"+logFileName)
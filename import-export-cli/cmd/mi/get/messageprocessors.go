@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetMessageProcessorCmdArguments(args []string) {
 }
 
 func executeListMessageProcessors() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getMessageProcessorCmdEnvironment, artifactMessageProcessors, getMessageProcessorCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageProcessorList(nodeEnv)
+		})
+		return
+	}
 	msgProcessorList, err := impl.GetMessageProcessorList(getMessageProcessorCmdEnvironment)
 	if err == nil {
 		impl.PrintMessageProcessorList(msgProcessorList, getMessageProcessorCmdFormat)
@@ -70,6 +77,14 @@ func executeListMessageProcessors() {
 }
 
 func executeShowMessageProcessor(msgProcessorName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getMessageProcessorCmdEnvironment, artifactMessageProcessors, msgProcessorName, getMessageProcessorCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageProcessor(nodeEnv, msgProcessorName)
+		}, func(details interface{}) {
+			impl.PrintMessageProcessorDetails(details.(*artifactutils.MessageProcessorData), getMessageProcessorCmdFormat)
+		})
+		return
+	}
 	msgProcessor, err := impl.GetMessageProcessor(getMessageProcessorCmdEnvironment, msgProcessorName)
 	if err == nil {
 		impl.PrintMessageProcessorDetails(msgProcessor, getMessageProcessorCmdFormat)
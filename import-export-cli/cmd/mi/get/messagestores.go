@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetMessageStoreCmdArguments(args []string) {
 }
 
 func executeListMessageStores() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getMessageStoreCmdEnvironment, artifactMessageStores, getMessageStoreCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageStoreList(nodeEnv)
+		})
+		return
+	}
 	messageStoreList, err := impl.GetMessageStoreList(getMessageStoreCmdEnvironment)
 	if err == nil {
 		impl.PrintMessageStoreList(messageStoreList, getMessageStoreCmdFormat)
@@ -70,6 +77,14 @@ func executeListMessageStores() {
 }
 
 func executeShowMessageStore(messageStoreName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getMessageStoreCmdEnvironment, artifactMessageStores, messageStoreName, getMessageStoreCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetMessageStore(nodeEnv, messageStoreName)
+		}, func(details interface{}) {
+			impl.PrintMessageStoreDetails(details.(*artifactutils.MessageStoreData), getMessageStoreCmdFormat)
+		})
+		return
+	}
 	messageStore, err := impl.GetMessageStore(getMessageStoreCmdEnvironment, messageStoreName)
 	if err == nil {
 		impl.PrintMessageStoreDetails(messageStore, getMessageStoreCmdFormat)
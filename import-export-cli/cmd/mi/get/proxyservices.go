@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetProxyServiceCmdArguments(args []string) {
 }
 
 func executeListProxyServices() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getProxyServiceCmdEnvironment, artifactProxyServices, getProxyServiceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetProxyServiceList(nodeEnv)
+		})
+		return
+	}
 	proxyList, err := impl.GetProxyServiceList(getProxyServiceCmdEnvironment)
 	if err == nil {
 		impl.PrintProxyServiceList(proxyList, getProxyServiceCmdFormat)
@@ -70,6 +77,14 @@ func executeListProxyServices() {
 }
 
 func executeShowProxyService(proxyName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getProxyServiceCmdEnvironment, artifactProxyServices, proxyName, getProxyServiceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetProxyService(nodeEnv, proxyName)
+		}, func(details interface{}) {
+			impl.PrintProxyServiceDetails(details.(*artifactutils.Proxy), getProxyServiceCmdFormat)
+		})
+		return
+	}
 	proxyService, err := impl.GetProxyService(getProxyServiceCmdEnvironment, proxyName)
 	if err == nil {
 		impl.PrintProxyServiceDetails(proxyService, getProxyServiceCmdFormat)
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -84,6 +85,14 @@ func handleGetRoleCmdArguments(args []string) {
 }
 
 func executeShowRole(role string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getRoleCmdEnvironment, "roles", role, getRoleCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetRoleInfo(nodeEnv, role, getRoleCmdDomain)
+		}, func(details interface{}) {
+			impl.PrintRoleDetails(details.(*artifactutils.RoleSummary), getRoleCmdFormat)
+		})
+		return
+	}
 	roleInfo, err := impl.GetRoleInfo(getRoleCmdEnvironment, role, getRoleCmdDomain)
 	if err == nil {
 		impl.PrintRoleDetails(roleInfo, getRoleCmdFormat)
@@ -93,6 +102,12 @@ func executeShowRole(role string) {
 }
 
 func executeListRoles() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getRoleCmdEnvironment, "roles", getRoleCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetRoleList(nodeEnv)
+		})
+		return
+	}
 	roleList, err := impl.GetRoleList(getRoleCmdEnvironment)
 	if err == nil {
 		impl.PrintRoleList(roleList, getRoleCmdFormat)
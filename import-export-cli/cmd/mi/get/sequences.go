@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetSequenceCmdArguments(args []string) {
 }
 
 func executeListSequences() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getSequenceCmdEnvironment, artifactSequences, getSequenceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetSequenceList(nodeEnv)
+		})
+		return
+	}
 	sequenceList, err := impl.GetSequenceList(getSequenceCmdEnvironment)
 	if err == nil {
 		impl.PrintSequenceList(sequenceList, getSequenceCmdFormat)
@@ -70,6 +77,14 @@ func executeListSequences() {
 }
 
 func executeShowSequence(sequenceName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getSequenceCmdEnvironment, artifactSequences, sequenceName, getSequenceCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetSequence(nodeEnv, sequenceName)
+		}, func(details interface{}) {
+			impl.PrintSequenceDetails(details.(*artifactutils.Sequence), getSequenceCmdFormat)
+		})
+		return
+	}
 	sequence, err := impl.GetSequence(getSequenceCmdEnvironment, sequenceName)
 	if err == nil {
 		impl.PrintSequenceDetails(sequence, getSequenceCmdFormat)
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 )
 
@@ -61,6 +62,12 @@ func handleGetTaskCmdArguments(args []string) {
 }
 
 func executeListTasks() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getTaskCmdEnvironment, artifactTasks, getTaskCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTaskList(nodeEnv)
+		})
+		return
+	}
 	taskList, err := impl.GetTaskList(getTaskCmdEnvironment)
 	if err == nil {
 		impl.PrintTaskList(taskList, getTaskCmdFormat)
@@ -70,6 +77,14 @@ func executeListTasks() {
 }
 
 func executeShowTask(taskName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getTaskCmdEnvironment, artifactTasks, taskName, getTaskCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTask(nodeEnv, taskName)
+		}, func(details interface{}) {
+			impl.PrintTaskDetails(details.(*artifactutils.Task), getTaskCmdFormat)
+		})
+		return
+	}
 	task, err := impl.GetTask(getTaskCmdEnvironment, taskName)
 	if err == nil {
 		impl.PrintTaskDetails(task, getTaskCmdFormat)
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -97,6 +98,12 @@ func handleGetTemplateCmdArguments(args []string) {
 }
 
 func executeListTemplates() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getTemplateCmdEnvironment, artifactTemplates, getTemplateCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTemplateList(nodeEnv)
+		})
+		return
+	}
 	templateList, err := impl.GetTemplateList(getTemplateCmdEnvironment)
 	if err == nil {
 		impl.PrintTemplateList(templateList, getTemplateCmdFormat)
@@ -106,6 +113,12 @@ func executeListTemplates() {
 }
 
 func executeGetTemplateByTypeCmd(templateType string) {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getTemplateCmdEnvironment, artifactTemplates, getTemplateCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTemplatesByType(nodeEnv, templateType)
+		})
+		return
+	}
 	templateList, err := impl.GetTemplatesByType(getTemplateCmdEnvironment, templateType)
 	if err == nil {
 		impl.PrintTemplatesByType(templateList, getTemplateCmdFormat)
@@ -115,6 +128,21 @@ func executeGetTemplateByTypeCmd(templateType string) {
 }
 
 func executeGetTemplateByNameCmd(templateType, templateName string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getTemplateCmdEnvironment, artifactTemplates, templateName, getTemplateCmdFormat, func(nodeEnv string) (interface{}, error) {
+			if templateType == sequenceKey {
+				return impl.GetSequenceTemplate(nodeEnv, templateName)
+			}
+			return impl.GetEndpointTemplate(nodeEnv, templateName)
+		}, func(details interface{}) {
+			if templateType == sequenceKey {
+				impl.PrintSequenceTemplateDetails(details.(*artifactutils.TemplateSequenceListByName), getTemplateCmdFormat)
+			} else {
+				impl.PrintEndpointTemplateDetails(details.(*artifactutils.TemplateEndpointListByName), getTemplateCmdFormat)
+			}
+		})
+		return
+	}
 	if templateType == sequenceKey {
 		sequenceTemplate, err := impl.GetSequenceTemplate(getTemplateCmdEnvironment, templateName)
 		if err == nil {
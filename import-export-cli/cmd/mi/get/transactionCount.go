@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -81,6 +82,21 @@ func handleGetTransactionCountCmdArguments(args []string) {
 }
 
 func executeGetTransactionCountForMonth(period ...string) {
+	if miUtils.AllNodes {
+		result, err := impl.RunOnAllNodes(getTransactionCountCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.GetTransactionCount(nodeEnv, period)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"Retrieving transactions count.", err)
+			return
+		}
+		// the transaction counts of the nodes are expected to differ, so they are not reported as drift
+		impl.PrintClusterArtifactDetails(result, "transaction count", getTransactionCountCmdFormat, false,
+			func(details interface{}) {
+				impl.PrintTransactionCount(details.(*artifactutils.TransactionCount), getTransactionCountCmdFormat)
+			})
+		return
+	}
 	transactionCount, err := impl.GetTransactionCount(getTransactionCountCmdEnvironment, period)
 	if err == nil {
 		impl.PrintTransactionCount(transactionCount, getTransactionCountCmdFormat)
@@ -81,6 +81,26 @@ func handleGetTransactionReportCmdArguments(args []string) {
 }
 
 func executeGetTransactionReport(targetDirectory string, period ...string) {
+	if miUtils.AllNodes {
+		result, err := impl.RunOnAllNodes(getTransactionReportCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			transactionReport, err := impl.GetTransactionReport(nodeEnv, period)
+			if err != nil {
+				return nil, err
+			}
+			_, node := utils.SplitMINodeEnv(nodeEnv)
+			nodeDirectory, err := impl.GetNodeDirectory(targetDirectory, node)
+			if err != nil {
+				return nil, err
+			}
+			return impl.CreateTransactionReportCSV(transactionReport, nodeDirectory)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"Retrieving Transaction Reports.", err)
+			return
+		}
+		impl.PrintClusterStateChanges(result, "")
+		return
+	}
 	transactionReport, err := impl.GetTransactionReport(getTransactionReportCmdEnvironment, period)
 	if err == nil {
 		impl.WriteTransactionReportAsCSV(transactionReport, targetDirectory)
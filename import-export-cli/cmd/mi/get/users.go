@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
 	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
 	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -98,6 +99,14 @@ func handleGetUserCmdArguments(args []string) {
 }
 
 func executeShowUser(userID string) {
+	if miUtils.AllNodes {
+		executeShowOnAllNodes(getUserCmdEnvironment, "users", userID, getUserCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetUserInfo(nodeEnv, userID, getUserCmdDomain)
+		}, func(details interface{}) {
+			impl.PrintUserDetails(details.(*artifactutils.UserSummary), getUserCmdFormat)
+		})
+		return
+	}
 	userInfo, err := impl.GetUserInfo(getUserCmdEnvironment, userID, getUserCmdDomain)
 	if err == nil {
 		impl.PrintUserDetails(userInfo, getUserCmdFormat)
@@ -107,6 +116,12 @@ func executeShowUser(userID string) {
 }
 
 func executeListUsers() {
+	if miUtils.AllNodes {
+		executeListOnAllNodes(getUserCmdEnvironment, "users", getUserCmdFormat, func(nodeEnv string) (interface{}, error) {
+			return impl.GetUserList(nodeEnv, getUserCmdRole, getUserCmdPattern)
+		})
+		return
+	}
 	userList, err := impl.GetUserList(getUserCmdEnvironment, getUserCmdRole, getUserCmdPattern)
 	if err == nil {
 		impl.PrintUserList(userList, getUserCmdFormat)
@@ -22,6 +22,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
@@ -65,4 +67,28 @@ func setEnvFlag(cmd *cobra.Command, param *string) {
 func setFormatFlag(cmd *cobra.Command, param *string) {
 	cmd.Flags().StringVarP(param, "format", "", "",
 		"Pretty-print using Go Templates. Use \"{{ jsonPretty . }}\" to list all fields")
+	formatter.AddOutputFlag(cmd.Flags(), param)
+}
+
+// executeListOnAllNodes gets the list of artifacts from every node of the environment and prints them in a single
+// table, along with the artifacts which are not the same on every node
+func executeListOnAllNodes(env, artifactType, format string, getList func(nodeEnv string) (interface{}, error)) {
+	result, err := impl.RunOnAllNodes(env, getList)
+	if err != nil {
+		printErrorForArtifactList(artifactType, err)
+		return
+	}
+	impl.PrintClusterArtifactLists(result, format)
+}
+
+// executeShowOnAllNodes gets an artifact from every node of the environment and prints its details for each node,
+// along with the nodes on which the artifact differs or is missing
+func executeShowOnAllNodes(env, artifactType, artifactName, format string,
+	getArtifact func(nodeEnv string) (interface{}, error), printDetails func(details interface{})) {
+	result, err := impl.RunOnAllNodes(env, getArtifact)
+	if err != nil {
+		printErrorForArtifact(artifactType, artifactName, err)
+		return
+	}
+	impl.PrintClusterArtifactDetails(result, artifactName, format, true, printDetails)
 }
@@ -0,0 +1,136 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package mi
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var logsCmdEnvironments []string
+var logsCmdFollow bool
+var logsCmdSince time.Duration
+var logsCmdPattern string
+var logsCmdLevel string
+var logsCmdFormat string
+var logsCmdInterval time.Duration
+
+const logsCmdLiteral = "logs [file-name]"
+const logsCmdShortDesc = "Print and follow the logs of Micro Integrators"
+
+const logsCmdLongDesc = "Print the entries of a log file (" + impl.DefaultLogFile + " if not provided) of the Micro Integrator " +
+	"in the environment specified by the flag --environment, -e and keep printing the new entries with the flag " +
+	"--follow, -f. The log file is polled from the last offset read and followed across log rotations.\n" +
+	"If several environments are given, the entries of all of them are merged by their timestamps. " +
+	"The entries can be filtered by their age, minimum level and a regular expression, and printed as JSON lines " +
+	"with their timestamp, level, thread, class and message"
+
+var logsCmdExamples = "To print the entries of wso2carbon.log\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " logs -e dev\n" +
+	"To follow the errors logged in the last 10 minutes and from now on\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " logs -f --since 10m --level ERROR -e dev\n" +
+	"To follow the entries of a log file matching a pattern in two environments as JSON\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " logs wso2carbon.log -f --grep 'HelloEP|OrderEP' -e dev -e prod -o json\n" +
+	"To follow the logs of every node of a cluster\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " logs -f -e prod --all-nodes\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+var logsCmd = &cobra.Command{
+	Use:     logsCmdLiteral,
+	Short:   logsCmdShortDesc,
+	Long:    logsCmdLongDesc,
+	Example: logsCmdExamples,
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		logFileName := impl.DefaultLogFile
+		if len(args) == 1 {
+			logFileName = args[0]
+		}
+		handleLogsCmdArguments(logFileName)
+	},
+}
+
+func init() {
+	MICmd.AddCommand(logsCmd)
+	logsCmd.Flags().StringSliceVarP(&logsCmdEnvironments, "environment", "e", []string{},
+		"Environment of the micro integrator, repeat to merge the logs of several environments")
+	logsCmd.Flags().BoolVarP(&logsCmdFollow, "follow", "f", false, "Keep printing the entries written to the log file")
+	logsCmd.Flags().DurationVar(&logsCmdSince, "since", 0,
+		"Only print the entries logged within this duration, e.g. 10m. Following starts with the new entries if not set")
+	logsCmd.Flags().StringVar(&logsCmdPattern, "grep", "", "Only print the entries matching this regular expression")
+	logsCmd.Flags().StringVar(&logsCmdLevel, "level", "",
+		"Only print the entries of this level or more severe. One of: TRACE|DEBUG|INFO|WARN|ERROR|FATAL")
+	logsCmd.Flags().StringVarP(&logsCmdFormat, "output", "o", "", "Output format of the entries. One of: json")
+	logsCmd.Flags().DurationVar(&logsCmdInterval, "interval", 2*time.Second, "Interval between polls of the log file")
+	logsCmd.MarkFlagRequired("environment")
+}
+
+func handleLogsCmdArguments(logFileName string) {
+	utils.Logln(utils.LogPrefixInfo + miUtils.GetTrimmedCmdLiteral(logsCmdLiteral) + " called")
+	if logsCmdFormat != "" && logsCmdFormat != formatter.JsonOutputKey {
+		utils.HandleErrorAndExit("Unsupported output format "+logsCmdFormat+", expected "+formatter.JsonOutputKey, nil)
+	}
+	filter, err := impl.NewLogFilter(logsCmdSince, logsCmdLevel, logsCmdPattern)
+	if err != nil {
+		utils.HandleErrorAndExit("Invalid log filter", err)
+	}
+	var tails []*impl.LogTail
+	for _, env := range logsCmdEnvironments {
+		credentials.HandleMissingCredentials(env)
+		if !miUtils.AllNodes {
+			tails = append(tails, impl.NewLogTail(env, logFileName))
+			continue
+		}
+		nodes, err := utils.GetMINodeEndpointsOfEnv(env, utils.MainConfigFilePath)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting the nodes of "+env, err)
+		}
+		for _, node := range nodes {
+			tails = append(tails, impl.NewLogTail(utils.GetMINodeEnv(env, node), logFileName))
+		}
+	}
+	executeLogs(tails, filter)
+}
+
+func executeLogs(tails []*impl.LogTail, filter *impl.LogFilter) {
+	stop := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		close(stop)
+	}()
+
+	showEnv := len(tails) > 1
+	err := impl.StreamLogs(tails, filter, logsCmdFollow, logsCmdInterval, stop, func(entry *impl.LogEntry) {
+		impl.PrintLogEntry(entry, logsCmdFormat, showEnv)
+	})
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the logs of "+strings.Join(logsCmdEnvironments, ", "), err)
+	}
+}
@@ -28,7 +28,10 @@ import (
 	miAddCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/add"
 	miDeactivateCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/deactivate"
 	miDeleteCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/delete"
+	miDeployCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/deploy"
 	miGetCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/get"
+	miUndeployCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/undeploy"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
 	miUpdateCmd "github.com/wso2/product-apim-tooling/import-export-cli/cmd/mi/update"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -36,6 +39,7 @@ import (
 var verbose bool
 var cfgFile string
 var insecure bool
+var trace bool
 
 const miCmdShortDesc = "Micro Integrator related commands"
 
@@ -45,7 +49,7 @@ func getMiCmdLongDesc() string {
 	if utils.GetMICmdName() == "" {
 		return utils.MICmd + " is a Command Line Tool for Managing WSO2 Micro Integrator"
 	}
-	return "Micro Integrator related commands such as login, logout, get, add, update, delete, activate, deactivate."
+	return "Micro Integrator related commands such as login, logout, get, add, update, delete, activate, deactivate, deploy, undeploy, logs."
 }
 
 // MICmd represents the mi command
@@ -71,17 +75,23 @@ func init() {
 		MICmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 		MICmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 			"Allow connections to SSL endpoints without certs")
+		MICmd.PersistentFlags().BoolVar(&trace, "trace", false,
+			"Log the redacted HTTP requests and responses with the time taken")
 		err := utils.SetConfigVars(utils.MainConfigFilePath)
 		if err != nil {
 			utils.HandleErrorAndExit("Error reading "+utils.MainConfigFilePath+".", err)
 		}
 	}
+	MICmd.PersistentFlags().BoolVar(&miUtils.AllNodes, "all-nodes", false,
+		"Send the request to every Micro Integrator node (mi_nodes) of the environment and merge the results")
 	MICmd.AddCommand(miGetCmd.GetCmd)
 	MICmd.AddCommand(miAddCmd.AddCmd)
 	MICmd.AddCommand(miDeleteCmd.DeleteCmd)
 	MICmd.AddCommand(miUpdateCmd.UpdateCmd)
 	MICmd.AddCommand(miActivateCmd.ActivateCmd)
 	MICmd.AddCommand(miDeactivateCmd.DeactivateCmd)
+	MICmd.AddCommand(miDeployCmd.DeployCmd)
+	MICmd.AddCommand(miUndeployCmd.UndeployCmd)
 }
 
 func createConfigFiles() {
@@ -116,6 +126,9 @@ func initConfig() {
 	if insecure {
 		utils.Insecure = true
 	}
+	if trace {
+		utils.EnableTraceMode()
+	}
 }
 
 // Execute adds all child commands to the root command sets flags appropriately.
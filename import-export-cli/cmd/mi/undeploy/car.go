@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package undeploy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	impl "github.com/wso2/product-apim-tooling/import-export-cli/mi/impl"
+	miUtils "github.com/wso2/product-apim-tooling/import-export-cli/mi/utils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var undeployCarCmdEnvironment string
+
+const undeployCarCmdLiteral = "car [app-name]"
+const undeployCarCmdShortDesc = "Undeploy a composite app from a Micro Integrator"
+
+const undeployCarCmdLongDesc = "Undeploy the composite app specified by the command line argument [app-name] from a Micro Integrator in the environment specified by the flag --environment, -e"
+
+var undeployCarCmdExamples = "To undeploy a composite app\n" +
+	"  " + utils.GetMICmdName() + " " + utils.MiCmdLiteral + " " + undeployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(undeployCarCmdLiteral) + " SampleApp -e dev\n" +
+	"NOTE: The flag (--environment (-e)) is mandatory"
+
+var undeployCarCmd = &cobra.Command{
+	Use:     undeployCarCmdLiteral,
+	Short:   undeployCarCmdShortDesc,
+	Long:    undeployCarCmdLongDesc,
+	Example: undeployCarCmdExamples,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		handleUndeployCarCmdArguments(args)
+	},
+}
+
+func init() {
+	UndeployCmd.AddCommand(undeployCarCmd)
+	undeployCarCmd.Flags().StringVarP(&undeployCarCmdEnvironment, "environment", "e", "", "Environment of the micro integrator from which the composite app should be undeployed")
+	undeployCarCmd.MarkFlagRequired("environment")
+}
+
+func handleUndeployCarCmdArguments(args []string) {
+	utils.Logln(utils.LogPrefixInfo + undeployCmdLiteral + " " + miUtils.GetTrimmedCmdLiteral(undeployCarCmdLiteral) + " called")
+	credentials.HandleMissingCredentials(undeployCarCmdEnvironment)
+	executeUndeployCar(args[0])
+}
+
+func executeUndeployCar(appName string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(undeployCarCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UndeployCompositeApp(nodeEnv, appName)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"undeploying composite app [ "+appName+" ]", err)
+		}
+		return
+	}
+	resp, err := impl.UndeployCompositeApp(undeployCarCmdEnvironment, appName)
+	if err != nil {
+		fmt.Println(utils.LogPrefixError+"undeploying composite app [ "+appName+" ]", err)
+	} else {
+		fmt.Println("Undeploying composite app [ "+appName+" ] status:", resp)
+	}
+}
@@ -64,6 +64,15 @@ func handleUpdateHashiCorpSecretCmdArguments(args []string) {
 }
 
 func executeUpdateHashiCorpSecretID(hashiCorpSecretID string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(updateHashiCorpSecretCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UpdateHashiCorpSecretID(nodeEnv, hashiCorpSecretID)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"updating secretID of HashiCorp configuration.", err)
+		}
+		return
+	}
 	resp, err := impl.UpdateHashiCorpSecretID(updateHashiCorpSecretCmdEnvironment, hashiCorpSecretID)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"updating secretID of HashiCorp configuration.", err)
@@ -64,6 +64,15 @@ func handleupdateLogLevelCmdArguments(args []string) {
 }
 
 func executeUpdateLogger(loggerName, logLevel string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(updateLogLevelCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UpdateMILogger(nodeEnv, loggerName, logLevel)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"updating logger [ "+loggerName+" ] ", err)
+		}
+		return
+	}
 	resp, err := impl.UpdateMILogger(updateLogLevelCmdEnvironment, loggerName, logLevel)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"updating logger [ "+loggerName+" ] ", err)
@@ -67,6 +67,15 @@ func handleupdateUserCmdArguments(args []string) {
 }
 
 func executeUpdateUser(userName, domain string, addedRoles, removedRoles []string) {
+	if miUtils.AllNodes {
+		err := impl.ApplyOnAllNodes(updateUserCmdEnvironment, func(nodeEnv string) (interface{}, error) {
+			return impl.UpdateMIUser(nodeEnv, userName, domain, addedRoles, removedRoles)
+		})
+		if err != nil {
+			fmt.Println(utils.LogPrefixError+"updating roles of user [ "+userName+" ] ", err)
+		}
+		return
+	}
 	resp, err := impl.UpdateMIUser(updateUserCmdEnvironment, userName, domain, addedRoles, removedRoles)
 	if err != nil {
 		fmt.Println(utils.LogPrefixError+"updating roles of user [ "+userName+" ] ", err)
@@ -0,0 +1,54 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Params command related usage Info
+const ParamsCmdLiteral = "params"
+const paramsCmdShortDesc = "Work with the params files of API projects"
+
+const paramsCmdLongDesc = `Work with the params files which override the configurations of API projects for each environment.
+A params file can extend another params file using "extends: <path-of-the-params-file>" and an environment can extend
+another environment of the same params file using "extends: <name-of-the-environment>". The configs are deep merged.
+Secrets can be referred in params files as {{ secret "vault://<mount>/<path>#<key>" }}, {{ secret "file://<path>" }} or
+{{ secret "env://<variable>" }}. Vault references are read from the KV version 2 secrets engine at VAULT_ADDR using VAULT_TOKEN.
+The resolved secrets are never logged and the temporary files holding them are not left behind by --skip-cleanup.`
+
+const paramsCmdExamples = utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -e prod --params prod_params.yaml -f ./PizzaShackAPI`
+
+// ParamsCmd represents the params command
+var ParamsCmd = &cobra.Command{
+	Use:     ParamsCmdLiteral,
+	Short:   paramsCmdShortDesc,
+	Long:    paramsCmdLongDesc,
+	Example: paramsCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ParamsCmdLiteral + " called")
+
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(ParamsCmd)
+}
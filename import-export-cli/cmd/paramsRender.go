@@ -0,0 +1,84 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var paramsRenderEnvironment string
+var paramsRenderParamsFile string
+var paramsRenderProjectPath string
+var paramsRenderFormat string
+
+// ParamsRender command related usage Info
+const ParamsRenderCmdLiteral = "render"
+const paramsRenderCmdShortDesc = "Print the configurations of an API resolved from a params file"
+
+const paramsRenderCmdLongDesc = `Resolve the params file or deployment directory specified by --params for the environment specified by --environment (-e)
+and print the configurations the API gets when it is imported with those params. The params files and environments which are
+extended are merged and the result is validated against the params schema shipped with apictl.
+When an API project is given using --file (-f), its api.yaml is printed with the endpoints and policies of the params applied.
+The endpoint and client certificates are read from the certificates directory next to the params file.
+Secret references in the params are resolved, but their values are masked in the output.
+NOTE: Both the flags --environment (-e) and --params are mandatory`
+
+const paramsRenderCmdExamples = utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -e prod --params prod_params.yaml
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -e prod-eu --params ./DeploymentArtifacts_PizzaShackAPI-1.0.0 -f ./PizzaShackAPI
+` + utils.ProjectName + ` ` + ParamsCmdLiteral + ` ` + ParamsRenderCmdLiteral + ` -e prod --params prod_params.yaml -f ./PizzaShackAPI --format json`
+
+// ParamsRenderCmd represents the params render command
+var ParamsRenderCmd = &cobra.Command{
+	Use: ParamsRenderCmdLiteral + " (--environment <environment-of-the-params> --params <path-to-params-file-or-deployment-directory>" +
+		" [--file <path-to-api-project>])",
+	Short:   paramsRenderCmdShortDesc,
+	Long:    paramsRenderCmdLongDesc,
+	Example: paramsRenderCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + ParamsCmdLiteral + " " + ParamsRenderCmdLiteral + " called")
+		if paramsRenderFormat != "" && paramsRenderFormat != "json" {
+			utils.HandleErrorAndExit("Invalid format "+paramsRenderFormat+". Supported format is json", nil)
+		}
+		rendered, err := impl.RenderAPIParams(paramsRenderParamsFile, paramsRenderEnvironment, paramsRenderProjectPath)
+		if err != nil {
+			utils.HandleErrorAndExit("Error rendering the params", err)
+		}
+		if err = impl.PrintRenderedAPIParams(os.Stdout, rendered, paramsRenderFormat); err != nil {
+			utils.HandleErrorAndExit("Error printing the rendered params", err)
+		}
+	},
+}
+
+func init() {
+	ParamsCmd.AddCommand(ParamsRenderCmd)
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderEnvironment, "environment", "e", "",
+		"Environment of the params file to be rendered")
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderParamsFile, "params", "", "",
+		"Params file or deployment directory to be rendered")
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderProjectPath, "file", "f", "",
+		"API project whose api.yaml should be rendered with the params")
+	ParamsRenderCmd.Flags().StringVarP(&paramsRenderFormat, "format", "", "",
+		"Output format of the rendered params. Supported format is json")
+	_ = ParamsRenderCmd.MarkFlagRequired("environment")
+	_ = ParamsRenderCmd.MarkFlagRequired("params")
+}
@@ -0,0 +1,50 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Promote command related usage Info
+const PromoteCmdLiteral = "promote"
+const promoteCmdShortDesc = "Promote an API from an environment to another"
+
+const promoteCmdLongDesc = `Promote an API from an environment to another by exporting a revision of it, importing it with the params of the
+target environment, and deploying a new revision of it. The promotion is rolled back automatically if the deployment
+or the smoke check run against the gateway fails`
+
+const promoteCmdExamples = utils.ProjectName + ` ` + PromoteCmdLiteral + ` ` + PromoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from staging --to prod`
+
+// PromoteCmd represents the promote command
+var PromoteCmd = &cobra.Command{
+	Use:     PromoteCmdLiteral,
+	Short:   promoteCmdShortDesc,
+	Long:    promoteCmdLongDesc,
+	Example: promoteCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PromoteCmdLiteral + " called")
+	},
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(PromoteCmd)
+}
@@ -0,0 +1,191 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var promoteAPIOptions impl.PromoteOptions
+var promoteAPIHideOnDevportal bool
+var promoteAPISmokeCheckTimeout int
+var promoteAPISmokeCheckInterval int
+var promoteAPILogFile string
+var promoteAPIOutput string
+
+// PromoteAPICmd related info
+const PromoteAPICmdLiteral = "api"
+const promoteAPICmdShortDesc = "Promote an API from an environment to another"
+
+const promoteAPICmdLongDesc = `Promote an API from the environment specified by the flag --from to the environment specified by the flag --to.
+The revision specified by the flag --rev, or the newest deployed revision, is exported and imported to the target
+environment with the params file specified by the flag --params. A new revision is created and deployed to the
+gateway environments specified by the flag --gateway-env (-g), or to the gateway environments the API is currently
+deployed in. A smoke check is then sent to the API in the gateway with a key generated as done by get keys, and the
+previously deployed revisions are undeployed from the other gateway environments.
+If the deployment or the smoke check fails, the previously deployed revisions are deployed again and the API is
+restored from them. Every step is recorded in a promotion log, which is written to the promotions directory of the
+config directory unless the flag --log-file is given`
+
+const promoteAPICmdExamples = utils.ProjectName + ` ` + PromoteCmdLiteral + ` ` + PromoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from staging --to prod
+` + utils.ProjectName + ` ` + PromoteCmdLiteral + ` ` + PromoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --rev 3 --from staging --to prod --params prod/api_params.yaml -g Default
+` + utils.ProjectName + ` ` + PromoteCmdLiteral + ` ` + PromoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from staging --to prod --smoke-check-path /menu --smoke-check-status 200
+` + utils.ProjectName + ` ` + PromoteCmdLiteral + ` ` + PromoteAPICmdLiteral + ` -n PizzaShackAPI -v 1.0.0 --from staging --to prod --skip-smoke-check -o json
+NOTE: The flags (--name (-n), --version (-v), --from and --to) are mandatory.`
+
+// PromoteAPICmd represents the promote api command
+var PromoteAPICmd = &cobra.Command{
+	Use:     PromoteAPICmdLiteral,
+	Short:   promoteAPICmdShortDesc,
+	Long:    promoteAPICmdLongDesc,
+	Example: promoteAPICmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + PromoteCmdLiteral + " " + PromoteAPICmdLiteral + " called")
+		executePromoteAPICmd(&promoteAPIOptions)
+	},
+}
+
+func executePromoteAPICmd(options *impl.PromoteOptions) {
+	options.DisplayOnDevportal = !promoteAPIHideOnDevportal
+	options.SmokeCheck.Timeout = time.Duration(promoteAPISmokeCheckTimeout) * time.Second
+	options.SmokeCheck.Interval = time.Duration(promoteAPISmokeCheckInterval) * time.Second
+	if !options.SkipSmokeCheck && promoteAPISmokeCheckInterval <= 0 {
+		utils.HandleErrorAndExit("Invalid flags", errors.New("the flag --smoke-check-interval should be greater "+
+			"than zero"))
+	}
+
+	fromAccessToken := getPromoteAccessToken(options.From)
+	toAccessToken := getPromoteAccessToken(options.To)
+	if !options.SkipSmokeCheck {
+		cred, err := GetCredentials(options.To)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		// the key of the smoke check is generated with a DCR client, as done by get keys
+		cred.ClientId, cred.ClientSecret, err = impl.CallDCREndpoint(cred, options.To)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while registering a client to generate the key of the smoke check", err)
+		}
+		options.KeyCredential = cred
+	}
+
+	logPath := promoteAPILogFile
+	if logPath == "" {
+		logPath = impl.GetDefaultPromotionLogPath(options.Name, options.Version, options.To, time.Now())
+	}
+	log := impl.NewPromotionLog(options, logPath)
+	err := impl.PromoteAPI(fromAccessToken, toAccessToken, options, log)
+	if !utils.PrintStructuredOutput(log, promoteAPIOutput) {
+		printPromotionLog(log)
+	}
+	if err != nil {
+		utils.HandleErrorAndExit("Error while promoting the API "+options.Name+"_"+options.Version+" (the promotion "+
+			"is "+log.Status+")", err)
+	}
+}
+
+// getPromoteAccessToken returns an access token of the environment
+func getPromoteAccessToken(environment string) string {
+	cred, err := GetCredentials(environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, environment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens of "+environment, err)
+	}
+	return accessToken
+}
+
+// printPromotionLog prints the steps and the status of the promotion
+func printPromotionLog(log *impl.PromotionLog) {
+	for _, step := range log.Steps {
+		if step.Error != "" {
+			fmt.Printf("%-18s %-10s %s\n", step.Name, step.Status, step.Error)
+		} else {
+			fmt.Printf("%-18s %-10s %s\n", step.Name, step.Status, step.Message)
+		}
+	}
+	if log.Status == impl.PromotionStatusSucceeded {
+		fmt.Println("API " + log.Name + "_" + log.Version + " promoted from " + log.From + " to " + log.To +
+			" as revision " + log.TargetRevision)
+	}
+	if log.Path() != "" {
+		fmt.Println("Promotion log: " + log.Path())
+	}
+}
+
+// init using Cobra
+func init() {
+	PromoteCmd.AddCommand(PromoteAPICmd)
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.Name, "name", "n", "", "Name of the API to be promoted")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.Version, "version", "v", "",
+		"Version of the API to be promoted")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.Provider, "provider", "r", "", "Provider of the API")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.From, "from", "", "",
+		"Environment from which the API is promoted")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.To, "to", "", "",
+		"Environment to which the API is promoted")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.Revision, "rev", "", "",
+		"Revision number to be promoted. The newest deployed revision is promoted if not given")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.ParamsPath, "params", "", "",
+		"Provide an API Manager params file or a directory generated using \"gen deployment-dir\" command, "+
+			"which is applied when importing the API to the target environment")
+	PromoteAPICmd.Flags().StringSliceVarP(&promoteAPIOptions.GatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment (<name> or <name>:<vhost>) to which the new revision has to be deployed. The gateway "+
+			"environments of the currently deployed revisions are used if not given")
+	PromoteAPICmd.Flags().BoolVarP(&promoteAPIHideOnDevportal, "hide-on-devportal", "", false,
+		"Hide the gateway URLs of the deployment in the Developer Portal")
+	PromoteAPICmd.Flags().BoolVar(&promoteAPIOptions.PreserveProvider, "preserve-provider", true,
+		"Preserve existing provider of API after importing")
+	PromoteAPICmd.Flags().BoolVarP(&promoteAPIOptions.RotateRevision, "rotate-revision", "", false,
+		"If the maximum revision limit reached, delete the oldest undeployed revision before creating a new one")
+	PromoteAPICmd.Flags().BoolVarP(&promoteAPIOptions.SkipSmokeCheck, "skip-smoke-check", "", false,
+		"Skip the smoke check after deploying the new revision")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.SmokeCheck.URL, "smoke-check-url", "", "",
+		"URL of the API used by the smoke check instead of the URL in the deployed gateway environment")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.SmokeCheck.Path, "smoke-check-path", "", "",
+		"Resource path appended to the URL of the API by the smoke check")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.SmokeCheck.Method, "smoke-check-method", "", http.MethodGet,
+		"HTTP method of the smoke check")
+	PromoteAPICmd.Flags().IntVarP(&promoteAPIOptions.SmokeCheck.ExpectedStatus, "smoke-check-status", "", 0,
+		"Expected status code of the smoke check. Any 2xx status code is accepted if not given")
+	PromoteAPICmd.Flags().IntVarP(&promoteAPISmokeCheckTimeout, "smoke-check-timeout", "", 60,
+		"Seconds the smoke check is retried until it succeeds")
+	PromoteAPICmd.Flags().IntVarP(&promoteAPISmokeCheckInterval, "smoke-check-interval", "", 5,
+		"Seconds between two attempts of the smoke check")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPIOptions.TokenEndpoint, "token", "t", "",
+		"Token endpoint URL of the target environment used to generate the key of the smoke check")
+	PromoteAPICmd.Flags().StringVarP(&promoteAPILogFile, "log-file", "", "",
+		"Path of the promotion log")
+	formatter.AddOutputFlag(PromoteAPICmd.Flags(), &promoteAPIOutput)
+	_ = PromoteAPICmd.MarkFlagRequired("name")
+	_ = PromoteAPICmd.MarkFlagRequired("version")
+	_ = PromoteAPICmd.MarkFlagRequired("from")
+	_ = PromoteAPICmd.MarkFlagRequired("to")
+}
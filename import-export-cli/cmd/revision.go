@@ -0,0 +1,131 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionCmdAPIName string
+var revisionCmdAPIProductName string
+var revisionCmdVersion string
+var revisionCmdProvider string
+var revisionCmdEnvironment string
+var revisionCmdOutput string
+
+// Revision command related usage Info
+const RevisionCmdLiteral = "revision"
+const revisionCmdShortDesc = "Manage the revisions of an API or API Product"
+
+const revisionCmdLongDesc = `Create, deploy, undeploy, restore and prune the revisions of the API specified by the flag --api, or of the
+API Product specified by the flag --api-product, in the environment specified by the flag --environment, -e`
+
+const revisionCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionCreateCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -e dev -d "Add the menu resource"
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionDeployCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --rev 3 -g Default -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionPruneCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 --keep 2 -e dev`
+
+// RevisionCmd represents the revision command
+var RevisionCmd = &cobra.Command{
+	Use:     RevisionCmdLiteral,
+	Short:   revisionCmdShortDesc,
+	Long:    revisionCmdLongDesc,
+	Example: revisionCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionCmdLiteral + " called")
+	},
+}
+
+// resolveRevisionArtifact validates the flags selecting the API or API Product of a revision command and returns an
+// access token of the environment, the id of the API or API Product and whether it is an API Product, along with the
+// result of the action on its revision to report
+func resolveRevisionArtifact(action string) (string, string, bool, *impl.ActionResult) {
+	if (revisionCmdAPIName == "") == (revisionCmdAPIProductName == "") {
+		utils.HandleErrorAndExit("Invalid flags", errors.New("exactly one of the flags --api or --api-product is "+
+			"required"))
+	}
+	apiProduct := revisionCmdAPIProductName != ""
+	result := &impl.ActionResult{Type: utils.ProjectTypeApi, Name: revisionCmdAPIName, Version: revisionCmdVersion,
+		Owner: revisionCmdProvider, Environment: revisionCmdEnvironment, Action: action}
+	if apiProduct {
+		result.Type = utils.ProjectTypeApiProduct
+		result.Name = revisionCmdAPIProductName
+	}
+
+	cred, err := GetCredentials(revisionCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting credentials", err)
+	}
+	accessToken, err := credentials.GetOAuthAccessToken(cred, revisionCmdEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error getting OAuth tokens of "+revisionCmdEnvironment, err)
+	}
+	artifactId, err := impl.GetRevisionArtifactId(accessToken, revisionCmdEnvironment, result.Name,
+		revisionCmdVersion, revisionCmdProvider, apiProduct)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting the id of the "+result.Type, err)
+	}
+	result.Id = artifactId
+	return accessToken, artifactId, apiProduct, result
+}
+
+// findRevision returns the revision with the revisionNum of the API or API Product with the artifactId
+func findRevision(accessToken, artifactId, revisionNum string, apiProduct bool) (*utils.Revisions, error) {
+	revisions, err := impl.GetRevisionsOfArtifact(accessToken, revisionCmdEnvironment, artifactId, apiProduct)
+	if err != nil {
+		return nil, err
+	}
+	return impl.FindRevisionByNumber(revisions, revisionNum)
+}
+
+// reportRevisionResult prints the result of a revision command, with message if the output format is not given.
+// The command exits with an error if err is not nil
+func reportRevisionResult(result *impl.ActionResult, err error, message string) {
+	if err != nil {
+		impl.PrintActionResult(result.Fail(err.Error()), revisionCmdOutput)
+		utils.HandleErrorAndExit("Error while trying to "+result.Action+" the revision of the "+result.Type, err)
+	}
+	if !impl.PrintActionResult(result.Succeed(), revisionCmdOutput) {
+		fmt.Println(message)
+	}
+}
+
+// init using Cobra
+func init() {
+	RootCmd.AddCommand(RevisionCmd)
+	RevisionCmd.PersistentFlags().StringVarP(&revisionCmdAPIName, "api", "", "",
+		"Name of the API")
+	RevisionCmd.PersistentFlags().StringVarP(&revisionCmdAPIProductName, "api-product", "", "",
+		"Name of the API Product")
+	RevisionCmd.PersistentFlags().StringVarP(&revisionCmdVersion, "version", "v", "",
+		"Version of the API or API Product")
+	RevisionCmd.PersistentFlags().StringVarP(&revisionCmdProvider, "provider", "r", "",
+		"Provider of the API or API Product")
+	RevisionCmd.PersistentFlags().StringVarP(&revisionCmdEnvironment, "environment", "e", "",
+		"Environment of the API or API Product")
+	_ = RevisionCmd.MarkPersistentFlagRequired("version")
+	_ = RevisionCmd.MarkPersistentFlagRequired("environment")
+	formatter.AddOutputFlag(RevisionCmd.PersistentFlags(), &revisionCmdOutput)
+}
@@ -0,0 +1,64 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionCreateDescription string
+
+// RevisionCreateCmd related info
+const RevisionCreateCmdLiteral = "create"
+const revisionCreateCmdShortDesc = "Create a revision of an API or API Product"
+
+const revisionCreateCmdLongDesc = `Create a revision from the current working copy of an API or API Product. The created revision is not deployed`
+
+const revisionCreateCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionCreateCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionCreateCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 -r admin -d "Add the menu resource" -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionCreateCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 -e dev -o json
+NOTE: The flags (--version (-v) and --environment (-e)) and exactly one of the flags (--api or --api-product) are mandatory.`
+
+// RevisionCreateCmd represents the revision create command
+var RevisionCreateCmd = &cobra.Command{
+	Use:     RevisionCreateCmdLiteral,
+	Short:   revisionCreateCmdShortDesc,
+	Long:    revisionCreateCmdLongDesc,
+	Example: revisionCreateCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionCreateCmdLiteral + " called")
+		accessToken, artifactId, apiProduct, result := resolveRevisionArtifact(impl.ActionCreate)
+		revision, err := impl.CreateRevision(accessToken, revisionCmdEnvironment, artifactId,
+			revisionCreateDescription, apiProduct)
+		if err == nil {
+			result.Revision = utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+		}
+		reportRevisionResult(result, err, "Revision "+result.Revision+" of "+result.Type+" "+result.Name+"_"+
+			result.Version+" created successfully")
+	},
+}
+
+// init using Cobra
+func init() {
+	RevisionCmd.AddCommand(RevisionCreateCmd)
+	RevisionCreateCmd.Flags().StringVarP(&revisionCreateDescription, "description", "d", "",
+		"Description of the revision")
+}
@@ -0,0 +1,83 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionDeployRevisionNum string
+var revisionDeployGatewayEnvs []string
+var revisionDeployHideOnDevportal bool
+
+// RevisionDeployCmd related info
+const RevisionDeployCmdLiteral = "deploy"
+const revisionDeployCmdShortDesc = "Deploy a revision of an API or API Product"
+
+const revisionDeployCmdLongDesc = `Deploy a revision of an API or API Product to the gateway environments specified by the flag --gateway-env, -g.
+A gateway environment is given as <name> or <name>:<vhost>. The first vhost of the gateway environment is used
+when the vhost is not given`
+
+const revisionDeployCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionDeployCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --rev 3 -g Default -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionDeployCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --rev 3 -g Default:gw.wso2.com -g US-Region -e prod
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionDeployCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 --rev 1 -g Default --hide-on-devportal -e dev
+NOTE: The flags (--version (-v), --rev, --gateway-env (-g) and --environment (-e)) and exactly one of the flags
+(--api or --api-product) are mandatory.`
+
+// RevisionDeployCmd represents the revision deploy command
+var RevisionDeployCmd = &cobra.Command{
+	Use:     RevisionDeployCmdLiteral,
+	Short:   revisionDeployCmdShortDesc,
+	Long:    revisionDeployCmdLongDesc,
+	Example: revisionDeployCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionDeployCmdLiteral + " called")
+		accessToken, artifactId, apiProduct, result := resolveRevisionArtifact(impl.ActionDeploy)
+		result.Revision = revisionDeployRevisionNum
+		revision, err := findRevision(accessToken, artifactId, revisionDeployRevisionNum, apiProduct)
+		var deployments []utils.Deployment
+		if err == nil {
+			deployments, err = impl.ResolveRevisionDeployments(accessToken, revisionCmdEnvironment,
+				revisionDeployGatewayEnvs, !revisionDeployHideOnDevportal)
+		}
+		if err == nil {
+			err = impl.DeployRevisionOfArtifact(accessToken, revisionCmdEnvironment, artifactId, revision.ID,
+				deployments, apiProduct)
+		}
+		reportRevisionResult(result, err, "Revision "+result.Revision+" of "+result.Type+" "+result.Name+"_"+
+			result.Version+" deployed to "+strings.Join(revisionDeployGatewayEnvs, ", "))
+	},
+}
+
+// init using Cobra
+func init() {
+	RevisionCmd.AddCommand(RevisionDeployCmd)
+	RevisionDeployCmd.Flags().StringVarP(&revisionDeployRevisionNum, "rev", "", "",
+		"Revision number to deploy")
+	RevisionDeployCmd.Flags().StringSliceVarP(&revisionDeployGatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment (<name> or <name>:<vhost>) to which the revision has to be deployed")
+	RevisionDeployCmd.Flags().BoolVarP(&revisionDeployHideOnDevportal, "hide-on-devportal", "", false,
+		"Hide the gateway URLs of the deployment in the Developer Portal")
+	_ = RevisionDeployCmd.MarkFlagRequired("rev")
+	_ = RevisionDeployCmd.MarkFlagRequired("gateway-env")
+}
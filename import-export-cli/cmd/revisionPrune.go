@@ -0,0 +1,114 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionPruneKeep int
+var revisionPruneDryRun bool
+
+// RevisionPruneCmd related info
+const RevisionPruneCmdLiteral = "prune"
+const revisionPruneCmdShortDesc = "Delete the old revisions of an API or API Product"
+
+const revisionPruneCmdLongDesc = `Delete the revisions of an API or API Product except the newest ones in the count specified by the flag --keep.
+Deployed revisions are never deleted`
+
+const revisionPruneCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionPruneCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --keep 2 -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionPruneCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 --keep 1 --dry-run -e dev
+NOTE: The flags (--version (-v), --keep and --environment (-e)) and exactly one of the flags (--api or --api-product)
+are mandatory.`
+
+// RevisionPruneCmd represents the revision prune command
+var RevisionPruneCmd = &cobra.Command{
+	Use:     RevisionPruneCmdLiteral,
+	Short:   revisionPruneCmdShortDesc,
+	Long:    revisionPruneCmdLongDesc,
+	Example: revisionPruneCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionPruneCmdLiteral + " called")
+		if revisionPruneKeep < 0 {
+			utils.HandleErrorAndExit("Invalid flags", errors.New("the flag --keep cannot be negative"))
+		}
+		accessToken, artifactId, apiProduct, artifact := resolveRevisionArtifact(impl.ActionDelete)
+		revisions, err := impl.GetRevisionsOfArtifact(accessToken, revisionCmdEnvironment, artifactId, apiProduct)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting the revisions of the "+artifact.Type, err)
+		}
+		revisionsToPrune := impl.GetRevisionsToPrune(revisions, revisionPruneKeep)
+		if revisionPruneDryRun {
+			impl.PrintRevisions(revisionsToPrune, revisionCmdOutput)
+			return
+		}
+		executeRevisionPruneCmd(accessToken, artifactId, apiProduct, artifact, revisionsToPrune)
+	},
+}
+
+// executeRevisionPruneCmd deletes revisionsToPrune and reports the result of deleting each of them
+func executeRevisionPruneCmd(accessToken, artifactId string, apiProduct bool, artifact *impl.ActionResult,
+	revisionsToPrune []utils.Revisions) {
+	results := []*impl.ActionResult{}
+	pruned := 0
+	for _, revision := range revisionsToPrune {
+		result := *artifact
+		result.Revision = utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+		err := impl.DeleteRevision(accessToken, revisionCmdEnvironment, artifactId, revision.ID, apiProduct)
+		if err != nil {
+			result.Fail(err.Error())
+		} else {
+			pruned++
+			result.Succeed()
+		}
+		results = append(results, &result)
+	}
+	if !utils.PrintStructuredOutput(results, revisionCmdOutput) {
+		for _, result := range results {
+			if result.Status == impl.ActionStatusSuccess {
+				fmt.Println("Revision " + result.Revision + " deleted")
+			} else {
+				fmt.Println("Error while deleting revision " + result.Revision + ": " + result.Message)
+			}
+		}
+		fmt.Println(strconv.Itoa(pruned) + " revision(s) of " + artifact.Type + " " + artifact.Name +
+			"_" + artifact.Version + " pruned")
+	}
+	if pruned < len(revisionsToPrune) {
+		utils.HandleErrorAndExit("Error while pruning the revisions of the "+artifact.Type,
+			errors.New(strconv.Itoa(len(revisionsToPrune)-pruned)+" of "+strconv.Itoa(len(revisionsToPrune))+
+				" revision(s) could not be deleted"))
+	}
+}
+
+// init using Cobra
+func init() {
+	RevisionCmd.AddCommand(RevisionPruneCmd)
+	RevisionPruneCmd.Flags().IntVarP(&revisionPruneKeep, "keep", "", 0,
+		"Number of the newest revisions to keep")
+	RevisionPruneCmd.Flags().BoolVarP(&revisionPruneDryRun, "dry-run", "", false,
+		"Print the revisions to delete without deleting them")
+	_ = RevisionPruneCmd.MarkFlagRequired("keep")
+}
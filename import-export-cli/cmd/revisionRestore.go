@@ -0,0 +1,65 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionRestoreRevisionNum string
+
+// RevisionRestoreCmd related info
+const RevisionRestoreCmdLiteral = "restore"
+const revisionRestoreCmdShortDesc = "Restore an API or API Product from a revision"
+
+const revisionRestoreCmdLongDesc = `Replace the current working copy of an API or API Product with a revision. The deployments are not changed`
+
+const revisionRestoreCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionRestoreCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --rev 2 -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionRestoreCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 -r admin --rev 1 -e dev
+NOTE: The flags (--version (-v), --rev and --environment (-e)) and exactly one of the flags (--api or --api-product)
+are mandatory.`
+
+// RevisionRestoreCmd represents the revision restore command
+var RevisionRestoreCmd = &cobra.Command{
+	Use:     RevisionRestoreCmdLiteral,
+	Short:   revisionRestoreCmdShortDesc,
+	Long:    revisionRestoreCmdLongDesc,
+	Example: revisionRestoreCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionRestoreCmdLiteral + " called")
+		accessToken, artifactId, apiProduct, result := resolveRevisionArtifact(impl.ActionRestore)
+		result.Revision = revisionRestoreRevisionNum
+		revision, err := findRevision(accessToken, artifactId, revisionRestoreRevisionNum, apiProduct)
+		if err == nil {
+			err = impl.RestoreRevision(accessToken, revisionCmdEnvironment, artifactId, revision.ID, apiProduct)
+		}
+		reportRevisionResult(result, err, result.Type+" "+result.Name+"_"+result.Version+" restored from revision "+
+			result.Revision)
+	},
+}
+
+// init using Cobra
+func init() {
+	RevisionCmd.AddCommand(RevisionRestoreCmd)
+	RevisionRestoreCmd.Flags().StringVarP(&revisionRestoreRevisionNum, "rev", "", "",
+		"Revision number to restore from")
+	_ = RevisionRestoreCmd.MarkFlagRequired("rev")
+}
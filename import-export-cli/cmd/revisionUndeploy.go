@@ -0,0 +1,73 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var revisionUndeployRevisionNum string
+var revisionUndeployGatewayEnvs []string
+
+// RevisionUndeployCmd related info
+const RevisionUndeployCmdLiteral = "undeploy"
+const revisionUndeployCmdShortDesc = "Undeploy a revision of an API or API Product"
+
+const revisionUndeployCmdLongDesc = `Undeploy a revision of an API or API Product from the gateway environments specified by the flag
+--gateway-env, -g. The revision is undeployed from all the gateway environments it is deployed in if the flag is not given`
+
+const revisionUndeployCmdExamples = utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionUndeployCmdLiteral + ` --api PizzaShackAPI -v 1.0.0 --rev 2 -g Default -e dev
+` + utils.ProjectName + ` ` + RevisionCmdLiteral + ` ` + RevisionUndeployCmdLiteral + ` --api-product LeasingAPIProduct -v 1.0.0 --rev 1 -e dev
+NOTE: The flags (--version (-v), --rev and --environment (-e)) and exactly one of the flags (--api or --api-product)
+are mandatory.`
+
+// RevisionUndeployCmd represents the revision undeploy command
+var RevisionUndeployCmd = &cobra.Command{
+	Use:     RevisionUndeployCmdLiteral,
+	Short:   revisionUndeployCmdShortDesc,
+	Long:    revisionUndeployCmdLongDesc,
+	Example: revisionUndeployCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + RevisionUndeployCmdLiteral + " called")
+		accessToken, artifactId, apiProduct, result := resolveRevisionArtifact(impl.ActionUndeploy)
+		result.Revision = revisionUndeployRevisionNum
+		err := impl.UndeployRevisionOfArtifact(accessToken, revisionCmdEnvironment, artifactId,
+			revisionUndeployRevisionNum, generateGatewayEnvsArray(revisionUndeployGatewayEnvs), apiProduct)
+		gatewayEnvs := "all the gateway environments"
+		if len(revisionUndeployGatewayEnvs) > 0 {
+			gatewayEnvs = strings.Join(revisionUndeployGatewayEnvs, ", ")
+		}
+		reportRevisionResult(result, err, "Revision "+result.Revision+" of "+result.Type+" "+result.Name+"_"+
+			result.Version+" undeployed from "+gatewayEnvs)
+	},
+}
+
+// init using Cobra
+func init() {
+	RevisionCmd.AddCommand(RevisionUndeployCmd)
+	RevisionUndeployCmd.Flags().StringVarP(&revisionUndeployRevisionNum, "rev", "", "",
+		"Revision number to undeploy")
+	RevisionUndeployCmd.Flags().StringSliceVarP(&revisionUndeployGatewayEnvs, "gateway-env", "g", []string{},
+		"Gateway environment from which the revision has to be undeployed")
+	_ = RevisionUndeployCmd.MarkFlagRequired("rev")
+}
@@ -45,6 +45,7 @@ import (
 var verbose bool
 var cfgFile string
 var insecure bool
+var trace bool
 var cmdPassword string
 var CmdUsername string
 var CmdExportEnvironment string
@@ -101,6 +102,8 @@ func init() {
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode")
 	RootCmd.PersistentFlags().BoolVarP(&insecure, "insecure", "k", false,
 		"Allow connections to SSL endpoints without certs")
+	RootCmd.PersistentFlags().BoolVar(&trace, "trace", false,
+		"Log the redacted HTTP requests and responses with the time taken")
 	//RootCmd.PersistentFlags().StringP("author", "a", "", "WSO2")
 
 	//viper.BindPFlag("author", RootCmd.PersistentFlags().Lookup("author"))
@@ -188,6 +191,9 @@ func initConfig() {
 	if insecure {
 		utils.Insecure = true
 	}
+	if trace {
+		utils.EnableTraceMode()
+	}
 
 	/*
 		if cfgFile != "" { // enable ability to specify config file via flag
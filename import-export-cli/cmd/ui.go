@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/ui"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var uiCmdEnvironment string
+
+// UICmd related info
+const UICmdLiteral = "ui"
+const uiCmdShortDesc = "Browse the APIs, API Products and Applications of an environment interactively"
+
+const uiCmdLongDesc = `Open an interactive terminal browser for the APIs, API Products and Applications in the environment
+specified by the flag --environment, -e. From an API, its revisions, deployments, subscriptions and definition can be
+viewed, and it can be exported, have its status changed, have revisions deployed or undeployed, or be deleted.
+Every action behaves the same as the corresponding apictl command.`
+
+var uiCmdExamples = utils.ProjectName + ` ` + UICmdLiteral + ` -e dev
+NOTE: The flag (--environment (-e)) is mandatory`
+
+// uiCmd represents the ui command
+var uiCmd = &cobra.Command{
+	Use:     UICmdLiteral,
+	Short:   uiCmdShortDesc,
+	Long:    uiCmdLongDesc,
+	Example: uiCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + UICmdLiteral + " called")
+		cred, err := GetCredentials(uiCmdEnvironment)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		executeUICmd(cred)
+	},
+}
+
+func executeUICmd(credential credentials.Credential) {
+	accessToken, err := credentials.GetOAuthAccessToken(credential, uiCmdEnvironment)
+	if err != nil {
+		utils.Logln(utils.LogPrefixError + "calling 'ui' " + err.Error())
+		utils.HandleErrorAndExit("Error calling '"+UICmdLiteral+"'", err)
+	}
+	if err := ui.Run(accessToken, uiCmdEnvironment); err != nil {
+		utils.HandleErrorAndExit("Error running the terminal browser", err)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(uiCmd)
+	uiCmd.Flags().StringVarP(&uiCmdEnvironment, "environment", "e",
+		"", "Environment to be browsed")
+	_ = uiCmd.MarkFlagRequired("environment")
+}
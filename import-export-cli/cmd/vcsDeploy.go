@@ -24,12 +24,18 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 	"github.com/wso2/product-apim-tooling/import-export-cli/git"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
 var flagVCSDeployEnvName string    // name of the environment the project changes need to be deployed
 var flagVCSDeploySkipRollback bool // specifies whether rolling back on error needs to be avoided
+var flagVCSDeployParallel int      // number of projects of the same type deployed at the same time
+var flagVCSDeployReport string     // path of the file the deployment report is written to
+var flagVCSDeployReportFormat string
+var flagVCSDeployPlan string   // path of a plan saved by the vcs plan command
+var flagVCSDeployOutput string // structured output format of the deployment report
 
 // deploy command related usage Info
 const deployCmdLiteral = "deploy"
@@ -38,10 +44,17 @@ const deployCmdLongDesc = `Deploys projects to the specified environment specifi
 Only the changed projects compared to the revision at the last successful deployment will be deployed. 
 If any project(s) got failed during the deployment, by default, the operation will rollback the environment to the last successful state. 
 If this needs to be avoided, use --skip-rollback=true
+Projects of the same type can be deployed in parallel using --parallel. API Products are deployed only after the APIs,
+and Applications after the API Products. The outcome of each project is stored in the vcs config and can be written
+as a JSON or JUnit report using --report.
+A plan saved by 'vcs plan' can be given using --plan. Then the deployment fails if the repositories or the environment
+are no longer in the planned state, so only the reviewed operations are performed.
 NOTE: --environment (-e) flag is mandatory`
 
 const deployCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev
-` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true`
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --skip-rollback=true
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --parallel 8 --report deploy-report.xml --report-format junit
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --plan dev.plan`
 
 // deployCmd represents the deploy command
 var DeployCmd = &cobra.Command{
@@ -51,13 +64,19 @@ var DeployCmd = &cobra.Command{
 	Example: deployCmdExamples,
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + deployCmdLiteral + " called")
+		out := formatter.MessageWriter(flagVCSDeployOutput)
 		if !utils.EnvExistsInMainConfigFile(flagVCSDeployEnvName, utils.MainConfigFilePath) {
-			fmt.Println(flagVCSDeployEnvName, "does not exists. Add it using add env")
+			fmt.Fprintln(out, flagVCSDeployEnvName, "does not exists. Add it using add env")
 			os.Exit(1)
 		}
+		if flagVCSDeployReportFormat != git.DeployReportFormatJSON &&
+			flagVCSDeployReportFormat != git.DeployReportFormatJUnit {
+			utils.HandleErrorAndExit("Invalid report format "+flagVCSDeployReportFormat+". Supported formats are "+
+				git.DeployReportFormatJSON+" and "+git.DeployReportFormatJUnit, nil)
+		}
 		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 		if mainConfig.Config.VCSSourceRepoPath == "" {
-			fmt.Println("VCS source repo path cannot be empty. Set it using apictl set command.")
+			fmt.Fprintln(out, "VCS source repo path cannot be empty. Set it using apictl set command.")
 			os.Exit(1)
 		}
 		credential, err := GetCredentials(flagVCSDeployEnvName)
@@ -68,10 +87,30 @@ var DeployCmd = &cobra.Command{
 		if err != nil {
 			utils.HandleErrorAndExit("Error while getting an access token for deploying the project(s)", err)
 		}
-		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName)
+		options := git.DeployOptions{Parallel: flagVCSDeployParallel, Out: out}
+		if flagVCSDeployPlan != "" {
+			options.Plan, err = git.LoadDeployPlan(flagVCSDeployPlan)
+			if err != nil {
+				utils.HandleErrorAndExit("Error while reading the plan", err)
+			}
+		}
+		report := git.NewDeployReport(flagVCSDeployEnvName)
+		failedProjects := git.DeployChangedFiles(accessOAuthToken, flagVCSDeployEnvName, options, report)
+		// the report is written before rolling back, so it reports the projects which caused the rollback
+		if flagVCSDeployReport != "" {
+			err = report.Write(flagVCSDeployReport, flagVCSDeployReportFormat)
+			if err != nil {
+				utils.HandleErrorAndContinue("Error while writing the deployment report to "+flagVCSDeployReport, err)
+			} else {
+				fmt.Fprintln(out, "Deployment report written to "+flagVCSDeployReport)
+			}
+		}
+		utils.PrintStructuredOutput(report, flagVCSDeployOutput)
 		if failedProjects != nil && len(failedProjects) > 0 && flagVCSDeploySkipRollback == false {
-			fmt.Println("\nRolling back to the last successful revision as there are failures..")
-			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName)
+			fmt.Fprintln(out, "\nRolling back to the last successful revision as there are failures..")
+			// the rollback restores the last successful revision, which is not the planned state
+			err = git.Rollback(accessOAuthToken, flagVCSDeployEnvName, git.DeployOptions{Parallel: options.Parallel,
+				Out: out})
 			if err != nil {
 				utils.HandleErrorAndExit("There are project deployment failures. Failed to rollback.", err)
 			} else {
@@ -91,6 +130,15 @@ func init() {
 	DeployCmd.Flags().BoolVarP(&flagVCSDeploySkipRollback, "skipRollback", "", false,
 		"Specifies whether rolling back to the last successful revision during an error situation should be skipped")
 	DeployCmd.Flags().MarkDeprecated("skipRollback", "Use skip-rollback flag")
+	DeployCmd.Flags().IntVarP(&flagVCSDeployParallel, "parallel", "", 1,
+		"Number of projects of the same type deployed at the same time")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployReport, "report", "", "",
+		"Path of the file to write the deployment report of each project")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployReportFormat, "report-format", "", git.DeployReportFormatJSON,
+		"Format of the deployment report (json or junit)")
+	DeployCmd.Flags().StringVarP(&flagVCSDeployPlan, "plan", "", "",
+		"Path of a plan saved by the vcs plan command to be applied")
 
 	_ = DeployCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(DeployCmd.Flags(), &flagVCSDeployOutput)
 }
@@ -0,0 +1,96 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/git"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+var flagVCSPlanEnvName string // name of the environment the plan is created for
+var flagVCSPlanOut string     // path of the file the plan is saved to
+
+// plan command related usage Info
+const vcsPlanCmdLiteral = "plan"
+const vcsPlanCmdShortDesc = "Shows the operations a deployment would perform in the specified environment"
+const vcsPlanCmdLongDesc = `Shows the operations deploying the changed projects would perform in the environment specified by --environment(-e).
+Each changed project is resolved against the environment to show whether it is created, updated or deleted, the
+revisions created and rotated, and the gateways the revisions are deployed to.
+The plan can be saved using --out and applied using 'vcs deploy --plan', which deploys only if the repositories and
+the environment are still in the planned state.
+NOTE: --environment (-e) flag is mandatory`
+
+const vcsPlanCmdExamples = utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsPlanCmdLiteral + ` -e dev
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + vcsPlanCmdLiteral + ` -e dev --out dev.plan
+` + utils.ProjectName + ` ` + vcsCmdLiteral + ` ` + deployCmdLiteral + ` -e dev --plan dev.plan`
+
+// VCSPlanCmd represents the vcs plan command
+var VCSPlanCmd = &cobra.Command{
+	Use:     vcsPlanCmdLiteral,
+	Short:   vcsPlanCmdShortDesc,
+	Long:    vcsPlanCmdLongDesc,
+	Example: vcsPlanCmdExamples,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.Logln(utils.LogPrefixInfo + vcsPlanCmdLiteral + " called")
+		if !utils.EnvExistsInMainConfigFile(flagVCSPlanEnvName, utils.MainConfigFilePath) {
+			fmt.Println(flagVCSPlanEnvName, "does not exists. Add it using add env")
+			os.Exit(1)
+		}
+		mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+		if mainConfig.Config.VCSSourceRepoPath == "" {
+			fmt.Println("VCS source repo path cannot be empty. Set it using apictl set command.")
+			os.Exit(1)
+		}
+		credential, err := GetCredentials(flagVCSPlanEnvName)
+		if err != nil {
+			utils.HandleErrorAndExit("Error getting credentials", err)
+		}
+		accessOAuthToken, err := credentials.GetOAuthAccessToken(credential, flagVCSPlanEnvName)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while getting an access token for planning the deployment", err)
+		}
+		plan, err := git.CreateDeployPlan(accessOAuthToken, flagVCSPlanEnvName)
+		if err != nil {
+			utils.HandleErrorAndExit("Error while creating the deployment plan", err)
+		}
+		plan.Print(os.Stdout)
+		if flagVCSPlanOut != "" {
+			if err = plan.Write(flagVCSPlanOut); err != nil {
+				utils.HandleErrorAndExit("Error while saving the plan to "+flagVCSPlanOut, err)
+			}
+			fmt.Println("\nPlan saved to " + flagVCSPlanOut + ". Apply it using '" + utils.ProjectName + " " +
+				vcsCmdLiteral + " " + deployCmdLiteral + " -e " + flagVCSPlanEnvName + " --plan " + flagVCSPlanOut + "'")
+		}
+	},
+}
+
+func init() {
+	VCSCmd.AddCommand(VCSPlanCmd)
+
+	VCSPlanCmd.Flags().StringVarP(&flagVCSPlanEnvName, "environment", "e", "", "Name of the "+
+		"environment to plan the deployment of the project(s)")
+	VCSPlanCmd.Flags().StringVarP(&flagVCSPlanOut, "out", "", "", "Path of the file to save the plan")
+
+	_ = VCSPlanCmd.MarkFlagRequired("environment")
+}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/spf13/cobra"
@@ -52,11 +53,15 @@ var VCSStatusCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		utils.Logln(utils.LogPrefixInfo + vcsStatusCmdLiteral + " called")
 		if !utils.EnvExistsInMainConfigFile(flagVCSStatusEnvName, utils.MainConfigFilePath) {
-			fmt.Println(flagVCSStatusEnvName, "does not exists. Add it using add env")
+			fmt.Fprintln(formatter.MessageWriter(flagVCSStatusFormat), flagVCSStatusEnvName,
+				"does not exists. Add it using add env")
 			os.Exit(1)
 		}
 
 		_, totalProjectsToUpdate, updatedProjectsPerType := git.GetStatus(flagVCSStatusEnvName, git.FromRevTypeLastAttempted)
+		if utils.PrintStructuredOutput(newVCSStatusOutput(updatedProjectsPerType), flagVCSStatusFormat) {
+			return
+		}
 		if totalProjectsToUpdate == 0 {
 			fmt.Println("Everything is up-to-date")
 			return
@@ -67,6 +72,9 @@ var VCSStatusCmd = &cobra.Command{
 		} else {
 			// Normal print without json
 			fmt.Println("Projects to Deploy (" + strconv.Itoa(totalProjectsToUpdate) + ")")
+			for _, projectType := range git.AdminProjectTypes {
+				printProjectsToUpdate(projectType, updatedProjectsPerType[projectType])
+			}
 			printProjectsToUpdate(utils.ProjectTypeApi, updatedProjectsPerType[utils.ProjectTypeApi])
 			printProjectsToUpdate(utils.ProjectTypeApiProduct, updatedProjectsPerType[utils.ProjectTypeApiProduct])
 			printProjectsToUpdate(utils.ProjectTypeApplication, updatedProjectsPerType[utils.ProjectTypeApplication])
@@ -74,9 +82,45 @@ var VCSStatusCmd = &cobra.Command{
 	},
 }
 
+// vcsStatusProject is a project ready to deploy, as printed with --output
+type vcsStatusProject struct {
+	Type                       string `json:"type"`
+	Name                       string `json:"name"`
+	Path                       string `json:"path"`
+	Operation                  string `json:"operation"`
+	FailedDuringPreviousDeploy bool   `json:"failedDuringPreviousDeploy"`
+}
+
+// newVCSStatusOutput returns the projects ready to deploy in the order of the normal output
+func newVCSStatusOutput(updatedProjectsPerType map[string][]*params.ProjectParams) []vcsStatusProject {
+	projects := []vcsStatusProject{}
+	projectTypes := append(append([]string{}, git.AdminProjectTypes...), utils.ProjectTypeApi,
+		utils.ProjectTypeApiProduct, utils.ProjectTypeApplication)
+	for _, projectType := range projectTypes {
+		for _, projectParam := range updatedProjectsPerType[projectType] {
+			operation := "save"
+			if projectParam.Deleted {
+				operation = "delete"
+			}
+			projects = append(projects, vcsStatusProject{
+				Type:                       projectType,
+				Name:                       projectParam.NickName,
+				Path:                       projectParam.RelativePath,
+				Operation:                  operation,
+				FailedDuringPreviousDeploy: projectParam.FailedDuringPreviousDeploy,
+			})
+		}
+	}
+	return projects
+}
+
 func printProjectsToUpdate(projectType string, projects []*params.ProjectParams) {
 	if len(projects) != 0 {
-		fmt.Println("\n" + projectType + "s (" + strconv.Itoa(len(projects)) + ") ...")
+		typeName := projectType
+		if !strings.HasSuffix(typeName, "s") {
+			typeName += "s"
+		}
+		fmt.Println("\n" + typeName + " (" + strconv.Itoa(len(projects)) + ") ...")
 		for i, projectParam := range projects {
 			var operation string
 			var failed string
@@ -121,4 +165,5 @@ func init() {
 		"Pretty-print status (only supported \"{{ jsonPretty . }}\" and \"{{ json . }}\")")
 
 	_ = VCSStatusCmd.MarkFlagRequired("environment")
+	formatter.AddOutputFlag(VCSStatusCmd.Flags(), &flagVCSStatusFormat)
 }
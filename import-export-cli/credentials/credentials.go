@@ -19,18 +19,24 @@
 package credentials
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"fmt"
-	"net/http"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 // DefaultConfigFile name
 var DefaultConfigFile = "keys.json"
 
+// CredentialStorePassphraseEnv is the environment variable holding the passphrase of the encrypted store
+const CredentialStorePassphraseEnv = "APICTL_CRED_STORE_PASSPHRASE"
+
 // Credential for storing apim user details
 type Credential struct {
 	// Username of user
@@ -43,6 +49,10 @@ type Credential struct {
 	ClientSecret string `json:"clientSecret"`
 	// PersonalAccessToken of API Manager
 	PersonalAccessToken string `json:"accessToken"`
+	// GrantType used to get access tokens, the password grant if blank
+	GrantType string `json:"grantType,omitempty"`
+	// AssertionFile holding the JWT exchanged using the jwt-bearer grant
+	AssertionFile string `json:"assertionFile,omitempty"`
 }
 
 // Credentials of cli
@@ -59,6 +69,8 @@ type Credentials struct {
 type Environment struct {
 	APIM Credential   `json:"apim"`
 	MI   MiCredential `json:"mi"`
+	// APIMTokens are the cached access tokens of apim, keyed by the user and the scopes
+	APIMTokens map[string]CachedToken `json:"apimTokens,omitempty"`
 }
 
 type MgAdapterEnv struct {
@@ -78,28 +90,100 @@ func GetCredentialStore(f string) (Store, error) {
 	return js, nil
 }
 
-// GetDefaultCredentialStore returns store from default path
+// GetDefaultCredentialStore returns the store selected in main_config.yaml, json store from default path otherwise
 func GetDefaultCredentialStore() (Store, error) {
-	return GetCredentialStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	mainConfig := utils.GetMainConfigFromFileSilently(utils.MainConfigFilePath)
+	return NewCredentialStore(mainConfig.Config.CredentialStore)
 }
 
-// GetOAuthAccessToken generates an accesstoken for CLI
-func GetOAuthAccessToken(credential Credential, env string) (string, error) {
-	if credential.PersonalAccessToken != "" {
-		return credential.PersonalAccessToken, nil
-	} else {
-		tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
-		data, err := utils.GetOAuthTokens(credential.Username, credential.Password,
-			Base64Encode(credential.ClientId+":"+credential.ClientSecret),
-			tokenEndpoint)
+// GetDefaultJsonStore returns the json store from default path regardless of the configured store
+func GetDefaultJsonStore() (*JsonStore, error) {
+	js := NewJsonStore(filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile))
+	if err := js.Load(); err != nil {
+		return nil, err
+	}
+	return js, nil
+}
+
+// NewCredentialStore creates and loads the store described by the given configuration
+func NewCredentialStore(config utils.CredentialStoreConfig) (Store, error) {
+	var store Store
+	switch config.Type {
+	case "", JsonStoreType:
+		path := config.Path
+		if path == "" {
+			path = filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultConfigFile)
+		}
+		return GetCredentialStore(path)
+	case EncryptedFileStoreType:
+		path := config.Path
+		if path == "" {
+			path = filepath.Join(utils.LocalCredentialsDirectoryPath, DefaultEncryptedStoreFile)
+		}
+		passphrase, err := getStorePassphrase(config.KeyFile)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		if accessToken, ok := data["access_token"]; ok {
-			return accessToken, nil
+		store = NewEncryptedFileStore(path, passphrase)
+	case KeyringStoreType:
+		store = NewKeyringStore(DefaultKeyringService)
+	case EnvStoreType:
+		store = NewEnvStore()
+	default:
+		return nil, fmt.Errorf("unknown credential store type %q, supported types are %s, %s, %s and %s",
+			config.Type, JsonStoreType, EncryptedFileStoreType, KeyringStoreType, EnvStoreType)
+	}
+	if err := store.Load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// terminalStorePassphrase is the passphrase read from the terminal, kept so it is asked only once per invocation
+var terminalStorePassphrase []byte
+
+// getStorePassphrase reads the passphrase of the encrypted store from the key file, the
+// APICTL_CRED_STORE_PASSPHRASE environment variable or the terminal, in that order
+func getStorePassphrase(keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
 		}
+		return bytes.TrimRight(data, "\r\n"), nil
 	}
-	return "", errors.New("access_token not found")
+	if passphrase := os.Getenv(CredentialStorePassphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	if !terminal.IsTerminal(int(syscall.Stdin)) {
+		return nil, errors.New("passphrase for the encrypted credential store is not provided, set " +
+			CredentialStorePassphraseEnv + " or key_file in the credential_store config")
+	}
+	if terminalStorePassphrase != nil {
+		return terminalStorePassphrase, nil
+	}
+	fmt.Print("Credential store passphrase:")
+	passphrase, err := terminal.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err == nil {
+		terminalStorePassphrase = passphrase
+	}
+	return passphrase, err
+}
+
+// GetOAuthAccessToken generates an accesstoken for CLI. The access token is cached in the default credential store
+// and reused until it is close to the expiry.
+func GetOAuthAccessToken(credential Credential, env string) (string, error) {
+	if credential.PersonalAccessToken != "" {
+		return credential.PersonalAccessToken, nil
+	}
+	store, err := GetDefaultCredentialStore()
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Unable to load the credential store, access tokens will not be cached:", err)
+		store = nil
+	}
+	tokenEndpoint := utils.GetInternalTokenEndpointOfEnv(env, utils.MainConfigFilePath)
+	return getOAuthAccessTokenFromCache(store, credential, env, tokenEndpoint)
 }
 
 // GetBasicAuth returns basic auth username:password encoded in base64
@@ -111,34 +195,7 @@ func GetBasicAuth(credential Credential) string {
 func RevokeAccessToken(credential Credential, env string, token string) error {
 	if credential.PersonalAccessToken != "" {
 		return nil
-	} else {
-		//get revoke endpoint
-		tokenRevokeEndpoint := utils.GetTokenRevokeEndpoint(env, utils.MainConfigFilePath)
-		//Encoding client secret and client Id
-		var b64EncodedClientIDClientSecret = utils.GetBase64EncodedCredentials(credential.ClientId, credential.ClientSecret)
-		// set headers to request
-		headers := make(map[string]string)
-		headers[utils.HeaderContentType] = utils.HeaderValueXWWWFormUrlEncoded
-		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
-
-		//Create body for the request
-		body := utils.HeaderToken + token + utils.TokenTypeForRevocation
-
-		utils.Logln(utils.LogPrefixInfo + "connecting to " + tokenRevokeEndpoint)
-		resp, err := utils.InvokePOSTRequest(tokenRevokeEndpoint, headers, body)
-
-		if err != nil {
-			return err
-		}
-
-		//Check status code
-		if resp.StatusCode() != http.StatusOK {
-			return errors.New("Request didn't respond 200 OK for searching token revocation " +
-				"Status: " + resp.Status())
-		}
-		responseDataMap := make(map[string]string) // a map to hold response data
-		data := []byte(resp.Body())
-		json.Unmarshal(data, &responseDataMap) // add response data to the map
-		return nil
 	}
+	tokenRevokeEndpoint := utils.GetTokenRevokeEndpoint(env, utils.MainConfigFilePath)
+	return revokeToken(credential, tokenRevokeEndpoint, token, utils.TokenTypeForRevocation)
 }
@@ -0,0 +1,380 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// DefaultEncryptedStoreFile name
+var DefaultEncryptedStoreFile = "keys.enc.json"
+
+// encryptedStoreVersion is the format version written to the encrypted store file
+const encryptedStoreVersion = 1
+
+// scrypt parameters used to derive the AES-256 key from the passphrase
+const (
+	scryptN      = 32768
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// encryptedFile is the on-disk envelope of the encrypted store
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileStore is storing keys in an AES-GCM encrypted file unlocked by a passphrase
+type EncryptedFileStore struct {
+	// Path to file
+	Path string
+
+	// internal usage
+	passphrase  []byte
+	salt        []byte
+	credentials Credentials
+}
+
+// NewEncryptedFileStore creates a new encrypted store protected with the given passphrase
+func NewEncryptedFileStore(path string, passphrase []byte) *EncryptedFileStore {
+	return &EncryptedFileStore{Path: path, passphrase: passphrase}
+}
+
+// Load decrypts the encrypted store
+func (s *EncryptedFileStore) Load() error {
+	if len(s.passphrase) == 0 {
+		return errors.New("passphrase of the encrypted credential store is empty")
+	}
+	s.credentials = Credentials{
+		Environments:   make(map[string]Environment),
+		MgwAdapterEnvs: make(map[string]MgAdapterEnv),
+	}
+
+	info, err := os.Stat(s.Path)
+	if os.IsNotExist(err) {
+		s.salt = make([]byte, saltLen)
+		_, err = io.ReadFull(rand.Reader, s.salt)
+		return err
+	} else if err != nil {
+		return err
+	} else if info.IsDir() {
+		return fmt.Errorf("%s is a directory", s.Path)
+	}
+
+	data, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return err
+	}
+	var envelope encryptedFile
+	if err = json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+	if envelope.Version != encryptedStoreVersion {
+		return fmt.Errorf("unsupported encrypted credential store version %d", envelope.Version)
+	}
+
+	gcm, err := s.newGCM(envelope.Salt)
+	if err != nil {
+		return err
+	}
+	plain, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return errors.New("unable to decrypt " + s.Path + ", passphrase or key file may be incorrect")
+	}
+
+	var cred Credentials
+	if err = json.Unmarshal(plain, &cred); err != nil {
+		return err
+	}
+	if cred.Environments != nil {
+		s.credentials.Environments = cred.Environments
+	}
+	if cred.MgwAdapterEnvs != nil {
+		s.credentials.MgwAdapterEnvs = cred.MgwAdapterEnvs
+	}
+	s.salt = envelope.Salt
+	return nil
+}
+
+// newGCM derives the key from the passphrase and salt and returns the AEAD cipher
+func (s *EncryptedFileStore) newGCM(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(s.passphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// saves to disk, a fresh nonce is used for every write
+func (s *EncryptedFileStore) persist() error {
+	plain, err := json.Marshal(s.credentials)
+	if err != nil {
+		return err
+	}
+	gcm, err := s.newGCM(s.salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(encryptedFile{
+		Version:    encryptedStoreVersion,
+		KDF:        "scrypt",
+		Salt:       s.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plain, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeStoreFile(s.Path, data, 0600)
+}
+
+// update applies change to the credentials decrypted again from the file while holding its lock and saves them,
+// so that the changes saved by other commands since the store was loaded are not overwritten
+func (s *EncryptedFileStore) update(change func() error) error {
+	unlock, err := lockStoreFile(s.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err = s.Load(); err != nil {
+		return err
+	}
+	if err = change(); err != nil {
+		return err
+	}
+	return s.persist()
+}
+
+// GetAPIMCredentials returns credentials for apim from the store or an error
+func (s *EncryptedFileStore) GetAPIMCredentials(env string) (Credential, error) {
+	if environment, ok := s.credentials.Environments[env]; ok {
+		return environment.APIM, nil
+	}
+	return Credential{}, fmt.Errorf("credentials not found for APIM in %s, use login", env)
+}
+
+// SetAPIMCredentials sets credentials for apim using username, password, clientID, client secret and access token
+func (s *EncryptedFileStore) SetAPIMCredentials(env, username, password, clientId, clientSecret, personalAccessToken string) error {
+	return s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.APIM = Credential{
+			Username:            username,
+			Password:            password,
+			ClientId:            clientId,
+			ClientSecret:        clientSecret,
+			PersonalAccessToken: personalAccessToken,
+		}
+		// tokens issued with the previous credentials are not reused
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// SetAPIMGrant sets credentials for apim using the grant type, clientID, client secret and assertion file
+func (s *EncryptedFileStore) SetAPIMGrant(env, grantType, clientId, clientSecret, assertionFile string) error {
+	return s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.APIM = Credential{
+			ClientId:      clientId,
+			ClientSecret:  clientSecret,
+			GrantType:     grantType,
+			AssertionFile: assertionFile,
+		}
+		// tokens issued with the previous credentials are not reused
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// GetMICredentials returns credentials for micro integrator from the store or an error
+func (s *EncryptedFileStore) GetMICredentials(env string) (MiCredential, error) {
+	if environment, ok := s.credentials.Environments[env]; ok {
+		return environment.MI, nil
+	}
+	return MiCredential{}, fmt.Errorf("credentials not found for Mi in %s, use login", env)
+}
+
+// SetMICredentials set credentials for mi using username, password, accessToken
+func (s *EncryptedFileStore) SetMICredentials(env, username, password, accessToken string) error {
+	return s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.MI = MiCredential{
+			Username:    username,
+			Password:    password,
+			AccessToken: accessToken,
+		}
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// GetMGToken returns token for microgateway adapter from the store or an error
+func (s *EncryptedFileStore) GetMGToken(env string) (MgAdapterEnv, error) {
+	if mgAdapterEnv, ok := s.credentials.MgwAdapterEnvs[env]; ok {
+		return mgAdapterEnv, nil
+	}
+	return MgAdapterEnv{}, fmt.Errorf(
+		"Tokens not found for Mgw in %s. Log in with `apictl mg login [env]`", env)
+}
+
+// SetMGToken set token for microgateway adapter
+func (s *EncryptedFileStore) SetMGToken(env, accessToken string) error {
+	return s.update(func() error {
+		s.credentials.MgwAdapterEnvs[env] = MgAdapterEnv{AccessToken: accessToken}
+		return nil
+	})
+}
+
+// EraseAPIM remove apim credentials from the store
+func (s *EncryptedFileStore) EraseAPIM(env string) error {
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !miCredentialsExists(environment.MI) {
+			delete(s.credentials.Environments, env)
+		} else {
+			environment.APIM = Credential{}
+			environment.APIMTokens = nil
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
+}
+
+// EraseMI remove mi credentials from the store
+func (s *EncryptedFileStore) EraseMI(env string) error {
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !apimCredentialsExists(environment.APIM) {
+			delete(s.credentials.Environments, env)
+		} else {
+			environment.MI = MiCredential{}
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
+}
+
+// EraseMG remove mg tokens from the store
+func (s *EncryptedFileStore) EraseMG(env string) error {
+	return s.update(func() error {
+		if _, ok := s.credentials.MgwAdapterEnvs[env]; !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		delete(s.credentials.MgwAdapterEnvs, env)
+		return nil
+	})
+}
+
+// GetAPIMTokens returns the cached apim access tokens of a given environment
+func (s *EncryptedFileStore) GetAPIMTokens(env string) (map[string]CachedToken, error) {
+	tokens := make(map[string]CachedToken)
+	for key, token := range s.credentials.Environments[env].APIMTokens {
+		tokens[key] = token
+	}
+	return tokens, nil
+}
+
+// SetAPIMToken caches an apim access token of a given environment
+func (s *EncryptedFileStore) SetAPIMToken(env, key string, token CachedToken) error {
+	return s.update(func() error {
+		environment := s.credentials.Environments[env]
+		if !apimCredentialsExists(environment.APIM) {
+			// the credentials have been erased by another command since the token was issued
+			return nil
+		}
+		if environment.APIMTokens == nil {
+			environment.APIMTokens = make(map[string]CachedToken)
+		}
+		environment.APIMTokens[key] = token
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// EraseAPIMTokens removes the cached apim access tokens of a given environment
+func (s *EncryptedFileStore) EraseAPIMTokens(env string) error {
+	if environment, ok := s.credentials.Environments[env]; !ok || environment.APIMTokens == nil {
+		return nil
+	}
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return nil
+		}
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// HasAPIM return the existance of apim credentials in the store for a given environment
+func (s *EncryptedFileStore) HasAPIM(env string) bool {
+	if environment, ok := s.credentials.Environments[env]; ok {
+		return apimCredentialsExists(environment.APIM)
+	}
+	return false
+}
+
+// HasMI return the existance of mi credentials in the store for a given environment
+func (s *EncryptedFileStore) HasMI(env string) bool {
+	if environment, ok := s.credentials.Environments[env]; ok {
+		return miCredentialsExists(environment.MI)
+	}
+	return false
+}
+
+// HasMG return the existance of mg tokens in the store for a given environment
+func (s *EncryptedFileStore) HasMG(env string) bool {
+	if mgwAdapterEnv, ok := s.credentials.MgwAdapterEnvs[env]; ok {
+		return mgTokenExists(mgwAdapterEnv)
+	}
+	return false
+}
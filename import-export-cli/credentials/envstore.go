@@ -0,0 +1,185 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvStoreVariablePrefix is the prefix of the environment variables read by the env store
+const EnvStoreVariablePrefix = "APICTL_"
+
+// Suffixes of the environment variables read by the env store. For an environment named "dev" the
+// APIM username is read from APICTL_DEV_USERNAME, the MI password from APICTL_DEV_MI_PASSWORD and so on.
+const (
+	envSuffixUsername     = "USERNAME"
+	envSuffixPassword     = "PASSWORD"
+	envSuffixClientID     = "CLIENT_ID"
+	envSuffixClientSecret = "CLIENT_SECRET"
+	envSuffixToken        = "TOKEN"
+	envSuffixGrantType    = "GRANT_TYPE"
+	envSuffixAssertion    = "ASSERTION_FILE"
+	envSuffixMIUsername   = "MI_USERNAME"
+	envSuffixMIPassword   = "MI_PASSWORD"
+	envSuffixMIToken      = "MI_TOKEN"
+	envSuffixMGToken      = "MG_TOKEN"
+)
+
+// EnvStore is a read-only store which takes every credential from APICTL_<ENV>_* environment variables.
+// It is intended for CI pipelines where the credentials are injected by the pipeline itself.
+type EnvStore struct{}
+
+// NewEnvStore creates a new env store
+func NewEnvStore() *EnvStore {
+	return &EnvStore{}
+}
+
+// EnvStoreVariableName returns the name of the variable holding the given credential of env
+func EnvStoreVariableName(env, suffix string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, env)
+	return EnvStoreVariablePrefix + strings.ToUpper(name) + "_" + suffix
+}
+
+func (s *EnvStore) get(env, suffix string) string {
+	return os.Getenv(EnvStoreVariableName(env, suffix))
+}
+
+// Load env store, nothing to load since variables are read on demand
+func (s *EnvStore) Load() error {
+	return nil
+}
+
+// GetAPIMCredentials returns credentials for apim from the environment variables or an error
+func (s *EnvStore) GetAPIMCredentials(env string) (Credential, error) {
+	credential := Credential{
+		Username:            s.get(env, envSuffixUsername),
+		Password:            s.get(env, envSuffixPassword),
+		ClientId:            s.get(env, envSuffixClientID),
+		ClientSecret:        s.get(env, envSuffixClientSecret),
+		PersonalAccessToken: s.get(env, envSuffixToken),
+		GrantType:           s.get(env, envSuffixGrantType),
+		AssertionFile:       s.get(env, envSuffixAssertion),
+	}
+	if !apimCredentialsExists(credential) {
+		return Credential{}, fmt.Errorf("credentials not found for APIM in %s, set %s and %s or %s", env,
+			EnvStoreVariableName(env, envSuffixUsername), EnvStoreVariableName(env, envSuffixPassword),
+			EnvStoreVariableName(env, envSuffixToken))
+	}
+	return credential, nil
+}
+
+// GetMICredentials returns credentials for micro integrator from the environment variables or an error
+func (s *EnvStore) GetMICredentials(env string) (MiCredential, error) {
+	credential := MiCredential{
+		Username:    s.get(env, envSuffixMIUsername),
+		Password:    s.get(env, envSuffixMIPassword),
+		AccessToken: s.get(env, envSuffixMIToken),
+	}
+	if !miCredentialsExists(credential) {
+		return MiCredential{}, fmt.Errorf("credentials not found for Mi in %s, set %s, %s and %s", env,
+			EnvStoreVariableName(env, envSuffixMIUsername), EnvStoreVariableName(env, envSuffixMIPassword),
+			EnvStoreVariableName(env, envSuffixMIToken))
+	}
+	return credential, nil
+}
+
+// GetMGToken returns token for microgateway adapter from the environment variables or an error
+func (s *EnvStore) GetMGToken(env string) (MgAdapterEnv, error) {
+	mgAdapterEnv := MgAdapterEnv{AccessToken: s.get(env, envSuffixMGToken)}
+	if !mgTokenExists(mgAdapterEnv) {
+		return MgAdapterEnv{}, fmt.Errorf("Tokens not found for Mgw in %s, set %s", env,
+			EnvStoreVariableName(env, envSuffixMGToken))
+	}
+	return mgAdapterEnv, nil
+}
+
+// SetAPIMCredentials is not supported by the env store
+func (s *EnvStore) SetAPIMCredentials(env, username, password, clientId, clientSecret, personalAccessToken string) error {
+	return ErrReadOnlyStore
+}
+
+// SetAPIMGrant is not supported by the env store
+func (s *EnvStore) SetAPIMGrant(env, grantType, clientId, clientSecret, assertionFile string) error {
+	return ErrReadOnlyStore
+}
+
+// SetMICredentials is not supported by the env store
+func (s *EnvStore) SetMICredentials(env, username, password, accessToken string) error {
+	return ErrReadOnlyStore
+}
+
+// SetMGToken is not supported by the env store
+func (s *EnvStore) SetMGToken(env, accessToken string) error {
+	return ErrReadOnlyStore
+}
+
+// EraseAPIM is not supported by the env store
+func (s *EnvStore) EraseAPIM(env string) error {
+	return ErrReadOnlyStore
+}
+
+// EraseMI is not supported by the env store
+func (s *EnvStore) EraseMI(env string) error {
+	return ErrReadOnlyStore
+}
+
+// EraseMG is not supported by the env store
+func (s *EnvStore) EraseMG(env string) error {
+	return ErrReadOnlyStore
+}
+
+// GetAPIMTokens returns no tokens as the env store does not cache tokens
+func (s *EnvStore) GetAPIMTokens(env string) (map[string]CachedToken, error) {
+	return map[string]CachedToken{}, nil
+}
+
+// SetAPIMToken is not supported by the env store
+func (s *EnvStore) SetAPIMToken(env, key string, token CachedToken) error {
+	return ErrReadOnlyStore
+}
+
+// EraseAPIMTokens is not supported by the env store
+func (s *EnvStore) EraseAPIMTokens(env string) error {
+	return ErrReadOnlyStore
+}
+
+// HasAPIM return the existance of apim credentials in the environment variables for a given environment
+func (s *EnvStore) HasAPIM(env string) bool {
+	_, err := s.GetAPIMCredentials(env)
+	return err == nil
+}
+
+// HasMI return the existance of mi credentials in the environment variables for a given environment
+func (s *EnvStore) HasMI(env string) bool {
+	_, err := s.GetMICredentials(env)
+	return err == nil
+}
+
+// HasMG return the existance of mg tokens in the environment variables for a given environment
+func (s *EnvStore) HasMG(env string) bool {
+	_, err := s.GetMGToken(env)
+	return err == nil
+}
@@ -75,11 +75,24 @@ func (s *JsonStore) persist() error {
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(s.Path, data, os.ModePerm)
+	return writeStoreFile(s.Path, data, 0600)
+}
+
+// update applies change to the credentials read again from the file while holding its lock and saves them, so
+// that the changes saved by other commands since the store was loaded are not overwritten
+func (s *JsonStore) update(change func() error) error {
+	unlock, err := lockStoreFile(s.Path)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer unlock()
+	if err = s.Load(); err != nil {
+		return err
+	}
+	if err = change(); err != nil {
+		return err
+	}
+	return s.persist()
 }
 
 // GetAPIMCredentials returns credentials for apim from the store or an error
@@ -105,8 +118,16 @@ func (s *JsonStore) GetAPIMCredentials(env string) (Credential, error) {
 		if err != nil {
 			return Credential{}, err
 		}
+		grantType, err := Base64Decode(environment.APIM.GrantType)
+		if err != nil {
+			return Credential{}, err
+		}
+		assertionFile, err := Base64Decode(environment.APIM.AssertionFile)
+		if err != nil {
+			return Credential{}, err
+		}
 		credential := Credential{
-			username, password, clientID, clientSecret, personalAccessToken,
+			username, password, clientID, clientSecret, personalAccessToken, grantType, assertionFile,
 		}
 		return credential, nil
 	}
@@ -115,16 +136,42 @@ func (s *JsonStore) GetAPIMCredentials(env string) (Credential, error) {
 
 // SetAPIMCredentials sets credentials for apim using username, password, clientID, client secret and access token
 func (s *JsonStore) SetAPIMCredentials(env, username, password, clientId, clientSecret, personalAccessToken string) error {
-	environment := s.credentials.Environments[env]
-	environment.APIM = Credential{
-		Username:            Base64Encode(username),
-		Password:            Base64Encode(password),
-		ClientId:            Base64Encode(clientId),
-		ClientSecret:        Base64Encode(clientSecret),
-		PersonalAccessToken: Base64Encode(personalAccessToken),
+	err := s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.APIM = Credential{
+			Username:            Base64Encode(username),
+			Password:            Base64Encode(password),
+			ClientId:            Base64Encode(clientId),
+			ClientSecret:        Base64Encode(clientSecret),
+			PersonalAccessToken: Base64Encode(personalAccessToken),
+		}
+		// tokens issued with the previous credentials are not reused
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	s.credentials.Environments[env] = environment
-	err := s.persist()
+	fmt.Printf(PlainTextWarnMessage, s.Path)
+	return nil
+}
+
+// SetAPIMGrant sets credentials for apim using the grant type, clientID, client secret and assertion file
+func (s *JsonStore) SetAPIMGrant(env, grantType, clientId, clientSecret, assertionFile string) error {
+	err := s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.APIM = Credential{
+			ClientId:      Base64Encode(clientId),
+			ClientSecret:  Base64Encode(clientSecret),
+			GrantType:     Base64Encode(grantType),
+			AssertionFile: Base64Encode(assertionFile),
+		}
+		// tokens issued with the previous credentials are not reused
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -157,14 +204,16 @@ func (s *JsonStore) GetMICredentials(env string) (MiCredential, error) {
 
 // SetMICredentials set credentials for mi using username, password, accessToken
 func (s *JsonStore) SetMICredentials(env, username, password, accessToken string) error {
-	environment := s.credentials.Environments[env]
-	environment.MI = MiCredential{
-		Username:    Base64Encode(username),
-		Password:    Base64Encode(password),
-		AccessToken: Base64Encode(accessToken),
-	}
-	s.credentials.Environments[env] = environment
-	err := s.persist()
+	err := s.update(func() error {
+		environment := s.credentials.Environments[env]
+		environment.MI = MiCredential{
+			Username:    Base64Encode(username),
+			Password:    Base64Encode(password),
+			AccessToken: Base64Encode(accessToken),
+		}
+		s.credentials.Environments[env] = environment
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -183,59 +232,119 @@ func (s *JsonStore) GetMGToken(env string) (MgAdapterEnv, error) {
 
 // SetMGToken set token for microgateway adapter
 func (s *JsonStore) SetMGToken(env, accessToken string) error {
-	mgwAdapterEnv := s.credentials.MgwAdapterEnvs[env]
-	mgwAdapterEnv.AccessToken = accessToken
-	s.credentials.MgwAdapterEnvs[env] = mgwAdapterEnv
-	if err := s.persist(); err != nil {
-		return err
-	}
-	return nil
+	return s.update(func() error {
+		mgwAdapterEnv := s.credentials.MgwAdapterEnvs[env]
+		mgwAdapterEnv.AccessToken = accessToken
+		s.credentials.MgwAdapterEnvs[env] = mgwAdapterEnv
+		return nil
+	})
 }
 
 // EraseAPIM remove apim credentials from the store
 func (s *JsonStore) EraseAPIM(env string) error {
-	environment, ok := s.credentials.Environments[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	}
-	if !miCredentialsExists(environment.MI) {
-		// delete the environment
-		delete(s.credentials.Environments, env)
-	} else {
-		// remove only apim credentials
-		environment.APIM = Credential{}
-		s.credentials.Environments[env] = environment
-	}
-	return s.persist()
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !miCredentialsExists(environment.MI) {
+			// delete the environment
+			delete(s.credentials.Environments, env)
+		} else {
+			// remove only apim credentials
+			environment.APIM = Credential{}
+			environment.APIMTokens = nil
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
 }
 
 // EraseMI remove mi credentials from the store
 func (s *JsonStore) EraseMI(env string) error {
-	environment, ok := s.credentials.Environments[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	}
-	if !apimCredentialsExists(environment.APIM) {
-		// delete the environment
-		delete(s.credentials.Environments, env)
-	} else {
-		// remove only mi credentials
-		environment.MI = MiCredential{}
-		s.credentials.Environments[env] = environment
-	}
-	return s.persist()
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		}
+		if !apimCredentialsExists(environment.APIM) {
+			// delete the environment
+			delete(s.credentials.Environments, env)
+		} else {
+			// remove only mi credentials
+			environment.MI = MiCredential{}
+			s.credentials.Environments[env] = environment
+		}
+		return nil
+	})
 }
 
 // EraseMG remove mg tokens from the store
 func (s *JsonStore) EraseMG(env string) error {
-	_, ok := s.credentials.MgwAdapterEnvs[env]
-	if !ok {
-		return fmt.Errorf("%s was not found", env)
-	} else {
-		// remove only mg tokens
-		delete(s.credentials.MgwAdapterEnvs, env)
+	return s.update(func() error {
+		_, ok := s.credentials.MgwAdapterEnvs[env]
+		if !ok {
+			return fmt.Errorf("%s was not found", env)
+		} else {
+			// remove only mg tokens
+			delete(s.credentials.MgwAdapterEnvs, env)
+		}
+		return nil
+	})
+}
+
+// GetAPIMTokens returns the cached apim access tokens of a given environment
+func (s *JsonStore) GetAPIMTokens(env string) (map[string]CachedToken, error) {
+	tokens := make(map[string]CachedToken)
+	for key, token := range s.credentials.Environments[env].APIMTokens {
+		accessToken, err := Base64Decode(token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		refreshToken, err := Base64Decode(token.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+		tokens[key] = CachedToken{AccessToken: accessToken, RefreshToken: refreshToken, ExpiresAt: token.ExpiresAt}
 	}
-	return s.persist()
+	return tokens, nil
+}
+
+// SetAPIMToken caches an apim access token of a given environment
+func (s *JsonStore) SetAPIMToken(env, key string, token CachedToken) error {
+	return s.update(func() error {
+		environment := s.credentials.Environments[env]
+		if !apimCredentialsExists(environment.APIM) {
+			// the credentials have been erased by another command since the token was issued
+			return nil
+		}
+		if environment.APIMTokens == nil {
+			environment.APIMTokens = make(map[string]CachedToken)
+		}
+		environment.APIMTokens[key] = CachedToken{
+			AccessToken:  Base64Encode(token.AccessToken),
+			RefreshToken: Base64Encode(token.RefreshToken),
+			ExpiresAt:    token.ExpiresAt,
+		}
+		s.credentials.Environments[env] = environment
+		return nil
+	})
+}
+
+// EraseAPIMTokens removes the cached apim access tokens of a given environment
+func (s *JsonStore) EraseAPIMTokens(env string) error {
+	if environment, ok := s.credentials.Environments[env]; !ok || environment.APIMTokens == nil {
+		return nil
+	}
+	return s.update(func() error {
+		environment, ok := s.credentials.Environments[env]
+		if !ok {
+			return nil
+		}
+		environment.APIMTokens = nil
+		s.credentials.Environments[env] = environment
+		return nil
+	})
 }
 
 // IsKeychainEnabled returns if another store is activated
@@ -276,6 +385,8 @@ func apimCredentialsExists(apimCred Credential) bool {
 		return true
 	} else if apimCred.PersonalAccessToken != "" {
 		return true
+	} else if apimCred.GrantType != "" && apimCred.ClientId != "" && apimCred.ClientSecret != "" {
+		return true
 	}
 	return false
 }
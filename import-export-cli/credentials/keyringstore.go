@@ -0,0 +1,235 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// DefaultKeyringService is the service name under which the secrets are saved in the OS keyring
+var DefaultKeyringService = "wso2-apictl"
+
+// Prefixes of the keyring entries of each credential type
+const (
+	keyringAPIMPrefix = "apim/"
+	keyringMIPrefix   = "mi/"
+	keyringMGPrefix   = "mg/"
+	// cached apim access tokens are saved separately, so the credentials are not rewritten for every new token
+	keyringAPIMTokensPrefix = "apim-tokens/"
+)
+
+// KeyringStore is storing keys in the OS keyring (Secret Service over D-Bus, macOS Keychain or
+// Windows Credential Manager). Each credential is saved as a separate JSON encoded secret.
+type KeyringStore struct {
+	// Service name used for the keyring entries
+	Service string
+}
+
+// NewKeyringStore creates a new keyring store
+func NewKeyringStore(service string) *KeyringStore {
+	return &KeyringStore{Service: service}
+}
+
+// Load checks whether the keyring is reachable
+func (s *KeyringStore) Load() error {
+	_, err := keyring.Get(s.Service, keyringAPIMPrefix)
+	if err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("unable to access the OS keyring: %v", err)
+	}
+	return nil
+}
+
+// read unmarshals the secret saved under key into v, returns false if the secret does not exist
+func (s *KeyringStore) read(key string, v interface{}) (bool, error) {
+	secret, err := keyring.Get(s.Service, key)
+	if err == keyring.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal([]byte(secret), v)
+}
+
+// write saves v as a JSON encoded secret under key
+func (s *KeyringStore) write(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(s.Service, key, string(data))
+}
+
+// erase removes the secret saved under key
+func (s *KeyringStore) erase(env, key string) error {
+	err := keyring.Delete(s.Service, key)
+	if err == keyring.ErrNotFound {
+		return fmt.Errorf("%s was not found", env)
+	}
+	return err
+}
+
+// GetAPIMCredentials returns credentials for apim from the store or an error
+func (s *KeyringStore) GetAPIMCredentials(env string) (Credential, error) {
+	var credential Credential
+	found, err := s.read(keyringAPIMPrefix+env, &credential)
+	if err != nil {
+		return Credential{}, err
+	}
+	if !found {
+		return Credential{}, fmt.Errorf("credentials not found for APIM in %s, use login", env)
+	}
+	return credential, nil
+}
+
+// SetAPIMCredentials sets credentials for apim using username, password, clientID, client secret and access token
+func (s *KeyringStore) SetAPIMCredentials(env, username, password, clientId, clientSecret, personalAccessToken string) error {
+	// tokens issued with the previous credentials are not reused
+	if err := s.EraseAPIMTokens(env); err != nil {
+		return err
+	}
+	return s.write(keyringAPIMPrefix+env, Credential{
+		Username:            username,
+		Password:            password,
+		ClientId:            clientId,
+		ClientSecret:        clientSecret,
+		PersonalAccessToken: personalAccessToken,
+	})
+}
+
+// SetAPIMGrant sets credentials for apim using the grant type, clientID, client secret and assertion file
+func (s *KeyringStore) SetAPIMGrant(env, grantType, clientId, clientSecret, assertionFile string) error {
+	// tokens issued with the previous credentials are not reused
+	if err := s.EraseAPIMTokens(env); err != nil {
+		return err
+	}
+	return s.write(keyringAPIMPrefix+env, Credential{
+		ClientId:      clientId,
+		ClientSecret:  clientSecret,
+		GrantType:     grantType,
+		AssertionFile: assertionFile,
+	})
+}
+
+// GetMICredentials returns credentials for micro integrator from the store or an error
+func (s *KeyringStore) GetMICredentials(env string) (MiCredential, error) {
+	var credential MiCredential
+	found, err := s.read(keyringMIPrefix+env, &credential)
+	if err != nil {
+		return MiCredential{}, err
+	}
+	if !found {
+		return MiCredential{}, fmt.Errorf("credentials not found for Mi in %s, use login", env)
+	}
+	return credential, nil
+}
+
+// SetMICredentials set credentials for mi using username, password, accessToken
+func (s *KeyringStore) SetMICredentials(env, username, password, accessToken string) error {
+	return s.write(keyringMIPrefix+env, MiCredential{
+		Username:    username,
+		Password:    password,
+		AccessToken: accessToken,
+	})
+}
+
+// GetMGToken returns token for microgateway adapter from the store or an error
+func (s *KeyringStore) GetMGToken(env string) (MgAdapterEnv, error) {
+	var mgAdapterEnv MgAdapterEnv
+	found, err := s.read(keyringMGPrefix+env, &mgAdapterEnv)
+	if err != nil {
+		return MgAdapterEnv{}, err
+	}
+	if !found {
+		return MgAdapterEnv{}, fmt.Errorf(
+			"Tokens not found for Mgw in %s. Log in with `apictl mg login [env]`", env)
+	}
+	return mgAdapterEnv, nil
+}
+
+// SetMGToken set token for microgateway adapter
+func (s *KeyringStore) SetMGToken(env, accessToken string) error {
+	return s.write(keyringMGPrefix+env, MgAdapterEnv{AccessToken: accessToken})
+}
+
+// EraseAPIM remove apim credentials from the store
+func (s *KeyringStore) EraseAPIM(env string) error {
+	if err := s.erase(env, keyringAPIMPrefix+env); err != nil {
+		return err
+	}
+	return s.EraseAPIMTokens(env)
+}
+
+// EraseMI remove mi credentials from the store
+func (s *KeyringStore) EraseMI(env string) error {
+	return s.erase(env, keyringMIPrefix+env)
+}
+
+// EraseMG remove mg tokens from the store
+func (s *KeyringStore) EraseMG(env string) error {
+	return s.erase(env, keyringMGPrefix+env)
+}
+
+// GetAPIMTokens returns the cached apim access tokens of a given environment
+func (s *KeyringStore) GetAPIMTokens(env string) (map[string]CachedToken, error) {
+	tokens := make(map[string]CachedToken)
+	if _, err := s.read(keyringAPIMTokensPrefix+env, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// SetAPIMToken caches an apim access token of a given environment
+func (s *KeyringStore) SetAPIMToken(env, key string, token CachedToken) error {
+	tokens, err := s.GetAPIMTokens(env)
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+	return s.write(keyringAPIMTokensPrefix+env, tokens)
+}
+
+// EraseAPIMTokens removes the cached apim access tokens of a given environment
+func (s *KeyringStore) EraseAPIMTokens(env string) error {
+	err := keyring.Delete(s.Service, keyringAPIMTokensPrefix+env)
+	if err == keyring.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// HasAPIM return the existance of apim credentials in the store for a given environment
+func (s *KeyringStore) HasAPIM(env string) bool {
+	credential, err := s.GetAPIMCredentials(env)
+	return err == nil && apimCredentialsExists(credential)
+}
+
+// HasMI return the existance of mi credentials in the store for a given environment
+func (s *KeyringStore) HasMI(env string) bool {
+	credential, err := s.GetMICredentials(env)
+	return err == nil && miCredentialsExists(credential)
+}
+
+// HasMG return the existance of mg tokens in the store for a given environment
+func (s *KeyringStore) HasMG(env string) bool {
+	mgAdapterEnv, err := s.GetMGToken(env)
+	return err == nil && mgTokenExists(mgAdapterEnv)
+}
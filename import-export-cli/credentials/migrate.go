@@ -0,0 +1,97 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"errors"
+	"sort"
+)
+
+// MigrateJsonStore moves every entry of the json store to the target store. Once all the entries are
+// copied, the json store is cleared and marked with the type of the target store.
+// Returns the names of the migrated environments.
+func MigrateJsonStore(source *JsonStore, target Store, targetType string) ([]string, error) {
+	if _, ok := target.(*JsonStore); ok {
+		return nil, errors.New("target credential store is a json store, select another store in main_config.yaml")
+	}
+	if _, ok := target.(*EnvStore); ok {
+		return nil, errors.New("env credential store is read-only, credentials cannot be migrated to it")
+	}
+
+	migrated := make(map[string]bool)
+	for env := range source.credentials.Environments {
+		if source.HasAPIM(env) {
+			cred, err := source.GetAPIMCredentials(env)
+			if err != nil {
+				return nil, err
+			}
+			if cred.GrantType != "" {
+				err = target.SetAPIMGrant(env, cred.GrantType, cred.ClientId, cred.ClientSecret, cred.AssertionFile)
+			} else {
+				err = target.SetAPIMCredentials(env, cred.Username, cred.Password, cred.ClientId, cred.ClientSecret,
+					cred.PersonalAccessToken)
+			}
+			if err != nil {
+				return nil, err
+			}
+			migrated[env] = true
+		}
+		if source.HasMI(env) {
+			cred, err := source.GetMICredentials(env)
+			if err != nil {
+				return nil, err
+			}
+			if err = target.SetMICredentials(env, cred.Username, cred.Password, cred.AccessToken); err != nil {
+				return nil, err
+			}
+			migrated[env] = true
+		}
+	}
+	for env := range source.credentials.MgwAdapterEnvs {
+		if source.HasMG(env) {
+			mgAdapterEnv, err := source.GetMGToken(env)
+			if err != nil {
+				return nil, err
+			}
+			if err = target.SetMGToken(env, mgAdapterEnv.AccessToken); err != nil {
+				return nil, err
+			}
+			migrated[env] = true
+		}
+	}
+
+	err := source.update(func() error {
+		source.credentials = Credentials{
+			Environments:   make(map[string]Environment),
+			MgwAdapterEnvs: make(map[string]MgAdapterEnv),
+			CredStore:      targetType,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	envs := make([]string, 0, len(migrated))
+	for env := range migrated {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
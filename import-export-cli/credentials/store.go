@@ -18,6 +18,19 @@
 
 package credentials
 
+import "errors"
+
+// Types of credential stores that can be selected in main_config.yaml
+const (
+	JsonStoreType          = "json"
+	EncryptedFileStoreType = "encrypted-file"
+	KeyringStoreType       = "keyring"
+	EnvStoreType           = "env"
+)
+
+// ErrReadOnlyStore is returned when a credential store does not support modifications
+var ErrReadOnlyStore = errors.New("credential store is read-only")
+
 type Store interface {
 	// Has return the existance of apim credentials in the store for a given environment
 	HasAPIM(env string) bool
@@ -33,6 +46,8 @@ type Store interface {
 	GetMGToken(env string) (MgAdapterEnv, error)
 	// SetAPIMCredentials sets credentials for micro integrator using username, password, clientID and client secret
 	SetAPIMCredentials(env, username, password, clientID, clientSecret, accessToken string) error
+	// SetAPIMGrant sets credentials for apim which get access tokens using a grant other than the password grant
+	SetAPIMGrant(env, grantType, clientID, clientSecret, assertionFile string) error
 	// SetMICredentials sets credentials for micro integrator using username, password and access token
 	SetMICredentials(env, username, password, accessToken string) error
 	// SetMGToken sets the Access Token for a Microgateway Adapter env
@@ -43,6 +58,12 @@ type Store interface {
 	EraseMI(env string) error
 	// Erase mg token in a given microgateway Adapter env
 	EraseMG(env string) error
+	// GetAPIMTokens returns the cached apim access tokens of a given environment keyed by the user and the scopes
+	GetAPIMTokens(env string) (map[string]CachedToken, error)
+	// SetAPIMToken caches an apim access token of a given environment under the key
+	SetAPIMToken(env, key string, token CachedToken) error
+	// EraseAPIMTokens removes the cached apim access tokens of a given environment
+	EraseAPIMTokens(env string) error
 	// Load store
 	Load() error
 }
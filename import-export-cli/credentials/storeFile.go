@@ -0,0 +1,88 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Timings of the lock taken while updating the file of a credential store
+const (
+	storeLockRetryInterval = 10 * time.Millisecond
+	// a lock older than storeLockStaleAfter is left behind by a process which exited while holding it
+	storeLockStaleAfter = 10 * time.Second
+	storeLockTimeout    = 30 * time.Second
+)
+
+// lockStoreFile takes the lock of the file of a credential store, so that the processes updating the store at the
+// same time do not overwrite the changes of each other. The lock is a file created next to the store, which works
+// on every platform. Returns a function which releases the lock
+func lockStoreFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(storeLockTimeout)
+	for {
+		lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			lock.Close()
+			return func() {
+				os.Remove(lockPath)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > storeLockStaleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("unable to lock %s, remove %s if no other command is running", path, lockPath)
+		}
+		time.Sleep(storeLockRetryInterval)
+	}
+}
+
+// writeStoreFile replaces the file of a credential store with data. data is written to a temporary file in the same
+// directory which is renamed over the store, so that a process reading the store never reads a partly written file.
+// The mode of the existing file is kept, a new file is created with perm
+func writeStoreFile(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
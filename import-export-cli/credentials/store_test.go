@@ -0,0 +1,313 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zalando/go-keyring"
+)
+
+var sampleAPIMCredential = Credential{
+	Username:     "admin",
+	Password:     "s3cr3t-p4ss",
+	ClientId:     "Cc6cvS9dOxhkgz9uhTnv7QlOjlka",
+	ClientSecret: "gqkpq1dOMj5u3jIjBEW2d7eW0xIa",
+}
+
+var sampleMICredential = MiCredential{
+	Username:    "miAdmin",
+	Password:    "miPass",
+	AccessToken: "eyJhbGciOiJSUzI1NiJ9.mi",
+}
+
+const sampleMGToken = "eyJhbGciOiJSUzI1NiJ9.mg"
+
+// testWritableStoreContract verifies the behaviour every writable store must provide.
+// open is called several times and must return a freshly loaded store backed by the same storage.
+func testWritableStoreContract(t *testing.T, open func() Store) {
+	store := open()
+	assert.False(t, store.HasAPIM("dev"), "Empty store should not have apim credentials")
+	assert.False(t, store.HasMI("dev"), "Empty store should not have mi credentials")
+	assert.False(t, store.HasMG("dev"), "Empty store should not have mg tokens")
+	_, err := store.GetAPIMCredentials("dev")
+	assert.Error(t, err, "Should return an error for missing apim credentials")
+	_, err = store.GetMICredentials("dev")
+	assert.Error(t, err, "Should return an error for missing mi credentials")
+	_, err = store.GetMGToken("dev")
+	assert.Error(t, err, "Should return an error for missing mg tokens")
+	assert.Error(t, store.EraseAPIM("dev"), "Should return an error when erasing a missing environment")
+
+	c := sampleAPIMCredential
+	assert.Nil(t, store.SetAPIMCredentials("dev", c.Username, c.Password, c.ClientId, c.ClientSecret, ""))
+	assert.Nil(t, store.SetMICredentials("dev", sampleMICredential.Username, sampleMICredential.Password,
+		sampleMICredential.AccessToken))
+	assert.Nil(t, store.SetMGToken("dev", sampleMGToken))
+	assert.Nil(t, store.SetAPIMCredentials("prod", "", "", "", "", "personal-token"))
+
+	store = open()
+	assert.True(t, store.HasAPIM("dev"), "Credentials should be persisted")
+	apimCred, err := store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleAPIMCredential, apimCred)
+	miCred, err := store.GetMICredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleMICredential, miCred)
+	mgToken, err := store.GetMGToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleMGToken, mgToken.AccessToken)
+	assert.True(t, store.HasAPIM("prod"), "Personal access token alone should be a valid apim credential")
+	assert.False(t, store.HasMI("prod"), "Credentials of an environment should not leak to another")
+
+	assert.Nil(t, store.SetAPIMGrant("ci", "jwt-bearer", c.ClientId, c.ClientSecret, "/var/run/token"))
+	store = open()
+	assert.True(t, store.HasAPIM("ci"), "Client credentials with a grant type should be a valid apim credential")
+	apimCred, err = store.GetAPIMCredentials("ci")
+	assert.Nil(t, err)
+	assert.Equal(t, Credential{ClientId: c.ClientId, ClientSecret: c.ClientSecret, GrantType: "jwt-bearer",
+		AssertionFile: "/var/run/token"}, apimCred)
+
+	token := CachedToken{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour).UTC()}
+	assert.Nil(t, store.SetAPIMToken("dev", "admin scopes", token))
+	store = open()
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.True(t, token.ExpiresAt.Equal(tokens["admin scopes"].ExpiresAt), "Cached tokens should be persisted")
+	assert.Equal(t, token.RefreshToken, tokens["admin scopes"].RefreshToken)
+	apimCred, err = store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleAPIMCredential, apimCred, "Caching a token should keep the credentials")
+	assert.Nil(t, store.EraseAPIMTokens("dev"))
+	tokens, err = store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens, "Erased tokens should not exist")
+	assert.Nil(t, store.SetAPIMToken("dev", "admin scopes", token))
+
+	assert.Nil(t, store.EraseAPIM("dev"))
+	store = open()
+	assert.False(t, store.HasAPIM("dev"), "Erased apim credentials should not exist")
+	tokens, err = store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens, "Erasing apim credentials should erase the cached tokens")
+	assert.True(t, store.HasMI("dev"), "Erasing apim credentials should keep mi credentials")
+	assert.Nil(t, store.EraseMI("dev"))
+	assert.Nil(t, store.EraseMG("dev"))
+	assert.Nil(t, store.EraseAPIM("prod"))
+
+	store = open()
+	assert.False(t, store.HasMI("dev"))
+	assert.False(t, store.HasMG("dev"))
+	assert.False(t, store.HasAPIM("prod"))
+}
+
+// testConcurrentFileStoreUpdates verifies that a store loaded before another command saved its changes
+// merges them instead of overwriting them, and that no temporary or lock files are left next to the store
+func testConcurrentFileStoreUpdates(t *testing.T, path string, open func() Store) {
+	c := sampleAPIMCredential
+	assert.Nil(t, open().SetAPIMCredentials("dev", c.Username, c.Password, c.ClientId, c.ClientSecret, ""))
+
+	stale := open()
+	assert.Nil(t, open().SetMICredentials("dev", sampleMICredential.Username, sampleMICredential.Password,
+		sampleMICredential.AccessToken))
+	assert.Nil(t, open().SetAPIMCredentials("prod", "", "", "", "", "personal-token"))
+	token := CachedToken{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour).UTC()}
+	assert.Nil(t, stale.SetAPIMToken("dev", "admin scopes", token))
+
+	store := open()
+	assert.True(t, store.HasMI("dev"), "Caching a token should keep the credentials saved by another command")
+	assert.True(t, store.HasAPIM("prod"), "Caching a token should keep the environments added by another command")
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, token.AccessToken, tokens["admin scopes"].AccessToken)
+
+	stale = open()
+	assert.Nil(t, open().EraseAPIM("dev"))
+	assert.Nil(t, stale.SetAPIMToken("dev", "admin scopes", token))
+	store = open()
+	assert.False(t, store.HasAPIM("dev"), "Caching a token should not restore the credentials erased by another command")
+	tokens, err = store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens)
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1, "Only the store file should be left behind")
+	assert.Equal(t, filepath.Base(path), files[0].Name())
+}
+
+func TestJsonStoreConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	testConcurrentFileStoreUpdates(t, path, func() Store {
+		store := NewJsonStore(path)
+		assert.Nil(t, store.Load())
+		return store
+	})
+}
+
+func TestEncryptedFileStoreConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEncryptedStoreFile)
+	testConcurrentFileStoreUpdates(t, path, func() Store {
+		store := NewEncryptedFileStore(path, []byte("correct horse battery staple"))
+		assert.Nil(t, store.Load())
+		return store
+	})
+}
+
+func TestLockStoreFileRemovesStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	assert.Nil(t, ioutil.WriteFile(path+".lock", nil, 0600))
+	stale := time.Now().Add(-2 * storeLockStaleAfter)
+	assert.Nil(t, os.Chtimes(path+".lock", stale, stale))
+
+	unlock, err := lockStoreFile(path)
+	assert.Nil(t, err, "A stale lock should not block the store")
+	unlock()
+	_, err = os.Stat(path + ".lock")
+	assert.True(t, os.IsNotExist(err), "Unlocking should remove the lock file")
+}
+
+func TestJsonStoreContract(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	testWritableStoreContract(t, func() Store {
+		store := NewJsonStore(path)
+		assert.Nil(t, store.Load())
+		return store
+	})
+}
+
+func TestEncryptedFileStoreContract(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEncryptedStoreFile)
+	testWritableStoreContract(t, func() Store {
+		store := NewEncryptedFileStore(path, []byte("correct horse battery staple"))
+		assert.Nil(t, store.Load())
+		return store
+	})
+}
+
+func TestKeyringStoreContract(t *testing.T) {
+	keyring.MockInit()
+	testWritableStoreContract(t, func() Store {
+		store := NewKeyringStore(DefaultKeyringService)
+		assert.Nil(t, store.Load())
+		return store
+	})
+}
+
+func TestEnvStoreContract(t *testing.T) {
+	store := NewEnvStore()
+	assert.Nil(t, store.Load())
+	assert.False(t, store.HasAPIM("dev"), "Store should be empty without variables")
+	assert.False(t, store.HasMI("dev"), "Store should be empty without variables")
+	assert.False(t, store.HasMG("dev"), "Store should be empty without variables")
+
+	t.Setenv("APICTL_DEV_USERNAME", sampleAPIMCredential.Username)
+	t.Setenv("APICTL_DEV_PASSWORD", sampleAPIMCredential.Password)
+	t.Setenv("APICTL_DEV_CLIENT_ID", sampleAPIMCredential.ClientId)
+	t.Setenv("APICTL_DEV_CLIENT_SECRET", sampleAPIMCredential.ClientSecret)
+	t.Setenv("APICTL_DEV_MI_USERNAME", sampleMICredential.Username)
+	t.Setenv("APICTL_DEV_MI_PASSWORD", sampleMICredential.Password)
+	t.Setenv("APICTL_DEV_MI_TOKEN", sampleMICredential.AccessToken)
+	t.Setenv("APICTL_DEV_MG_TOKEN", sampleMGToken)
+	t.Setenv("APICTL_PROD_EU_TOKEN", "personal-token")
+	t.Setenv("APICTL_CI_CLIENT_ID", sampleAPIMCredential.ClientId)
+	t.Setenv("APICTL_CI_CLIENT_SECRET", sampleAPIMCredential.ClientSecret)
+	t.Setenv("APICTL_CI_GRANT_TYPE", "client_credentials")
+
+	apimCred, err := store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleAPIMCredential, apimCred)
+	miCred, err := store.GetMICredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleMICredential, miCred)
+	mgToken, err := store.GetMGToken("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleMGToken, mgToken.AccessToken)
+	assert.True(t, store.HasAPIM("prod-eu"), "Environment names should be normalized to variable names")
+	apimCred, err = store.GetAPIMCredentials("ci")
+	assert.Nil(t, err)
+	assert.Equal(t, "client_credentials", apimCred.GrantType)
+
+	assert.Equal(t, ErrReadOnlyStore, store.SetAPIMCredentials("dev", "a", "b", "c", "d", ""))
+	assert.Equal(t, ErrReadOnlyStore, store.SetAPIMGrant("dev", "client_credentials", "a", "b", ""))
+	assert.Equal(t, ErrReadOnlyStore, store.SetMICredentials("dev", "a", "b", "c"))
+	assert.Equal(t, ErrReadOnlyStore, store.SetMGToken("dev", "a"))
+	assert.Equal(t, ErrReadOnlyStore, store.EraseAPIM("dev"))
+	assert.Equal(t, ErrReadOnlyStore, store.EraseMI("dev"))
+	assert.Equal(t, ErrReadOnlyStore, store.EraseMG("dev"))
+	assert.Equal(t, ErrReadOnlyStore, store.SetAPIMToken("dev", "key", CachedToken{AccessToken: "a"}))
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestEncryptedFileStoreWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultEncryptedStoreFile)
+	store := NewEncryptedFileStore(path, []byte("passphrase"))
+	assert.Nil(t, store.Load())
+	c := sampleAPIMCredential
+	assert.Nil(t, store.SetAPIMCredentials("dev", c.Username, c.Password, c.ClientId, c.ClientSecret, ""))
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), c.Password, "Password should not be stored in plain text")
+	assert.NotContains(t, string(data), c.ClientSecret, "Client secret should not be stored in plain text")
+
+	assert.Error(t, NewEncryptedFileStore(path, []byte("wrong")).Load(), "Should not decrypt with a wrong passphrase")
+	assert.Error(t, NewEncryptedFileStore(path, nil).Load(), "Should not load without a passphrase")
+}
+
+func TestMigrateJsonStore(t *testing.T) {
+	dir := t.TempDir()
+	source := NewJsonStore(filepath.Join(dir, DefaultConfigFile))
+	assert.Nil(t, source.Load())
+	c := sampleAPIMCredential
+	assert.Nil(t, source.SetAPIMCredentials("dev", c.Username, c.Password, c.ClientId, c.ClientSecret, ""))
+	assert.Nil(t, source.SetMICredentials("prod", sampleMICredential.Username, sampleMICredential.Password,
+		sampleMICredential.AccessToken))
+	assert.Nil(t, source.SetMGToken("mg", sampleMGToken))
+	assert.Nil(t, source.SetAPIMGrant("ci", "client_credentials", c.ClientId, c.ClientSecret, ""))
+
+	target := NewEncryptedFileStore(filepath.Join(dir, DefaultEncryptedStoreFile), []byte("passphrase"))
+	assert.Nil(t, target.Load())
+	envs, err := MigrateJsonStore(source, target, EncryptedFileStoreType)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ci", "dev", "mg", "prod"}, envs)
+
+	apimCred, err := target.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, sampleAPIMCredential, apimCred)
+	apimCred, err = target.GetAPIMCredentials("ci")
+	assert.Nil(t, err)
+	assert.Equal(t, "client_credentials", apimCred.GrantType, "Grant of the credentials should be migrated")
+	assert.True(t, target.HasMI("prod"))
+	assert.True(t, target.HasMG("mg"))
+
+	source = NewJsonStore(source.Path)
+	assert.Nil(t, source.Load())
+	assert.False(t, source.HasAPIM("dev"), "Migrated credentials should be removed from keys.json")
+	assert.True(t, source.IsKeychainEnabled(), "keys.json should record the store the credentials were moved to")
+
+	_, err = MigrateJsonStore(source, NewEnvStore(), EnvStoreType)
+	assert.Error(t, err, "Should not migrate to a read-only store")
+}
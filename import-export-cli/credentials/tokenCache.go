@@ -0,0 +1,215 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// TokenExpiryMargin is the time before the expiry of a cached access token from which it is no longer used
+var TokenExpiryMargin = time.Minute
+
+// CachedToken is an access token of APIM cached in the credential store so that it can be reused across invocations
+type CachedToken struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// TokenCacheKey returns the key of the tokens issued to username with the given scopes
+func TokenCacheKey(username, scopes string) string {
+	return username + " " + scopes
+}
+
+// isUsable returns true if the access token does not expire within the expiry margin from now
+func (t CachedToken) isUsable(now time.Time) bool {
+	return t.AccessToken != "" && now.Add(TokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+func newCachedToken(tokenResponse *utils.OAuthTokenResponse, now time.Time) CachedToken {
+	return CachedToken{
+		AccessToken:  tokenResponse.AccessToken,
+		RefreshToken: tokenResponse.RefreshToken,
+		ExpiresAt:    now.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}
+}
+
+// tokenCacheSubject returns the user or the client the tokens of the credential are issued to
+func (c Credential) tokenCacheSubject() string {
+	if c.GrantType != "" && c.GrantType != utils.LoginGrantPassword {
+		return c.GrantType + ":" + c.ClientId
+	}
+	return c.Username
+}
+
+// getOAuthAccessTokenFromCache returns the access token cached in the store until it is close to the expiry. Then the
+// token is refreshed using the refresh_token grant, and the grant of the credential is used only if the refresh fails.
+// New tokens are cached in the store. A nil store disables the cache.
+func getOAuthAccessTokenFromCache(store Store, credential Credential, env, tokenEndpoint string) (string, error) {
+	key := TokenCacheKey(credential.tokenCacheSubject(), utils.DefaultOAuthScopes)
+	var cachedToken CachedToken
+	var found bool
+	if store != nil {
+		tokens, err := store.GetAPIMTokens(env)
+		if err != nil {
+			utils.Logln(utils.LogPrefixWarning+"Unable to read the cached tokens of "+env+":", err)
+		}
+		cachedToken, found = tokens[key]
+	}
+	now := time.Now()
+	if found && cachedToken.isUsable(now) {
+		utils.Logln(utils.LogPrefixInfo + "Using the cached access token of " + env)
+		return cachedToken.AccessToken, nil
+	}
+
+	b64EncodedClientIDClientSecret := Base64Encode(credential.ClientId + ":" + credential.ClientSecret)
+	var tokenResponse *utils.OAuthTokenResponse
+	var err error
+	if found && cachedToken.RefreshToken != "" {
+		utils.Logln(utils.LogPrefixInfo + "Refreshing the access token of " + env)
+		tokenResponse, err = utils.GetOAuthTokensWithRefreshGrant(cachedToken.RefreshToken, utils.DefaultOAuthScopes,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+		if err != nil {
+			utils.Logln(utils.LogPrefixWarning+"Unable to refresh the access token, requesting a new token:", err)
+		}
+	}
+	if tokenResponse == nil {
+		tokenResponse, err = requestOAuthTokens(credential, env, tokenEndpoint)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if store != nil {
+		err = store.SetAPIMToken(env, key, newCachedToken(tokenResponse, now))
+		if err != nil && err != ErrReadOnlyStore {
+			utils.Logln(utils.LogPrefixWarning+"Unable to cache the access token of "+env+":", err)
+		}
+	}
+	return tokenResponse.AccessToken, nil
+}
+
+// requestOAuthTokens requests new tokens using the grant of the credential
+func requestOAuthTokens(credential Credential, env, tokenEndpoint string) (*utils.OAuthTokenResponse, error) {
+	b64EncodedClientIDClientSecret := Base64Encode(credential.ClientId + ":" + credential.ClientSecret)
+	switch credential.GrantType {
+	case "", utils.LoginGrantPassword:
+		return utils.GetOAuthTokensWithPasswordGrant(credential.Username, credential.Password,
+			utils.DefaultOAuthScopes, b64EncodedClientIDClientSecret, tokenEndpoint)
+	case utils.LoginGrantClientCredentials:
+		return utils.GetOAuthTokensWithClientCredentialsGrant(utils.DefaultOAuthScopes,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+	case utils.LoginGrantJWTBearer:
+		assertion, err := ReadAssertionFile(credential.AssertionFile)
+		if err != nil {
+			return nil, err
+		}
+		return utils.GetOAuthTokensWithJWTBearerGrant(assertion, utils.DefaultOAuthScopes,
+			b64EncodedClientIDClientSecret, tokenEndpoint)
+	case utils.LoginGrantDeviceCode:
+		// the device authorization needs the user, so it is only done by the login command
+		return nil, fmt.Errorf("the session of %s has expired, login again using '%s login %s --grant %s'",
+			env, utils.ProjectName, env, utils.LoginGrantDeviceCode)
+	default:
+		return nil, fmt.Errorf("unsupported grant type %q for %s", credential.GrantType, env)
+	}
+}
+
+// ReadAssertionFile reads the JWT assertion exchanged using the jwt-bearer grant. The file is read on each token
+// request since workload identity tokens are short lived and replaced by the issuer.
+func ReadAssertionFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	assertion := strings.TrimSpace(string(data))
+	if assertion == "" {
+		return "", errors.New("assertion file " + path + " is empty")
+	}
+	return assertion, nil
+}
+
+// CacheOAuthTokens caches the tokens issued to the credential stored for env, so that they are used by the
+// following commands
+func CacheOAuthTokens(store Store, credential Credential, env string, tokenResponse *utils.OAuthTokenResponse) error {
+	key := TokenCacheKey(credential.tokenCacheSubject(), utils.DefaultOAuthScopes)
+	return store.SetAPIMToken(env, key, newCachedToken(tokenResponse, time.Now()))
+}
+
+// RevokeCachedTokens revokes the access and refresh tokens cached for env and removes them from the store
+func RevokeCachedTokens(store Store, credential Credential, env string) error {
+	return revokeCachedTokens(store, credential, env, utils.GetTokenRevokeEndpoint(env, utils.MainConfigFilePath))
+}
+
+func revokeCachedTokens(store Store, credential Credential, env, tokenRevokeEndpoint string) error {
+	tokens, err := store.GetAPIMTokens(env)
+	if err != nil {
+		return err
+	}
+	var revokeErrors error
+	for _, token := range tokens {
+		if token.RefreshToken != "" {
+			err = revokeToken(credential, tokenRevokeEndpoint, token.RefreshToken, utils.RefreshTokenTypeForRevocation)
+			if err != nil {
+				revokeErrors = multierror.Append(revokeErrors, err)
+			}
+		}
+		err = revokeToken(credential, tokenRevokeEndpoint, token.AccessToken, utils.TokenTypeForRevocation)
+		if err != nil {
+			revokeErrors = multierror.Append(revokeErrors, err)
+		}
+	}
+	// the tokens are removed from the store even if the revocation fails, so they are not used anymore
+	if err = store.EraseAPIMTokens(env); err != nil && err != ErrReadOnlyStore {
+		revokeErrors = multierror.Append(revokeErrors, err)
+	}
+	return revokeErrors
+}
+
+// revokeToken revokes the token of the given type (token_type_hint) at the revoke endpoint
+func revokeToken(credential Credential, tokenRevokeEndpoint, token, tokenTypeHint string) error {
+	//Encoding client secret and client Id
+	var b64EncodedClientIDClientSecret = utils.GetBase64EncodedCredentials(credential.ClientId, credential.ClientSecret)
+	// set headers to request
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueXWWWFormUrlEncoded
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
+
+	//Create body for the request
+	body := utils.HeaderToken + token + tokenTypeHint
+
+	utils.Logln(utils.LogPrefixInfo + "connecting to " + tokenRevokeEndpoint)
+	resp, err := utils.InvokePOSTRequest(tokenRevokeEndpoint, headers, body)
+	if err != nil {
+		return err
+	}
+	//Check status code
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New("Request didn't respond 200 OK for token revocation Status: " + resp.Status())
+	}
+	return nil
+}
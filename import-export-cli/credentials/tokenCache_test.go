@@ -0,0 +1,246 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package credentials
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// tokenServer is a token and revoke endpoint issuing sequential tokens
+type tokenServer struct {
+	*httptest.Server
+	mutex        sync.Mutex
+	grants       []string
+	assertions   []string
+	revoked      []string
+	expiresIn    int64
+	failRefresh  bool
+	issuedTokens int
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	server := &tokenServer{expiresIn: 3600}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		grantType := r.PostForm.Get("grant_type")
+		server.grants = append(server.grants, grantType)
+		if grantType == "refresh_token" && server.failRefresh {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if grantType == "password" {
+			assert.Equal(t, sampleAPIMCredential.Password, r.PostForm.Get("password"))
+		}
+		if grantType == utils.OAuthGrantTypeJWTBearer {
+			server.assertions = append(server.assertions, r.PostForm.Get("assertion"))
+		}
+		assert.Equal(t, utils.DefaultOAuthScopes, r.PostForm.Get("scope"))
+		server.issuedTokens++
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-" + strconv.Itoa(server.issuedTokens),
+			"refresh_token": "refresh-" + strconv.Itoa(server.issuedTokens),
+			"token_type":    "Bearer",
+			"expires_in":    server.expiresIn,
+		})
+	})
+	mux.HandleFunc("/oauth2/revoke", func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		server.revoked = append(server.revoked, r.PostForm.Get("token_type_hint")+":"+r.PostForm.Get("token"))
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestTokenStore(t *testing.T) Store {
+	store := NewJsonStore(filepath.Join(t.TempDir(), DefaultConfigFile))
+	assert.Nil(t, store.Load())
+	c := sampleAPIMCredential
+	assert.Nil(t, store.SetAPIMCredentials("dev", c.Username, c.Password, c.ClientId, c.ClientSecret, ""))
+	return store
+}
+
+func TestGetOAuthAccessTokenFromCacheReusesToken(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+
+	for i := 0; i < 3; i++ {
+		token, err := getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+		assert.Nil(t, err)
+		assert.Equal(t, "access-1", token)
+	}
+	assert.Equal(t, []string{"password"}, server.grants, "The cached token should be reused")
+
+	// a freshly loaded store should still have the token
+	reloaded := NewJsonStore(store.(*JsonStore).Path)
+	assert.Nil(t, reloaded.Load())
+	token, err := getOAuthAccessTokenFromCache(reloaded, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token)
+	assert.Len(t, server.grants, 1)
+}
+
+func TestGetOAuthAccessTokenFromCacheRefreshesExpiringToken(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+	// tokens expiring within the expiry margin are not reused
+	server.expiresIn = int64(TokenExpiryMargin/time.Second) - 1
+
+	token, err := getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token)
+	token, err = getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-2", token)
+	assert.Equal(t, []string{"password", "refresh_token"}, server.grants)
+
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh-2", tokens[TokenCacheKey(sampleAPIMCredential.Username, utils.DefaultOAuthScopes)].RefreshToken)
+}
+
+func TestGetOAuthAccessTokenFromCacheFallsBackToPasswordGrant(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+	server.expiresIn = 0
+	server.failRefresh = true
+
+	_, err := getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	token, err := getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-2", token)
+	assert.Equal(t, []string{"password", "refresh_token", "password"}, server.grants)
+}
+
+func TestGetOAuthAccessTokenFromCacheWithoutStore(t *testing.T) {
+	server := newTokenServer(t)
+	for i := 1; i <= 2; i++ {
+		token, err := getOAuthAccessTokenFromCache(nil, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+		assert.Nil(t, err)
+		assert.Equal(t, "access-"+strconv.Itoa(i), token)
+	}
+
+	_, err := getOAuthAccessTokenFromCache(NewEnvStore(), sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err, "A read-only store should not fail the token request")
+	assert.Equal(t, []string{"password", "password", "password"}, server.grants)
+}
+
+func TestRevokeCachedTokens(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+	_, err := getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+
+	assert.Nil(t, revokeCachedTokens(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/revoke"))
+	assert.Equal(t, []string{"refresh_token:refresh-1", "access_token:access-1"}, server.revoked)
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens, "Revoked tokens should be removed from the cache")
+	assert.True(t, store.HasAPIM("dev"), "Revoking the tokens should keep the credentials")
+
+	// the tokens are removed even if the revoke endpoint is not reachable
+	_, err = getOAuthAccessTokenFromCache(store, sampleAPIMCredential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.NotNil(t, revokeCachedTokens(store, sampleAPIMCredential, "dev", server.URL+"/missing"))
+	tokens, err = store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestGetOAuthAccessTokenFromCacheWithGrant(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+	server.expiresIn = 0
+	server.failRefresh = true
+	c := sampleAPIMCredential
+	assert.Nil(t, store.SetAPIMGrant("dev", utils.LoginGrantClientCredentials, c.ClientId, c.ClientSecret, ""))
+	credential, err := store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+
+	for i := 1; i <= 2; i++ {
+		token, err := getOAuthAccessTokenFromCache(store, credential, "dev", server.URL+"/oauth2/token")
+		assert.Nil(t, err)
+		assert.Equal(t, "access-"+strconv.Itoa(i), token)
+	}
+	assert.Equal(t, []string{"client_credentials", "refresh_token", "client_credentials"}, server.grants,
+		"Expired tokens should be requested again using the grant of the login")
+	tokens, err := store.GetAPIMTokens("dev")
+	assert.Nil(t, err)
+	assert.Contains(t, tokens, TokenCacheKey("client_credentials:"+c.ClientId, utils.DefaultOAuthScopes))
+}
+
+func TestGetOAuthAccessTokenFromCacheWithJWTBearerGrant(t *testing.T) {
+	server := newTokenServer(t)
+	server.expiresIn = 0
+	assertionFile := filepath.Join(t.TempDir(), "token")
+	credential := Credential{ClientId: "client", ClientSecret: "secret", GrantType: utils.LoginGrantJWTBearer,
+		AssertionFile: assertionFile}
+
+	// the assertion is read on each request since the issuer replaces it
+	for _, assertion := range []string{"first.jwt", "second.jwt"} {
+		assert.Nil(t, ioutil.WriteFile(assertionFile, []byte(assertion+"\n"), 0600))
+		_, err := getOAuthAccessTokenFromCache(nil, credential, "dev", server.URL+"/oauth2/token")
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []string{"first.jwt", "second.jwt"}, server.assertions)
+
+	assert.Nil(t, ioutil.WriteFile(assertionFile, []byte("  \n"), 0600))
+	_, err := getOAuthAccessTokenFromCache(nil, credential, "dev", server.URL+"/oauth2/token")
+	assert.Error(t, err, "Should not request tokens with an empty assertion")
+}
+
+func TestGetOAuthAccessTokenFromCacheWithDeviceCodeGrant(t *testing.T) {
+	server := newTokenServer(t)
+	store := newTestTokenStore(t)
+	c := sampleAPIMCredential
+	assert.Nil(t, store.SetAPIMGrant("dev", utils.LoginGrantDeviceCode, c.ClientId, c.ClientSecret, ""))
+	credential, err := store.GetAPIMCredentials("dev")
+	assert.Nil(t, err)
+	server.expiresIn = 0
+	assert.Nil(t, CacheOAuthTokens(store, credential, "dev", &utils.OAuthTokenResponse{AccessToken: "device-access",
+		RefreshToken: "device-refresh"}))
+
+	token, err := getOAuthAccessTokenFromCache(store, credential, "dev", server.URL+"/oauth2/token")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token, "Device login should be kept alive using the refresh token")
+
+	server.failRefresh = true
+	_, err = getOAuthAccessTokenFromCache(store, credential, "dev", server.URL+"/oauth2/token")
+	assert.Error(t, err, "Device authorization needs the user, so the login should be repeated")
+	assert.Contains(t, err.Error(), "--grant device_code")
+	assert.Equal(t, []string{"refresh_token", "refresh_token"}, server.grants)
+}
@@ -0,0 +1,211 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/wso2/product-apim-tooling/import-export-cli/templates"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JsonOutputKey is the identifier used for json output
+const JsonOutputKey = "json"
+
+// YamlOutputKey is the identifier used for yaml output
+const YamlOutputKey = "yaml"
+
+// JsonPathOutputPrefix is the prefix of a jsonpath output. eg: jsonpath={.items[*].name}
+const JsonPathOutputPrefix = "jsonpath="
+
+// GoTemplateOutputPrefix is the prefix of a go-template output. eg: go-template={{.name}}
+const GoTemplateOutputPrefix = "go-template="
+
+// OutputFlagUsage is the usage of the --output flag shared by the commands
+const OutputFlagUsage = "Output format. One of: json|yaml|jsonpath=<template>|go-template=<template>"
+
+// stdout is the standard output of the process, where the structured output is written
+var stdout io.Writer = os.Stdout
+
+// IsJson returns true if format is json
+func (f Format) IsJson() bool {
+	return string(f) == JsonOutputKey
+}
+
+// IsYaml returns true if format is yaml
+func (f Format) IsYaml() bool {
+	return string(f) == YamlOutputKey
+}
+
+// IsJsonPath returns true if format string is prefixed with jsonpath=
+func (f Format) IsJsonPath() bool {
+	return strings.HasPrefix(string(f), JsonPathOutputPrefix)
+}
+
+// IsGoTemplate returns true if format string is prefixed with go-template=
+func (f Format) IsGoTemplate() bool {
+	return strings.HasPrefix(string(f), GoTemplateOutputPrefix)
+}
+
+// IsStructured returns true if format is one of the machine readable output formats
+func (f Format) IsStructured() bool {
+	return f.IsJson() || f.IsYaml() || f.IsJsonPath() || f.IsGoTemplate()
+}
+
+// ValidateOutputFormat returns an error if format is not a supported output format
+func ValidateOutputFormat(format string) error {
+	f := Format(format)
+	if !f.IsStructured() {
+		return fmt.Errorf("unsupported output format %q. Supported formats: json, yaml, "+
+			"jsonpath=<template>, go-template=<template>", format)
+	}
+	if f.IsJsonPath() && strings.TrimSpace(format[len(JsonPathOutputPrefix):]) == "" {
+		return fmt.Errorf("jsonpath template cannot be empty")
+	}
+	if f.IsGoTemplate() && strings.TrimSpace(format[len(GoTemplateOutputPrefix):]) == "" {
+		return fmt.Errorf("go-template cannot be empty")
+	}
+	return nil
+}
+
+// WriteData writes data in the structured output format of the context. data is serialized using its json
+// tags, so jsonpath and go-template expressions refer to the same field names shown in the json output
+func (ctx *Context) WriteData(data interface{}) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if ctx.Format.IsJson() {
+		_, err = fmt.Fprintln(ctx.Output, string(content))
+		return err
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// keep numbers as they are instead of converting them to float64 (eg: 1e+06)
+	decoder.UseNumber()
+	if err = decoder.Decode(&generic); err != nil {
+		return err
+	}
+
+	switch {
+	case ctx.Format.IsYaml():
+		content, err = yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = ctx.Output.Write(content)
+		return err
+	case ctx.Format.IsJsonPath():
+		return writeJsonPath(ctx.Output, string(ctx.Format)[len(JsonPathOutputPrefix):], generic)
+	case ctx.Format.IsGoTemplate():
+		tmpl, err := templates.NewBasicFormatter("output").Parse(string(ctx.Format)[len(GoTemplateOutputPrefix):])
+		if err != nil {
+			return fmt.Errorf("Template parsing error: %v\n", err)
+		}
+		return tmpl.Execute(ctx.Output, generic)
+	}
+	return fmt.Errorf("unsupported output format %q", ctx.Format)
+}
+
+// writeJsonPath writes the results of the kubectl style jsonpath expression on data to output
+func writeJsonPath(output io.Writer, expression string, data interface{}) error {
+	expression = strings.TrimSpace(expression)
+	// allow the short form used with kubectl. eg: .items[0].name
+	if !strings.Contains(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	parser := jsonpath.New("output")
+	if err := parser.Parse(expression); err != nil {
+		return fmt.Errorf("jsonpath parsing error: %v", err)
+	}
+	if err := parser.Execute(output, data); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(output)
+	return err
+}
+
+// WriteOutput writes data to the standard output if format is a structured output format. It returns false
+// without writing anything for the other formats so that the caller can continue with the usual output
+func WriteOutput(format string, data interface{}) (bool, error) {
+	if !Format(format).IsStructured() {
+		return false, nil
+	}
+	return true, NewContext(stdout, format).WriteData(data)
+}
+
+// MessageWriter returns the writer for the messages of a command other than its structured output. It is stderr
+// when format is a structured output format so that the messages do not mix with the output, stdout otherwise
+func MessageWriter(format string) io.Writer {
+	if Format(format).IsStructured() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// OutputValue is a pflag.Value for the --output flag which only accepts the structured output formats
+type OutputValue struct {
+	value *string
+}
+
+// NewOutputValue returns the value for an --output flag writing the format to value
+func NewOutputValue(value *string) *OutputValue {
+	return &OutputValue{value: value}
+}
+
+// String returns the current value of the flag. The value is shared with the --format flag of some commands, so
+// only a structured output format is returned (and shown as the default in the usage)
+func (o *OutputValue) String() string {
+	if o.value == nil || !Format(*o.value).IsStructured() {
+		return ""
+	}
+	return *o.value
+}
+
+// Set validates and sets the output format
+func (o *OutputValue) Set(format string) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	*o.value = format
+	return nil
+}
+
+// Type returns the type name shown in the usage
+func (o *OutputValue) Type() string {
+	return "string"
+}
+
+// AddOutputFlag adds the --output flag to flags. The -o shorthand is only added if the command does not already
+// use it for another flag (eg: --owner), so this should be called after the other flags are added
+func AddOutputFlag(flags *pflag.FlagSet, value *string) {
+	if flags.ShorthandLookup("o") == nil {
+		flags.VarP(NewOutputValue(value), "output", "o", OutputFlagUsage)
+	} else {
+		flags.Var(NewOutputValue(value), "output", OutputFlagUsage)
+	}
+}
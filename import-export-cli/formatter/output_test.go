@@ -0,0 +1,124 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package formatter
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+type outputTestAPI struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Count   int    `json:"count,omitempty"`
+}
+
+var outputTestAPIs = []outputTestAPI{
+	{Id: "01", Name: "PizzaShackAPI", Version: "1.0.0", Count: 1000000},
+	{Id: "02", Name: "SwaggerPetstore", Version: "1.0.5"},
+}
+
+func writeOutput(t *testing.T, format string, data interface{}) string {
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, NewContext(buffer, format).WriteData(data))
+	return buffer.String()
+}
+
+func TestWriteDataJson(t *testing.T) {
+	output := writeOutput(t, "json", outputTestAPIs[1])
+	assert.Equal(t, "{\n  \"id\": \"02\",\n  \"name\": \"SwaggerPetstore\",\n  \"version\": \"1.0.5\"\n}\n", output)
+}
+
+func TestWriteDataYaml(t *testing.T) {
+	output := writeOutput(t, "yaml", outputTestAPIs)
+	assert.Equal(t, "- count: 1000000\n  id: \"01\"\n  name: PizzaShackAPI\n  version: 1.0.0\n"+
+		"- id: \"02\"\n  name: SwaggerPetstore\n  version: 1.0.5\n", output)
+}
+
+func TestWriteDataJsonPath(t *testing.T) {
+	assert.Equal(t, "PizzaShackAPI SwaggerPetstore\n", writeOutput(t, "jsonpath={[*].name}", outputTestAPIs))
+	assert.Equal(t, "1000000\n", writeOutput(t, "jsonpath={[0].count}", outputTestAPIs),
+		"Numbers should not be printed in the exponent format")
+	assert.Equal(t, "01\n", writeOutput(t, "jsonpath=[?(@.name==\"PizzaShackAPI\")].id", outputTestAPIs),
+		"The braces should be optional")
+	assert.Equal(t, "PizzaShackAPI:1.0.0\nSwaggerPetstore:1.0.5\n\n",
+		writeOutput(t, `jsonpath={range [*]}{.name}:{.version}{"\n"}{end}`, outputTestAPIs))
+
+	err := NewContext(&bytes.Buffer{}, "jsonpath={[*].name").WriteData(outputTestAPIs)
+	assert.NotNil(t, err)
+}
+
+func TestWriteDataGoTemplate(t *testing.T) {
+	assert.Equal(t, "PizzaShackAPI,SwaggerPetstore,",
+		writeOutput(t, "go-template={{range .}}{{.name}},{{end}}", outputTestAPIs))
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{"json", "yaml", "jsonpath={.name}", "go-template={{.name}}"} {
+		assert.Nil(t, ValidateOutputFormat(format), format)
+	}
+	for _, format := range []string{"", "xml", "table", "{{.name}}", "jsonpath=", "go-template= "} {
+		assert.NotNil(t, ValidateOutputFormat(format), format)
+	}
+}
+
+func TestAddOutputFlag(t *testing.T) {
+	flags := pflag.NewFlagSet("get", pflag.ContinueOnError)
+	var format string
+	AddOutputFlag(flags, &format)
+	assert.Equal(t, "o", flags.Lookup("output").Shorthand)
+
+	flags = pflag.NewFlagSet("get", pflag.ContinueOnError)
+	var owner string
+	flags.StringVarP(&owner, "owner", "o", "", "Owner")
+	AddOutputFlag(flags, &format)
+	assert.Equal(t, "", flags.Lookup("output").Shorthand, "-o should be kept for the existing flag")
+
+	assert.NotNil(t, flags.Parse([]string{"--output", "xml"}))
+	assert.Equal(t, "", format)
+}
+
+func TestOutputFlagKeepsStdout(t *testing.T) {
+	originalStdout := os.Stdout
+	flags := pflag.NewFlagSet("get", pflag.ContinueOnError)
+	var format string
+	AddOutputFlag(flags, &format)
+
+	assert.Nil(t, flags.Parse([]string{"-o", "jsonpath={.name}"}))
+	assert.Equal(t, "jsonpath={.name}", format)
+	assert.Equal(t, originalStdout, os.Stdout)
+}
+
+func TestMessageWriter(t *testing.T) {
+	assert.Equal(t, os.Stderr, MessageWriter("json"))
+	assert.Equal(t, os.Stderr, MessageWriter("jsonpath={.name}"))
+	assert.Equal(t, os.Stdout, MessageWriter(""))
+	assert.Equal(t, os.Stdout, MessageWriter("table {{.Name}}"))
+}
+
+func TestWriteOutputSkipsOtherFormats(t *testing.T) {
+	written, err := WriteOutput("table {{.Name}}", outputTestAPIs)
+	assert.False(t, written)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,507 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Actions taken by the apply command on an artifact
+const (
+	ApplyActionCreate    = "create"
+	ApplyActionUpdate    = "update"
+	ApplyActionUnchanged = "unchanged"
+	ApplyActionDelete    = "delete"
+)
+
+// ApplyStatusPlanned is the status of an artifact when the apply command is run with --dry-run
+const ApplyStatusPlanned = "planned"
+
+const throttlingPolicyFileType = "throttling policy"
+const apiPolicyFileType = "operation_policy_specification"
+
+// applyOrder is the order in which the artifact types are applied. Policies are used by APIs, APIs are used by
+// API Products and Applications subscribe to both, hence an artifact is applied after the ones it depends on.
+// Removed artifacts are deleted in the reverse order.
+var applyOrder = []string{utils.ProjectTypePolicy, utils.ProjectTypeAPIPolicy, utils.ProjectTypeApi,
+	utils.ProjectTypeApiProduct, utils.ProjectTypeApplication}
+
+// ApplyArtifact is an artifact found in the directory given to the apply command
+type ApplyArtifact struct {
+	Type    string `yaml:"type" json:"type"`
+	Name    string `yaml:"name" json:"name"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+	// Owner is the provider of an API or API Product and the owner of an Application
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+	// PolicyType is the subtype of a throttling policy, ex: subscription policy
+	PolicyType   string `yaml:"policyType,omitempty" json:"policyType,omitempty"`
+	RelativePath string `yaml:"path" json:"path"`
+
+	// AbsolutePath is not persisted as the state must still be usable once the artifact is removed from the tree
+	AbsolutePath string          `yaml:"-" json:"-"`
+	MetaData     *utils.MetaData `yaml:"-" json:"-"`
+}
+
+// Key identifies the artifact in the environment
+func (a *ApplyArtifact) Key() string {
+	return strings.Join([]string{a.Type, a.PolicyType, a.Name, a.Version, a.Owner}, ":")
+}
+
+// ApplyResult is the outcome of applying a single artifact
+type ApplyResult struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path"`
+	Action  string `json:"action"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ApplyState is the set of artifacts applied from a directory to an environment, used to find the artifacts
+// removed from the directory since the previous run
+type ApplyState struct {
+	Source      string           `yaml:"source"`
+	Environment string           `yaml:"environment"`
+	Artifacts   []*ApplyArtifact `yaml:"artifacts"`
+}
+
+// applyClient performs the operations of the apply command on an environment
+type applyClient interface {
+	exists(artifact *ApplyArtifact) (bool, error)
+	importArtifact(artifact *ApplyArtifact, update bool) error
+	deleteArtifact(artifact *ApplyArtifact) error
+}
+
+// DiscoverApplyArtifacts finds the API, API Product, Application and policy projects inside basePath.
+// API, API Product and Application projects are detected the same way as in a VCS repository, using their
+// *_meta.yaml files. A directory detected as a project is not searched further.
+func DiscoverApplyArtifacts(basePath string) ([]*ApplyArtifact, error) {
+	basePath, err := filepath.Abs(basePath)
+	if err != nil {
+		return nil, err
+	}
+	pathInfoMap := make(map[string]*params.ProjectParams)
+	var artifacts []*ApplyArtifact
+	err = filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != basePath && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, _ := filepath.Rel(basePath, path)
+		if !info.IsDir() {
+			artifact, err := getThrottlingPolicyArtifact(path)
+			if artifact != nil {
+				artifact.RelativePath = relativePath
+				artifacts = append(artifacts, artifact)
+			}
+			return err
+		}
+
+		projectParams := checkProjectTypeOfSpecificPath(basePath, path, pathInfoMap)
+		var artifact *ApplyArtifact
+		if projectParams.Type != utils.ProjectTypeNone {
+			artifact, err = getProjectArtifact(projectParams)
+		} else {
+			artifact, err = getAPIPolicyArtifact(path)
+		}
+		if err != nil {
+			return err
+		}
+		if artifact == nil {
+			return nil
+		}
+		artifact.AbsolutePath = path
+		artifact.RelativePath = relativePath
+		artifacts = append(artifacts, artifact)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+	SortApplyArtifacts(artifacts)
+	return artifacts, nil
+}
+
+// Read the identity of an API, API Product or Application project from its definition file
+func getProjectArtifact(projectParams *params.ProjectParams) (*ApplyArtifact, error) {
+	artifact := &ApplyArtifact{Type: projectParams.Type, MetaData: projectParams.MetaData}
+	switch projectParams.Type {
+	case utils.ProjectTypeApi:
+		apiInfo, _, err := impl.GetAPIDefinition(projectParams.AbsolutePath)
+		if err != nil {
+			return nil, errors.New("Error reading the API definition of " + projectParams.AbsolutePath + ": " + err.Error())
+		}
+		artifact.Name, artifact.Version = apiInfo.Data.Name, apiInfo.Data.Version
+		if preservesProvider(projectParams.MetaData) {
+			artifact.Owner = apiInfo.Data.Provider
+		}
+	case utils.ProjectTypeApiProduct:
+		apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParams.AbsolutePath)
+		if err != nil {
+			return nil, errors.New("Error reading the API Product definition of " + projectParams.AbsolutePath + ": " +
+				err.Error())
+		}
+		artifact.Name, artifact.Version = apiProductInfo.Data.Name, apiProductInfo.Data.Version
+		if preservesProvider(projectParams.MetaData) {
+			artifact.Owner = apiProductInfo.Data.Provider
+		}
+	case utils.ProjectTypeApplication:
+		appInfo, _, err := impl.GetApplicationDefinition(projectParams.AbsolutePath)
+		if err != nil {
+			return nil, errors.New("Error reading the Application definition of " + projectParams.AbsolutePath + ": " +
+				err.Error())
+		}
+		artifact.Name, artifact.Owner = appInfo.Data.Applicationinfo.Name, appInfo.Data.Applicationinfo.Owner
+		// the owner given in the meta file overrides the owner in the definition, as in "import app --owner"
+		if projectParams.MetaData != nil && projectParams.MetaData.Owner != "" {
+			artifact.Owner = projectParams.MetaData.Owner
+		}
+	}
+	return artifact, nil
+}
+
+// An API or API Product is imported with the provider in its definition only if the provider is preserved, otherwise
+// the user applying it becomes the provider, which is not known here
+func preservesProvider(metaData *utils.MetaData) bool {
+	return metaData != nil && metaData.DeployConfig.Import.PreserveProvider
+}
+
+// Detect an API policy directory, which contains a policy specification named after the directory,
+// ex: AddLogMessage/AddLogMessage.yaml
+func getAPIPolicyArtifact(dirPath string) (*ApplyArtifact, error) {
+	for _, extension := range []string{".yaml", ".yml", ".json"} {
+		specPath := filepath.Join(dirPath, filepath.Base(dirPath)+extension)
+		if !utils.IsFileExist(specPath) {
+			continue
+		}
+		content, err := ioutil.ReadFile(specPath)
+		if err != nil {
+			return nil, err
+		}
+		var spec struct {
+			Type string `yaml:"type"`
+			Data struct {
+				Name    string `yaml:"name"`
+				Version string `yaml:"version"`
+			} `yaml:"data"`
+		}
+		if yaml.Unmarshal(content, &spec) != nil || spec.Type != apiPolicyFileType {
+			return nil, nil
+		}
+		return &ApplyArtifact{Type: utils.ProjectTypeAPIPolicy, Name: spec.Data.Name, Version: spec.Data.Version}, nil
+	}
+	return nil, nil
+}
+
+// Detect a throttling policy file exported using "export policy rate-limiting"
+func getThrottlingPolicyArtifact(filePath string) (*ApplyArtifact, error) {
+	switch filepath.Ext(filePath) {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var policy struct {
+		Type    string `yaml:"type"`
+		Subtype string `yaml:"subtype"`
+		Data    struct {
+			PolicyName string `yaml:"policyName"`
+		} `yaml:"data"`
+	}
+	if yaml.Unmarshal(content, &policy) != nil || !strings.EqualFold(policy.Type, throttlingPolicyFileType) {
+		return nil, nil
+	}
+	if getThrottlingPolicyCmdType(policy.Subtype) == "" {
+		return nil, errors.New("Unknown throttling policy type " + policy.Subtype + " in " + filePath)
+	}
+	return &ApplyArtifact{Type: utils.ProjectTypePolicy, Name: policy.Data.PolicyName, PolicyType: policy.Subtype,
+		AbsolutePath: filePath}, nil
+}
+
+// Map the subtype of an exported throttling policy to the policy type accepted by the delete policy command
+func getThrottlingPolicyCmdType(subtype string) string {
+	switch subtype {
+	case impl.ExportPolicyTypeSubscription:
+		return impl.CmdPolicyTypeSubscription
+	case impl.ExportPolicyTypeApplication:
+		return impl.CmdPolicyTypeApplication
+	case impl.ExportPolicyTypeAdvanced:
+		return impl.CmdPolicyTypeAdvanced
+	case impl.ExportPolicyTypeCustom:
+		return impl.CmdPolicyTypeCustom
+	}
+	return ""
+}
+
+func applyOrderOf(projectType string) int {
+	for i, t := range applyOrder {
+		if t == projectType {
+			return i
+		}
+	}
+	return len(applyOrder)
+}
+
+// SortApplyArtifacts sorts the artifacts in the order they should be applied
+func SortApplyArtifacts(artifacts []*ApplyArtifact) {
+	sort.SliceStable(artifacts, func(i, j int) bool {
+		if artifacts[i].Type != artifacts[j].Type {
+			return applyOrderOf(artifacts[i].Type) < applyOrderOf(artifacts[j].Type)
+		}
+		return artifacts[i].RelativePath < artifacts[j].RelativePath
+	})
+}
+
+// GetRemovedApplyArtifacts returns the artifacts of the previous state which are no longer in the tree, in the
+// order they should be deleted
+func GetRemovedApplyArtifacts(previous, current []*ApplyArtifact) []*ApplyArtifact {
+	currentKeys := make(map[string]bool)
+	for _, artifact := range current {
+		currentKeys[artifact.Key()] = true
+	}
+	var removed []*ApplyArtifact
+	for _, artifact := range previous {
+		if !currentKeys[artifact.Key()] {
+			removed = append(removed, artifact)
+		}
+	}
+	SortApplyArtifacts(removed)
+	for i, j := 0, len(removed)-1; i < j; i, j = i+1, j-1 {
+		removed[i], removed[j] = removed[j], removed[i]
+	}
+	return removed
+}
+
+// GetApplyStateFilePath returns the file holding the state of the given directory in the given environment
+func GetApplyStateFilePath(sourceDir, environment string) (string, error) {
+	absSourceDir, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(absSourceDir))
+	return filepath.Join(utils.DefaultApplyStateDirPath, environment, hex.EncodeToString(hash[:8])+".yaml"), nil
+}
+
+// LoadApplyState reads the state at path, an empty state is returned if the file does not exist
+func LoadApplyState(path string) (*ApplyState, error) {
+	state := &ApplyState{}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(content, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Write saves the state to path
+func (s *ApplyState) Write(path string) error {
+	if err := utils.CreateDirIfNotExist(filepath.Dir(path)); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// Apply creates or updates every artifact found in sourceDir in the given environment. With prune, the artifacts
+// applied previously from sourceDir which are no longer in it are deleted from the environment. With dryRun, the
+// actions are only planned.
+func Apply(accessToken, environment, sourceDir string, dryRun, prune bool) ([]*ApplyResult, error) {
+	return apply(&apimApplyClient{accessToken: accessToken, environment: environment}, environment, sourceDir,
+		dryRun, prune)
+}
+
+func apply(client applyClient, environment, sourceDir string, dryRun, prune bool) ([]*ApplyResult, error) {
+	sourceDir, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := DiscoverApplyArtifacts(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := GetApplyStateFilePath(sourceDir, environment)
+	if err != nil {
+		return nil, err
+	}
+	state, err := LoadApplyState(statePath)
+	if err != nil {
+		return nil, errors.New("Error reading the apply state " + statePath + ": " + err.Error())
+	}
+
+	previousArtifacts := make(map[string]*ApplyArtifact)
+	for _, artifact := range state.Artifacts {
+		previousArtifacts[artifact.Key()] = artifact
+	}
+
+	// only the artifacts applied successfully are recorded, an artifact which failed to apply stays in the state
+	// only if it was applied before
+	var results []*ApplyResult
+	newState := &ApplyState{Source: sourceDir, Environment: environment}
+	for _, artifact := range artifacts {
+		result := applyArtifact(client, artifact, dryRun)
+		if result.Status != utils.BulkStatusFailed {
+			newState.Artifacts = append(newState.Artifacts, artifact)
+		} else if previous, ok := previousArtifacts[artifact.Key()]; ok {
+			newState.Artifacts = append(newState.Artifacts, previous)
+		}
+		results = append(results, result)
+	}
+
+	// artifacts removed from the tree are kept in the state until they are pruned
+	for _, artifact := range GetRemovedApplyArtifacts(state.Artifacts, artifacts) {
+		if !prune {
+			utils.Logln(utils.LogPrefixInfo + artifact.Type + " " + artifact.Name + " was removed from the tree, " +
+				"use --prune to delete it")
+			newState.Artifacts = append(newState.Artifacts, artifact)
+			continue
+		}
+		result := pruneArtifact(client, artifact, dryRun)
+		if result.Status == utils.BulkStatusFailed || dryRun {
+			newState.Artifacts = append(newState.Artifacts, artifact)
+		}
+		results = append(results, result)
+	}
+
+	if !dryRun {
+		if err = newState.Write(statePath); err != nil {
+			return results, errors.New("Error writing the apply state " + statePath + ": " + err.Error())
+		}
+	}
+	return results, nil
+}
+
+func newApplyResult(artifact *ApplyArtifact) *ApplyResult {
+	return &ApplyResult{Type: artifact.Type, Name: artifact.Name, Version: artifact.Version,
+		Path: artifact.RelativePath}
+}
+
+// Create the artifact if it does not exist in the environment, update it otherwise
+func applyArtifact(client applyClient, artifact *ApplyArtifact, dryRun bool) *ApplyResult {
+	result := newApplyResult(artifact)
+	exists, err := client.exists(artifact)
+	if err != nil {
+		result.Action, result.Status, result.Message = ApplyActionCreate, utils.BulkStatusFailed, err.Error()
+		return result
+	}
+	result.Action = ApplyActionCreate
+	if exists {
+		result.Action = ApplyActionUpdate
+		// API policies are versioned and cannot be updated, a change requires a new version of the policy
+		if artifact.Type == utils.ProjectTypeAPIPolicy {
+			result.Action, result.Status = ApplyActionUnchanged, utils.BulkStatusSkipped
+			result.Message = "API policy versions cannot be updated"
+			return result
+		}
+	}
+	if dryRun {
+		result.Status = ApplyStatusPlanned
+		return result
+	}
+	utils.Logln(utils.LogPrefixInfo + "Applying " + artifact.Type + " " + artifact.Name + " (" + result.Action + ")")
+	if err = client.importArtifact(artifact, exists); err != nil {
+		result.Status, result.Message = utils.BulkStatusFailed, err.Error()
+		return result
+	}
+	result.Status = utils.BulkStatusSucceeded
+	return result
+}
+
+// Delete an artifact removed from the tree, unless it has already been removed from the environment
+func pruneArtifact(client applyClient, artifact *ApplyArtifact, dryRun bool) *ApplyResult {
+	result := newApplyResult(artifact)
+	result.Action = ApplyActionDelete
+	exists, err := client.exists(artifact)
+	if err != nil {
+		result.Status, result.Message = utils.BulkStatusFailed, err.Error()
+		return result
+	}
+	if !exists {
+		result.Status, result.Message = utils.BulkStatusSkipped, "not found in the environment"
+		return result
+	}
+	if dryRun {
+		result.Status = ApplyStatusPlanned
+		return result
+	}
+	utils.Logln(utils.LogPrefixInfo + "Deleting " + artifact.Type + " " + artifact.Name)
+	if err = client.deleteArtifact(artifact); err != nil {
+		result.Status, result.Message = utils.BulkStatusFailed, err.Error()
+		return result
+	}
+	result.Status = utils.BulkStatusSucceeded
+	return result
+}
+
+// PrintApplyResults prints the result of every artifact as a table, or in the given structured output format
+func PrintApplyResults(results []*ApplyResult, format string) {
+	if utils.PrintStructuredOutput(results, format) {
+		return
+	}
+	if len(results) == 0 {
+		fmt.Println("No artifacts found to apply")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Type", "Name", "Version", "Path", "Action", "Status", "Message"})
+	for _, result := range results {
+		table.Append([]string{result.Type, result.Name, result.Version, result.Path, result.Action, result.Status,
+			result.Message})
+	}
+	table.Render()
+}
+
+// HasApplyFailures returns true if any of the artifacts could not be applied
+func HasApplyFailures(results []*ApplyResult) bool {
+	for _, result := range results {
+		if result.Status == utils.BulkStatusFailed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// applyLookupLimit is the maximum number of Applications or API policies searched for an existing artifact
+const applyLookupLimit = "1000"
+
+// apimApplyClient applies artifacts to an API Manager environment using the REST APIs of the environment
+type apimApplyClient struct {
+	accessToken string
+	environment string
+}
+
+// Check whether an artifact with the same identity exists in the environment. The list APIs are used instead of
+// the id lookups, as the latter do not distinguish a missing artifact from a failed request.
+func (c *apimApplyClient) exists(artifact *ApplyArtifact) (bool, error) {
+	switch artifact.Type {
+	case utils.ProjectTypeApi:
+		_, apis, err := impl.GetAPIListFromEnv(c.accessToken, c.environment,
+			"name:\""+artifact.Name+"\" version:\""+artifact.Version+"\"", "")
+		if err != nil {
+			return false, err
+		}
+		for _, api := range apis {
+			if api.Name == artifact.Name && api.Version == artifact.Version && matchesOwner(artifact, api.Provider) {
+				return true, nil
+			}
+		}
+	case utils.ProjectTypeApiProduct:
+		_, apiProducts, err := impl.GetAPIProductListFromEnv(c.accessToken, c.environment,
+			"name:\""+artifact.Name+"\" version:\""+artifact.Version+"\"", "")
+		if err != nil {
+			return false, err
+		}
+		for _, apiProduct := range apiProducts {
+			if apiProduct.Name == artifact.Name && apiProduct.Version == artifact.Version &&
+				matchesOwner(artifact, apiProduct.Provider) {
+				return true, nil
+			}
+		}
+	case utils.ProjectTypeApplication:
+		_, apps, err := impl.GetApplicationListFromEnv(c.accessToken, c.environment, artifact.Owner, applyLookupLimit)
+		if err != nil {
+			return false, err
+		}
+		for _, app := range apps {
+			if app.Name == artifact.Name && matchesOwner(artifact, app.Owner) {
+				return true, nil
+			}
+		}
+	case utils.ProjectTypePolicy:
+		resp, err := impl.GetThrottlePolicyListFromEnv(c.accessToken, c.environment,
+			"name:"+artifact.Name+" type:"+getThrottlingPolicyQueryType(artifact.PolicyType))
+		if err != nil {
+			return false, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return false, errors.New(resp.Status())
+		}
+		var policyList utils.ThrottlingPoliciesDetailsList
+		if err = json.Unmarshal(resp.Body(), &policyList); err != nil {
+			return false, err
+		}
+		for _, policy := range policyList.List {
+			if policy.PolicyName == artifact.Name {
+				return true, nil
+			}
+		}
+	case utils.ProjectTypeAPIPolicy:
+		resp, err := impl.GetAPIPolicyListFromEnv(c.accessToken, c.environment, applyLookupLimit)
+		if err != nil {
+			return false, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return false, errors.New(resp.Status())
+		}
+		var policyList utils.APIPoliciesList
+		if err = json.Unmarshal(resp.Body(), &policyList); err != nil {
+			return false, err
+		}
+		for _, policy := range policyList.List {
+			if policy.Name == artifact.Name && policy.Version == artifact.Version {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// An artifact without an owner, ex: an API imported without preserving the provider, matches any owner
+func matchesOwner(artifact *ApplyArtifact, owner string) bool {
+	return artifact.Owner == "" || artifact.Owner == owner
+}
+
+// Import an artifact, the deploy configuration in the *_meta.yaml file of a project is honoured as in "vcs deploy"
+// except for the update flags, which are decided by whether the artifact exists. The messages of the imports are
+// discarded, as the outcome of each artifact is reported in the apply results.
+func (c *apimApplyClient) importArtifact(artifact *ApplyArtifact, update bool) error {
+	var importParams utils.ImportConfig
+	if artifact.MetaData != nil {
+		importParams = artifact.MetaData.DeployConfig.Import
+	}
+	switch artifact.Type {
+	case utils.ProjectTypeApi:
+		return impl.ImportAPIToEnv(ioutil.Discard, c.accessToken, c.environment, artifact.AbsolutePath, "", update,
+			importParams.PreserveProvider, false, importParams.RotateRevision, false, false, "")
+	case utils.ProjectTypeApiProduct:
+		// the APIs of the product are applied separately, hence they are neither imported nor updated here
+		return impl.ImportAPIProductToEnv(ioutil.Discard, c.accessToken, c.environment, artifact.AbsolutePath, "",
+			false, false, update, importParams.PreserveProvider, false, importParams.RotateRevision, false)
+	case utils.ProjectTypeApplication:
+		var appOwner string
+		if artifact.MetaData != nil {
+			appOwner = artifact.MetaData.Owner
+		}
+		_, err := impl.ImportApplicationToEnv(ioutil.Discard, c.accessToken, c.environment, artifact.AbsolutePath,
+			appOwner, update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
+		return err
+	case utils.ProjectTypePolicy:
+		return impl.ImportThrottlingPolicyToEnv(ioutil.Discard, c.accessToken, c.environment, artifact.AbsolutePath,
+			update)
+	case utils.ProjectTypeAPIPolicy:
+		return impl.ImportAPIPolicyToEnv(ioutil.Discard, c.accessToken, c.environment, artifact.AbsolutePath)
+	}
+	return errors.New("Unsupported artifact type " + artifact.Type)
+}
+
+// Delete an artifact which is known to exist in the environment
+func (c *apimApplyClient) deleteArtifact(artifact *ApplyArtifact) error {
+	var err error
+	switch artifact.Type {
+	case utils.ProjectTypeApi:
+		_, err = impl.DeleteAPI(c.accessToken, c.environment, artifact.Name, artifact.Version, artifact.Owner)
+	case utils.ProjectTypeApiProduct:
+		_, err = impl.DeleteAPIProduct(c.accessToken, c.environment, artifact.Name, artifact.Version, artifact.Owner)
+	case utils.ProjectTypeApplication:
+		_, err = impl.DeleteApplication(c.accessToken, c.environment, artifact.Name, artifact.Owner)
+	case utils.ProjectTypePolicy:
+		_, err = impl.DeleteThrottlingPolicy(c.accessToken, artifact.Name, getThrottlingPolicyCmdType(artifact.PolicyType),
+			c.environment)
+	case utils.ProjectTypeAPIPolicy:
+		_, err = impl.DeleteAPIPolicy(c.accessToken, artifact.Name, artifact.Version, c.environment)
+	default:
+		err = errors.New("Unsupported artifact type " + artifact.Type)
+	}
+	return err
+}
+
+// Map the subtype of an exported throttling policy to the policy type used in throttling policy search queries
+func getThrottlingPolicyQueryType(subtype string) string {
+	switch subtype {
+	case impl.ExportPolicyTypeSubscription:
+		return impl.QueryPolicyTypeSubscription
+	case impl.ExportPolicyTypeApplication:
+		return impl.QueryPolicyTypeApplication
+	case impl.ExportPolicyTypeAdvanced:
+		return impl.QueryPolicyTypeAdvanced
+	case impl.ExportPolicyTypeCustom:
+		return impl.QueryCmdPolicyTypeCustom
+	}
+	return ""
+}
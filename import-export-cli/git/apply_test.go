@@ -0,0 +1,234 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// fakeApplyClient records the operations of the apply command instead of calling an environment
+type fakeApplyClient struct {
+	existing map[string]bool
+	failing  map[string]bool
+	imported []string
+	deleted  []string
+}
+
+func (c *fakeApplyClient) exists(artifact *ApplyArtifact) (bool, error) {
+	return c.existing[artifact.Name], nil
+}
+
+func (c *fakeApplyClient) importArtifact(artifact *ApplyArtifact, update bool) error {
+	if c.failing[artifact.Name] {
+		return errors.New("409 Conflict")
+	}
+	c.imported = append(c.imported, artifact.Name)
+	c.existing[artifact.Name] = true
+	return nil
+}
+
+func (c *fakeApplyClient) deleteArtifact(artifact *ApplyArtifact) error {
+	c.deleted = append(c.deleted, artifact.Name)
+	delete(c.existing, artifact.Name)
+	return nil
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+// Create a tree with an Application, an API Product, an API, an API policy and a throttling policy
+func createApplyTestTree(t *testing.T) string {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "apps", "PizzaApp", utils.MetaFileApplication), "owner: admin\n")
+	writeTestFile(t, filepath.Join(dir, "apps", "PizzaApp", "application.yaml"),
+		"type: application\ndata:\n  applicationInfo:\n    name: PizzaApp\n    owner: alice\n")
+	writeTestFile(t, filepath.Join(dir, "products", "ShopProduct", utils.MetaFileAPIProduct), "name: ShopProduct\n")
+	writeTestFile(t, filepath.Join(dir, "products", "ShopProduct", "api_product.yaml"),
+		"type: api_product\ndata:\n  name: ShopProduct\n  version: 1.0.0\n  provider: admin\n")
+	writeTestFile(t, filepath.Join(dir, "apis", "PizzaShackAPI", utils.MetaFileAPI),
+		"name: PizzaShackAPI\ndeploy:\n  import:\n    preserveProvider: true\n")
+	writeTestFile(t, filepath.Join(dir, "apis", "PizzaShackAPI", "api.yaml"),
+		"type: api\ndata:\n  name: PizzaShackAPI\n  version: 1.0.0\n  provider: admin\n")
+	writeTestFile(t, filepath.Join(dir, "apis", "PizzaShackAPI", "Definitions", "swagger.yaml"), "openapi: 3.0.1\n")
+	writeTestFile(t, filepath.Join(dir, "policies", "AddHeader", "AddHeader.yaml"),
+		"type: operation_policy_specification\ndata:\n  name: AddHeader\n  version: v1\n")
+	writeTestFile(t, filepath.Join(dir, "policies", "Gold.yaml"),
+		"type: throttling policy\nsubtype: subscription policy\ndata:\n  policyName: Gold\n")
+	writeTestFile(t, filepath.Join(dir, "README.yaml"), "title: not an artifact\n")
+	writeTestFile(t, filepath.Join(dir, ".git", "Ignored.yaml"),
+		"type: throttling policy\nsubtype: subscription policy\ndata:\n  policyName: Ignored\n")
+	return dir
+}
+
+func TestDiscoverApplyArtifactsInDependencyOrder(t *testing.T) {
+	artifacts, err := DiscoverApplyArtifacts(createApplyTestTree(t))
+	assert.Nil(t, err)
+	assert.Len(t, artifacts, 5)
+
+	var names, types []string
+	for _, artifact := range artifacts {
+		names = append(names, artifact.Name)
+		types = append(types, artifact.Type)
+	}
+	assert.Equal(t, []string{"Gold", "AddHeader", "PizzaShackAPI", "ShopProduct", "PizzaApp"}, names)
+	assert.Equal(t, []string{utils.ProjectTypePolicy, utils.ProjectTypeAPIPolicy, utils.ProjectTypeApi,
+		utils.ProjectTypeApiProduct, utils.ProjectTypeApplication}, types)
+
+	assert.Equal(t, "subscription policy", artifacts[0].PolicyType)
+	assert.Equal(t, "v1", artifacts[1].Version)
+	assert.Equal(t, filepath.Join("apis", "PizzaShackAPI"), artifacts[2].RelativePath)
+	// the provider identifies an API only if it is preserved on import
+	assert.Equal(t, "admin", artifacts[2].Owner)
+	assert.Empty(t, artifacts[3].Owner)
+	// the owner in the meta file takes precedence over the owner in the definition
+	assert.Equal(t, "admin", artifacts[4].Owner)
+}
+
+func TestDiscoverApplyArtifactsRejectsUnknownThrottlingPolicyType(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "Gold.yaml"),
+		"type: throttling policy\nsubtype: unknown policy\ndata:\n  policyName: Gold\n")
+	_, err := DiscoverApplyArtifacts(dir)
+	assert.NotNil(t, err)
+}
+
+func TestGetRemovedApplyArtifactsInReverseOrder(t *testing.T) {
+	previous := []*ApplyArtifact{
+		{Type: utils.ProjectTypePolicy, Name: "Gold", PolicyType: "subscription policy"},
+		{Type: utils.ProjectTypeApi, Name: "PizzaShackAPI", Version: "1.0.0"},
+		{Type: utils.ProjectTypeApi, Name: "OldAPI", Version: "1.0.0"},
+		{Type: utils.ProjectTypeApplication, Name: "OldApp"},
+	}
+	current := []*ApplyArtifact{
+		{Type: utils.ProjectTypeApi, Name: "PizzaShackAPI", Version: "1.0.0"},
+		// a new version of an API is a different artifact, so the old version is removed
+		{Type: utils.ProjectTypeApi, Name: "OldAPI", Version: "2.0.0"},
+	}
+	removed := GetRemovedApplyArtifacts(previous, current)
+	assert.Len(t, removed, 3)
+	assert.Equal(t, "OldApp", removed[0].Name)
+	assert.Equal(t, "OldAPI", removed[1].Name)
+	assert.Equal(t, "1.0.0", removed[1].Version)
+	assert.Equal(t, "Gold", removed[2].Name)
+}
+
+func TestApplyCreatesUpdatesAndPrunes(t *testing.T) {
+	defaultApplyStateDirPath := utils.DefaultApplyStateDirPath
+	utils.DefaultApplyStateDirPath = t.TempDir()
+	defer func() { utils.DefaultApplyStateDirPath = defaultApplyStateDirPath }()
+
+	dir := createApplyTestTree(t)
+	client := &fakeApplyClient{existing: map[string]bool{"PizzaShackAPI": true, "AddHeader": true},
+		failing: map[string]bool{}}
+
+	// a dry run does not change the environment or the state
+	results, err := apply(client, "dev", dir, true, false)
+	assert.Nil(t, err)
+	assert.Len(t, results, 5)
+	assert.Empty(t, client.imported)
+	assert.Equal(t, ApplyActionCreate, results[0].Action)
+	assert.Equal(t, ApplyStatusPlanned, results[0].Status)
+	assert.Equal(t, ApplyActionUnchanged, results[1].Action)
+	assert.Equal(t, ApplyActionUpdate, results[2].Action)
+	statePath, _ := GetApplyStateFilePath(dir, "dev")
+	assert.False(t, utils.IsFileExist(statePath))
+
+	results, err = apply(client, "dev", dir, false, false)
+	assert.Nil(t, err)
+	assert.False(t, HasApplyFailures(results))
+	assert.Equal(t, []string{"Gold", "PizzaShackAPI", "ShopProduct", "PizzaApp"}, client.imported)
+
+	// removed artifacts are kept in the state until the command is run with --prune
+	assert.Nil(t, os.RemoveAll(filepath.Join(dir, "apps")))
+	assert.Nil(t, os.RemoveAll(filepath.Join(dir, "products")))
+	results, err = apply(client, "dev", dir, false, false)
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Empty(t, client.deleted)
+
+	results, err = apply(client, "dev", dir, false, true)
+	assert.Nil(t, err)
+	assert.Len(t, results, 5)
+	assert.Equal(t, []string{"PizzaApp", "ShopProduct"}, client.deleted)
+	assert.Equal(t, ApplyActionDelete, results[3].Action)
+	assert.Equal(t, utils.BulkStatusSucceeded, results[3].Status)
+
+	state, err := LoadApplyState(statePath)
+	assert.Nil(t, err)
+	assert.Len(t, state.Artifacts, 3)
+}
+
+func TestApplyReportsFailures(t *testing.T) {
+	defaultApplyStateDirPath := utils.DefaultApplyStateDirPath
+	utils.DefaultApplyStateDirPath = t.TempDir()
+	defer func() { utils.DefaultApplyStateDirPath = defaultApplyStateDirPath }()
+
+	client := &fakeApplyClient{existing: map[string]bool{}, failing: map[string]bool{"PizzaShackAPI": true}}
+	results, err := apply(client, "dev", createApplyTestTree(t), false, false)
+	assert.Nil(t, err)
+	assert.True(t, HasApplyFailures(results))
+	assert.Equal(t, utils.BulkStatusFailed, results[2].Status)
+	assert.Equal(t, "409 Conflict", results[2].Message)
+	// the remaining artifacts are still applied
+	assert.Equal(t, []string{"Gold", "AddHeader", "ShopProduct", "PizzaApp"}, client.imported)
+}
+
+func TestApplyRecordsOnlyAppliedArtifactsInState(t *testing.T) {
+	defaultApplyStateDirPath := utils.DefaultApplyStateDirPath
+	utils.DefaultApplyStateDirPath = t.TempDir()
+	defer func() { utils.DefaultApplyStateDirPath = defaultApplyStateDirPath }()
+
+	dir := createApplyTestTree(t)
+	statePath, _ := GetApplyStateFilePath(dir, "dev")
+	client := &fakeApplyClient{existing: map[string]bool{}, failing: map[string]bool{"PizzaShackAPI": true}}
+	_, err := apply(client, "dev", dir, false, false)
+	assert.Nil(t, err)
+	state, err := LoadApplyState(statePath)
+	assert.Nil(t, err)
+	assert.Len(t, state.Artifacts, 4)
+	for _, artifact := range state.Artifacts {
+		assert.NotEqual(t, "PizzaShackAPI", artifact.Name)
+	}
+
+	// an artifact applied before stays in the state when it fails to update, so that it can still be pruned
+	client.failing = map[string]bool{}
+	_, err = apply(client, "dev", dir, false, false)
+	assert.Nil(t, err)
+	client.failing = map[string]bool{"PizzaShackAPI": true}
+	_, err = apply(client, "dev", dir, false, false)
+	assert.Nil(t, err)
+	state, err = LoadApplyState(statePath)
+	assert.Nil(t, err)
+	assert.Len(t, state.Artifacts, 5)
+}
+
+func TestMatchesOwner(t *testing.T) {
+	assert.True(t, matchesOwner(&ApplyArtifact{Name: "PizzaShackAPI"}, "admin"))
+	assert.True(t, matchesOwner(&ApplyArtifact{Name: "PizzaShackAPI", Owner: "admin"}, "admin"))
+	assert.False(t, matchesOwner(&ApplyArtifact{Name: "PizzaShackAPI", Owner: "admin"}, "alice"))
+}
@@ -0,0 +1,522 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// apimMaxRevisions is the number of revisions API Manager keeps for an API or an API Product
+const apimMaxRevisions = 5
+
+// Changes planned for a gateway environment of an API or an API Product
+const (
+	// PlanGatewayDeploy deploys the new revision to a gateway the artifact is not deployed to
+	PlanGatewayDeploy = "deploy"
+	// PlanGatewayRedeploy replaces the revision deployed to a gateway with the new revision
+	PlanGatewayRedeploy = "redeploy"
+	// PlanGatewayKeep keeps the revision deployed to a gateway which is not given in the deployment environments
+	PlanGatewayKeep = "keep"
+)
+
+// PlanRepo is the state of a repository a plan is created from
+type PlanRepo struct {
+	Id                    string `json:"id"`
+	Revision              string `json:"revision"`
+	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+}
+
+// PlannedRevision is the revision created when an API or an API Product is deployed to gateways
+type PlannedRevision struct {
+	// Existing is the number of revisions the artifact already has in the environment
+	Existing int `json:"existing"`
+	// Rotated is the revision deleted to make room for the new revision
+	Rotated string `json:"rotated,omitempty"`
+}
+
+// PlannedGateway is the change planned for a gateway environment
+type PlannedGateway struct {
+	Name            string `json:"name"`
+	Change          string `json:"change"`
+	CurrentRevision string `json:"currentRevision,omitempty"`
+}
+
+// PlannedProject is the operation planned for a changed project
+type PlannedProject struct {
+	Type             string           `json:"type"`
+	Name             string           `json:"name"`
+	Path             string           `json:"path"`
+	Operation        string           `json:"operation"`
+	FailedPreviously bool             `json:"failedPreviously,omitempty"`
+	Revision         *PlannedRevision `json:"revision,omitempty"`
+	Gateways         []PlannedGateway `json:"gateways,omitempty"`
+	Warnings         []string         `json:"warnings,omitempty"`
+}
+
+// DeployPlan is the set of operations a VCS deployment performs in an environment. A saved plan can be given to
+// 'vcs deploy' so that only the reviewed operations are performed.
+type DeployPlan struct {
+	Environment    string           `json:"environment"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	SourceRepo     PlanRepo         `json:"sourceRepo"`
+	DeploymentRepo *PlanRepo        `json:"deploymentRepo,omitempty"`
+	Projects       []PlannedProject `json:"projects"`
+}
+
+// CreateDeployPlan resolves the projects changed since the last deployment against the environment and returns the
+// operations deploying them would perform
+// accesstoken is the access token to access the APIM product REST APIs
+// environment is the environment name
+func CreateDeployPlan(accessToken, environment string) (*DeployPlan, error) {
+	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
+	source := getDeploySource(mainConfig, environment)
+	plan := &DeployPlan{
+		Environment:    environment,
+		CreatedAt:      time.Now(),
+		SourceRepo:     source.sourceRepo,
+		DeploymentRepo: source.deploymentRepo,
+		Projects:       []PlannedProject{},
+	}
+	projectTypes := append(append([]string{}, AdminProjectTypes...), utils.ProjectTypeApi, utils.ProjectTypeApiProduct,
+		utils.ProjectTypeApplication)
+	for _, projectType := range projectTypes {
+		for _, projectParam := range source.updatedProjectsPerType[projectType] {
+			utils.Logln(utils.LogPrefixInfo + "Planning " + projectParam.NickName)
+			plannedProject, err := planProject(accessToken, environment, mainConfig, projectParam)
+			if err != nil {
+				return nil, errors.New("Error while planning " + projectParam.NickName + ": " + err.Error())
+			}
+			plan.Projects = append(plan.Projects, *plannedProject)
+		}
+	}
+	return plan, nil
+}
+
+// planProject resolves the operation deploying projectParam would perform in the environment
+func planProject(accessToken, environment string, mainConfig *utils.MainConfig,
+	projectParam *params.ProjectParams) (*PlannedProject, error) {
+	plannedProject := &PlannedProject{
+		Type:             projectParam.Type,
+		Name:             projectParam.NickName,
+		Path:             projectParam.RelativePath,
+		FailedPreviously: projectParam.FailedDuringPreviousDeploy,
+	}
+	if projectParam.Deleted {
+		plannedProject.Operation = DeployOperationDelete
+		return plannedProject, nil
+	}
+	if isAdminProjectType(projectParam.Type) {
+		plannedProject.Operation = resolveAdminDeployOperation(accessToken, environment, projectParam)
+		return plannedProject, nil
+	}
+
+	var deploymentDir string
+	var err error
+	switch projectParam.Type {
+	case utils.ProjectTypeApi:
+		deploymentDir, err = resolveDeploymentProjectDir(mainConfig, projectParam, utils.MetaFileAPI)
+	case utils.ProjectTypeApiProduct:
+		deploymentDir, err = resolveDeploymentProjectDir(mainConfig, projectParam, utils.MetaFileAPIProduct)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := projectArtifactExists(accessToken, environment, projectParam)
+	if err != nil {
+		return nil, err
+	}
+	importParams := projectParam.MetaData.DeployConfig.Import
+	update := importParams.Update
+	if projectParam.Type == utils.ProjectTypeApiProduct {
+		update = importParams.UpdateAPIProduct
+	}
+	if exists {
+		plannedProject.Operation = DeployOperationUpdate
+		if !update {
+			plannedProject.Warnings = append(plannedProject.Warnings, "the "+projectParam.Type+" already exists "+
+				"and updating is not enabled in the meta file, the deployment will fail")
+		}
+	} else {
+		plannedProject.Operation = DeployOperationCreate
+	}
+	if projectParam.Type == utils.ProjectTypeApplication {
+		return plannedProject, nil
+	}
+
+	desiredGateways, err := loadPlannedGateways(generateSourceProjectPath(mainConfig, projectParam), deploymentDir,
+		environment)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []utils.Revisions
+	if exists {
+		revisions, err = getArtifactRevisions(accessToken, environment, projectParam)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plannedProject.Gateways = planGateways(desiredGateways, revisions)
+	if len(desiredGateways) > 0 {
+		// a new revision is created only if the artifact is deployed to gateways
+		plannedProject.Revision = &PlannedRevision{Existing: len(revisions)}
+		if len(revisions) >= apimMaxRevisions {
+			if importParams.RotateRevision {
+				plannedProject.Revision.Rotated = getEarliestRevision(revisions)
+			} else {
+				plannedProject.Warnings = append(plannedProject.Warnings, "the maximum number of revisions ("+
+					strconv.Itoa(apimMaxRevisions)+") is reached and rotating revisions is not enabled in the "+
+					"meta file, the deployment will fail")
+			}
+		}
+	}
+	return plannedProject, nil
+}
+
+// loadPlannedGateways returns the gateway environments an API or an API Product is deployed to. The deployment
+// environments given for the environment in the params file of the deployment directory override the
+// deployment_environments.yaml file of the project.
+func loadPlannedGateways(sourceProjectPath, deploymentDir, environment string) ([]string, error) {
+	if deploymentDir != "" {
+		paramsPath := filepath.Join(deploymentDir, utils.ParamFile)
+		if utils.IsFileExist(paramsPath) {
+			document, err := params.LoadResolvedApiParams(paramsPath)
+			if err != nil {
+				return nil, err
+			}
+			if gateways, ok := getParamsDeploymentEnvironments(document, environment); ok {
+				return gateways, nil
+			}
+		}
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(sourceProjectPath, utils.DeploymentEnvFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var deploymentEnvs struct {
+		Data []map[string]interface{} `yaml:"data"`
+	}
+	if err = yaml.Unmarshal(content, &deploymentEnvs); err != nil {
+		return nil, err
+	}
+	return getDeploymentEnvironmentNames(deploymentEnvs.Data), nil
+}
+
+// getParamsDeploymentEnvironments returns the deployment environments given for the environment in a params
+// document. Returns false if the deployment environments are not given.
+func getParamsDeploymentEnvironments(document map[string]interface{}, environment string) ([]string, bool) {
+	envs, _ := document["environments"].([]interface{})
+	for _, env := range envs {
+		envMap, _ := env.(map[string]interface{})
+		if fmt.Sprint(envMap["name"]) != environment {
+			continue
+		}
+		configs, _ := envMap["configs"].(map[string]interface{})
+		deploymentEnvs, ok := configs["deploymentEnvironments"].([]interface{})
+		if !ok {
+			return nil, false
+		}
+		var entries []map[string]interface{}
+		for _, deploymentEnv := range deploymentEnvs {
+			if entry, ok := deploymentEnv.(map[string]interface{}); ok {
+				entries = append(entries, entry)
+			}
+		}
+		return getDeploymentEnvironmentNames(entries), true
+	}
+	return nil, false
+}
+
+func getDeploymentEnvironmentNames(entries []map[string]interface{}) []string {
+	var names []string
+	for _, entry := range entries {
+		if name, ok := entry["deploymentEnvironment"]; ok && name != nil {
+			names = append(names, fmt.Sprint(name))
+		}
+	}
+	return names
+}
+
+// getArtifactRevisions returns the revisions of the API or the API Product of projectParam in the environment
+func getArtifactRevisions(accessToken, environment string, projectParam *params.ProjectParams) ([]utils.Revisions,
+	error) {
+	artifact, err := getDeployedArtifactIdentity(projectParam)
+	if err != nil {
+		return nil, err
+	}
+	query := "name:\"" + artifact.Name + "\" version:\"" + artifact.Version + "\""
+	if projectParam.Type == utils.ProjectTypeApiProduct {
+		_, apiProducts, err := impl.GetAPIProductListFromEnv(accessToken, environment, query, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, apiProduct := range apiProducts {
+			if apiProduct.Name == artifact.Name && apiProduct.Version == artifact.Version {
+				endpoint := utils.AppendSlashToString(utils.GetApiProductListEndpointOfEnv(environment,
+					utils.MainConfigFilePath))
+				_, revisions, err := impl.GetAPIProductRevisionsList(accessToken, endpoint+apiProduct.ID+"/revisions")
+				return revisions, err
+			}
+		}
+		return nil, nil
+	}
+	_, apis, err := impl.GetAPIListFromEnv(accessToken, environment, query, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, api := range apis {
+		if api.Name == artifact.Name && api.Version == artifact.Version {
+			endpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath))
+			_, revisions, err := impl.GetRevisionsList(accessToken, endpoint+api.ID+"/revisions")
+			return revisions, err
+		}
+	}
+	return nil, nil
+}
+
+// planGateways compares the gateways the new revision is deployed to with the gateways the existing revisions are
+// deployed to. Gateways which are not given keep the revisions deployed to them.
+func planGateways(desiredGateways []string, revisions []utils.Revisions) []PlannedGateway {
+	currentRevisions := make(map[string]string)
+	for _, revision := range revisions {
+		for _, deployment := range revision.Deployments {
+			currentRevisions[deployment.Name] = revision.RevisionNumber
+		}
+	}
+	var gateways []PlannedGateway
+	planned := make(map[string]bool)
+	for _, gateway := range desiredGateways {
+		if planned[gateway] {
+			continue
+		}
+		planned[gateway] = true
+		if currentRevision, ok := currentRevisions[gateway]; ok {
+			gateways = append(gateways, PlannedGateway{Name: gateway, Change: PlanGatewayRedeploy,
+				CurrentRevision: currentRevision})
+		} else {
+			gateways = append(gateways, PlannedGateway{Name: gateway, Change: PlanGatewayDeploy})
+		}
+	}
+	var keptGateways []string
+	for gateway := range currentRevisions {
+		if !planned[gateway] {
+			keptGateways = append(keptGateways, gateway)
+		}
+	}
+	sort.Strings(keptGateways)
+	for _, gateway := range keptGateways {
+		gateways = append(gateways, PlannedGateway{Name: gateway, Change: PlanGatewayKeep,
+			CurrentRevision: currentRevisions[gateway]})
+	}
+	return gateways
+}
+
+// getEarliestRevision returns the revision with the lowest number, which is deleted when the revisions are rotated
+func getEarliestRevision(revisions []utils.Revisions) string {
+	earliest, earliestNumber := "", -1
+	for _, revision := range revisions {
+		fields := strings.Fields(revision.RevisionNumber)
+		if len(fields) == 0 {
+			continue
+		}
+		number, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			continue
+		}
+		if earliestNumber < 0 || number < earliestNumber {
+			earliest, earliestNumber = revision.RevisionNumber, number
+		}
+	}
+	return earliest
+}
+
+// LoadDeployPlan reads a plan saved by 'vcs plan'
+func LoadDeployPlan(path string) (*DeployPlan, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plan := &DeployPlan{}
+	if err = json.Unmarshal(content, plan); err != nil {
+		return nil, errors.New(path + " is not a valid plan: " + err.Error())
+	}
+	return plan, nil
+}
+
+// Write saves the plan as JSON to path
+func (p *DeployPlan) Write(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Summary returns the number of projects to create, update and delete
+func (p *DeployPlan) Summary() (toCreate, toUpdate, toDelete int) {
+	for _, project := range p.Projects {
+		switch project.Operation {
+		case DeployOperationCreate:
+			toCreate++
+		case DeployOperationUpdate:
+			toUpdate++
+		case DeployOperationDelete:
+			toDelete++
+		}
+	}
+	return
+}
+
+// Print writes the plan to w in a human readable form
+func (p *DeployPlan) Print(w io.Writer) {
+	if len(p.Projects) == 0 {
+		fmt.Fprintln(w, "Everything is up-to-date. No changes are planned for "+p.Environment+".")
+		return
+	}
+	fmt.Fprintln(w, "The following operations will be performed in "+p.Environment+":")
+	symbols := map[string]string{DeployOperationCreate: "+", DeployOperationUpdate: "~", DeployOperationDelete: "-"}
+	for _, project := range p.Projects {
+		var failed string
+		if project.FailedPreviously {
+			failed = " [failed previously]"
+		}
+		fmt.Fprintf(w, "\n  %s %-6s %s %s (%s)%s\n", symbols[project.Operation], project.Operation, project.Type,
+			project.Name, project.Path, failed)
+		if project.Revision != nil {
+			revision := "+ new revision (" + strconv.Itoa(project.Revision.Existing) + " existing)"
+			if project.Revision.Rotated != "" {
+				revision += ", deletes " + project.Revision.Rotated
+			}
+			fmt.Fprintln(w, "      "+revision)
+		}
+		for _, gateway := range project.Gateways {
+			switch gateway.Change {
+			case PlanGatewayDeploy:
+				fmt.Fprintln(w, "      + gateway "+gateway.Name)
+			case PlanGatewayRedeploy:
+				fmt.Fprintln(w, "      ~ gateway "+gateway.Name+" (replaces "+gateway.CurrentRevision+")")
+			case PlanGatewayKeep:
+				fmt.Fprintln(w, "        gateway "+gateway.Name+" (keeps "+gateway.CurrentRevision+")")
+			}
+		}
+		for _, warning := range project.Warnings {
+			fmt.Fprintln(w, "      ! "+warning)
+		}
+	}
+	toCreate, toUpdate, toDelete := p.Summary()
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", toCreate, toUpdate, toDelete)
+}
+
+func planProjectKey(projectType, path string) string {
+	return projectType + ":" + path
+}
+
+// verifySource checks whether the repositories and the changed projects are still the same as when the plan was
+// created. Nothing is verified without a plan.
+func (p *DeployPlan) verifySource(environment string, source *deploySource) error {
+	if p == nil {
+		return nil
+	}
+	if p.Environment != environment {
+		return errors.New("the plan is created for the environment " + p.Environment + ", not " + environment)
+	}
+	if err := verifyPlanRepo("source", &p.SourceRepo, &source.sourceRepo); err != nil {
+		return err
+	}
+	if err := verifyPlanRepo("deployment", p.DeploymentRepo, source.deploymentRepo); err != nil {
+		return err
+	}
+
+	plannedProjects := make(map[string]PlannedProject)
+	for _, project := range p.Projects {
+		plannedProjects[planProjectKey(project.Type, project.Path)] = project
+	}
+	var count int
+	for _, projects := range source.updatedProjectsPerType {
+		for _, projectParam := range projects {
+			count++
+			project, ok := plannedProjects[planProjectKey(projectParam.Type, projectParam.RelativePath)]
+			if !ok {
+				return errors.New(projectParam.NickName + " (" + projectParam.RelativePath + ") is not in the plan")
+			}
+			if projectParam.Deleted != (project.Operation == DeployOperationDelete) {
+				return errors.New("the plan does not " + DeployOperationDelete + " " + projectParam.NickName +
+					" (" + projectParam.RelativePath + ") as it is now")
+			}
+		}
+	}
+	if count != len(p.Projects) {
+		return errors.New("the plan has " + strconv.Itoa(len(p.Projects)) + " projects while " +
+			strconv.Itoa(count) + " projects are changed")
+	}
+	return nil
+}
+
+func verifyPlanRepo(name string, planned, current *PlanRepo) error {
+	if planned == nil && current == nil {
+		return nil
+	}
+	if planned == nil || current == nil || planned.Id != current.Id {
+		return errors.New("the " + name + " repository is not the one the plan is created from")
+	}
+	if planned.Revision != current.Revision {
+		return errors.New("the " + name + " repository is at " + current.Revision + " while the plan is created at " +
+			planned.Revision)
+	}
+	if planned.LastAttemptedRevision != current.LastAttemptedRevision {
+		return errors.New("the environment is deployed from the " + name + " repository after the plan is created")
+	}
+	return nil
+}
+
+// verifyOperation checks whether the operation deploying projectParam performs is the planned one. Nothing is
+// verified without a plan.
+func (p *DeployPlan) verifyOperation(projectParam *params.ProjectParams, operation string) error {
+	if p == nil {
+		return nil
+	}
+	for _, project := range p.Projects {
+		if project.Type == projectParam.Type && project.Path == projectParam.RelativePath {
+			if project.Operation != operation {
+				return errors.New("the plan is to " + project.Operation + " " + projectParam.NickName +
+					", but it would " + operation + " as the environment has changed after the plan is created")
+			}
+			return nil
+		}
+	}
+	return errors.New(projectParam.NickName + " (" + projectParam.RelativePath + ") is not in the plan")
+}
@@ -0,0 +1,177 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func newTestDeployPlan() *DeployPlan {
+	return &DeployPlan{
+		Environment: "dev",
+		SourceRepo:  PlanRepo{Id: "source", Revision: "b2", LastAttemptedRevision: "b1"},
+		Projects: []PlannedProject{
+			{Type: utils.ProjectTypeApi, Name: "PizzaAPI-1.0.0", Path: "PizzaAPI", Operation: DeployOperationCreate,
+				Revision: &PlannedRevision{}, Gateways: []PlannedGateway{{Name: "Default", Change: PlanGatewayDeploy}}},
+			{Type: utils.ProjectTypeApi, Name: "PetAPI-1.0.0", Path: "PetAPI", Operation: DeployOperationUpdate,
+				Revision: &PlannedRevision{Existing: 5, Rotated: "Revision 1"},
+				Gateways: []PlannedGateway{
+					{Name: "Default", Change: PlanGatewayRedeploy, CurrentRevision: "Revision 5"},
+					{Name: "Internal", Change: PlanGatewayKeep, CurrentRevision: "Revision 3"}}},
+			{Type: utils.ProjectTypeApplication, Name: "App", Path: "App", Operation: DeployOperationDelete},
+		},
+	}
+}
+
+func newTestDeploySource() *deploySource {
+	return &deploySource{
+		sourceRepo: PlanRepo{Id: "source", Revision: "b2", LastAttemptedRevision: "b1"},
+		updatedProjectsPerType: map[string][]*params.ProjectParams{
+			utils.ProjectTypeApi: {
+				{Type: utils.ProjectTypeApi, NickName: "PizzaAPI-1.0.0", RelativePath: "PizzaAPI"},
+				{Type: utils.ProjectTypeApi, NickName: "PetAPI-1.0.0", RelativePath: "PetAPI"},
+			},
+			utils.ProjectTypeApplication: {
+				{Type: utils.ProjectTypeApplication, NickName: "App", RelativePath: "App", Deleted: true},
+			},
+		},
+	}
+}
+
+func TestDeployPlanWriteAndLoad(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "dev.plan")
+	assert.Nil(t, newTestDeployPlan().Write(planPath))
+
+	plan, err := LoadDeployPlan(planPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "dev", plan.Environment)
+	assert.Len(t, plan.Projects, 3)
+	assert.Equal(t, "Revision 1", plan.Projects[1].Revision.Rotated)
+	toCreate, toUpdate, toDelete := plan.Summary()
+	assert.Equal(t, []int{1, 1, 1}, []int{toCreate, toUpdate, toDelete})
+}
+
+func TestDeployPlanPrint(t *testing.T) {
+	var out bytes.Buffer
+	newTestDeployPlan().Print(&out)
+
+	assert.Contains(t, out.String(), "+ create API PizzaAPI-1.0.0 (PizzaAPI)")
+	assert.Contains(t, out.String(), "+ new revision (5 existing), deletes Revision 1")
+	assert.Contains(t, out.String(), "~ gateway Default (replaces Revision 5)")
+	assert.Contains(t, out.String(), "  gateway Internal (keeps Revision 3)")
+	assert.Contains(t, out.String(), "- delete Application App (App)")
+	assert.Contains(t, out.String(), "Plan: 1 to create, 1 to update, 1 to delete.")
+}
+
+func TestDeployPlanVerifySource(t *testing.T) {
+	plan := newTestDeployPlan()
+	assert.Nil(t, plan.verifySource("dev", newTestDeploySource()))
+	assert.Nil(t, (*DeployPlan)(nil).verifySource("dev", newTestDeploySource()))
+	assert.NotNil(t, plan.verifySource("prod", newTestDeploySource()))
+
+	source := newTestDeploySource()
+	source.sourceRepo.Revision = "b3"
+	assert.NotNil(t, plan.verifySource("dev", source))
+
+	source = newTestDeploySource()
+	source.deploymentRepo = &PlanRepo{Id: "deployment", Revision: "d1"}
+	assert.NotNil(t, plan.verifySource("dev", source))
+
+	source = newTestDeploySource()
+	source.updatedProjectsPerType[utils.ProjectTypeApiProduct] = []*params.ProjectParams{
+		{Type: utils.ProjectTypeApiProduct, NickName: "Product", RelativePath: "Product"}}
+	assert.NotNil(t, plan.verifySource("dev", source))
+
+	source = newTestDeploySource()
+	source.updatedProjectsPerType[utils.ProjectTypeApi] = source.updatedProjectsPerType[utils.ProjectTypeApi][:1]
+	assert.NotNil(t, plan.verifySource("dev", source))
+
+	source = newTestDeploySource()
+	source.updatedProjectsPerType[utils.ProjectTypeApi][0].Deleted = true
+	assert.NotNil(t, plan.verifySource("dev", source))
+}
+
+func TestDeployPlanVerifyOperation(t *testing.T) {
+	plan := newTestDeployPlan()
+	pizzaAPI := &params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PizzaAPI-1.0.0", RelativePath: "PizzaAPI"}
+	assert.Nil(t, plan.verifyOperation(pizzaAPI, DeployOperationCreate))
+	assert.NotNil(t, plan.verifyOperation(pizzaAPI, DeployOperationUpdate))
+	assert.NotNil(t, plan.verifyOperation(&params.ProjectParams{Type: utils.ProjectTypeApi, RelativePath: "Other"},
+		DeployOperationCreate))
+	assert.Nil(t, (*DeployPlan)(nil).verifyOperation(pizzaAPI, DeployOperationUpdate))
+}
+
+func TestPlanGateways(t *testing.T) {
+	revisions := []utils.Revisions{
+		{RevisionNumber: "Revision 3", Deployments: []utils.Deployment{{Name: "Internal"}}},
+		{RevisionNumber: "Revision 5", Deployments: []utils.Deployment{{Name: "Default"}}},
+		{RevisionNumber: "Revision 4"},
+	}
+	gateways := planGateways([]string{"Default", "Sandbox"}, revisions)
+	assert.Equal(t, []PlannedGateway{
+		{Name: "Default", Change: PlanGatewayRedeploy, CurrentRevision: "Revision 5"},
+		{Name: "Sandbox", Change: PlanGatewayDeploy},
+		{Name: "Internal", Change: PlanGatewayKeep, CurrentRevision: "Revision 3"},
+	}, gateways)
+	assert.Equal(t, "Revision 3", getEarliestRevision(revisions))
+	assert.Empty(t, planGateways(nil, nil))
+}
+
+func TestLoadPlannedGateways(t *testing.T) {
+	sourceProjectPath := t.TempDir()
+	writeTestFile(t, filepath.Join(sourceProjectPath, utils.DeploymentEnvFile), `type: deployment_environments
+version: v4.5.0
+data:
+  - displayOnDevportal: true
+    deploymentEnvironment: Default
+  - displayOnDevportal: true
+    deploymentEnvironment: Internal
+`)
+	gateways, err := loadPlannedGateways(sourceProjectPath, "", "dev")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Default", "Internal"}, gateways)
+
+	deploymentDir := t.TempDir()
+	writeTestFile(t, filepath.Join(deploymentDir, utils.ParamFile), `environments:
+  - name: dev
+    configs:
+      deploymentEnvironments:
+        - displayOnDevportal: true
+          deploymentEnvironment: Sandbox
+  - name: prod
+`)
+	gateways, err = loadPlannedGateways(sourceProjectPath, deploymentDir, "dev")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Sandbox"}, gateways)
+
+	// the params of prod do not override the deployment environments
+	gateways, err = loadPlannedGateways(sourceProjectPath, deploymentDir, "prod")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Default", "Internal"}, gateways)
+
+	gateways, err = loadPlannedGateways(t.TempDir(), "", "dev")
+	assert.Nil(t, err)
+	assert.Empty(t, gateways)
+}
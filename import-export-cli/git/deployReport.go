@@ -0,0 +1,237 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Operations performed on a project during a deployment
+const (
+	DeployOperationCreate = "create"
+	DeployOperationUpdate = "update"
+	DeployOperationDelete = "delete"
+)
+
+// Formats of the deployment report
+const (
+	DeployReportFormatJSON  = "json"
+	DeployReportFormatJUnit = "junit"
+)
+
+// DeployOptions controls how the changed projects are deployed
+type DeployOptions struct {
+	// Parallel is the number of projects of the same type deployed at the same time
+	Parallel int
+	// Plan is the reviewed plan the deployment should follow, if any
+	Plan *DeployPlan
+	// Out is where the progress of the deployment is printed, stdout if nil
+	Out io.Writer
+}
+
+// Returns the writer the progress of the deployment is printed to
+func (options DeployOptions) out() io.Writer {
+	if options.Out == nil {
+		return os.Stdout
+	}
+	return options.Out
+}
+
+// DefaultDeployOptions deploys one project at a time
+var DefaultDeployOptions = DeployOptions{Parallel: 1}
+
+// ProjectDeployResult is the outcome of deploying a single project. It is written to the deployment report and
+// persisted per project in the VCS config file.
+type ProjectDeployResult struct {
+	Type           string    `json:"type" yaml:"type"`
+	Name           string    `json:"name" yaml:"name"`
+	Path           string    `json:"path" yaml:"-"`
+	Operation      string    `json:"operation" yaml:"operation"`
+	Status         string    `json:"status" yaml:"status"`
+	Error          string    `json:"error,omitempty" yaml:"error,omitempty"`
+	DurationMillis int64     `json:"durationMillis" yaml:"durationMillis"`
+	Revision       string    `json:"-" yaml:"revision,omitempty"`
+	FinishedAt     time.Time `json:"finishedAt" yaml:"finishedAt"`
+}
+
+// newProjectDeployResult creates the result of deploying projectParam, which started at startedAt and ended with err
+func newProjectDeployResult(projectParam *params.ProjectParams, operation string, startedAt time.Time,
+	err error) ProjectDeployResult {
+	result := ProjectDeployResult{
+		Type:           projectParam.Type,
+		Name:           projectParam.NickName,
+		Path:           projectParam.RelativePath,
+		Operation:      operation,
+		Status:         utils.BulkStatusSucceeded,
+		DurationMillis: time.Since(startedAt).Milliseconds(),
+		FinishedAt:     time.Now(),
+	}
+	if err != nil {
+		result.Status = utils.BulkStatusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// DeployReport is the summary of a VCS deployment
+type DeployReport struct {
+	Environment string                `json:"environment"`
+	Revision    string                `json:"revision,omitempty"`
+	StartedAt   time.Time             `json:"startedAt"`
+	FinishedAt  time.Time             `json:"finishedAt"`
+	Succeeded   int                   `json:"succeeded"`
+	Failed      int                   `json:"failed"`
+	Projects    []ProjectDeployResult `json:"projects"`
+
+	mutex sync.Mutex
+}
+
+// NewDeployReport starts a report for a deployment to the given environment
+func NewDeployReport(environment string) *DeployReport {
+	return &DeployReport{Environment: environment, StartedAt: time.Now(), Projects: []ProjectDeployResult{}}
+}
+
+// Add records the result of a project, safe to be called from several goroutines. Nothing is recorded in a nil
+// report.
+func (r *DeployReport) Add(result ProjectDeployResult) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if result.Status == utils.BulkStatusFailed {
+		r.Failed++
+	} else {
+		r.Succeeded++
+	}
+	r.Projects = append(r.Projects, result)
+}
+
+// Results returns the results of the projects recorded so far
+func (r *DeployReport) Results() []ProjectDeployResult {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]ProjectDeployResult{}, r.Projects...)
+}
+
+// Write finishes the report and saves it to path in the given format (json or junit)
+func (r *DeployReport) Write(path, format string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.FinishedAt = time.Now()
+	var data []byte
+	var err error
+	switch format {
+	case DeployReportFormatJSON, "":
+		data, err = json.MarshalIndent(r, "", "  ")
+	case DeployReportFormatJUnit:
+		data, err = xml.MarshalIndent(r.toJUnit(), "", "  ")
+		data = append([]byte(xml.Header), data...)
+	default:
+		return errors.New("unsupported report format " + format + ", supported formats are " +
+			DeployReportFormatJSON + " and " + DeployReportFormatJUnit)
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// toJUnit converts the report to JUnit XML, with a test suite per project type and a test case per project
+func (r *DeployReport) toJUnit() junitTestSuites {
+	suites := junitTestSuites{
+		Name:     "apictl vcs deploy " + r.Environment,
+		Tests:    len(r.Projects),
+		Failures: r.Failed,
+		Time:     junitSeconds(r.FinishedAt.Sub(r.StartedAt).Milliseconds()),
+	}
+	suiteIndexes := make(map[string]int)
+	suiteMillis := make(map[string]int64)
+	for _, project := range r.Projects {
+		index, ok := suiteIndexes[project.Type]
+		if !ok {
+			index = len(suites.Suites)
+			suiteIndexes[project.Type] = index
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: project.Type})
+		}
+		suite := &suites.Suites[index]
+		testCase := junitTestCase{
+			Name:      project.Name + " (" + project.Operation + ")",
+			ClassName: project.Path,
+			Time:      junitSeconds(project.DurationMillis),
+		}
+		if project.Status == utils.BulkStatusFailed {
+			testCase.Failure = &junitFailure{Message: project.Error, Type: project.Operation, Text: project.Error}
+			suite.Failures++
+		}
+		suite.Tests++
+		suiteMillis[project.Type] += project.DurationMillis
+		suite.Time = junitSeconds(suiteMillis[project.Type])
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	return suites
+}
+
+func junitSeconds(millis int64) string {
+	return fmt.Sprintf("%.3f", float64(millis)/1000)
+}
@@ -0,0 +1,147 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func newTestDeployReport() *DeployReport {
+	report := NewDeployReport("dev")
+	report.Add(newProjectDeployResult(&params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PizzaAPI-1.0.0",
+		RelativePath: "apis/PizzaAPI"}, DeployOperationCreate, time.Now(), nil))
+	report.Add(newProjectDeployResult(&params.ProjectParams{Type: utils.ProjectTypeApi, NickName: "PetAPI-1.0.0",
+		RelativePath: "apis/PetAPI"}, DeployOperationUpdate, time.Now(), errors.New("409 Conflict")))
+	report.Add(newProjectDeployResult(&params.ProjectParams{Type: utils.ProjectTypeApplication, NickName: "App",
+		RelativePath: "apps/App"}, DeployOperationDelete, time.Now(), nil))
+	return report
+}
+
+func TestDeployReportWriteJSON(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	assert.Nil(t, newTestDeployReport().Write(reportPath, DeployReportFormatJSON))
+
+	data, err := ioutil.ReadFile(reportPath)
+	assert.Nil(t, err)
+	var report DeployReport
+	assert.Nil(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "dev", report.Environment)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Projects, 3)
+	assert.Equal(t, "apis/PetAPI", report.Projects[1].Path)
+	assert.Equal(t, DeployOperationUpdate, report.Projects[1].Operation)
+	assert.Equal(t, utils.BulkStatusFailed, report.Projects[1].Status)
+	assert.Equal(t, "409 Conflict", report.Projects[1].Error)
+}
+
+func TestDeployReportWriteJUnit(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.xml")
+	assert.Nil(t, newTestDeployReport().Write(reportPath, DeployReportFormatJUnit))
+
+	data, err := ioutil.ReadFile(reportPath)
+	assert.Nil(t, err)
+	var suites junitTestSuites
+	assert.Nil(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Len(t, suites.Suites, 2)
+	assert.Equal(t, utils.ProjectTypeApi, suites.Suites[0].Name)
+	assert.Equal(t, 2, suites.Suites[0].Tests)
+	assert.Equal(t, 1, suites.Suites[0].Failures)
+	assert.Nil(t, suites.Suites[0].Cases[0].Failure)
+	assert.Equal(t, "PetAPI-1.0.0 (update)", suites.Suites[0].Cases[1].Name)
+	assert.Equal(t, "apis/PetAPI", suites.Suites[0].Cases[1].ClassName)
+	assert.Equal(t, "409 Conflict", suites.Suites[0].Cases[1].Failure.Message)
+	assert.Equal(t, utils.ProjectTypeApplication, suites.Suites[1].Name)
+}
+
+func TestDeployReportWriteUnsupportedFormat(t *testing.T) {
+	err := newTestDeployReport().Write(filepath.Join(t.TempDir(), "report.txt"), "txt")
+	assert.NotNil(t, err)
+}
+
+func TestMergeProjectStatus(t *testing.T) {
+	projectStatus := map[string]*ProjectDeployResult{
+		"apis/PizzaAPI": {Name: "PizzaAPI-1.0.0", Status: utils.BulkStatusFailed, Revision: "abc"},
+		"apis/OldAPI":   {Name: "OldAPI-1.0.0", Status: utils.BulkStatusSucceeded, Revision: "abc"},
+	}
+	merged := mergeProjectStatus(projectStatus, newTestDeployReport().Results(), "def")
+
+	assert.Len(t, merged, 4)
+	assert.Equal(t, utils.BulkStatusSucceeded, merged["apis/PizzaAPI"].Status)
+	assert.Equal(t, "def", merged["apis/PizzaAPI"].Revision)
+	assert.Equal(t, "abc", merged["apis/OldAPI"].Revision)
+	assert.Equal(t, "409 Conflict", merged["apis/PetAPI"].Error)
+	assert.Nil(t, mergeProjectStatus(nil, nil, "def"))
+}
+
+func TestDeployProjectsOfType(t *testing.T) {
+	projects := []*params.ProjectParams{
+		{Type: utils.ProjectTypeApi, NickName: "A"},
+		{Type: utils.ProjectTypeApi, NickName: "B", Deleted: true},
+		{Type: utils.ProjectTypeApi, NickName: "C"},
+		{Type: utils.ProjectTypeApi, NickName: "D"},
+	}
+	deletedProjectsPerType := make(map[string][]*params.ProjectParams)
+	var deployed []string
+	var lock sync.Mutex
+	hasDeleted := deployProjectsOfType(projects, "APIs", DeployOptions{Parallel: 3}, deletedProjectsPerType,
+		func(i int, projectParam *params.ProjectParams) {
+			assert.Equal(t, projects[i], projectParam)
+			lock.Lock()
+			deployed = append(deployed, projectParam.NickName)
+			lock.Unlock()
+		})
+
+	sort.Strings(deployed)
+	assert.True(t, hasDeleted)
+	assert.Equal(t, []string{"A", "C", "D"}, deployed)
+	assert.Len(t, deletedProjectsPerType[utils.ProjectTypeApi], 1)
+	assert.Equal(t, "B", deletedProjectsPerType[utils.ProjectTypeApi][0].NickName)
+	assert.False(t, deployProjectsOfType(nil, "APIs", DefaultDeployOptions, deletedProjectsPerType, nil))
+}
+
+func TestRecordProjectDeploymentInParallel(t *testing.T) {
+	failedProjects := make(map[string][]*params.ProjectParams)
+	report := NewDeployReport("dev")
+	utils.RunInParallel(20, 5, func(i int) {
+		var err error
+		if i%2 == 0 {
+			err = errors.New("failed")
+		}
+		recordProjectDeployment(failedProjects, report, &params.ProjectParams{Type: utils.ProjectTypeApi},
+			DeployOperationCreate, time.Now(), err)
+	})
+	assert.Len(t, failedProjects[utils.ProjectTypeApi], 10)
+	assert.Equal(t, 10, report.Failed)
+	assert.Equal(t, 10, report.Succeeded)
+}
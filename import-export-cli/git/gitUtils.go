@@ -29,6 +29,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
@@ -37,6 +39,12 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// AdminProjectTypes are the types of the exported admin artifacts, which are projects of a single file. They are
+// deployed before the other projects in this order, as the APIs can use the gateway environments, key managers and scopes
+var AdminProjectTypes = []string{utils.ProjectTypeTenantConfig, utils.ProjectTypeTenantTheme,
+	utils.ProjectTypeSystemScopes, utils.ProjectTypeKeyManager, utils.ProjectTypeGatewayEnvironment,
+	utils.ProjectTypeSharedScope}
+
 // Reads the vcs configuration file and returns. Silently catch the error when config file is not found
 // filePath is the path to look for the VCS configuration file
 // returns *VCSConfig VCS configuration
@@ -178,7 +186,8 @@ func failedDuringEarlierDeploy(vcsEnvConfig Environment, projectParams *params.P
 // Rollbacks the projects to the initial state when any of the projects were failed during deployment
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func Rollback(accessToken, environment string) error {
+// options controls how the projects are deployed
+func Rollback(accessToken, environment string, options DeployOptions) error {
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
 	changeDirectoryToSourceRepo(mainConfig)
@@ -243,7 +252,8 @@ func Rollback(accessToken, environment string) error {
 
 	// Again change directory to the source repo and deploy the updated projects
 	changeDirectoryToSourceRepo(mainConfig)
-	deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+	deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType,
+		options, NewDeployReport(environment))
 
 	// Again change directory to the source repo (because inside deployUpdatedProjects the directory must have changed to the deployment)
 	changeDirectoryToSourceRepo(mainConfig)
@@ -308,84 +318,131 @@ func deleteTmpBranch(tmpBranch string) {
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
 // deletedProjectsPerType A map that has keys as Apps/APIs or API Products and values as deleted projects of each type
+// report is the deployment report the outcome of each deletion is recorded in
+// out is where the progress of the deletion is printed
 // This will return the failed projects with the same structure at the end if such projects exist during deletion.
-func deployProjectDeletions(accessToken, environment string, deletedProjectsPerType map[string][]*params.ProjectParams,
-	failedProjects map[string][]*params.ProjectParams) map[string][]*params.ProjectParams {
+func deployProjectDeletions(out io.Writer, accessToken, environment string,
+	deletedProjectsPerType map[string][]*params.ProjectParams, failedProjects map[string][]*params.ProjectParams,
+	report *DeployReport) map[string][]*params.ProjectParams {
 	// Deleting Application projects
 	applicationProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApplication]
 	if len(applicationProjectsToDelete) != 0 {
-		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjectsToDelete)) + ") ...")
+		fmt.Fprintln(out, "\nApplications ("+strconv.Itoa(len(applicationProjectsToDelete))+") ...")
 		for i, projectParam := range applicationProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Data.Applicationinfo.Name,
-				appInfo.Data.Applicationinfo.Owner)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			impl.PrintDeleteAppResponse(resp, err)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			deleteProject(out, failedProjects, report, projectParam, func() error {
+				appInfo, _, err := impl.GetApplicationDefinition(projectParam.AbsolutePath)
+				if err != nil {
+					return err
+				}
+				resp, err := impl.DeleteApplication(accessToken, environment, appInfo.Data.Applicationinfo.Name,
+					appInfo.Data.Applicationinfo.Owner)
+				if err != nil {
+					return err
+				}
+				impl.PrintDeleteAppResponse(out, resp, err)
+				return nil
+			})
 		}
 	}
 
 	// Deleting API Product projects
 	apiProductProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApiProduct]
 	if len(apiProductProjectsToDelete) != 0 {
-		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjectsToDelete)) + ") ...")
+		fmt.Fprintln(out, "\nAPI Products ("+strconv.Itoa(len(apiProductProjectsToDelete))+") ...")
 		for i, projectParam := range apiProductProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.Data.Name, apiProductInfo.Data.Version, apiProductInfo.Data.Provider)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			impl.PrintDeleteAPIProductResponse(resp, err)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			deleteProject(out, failedProjects, report, projectParam, func() error {
+				apiProductInfo, _, err := impl.GetAPIProductDefinition(projectParam.AbsolutePath)
+				if err != nil {
+					return err
+				}
+				resp, err := impl.DeleteAPIProduct(accessToken, environment, apiProductInfo.Data.Name, apiProductInfo.Data.Version, apiProductInfo.Data.Provider)
+				if err != nil {
+					return err
+				}
+				impl.PrintDeleteAPIProductResponse(out, resp, err)
+				return nil
+			})
 		}
 	}
 
 	// Deleting API projects
 	apiProjectsToDelete := deletedProjectsPerType[utils.ProjectTypeApi]
 	if len(apiProjectsToDelete) != 0 {
-		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjectsToDelete)) + ") ...")
+		fmt.Fprintln(out, "\nAPIs ("+strconv.Itoa(len(apiProjectsToDelete))+") ...")
 		for i, projectParam := range apiProjectsToDelete {
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.Data.Name, apiInfo.Data.Version, apiInfo.Data.Provider)
-			if handleIfError(err, failedProjects, projectParam) {
-				continue
-			}
-			impl.PrintDeleteAPIResponse(resp, err)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			deleteProject(out, failedProjects, report, projectParam, func() error {
+				apiInfo, _, err := impl.GetAPIDefinition(projectParam.AbsolutePath)
+				if err != nil {
+					return err
+				}
+				resp, err := impl.DeleteAPI(accessToken, environment, apiInfo.Data.Name, apiInfo.Data.Version, apiInfo.Data.Provider)
+				if err != nil {
+					return err
+				}
+				impl.PrintDeleteAPIResponse(out, resp, err)
+				return nil
+			})
+		}
+	}
+
+	// Deleting admin artifact projects in the reverse order of deploying them, after the APIs which can use them
+	for i := len(AdminProjectTypes) - 1; i >= 0; i-- {
+		adminProjectsToDelete := deletedProjectsPerType[AdminProjectTypes[i]]
+		if len(adminProjectsToDelete) == 0 {
+			continue
+		}
+		fmt.Fprintln(out, "\n"+getAdminProjectTypeName(AdminProjectTypes[i])+
+			" ("+strconv.Itoa(len(adminProjectsToDelete))+") ...")
+		for j, projectParam := range adminProjectsToDelete {
+			fmt.Fprintln(out, strconv.Itoa(j+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			deleteProject(out, failedProjects, report, projectParam, func() error {
+				return impl.DeleteAdminArtifactFromEnv(accessToken, environment, projectParam.AbsolutePath)
+			})
 		}
 	}
 
 	return failedProjects
 }
 
+// Runs the deletion of projectParam and records the outcome in failedProjects and the report
+func deleteProject(out io.Writer, failedProjects map[string][]*params.ProjectParams, report *DeployReport,
+	projectParam *params.ProjectParams, deleteFunc func() error) {
+	startedAt := time.Now()
+	err := deleteFunc()
+	handleIfError(out, err, failedProjects, projectParam)
+	report.Add(newProjectDeployResult(projectParam, DeployOperationDelete, startedAt, err))
+}
+
+// failedProjectsLock guards the failed projects map while the projects are deployed in parallel
+var failedProjectsLock sync.Mutex
+
 // Logs the error and appends the failed project given from projectParam into the failedProjects map.
-func handleIfError(err error, failedProjects map[string][]*params.ProjectParams, projectParam *params.ProjectParams) bool {
+func handleIfError(out io.Writer, err error, failedProjects map[string][]*params.ProjectParams,
+	projectParam *params.ProjectParams) bool {
 	if err != nil {
-		fmt.Println("Error... ", err)
+		fmt.Fprintln(out, "Error... ", err)
+		failedProjectsLock.Lock()
 		failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+		failedProjectsLock.Unlock()
 	}
 	return err != nil
 }
 
 // Deploys the updated projects. It will only handle new or updated projects and deleted projects will be tracked and
 // skipped. Those deleted projects will be returned from the 2nd return argument.
+// Projects of the same type are deployed in parallel as specified in options, while the types are deployed one after
+// the other so that API Products are deployed only after the APIs they depend on.
 // accesstoken is the access token to access the APIM product REST APIs
 // sourceRepoId is the id of the source git repository (located in vcs.yaml)
 // deploymentRepoId is the id of the deployment git repository (located in vcs.yaml)
 // environment is the environment name
 // totalProjectsToUpdate is the number of total projects that needs to be deployed.
 // updatedProjectsPerType is a map of string -> ProjectParams which consists of updated projects per each type (API, App..)
+// options controls how the projects are deployed
+// report is the deployment report the outcome of each project is recorded in
 // Returns bool, true if any deleted projects exists so the process should continue with project deletion path
 // Returns map[string][]*params.ProjectParams, a map of project type (API, App.. ) to each project detail which are
 //
@@ -395,124 +452,287 @@ func handleIfError(err error, failedProjects map[string][]*params.ProjectParams,
 //
 //	failed during the deployment
 func deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment string, totalProjectsToUpdate int,
-	updatedProjectsPerType map[string][]*params.ProjectParams) (bool, map[string][]*params.ProjectParams,
-	map[string][]*params.ProjectParams) {
+	updatedProjectsPerType map[string][]*params.ProjectParams, options DeployOptions,
+	report *DeployReport) (bool, map[string][]*params.ProjectParams, map[string][]*params.ProjectParams) {
+	out := options.out()
 	if totalProjectsToUpdate == 0 {
-		fmt.Println("Everything is up-to-date")
+		fmt.Fprintln(out, "Everything is up-to-date")
 		return false, nil, nil
 	}
 
-	fmt.Println("Deploying Projects (" + strconv.Itoa(totalProjectsToUpdate) + ")...")
+	fmt.Fprintln(out, "Deploying Projects ("+strconv.Itoa(totalProjectsToUpdate)+")...")
 
 	var failedProjects = make(map[string][]*params.ProjectParams)
-	var hasDeletedProjects bool
 	var deletedProjectsPerType = make(map[string][]*params.ProjectParams)
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
 
-	// deploying API projects
-	apiProjects := updatedProjectsPerType[utils.ProjectTypeApi]
-	if len(apiProjects) != 0 {
-		fmt.Println("\nAPIs (" + strconv.Itoa(len(apiProjects)) + ") ...")
-		for i, projectParam := range apiProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
-			}
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
-			if !dirExists {
-				projectDeploymentParamsDirLocation = ""
-			} else {
-				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
-					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPI)
+	// deploying admin artifact projects
+	var hasDeletedAdminProjects bool
+	for _, projectType := range AdminProjectTypes {
+		hasDeleted := deployProjectsOfType(updatedProjectsPerType[projectType], getAdminProjectTypeName(projectType),
+			options, deletedProjectsPerType, func(i int, projectParam *params.ProjectParams) {
+				startedAt := time.Now()
+				operation := resolveAdminDeployOperation(accessToken, environment, projectParam)
+				fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+				if err := options.Plan.verifyOperation(projectParam, operation); err != nil {
+					fmt.Fprintln(out, "\terror... ", err)
+					recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+					return
+				}
+				err := deployAdminProject(accessToken, environment, projectParam)
 				if err != nil {
-					fmt.Println("Error... ", err)
-					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+					fmt.Fprintln(out, "\terror... ", err)
 				}
+				recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+			})
+		hasDeletedAdminProjects = hasDeletedAdminProjects || hasDeleted
+	}
+
+	// deploying API projects
+	hasDeletedApis := deployProjectsOfType(updatedProjectsPerType[utils.ProjectTypeApi], "APIs", options,
+		deletedProjectsPerType, func(i int, projectParam *params.ProjectParams) {
+			startedAt := time.Now()
+			projectDeploymentParamsDirLocation, deployErr := resolveDeploymentProjectDir(mainConfig, projectParam,
+				utils.MetaFileAPI)
+			if deployErr != nil {
+				fmt.Fprintln(out, "Error... ", deployErr)
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			err := impl.ImportAPIToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+			operation := resolveDeployOperation(accessToken, environment, projectParam,
+				importParams.Update || options.Plan != nil)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			if err := options.Plan.verifyOperation(projectParam, operation); err != nil {
+				fmt.Fprintln(out, "Error... ", err)
+				recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+				return
+			}
+			err := impl.ImportAPIToEnv(out, accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
 				projectDeploymentParamsDirLocation, importParams.Update, importParams.PreserveProvider, false, importParams.RotateRevision, false, false, "")
 			if err != nil {
-				fmt.Println("Error... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				fmt.Fprintln(out, "Error... ", err)
+				deployErr = err
 			}
-		}
-	}
+			recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, deployErr)
+		})
 
 	// deploying API product projects
-	apiProductProjects := updatedProjectsPerType[utils.ProjectTypeApiProduct]
-	if len(apiProductProjects) != 0 {
-		fmt.Println("\nAPI Products (" + strconv.Itoa(len(apiProductProjects)) + ") ...")
-		for i, projectParam := range apiProductProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
-			}
-			projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
-			dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
-			if !dirExists {
-				projectDeploymentParamsDirLocation = ""
-			} else {
-				err := resolveProjectParamsMetaDataDeployConfig(&projectParam.MetaData.DeployConfig,
-					projectDeploymentParamsDirLocation+string(os.PathSeparator)+utils.MetaFileAPIProduct)
-				if err != nil {
-					fmt.Println("Error... ", err)
-					failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
-				}
+	hasDeletedApiProducts := deployProjectsOfType(updatedProjectsPerType[utils.ProjectTypeApiProduct], "API Products",
+		options, deletedProjectsPerType, func(i int, projectParam *params.ProjectParams) {
+			startedAt := time.Now()
+			projectDeploymentParamsDirLocation, deployErr := resolveDeploymentProjectDir(mainConfig, projectParam,
+				utils.MetaFileAPIProduct)
+			if deployErr != nil {
+				fmt.Fprintln(out, "Error... ", deployErr)
 			}
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			err := impl.ImportAPIProductToEnv(accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
+			operation := resolveDeployOperation(accessToken, environment, projectParam,
+				importParams.UpdateAPIProduct || options.Plan != nil)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			if err := options.Plan.verifyOperation(projectParam, operation); err != nil {
+				fmt.Fprintln(out, "\terror... ", err)
+				recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+				return
+			}
+			err := impl.ImportAPIProductToEnv(out, accessToken, environment, generateSourceProjectPath(mainConfig, projectParam),
 				projectDeploymentParamsDirLocation, importParams.ImportAPIs, importParams.UpdateAPIs, importParams.UpdateAPIProduct,
 				importParams.PreserveProvider, false, importParams.RotateRevision, false)
 			if err != nil {
-				fmt.Println("\terror... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				fmt.Fprintln(out, "\terror... ", err)
+				deployErr = err
 			}
-		}
-	}
+			recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, deployErr)
+		})
 
 	// deploying Application projects
-	applicationProjects := updatedProjectsPerType[utils.ProjectTypeApplication]
-	if len(applicationProjects) != 0 {
-		fmt.Println("\nApplications (" + strconv.Itoa(len(applicationProjects)) + ") ...")
-		for i, projectParam := range applicationProjects {
-			// if the project is a deleted one, we do it later. So keep it for now.
-			if projectParam.Deleted {
-				handleProjectDeletion(i, projectParam, deletedProjectsPerType)
-				hasDeletedProjects = true
-				continue
-			}
+	hasDeletedApplications := deployProjectsOfType(updatedProjectsPerType[utils.ProjectTypeApplication], "Applications",
+		options, deletedProjectsPerType, func(i int, projectParam *params.ProjectParams) {
+			startedAt := time.Now()
 			importParams := projectParam.MetaData.DeployConfig.Import
-			fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ")")
-			_, err := impl.ImportApplicationToEnv(accessToken, environment, projectParam.AbsolutePath, projectParam.MetaData.Owner,
-				importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions, importParams.SkipKeys, false)
+			operation := resolveDeployOperation(accessToken, environment, projectParam,
+				importParams.Update || options.Plan != nil)
+			fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+")")
+			if err := options.Plan.verifyOperation(projectParam, operation); err != nil {
+				fmt.Fprintln(out, "\terror... ", err)
+				recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+				return
+			}
+			_, err := impl.ImportApplicationToEnv(out, accessToken, environment, projectParam.AbsolutePath,
+				projectParam.MetaData.Owner, importParams.Update, importParams.PreserveOwner, importParams.SkipSubscriptions,
+				importParams.SkipKeys, false)
 			if err != nil {
-				fmt.Println("\terror... ", err)
-				failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+				fmt.Fprintln(out, "\terror... ", err)
 			}
-		}
-	}
+			recordProjectDeployment(failedProjects, report, projectParam, operation, startedAt, err)
+		})
+
+	hasDeletedProjects := hasDeletedAdminProjects || hasDeletedApis || hasDeletedApiProducts || hasDeletedApplications
 
 	// If there are no deleted projects, update the VCS config file as there is nothing remaining to do.
 	//  If there are deleted projects, this needs to handle after deleting those.
 	if !hasDeletedProjects {
-		updateVCSConfig(sourceRepoId, environment, failedProjects)
+		updateVCSConfig(sourceRepoId, environment, failedProjects, report.Results())
 	}
 	if mainConfig.Config.VCSDeploymentRepoPath != "" && deploymentRepoId != "" {
 		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
-		updateVCSConfig(deploymentRepoId, environment, failedProjects)
+		updateVCSConfig(deploymentRepoId, environment, failedProjects, nil)
 	}
 
 	return hasDeletedProjects, deletedProjectsPerType, failedProjects
 }
 
+// Deploys the projects of a single type by calling deploy for each project, at most options.Parallel projects at a
+// time. Deleted projects are not deployed, but appended into deletedProjectsPerType to be deleted later.
+// typeName is the name of the project type printed before the projects
+// Returns true if any of the projects are deleted
+func deployProjectsOfType(projects []*params.ProjectParams, typeName string, options DeployOptions,
+	deletedProjectsPerType map[string][]*params.ProjectParams, deploy func(i int, projectParam *params.ProjectParams)) bool {
+	if len(projects) == 0 {
+		return false
+	}
+	out := options.out()
+	fmt.Fprintln(out, "\n"+typeName+" ("+strconv.Itoa(len(projects))+") ...")
+	var hasDeletedProjects bool
+	var indexesToDeploy []int
+	for i, projectParam := range projects {
+		// if the project is a deleted one, we do it later. So keep it for now.
+		if projectParam.Deleted {
+			handleProjectDeletion(out, i, projectParam, deletedProjectsPerType)
+			hasDeletedProjects = true
+			continue
+		}
+		indexesToDeploy = append(indexesToDeploy, i)
+	}
+	utils.RunInParallel(len(indexesToDeploy), options.Parallel, func(i int) {
+		deploy(indexesToDeploy[i], projects[indexesToDeploy[i]])
+	})
+	return hasDeletedProjects
+}
+
+// Records the outcome of deploying projectParam in failedProjects and the report
+func recordProjectDeployment(failedProjects map[string][]*params.ProjectParams, report *DeployReport,
+	projectParam *params.ProjectParams, operation string, startedAt time.Time, err error) {
+	if err != nil {
+		failedProjectsLock.Lock()
+		failedProjects[projectParam.Type] = append(failedProjects[projectParam.Type], projectParam)
+		failedProjectsLock.Unlock()
+	}
+	report.Add(newProjectDeployResult(projectParam, operation, startedAt, err))
+}
+
+// Finds whether deploying projectParam creates or updates the artifact in the environment. Unless lookup is true,
+// the artifact is assumed to be created, as a project imported without the update flag can only create the artifact.
+// The operation is reported as an update if the environment cannot be checked.
+func resolveDeployOperation(accessToken, environment string, projectParam *params.ProjectParams, lookup bool) string {
+	if !lookup {
+		return DeployOperationCreate
+	}
+	exists, err := projectArtifactExists(accessToken, environment, projectParam)
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Cannot find the deploy operation of "+projectParam.NickName+":", err)
+		return DeployOperationUpdate
+	}
+	if !exists {
+		return DeployOperationCreate
+	}
+	return DeployOperationUpdate
+}
+
+// Resolves the operation deploying an admin artifact project would perform in the environment. The tenant config,
+// tenant theme and system scopes always exist in an environment, hence they are always updated.
+func resolveAdminDeployOperation(accessToken, environment string, projectParam *params.ProjectParams) string {
+	if projectParam.Type == utils.ProjectTypeTenantTheme {
+		return DeployOperationUpdate
+	}
+	artifact, err := impl.LoadAdminArtifactFromFile(projectParam.AbsolutePath, "")
+	if err == nil {
+		var exists bool
+		exists, err = impl.AdminArtifactExistsInEnv(accessToken, environment, artifact)
+		if err == nil && !exists {
+			return DeployOperationCreate
+		}
+	}
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Cannot find the deploy operation of "+projectParam.NickName+":", err)
+	}
+	return DeployOperationUpdate
+}
+
+// Deploys the admin artifact file of projectParam to the environment. Existing artifacts are always updated, as the
+// repository is the source of truth of the environment.
+func deployAdminProject(accessToken, environment string, projectParam *params.ProjectParams) error {
+	if projectParam.Type == utils.ProjectTypeTenantTheme {
+		return impl.ImportTenantThemeToEnv(accessToken, environment, projectParam.AbsolutePath)
+	}
+	artifact, err := impl.LoadAdminArtifactFromFile(projectParam.AbsolutePath, "")
+	if err != nil {
+		return err
+	}
+	return impl.ImportAdminArtifactToEnv(accessToken, environment, artifact, true)
+}
+
+// Returns whether projectType is the type of an exported admin artifact
+func isAdminProjectType(projectType string) bool {
+	for _, adminProjectType := range AdminProjectTypes {
+		if projectType == adminProjectType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the plural name of an admin artifact project type (ex: Key Managers) printed before the projects of the type
+func getAdminProjectTypeName(projectType string) string {
+	if strings.HasSuffix(projectType, "s") {
+		return projectType
+	}
+	return projectType + "s"
+}
+
+// Returns the project type of an exported admin artifact file (ex: a key manager), or ProjectTypeNone if the file at
+// fullPath is not one
+func getAdminProjectType(fullPath string) string {
+	if filepath.Base(fullPath) == impl.TenantThemeFileName {
+		return utils.ProjectTypeTenantTheme
+	}
+	if artifactType := impl.GetAdminArtifactFileType(fullPath); artifactType != "" {
+		return impl.GetAdminArtifactProjectType(artifactType)
+	}
+	return utils.ProjectTypeNone
+}
+
+// Checks whether the artifact of projectParam exists in the environment
+func projectArtifactExists(accessToken, environment string, projectParam *params.ProjectParams) (bool, error) {
+	artifact, err := getDeployedArtifactIdentity(projectParam)
+	if err != nil {
+		return false, err
+	}
+	return (&apimApplyClient{accessToken: accessToken, environment: environment}).exists(artifact)
+}
+
+// Returns the identity of the artifact deployed from projectParam. The name and the version of APIs and API Products
+// are taken from the meta file, as the project can be in the deployment repository without the API definition.
+func getDeployedArtifactIdentity(projectParam *params.ProjectParams) (*ApplyArtifact, error) {
+	if projectParam.MetaData != nil && projectParam.MetaData.Name != "" &&
+		(projectParam.Type == utils.ProjectTypeApi || projectParam.Type == utils.ProjectTypeApiProduct) {
+		return &ApplyArtifact{Type: projectParam.Type, Name: projectParam.MetaData.Name,
+			Version: projectParam.MetaData.Version}, nil
+	}
+	return getProjectArtifact(projectParam)
+}
+
+// Resolves the deployment params directory of an API or API Product project and the deploy configurations given in
+// the meta file of that directory. An empty directory is returned if the project has no deployment directory.
+func resolveDeploymentProjectDir(mainConfig *utils.MainConfig, projectParam *params.ProjectParams,
+	metaFile string) (string, error) {
+	projectDeploymentParamsDirLocation := generateDeploymentProjectPath(mainConfig, projectParam)
+	dirExists, _ := utils.IsDirExists(projectDeploymentParamsDirLocation)
+	if !dirExists {
+		return "", nil
+	}
+	return projectDeploymentParamsDirLocation, resolveProjectParamsMetaDataDeployConfig(
+		&projectParam.MetaData.DeployConfig, projectDeploymentParamsDirLocation+string(os.PathSeparator)+metaFile)
+}
+
 // This method is responsible for resolving the correct meta data deplof configurations
 // for API and API Product projects by considering both the Source and Deployment repositories
 // sourceDeploymentMetaData is the values of the meta data file from the Source repository
@@ -533,7 +753,9 @@ func resolveProjectParamsMetaDataDeployConfig(sourceDeploymentMetaData *utils.De
 // repoId is the id of the git repository (located in vcs.yaml)
 // environment is the environment name
 // failedProjects are a map of project type to failed projects during the previous deployment
-func updateVCSConfig(repoId, environment string, failedProjects map[string][]*params.ProjectParams) {
+// results are the outcomes of the projects deployed, which are stored as the status of each project
+func updateVCSConfig(repoId, environment string, failedProjects map[string][]*params.ProjectParams,
+	results []ProjectDeployResult) {
 	vcsConfig, envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment)
 	var err error
 	envVCSConfig.LastAttemptedRev, err = getLatestCommitId()
@@ -541,6 +763,7 @@ func updateVCSConfig(repoId, environment string, failedProjects map[string][]*pa
 		utils.HandleErrorAndExit("Error while getting latest commit-id", err)
 	}
 	envVCSConfig.FailedProjects = failedProjects
+	envVCSConfig.ProjectStatus = mergeProjectStatus(envVCSConfig.ProjectStatus, results, envVCSConfig.LastAttemptedRev)
 
 	if len(failedProjects) == 0 {
 		if len(envVCSConfig.LastSuccessfulRev) == 0 || len(envVCSConfig.LastSuccessfulRev) > 0 &&
@@ -559,12 +782,31 @@ func updateVCSConfig(repoId, environment string, failedProjects map[string][]*pa
 	utils.WriteConfigFile(vcsConfig, VCSConfigFilePath)
 }
 
+// Merges the outcomes of the projects deployed at the given revision into the status of each project. The status of
+// the projects which were not deployed is kept as it is.
+func mergeProjectStatus(projectStatus map[string]*ProjectDeployResult, results []ProjectDeployResult,
+	revision string) map[string]*ProjectDeployResult {
+	if len(results) == 0 {
+		return projectStatus
+	}
+	if projectStatus == nil {
+		projectStatus = make(map[string]*ProjectDeployResult)
+	}
+	for _, result := range results {
+		status := result
+		status.Revision = revision
+		projectStatus[result.Path] = &status
+	}
+	return projectStatus
+}
+
 // Logs the deletion project info message and appends the project to delete (projectParam) into deletedProjectsPerType map.
 // i is the index of the project
 // projectParam is the project to be deleted
 // deletedProjectsPerType is the map of project type -> projects which are keeping the projects to delete
-func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedProjectsPerType map[string][]*params.ProjectParams) {
-	fmt.Println(strconv.Itoa(i+1) + ": " + projectParam.NickName + ": (" + projectParam.RelativePath + ") awaiting deletion..")
+func handleProjectDeletion(out io.Writer, i int, projectParam *params.ProjectParams,
+	deletedProjectsPerType map[string][]*params.ProjectParams) {
+	fmt.Fprintln(out, strconv.Itoa(i+1)+": "+projectParam.NickName+": ("+projectParam.RelativePath+") awaiting deletion..")
 	if deletedProjectsPerType[projectParam.Type] == nil {
 		deletedProjectsPerType[projectParam.Type] = []*params.ProjectParams{}
 	}
@@ -575,29 +817,34 @@ func handleProjectDeletion(i int, projectParam *params.ProjectParams, deletedPro
 // Deploy all the changes to the specified environment.
 // accesstoken is the access token to access the APIM product REST APIs
 // environment is the environment name
-func DeployChangedFiles(accessToken, environment string) map[string][]*params.ProjectParams {
+// options controls how the projects are deployed
+// report is the deployment report the outcome of each project is recorded in, if not nil
+func DeployChangedFiles(accessToken, environment string, options DeployOptions,
+	report *DeployReport) map[string][]*params.ProjectParams {
+	out := options.out()
 	mainConfig := utils.GetMainConfigFromFile(utils.MainConfigFilePath)
-
-	changeDirectoryToSourceRepo(mainConfig)
-	// Get the status of the source repo
-	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted)
-
-	var deploymentRepoId string
-	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
-	if mainConfig.Config.VCSDeploymentRepoPath != "" {
-		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
-		// Get the status of the deployment repo
-		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted)
+	if report == nil {
+		// the report is still required to store the status of each project in the VCS config
+		report = NewDeployReport(environment)
 	}
 
-	// Get the aggregated status of both the source and the deployment repos
-	totalProjectsToUpdate, updatedProjectsPerType := aggregateSourceAndDeploymentStatusResults(sourceRepoUpdatedProjectsPerType,
-		deploymentRepoUpdatedProjectsPerType)
+	source := getDeploySource(mainConfig, environment)
+	report.Revision = source.sourceRepo.Revision
+	// Make sure the repositories are still in the state the plan was created from
+	if err := options.Plan.verifySource(environment, source); err != nil {
+		utils.HandleErrorAndExit("The plan cannot be applied. Create a new plan using 'vcs plan'", err)
+	}
+	sourceRepoId, deploymentRepoId := source.sourceRepo.Id, ""
+	if source.deploymentRepo != nil {
+		deploymentRepoId = source.deploymentRepo.Id
+	}
+	totalProjectsToUpdate, updatedProjectsPerType := source.totalProjectsToUpdate, source.updatedProjectsPerType
 
 	// Again change directory to the source repo and deploy the updated projects
 	changeDirectoryToSourceRepo(mainConfig)
 	hasDeletedProjects, deletedProjectsPerType, failedProjects :=
-		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType)
+		deployUpdatedProjects(accessToken, sourceRepoId, deploymentRepoId, environment, totalProjectsToUpdate, updatedProjectsPerType,
+			options, report)
 
 	// Deletion will only be considered for source repo
 	if hasDeletedProjects {
@@ -619,18 +866,64 @@ func DeployChangedFiles(accessToken, environment string) map[string][]*params.Pr
 		lastSuccessfulRev := envVCSConfig.LastSuccessfulRev[0]
 		tmpBranchName := "tmp-" + lastSuccessfulRev[0:8]
 
-		fmt.Println("\nDeleting projects ..")
+		fmt.Fprintln(out, "\nDeleting projects ..")
 		checkoutNewBranchFromRevision(tmpBranchName, lastSuccessfulRev)
-		failedProjects = deployProjectDeletions(accessToken, environment, deletedProjectsPerType, failedProjects)
+		failedProjects = deployProjectDeletions(out, accessToken, environment, deletedProjectsPerType, failedProjects, report)
 		checkoutBranch(currentBranch)
 		deleteTmpBranch(tmpBranchName)
 
 		// Update the VCS config with failed projects, last attempted and last successful revisions
-		updateVCSConfig(sourceRepoId, environment, failedProjects)
+		updateVCSConfig(sourceRepoId, environment, failedProjects, report.Results())
 	}
 	return failedProjects
 }
 
+// The state of the source and the deployment repositories and the projects which are changed since the last
+// attempted deployment to an environment
+type deploySource struct {
+	sourceRepo             PlanRepo
+	deploymentRepo         *PlanRepo
+	totalProjectsToUpdate  int
+	updatedProjectsPerType map[string][]*params.ProjectParams
+}
+
+// Collects the projects to deploy from the source and the deployment repositories, by comparing the current revisions
+// with the last attempted revisions.
+// The current directory is changed to the source repo at the end.
+func getDeploySource(mainConfig *utils.MainConfig, environment string) *deploySource {
+	source := &deploySource{}
+	changeDirectoryToSourceRepo(mainConfig)
+	// Get the status of the source repo
+	sourceRepoId, _, sourceRepoUpdatedProjectsPerType := GetStatus(environment, FromRevTypeLastAttempted)
+	source.sourceRepo = getPlanRepo(sourceRepoId, environment)
+
+	var deploymentRepoUpdatedProjectsPerType map[string][]*params.ProjectParams
+	if mainConfig.Config.VCSDeploymentRepoPath != "" {
+		changeDirectory(mainConfig.Config.VCSDeploymentRepoPath)
+		// Get the status of the deployment repo
+		var deploymentRepoId string
+		deploymentRepoId, _, deploymentRepoUpdatedProjectsPerType = GetStatus(environment, FromRevTypeLastAttempted)
+		deploymentRepo := getPlanRepo(deploymentRepoId, environment)
+		source.deploymentRepo = &deploymentRepo
+	}
+
+	// Get the aggregated status of both the source and the deployment repos
+	source.totalProjectsToUpdate, source.updatedProjectsPerType = aggregateSourceAndDeploymentStatusResults(
+		sourceRepoUpdatedProjectsPerType, deploymentRepoUpdatedProjectsPerType)
+	changeDirectoryToSourceRepo(mainConfig)
+	return source
+}
+
+// Returns the current and the last attempted revisions of the repository in the current directory
+func getPlanRepo(repoId, environment string) PlanRepo {
+	revision, err := getLatestCommitId()
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting latest commit-id", err)
+	}
+	_, envVCSConfig, _ := getVCSEnvironmentDetails(repoId, environment)
+	return PlanRepo{Id: repoId, Revision: revision, LastAttemptedRevision: envVCSConfig.LastAttemptedRev}
+}
+
 // Create 'vcs.yaml' in the repository root folder with a unique id (uuid) for the repository.
 //
 //	If the value of force is false, and the file is already created, gives an error.
@@ -761,6 +1054,12 @@ func checkProjectTypeOfSpecificPath(repoBasePath, fullPath string,
 		if strings.HasSuffix(fullPath, utils.MetaFileApplication) {
 			projectParams.Type = utils.ProjectTypeApplication
 		}
+		// a deleted admin artifact file (ex: a key manager) is a project by itself, and its type is found from the
+		// name of the file as the file cannot be read
+		if artifactType := impl.GetDeletableAdminArtifactFileType(fullPath); artifactType != "" {
+			projectParams.Type = impl.GetAdminArtifactProjectType(artifactType)
+			return projectParams
+		}
 		//This means project type is set from any of the above condition.
 		//  Then set the correct basePath of the project.
 		if projectParams.Type != utils.ProjectTypeNone {
@@ -777,6 +1076,12 @@ func checkProjectTypeOfSpecificPath(repoBasePath, fullPath string,
 		// return the projectParams as a deleted project
 		return projectParams
 	}
+	if err != nil {
+		// fullPath is a file, which is a project by itself if it is an exported admin artifact (ex: a key manager)
+		projectParams.Type = getAdminProjectType(fullPath)
+		pathInfoMap[fullPath] = projectParams
+		return projectParams
+	}
 
 	//If the path exists (checked previously), read through the file names of the specific path and check for
 	//  *_meta.yaml to determine the project type
@@ -873,6 +1178,15 @@ func aggregateSourceAndDeploymentStatusResults(sourceRepoUpdatedProjectsPerType,
 	var totalNumberOfProjects = 0
 	finalAggregatedProjectsPerType := make(map[string][]*params.ProjectParams)
 
+	for _, projectType := range AdminProjectTypes {
+		finalAggregatedProjectsPerType[projectType] = []*params.ProjectParams{}
+		var updatedAdminProjects []string // This will be used only for search to know whether a project is already there
+		addProjectsToUniqueList(sourceRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
+			&updatedAdminProjects, projectType, &totalNumberOfProjects)
+		addProjectsToUniqueList(deploymentRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
+			&updatedAdminProjects, projectType, &totalNumberOfProjects)
+	}
+
 	finalAggregatedProjectsPerType[utils.ProjectTypeApi] = []*params.ProjectParams{}
 	var updatedApiProjects []string // This will be used only for search to know whether a project is already there
 	addProjectsToUniqueList(sourceRepoUpdatedProjectsPerType, finalAggregatedProjectsPerType,
@@ -0,0 +1,49 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestCheckProjectTypeOfDeletedAdminArtifact(t *testing.T) {
+	repoBasePath := t.TempDir()
+	fullPath := filepath.Join(repoBasePath, "admin", "KeyManager-Okta.yaml")
+
+	projectParams := checkProjectTypeOfSpecificPath(repoBasePath, fullPath, map[string]*params.ProjectParams{})
+	if !projectParams.Deleted {
+		t.Error("Expected the key manager to be deleted")
+	}
+	if projectParams.Type != utils.ProjectTypeKeyManager {
+		t.Errorf("Incorrect type. Expected '%s', got '%s'\n", utils.ProjectTypeKeyManager, projectParams.Type)
+	}
+	if projectParams.AbsolutePath != fullPath || projectParams.NickName != "KeyManager-Okta.yaml" {
+		t.Errorf("Incorrect paths. Got %+v\n", projectParams)
+	}
+
+	projectParams = checkProjectTypeOfSpecificPath(repoBasePath, filepath.Join(repoBasePath, "admin",
+		"TenantConfig.yaml"), map[string]*params.ProjectParams{})
+	if projectParams.Type != utils.ProjectTypeNone {
+		t.Errorf("Expected no project type for a deleted tenant config, got '%s'\n", projectParams.Type)
+	}
+}
@@ -10,6 +10,8 @@ type Environment struct {
     LastAttemptedRev  string                             `yaml:"lastAttemptedRev"`
     LastSuccessfulRev []string                           `yaml:"lastSuccessfulRev"`
     FailedProjects    map[string][]*params.ProjectParams `yaml:"failedProjects"`
+    // ProjectStatus is the outcome of the last deployment of each project, keyed by the path of the project
+    ProjectStatus     map[string]*ProjectDeployResult    `yaml:"projectStatus,omitempty"`
 }
 
 type Repo struct {
@@ -0,0 +1,121 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Actions reported in an ActionResult
+const (
+	ActionImport       = "import"
+	ActionExport       = "export"
+	ActionDelete       = "delete"
+	ActionChangeStatus = "change-status"
+	ActionCreate       = "create"
+	ActionDeploy       = "deploy"
+	ActionUndeploy     = "undeploy"
+	ActionRestore      = "restore"
+)
+
+// Statuses of an ActionResult
+const (
+	ActionStatusSuccess = "success"
+	ActionStatusFailed  = "failed"
+)
+
+// ActionResult is the result of a command which changes an artifact. It is printed with --output, so scripts can
+// read the outcome of the command instead of parsing its messages
+type ActionResult struct {
+	Type        string `json:"type"`
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Revision    string `json:"revision,omitempty"`
+	State       string `json:"state,omitempty"`
+	Environment string `json:"environment"`
+	Action      string `json:"action"`
+	Status      string `json:"status"`
+	Path        string `json:"path,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// Succeed marks the result as successful
+func (r *ActionResult) Succeed() *ActionResult {
+	r.Status = ActionStatusSuccess
+	return r
+}
+
+// Fail marks the result as failed with the reason
+func (r *ActionResult) Fail(reason string) *ActionResult {
+	r.Status = ActionStatusFailed
+	r.Message = reason
+	return r
+}
+
+// PrintActionResult prints result and returns true if format is a structured output format
+func PrintActionResult(result *ActionResult, format string) bool {
+	return utils.PrintStructuredOutput(result, format)
+}
+
+// NewImportResult returns the result of importing the project or archive at importPath. The name, version and owner
+// are read from the meta file of the project (eg: api_meta.yaml) when it is available
+func NewImportResult(projectType, environment, importPath, metaFile string) *ActionResult {
+	result := &ActionResult{Type: projectType, Environment: environment, Action: ActionImport, Path: importPath}
+	if metaFile == "" {
+		return result
+	}
+	var metaData *utils.MetaData
+	var err error
+	if info, statErr := os.Stat(importPath); statErr == nil && info.IsDir() {
+		metaData, err = LoadMetaInfoFromFile(filepath.Join(importPath, metaFile))
+	} else if strings.HasSuffix(importPath, ".zip") {
+		metaData, err = readMetaDataFromArchive(importPath, metaFile)
+	} else {
+		return result
+	}
+	if err != nil {
+		utils.Logln(utils.LogPrefixWarning+"Could not read "+metaFile+" of "+importPath+":", err)
+		return result
+	}
+	result.Name = metaData.Name
+	result.Version = metaData.Version
+	result.Owner = metaData.Owner
+	return result
+}
+
+// GetLifecycleState returns the lifecycle state of the API or API Product from the response of a change status
+// request. An empty string is returned if the response does not contain it
+func GetLifecycleState(responseBody []byte) string {
+	var workflowResponse struct {
+		LifecycleState struct {
+			State string `json:"state"`
+		} `json:"lifecycleState"`
+	}
+	if err := json.Unmarshal(responseBody, &workflowResponse); err != nil {
+		return ""
+	}
+	return workflowResponse.LifecycleState.State
+}
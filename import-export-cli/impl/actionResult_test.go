@@ -0,0 +1,68 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestNewImportResultReadsMetaFileOfArchive(t *testing.T) {
+	apisDir := t.TempDir()
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0.zip")
+	archive := filepath.Join(apisDir, "PizzaShackAPI_1.0.0.zip")
+
+	result := NewImportResult(utils.ProjectTypeApi, "dev", archive, utils.MetaFileAPI).Succeed()
+	assert.Equal(t, &ActionResult{Type: utils.ProjectTypeApi, Name: "PizzaShackAPI", Version: "1.0.0",
+		Environment: "dev", Action: ActionImport, Status: ActionStatusSuccess, Path: archive}, result)
+}
+
+func TestNewImportResultReadsMetaFileOfDirectory(t *testing.T) {
+	projectDir := t.TempDir()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, utils.MetaFileApplication),
+		[]byte("name: PizzaApp\nowner: admin\n"), 0644))
+
+	result := NewImportResult(utils.ProjectTypeApplication, "dev", projectDir, utils.MetaFileApplication)
+	assert.Equal(t, "PizzaApp", result.Name)
+	assert.Equal(t, "admin", result.Owner)
+	assert.Equal(t, ActionImport, result.Action)
+}
+
+func TestNewImportResultWithoutMetaFile(t *testing.T) {
+	projectDir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(projectDir, "Definitions"), os.ModePerm))
+
+	result := NewImportResult(utils.ProjectTypeApi, "dev", projectDir, utils.MetaFileAPI).Fail("409 Conflict")
+	assert.Equal(t, "", result.Name, "Name should be empty when the project has no meta file")
+	assert.Equal(t, projectDir, result.Path)
+	assert.Equal(t, ActionStatusFailed, result.Status)
+	assert.Equal(t, "409 Conflict", result.Message)
+}
+
+func TestGetLifecycleState(t *testing.T) {
+	assert.Equal(t, "PUBLISHED", GetLifecycleState([]byte(`{"workflowStatus":"APPROVED",
+		"lifecycleState":{"state":"PUBLISHED","availableTransitions":[]}}`)))
+	assert.Equal(t, "", GetLifecycleState([]byte(`{"workflowStatus":"CREATED"}`)))
+	assert.Equal(t, "", GetLifecycleState([]byte("API state changed")))
+}
@@ -103,6 +103,8 @@ func AddEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePath
 		validatedEnvEndpoints.MiManagementEndpoint = envEndpoints.MiManagementEndpoint
 	}
 
+	validatedEnvEndpoints.MiNodeEndpoints = envEndpoints.MiNodeEndpoints
+
 	if envEndpoints.AIServiceEndpoint != "" {
 		validatedEnvEndpoints.AIServiceEndpoint = envEndpoints.AIServiceEndpoint
 	}
@@ -140,6 +142,7 @@ func AddMIEnv(envName string, envEndpoints *utils.EnvEndpoints, mainConfigFilePa
 
 	var validatedEnvEndpoints = utils.EnvEndpoints{
 		MiManagementEndpoint: envEndpoints.MiManagementEndpoint,
+		MiNodeEndpoints:      envEndpoints.MiNodeEndpoints,
 	}
 	if envEndpoints.MiManagementEndpoint != "" {
 		validatedEnvEndpoints.MiManagementEndpoint = envEndpoints.MiManagementEndpoint
@@ -0,0 +1,594 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Types of the admin artifacts, written to the type field of the exported files
+const (
+	AdminArtifactTypeKeyManager         = "key manager"
+	AdminArtifactTypeGatewayEnvironment = "gateway environment"
+	AdminArtifactTypeTenantConfig       = "tenant config"
+	AdminArtifactTypeSystemScopes       = "system scopes"
+	AdminArtifactTypeSharedScope        = "shared scope"
+
+	adminArtifactVersion = "v4"
+
+	// TenantThemeFileName is the name of the archive the tenant theme is exported to
+	TenantThemeFileName = "tenant-theme.zip"
+
+	// sharedScopeLookupLimit is the maximum number of shared scopes searched for a shared scope by its name
+	sharedScopeLookupLimit = "1000"
+)
+
+// namedAdminArtifact describes an admin artifact which is identified by its name within an environment
+type namedAdminArtifact struct {
+	// resource of the artifact collection relative to the REST API
+	resource string
+	// whether the collection belongs to the Publisher REST API instead of the Admin REST API
+	publisher bool
+	// whether the list of the collection contains summaries only, so that an artifact has to be fetched by its id
+	fetchByID bool
+	// fields specific to the environment the artifact is exported from, which are not exported
+	envSpecificFields []string
+}
+
+var namedAdminArtifacts = map[string]namedAdminArtifact{
+	AdminArtifactTypeKeyManager: {resource: "key-managers", fetchByID: true,
+		envSpecificFields: []string{"id"}},
+	AdminArtifactTypeGatewayEnvironment: {resource: "environments",
+		envSpecificFields: []string{"id", "isReadOnly"}},
+	AdminArtifactTypeSharedScope: {resource: "scopes", publisher: true, fetchByID: true,
+		envSpecificFields: []string{"id", "usageCount"}},
+}
+
+// adminArtifactFileNames are the names of the exported files without the extension. The name of the artifact is
+// appended to it for the named artifacts (eg: KeyManager-Okta.yaml)
+var adminArtifactFileNames = map[string]string{
+	AdminArtifactTypeKeyManager:         "KeyManager",
+	AdminArtifactTypeGatewayEnvironment: "GatewayEnvironment",
+	AdminArtifactTypeTenantConfig:       "TenantConfig",
+	AdminArtifactTypeSystemScopes:       "SystemScopes",
+	AdminArtifactTypeSharedScope:        "SharedScope",
+}
+
+var adminArtifactProjectTypes = map[string]string{
+	AdminArtifactTypeKeyManager:         utils.ProjectTypeKeyManager,
+	AdminArtifactTypeGatewayEnvironment: utils.ProjectTypeGatewayEnvironment,
+	AdminArtifactTypeTenantConfig:       utils.ProjectTypeTenantConfig,
+	AdminArtifactTypeSystemScopes:       utils.ProjectTypeSystemScopes,
+	AdminArtifactTypeSharedScope:        utils.ProjectTypeSharedScope,
+}
+
+// keyManagerSecretProperties are the additional properties of a key manager holding its credentials. Their values
+// are not exported, but replaced with references to environment variables, which are substituted on import
+var keyManagerSecretProperties = []string{"client_secret", "consumer_secret", "password"}
+
+// reNonEnvVariableChars matches the characters which cannot be in the name of an environment variable
+var reNonEnvVariableChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// systemScopes is the content of an exported system scopes file, which holds the role mappings of the system scopes
+// together with the role aliases as both are configured in the same place of the Admin Portal
+type systemScopes struct {
+	Scopes      json.RawMessage `json:"scopes"`
+	RoleAliases json.RawMessage `json:"roleAliases"`
+}
+
+// GetAdminArtifactProjectType returns the project type (eg: Key Manager) of the admin artifact type
+func GetAdminArtifactProjectType(artifactType string) string {
+	return adminArtifactProjectTypes[artifactType]
+}
+
+// GetAdminArtifactName returns the name of the key manager, gateway environment or shared scope in artifact. An
+// empty string is returned for the artifacts without a name
+func GetAdminArtifactName(artifact *utils.AdminArtifact) string {
+	if _, ok := namedAdminArtifacts[artifact.Type]; !ok {
+		return ""
+	}
+	for _, item := range artifact.Data {
+		if item.Key == "name" {
+			return fmt.Sprint(item.Value)
+		}
+	}
+	return ""
+}
+
+// ExportAdminArtifactFromEnv exports the admin artifact of artifactType from the environment. name is the name of the
+// key manager, gateway environment or shared scope, and is ignored for the tenant config and system scopes
+func ExportAdminArtifactFromEnv(accessToken, environment, artifactType, name string) (*utils.AdminArtifact, error) {
+	var content []byte
+	var err error
+	adminEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)
+	namedArtifact, named := namedAdminArtifacts[artifactType]
+	switch {
+	case artifactType == AdminArtifactTypeTenantConfig:
+		content, err = invokeAdminArtifactRequest(accessToken, http.MethodGet, adminEndpoint+"/tenant-config", nil)
+	case artifactType == AdminArtifactTypeSystemScopes:
+		content, err = exportSystemScopes(accessToken, adminEndpoint)
+	case named:
+		content, err = namedArtifact.export(accessToken, environment, name)
+	default:
+		return nil, errors.New("unknown admin artifact type " + artifactType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := &utils.AdminArtifact{Type: artifactType, Version: adminArtifactVersion}
+	if err := yaml.Unmarshal(content, &artifact.Data); err != nil {
+		return nil, errors.New("invalid response while exporting the " + artifactType + ": " + err.Error())
+	}
+	if named {
+		artifact.Data = removeAdminArtifactFields(artifact.Data, namedArtifact.envSpecificFields)
+	}
+	if artifactType == AdminArtifactTypeKeyManager {
+		replaceKeyManagerSecrets(artifact.Data, GetAdminArtifactName(artifact))
+	}
+	return artifact, nil
+}
+
+// replaceKeyManagerSecrets replaces the credentials in the additional properties of the key manager named name with
+// ${VAR} references to environment variables (eg: ${KEY_MANAGER_OKTA_CLIENT_SECRET}). Values which are already
+// references are kept as they are
+func replaceKeyManagerSecrets(data yaml.MapSlice, name string) {
+	for _, item := range data {
+		if item.Key != "additionalProperties" {
+			continue
+		}
+		properties, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return
+		}
+		for i, property := range properties {
+			key := fmt.Sprint(property.Key)
+			value, ok := property.Value.(string)
+			if !ok || value == "" || strings.HasPrefix(value, "${") || !isKeyManagerSecretProperty(key) {
+				continue
+			}
+			properties[i].Value = "${" + keyManagerSecretEnvVariable(name, key) + "}"
+		}
+	}
+}
+
+// isKeyManagerSecretProperty returns whether the additional property key of a key manager holds a credential
+func isKeyManagerSecretProperty(key string) bool {
+	for _, secretProperty := range keyManagerSecretProperties {
+		if strings.EqualFold(key, secretProperty) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyManagerSecretEnvVariable returns the environment variable the value of the credential property of the key
+// manager named name is read from on import
+func keyManagerSecretEnvVariable(name, property string) string {
+	return strings.Trim(strings.ToUpper(reNonEnvVariableChars.ReplaceAllString("KEY_MANAGER_"+name+"_"+property,
+		"_")), "_")
+}
+
+// WriteAdminArtifactToFile writes the admin artifact to exportLocationPath in the exportFormat (JSON or YAML) and
+// returns the path of the file
+func WriteAdminArtifactToFile(exportLocationPath string, artifact *utils.AdminArtifact,
+	exportFormat string) (string, error) {
+	if err := utils.CreateDirIfNotExist(exportLocationPath); err != nil {
+		return "", err
+	}
+	fileName := adminArtifactFileNames[artifact.Type]
+	if name := GetAdminArtifactName(artifact); name != "" {
+		fileName += "-" + name
+	}
+	content, err := yaml.Marshal(artifact)
+	if err != nil {
+		return "", err
+	}
+	if exportFormat == utils.DefaultExportFormat {
+		fileName += ".yaml"
+	} else {
+		fileName += ".json"
+		jsonContent, err := utils.YamlToJson(content)
+		if err != nil {
+			return "", err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, jsonContent, "", " "); err != nil {
+			return "", err
+		}
+		content = indented.Bytes()
+	}
+	filePath := filepath.Join(exportLocationPath, fileName)
+	if err := ioutil.WriteFile(filePath, content, os.ModePerm); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// GetAdminArtifactFileType returns the admin artifact type of the file at filePath, or an empty string if the file is
+// not an exported admin artifact
+func GetAdminArtifactFileType(filePath string) string {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	if extension != ".yaml" && extension != ".yml" && extension != ".json" {
+		return ""
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return ""
+	}
+	var header struct {
+		Type string `yaml:"type"`
+	}
+	if err := yaml.Unmarshal(content, &header); err != nil {
+		return ""
+	}
+	if _, ok := adminArtifactFileNames[header.Type]; !ok {
+		return ""
+	}
+	return header.Type
+}
+
+// GetDeletableAdminArtifactFileType returns the type of the key manager, gateway environment or shared scope exported
+// to filePath from the name of the file (eg: KeyManager-Okta.yaml), or an empty string if it is not the name of such
+// a file. The file need not exist, so that the type of a file deleted from a repository can be found
+func GetDeletableAdminArtifactFileType(filePath string) string {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	if extension != ".yaml" && extension != ".yml" && extension != ".json" {
+		return ""
+	}
+	fileName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	for artifactType := range namedAdminArtifacts {
+		prefix := adminArtifactFileNames[artifactType] + "-"
+		if strings.HasPrefix(fileName, prefix) && len(fileName) > len(prefix) {
+			return artifactType
+		}
+	}
+	return ""
+}
+
+// LoadAdminArtifactFromFile reads the admin artifact in the YAML or JSON file at filePath. Environment variables given
+// in ${VAR} format are substituted, so that the values specific to an environment (eg: the client secret of a key
+// manager) need not be stored in the file. An error is returned if artifactType is not empty and the file has an
+// artifact of a different type
+func LoadAdminArtifactFromFile(filePath, artifactType string) (*utils.AdminArtifact, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	substitutedContent, err := utils.EnvSubstituteForCurlyBraces(string(content))
+	if err != nil {
+		return nil, err
+	}
+	artifact := &utils.AdminArtifact{}
+	if err := yaml.Unmarshal([]byte(substitutedContent), artifact); err != nil {
+		return nil, errors.New("invalid file " + filePath + ": " + err.Error())
+	}
+	if _, ok := adminArtifactFileNames[artifact.Type]; !ok {
+		return nil, errors.New(filePath + " is not an exported key manager, gateway environment, tenant config, " +
+			"system scopes or shared scope")
+	}
+	if artifactType != "" && artifact.Type != artifactType {
+		return nil, errors.New(filePath + " has a " + artifact.Type + " instead of a " + artifactType)
+	}
+	return artifact, nil
+}
+
+// ImportAdminArtifactToEnv imports the admin artifact to the environment. A key manager, gateway environment or shared
+// scope with the same name is updated only if update is true, while the tenant config and the system scopes of the
+// environment are always replaced
+func ImportAdminArtifactToEnv(accessToken, environment string, artifact *utils.AdminArtifact, update bool) error {
+	adminEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath)
+	switch artifact.Type {
+	case AdminArtifactTypeTenantConfig:
+		content, err := adminArtifactDataToJson(artifact.Data)
+		if err != nil {
+			return err
+		}
+		_, err = invokeAdminArtifactRequest(accessToken, http.MethodPut, adminEndpoint+"/tenant-config", content)
+		return err
+	case AdminArtifactTypeSystemScopes:
+		return importSystemScopes(accessToken, adminEndpoint, artifact.Data)
+	}
+
+	namedArtifact, ok := namedAdminArtifacts[artifact.Type]
+	if !ok {
+		return errors.New("unknown admin artifact type " + artifact.Type)
+	}
+	name := GetAdminArtifactName(artifact)
+	if name == "" {
+		return errors.New("the name of the " + artifact.Type + " is not specified")
+	}
+	id, _, err := namedArtifact.find(accessToken, environment, name)
+	if err != nil {
+		return err
+	}
+	content, err := adminArtifactDataToJson(artifact.Data)
+	if err != nil {
+		return err
+	}
+	endpoint := namedArtifact.endpoint(environment)
+	if id == "" {
+		utils.Logln(utils.LogPrefixInfo + "Creating the " + artifact.Type + " " + name)
+		_, err = invokeAdminArtifactRequest(accessToken, http.MethodPost, endpoint, content)
+		return err
+	}
+	if !update {
+		return errors.New("the " + artifact.Type + " " + name + " already exists in " + environment +
+			", use --update to update it")
+	}
+	utils.Logln(utils.LogPrefixInfo + "Updating the " + artifact.Type + " " + name)
+	_, err = invokeAdminArtifactRequest(accessToken, http.MethodPut, endpoint+"/"+id, content)
+	return err
+}
+
+// AdminArtifactExistsInEnv returns whether the key manager, gateway environment or shared scope in artifact exists in
+// the environment. The tenant config and the system scopes always exist in an environment
+func AdminArtifactExistsInEnv(accessToken, environment string, artifact *utils.AdminArtifact) (bool, error) {
+	namedArtifact, ok := namedAdminArtifacts[artifact.Type]
+	if !ok {
+		return true, nil
+	}
+	id, _, err := namedArtifact.find(accessToken, environment, GetAdminArtifactName(artifact))
+	return id != "", err
+}
+
+// DeleteAdminArtifactFromEnv deletes the key manager, gateway environment or shared scope exported to the file at
+// filePath from the environment. The environment variables in the file are not substituted as only the name of the
+// artifact is needed. Nothing is deleted if the artifact does not exist in the environment
+func DeleteAdminArtifactFromEnv(accessToken, environment, filePath string) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	artifact := &utils.AdminArtifact{}
+	if err := yaml.Unmarshal(content, artifact); err != nil {
+		return errors.New("invalid file " + filePath + ": " + err.Error())
+	}
+	namedArtifact, ok := namedAdminArtifacts[artifact.Type]
+	if !ok {
+		return errors.New(filePath + " is not an exported key manager, gateway environment or shared scope")
+	}
+	name := GetAdminArtifactName(artifact)
+	if name == "" {
+		return errors.New("the name of the " + artifact.Type + " is not specified")
+	}
+	id, _, err := namedArtifact.find(accessToken, environment, name)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		utils.Logln(utils.LogPrefixInfo + "The " + artifact.Type + " " + name + " does not exist in " + environment)
+		return nil
+	}
+	utils.Logln(utils.LogPrefixInfo + "Deleting the " + artifact.Type + " " + name)
+	_, err = invokeAdminArtifactRequest(accessToken, http.MethodDelete, namedArtifact.endpoint(environment)+"/"+id,
+		nil)
+	return err
+}
+
+// ExportTenantThemeFromEnv writes the tenant theme of the environment to the TenantThemeFileName archive in
+// exportLocationPath and returns the path of the archive
+func ExportTenantThemeFromEnv(accessToken, environment, exportLocationPath string) (string, error) {
+	url := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/tenant-theme"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationZip
+	utils.Logln(utils.LogPrefixInfo+"ExportTenantTheme: URL:", url)
+	resp, err := utils.InvokeGETRequest(url, headers)
+	if err != nil {
+		return "", err
+	}
+	if err := checkAdminArtifactResponse(resp); err != nil {
+		return "", err
+	}
+	if err := utils.CreateDirIfNotExist(exportLocationPath); err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(exportLocationPath, TenantThemeFileName)
+	if err := ioutil.WriteFile(filePath, resp.Body(), os.ModePerm); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// ImportTenantThemeToEnv replaces the tenant theme of the environment with the theme archive at filePath
+func ImportTenantThemeToEnv(accessToken, environment, filePath string) error {
+	if _, err := os.Stat(filePath); err != nil {
+		return err
+	}
+	url := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/tenant-theme"
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	utils.Logln(utils.LogPrefixInfo+"ImportTenantTheme: URL:", url)
+	resp, err := utils.InvokePUTRequestWithFile(url, headers, "file", filePath)
+	if err != nil {
+		return err
+	}
+	return checkAdminArtifactResponse(resp)
+}
+
+// endpoint returns the endpoint of the artifact collection in the environment
+func (a namedAdminArtifact) endpoint(environment string) string {
+	if a.publisher {
+		return utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + a.resource
+	}
+	return utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/" + a.resource
+}
+
+// find returns the id and the list entry of the artifact named name in the environment. An empty id is returned if
+// the artifact does not exist
+func (a namedAdminArtifact) find(accessToken, environment, name string) (string, []byte, error) {
+	queryParamString := ""
+	if a.publisher {
+		queryParamString = getPagingQueryParams(sharedScopeLookupLimit, "").Encode()
+	}
+	body, err := invokeListRequest(accessToken, a.endpoint(environment), queryParamString)
+	if err != nil {
+		return "", nil, err
+	}
+	var list struct {
+		List []json.RawMessage `json:"list"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return "", nil, err
+	}
+	for _, entry := range list.List {
+		var summary struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(entry, &summary); err != nil {
+			return "", nil, err
+		}
+		if summary.Name == name {
+			return summary.ID, entry, nil
+		}
+	}
+	return "", nil, nil
+}
+
+// export returns the content of the artifact named name in the environment
+func (a namedAdminArtifact) export(accessToken, environment, name string) ([]byte, error) {
+	id, entry, err := a.find(accessToken, environment, name)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, errors.New(name + " is not found in " + environment)
+	}
+	if !a.fetchByID {
+		return entry, nil
+	}
+	return invokeAdminArtifactRequest(accessToken, http.MethodGet, a.endpoint(environment)+"/"+id, nil)
+}
+
+// exportSystemScopes returns the role mappings of the system scopes and the role aliases of the environment
+func exportSystemScopes(accessToken, adminEndpoint string) ([]byte, error) {
+	scopes, err := invokeAdminArtifactRequest(accessToken, http.MethodGet, adminEndpoint+"/system-scopes", nil)
+	if err != nil {
+		return nil, err
+	}
+	roleAliases, err := invokeAdminArtifactRequest(accessToken, http.MethodGet,
+		adminEndpoint+"/system-scopes/role-aliases", nil)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(systemScopes{Scopes: scopes, RoleAliases: roleAliases})
+}
+
+// importSystemScopes replaces the role mappings of the system scopes and the role aliases of the environment with the
+// ones in data. Either of them is left unchanged if it is not in data
+func importSystemScopes(accessToken, adminEndpoint string, data yaml.MapSlice) error {
+	resources := map[string]string{"scopes": "/system-scopes", "roleAliases": "/system-scopes/role-aliases"}
+	for _, item := range data {
+		resource, ok := resources[fmt.Sprint(item.Key)]
+		if !ok {
+			continue
+		}
+		content, err := adminArtifactDataToJson(item.Value)
+		if err != nil {
+			return err
+		}
+		if _, err := invokeAdminArtifactRequest(accessToken, http.MethodPut, adminEndpoint+resource,
+			content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invokeAdminArtifactRequest invokes a request with an optional JSON body on an admin artifact endpoint and returns
+// the body of a successful response
+func invokeAdminArtifactRequest(accessToken, method, url string, body []byte) ([]byte, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	headers[utils.HeaderAccept] = utils.HeaderValueApplicationJSON
+	utils.Logln(utils.LogPrefixInfo+method+" URL:", url)
+	var resp *resty.Response
+	var err error
+	switch method {
+	case http.MethodPost:
+		headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+		resp, err = utils.InvokePOSTRequest(url, headers, string(body))
+	case http.MethodPut:
+		headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+		resp, err = utils.InvokePUTRequestWithoutQueryParams(url, headers, string(body))
+	case http.MethodDelete:
+		resp, err = utils.InvokeDELETERequest(url, headers)
+	default:
+		resp, err = utils.InvokeGETRequest(url, headers)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAdminArtifactResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+// checkAdminArtifactResponse returns an error with the status and the body of resp if the request has failed
+func checkAdminArtifactResponse(resp *resty.Response) error {
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if !resp.IsSuccess() {
+		return errors.New(resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
+
+// adminArtifactDataToJson converts the data of an admin artifact to the JSON payload of the REST APIs
+func adminArtifactDataToJson(data interface{}) ([]byte, error) {
+	content, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return utils.YamlToJson(content)
+}
+
+// removeAdminArtifactFields returns data without the top level fields
+func removeAdminArtifactFields(data yaml.MapSlice, fields []string) yaml.MapSlice {
+	result := yaml.MapSlice{}
+	for _, item := range data {
+		removed := false
+		for _, field := range fields {
+			if item.Key == field {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, item)
+		}
+	}
+	return result
+}
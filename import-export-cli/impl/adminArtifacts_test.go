@@ -0,0 +1,154 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+func newTestKeyManagerArtifact(t *testing.T) *utils.AdminArtifact {
+	artifact := &utils.AdminArtifact{Type: AdminArtifactTypeKeyManager, Version: adminArtifactVersion}
+	err := yaml.Unmarshal([]byte(`{"id": "km-1", "name": "Okta", "type": "Okta", "enabled": true,
+		"additionalProperties": {"client_id": "abc", "client_secret": "${OKTA_CLIENT_SECRET}"}}`), &artifact.Data)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	artifact.Data = removeAdminArtifactFields(artifact.Data, namedAdminArtifacts[artifact.Type].envSpecificFields)
+	return artifact
+}
+
+func TestWriteAndLoadAdminArtifact(t *testing.T) {
+	dir := t.TempDir()
+	os.Setenv("OKTA_CLIENT_SECRET", "secret")
+	defer os.Unsetenv("OKTA_CLIENT_SECRET")
+
+	for _, format := range []string{utils.DefaultExportFormat, "JSON"} {
+		filePath, err := WriteAdminArtifactToFile(dir, newTestKeyManagerArtifact(t), format)
+		if err != nil {
+			t.Fatal("Error" + err.Error())
+		}
+		expectedFileName := "KeyManager-Okta.yaml"
+		if format != utils.DefaultExportFormat {
+			expectedFileName = "KeyManager-Okta.json"
+		}
+		if filepath.Base(filePath) != expectedFileName {
+			t.Errorf("Incorrect file name. Expected '%s', got '%s'\n", expectedFileName, filepath.Base(filePath))
+		}
+		if fileType := GetAdminArtifactFileType(filePath); fileType != AdminArtifactTypeKeyManager {
+			t.Errorf("Incorrect file type. Expected '%s', got '%s'\n", AdminArtifactTypeKeyManager, fileType)
+		}
+
+		artifact, err := LoadAdminArtifactFromFile(filePath, AdminArtifactTypeKeyManager)
+		if err != nil {
+			t.Fatal("Error" + err.Error())
+		}
+		if name := GetAdminArtifactName(artifact); name != "Okta" {
+			t.Errorf("Incorrect name. Expected '%s', got '%s'\n", "Okta", name)
+		}
+		content, err := adminArtifactDataToJson(artifact.Data)
+		if err != nil {
+			t.Fatal("Error" + err.Error())
+		}
+		expected := `{"additionalProperties":{"client_id":"abc","client_secret":"secret"},"enabled":true,` +
+			`"name":"Okta","type":"Okta"}`
+		if string(content) != expected {
+			t.Errorf("Incorrect payload. Expected %s, got %s\n", expected, string(content))
+		}
+	}
+}
+
+func TestLoadAdminArtifactWithMissingEnvVariable(t *testing.T) {
+	filePath, err := WriteAdminArtifactToFile(t.TempDir(), newTestKeyManagerArtifact(t), utils.DefaultExportFormat)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	os.Unsetenv("OKTA_CLIENT_SECRET")
+	if _, err := LoadAdminArtifactFromFile(filePath, ""); err == nil {
+		t.Error("Expected an error for the missing environment variable")
+	}
+}
+
+func TestLoadAdminArtifactOfDifferentType(t *testing.T) {
+	os.Setenv("OKTA_CLIENT_SECRET", "secret")
+	defer os.Unsetenv("OKTA_CLIENT_SECRET")
+	filePath, err := WriteAdminArtifactToFile(t.TempDir(), newTestKeyManagerArtifact(t), utils.DefaultExportFormat)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if _, err := LoadAdminArtifactFromFile(filePath, AdminArtifactTypeGatewayEnvironment); err == nil {
+		t.Error("Expected an error for importing a key manager as a gateway environment")
+	}
+}
+
+func TestGetAdminArtifactFileTypeOfOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "Subscription-Gold.yaml")
+	if err := os.WriteFile(policyFile, []byte("type: throttling policy\nsubtype: subscription policy\n"),
+		os.ModePerm); err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if fileType := GetAdminArtifactFileType(policyFile); fileType != "" {
+		t.Errorf("Expected no admin artifact type for a throttling policy, got '%s'\n", fileType)
+	}
+	if fileType := GetAdminArtifactFileType(filepath.Join(dir, "README.md")); fileType != "" {
+		t.Errorf("Expected no admin artifact type for a markdown file, got '%s'\n", fileType)
+	}
+}
+
+func TestReplaceKeyManagerSecrets(t *testing.T) {
+	artifact := &utils.AdminArtifact{Type: AdminArtifactTypeKeyManager, Version: adminArtifactVersion}
+	err := yaml.Unmarshal([]byte(`{"name": "Okta Prod", "type": "Okta", "additionalProperties": {"client_id": "abc",
+		"client_secret": "s3cr3t", "Password": "admin", "consumer_secret": "${OKTA_CONSUMER_SECRET}"}}`), &artifact.Data)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	replaceKeyManagerSecrets(artifact.Data, GetAdminArtifactName(artifact))
+
+	content, err := adminArtifactDataToJson(artifact.Data)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	expected := `{"additionalProperties":{"Password":"${KEY_MANAGER_OKTA_PROD_PASSWORD}","client_id":"abc",` +
+		`"client_secret":"${KEY_MANAGER_OKTA_PROD_CLIENT_SECRET}","consumer_secret":"${OKTA_CONSUMER_SECRET}"},` +
+		`"name":"Okta Prod","type":"Okta"}`
+	if string(content) != expected {
+		t.Errorf("Incorrect payload. Expected %s, got %s\n", expected, string(content))
+	}
+}
+
+func TestGetDeletableAdminArtifactFileType(t *testing.T) {
+	fileTypes := map[string]string{
+		"KeyManager-Okta.yaml":            AdminArtifactTypeKeyManager,
+		"GatewayEnvironment-Default.json": AdminArtifactTypeGatewayEnvironment,
+		"SharedScope-read.yml":            AdminArtifactTypeSharedScope,
+		"TenantConfig.yaml":               "",
+		"KeyManager-.yaml":                "",
+		"KeyManager-Okta.md":              "",
+	}
+	for fileName, expected := range fileTypes {
+		if fileType := GetDeletableAdminArtifactFileType(filepath.Join("admin", fileName)); fileType != expected {
+			t.Errorf("Incorrect type of %s. Expected '%s', got '%s'\n", fileName, expected, fileType)
+		}
+	}
+}
@@ -35,11 +35,21 @@ func AddAPIProductsToQueue(accessToken string, apiListQueue chan<- []map[string]
 			apiListQueue <- apiList
 		}
 		apiListOffset += utils.MaxAPIsToExportOnce
-		count, apiProducts, _ = GetAPIProductListFromEnv(accessToken, CmdUploadEnvironment, "", strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(apiListOffset))
+		count, apiProducts = getAPIProductListToUpload(accessToken)
 		startingApiIndexFromList = 0
 	}
 }
 
+// getAPIProductListToUpload returns the next page of API Products of the upload environment
+func getAPIProductListToUpload(accessToken string) (int32, []utils.APIProduct) {
+	count, products, err := GetAPIProductListFromEnv(accessToken, CmdUploadEnvironment, "",
+		strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(apiListOffset))
+	if err != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"Getting List of API Products.", err)
+	}
+	return count, products
+}
+
 func GetAPIPayload(apiOrProduct interface{}, accessToken, cmdUploadEnvironment string, uploadProducts bool) map[string]interface{} {
 	var name string
 	var resp *resty.Response
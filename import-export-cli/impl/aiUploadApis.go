@@ -3,7 +3,6 @@ package impl
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -77,16 +76,18 @@ func ProduceAPIPayloads(accessToken string, apiListQueue chan<- []map[string]int
 	startingApiIndexFromList = 0
 	if UploadAll {
 		count, apis = getAPIList(Credential, CmdUploadEnvironment, "")
-		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false)
+		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false,
+			utils.DefaultBulkOperationOptions)
 		apiListOffset = 0
-		count, apiProducts, _ = GetAPIProductListFromEnv(accessToken, CmdUploadEnvironment, "", strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(apiListOffset))
+		count, apiProducts = getAPIProductListToUpload(accessToken)
 		AddAPIProductsToQueue(accessToken, apiListQueue)
 	} else if UploadProducts {
-		count, apiProducts, _ = GetAPIProductListFromEnv(accessToken, CmdUploadEnvironment, "", strconv.Itoa(utils.MaxAPIsToExportOnce)+"&offset="+strconv.Itoa(apiListOffset))
+		count, apiProducts = getAPIProductListToUpload(accessToken)
 		AddAPIProductsToQueue(accessToken, apiListQueue)
 	} else {
 		count, apis = getAPIList(Credential, CmdUploadEnvironment, "")
-		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false)
+		ExportAPIs(Credential, "", CmdUploadEnvironment, Tenant, "json", "", "", true, true, false, true, false,
+			utils.DefaultBulkOperationOptions)
 	}
 	close(apiListQueue)
 }
@@ -143,7 +143,7 @@ func GetAPIProductList(accessToken, unifiedSearchEndpoint, query, limit string)
 	resp, err := utils.InvokeGETRequestWithQueryParamsString(unifiedSearchEndpoint, queryParamString, headers)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+unifiedSearchEndpoint, err)
+		return 0, nil, errors.New("Unable to connect to " + unifiedSearchEndpoint + ": " + err.Error())
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -153,7 +153,7 @@ func GetAPIProductList(accessToken, unifiedSearchEndpoint, query, limit string)
 		unmarshalError := json.Unmarshal([]byte(resp.Body()), &apiProductListResponse)
 
 		if unmarshalError != nil {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", unmarshalError)
+			return 0, nil, errors.New("invalid JSON response: " + unmarshalError.Error())
 		}
 		return apiProductListResponse.Count, apiProductListResponse.List, nil
 	} else {
@@ -177,7 +177,7 @@ func GetAPIProductRevisionsList(accessToken, revisionListEndpoint string) (count
 	resp, err := utils.InvokeGETRequest(revisionListEndpoint, headers)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+revisionListEndpoint, err)
+		return 0, nil, errors.New("Unable to connect to " + revisionListEndpoint + ": " + err.Error())
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -187,7 +187,7 @@ func GetAPIProductRevisionsList(accessToken, revisionListEndpoint string) (count
 		unmarshalError := json.Unmarshal([]byte(resp.Body()), &revisionListResponse)
 
 		if unmarshalError != nil {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", unmarshalError)
+			return 0, nil, errors.New("invalid JSON response: " + unmarshalError.Error())
 		}
 		return revisionListResponse.Count, revisionListResponse.List, nil
 	} else {
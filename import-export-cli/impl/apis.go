@@ -135,7 +135,7 @@ func GetAPIList(accessToken, apiListEndpoint, query, limit string) (count int32,
 	resp, err := utils.InvokeGETRequestWithQueryParamsString(apiListEndpoint, queryParamSring, headers)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+apiListEndpoint, err)
+		return 0, nil, errors.New("Unable to connect to " + apiListEndpoint + ": " + err.Error())
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -145,7 +145,7 @@ func GetAPIList(accessToken, apiListEndpoint, query, limit string) (count int32,
 		unmarshalError := json.Unmarshal([]byte(resp.Body()), &apiListResponse)
 
 		if unmarshalError != nil {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", unmarshalError)
+			return 0, nil, errors.New("invalid JSON response: " + unmarshalError.Error())
 		}
 
 		return apiListResponse.Count, apiListResponse.List, nil
@@ -169,7 +169,7 @@ func GetRevisionsList(accessToken, revisionListEndpoint string) (count int32, re
 	resp, err := utils.InvokeGETRequest(revisionListEndpoint, headers)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+revisionListEndpoint, err)
+		return 0, nil, errors.New("Unable to connect to " + revisionListEndpoint + ": " + err.Error())
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -179,10 +179,34 @@ func GetRevisionsList(accessToken, revisionListEndpoint string) (count int32, re
 		unmarshalError := json.Unmarshal([]byte(resp.Body()), &revisionListResponse)
 
 		if unmarshalError != nil {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", unmarshalError)
+			return 0, nil, errors.New("invalid JSON response: " + unmarshalError.Error())
 		}
 		return revisionListResponse.Count, revisionListResponse.List, nil
 	} else {
 		return 0, nil, errors.New(string(resp.Body()))
 	}
 }
+
+// GetAPISwaggerFromEnv returns the OpenAPI definition of an API
+// @param accessToken : Access Token for the resource
+// @param environment : Environment where the API resides
+// @param apiId : API ID
+// @return the definition of the API
+// @return error
+func GetAPISwaggerFromEnv(accessToken, environment, apiId string) (string, error) {
+	swaggerEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(environment,
+		utils.MainConfigFilePath)) + apiId + "/swagger"
+	utils.Logln(utils.LogPrefixInfo+"GetAPISwagger: URL:", swaggerEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	resp, err := utils.InvokeGETRequest(swaggerEndpoint, headers)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	return string(resp.Body()), nil
+}
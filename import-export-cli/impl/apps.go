@@ -123,7 +123,7 @@ func GetApplicationList(accessToken, applicationListEndpoint, appOwner, limit st
 		resp, err = utils.InvokeGETRequestWithQueryParam("user", appOwner, applicationListEndpoint, headers)
 	}
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+applicationListEndpoint, err)
+		return 0, nil, errors.New("Unable to connect to " + applicationListEndpoint + ": " + err.Error())
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -133,7 +133,7 @@ func GetApplicationList(accessToken, applicationListEndpoint, appOwner, limit st
 		unmarshalError := json.Unmarshal([]byte(resp.Body()), &appListResponse)
 
 		if unmarshalError != nil {
-			utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", unmarshalError)
+			return 0, nil, errors.New("invalid JSON response: " + unmarshalError.Error())
 		}
 
 		return appListResponse.Count, appListResponse.List, nil
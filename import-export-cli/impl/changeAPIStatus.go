@@ -19,6 +19,8 @@
 package impl
 
 import (
+	"errors"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -42,7 +44,7 @@ func changeAPIStatus(changeAPIStatusEndpoint, stateChangeAction, name, version,
 	changeAPIStatusEndpoint = utils.AppendSlashToString(changeAPIStatusEndpoint)
 	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while getting API Id for state change ", err)
+		return nil, errors.New("Error while getting API Id for state change: " + err.Error())
 	}
 	url := changeAPIStatusEndpoint + "change-lifecycle"
 	utils.Logln(utils.LogPrefixInfo+"APIStateChange: URL:", url)
@@ -0,0 +1,189 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const deferredSubscriptionsFileName = "deferred.json"
+const deferredApplicationDirName = "application"
+
+// DeferredSubscriptions are the subscriptions of an imported Application which were not created, as their APIs
+// were not found in the environment. The prepared Application is kept along with them, so that it can be imported
+// again to create the subscriptions once the APIs exist.
+type DeferredSubscriptions struct {
+	Application   string                        `json:"application"`
+	Owner         string                        `json:"owner"`
+	Environment   string                        `json:"environment"`
+	AppOwner      string                        `json:"appOwner,omitempty"`
+	PreserveOwner bool                          `json:"preserveOwner"`
+	DeferredAt    time.Time                     `json:"deferredAt"`
+	Subscriptions []ApplicationSubscriptionPlan `json:"subscriptions"`
+
+	dir string
+}
+
+// DeferredSubscriptionResult is the outcome of creating a deferred subscription
+type DeferredSubscriptionResult struct {
+	Application string `json:"application"`
+	Owner       string `json:"owner"`
+	API         string `json:"api"`
+	Status      string `json:"status"`
+	Message     string `json:"message,omitempty"`
+}
+
+// SaveDeferredSubscriptions keeps the missing subscriptions of the report and the prepared Application in
+// projectPath, to be created by ResumeDeferredSubscriptions. Nothing is saved if no subscription is missing.
+// @param appOwner: Owner of the Application given when importing it
+// @param preserveOwner: Whether the owner of the Application was preserved when importing it
+// @return the directory the deferred subscriptions are saved in
+func SaveDeferredSubscriptions(projectPath string, report *ApplicationImportReport, appOwner string,
+	preserveOwner bool) (string, error) {
+	missing := report.Missing()
+	if len(missing) == 0 {
+		return "", nil
+	}
+	dir := filepath.Join(utils.DefaultDeferredSubscriptionsDirPath, report.Environment,
+		report.Owner+"_"+report.Application)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := utils.CreateDirIfNotExist(dir); err != nil {
+		return "", err
+	}
+	if err := utils.CopyDir(projectPath, filepath.Join(dir, deferredApplicationDirName)); err != nil {
+		return "", err
+	}
+	deferred := &DeferredSubscriptions{
+		Application:   report.Application,
+		Owner:         report.Owner,
+		Environment:   report.Environment,
+		AppOwner:      appOwner,
+		PreserveOwner: preserveOwner,
+		DeferredAt:    time.Now(),
+		Subscriptions: missing,
+		dir:           dir,
+	}
+	return dir, deferred.write()
+}
+
+// GetDeferredSubscriptionsOfEnv returns the deferred subscriptions of the Applications imported to the environment
+func GetDeferredSubscriptionsOfEnv(environment string) ([]*DeferredSubscriptions, error) {
+	envDir := filepath.Join(utils.DefaultDeferredSubscriptionsDirPath, environment)
+	entries, err := ioutil.ReadDir(envDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deferredList []*DeferredSubscriptions
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(envDir, entry.Name())
+		data, err := ioutil.ReadFile(filepath.Join(dir, deferredSubscriptionsFileName))
+		if err != nil {
+			return nil, err
+		}
+		deferred := &DeferredSubscriptions{dir: dir}
+		if err := json.Unmarshal(data, deferred); err != nil {
+			return nil, err
+		}
+		deferredList = append(deferredList, deferred)
+	}
+	return deferredList, nil
+}
+
+// ResumeDeferredSubscriptions creates the deferred subscriptions whose APIs are found in the environment now, by
+// importing the kept Application again with its subscriptions. The subscriptions whose APIs are still missing stay
+// deferred, and the deferred subscriptions are removed when none is left.
+func ResumeDeferredSubscriptions(accessToken string, deferred *DeferredSubscriptions) ([]DeferredSubscriptionResult,
+	error) {
+	return resumeDeferredSubscriptions(deferred, func(api APIIdentifier, apiType string) (bool, error) {
+		return subscribedAPIExistsInEnv(accessToken, deferred.Environment, api, apiType)
+	}, func(applicationPath string) error {
+		_, err := ImportApplicationToEnv(ioutil.Discard, accessToken, deferred.Environment, applicationPath,
+			deferred.AppOwner, true, deferred.PreserveOwner, false, true, false)
+		return err
+	})
+}
+
+func resumeDeferredSubscriptions(deferred *DeferredSubscriptions,
+	apiExists func(api APIIdentifier, apiType string) (bool, error),
+	importApplication func(applicationPath string) error) ([]DeferredSubscriptionResult, error) {
+	var results []DeferredSubscriptionResult
+	var available, stillMissing []ApplicationSubscriptionPlan
+	for _, subscription := range deferred.Subscriptions {
+		exists, err := apiExists(subscription.TargetAPI, subscription.Type)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			available = append(available, subscription)
+		} else {
+			stillMissing = append(stillMissing, subscription)
+			results = append(results, deferred.newResult(subscription, utils.BulkStatusSkipped,
+				subscription.Type+" "+subscription.TargetAPI.String()+" is not found"))
+		}
+	}
+	if len(available) == 0 {
+		return results, nil
+	}
+
+	err := importApplication(filepath.Join(deferred.dir, deferredApplicationDirName))
+	for _, subscription := range available {
+		if err != nil {
+			results = append(results, deferred.newResult(subscription, utils.BulkStatusFailed, err.Error()))
+		} else {
+			results = append(results, deferred.newResult(subscription, utils.BulkStatusSucceeded, ""))
+		}
+	}
+	if err != nil {
+		return results, err
+	}
+	if len(stillMissing) == 0 {
+		return results, os.RemoveAll(deferred.dir)
+	}
+	deferred.Subscriptions = stillMissing
+	return results, deferred.write()
+}
+
+func (d *DeferredSubscriptions) newResult(subscription ApplicationSubscriptionPlan, status,
+	message string) DeferredSubscriptionResult {
+	return DeferredSubscriptionResult{Application: d.Application, Owner: d.Owner,
+		API: subscription.TargetAPI.String(), Status: status, Message: message}
+}
+
+// writes the deferred subscriptions to their directory
+func (d *DeferredSubscriptions) write() error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(d.dir, deferredSubscriptionsFileName), data, 0644)
+}
@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -40,7 +41,7 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	apiId, err := GetAPIId(accessToken, environment, deleteAPIName, deleteAPIVersion, deleteAPIProvider)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while getting API Id for deletion ", err)
+		return nil, errors.New("Error while getting API Id for deletion: " + err.Error())
 	}
 	url := deleteEndpoint + apiId
 	utils.Logln(utils.LogPrefixInfo+"DeleteAPI: URL:", url)
@@ -58,10 +59,10 @@ func DeleteAPI(accessToken, environment, deleteAPIName, deleteAPIVersion, delete
 	return resp, nil
 }
 
-func PrintDeleteAPIResponse(resp *resty.Response, err error) {
+func PrintDeleteAPIResponse(out io.Writer, resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API:", err)
+		fmt.Fprintln(out, "Error deleting API:", err)
 	} else {
-		fmt.Println("API deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(out, "API deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -70,10 +71,10 @@ func DeleteAPIPolicy(accessToken, policyName, policyVersion, environment string)
 	return resp, nil
 }
 
-func PrintDeleteAPIPolicyResponse(policyName, policyVersion string, err error) {
+func PrintDeleteAPIPolicyResponse(out io.Writer, policyName, policyVersion string, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API Policy:", err)
+		fmt.Fprintln(out, "Error deleting API Policy:", err)
 	} else {
-		fmt.Println(policyName + " API Policy with the version " + policyVersion + " deleted successfully!")
+		fmt.Fprintln(out, policyName+" API Policy with the version "+policyVersion+" deleted successfully!")
 	}
 }
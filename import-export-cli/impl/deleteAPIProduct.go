@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/go-resty/resty/v2"
@@ -41,7 +42,7 @@ func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductVersio
 	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	apiProductId, err := GetAPIProductId(accessToken, environment, apiProductName, apiProductVersion, apiProductProvider)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while getting API Product Id for deletion ", err)
+		return nil, errors.New("Error while getting API Product Id for deletion: " + err.Error())
 	}
 	url := deleteEndpoint + apiProductId
 	utils.Logln(utils.LogPrefixInfo+"DeleteAPIProduct: URL:", url)
@@ -59,10 +60,10 @@ func DeleteAPIProduct(accessToken, environment, apiProductName, apiProductVersio
 	return resp, nil
 }
 
-func PrintDeleteAPIProductResponse(resp *resty.Response, err error) {
+func PrintDeleteAPIProductResponse(out io.Writer, resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting API Product:", err)
+		fmt.Fprintln(out, "Error deleting API Product:", err)
 	} else {
-		fmt.Println("API Product deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(out, "API Product deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
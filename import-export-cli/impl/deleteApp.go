@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -37,10 +38,10 @@ func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner s
 	deleteEndpoint = utils.AppendSlashToString(deleteEndpoint)
 	appId, err := GetAppId(accessToken, environment, deleteAppName, deleteAppOwner)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while getting App Id for deletion ", err)
+		return nil, errors.New("Error while getting App Id for deletion: " + err.Error())
 	}
 	if appId == "" {
-		utils.HandleErrorAndExit("Cannot find the application: "+deleteAppName+" for owner: "+deleteAppOwner, err)
+		return nil, errors.New("Cannot find the application: " + deleteAppName + " for owner: " + deleteAppOwner)
 	}
 	url := deleteEndpoint + appId
 	utils.Logln(utils.LogPrefixInfo+"DeleteApplication: URL:", url)
@@ -58,10 +59,10 @@ func DeleteApplication(accessToken, environment, deleteAppName, deleteAppOwner s
 	return resp, nil
 }
 
-func PrintDeleteAppResponse(resp *resty.Response, err error) {
+func PrintDeleteAppResponse(out io.Writer, resp *resty.Response, err error) {
 	if err != nil {
-		fmt.Println("Error deleting Application:", err)
+		fmt.Fprintln(out, "Error deleting Application:", err)
 	} else {
-		fmt.Println("Application deleted successfully!. Status: " + strconv.Itoa(resp.StatusCode()))
+		fmt.Fprintln(out, "Application deleted successfully!. Status: "+strconv.Itoa(resp.StatusCode()))
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -123,10 +124,10 @@ func getThrottlingPolicyId(accessToken, environment, url, queryParamString, poli
 	return "", nil
 }
 
-func PrintDeleteThrottlingPolicyResponse(policyName, policyType string, err error) {
+func PrintDeleteThrottlingPolicyResponse(out io.Writer, policyName, policyType string, err error) {
 	if err != nil {
-		fmt.Println("Error deleting Throttling Policy:", err)
+		fmt.Fprintln(out, "Error deleting Throttling Policy:", err)
 	} else {
-		fmt.Println(policyName + " Throttling Policy with type " + policyType + " deleted successfully!")
+		fmt.Fprintln(out, policyName+" Throttling Policy with type "+policyType+" deleted successfully!")
 	}
 }
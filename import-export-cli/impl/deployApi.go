@@ -0,0 +1,65 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// DeployRevisionToGateways deploys a revision of an API to the given gateway environments
+// @param accessToken : Access Token for the resource
+// @param environment : Environment where the API resides
+// @param apiId : API ID
+// @param revisionId : ID of the revision to deploy
+// @param gateways : Gateway environments to which the revision has to be deployed
+// @return response Response in the form of *resty.Response
+func DeployRevisionToGateways(accessToken, environment, apiId, revisionId string,
+	gateways []utils.Deployment) (*resty.Response, error) {
+	apiRevisionEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return deployRevision(accessToken, apiRevisionEndpoint, apiId, revisionId, gateways)
+}
+
+// Function is used to deploy a revision of an API
+// @param accessToken : Access Token for the resource
+// @param deployRevisionEndpoint : API resource to deploy the revisions
+// @param apiId : API ID
+// @param revisionId : ID of the revision to deploy
+// @param gateways : Gateway environments to which the revision has to be deployed
+// @return response Response in the form of *resty.Response
+func deployRevision(accessToken, deployRevisionEndpoint, apiId, revisionId string,
+	gateways []utils.Deployment) (*resty.Response, error) {
+	deployRevisionEndpoint = utils.AppendSlashToString(deployRevisionEndpoint) + apiId +
+		"/deploy-revision?revisionId=" + revisionId
+
+	utils.Logln(utils.LogPrefixInfo+"Deploy URL:", deployRevisionEndpoint)
+
+	headers := make(map[string]string)
+	headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+
+	body, err := json.Marshal(gateways)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.InvokePOSTRequest(deployRevisionEndpoint, headers, string(body))
+}
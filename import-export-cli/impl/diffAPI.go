@@ -0,0 +1,397 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Sections of an API project compared by the diff api command
+const (
+	DiffSectionAPI                    = "api.yaml"
+	DiffSectionDefinition             = "definition"
+	DiffSectionEndpoints              = "endpoints"
+	DiffSectionPolicies               = "policies"
+	DiffSectionDeploymentEnvironments = "deployment_environments"
+)
+
+// diffIgnoredAPIFields are server generated fields of api.yaml which differ between environments by nature
+var diffIgnoredAPIFields = []string{"id", "createdTime", "lastUpdatedTime", "lastUpdatedTimestamp", "isRevision",
+	"revisionId", "workflowStatus", "gatewayVendor", "hasThumbnail"}
+
+// ANSI colors used in the text output
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBold   = "\033[1m"
+)
+
+// APIDiffSnapshot is the comparable content of an API project, either loaded from disk or exported from an
+// environment
+type APIDiffSnapshot struct {
+	Name                   string
+	Version                string
+	API                    map[string]interface{}
+	Definition             interface{}
+	Endpoints              interface{}
+	Policies               map[string]interface{}
+	DeploymentEnvironments interface{}
+}
+
+// APIDiffSection holds the changes of a single section of the API project
+type APIDiffSection struct {
+	Name    string             `json:"name"`
+	Changes []utils.DiffChange `json:"changes"`
+
+	from interface{}
+	to   interface{}
+}
+
+// APIDiffResult is the outcome of comparing two API snapshots
+type APIDiffResult struct {
+	Name     string           `json:"name"`
+	Version  string           `json:"version"`
+	From     string           `json:"from"`
+	To       string           `json:"to"`
+	Sections []APIDiffSection `json:"sections"`
+}
+
+// HasChanges returns true if any of the sections differ
+func (r *APIDiffResult) HasChanges() bool {
+	for _, section := range r.Sections {
+		if len(section.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadAPIDiffSnapshotFromProject loads an API project directory or archive for comparison. Environment variables
+// in the project are substituted the same way as in import api.
+func LoadAPIDiffSnapshotFromProject(projectPath string) (*APIDiffSnapshot, error) {
+	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
+	resolvedPath, err := resolveImportFilePath(projectPath, exportDirectory)
+	if err != nil {
+		return nil, err
+	}
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(resolvedPath)
+	if err != nil {
+		return nil, err
+	}
+	defer removeDiffWorkspace(filepath.Dir(tmpPath))
+
+	if err = replaceEnvVariables(tmpPath); err != nil {
+		return nil, err
+	}
+	return loadAPIDiffSnapshot(tmpPath)
+}
+
+// LoadAPIDiffSnapshotFromEnv exports an API from an environment and loads it for comparison
+func LoadAPIDiffSnapshotFromEnv(accessToken, environment, name, version, provider, revisionNum string,
+	latestRevision bool) (*APIDiffSnapshot, error) {
+	resp, err := ExportAPIFromEnv(accessToken, name, version, revisionNum, provider, utils.DefaultExportFormat,
+		environment, true, latestRevision, false)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("error exporting API %s %s from %s: %s %s", name, version, environment,
+			resp.Status(), string(resp.Body()))
+	}
+
+	tempZipFile, err := utils.WriteResponseToTempZip(name+"_"+version+".zip", resp)
+	if err != nil {
+		return nil, err
+	}
+	defer removeDiffWorkspace(filepath.Dir(tempZipFile))
+
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(tempZipFile)
+	if err != nil {
+		return nil, err
+	}
+	defer removeDiffWorkspace(filepath.Dir(tmpPath))
+	return loadAPIDiffSnapshot(tmpPath)
+}
+
+func removeDiffWorkspace(path string) {
+	utils.Logln(utils.LogPrefixInfo+"Deleting", path)
+	if err := os.RemoveAll(path); err != nil {
+		utils.Logln(utils.LogPrefixError + err.Error())
+	}
+}
+
+// loadAPIDiffSnapshot reads the api.yaml, definition, policies and deployment environments of a project directory
+func loadAPIDiffSnapshot(projectPath string) (*APIDiffSnapshot, error) {
+	_, apiContent, err := resolveYamlOrJSON(filepath.Join(projectPath, "api"))
+	if err != nil {
+		return nil, err
+	}
+	var apiFile struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err = json.Unmarshal(apiContent, &apiFile); err != nil {
+		return nil, err
+	}
+	if apiFile.Data == nil {
+		return nil, errors.New("invalid api.yaml in " + projectPath)
+	}
+
+	snapshot := &APIDiffSnapshot{
+		Name:     fmt.Sprint(apiFile.Data["name"]),
+		Version:  fmt.Sprint(apiFile.Data["version"]),
+		API:      apiFile.Data,
+		Policies: make(map[string]interface{}),
+	}
+	for _, field := range diffIgnoredAPIFields {
+		delete(snapshot.API, field)
+	}
+	snapshot.Endpoints = snapshot.API["endpointConfig"]
+	delete(snapshot.API, "endpointConfig")
+
+	if apiPolicies, ok := snapshot.API["apiPolicies"]; ok {
+		snapshot.Policies["apiPolicies"] = apiPolicies
+		delete(snapshot.API, "apiPolicies")
+	}
+	if operations, ok := snapshot.API["operations"].([]interface{}); ok {
+		operationPolicies := make(map[string]interface{})
+		for _, operation := range operations {
+			op, ok := operation.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			delete(op, "id")
+			if policies, ok := op["operationPolicies"]; ok {
+				operationPolicies[fmt.Sprintf("%v %v", op["verb"], op["target"])] = policies
+				delete(op, "operationPolicies")
+			}
+		}
+		if len(operationPolicies) > 0 {
+			snapshot.Policies["operationPolicies"] = operationPolicies
+		}
+	}
+	policyFiles, err := loadDiffPolicyFiles(filepath.Join(projectPath, utils.InitProjectSequences))
+	if err != nil {
+		return nil, err
+	}
+	if len(policyFiles) > 0 {
+		snapshot.Policies["files"] = policyFiles
+	}
+
+	if snapshot.Definition, err = loadDiffDefinition(projectPath); err != nil {
+		return nil, err
+	}
+
+	deploymentEnvPath := filepath.Join(projectPath, strings.TrimSuffix(utils.DeploymentEnvFile, ".yaml"))
+	if _, content, err := resolveYamlOrJSON(deploymentEnvPath); err == nil {
+		var deploymentEnvs struct {
+			Data interface{} `json:"data"`
+		}
+		if err = json.Unmarshal(content, &deploymentEnvs); err != nil {
+			return nil, err
+		}
+		snapshot.DeploymentEnvironments = deploymentEnvs.Data
+	}
+	return snapshot, nil
+}
+
+// loadDiffDefinition loads the OpenAPI, AsyncAPI or GraphQL definition of the project
+func loadDiffDefinition(projectPath string) (interface{}, error) {
+	for _, definitionFile := range []string{utils.InitProjectDefinitionsSwagger, utils.InitProjectDefinitionsAsyncAPI} {
+		definitionPath := strings.TrimSuffix(filepath.Join(projectPath, definitionFile), ".yaml")
+		if _, content, err := resolveYamlOrJSON(definitionPath); err == nil {
+			var definition interface{}
+			if err = json.Unmarshal(content, &definition); err != nil {
+				return nil, err
+			}
+			return definition, nil
+		}
+	}
+	schemaPath := filepath.Join(projectPath, utils.InitProjectDefinitionsGraphQLSchema)
+	if utils.IsFileExist(schemaPath) {
+		content, err := ioutil.ReadFile(schemaPath)
+		if err != nil {
+			return nil, err
+		}
+		return string(content), nil
+	}
+	return nil, nil
+}
+
+// loadDiffPolicyFiles loads the policy specifications and templates in the Policies directory of the project
+func loadDiffPolicyFiles(policiesPath string) (map[string]interface{}, error) {
+	files := make(map[string]interface{})
+	entries, err := ioutil.ReadDir(policiesPath)
+	if os.IsNotExist(err) {
+		return files, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(policiesPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext == ".yaml" || ext == ".yml" || ext == ".json" {
+			jsonContent, err := utils.YamlToJson(content)
+			if err == nil {
+				var spec interface{}
+				if err = json.Unmarshal(jsonContent, &spec); err == nil {
+					files[entry.Name()] = spec
+					continue
+				}
+			}
+		}
+		files[entry.Name()] = string(content)
+	}
+	return files, nil
+}
+
+// DiffAPISnapshots compares two API snapshots section by section
+func DiffAPISnapshots(fromLabel, toLabel string, from, to *APIDiffSnapshot) *APIDiffResult {
+	result := &APIDiffResult{Name: to.Name, Version: to.Version, From: fromLabel, To: toLabel}
+	addSection := func(name string, fromValue, toValue interface{}) {
+		changes := utils.DiffObjects(fromValue, toValue)
+		if changes == nil {
+			changes = []utils.DiffChange{}
+		}
+		result.Sections = append(result.Sections, APIDiffSection{Name: name, Changes: changes,
+			from: fromValue, to: toValue})
+	}
+	addSection(DiffSectionAPI, from.API, to.API)
+	addSection(DiffSectionDefinition, from.Definition, to.Definition)
+	addSection(DiffSectionEndpoints, from.Endpoints, to.Endpoints)
+	addSection(DiffSectionPolicies, from.Policies, to.Policies)
+	addSection(DiffSectionDeploymentEnvironments, from.DeploymentEnvironments, to.DeploymentEnvironments)
+	return result
+}
+
+// PrintAPIDiff writes the diff result to out in the given structured output format, or else as a unified patch if
+// patch is set and as text otherwise
+func PrintAPIDiff(out io.Writer, result *APIDiffResult, format string, patch, colored bool) error {
+	if format != "" {
+		return formatter.NewContext(out, format).WriteData(result)
+	}
+	if patch {
+		return printAPIDiffPatch(out, result)
+	}
+	printAPIDiffText(out, result, colored)
+	return nil
+}
+
+func printAPIDiffText(out io.Writer, result *APIDiffResult, colored bool) {
+	paint := func(color, text string) string {
+		if !colored {
+			return text
+		}
+		return color + text + colorReset
+	}
+	fmt.Fprintf(out, "%s\n", paint(colorBold, fmt.Sprintf("API %s %s: %s -> %s", result.Name, result.Version,
+		result.From, result.To)))
+	for _, section := range result.Sections {
+		fmt.Fprintf(out, "\n%s\n", paint(colorBold, section.Name))
+		if len(section.Changes) == 0 {
+			fmt.Fprintln(out, "  no changes")
+			continue
+		}
+		for _, change := range section.Changes {
+			switch change.Type {
+			case utils.DiffChangeAdded:
+				fmt.Fprintln(out, paint(colorGreen, fmt.Sprintf("  + %s: %s", change.Path, formatDiffValue(change.To))))
+			case utils.DiffChangeRemoved:
+				fmt.Fprintln(out, paint(colorRed, fmt.Sprintf("  - %s: %s", change.Path, formatDiffValue(change.From))))
+			default:
+				fmt.Fprintln(out, paint(colorYellow, fmt.Sprintf("  ~ %s: %s -> %s", change.Path,
+					formatDiffValue(change.From), formatDiffValue(change.To))))
+			}
+		}
+	}
+}
+
+func formatDiffValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func printAPIDiffPatch(out io.Writer, result *APIDiffResult) error {
+	for _, section := range result.Sections {
+		if len(section.Changes) == 0 {
+			continue
+		}
+		fromText, err := diffSectionToText(section.from)
+		if err != nil {
+			return err
+		}
+		toText, err := diffSectionToText(section.to)
+		if err != nil {
+			return err
+		}
+		name := section.Name
+		if !strings.HasSuffix(name, ".yaml") {
+			name += ".yaml"
+		}
+		patch, err := utils.UnifiedDiff(result.From+"/"+name, result.To+"/"+name, fromText, toText)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, patch)
+	}
+	return nil
+}
+
+// diffSectionToText renders a section as YAML with sorted keys so the patch is stable
+func diffSectionToText(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	yamlContent, err := utils.JsonToYaml(data)
+	if err != nil {
+		return "", err
+	}
+	return string(yamlContent), nil
+}
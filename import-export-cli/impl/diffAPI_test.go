@@ -0,0 +1,129 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const diffTestAPIYaml = `type: api
+version: v4.5.0
+data:
+  id: %ID%
+  name: PizzaShackAPI
+  version: 1.0.0
+  context: %CONTEXT%
+  lastUpdatedTime: %ID%
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: %URL%
+  operations:
+   - id: %ID%
+     target: /menu
+     verb: GET
+     operationPolicies:
+       request:
+        - policyName: addHeader
+          policyVersion: v1
+`
+
+const diffTestDeploymentEnvs = `type: deployment_environments
+version: v4.5.0
+data:
+ - displayOnDevportal: true
+   deploymentEnvironment: Default
+`
+
+func writeDiffTestProject(t *testing.T, id, context, url string) string {
+	dir := filepath.Join(t.TempDir(), "PizzaShackAPI-1.0.0")
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, utils.InitProjectDefinitions), os.ModePerm))
+	apiYaml := strings.NewReplacer("%ID%", id, "%CONTEXT%", context, "%URL%", url).Replace(diffTestAPIYaml)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "api.yaml"), []byte(apiYaml), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, utils.InitProjectDefinitionsSwagger),
+		[]byte("openapi: 3.0.1\ninfo:\n  title: PizzaShackAPI\n  version: 1.0.0\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, utils.DeploymentEnvFile), []byte(diffTestDeploymentEnvs), 0644))
+	return dir
+}
+
+func TestDiffAPISnapshotsIgnoresServerGeneratedFields(t *testing.T) {
+	from, err := loadAPIDiffSnapshot(writeDiffTestProject(t, "1111", "/pizzashack", "https://dev.wso2.com"))
+	assert.Nil(t, err)
+	to, err := loadAPIDiffSnapshot(writeDiffTestProject(t, "2222", "/pizzashack", "https://dev.wso2.com"))
+	assert.Nil(t, err)
+
+	result := DiffAPISnapshots("dev", "prod", from, to)
+	assert.False(t, result.HasChanges(), "Ids and timestamps should not be reported as changes")
+	assert.Equal(t, "PizzaShackAPI", result.Name)
+	assert.Len(t, result.Sections, 5)
+	assert.NotNil(t, from.Policies["operationPolicies"], "Operation policies should be moved to the policies section")
+	assert.NotNil(t, from.DeploymentEnvironments)
+}
+
+func TestDiffAPISnapshotsSections(t *testing.T) {
+	from, err := loadAPIDiffSnapshot(writeDiffTestProject(t, "1", "/pizzashack", "https://dev.wso2.com"))
+	assert.Nil(t, err)
+	to, err := loadAPIDiffSnapshot(writeDiffTestProject(t, "1", "/pizza", "https://prod.wso2.com"))
+	assert.Nil(t, err)
+
+	result := DiffAPISnapshots("dev", "prod", from, to)
+	assert.True(t, result.HasChanges())
+	for _, section := range result.Sections {
+		switch section.Name {
+		case DiffSectionAPI:
+			assert.Equal(t, []utils.DiffChange{{Path: "context", Type: utils.DiffChangeModified,
+				From: "/pizzashack", To: "/pizza"}}, section.Changes)
+		case DiffSectionEndpoints:
+			assert.Len(t, section.Changes, 1)
+			assert.Equal(t, "production_endpoints.url", section.Changes[0].Path)
+		default:
+			assert.Empty(t, section.Changes, "Section %s should not have changes", section.Name)
+		}
+	}
+
+	var out bytes.Buffer
+	assert.Nil(t, PrintAPIDiff(&out, result, formatter.JsonOutputKey, false, false))
+	var decoded APIDiffResult
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "prod", decoded.To)
+
+	out.Reset()
+	assert.Nil(t, PrintAPIDiff(&out, result, "", true, false))
+	assert.Contains(t, out.String(), "--- dev/api.yaml")
+	assert.Contains(t, out.String(), "+context: /pizza")
+	assert.NotContains(t, out.String(), "definition.yaml", "Sections without changes should not be in the patch")
+
+	out.Reset()
+	assert.Nil(t, PrintAPIDiff(&out, result, "", false, false))
+	assert.Contains(t, out.String(), "~ context: /pizzashack -> /pizza")
+
+	out.Reset()
+	assert.Nil(t, PrintAPIDiff(&out, result, "jsonpath={.sections[0].changes[0].path}", false, false))
+	assert.Equal(t, "context\n", out.String())
+}
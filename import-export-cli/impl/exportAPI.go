@@ -19,7 +19,9 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"path/filepath"
 	"strconv"
@@ -78,12 +80,14 @@ func exportAPI(name, version, revisionNum, provider, format, publisherEndpoint,
 // @param exportAPIName : Name of the API to be exported
 // @param exportAPIVersion: Version of the API to be exported
 // @param exportAPIRevisionNumber: Revision number of the api
+// @param out: Writer to which the location of the zip file is printed, nothing is printed if nil
 // @param zipLocationPath: Path to the export directory
-// @param runningExportApiCommand: Whether the export API command is running
 // @param resp : Response returned from making the HTTP request (only pass a 200 OK)
 // Exported API will be written to a zip file
-func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath string,
-	runningExportApiCommand bool, resp *resty.Response) {
+// @return path of the zip file
+// @return error
+func WriteToZip(out io.Writer, exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLocationPath string,
+	resp *resty.Response) (string, error) {
 	zipFilename := exportAPIName + "_" + exportAPIVersion
 	if exportAPIRevisionNumber != "" {
 		zipFilename += "_" + utils.GetRevisionNamFromRevisionNum(exportAPIRevisionNumber)
@@ -92,12 +96,12 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 	// Writes the REST API response to a temporary zip file
 	tempZipFile, err := utils.WriteResponseToTempZip(zipFilename, resp)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported API", err)
+		return "", errors.New("Error creating the temporary zip file to store the exported API: " + err.Error())
 	}
 
 	err = utils.CreateDirIfNotExist(zipLocationPath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating dir to store zip archive: "+zipLocationPath, err)
+		return "", errors.New("Error creating dir to store zip archive " + zipLocationPath + ": " + err.Error())
 	}
 	exportedFinalZip := filepath.Join(zipLocationPath, zipFilename)
 
@@ -115,12 +119,13 @@ func WriteToZip(exportAPIName, exportAPIVersion, exportAPIRevisionNumber, zipLoc
 	}
 	err = IncludeMetaFileToZip(tempZipFile, exportedFinalZip, utils.MetaFileAPI, metaData)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the final zip archive with api_meta.yaml file", err)
+		return "", errors.New("Error creating the final zip archive with api_meta.yaml file: " + err.Error())
 	}
 
 	// Output the final zip file location.
-	if runningExportApiCommand {
-		fmt.Println("Successfully exported API!")
-		fmt.Println("Find the exported API at " + exportedFinalZip)
+	if out != nil {
+		fmt.Fprintln(out, "Successfully exported API!")
+		fmt.Fprintln(out, "Find the exported API at "+exportedFinalZip)
 	}
+	return exportedFinalZip, nil
 }
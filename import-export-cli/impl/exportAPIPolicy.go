@@ -20,6 +20,7 @@ package impl
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"path/filepath"
 
@@ -55,8 +56,10 @@ func ExportAPIPolicyFromEnv(accessToken, exportEnvironment, apiPolicyName, apiPo
 	return resp, nil
 }
 
-// WriteAPIPolicyToFile writes the policy to a specified location
-func WriteAPIPolicyToFile(exportLocationPath string, resp *resty.Response, exportAPIPolicyVersion string, exportAPIPolicyName string) {
+// WriteAPIPolicyToFile writes the policy to a specified location and returns the path of the file. The location of
+// the file is printed to out unless it is nil
+func WriteAPIPolicyToFile(out io.Writer, exportLocationPath string, resp *resty.Response, exportAPIPolicyVersion string,
+	exportAPIPolicyName string) string {
 	err := utils.CreateDirIfNotExist(exportLocationPath)
 	if err != nil {
 		utils.HandleErrorAndExit("Error creating dir to store zip archives: "+exportLocationPath, err)
@@ -70,7 +73,10 @@ func WriteAPIPolicyToFile(exportLocationPath string, resp *resty.Response, expor
 		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported API", err)
 	}
 
-	fmt.Println("Successfully exported API Policy!")
-	fmt.Println("Find the exported API Policy at " +
-		utils.AppendSlashToString(exportLocationPath) + zipFileName)
+	if out != nil {
+		fmt.Fprintln(out, "Successfully exported API Policy!")
+		fmt.Fprintln(out, "Find the exported API Policy at "+
+			utils.AppendSlashToString(exportLocationPath)+zipFileName)
+	}
+	return zipFile
 }
@@ -19,7 +19,9 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"path/filepath"
 	"strconv"
@@ -74,20 +76,23 @@ func exportAPIProduct(name, version, revisionNum, provider, format, publisherEnd
 }
 
 // WriteAPIProductToZip
+// @param out : Writer to which the location of the zip file is printed, nothing is printed if nil
 // @param exportAPIProductName : Name of the API Product to be exported
 // @param resp : Response returned from making the HTTP request (only pass a 200 OK)
 // Exported API Product will be written to a zip file
-func WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, zipLocationPath string, runningExportAPIProductCommand bool, resp *resty.Response) {
+// @return path of the zip file
+func WriteAPIProductToZip(out io.Writer, exportAPIProductName, exportAPIProductVersion, zipLocationPath string,
+	resp *resty.Response) (string, error) {
 	zipFilename := exportAPIProductName + "_" + exportAPIProductVersion + ".zip" // MyAPIProduct_1.0.0.zip
 	// Writes the REST API response to a temporary zip file
 	tempZipFile, err := utils.WriteResponseToTempZip(zipFilename, resp)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported API Product", err)
+		return "", errors.New("Error creating the temporary zip file to store the exported API Product: " + err.Error())
 	}
 
 	err = utils.CreateDirIfNotExist(zipLocationPath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating dir to store zip archive: "+zipLocationPath, err)
+		return "", errors.New("Error creating dir to store zip archive " + zipLocationPath + ": " + err.Error())
 	}
 	exportedFinalZip := filepath.Join(zipLocationPath, zipFilename)
 
@@ -107,11 +112,12 @@ func WriteAPIProductToZip(exportAPIProductName, exportAPIProductVersion, zipLoca
 	}
 	err = IncludeMetaFileToZip(tempZipFile, exportedFinalZip, utils.MetaFileAPIProduct, metaData)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the final zip archive with api_product_meta.yaml file", err)
+		return "", errors.New("Error creating the final zip archive with api_product_meta.yaml file: " + err.Error())
 	}
 
-	if runningExportAPIProductCommand {
-		fmt.Println("Successfully exported API Product!")
-		fmt.Println("Find the exported API Product at " + exportedFinalZip)
+	if out != nil {
+		fmt.Fprintln(out, "Successfully exported API Product!")
+		fmt.Fprintln(out, "Find the exported API Product at "+exportedFinalZip)
 	}
+	return exportedFinalZip, nil
 }
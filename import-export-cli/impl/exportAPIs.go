@@ -20,10 +20,12 @@ package impl
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
 	"strconv"
 	"sync/atomic"
+	"time"
 
 	"github.com/spf13/cast"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -41,9 +43,7 @@ var startingApiIndexFromList int
 var mainConfigFilePath string
 
 // Prepare resumption of previous-halted export-apis operation
-func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	var lastSuceededAPI utils.API
-	lastSuceededAPI = utils.ReadLastSucceededAPIFileData(exportRelatedFilesPath)
+func PrepareResumption(out io.Writer, credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
 	var migrationApisExportMetadata utils.MigrationApisExportMetadata
 	err := migrationApisExportMetadata.ReadMigrationApisExportMetadataFile(filepath.Join(exportRelatedFilesPath,
 		utils.MigrationAPIsExportMetadataFileName))
@@ -53,7 +53,12 @@ func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath
 	}
 	apis = migrationApisExportMetadata.ApiListToExport
 	apiListOffset = migrationApisExportMetadata.ApiListOffset
-	startingApiIndexFromList = getLastSuceededApiIndex(lastSuceededAPI) + 1
+	if utils.IsFileExist(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportLedgerFileName)) {
+		// APIs completed out of order are skipped using the ledger, so the whole batch is visited again
+		startingApiIndexFromList = 0
+	} else {
+		startingApiIndexFromList = getLastSuceededApiIndex(utils.ReadLastSucceededAPIFileData(exportRelatedFilesPath)) + 1
+	}
 
 	//find count of APIs left to be exported
 	count = int32(len(apis) - startingApiIndexFromList)
@@ -67,15 +72,15 @@ func PrepareResumption(credential credentials.Credential, exportRelatedFilesPath
 			utils.WriteMigrationApisExportMetadataFile(apis, cmdResourceTenantDomain, cmdUsername,
 				exportRelatedFilesPath, apiListOffset)
 		} else {
-			fmt.Println("Command: export apis execution completed !")
+			fmt.Fprintln(out, "Command: export apis execution completed !")
 		}
 	}
 }
 
 // Delete directories where the APIs are exported, reset the indexes, get first API list and write the
 // migration-apis-export-metadata.yaml file
-func PrepareStartFromBeginning(credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported APIs of the given target tenant, in the given environment if " +
+func PrepareStartFromBeginning(out io.Writer, credential credentials.Credential, exportRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
+	fmt.Fprintln(out, "Cleaning all the previously exported APIs of the given target tenant, in the given environment if "+
 		"any, and prepare to export APIs from beginning")
 	//cleaning existing old files (if exists) related to exportation
 	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportRelatedFilesPath, utils.ExportedApisDirName)); err != nil {
@@ -90,6 +95,14 @@ func PrepareStartFromBeginning(credential credentials.Credential, exportRelatedF
 		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
 			"exportation", err)
 	}
+	if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportLedgerFileName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
+	if err := utils.RemoveFileIfExists(filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportReportFileName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
 
 	apiListOffset = 0
 	startingApiIndexFromList = 0
@@ -146,61 +159,65 @@ func getRevisionsListForAPI(accessToken, cmdExportEnvironment string, api utils.
 // Do the API exportation
 func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
 	exportAPIsFormat, cmdUsername, apiExportDir string, exportAPIPreserveStatus, runningExportApiCommand,
-	exportAllRevisions, exportForAI, exportAPIPreserveCredentials bool) {
+	exportAllRevisions, exportForAI, exportAPIPreserveCredentials bool, bulkOptions utils.BulkOperationOptions) {
+	out := bulkOptions.MessageWriter()
 	if count == 0 {
-		fmt.Println("No APIs available to be exported..!")
+		fmt.Fprintln(out, "No APIs available to be exported..!")
 	} else {
-		var counterSuceededAPIs = 0
+		var ledger *utils.BulkOperationLedger
+		var report *utils.BulkOperationReport
+		var limiter *utils.RateLimiter
+		if !exportForAI {
+			var err error
+			ledger, err = utils.LoadBulkOperationLedger(filepath.Join(exportRelatedFilesPath,
+				utils.MigrationAPIsExportLedgerFileName))
+			if err != nil {
+				utils.HandleErrorAndExit("Error reading the export ledger", err)
+			}
+			report = utils.NewBulkOperationReport("export apis", cmdExportEnvironment, cmdResourceTenantDomain)
+			limiter = utils.NewRateLimiter(bulkOptions.RateLimit)
+			defer limiter.Stop()
+		}
 		for count > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", count, "of APIs to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(apiListOffset)+". Maximum limit of APIs exported in single iteration is "+
 				strconv.Itoa(utils.MaxAPIsToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
 			if preCommandErr == nil {
-				apiList := []map[string]interface{}{}
-				for i := startingApiIndexFromList; i < len(apis); i++ {
-					if exportForAI {
+				if exportForAI {
+					apiList := []map[string]interface{}{}
+					for i := startingApiIndexFromList; i < len(apis); i++ {
 						apiPayload := GetAPIPayload(apis[i], accessToken, CmdUploadEnvironment, false)
 						if apiPayload != nil {
 							apiList = append(apiList, apiPayload)
 						}
-						continue
-					} else {
-						if exportAllRevisions {
-							//Export the working copy of the api
-							exportAPIandWriteToZip(apis[i], "", accessToken, cmdExportEnvironment, apiExportDir,
-								exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand,
-								exportAPIPreserveCredentials)
-							counterSuceededAPIs++
-						}
-						revisionCount, revisions, err := getRevisionsListForAPI(accessToken, cmdExportEnvironment, apis[i],
-							exportAllRevisions)
-						if err != nil {
-							fmt.Println("An error occurred while getting the revisions list for API "+apis[i].Version+
-								"_"+apis[i].Version, err)
-						} else if revisionCount > 0 {
-							for j := 0; j < len(revisions); j++ {
-								exportApiRevision := utils.GetRevisionNumFromRevisionName(revisions[j].RevisionNumber)
-								exportAPIandWriteToZip(apis[i], exportApiRevision, accessToken, cmdExportEnvironment,
-									apiExportDir, exportRelatedFilesPath, exportAPIsFormat, exportAPIPreserveStatus,
-									runningExportApiCommand, exportAPIPreserveCredentials)
-								counterSuceededAPIs++
-							}
-						}
 					}
-				}
-				if exportForAI {
 					atomic.AddInt32(&totalAPIs, int32(len(apiList)))
 					if len(apiList) > 0 {
 						apiListQueue <- apiList
 					}
+				} else {
+					batch := apis[startingApiIndexFromList:]
+					failedBefore := report.Failed
+					utils.RunInParallel(len(batch), bulkOptions.Parallel, func(i int) {
+						exportAPIWithRevisions(batch[i], accessToken, cmdExportEnvironment, apiExportDir,
+							exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions,
+							exportAPIPreserveCredentials, bulkOptions, limiter, ledger, report)
+					})
+					if report.Failed > failedBefore {
+						// The offset is not moved forward, so the failed APIs are retried when the command is run again
+						writeBulkOperationReport(report, filepath.Join(exportRelatedFilesPath,
+							utils.MigrationAPIsExportReportFileName), bulkOptions)
+						utils.HandleErrorAndExit(cast.ToString(report.Failed-failedBefore)+" API(s) of the batch "+
+							"could not be exported. Run the command again without --force to resume the export", nil)
+					}
 				}
 			} else {
 				// error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
+				fmt.Fprintln(out, "Error getting OAuth Tokens : "+preCommandErr.Error())
 			}
 			if !exportForAI {
-				fmt.Println("Batch of " + cast.ToString(count) + " APIs exported successfully..!")
+				fmt.Fprintln(out, "Batch of "+cast.ToString(count)+" APIs exported successfully..!")
 			}
 
 			apiListOffset += utils.MaxAPIsToExportOnce
@@ -214,17 +231,100 @@ func ExportAPIs(credential credentials.Credential, exportRelatedFilesPath, cmdEx
 			}
 		}
 		if !exportForAI {
-			fmt.Println("\nTotal number of APIs exported: " + cast.ToString(counterSuceededAPIs))
-			fmt.Println("API export path: " + apiExportDir)
-			fmt.Println("\nCommand: export-apis execution completed !")
+			writeBulkOperationReport(report, filepath.Join(exportRelatedFilesPath, utils.MigrationAPIsExportReportFileName),
+				bulkOptions)
+			fmt.Fprintln(out, "\nTotal number of APIs exported: "+cast.ToString(report.Succeeded))
+			fmt.Fprintln(out, "API export path: "+apiExportDir)
+			fmt.Fprintln(out, "\nCommand: export-apis execution completed !")
 		}
 	}
 }
 
+// Export the working copy (if all revisions are exported) and the revisions of an API. The outcome of each
+// exported archive is recorded in the ledger and the report.
+func exportAPIWithRevisions(api utils.API, accessToken, cmdExportEnvironment, apiExportDir, exportAPIsFormat string,
+	exportAPIPreserveStatus, runningExportApiCommand, exportAllRevisions, exportAPIPreserveCredentials bool,
+	bulkOptions utils.BulkOperationOptions, limiter *utils.RateLimiter, ledger *utils.BulkOperationLedger,
+	report *utils.BulkOperationReport) {
+	out := bulkOptions.MessageWriter()
+	var revisionNumbers []string
+	if exportAllRevisions {
+		//Export the working copy of the api
+		revisionNumbers = append(revisionNumbers, "")
+	}
+	var revisions []utils.Revisions
+	_, err := utils.RunWithRetry(bulkOptions.MaxRetries, func() error {
+		limiter.Wait()
+		var listErr error
+		_, revisions, listErr = getRevisionsListForAPI(accessToken, cmdExportEnvironment, api, exportAllRevisions)
+		return listErr
+	})
+	if err != nil {
+		fmt.Fprintln(out, "An error occurred while getting the revisions list for API "+api.Name+
+			"_"+api.Version, err)
+		report.Add(utils.BulkArtifactResult{Key: getAPIExportLedgerKey(api, ""), Type: "api", Name: api.Name,
+			Version: api.Version, Owner: api.Provider, Status: utils.BulkStatusFailed, Attempts: 1,
+			Error: err.Error()})
+		return
+	}
+	for _, revision := range revisions {
+		revisionNumbers = append(revisionNumbers, utils.GetRevisionNumFromRevisionName(revision.RevisionNumber))
+	}
+
+	for _, revisionNumber := range revisionNumbers {
+		key := getAPIExportLedgerKey(api, revisionNumber)
+		result := utils.BulkArtifactResult{Key: key, Type: "api", Name: api.Name, Version: api.Version,
+			Owner: api.Provider, Revision: revisionNumber}
+		if ledger.IsCompleted(key) {
+			utils.Logln(utils.LogPrefixInfo + "Skipping " + key + " as it has been already exported")
+			result.Status = utils.BulkStatusSkipped
+			report.Add(result)
+			continue
+		}
+		startTime := time.Now()
+		result.Attempts, err = utils.RunWithRetry(bulkOptions.MaxRetries, func() error {
+			limiter.Wait()
+			return exportAPIandWriteToZip(out, api, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
+				exportAPIsFormat, exportAPIPreserveStatus, runningExportApiCommand, exportAPIPreserveCredentials)
+		})
+		result.DurationMillis = time.Since(startTime).Milliseconds()
+		result.Status = utils.BulkStatusSucceeded
+		if err != nil {
+			fmt.Fprintln(out, "Error exporting API:", api.Name, "-", api.Version, " of Provider:", api.Provider, "-", err)
+			result.Status = utils.BulkStatusFailed
+			result.Error = err.Error()
+		}
+		if ledgerErr := ledger.Record(key, result.Status); ledgerErr != nil {
+			utils.HandleErrorAndExit("Error writing to the export ledger", ledgerErr)
+		}
+		report.Add(result)
+	}
+}
+
+// Get the key of an exported API archive in the ledger, ex: PizzaShackAPI:1.0.0:admin:2
+func getAPIExportLedgerKey(api utils.API, revisionNumber string) string {
+	if revisionNumber == "" {
+		revisionNumber = "working-copy"
+	}
+	return api.Name + ":" + api.Version + ":" + api.Provider + ":" + revisionNumber
+}
+
+// Write the report of a bulk export or import, print the summary and print the report in the output format, if any
+func writeBulkOperationReport(report *utils.BulkOperationReport, reportPath string,
+	bulkOptions utils.BulkOperationOptions) {
+	if err := report.Write(reportPath); err != nil {
+		utils.HandleErrorAndContinue("Error writing the report to "+reportPath, err)
+	}
+	out := bulkOptions.MessageWriter()
+	report.PrintSummary(out)
+	fmt.Fprintln(out, "Report: "+reportPath)
+	utils.PrintStructuredOutput(report, bulkOptions.Output)
+}
+
 // Export the API and archive to zip format
-func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
-	exportRelatedFilesPath, exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand,
-	exportAPIPreserveCredentials bool) {
+func exportAPIandWriteToZip(out io.Writer, api utils.API, revisionNumber, accessToken, cmdExportEnvironment, apiExportDir,
+	exportAPIsFormat string, exportAPIPreserveStatus, runningExportApiCommand,
+	exportAPIPreserveCredentials bool) error {
 
 	exportAPIName := api.Name
 	exportAPIVersion := api.Version
@@ -237,18 +337,20 @@ func exportAPIandWriteToZip(api utils.API, revisionNumber, accessToken, cmdExpor
 		exportApiProvider, exportAPIsFormat, cmdExportEnvironment, exportAPIPreserveStatus, false,
 		exportAPIPreserveCredentials)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return err
 	}
 
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteToZip(exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, runningExportApiCommand, resp)
-		//write on last-succeeded-api.log
-		utils.WriteLastSuceededAPIFileData(exportRelatedFilesPath, api)
-	} else {
-		fmt.Println("Error exporting API:", exportAPIName, "-", exportAPIVersion, " of Provider:", exportApiProvider)
-		utils.PrintErrorResponseAndExit(resp)
+	if resp.StatusCode() != http.StatusOK {
+		return utils.GetBulkResponseError(resp)
+	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	// the location of each archive is printed only by the export apis command
+	var zipOut io.Writer
+	if runningExportApiCommand {
+		zipOut = out
 	}
+	_, err = WriteToZip(zipOut, exportAPIName, exportAPIVersion, exportApiRevision, apiExportDir, resp)
+	return utils.NonRetryable(err)
 }
 
 // Create the required directory structure to save the exported APIs
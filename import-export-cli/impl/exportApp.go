@@ -19,7 +19,9 @@
 package impl
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 
@@ -70,22 +72,26 @@ func ExportApp(name, owner, format, devportalApplicationsEndpoint, accessToken s
 }
 
 // WriteApplicationToZip
+// @param out : Writer to which the location of the zip file is printed, nothing is printed if nil
 // @param exportAppName : Name of the Application to be exported
 // @param exportAppOwner : Owner of the Application to be exported
 // @param resp : Response returned from making the HTTP request (only pass a 200 OK)
 // Exported Application will be written to a zip file
-func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string,
-	resp *resty.Response) {
+// @return path of the zip file
+// @return error
+func WriteApplicationToZip(out io.Writer, exportAppName, exportAppOwner, zipLocationPath string,
+	resp *resty.Response) (string, error) {
 	zipFilename := replaceUserStoreDomainDelimiter(exportAppOwner) + "_" + exportAppName + ".zip" // admin_testApp.zip
 	// Writes the REST API response to a temporary zip file
 	tempZipFile, err := utils.WriteResponseToTempZip(zipFilename, resp)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the temporary zip file to store the exported application", err)
+		return "", errors.New("Error creating the temporary zip file to store the exported application: " +
+			err.Error())
 	}
 
 	err = utils.CreateDirIfNotExist(zipLocationPath)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating dir to store zip archive: "+zipLocationPath, err)
+		return "", errors.New("Error creating dir to store zip archive " + zipLocationPath + ": " + err.Error())
 	}
 
 	exportedFinalZip := filepath.Join(zipLocationPath, zipFilename)
@@ -105,11 +111,14 @@ func WriteApplicationToZip(exportAppName, exportAppOwner, zipLocationPath string
 	}
 	err = IncludeMetaFileToZip(tempZipFile, exportedFinalZip, utils.MetaFileApplication, metaData)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating the final zip archive with application_meta.yaml file", err)
+		return "", errors.New("Error creating the final zip archive with application_meta.yaml file: " + err.Error())
 	}
 
-	fmt.Println("Successfully exported Application!")
-	fmt.Println("Find the exported Application at " + exportedFinalZip)
+	if out != nil {
+		fmt.Fprintln(out, "Successfully exported Application!")
+		fmt.Fprintln(out, "Find the exported Application at "+exportedFinalZip)
+	}
+	return exportedFinalZip, nil
 }
 
 // The Application owner name is used to construct a unique name for the app export zip.
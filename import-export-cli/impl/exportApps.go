@@ -20,9 +20,11 @@ package impl
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cast"
 	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
@@ -38,9 +40,7 @@ var exportAppsFormat string
 var startingAppIndexFromList int
 
 // Prepare resumption of previous-halted export-apps operation
-func PrepareResumptionForApps(credential credentials.Credential, exportAppsRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	var lastSuceededApp utils.Application
-	lastSuceededApp = utils.ReadLastSucceededAppFileData(exportAppsRelatedFilesPath)
+func PrepareResumptionForApps(out io.Writer, credential credentials.Credential, exportAppsRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
 	var migrationAppsExportMetadata utils.MigrationAppsExportMetadata
 	err := migrationAppsExportMetadata.ReadMigrationAppsExportMetadataFile(filepath.Join(exportAppsRelatedFilesPath,
 		utils.MigrationAppsExportMetadataFileName))
@@ -50,7 +50,12 @@ func PrepareResumptionForApps(credential credentials.Credential, exportAppsRelat
 	}
 	apps = migrationAppsExportMetadata.AppListToExport
 	appListOffset = migrationAppsExportMetadata.AppListOffset
-	startingAppIndexFromList = getLastSuceededAppIndex(lastSuceededApp) + 1
+	if utils.IsFileExist(filepath.Join(exportAppsRelatedFilesPath, utils.MigrationAppsExportLedgerFileName)) {
+		// Apps completed out of order are skipped using the ledger, so the whole batch is visited again
+		startingAppIndexFromList = 0
+	} else {
+		startingAppIndexFromList = getLastSuceededAppIndex(utils.ReadLastSucceededAppFileData(exportAppsRelatedFilesPath)) + 1
+	}
 
 	// Find count of Apps left to be exported
 	appCount = int32(len(apps) - startingAppIndexFromList)
@@ -64,15 +69,15 @@ func PrepareResumptionForApps(credential credentials.Credential, exportAppsRelat
 			utils.WriteMigrationAppsExportMetadataFile(apps, cmdResourceTenantDomain, cmdUsername,
 				exportAppsRelatedFilesPath, appListOffset)
 		} else {
-			fmt.Println("Command: export apps execution completed!")
+			fmt.Fprintln(out, "Command: export apps execution completed!")
 		}
 	}
 }
 
 // Delete directories where the Apps are exported, reset the indexes, get first App list and write the
 // migration-apps-export-metadata.yaml file
-func PrepareStartAppsFromBeginning(credential credentials.Credential, exportAppsRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
-	fmt.Println("Cleaning all the previously exported Apps of the given target tenant, in the given environment if " +
+func PrepareStartAppsFromBeginning(out io.Writer, credential credentials.Credential, exportAppsRelatedFilesPath, cmdResourceTenantDomain, cmdUsername, cmdExportEnvironment string) {
+	fmt.Fprintln(out, "Cleaning all the previously exported Apps of the given target tenant, in the given environment if "+
 		"any, and prepare to export Apps from beginning")
 	// Cleaning existing old files (if exists) related to exportation
 	if err := utils.RemoveDirectoryIfExists(filepath.Join(exportAppsRelatedFilesPath, utils.ExportedAppsDirName)); err != nil {
@@ -87,6 +92,14 @@ func PrepareStartAppsFromBeginning(credential credentials.Credential, exportApps
 		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
 			"exportation", err)
 	}
+	if err := utils.RemoveFileIfExists(filepath.Join(exportAppsRelatedFilesPath, utils.MigrationAppsExportLedgerFileName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
+	if err := utils.RemoveFileIfExists(filepath.Join(exportAppsRelatedFilesPath, utils.MigrationAppsExportReportFileName)); err != nil {
+		utils.HandleErrorAndExit("Error occurred while cleaning existing old files (if exists) related to "+
+			"exportation", err)
+	}
 
 	appListOffset = 0
 	startingAppIndexFromList = 0
@@ -131,27 +144,43 @@ func getAppList(credential credentials.Credential, cmdExportEnvironment, cmdReso
 
 // Do the App exportation
 func ExportApps(credential credentials.Credential, exportAppsRelatedFilesPath, cmdExportEnvironment, cmdResourceTenantDomain,
-	exportAppsFormat, cmdUsername, appExportDir string, exportAppsWithKeys bool) {
+	exportAppsFormat, cmdUsername, appExportDir string, exportAppsWithKeys bool, bulkOptions utils.BulkOperationOptions) {
+	out := bulkOptions.MessageWriter()
 	if appCount == 0 {
-		fmt.Println("No Apps available to be exported..!")
+		fmt.Fprintln(out, "No Apps available to be exported..!")
 	} else {
-		var counterSuceededApps = 0
+		ledger, err := utils.LoadBulkOperationLedger(filepath.Join(exportAppsRelatedFilesPath,
+			utils.MigrationAppsExportLedgerFileName))
+		if err != nil {
+			utils.HandleErrorAndExit("Error reading the export ledger", err)
+		}
+		report := utils.NewBulkOperationReport("export apps", cmdExportEnvironment, cmdResourceTenantDomain)
+		reportPath := filepath.Join(exportAppsRelatedFilesPath, utils.MigrationAppsExportReportFileName)
+		limiter := utils.NewRateLimiter(bulkOptions.RateLimit)
+		defer limiter.Stop()
 		for appCount > 0 {
 			utils.Logln(utils.LogPrefixInfo+"Found ", appCount, "of Apps to be exported in the iteration beginning with the offset #"+
 				strconv.Itoa(appListOffset)+". Maximum limit of Apps exported in single iteration is "+
 				strconv.Itoa(utils.MaxAppsToExportOnce))
 			accessToken, preCommandErr := credentials.GetOAuthAccessToken(credential, cmdExportEnvironment)
 			if preCommandErr == nil {
-				for i := startingAppIndexFromList; i < len(apps); i++ {
-                    exportAppAndWriteToZip(apps[i], accessToken, cmdExportEnvironment, appExportDir,
-                        exportAppsRelatedFilesPath, exportAppsFormat, exportAppsWithKeys)
-                    counterSuceededApps++
+				batch := apps[startingAppIndexFromList:]
+				failedBefore := report.Failed
+				utils.RunInParallel(len(batch), bulkOptions.Parallel, func(i int) {
+					exportAppWithLedger(batch[i], accessToken, cmdExportEnvironment, appExportDir, exportAppsFormat,
+						exportAppsWithKeys, bulkOptions, limiter, ledger, report)
+				})
+				if report.Failed > failedBefore {
+					// The offset is not moved forward, so the failed Apps are retried when the command is run again
+					writeBulkOperationReport(report, reportPath, bulkOptions)
+					utils.HandleErrorAndExit(cast.ToString(report.Failed-failedBefore)+" App(s) of the batch "+
+						"could not be exported. Run the command again without --force to resume the export", nil)
 				}
 			} else {
 				// Error getting OAuth tokens
-				fmt.Println("Error getting OAuth Tokens : " + preCommandErr.Error())
+				fmt.Fprintln(out, "Error getting OAuth Tokens : "+preCommandErr.Error())
 			}
-			fmt.Println("Batch of " + cast.ToString(appCount) + " Apps exported successfully..!")
+			fmt.Fprintln(out, "Batch of "+cast.ToString(appCount)+" Apps exported successfully..!")
 
 			appListOffset += utils.MaxAppsToExportOnce
 			appCount, apps = getAppList(credential, cmdExportEnvironment, cmdResourceTenantDomain)
@@ -161,33 +190,69 @@ func ExportApps(credential credentials.Credential, exportAppsRelatedFilesPath, c
 					exportAppsRelatedFilesPath, appListOffset)
 			}
 		}
-		fmt.Println("\nTotal number of Apps exported: " + cast.ToString(counterSuceededApps))
-		fmt.Println("App export path: " + appExportDir)
-		fmt.Println("\nCommand: export-apps execution completed !")
+		writeBulkOperationReport(report, reportPath, bulkOptions)
+		fmt.Fprintln(out, "\nTotal number of Apps exported: "+cast.ToString(report.Succeeded))
+		fmt.Fprintln(out, "App export path: "+appExportDir)
+		fmt.Fprintln(out, "\nCommand: export-apps execution completed !")
 	}
 }
 
+// Export an App unless the ledger shows it has been already exported, and record the outcome in the ledger
+// and the report
+func exportAppWithLedger(app utils.Application, accessToken, cmdExportEnvironment, appExportDir, exportAppsFormat string,
+	exportAppsWithKeys bool, bulkOptions utils.BulkOperationOptions, limiter *utils.RateLimiter,
+	ledger *utils.BulkOperationLedger, report *utils.BulkOperationReport) {
+	key := getAppExportLedgerKey(app)
+	result := utils.BulkArtifactResult{Key: key, Type: "app", Name: app.Name, Owner: app.Owner}
+	if ledger.IsCompleted(key) {
+		utils.Logln(utils.LogPrefixInfo + "Skipping " + key + " as it has been already exported")
+		result.Status = utils.BulkStatusSkipped
+		report.Add(result)
+		return
+	}
+	startTime := time.Now()
+	var err error
+	result.Attempts, err = utils.RunWithRetry(bulkOptions.MaxRetries, func() error {
+		limiter.Wait()
+		return exportAppAndWriteToZip(bulkOptions.MessageWriter(), app, accessToken, cmdExportEnvironment, appExportDir, exportAppsFormat,
+			exportAppsWithKeys)
+	})
+	result.DurationMillis = time.Since(startTime).Milliseconds()
+	result.Status = utils.BulkStatusSucceeded
+	if err != nil {
+		fmt.Fprintln(bulkOptions.MessageWriter(), "Error exporting App:", app.Name, " of Owner:", app.Owner, "-", err)
+		result.Status = utils.BulkStatusFailed
+		result.Error = err.Error()
+	}
+	if ledgerErr := ledger.Record(key, result.Status); ledgerErr != nil {
+		utils.HandleErrorAndExit("Error writing to the export ledger", ledgerErr)
+	}
+	report.Add(result)
+}
+
+// Get the key of an exported App archive in the ledger, ex: SampleApp:admin
+func getAppExportLedgerKey(app utils.Application) string {
+	return app.Name + ":" + app.Owner
+}
+
 // Export the App and archive to zip format
-func exportAppAndWriteToZip(app utils.Application, accessToken, cmdExportEnvironment, appExportDir,
-	exportAppsRelatedFilesPath, exportAppsFormat string, exportAppsWithKeys bool) {
+func exportAppAndWriteToZip(out io.Writer, app utils.Application, accessToken, cmdExportEnvironment, appExportDir,
+	exportAppsFormat string, exportAppsWithKeys bool) error {
 
 	exportAppName := app.Name
 	exportAppOwner := app.Owner
 	resp, err := ExportAppFromEnv(accessToken, exportAppName, exportAppOwner, exportAppsFormat, cmdExportEnvironment,
-	 exportAppsWithKeys)
+		exportAppsWithKeys)
 	if err != nil {
-		utils.HandleErrorAndExit("Error exporting", err)
+		return err
 	}
 
-	if resp.StatusCode() == http.StatusOK {
-		utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
-		WriteApplicationToZip(exportAppName, exportAppOwner, appExportDir, resp)
-		// write on last-succeeded-app.log
-		utils.WriteLastSuceededAppFileData(exportAppsRelatedFilesPath, app)
-	} else {
-		fmt.Println("Error exporting App:", exportAppName, " of Owner:", exportAppOwner)
-		utils.PrintErrorResponseAndExit(resp)
+	if resp.StatusCode() != http.StatusOK {
+		return utils.GetBulkResponseError(resp)
 	}
+	utils.Logf(utils.LogPrefixInfo+"ResponseStatus: %v\n", resp.Status())
+	_, err = WriteApplicationToZip(out, exportAppName, exportAppOwner, appExportDir, resp)
+	return utils.NonRetryable(err)
 }
 
 // Create the required directory structure to save the exported Apps
@@ -25,6 +25,7 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -87,20 +88,22 @@ func ExportThrottlingPolicyFromEnv(accessToken string, exportEnvironment string,
 	return resp, nil
 }
 
-// WriteThrottlePolicyToFile writes the policy to a specified location
-func WriteThrottlePolicyToFile(ExportLocationPath string, resp *resty.Response, ExportFormat string,
-	runningExportThrottlePolicyCommand bool) {
+// WriteThrottlePolicyToFile writes the policy to a specified location and returns the path of the file. The location
+// of the file is printed to out unless it is nil
+func WriteThrottlePolicyToFile(out io.Writer, ExportLocationPath string, resp *resty.Response,
+	ExportFormat string) string {
 	err := utils.CreateDirIfNotExist(ExportLocationPath)
 	if err != nil {
 		utils.HandleErrorAndExit("Error creating dir to store zip archives: "+ExportLocationPath, err)
 	}
 	fileName, marshaledData := resolveThrottlePolicy(ExportFormat, resp)
 	_, _ = throttlingPolicyWrite(ExportLocationPath, fileName, marshaledData)
-	if runningExportThrottlePolicyCommand {
-		fmt.Println("Successfully exported Throttling Policy!")
-		fmt.Println("Find the exported Throttling Policies at " +
-			utils.AppendSlashToString(ExportLocationPath) + fileName)
+	if out != nil {
+		fmt.Fprintln(out, "Successfully exported Throttling Policy!")
+		fmt.Fprintln(out, "Find the exported Throttling Policies at "+
+			utils.AppendSlashToString(ExportLocationPath)+fileName)
 	}
+	return filepath.Join(ExportLocationPath, fileName)
 }
 
 // resolves the policy file name with the policy type
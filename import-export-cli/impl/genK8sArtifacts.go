@@ -0,0 +1,214 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apktransformer "github.com/wso2/product-apim-tooling/apk-transformer"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// GenK8sArtifactsOptions holds the inputs of gen k8s-artifacts
+type GenK8sArtifactsOptions struct {
+	// Source is the API project directory or archive
+	Source string
+	// Destination is the directory the resources are written to
+	Destination string
+	// ParamsFile is the params file or the deployment directory applied to the project as it is applied when
+	// importing the project
+	ParamsFile string
+	// Environment is the environment of the params file to apply
+	Environment string
+	// Resources holds the deployment specific values of the generated resources
+	Resources apktransformer.Options
+}
+
+// GenerateK8sArtifacts generates the APK resources of an API project after applying the params of the
+// environment to it, and writes them to the destination, which is returned. It works offline and does not
+// contact APIM, APK or a Kubernetes cluster.
+func GenerateK8sArtifacts(options GenK8sArtifactsOptions) (*apktransformer.Artifacts, string, error) {
+	projectDir, cleanup, err := resolveK8sArtifactsSource(options.Source)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cleanup()
+
+	project, err := apktransformer.LoadProject(projectDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if options.ParamsFile != "" {
+		if options.Environment == "" {
+			return nil, "", errors.New("environment is required to apply the params")
+		}
+		if err = applyK8sArtifactsParams(project, &options.Resources, options.ParamsFile,
+			options.Environment); err != nil {
+			return nil, "", err
+		}
+	}
+
+	conf, unresolvedPolicies, err := project.APKConf(nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, policy := range unresolvedPolicies {
+		fmt.Printf("Warning: throttling policy %s can not be resolved offline and is not applied to the API\n", policy)
+	}
+
+	artifacts, err := apktransformer.Generate(conf, project.Definition, options.Resources)
+	if err != nil {
+		return nil, "", err
+	}
+	warnK8sArtifactsNotGenerated(conf)
+
+	destination := options.Destination
+	if destination == "" {
+		destination = project.API.Name + "-" + project.API.Version + "-k8s"
+	}
+	utils.Logln(utils.LogPrefixInfo + "Writing the resources to " + destination)
+	if err = artifacts.WriteToDir(destination); err != nil {
+		return nil, "", err
+	}
+	return artifacts, destination, nil
+}
+
+// applyK8sArtifactsParams applies the params of the environment to the project the same way they are applied when
+// importing it. The endpoints, the endpoint security and the policies are applied to the api.yaml, the endpoint and
+// the client certificates are added to the project and the vhost of the first deployment environment is used for
+// the production routes unless a vhost is given.
+func applyK8sArtifactsParams(project *apktransformer.Project, resources *apktransformer.Options, paramsFile,
+	environment string) error {
+	rendered, err := RenderAPIParams(paramsFile, environment, project.Path)
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo + "Applying the params of the environment " + environment)
+	content, err := json.Marshal(rendered.API)
+	if err != nil {
+		return err
+	}
+	if project.API, err = apktransformer.ParseAPI(content); err != nil {
+		return errors.New("error applying the params to the api.yaml: " + err.Error())
+	}
+
+	// the certificates are referred by their file names, which are the keys of the secrets or the config maps
+	// holding them
+	var endpointCerts apktransformer.EndpointCertDescriptor
+	var clientCerts apktransformer.CertDescriptor
+	for _, cert := range rendered.Certificates {
+		if cert.Error != "" {
+			return errors.New("error reading the certificate " + cert.Alias + ": " + cert.Error)
+		}
+		if cert.Type == ParamsCertificateTypeEndpoint {
+			endpointCerts.EndpointCertData = append(endpointCerts.EndpointCertData, apktransformer.EndpointCert{
+				Alias: cert.Alias, Endpoint: cert.HostName, Certificate: filepath.Base(cert.Path)})
+		} else {
+			clientCerts.CertData = append(clientCerts.CertData, apktransformer.ClientCert{
+				Alias: cert.Alias, TierName: cert.TierName, Certificate: filepath.Base(cert.Path)})
+		}
+	}
+	if len(endpointCerts.EndpointCertData) > 0 {
+		if content, err = json.Marshal(endpointCerts); err != nil {
+			return err
+		}
+		project.Certificates.EndpointCerts = string(content)
+	}
+	if len(clientCerts.CertData) > 0 {
+		if content, err = json.Marshal(clientCerts); err != nil {
+			return err
+		}
+		project.Certificates.ClientCerts = string(content)
+	}
+
+	if resources.ProductionVhost == "" {
+		for _, deploymentEnvironment := range rendered.DeploymentEnvironments {
+			deployment, _ := deploymentEnvironment.(map[string]interface{})
+			if vhost := getStringValue(deployment, "deploymentVhost"); vhost != "" {
+				resources.ProductionVhost = vhost
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// warnK8sArtifactsNotGenerated prints the secrets and the config maps holding the certificates and the credentials
+// which the resources refer to, and the policies of the API which are not generated
+func warnK8sArtifactsNotGenerated(conf *apktransformer.APKConf) {
+	endpoints := append(append([]apktransformer.EndpointConfiguration{}, conf.EndpointConfigurations.Production...),
+		conf.EndpointConfigurations.Sandbox...)
+	for _, endpoint := range endpoints {
+		if endpoint.Certificate != nil {
+			fmt.Printf("Warning: create the secret %s with the certificate %s of the endpoint %s\n",
+				endpoint.Certificate.SecretName, endpoint.Certificate.SecretKey, endpoint.Endpoint)
+		}
+		if endpoint.Security != nil && endpoint.Security.Enabled {
+			fmt.Printf("Warning: create the secret %s with the credentials of the endpoint %s\n",
+				endpoint.Security.SecurityType.SecretName, endpoint.Endpoint)
+		}
+	}
+	for _, auth := range conf.Authentication {
+		for _, cert := range auth.Certificates {
+			fmt.Printf("Warning: create the config map %s with the client certificate %s\n", cert.Name, cert.Key)
+		}
+	}
+	hasPolicies := conf.APIPolicies != nil
+	for _, operation := range conf.Operations {
+		hasPolicies = hasPolicies || operation.OperationPolicies != nil
+	}
+	if hasPolicies {
+		fmt.Println("Warning: the operation policies of the API are not generated")
+	}
+}
+
+// resolveK8sArtifactsSource returns the project directory of the source, extracting it to a temporary
+// directory if it is an archive, and a function removing the extracted files
+func resolveK8sArtifactsSource(source string) (string, func(), error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", nil, err
+	}
+	if info.IsDir() {
+		return source, func() {}, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "apictl-k8s-artifacts")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tempDir) }
+	files, err := utils.Unzip(source, tempDir)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if len(files) == 0 {
+		cleanup()
+		return "", nil, errors.New(source + " is empty")
+	}
+	// the project is the top level directory of the archive
+	return filepath.Join(tempDir, strings.SplitN(filepath.ToSlash(files[0]), "/", 2)[0]), cleanup, nil
+}
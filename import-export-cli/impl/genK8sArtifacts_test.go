@@ -0,0 +1,171 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apktransformer "github.com/wso2/product-apim-tooling/apk-transformer"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const k8sArtifactsTestProject = "../cmd/testdata/PizzaShackAPI-1.0.0"
+
+const k8sArtifactsTestParams = `environments:
+  - name: dev
+    configs:
+      endpoints:
+        production:
+          url: http://pizzashack.dev.svc:8080/api
+`
+
+const k8sArtifactsTestDeploymentParams = `environments:
+  - name: prod
+    configs:
+      endpoints:
+        production:
+          url: https://pizzashack.prod.svc:8443/api
+      security:
+        production:
+          enabled: true
+          type: basic
+          username: admin
+          password: admin
+      certs:
+        - hostName: https://pizzashack.prod.svc:8443/api
+          alias: pizzashack
+          path: pizzashack.crt
+      deploymentEnvironments:
+        - deploymentEnvironment: Default
+          deploymentVhost: prod.pizza.com
+`
+
+func readK8sArtifactsTestBackend(t *testing.T, dir, uniqueID, env string) map[string]interface{} {
+	content, err := os.ReadFile(filepath.Join(dir, "backend-"+uniqueID+"-"+env+"-backend.yaml"))
+	require.NoError(t, err)
+	var backend map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(content, &backend))
+	return backend
+}
+
+func TestGenerateK8sArtifacts(t *testing.T) {
+	destination := filepath.Join(t.TempDir(), "pizzashack")
+	artifacts, dir, err := GenerateK8sArtifacts(GenK8sArtifactsOptions{
+		Source:      k8sArtifactsTestProject,
+		Destination: destination,
+		Resources:   apktransformer.Options{Namespace: "apk"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, destination, dir)
+	assert.Equal(t, apktransformer.GetUniqueIDForAPI("PizzaShackAPI", "1.0.0", "default"), artifacts.UniqueID)
+
+	for _, resource := range artifacts.Resources {
+		assert.FileExists(t, filepath.Join(destination, resource.FileName()))
+		assert.Equal(t, "apk", resource.Metadata.Namespace)
+	}
+	assert.FileExists(t, filepath.Join(destination, apktransformer.KustomizationFile))
+
+	backend := readK8sArtifactsTestBackend(t, destination, artifacts.UniqueID, "production")
+	spec := backend["spec"].(map[interface{}]interface{})
+	assert.Equal(t, "localhost", spec["services"].([]interface{})[0].(map[interface{}]interface{})["host"])
+}
+
+func TestGenerateK8sArtifactsWithParams(t *testing.T) {
+	paramsFile := filepath.Join(t.TempDir(), "params.yaml")
+	require.NoError(t, os.WriteFile(paramsFile, []byte(k8sArtifactsTestParams), 0644))
+	destination := t.TempDir()
+
+	artifacts, _, err := GenerateK8sArtifacts(GenK8sArtifactsOptions{
+		Source:      k8sArtifactsTestProject,
+		Destination: destination,
+		ParamsFile:  paramsFile,
+		Environment: "dev",
+	})
+	require.NoError(t, err)
+
+	spec := readK8sArtifactsTestBackend(t, destination, artifacts.UniqueID, "production")["spec"].(map[interface{}]interface{})
+	service := spec["services"].([]interface{})[0].(map[interface{}]interface{})
+	assert.Equal(t, "pizzashack.dev.svc", service["host"])
+	assert.Equal(t, 8080, service["port"])
+	assert.Equal(t, "http", spec["protocol"])
+	// the params do not override the sandbox endpoint, hence the sandbox routes are not generated
+	_, err = os.Stat(filepath.Join(destination, "backend-"+artifacts.UniqueID+"-sandbox-backend.yaml"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGenerateK8sArtifactsWithDeploymentParams(t *testing.T) {
+	deploymentDir := filepath.Join(t.TempDir(), "DeploymentArtifacts_PizzaShackAPI-1.0.0")
+	require.NoError(t, os.MkdirAll(filepath.Join(deploymentDir, utils.DeploymentCertificatesDirectory), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(deploymentDir, utils.ParamFile),
+		[]byte(k8sArtifactsTestDeploymentParams), 0644))
+	writeTestCertificate(t, filepath.Join(deploymentDir, utils.DeploymentCertificatesDirectory, "pizzashack.crt"))
+	destination := t.TempDir()
+
+	artifacts, _, err := GenerateK8sArtifacts(GenK8sArtifactsOptions{
+		Source:      k8sArtifactsTestProject,
+		Destination: destination,
+		ParamsFile:  deploymentDir,
+		Environment: "prod",
+	})
+	require.NoError(t, err)
+
+	spec := readK8sArtifactsTestBackend(t, destination, artifacts.UniqueID, "production")["spec"].(map[interface{}]interface{})
+	assert.Equal(t, "https", spec["protocol"])
+	tls := spec["tls"].(map[interface{}]interface{})["secretRef"].(map[interface{}]interface{})
+	assert.Equal(t, "pizzashack", tls["name"])
+	assert.Equal(t, "pizzashack.crt", tls["key"])
+	basic := spec["security"].(map[interface{}]interface{})["basic"].(map[interface{}]interface{})
+	assert.NotEmpty(t, basic["secretRef"].(map[interface{}]interface{})["name"])
+
+	// the vhost of the deployment environment is used for the routes, the params have no sandbox endpoint
+	for _, resource := range artifacts.Resources {
+		if resource.Kind != apktransformer.KindHTTPRoute {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(destination, resource.FileName()))
+		require.NoError(t, err)
+		var route map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(content, &route))
+		assert.Equal(t, []interface{}{"prod.pizza.com"}, route["spec"].(map[interface{}]interface{})["hostnames"])
+	}
+}
+
+func TestGenerateK8sArtifactsFromArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "PizzaShackAPI-1.0.0.zip")
+	require.NoError(t, utils.Zip(k8sArtifactsTestProject, archive))
+	destination := t.TempDir()
+
+	artifacts, _, err := GenerateK8sArtifacts(GenK8sArtifactsOptions{Source: archive, Destination: destination})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(destination, "api-"+artifacts.UniqueID+".yaml"))
+}
+
+func TestGenerateK8sArtifactsParamsWithoutEnvironment(t *testing.T) {
+	_, _, err := GenerateK8sArtifacts(GenK8sArtifactsOptions{
+		Source:      k8sArtifactsTestProject,
+		Destination: t.TempDir(),
+		ParamsFile:  "params.yaml",
+	})
+	assert.Error(t, err)
+}
@@ -124,6 +124,9 @@ func PrintAPIPolicies(resp *resty.Response, format string) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error unmarshalling response data", err)
 	}
+	if utils.PrintStructuredOutput(policies, format) {
+		return
+	}
 	if format == "" {
 		format = defaultAPIPolicyTableFormat
 		// create policy context with standard output
@@ -103,6 +103,9 @@ func GetRevisionListFromEnv(accessToken, environment, apiName, apiVersion, provi
 // @param revisions	Available revisions list for the API
 // @param format	Format type of the output
 func PrintRevisions(revisions []utils.Revisions, format string) {
+	if utils.PrintStructuredOutput(revisions, format) {
+		return
+	}
 	if format == "" {
 		format = defaultRevisionTableFormat
 	} else if format == utils.JsonArrayFormatType {
@@ -99,6 +99,9 @@ func GetAPIProductListFromEnv(accessToken, environment, query, limit string) (co
 
 // PrintAPIProducts
 func PrintAPIProducts(apiProducts []utils.APIProduct, format string) {
+	if utils.PrintStructuredOutput(apiProducts, format) {
+		return
+	}
 	if format == "" {
 		format = defaultApiProductTableFormat
 	} else if format == utils.JsonArrayFormatType {
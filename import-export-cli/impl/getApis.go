@@ -107,6 +107,9 @@ func GetAPIListFromEnv(accessToken, environment, query, limit string) (count int
 
 // PrintAPIs
 func PrintAPIs(apis []utils.API, format string) {
+	if utils.PrintStructuredOutput(apis, format) {
+		return
+	}
 	if format == "" {
 		format = defaultApiTableFormat
 	} else if format == utils.JsonArrayFormatType {
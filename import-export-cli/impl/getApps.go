@@ -110,6 +110,9 @@ func extractAppDefinition(jsonContent []byte) (*v2.ApplicationDefinition, error)
 
 // PrintApps
 func PrintApps(apps []utils.Application, format string) {
+	if utils.PrintStructuredOutput(apps, format) {
+		return
+	}
 	if format == "" {
 		format = defaultAppTableFormat
 	} else if format == utils.JsonArrayFormatType {
@@ -0,0 +1,119 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	deploymentRevisionHeader     = "REVISION"
+	deploymentRevisionIdHeader   = "REVISION ID"
+	deploymentGatewayEnvHeader   = "GATEWAY ENVIRONMENT"
+	deploymentVhostHeader        = "VHOST"
+	deploymentStatusHeader       = "STATUS"
+	deploymentDeployedTimeHeader = "DEPLOYED TIME"
+
+	defaultDeploymentTableFormat = "table {{.Revision}}\t{{.RevisionId}}\t{{.GatewayEnvironment}}\t{{.Vhost}}\t" +
+		"{{.Status}}\t{{.DeployedTime}}"
+)
+
+// GetDeploymentListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the deployments
+// @param apiName : Name of the API
+// @param apiVersion : Version of the API
+// @param provider : Provider of the API
+// @return array of deployment entries of the deployed revisions
+// @return error
+func GetDeploymentListFromEnv(accessToken, environment, apiName, apiVersion,
+	provider string) ([]utils.DeploymentEntry, error) {
+	_, revisions, err := GetRevisionListFromEnv(accessToken, environment, apiName, apiVersion, provider,
+		"deployed:true")
+	if err != nil {
+		return nil, err
+	}
+	return GetDeploymentsOfRevisions(revisions), nil
+}
+
+// GetDeploymentsOfRevisions flattens the deployment info of the given revisions
+// @param revisions : Revisions of an API
+// @return array of deployment entries, one per gateway environment a revision is deployed to
+func GetDeploymentsOfRevisions(revisions []utils.Revisions) []utils.DeploymentEntry {
+	deployments := []utils.DeploymentEntry{}
+	for _, r := range revisions {
+		for _, d := range r.Deployments {
+			deployments = append(deployments, utils.DeploymentEntry{
+				RevisionId:         r.ID,
+				Revision:           r.RevisionNumber,
+				GatewayEnvironment: d.Name,
+				Vhost:              d.Vhost,
+				DisplayOnDevportal: d.DisplayOnDevportal,
+				Status:             d.Status,
+				DeployedTime:       d.DeployedTime,
+			})
+		}
+	}
+	return deployments
+}
+
+// PrintDeployments prints the deployment list in a specific format
+func PrintDeployments(deployments []utils.DeploymentEntry, format string) {
+	if utils.PrintStructuredOutput(deployments, format) {
+		return
+	}
+	if format == "" {
+		format = defaultDeploymentTableFormat
+	}
+
+	// create deployment context with standard output
+	deploymentContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, d := range deployments {
+			if err := t.Execute(w, d); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	deploymentTableHeaders := map[string]string{
+		"Revision":           deploymentRevisionHeader,
+		"RevisionId":         deploymentRevisionIdHeader,
+		"GatewayEnvironment": deploymentGatewayEnvHeader,
+		"Vhost":              deploymentVhostHeader,
+		"Status":             deploymentStatusHeader,
+		"DeployedTime":       deploymentDeployedTimeHeader,
+	}
+
+	// execute context
+	if err := deploymentContext.Write(renderer, deploymentTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
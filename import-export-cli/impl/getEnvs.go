@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"text/template"
 
 	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
@@ -111,6 +112,19 @@ func (e *endpoints) MarshalJSON() ([]byte, error) {
 
 // PrintEnvs
 func PrintEnvs(envData map[string]utils.EnvEndpoints, format, defaulEnvsTableFormat string) {
+	if formatter.Format(format).IsStructured() {
+		names := make([]string, 0, len(envData))
+		for name := range envData {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		envs := make([]*endpoints, 0, len(names))
+		for _, name := range names {
+			envs = append(envs, newEndpointFromEnvEndpoints(name, envData[name]))
+		}
+		utils.PrintStructuredOutput(envs, format)
+		return
+	}
 	if format == "" {
 		format = defaulEnvsTableFormat
 	}
@@ -0,0 +1,127 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	gatewayEnvIdHeader          = "ID"
+	gatewayEnvNameHeader        = "NAME"
+	gatewayEnvDisplayNameHeader = "DISPLAY NAME"
+	gatewayEnvTypeHeader        = "TYPE"
+	gatewayEnvGatewayTypeHeader = "GATEWAY TYPE"
+	gatewayEnvVhostsHeader      = "VHOSTS"
+
+	defaultGatewayEnvTableFormat = "table {{.ID}}\t{{.Name}}\t{{.DisplayName}}\t{{.Type}}\t{{.GatewayType}}\t{{.Hosts}}"
+)
+
+// gatewayEnvironment holds information about a gateway environment for outputting
+type gatewayEnvironment struct {
+	utils.GatewayEnvironment
+}
+
+// Hosts of the vhosts of the gateway environment
+func (g gatewayEnvironment) Hosts() string {
+	hosts := make([]string, 0, len(g.Vhosts))
+	for _, v := range g.Vhosts {
+		hosts = append(hosts, v.Host)
+	}
+	return strings.Join(hosts, ",")
+}
+
+// GetGatewayEnvironmentListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the gateway environments
+// @return count (no. of gateway environments)
+// @return array of gateway environments
+// @return error
+func GetGatewayEnvironmentListFromEnv(accessToken, environment string) (count int,
+	gatewayEnvironments []utils.GatewayEnvironment, err error) {
+	gatewayEnvListEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/environments"
+	return GetGatewayEnvironmentList(accessToken, gatewayEnvListEndpoint)
+}
+
+// GetGatewayEnvironmentList Get the list of gateway environments from the Admin REST API
+// @param accessToken : Access Token for the environment
+// @param gatewayEnvListEndpoint : Gateway environment list endpoint
+// @return count (no. of gateway environments)
+// @return array of gateway environments
+// @return error
+func GetGatewayEnvironmentList(accessToken, gatewayEnvListEndpoint string) (count int,
+	gatewayEnvironments []utils.GatewayEnvironment, err error) {
+	body, err := invokeListRequest(accessToken, gatewayEnvListEndpoint, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	gatewayEnvList := &utils.GatewayEnvironmentList{}
+	if err := json.Unmarshal(body, gatewayEnvList); err != nil {
+		return 0, nil, errors.New("invalid JSON response: " + err.Error())
+	}
+	return gatewayEnvList.Count, gatewayEnvList.List, nil
+}
+
+// PrintGatewayEnvironments prints the gateway environment list in a specific format
+func PrintGatewayEnvironments(gatewayEnvironments []utils.GatewayEnvironment, format string) {
+	if utils.PrintStructuredOutput(gatewayEnvironments, format) {
+		return
+	}
+	if format == "" {
+		format = defaultGatewayEnvTableFormat
+	}
+
+	// create gateway environment context with standard output
+	gatewayEnvContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, g := range gatewayEnvironments {
+			if err := t.Execute(w, gatewayEnvironment{g}); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	gatewayEnvTableHeaders := map[string]string{
+		"ID":          gatewayEnvIdHeader,
+		"Name":        gatewayEnvNameHeader,
+		"DisplayName": gatewayEnvDisplayNameHeader,
+		"Type":        gatewayEnvTypeHeader,
+		"GatewayType": gatewayEnvGatewayTypeHeader,
+		"Hosts":       gatewayEnvVhostsHeader,
+	}
+
+	// execute context
+	if err := gatewayEnvContext.Write(renderer, gatewayEnvTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,107 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	keyManagerIdHeader      = "ID"
+	keyManagerNameHeader    = "NAME"
+	keyManagerTypeHeader    = "TYPE"
+	keyManagerEnabledHeader = "ENABLED"
+
+	defaultKeyManagerTableFormat = "table {{.ID}}\t{{.Name}}\t{{.Type}}\t{{.Enabled}}"
+)
+
+// GetKeyManagerListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the key managers
+// @return count (no. of key managers)
+// @return array of key managers
+// @return error
+func GetKeyManagerListFromEnv(accessToken, environment string) (count int, keyManagers []utils.KeyManager,
+	err error) {
+	keyManagerListEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/key-managers"
+	return GetKeyManagerList(accessToken, keyManagerListEndpoint)
+}
+
+// GetKeyManagerList Get the list of key managers from the Admin REST API
+// @param accessToken : Access Token for the environment
+// @param keyManagerListEndpoint : Key manager list endpoint
+// @return count (no. of key managers)
+// @return array of key managers
+// @return error
+func GetKeyManagerList(accessToken, keyManagerListEndpoint string) (count int, keyManagers []utils.KeyManager,
+	err error) {
+	body, err := invokeListRequest(accessToken, keyManagerListEndpoint, "")
+	if err != nil {
+		return 0, nil, err
+	}
+	keyManagerList := &utils.KeyManagerList{}
+	if err := json.Unmarshal(body, keyManagerList); err != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", err)
+	}
+	return keyManagerList.Count, keyManagerList.List, nil
+}
+
+// PrintKeyManagers prints the key manager list in a specific format
+func PrintKeyManagers(keyManagers []utils.KeyManager, format string) {
+	if utils.PrintStructuredOutput(keyManagers, format) {
+		return
+	}
+	if format == "" {
+		format = defaultKeyManagerTableFormat
+	}
+
+	// create key manager context with standard output
+	keyManagerContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, k := range keyManagers {
+			if err := t.Execute(w, k); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	keyManagerTableHeaders := map[string]string{
+		"ID":      keyManagerIdHeader,
+		"Name":    keyManagerNameHeader,
+		"Type":    keyManagerTypeHeader,
+		"Enabled": keyManagerEnabledHeader,
+	}
+
+	// execute context
+	if err := keyManagerContext.Write(renderer, keyManagerTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -40,8 +40,33 @@ var apiProvider string
 var keyGenEnv string
 var keyGenTokenEndpoint string
 
-//Subscribe the given API or API Product to the default application and generate an access token
-func GetKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint string) {
+// KeysResult is the result of the get keys command printed in a structured output format
+type KeysResult struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+	Environment string `json:"environment"`
+	AccessToken string `json:"accessToken"`
+}
+
+//Subscribe the given API or API Product to the default application and print an access token. The token is printed
+//as a KeysResult if format is a structured output format
+func GetKeys(cred credentials.Credential, envName, name, version, provider, tokenEndpoint, format string) {
+	accessToken, err := GenerateAccessTokenOfAPI(cred, envName, name, version, provider, tokenEndpoint)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting keys", err)
+	}
+	result := &KeysResult{Name: name, Version: version, Provider: provider, Environment: envName,
+		AccessToken: accessToken}
+	if !utils.PrintStructuredOutput(result, format) {
+		fmt.Println(accessToken)
+	}
+}
+
+//Subscribe the given API or API Product to the default application and return an access token to invoke it, or an
+//error if the token cannot be generated
+func GenerateAccessTokenOfAPI(cred credentials.Credential, envName, name, version, provider,
+	tokenEndpoint string) (string, error) {
 	keyGenEnv = envName
 	apiName = name
 	apiVersion = version
@@ -51,7 +76,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 	//generating access token for the env based on the credentials
 	accessToken, err := credentials.GetOAuthAccessToken(cred, keyGenEnv)
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", newKeysError("Internal error occurred", err)
 	}
 	utils.Logln(utils.LogPrefixInfo + "Generated a token to access the Publisher and DevPortal REST APIs.")
 	//retrieving subscription tiers
@@ -62,19 +87,19 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 		// Needs an available subscription tier when subscribing to the particular API or API Product using the application
 		subscriptionThrottlingTier = tiers[0]
 	} else {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", newKeysError("Internal error occurred", err)
 	}
 	// Retrieving application throttling policy
 	applicationThrottlingPolicy, err := getApplicationThrottlingPolicy(accessToken)
-	// If the application throttling policy call fails, exit with the error
+	// If the application throttling policy call fails, return the error
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", newKeysError("Internal error occurred", err)
 	}
 	utils.Logln(utils.LogPrefixInfo+"Retrieved application throttling policy successfully: ", applicationThrottlingPolicy)
 	//search if the default cli application already exists
 	appId, err := searchApplication(utils.DefaultCliApp, accessToken)
 	if err != nil {
-		utils.HandleErrorAndExit("Internal error occurred", err)
+		return "", newKeysError("Internal error occurred", err)
 	}
 	utils.Logln(utils.LogPrefixInfo + "Searched if application exists.")
 	//if the application exists
@@ -84,7 +109,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 		subId, err := subscribe(appId, accessToken)
 		// If subscription fails
 		if subId == "" && err != nil {
-			utils.HandleErrorAndExit("Error occurred while subscribing.", err)
+			return "", newKeysError("Error occurred while subscribing.", err)
 		}
 
 		scopes, err := getScopes(appId, accessToken)
@@ -98,7 +123,7 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 			//retrieve keys of application to see if there are already generated keys
 			appKeys, keysErr := getApplicationKeys(appId, accessToken)
 			if keysErr != nil {
-				utils.HandleErrorAndExit("Error occurred while getting CLI application keys.", keysErr)
+				return "", newKeysError("Error occurred while getting CLI application keys.", keysErr)
 			}
 
 			//if keys have been already generated before, then update the consumer key and secret
@@ -107,26 +132,25 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 				token, err := getNewToken(&appKeys.List[0], scopes)
 				//Assert token endpoint related fails and errors
 				if err != nil {
-					utils.HandleErrorAndExit("Error while generating token. ", err)
+					return "", newKeysError("Error while generating token. ", err)
 				}
 
-				if accessToken != "" {
-					// Access Token generated successfully.
-					fmt.Println(token)
-				} else {
-					utils.HandleErrorAndExit("Error while generating token: ", err)
+				if token == "" {
+					return "", newKeysError("Error while generating token: ", err)
 				}
+				// Access Token generated successfully.
+				return token, nil
 			} else {
 				//If the application is already created but the keys have not generated in the first time
 				keygenResponse, err := generateApplicationKeys(appId, accessToken)
 				if keygenResponse == nil && err != nil {
-					utils.HandleErrorAndExit("Error occurred while generating CLI application keys.", err)
+					return "", newKeysError("Error occurred while generating CLI application keys.", err)
 				}
 				// Access Token generated successfully.
-				fmt.Println(keygenResponse.Token.AccessToken)
+				return keygenResponse.Token.AccessToken, nil
 			}
 		} else {
-			utils.HandleErrorAndExit("Error while retrieving the CLI application:", err)
+			return "", newKeysError("Error while retrieving the CLI application:", err)
 		}
 	} else {
 		//If the default cli appId does not exist in the environment
@@ -137,37 +161,44 @@ func GetKeys(cred credentials.Credential, envName, name, version, provider, toke
 			utils.Logln(utils.LogPrefixInfo+"Created CLI application: ", appName)
 		} else {
 			//if error occurred while creating the application, then
-			utils.HandleErrorAndExit("Error while creating the CLI application:", err)
+			return "", newKeysError("Error while creating the CLI application:", err)
 		}
 		//Search the if the given API or API Product is present to subscribe
 		subId, err := subscribe(appId, accessToken)
 		//If subscription failed
 		if subId == "" && err != nil {
-			utils.HandleErrorAndExit("Error occurred while subscribing.", err)
+			return "", newKeysError("Error occurred while subscribing.", err)
 		}
 		scopes, err := getScopes(appId, accessToken)
 		//If errors occurred while retrieving scopes
 		if scopes == nil && err != nil {
-			utils.HandleErrorAndExit("Error while retrieving scopes ", err)
+			return "", newKeysError("Error while retrieving scopes ", err)
 		}
 		//Generate the tokens
 		keygenResponse, err := generateApplicationKeys(appId, accessToken)
 		if err != nil {
-			utils.HandleErrorAndExit("Error while generating CLI application keys", err)
+			return "", newKeysError("Error while generating CLI application keys", err)
 		}
 		appKey := &utils.ApplicationKey{}
 		appKey.ConsumerKey = keygenResponse.ConsumerKey
 		appKey.ConsumerSecret = keygenResponse.ConsumerSecret
 		token, err := getNewToken(appKey, scopes)
-		if token != "" {
-			// Access Token generated successfully.
-			fmt.Println(token)
-		} else {
-			utils.HandleErrorAndExit("Error while generating token: ", err)
+		if token == "" {
+			return "", newKeysError("Error while generating token: ", err)
 		}
+		// Access Token generated successfully.
+		return token, nil
 	}
 }
 
+
+// newKeysError returns an error of a failed step of generating keys with the cause of the failure, if there is one
+func newKeysError(message string, err error) error {
+	if err == nil {
+		return errors.New(strings.TrimSpace(message))
+	}
+	return errors.New(strings.TrimSpace(message) + " " + err.Error())
+}
 // Retrieve an available throttling tiers of the API or API Product
 // @param accessToken : Access token to authenticate the devportal REST API
 // @return tiers, error
@@ -392,7 +423,7 @@ func subscribe(appId string, accessToken string) (string, error) {
 		if subId != "" {
 			utils.Logln(utils.LogPrefixInfo+"API or API Product", apiName, ":", apiVersion, "subscribed successfully.")
 		} else {
-			utils.HandleErrorAndExit("Error while subscribing the CLI application to the API: "+appId, err)
+			return "", newKeysError("Error while subscribing the CLI application to the API: "+appId, err)
 		}
 		return subId, err
 	} else {
@@ -481,7 +512,7 @@ func subscribeApiOrProduct(apiId string, appId string, accessToken string) (stri
 		//If there is no subscription, make a subscription
 		body, err := json.Marshal(subscriptionReq)
 		if body == nil && err != nil {
-			utils.HandleErrorAndExit("Error occurred while creating CLI application subscription request.", err)
+			return "", newKeysError("Error occurred while creating CLI application subscription request.", err)
 		}
 		resp, err := utils.InvokePOSTRequest(subEndpoint, headers, string(body))
 		if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
@@ -623,7 +654,7 @@ func createApplication(accessToken string, throttlingPolicy string) (string, str
 	}
 	body, err := json.Marshal(appUpdateReq)
 	if body == nil && err != nil {
-		utils.HandleErrorAndExit("Error occurred while creating CLI application update request.", err)
+		return "", "", newKeysError("Error occurred while creating CLI application update request.", err)
 	}
 	resp, err := utils.InvokePOSTRequest(applicationEndpoint, headers, string(body))
 	if resp.StatusCode() == http.StatusOK || resp.StatusCode() == http.StatusCreated {
@@ -732,7 +763,7 @@ func generateApplicationKeys(appId string, token string) (*utils.KeygenResponse,
 	}
 	body, err := json.Marshal(generateKeyReq)
 	if body == nil && err != nil {
-		utils.HandleErrorAndExit("Error occurred while creating CLI application key generation request.", err)
+		return nil, newKeysError("Error occurred while creating CLI application key generation request.", err)
 	}
 
 	resp, err := utils.InvokePOSTRequest(applicationEndpoint, headers, string(body))
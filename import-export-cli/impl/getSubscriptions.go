@@ -0,0 +1,239 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	subscriptionIdHeader               = "ID"
+	subscriptionApplicationHeader      = "APPLICATION"
+	subscriptionApplicationOwnerHeader = "OWNER"
+	subscriptionApiHeader              = "API"
+	subscriptionApiVersionHeader       = "VERSION"
+	subscriptionPolicyHeader           = "POLICY"
+	subscriptionStatusHeader           = "STATUS"
+
+	defaultSubscriptionTableFormat = "table {{.Id}}\t{{.ApplicationName}}\t{{.ApplicationOwner}}\t{{.ApiName}}\t" +
+		"{{.ApiVersion}}\t{{.ThrottlingPolicy}}\t{{.Status}}"
+)
+
+// GetAPISubscriptionListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the subscription list
+// @param apiName : Name of the API
+// @param apiVersion : Version of the API
+// @param provider : Provider of the API
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of subscriptions)
+// @return array of subscription entries
+// @return error
+func GetAPISubscriptionListFromEnv(accessToken, environment, apiName, apiVersion, provider, limit,
+	offset string) (count int, subscriptions []utils.SubscriptionEntry, err error) {
+	apiId, err := GetAPIId(accessToken, environment, apiName, apiVersion, provider)
+	if err != nil {
+		return 0, nil, err
+	}
+	subscriptionListEndpoint := utils.GetPublisherEndpointOfEnv(environment, utils.MainConfigFilePath) + "/subscriptions"
+	count, subscriptions, err = GetAPISubscriptionList(accessToken, subscriptionListEndpoint, apiId, limit, offset)
+	for i := range subscriptions {
+		subscriptions[i].ApiName = apiName
+		subscriptions[i].ApiVersion = apiVersion
+	}
+	return count, subscriptions, err
+}
+
+// GetApplicationSubscriptionListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the subscription list
+// @param appName : Name of the Application
+// @param appOwner : Owner of the Application
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of subscriptions)
+// @return array of subscription entries
+// @return error
+func GetApplicationSubscriptionListFromEnv(accessToken, environment, appName, appOwner, limit,
+	offset string) (count int, subscriptions []utils.SubscriptionEntry, err error) {
+	appId, err := GetAppId(accessToken, environment, appName, appOwner)
+	if err != nil {
+		return 0, nil, err
+	}
+	if appId == "" {
+		return 0, nil, errors.New("Cannot find the application: " + appName)
+	}
+	subscriptionListEndpoint := utils.GetDevPortalSubscriptionListEndpointOfEnv(environment, utils.MainConfigFilePath)
+	return GetApplicationSubscriptionList(accessToken, subscriptionListEndpoint, appId, limit, offset)
+}
+
+// GetAPISubscriptionList Get the list of subscriptions of an API from the Publisher REST API
+// @param accessToken : Access Token for the environment
+// @param subscriptionListEndpoint : Subscription list endpoint of the Publisher
+// @param apiId : UUID of the API
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of subscriptions)
+// @return array of subscription entries
+// @return error
+func GetAPISubscriptionList(accessToken, subscriptionListEndpoint, apiId, limit,
+	offset string) (count int, subscriptions []utils.SubscriptionEntry, err error) {
+	queryParams := getPagingQueryParams(limit, offset)
+	queryParams.Set("apiId", apiId)
+	body, err := invokeListRequest(accessToken, subscriptionListEndpoint, queryParams.Encode())
+	if err != nil {
+		return 0, nil, err
+	}
+	subscriptionList := &utils.PublisherSubscriptionList{}
+	if err := json.Unmarshal(body, subscriptionList); err != nil {
+		return 0, nil, errors.New("invalid JSON response: " + err.Error())
+	}
+	for _, s := range subscriptionList.List {
+		owner := s.ApplicationInfo.Owner
+		if owner == "" {
+			owner = s.ApplicationInfo.Subscriber
+		}
+		subscriptions = append(subscriptions, utils.SubscriptionEntry{
+			Id:               s.SubscriptionID,
+			ApplicationId:    s.ApplicationInfo.ApplicationID,
+			ApplicationName:  s.ApplicationInfo.Name,
+			ApplicationOwner: owner,
+			ApiId:            apiId,
+			ThrottlingPolicy: s.ThrottlingPolicy,
+			Status:           s.SubscriptionStatus,
+		})
+	}
+	return subscriptionList.Count, subscriptions, nil
+}
+
+// GetApplicationSubscriptionList Get the list of subscriptions of an Application from the Developer Portal REST API
+// @param accessToken : Access Token for the environment
+// @param subscriptionListEndpoint : Subscription list endpoint of the Developer Portal
+// @param appId : UUID of the Application
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of subscriptions)
+// @return array of subscription entries
+// @return error
+func GetApplicationSubscriptionList(accessToken, subscriptionListEndpoint, appId, limit,
+	offset string) (count int, subscriptions []utils.SubscriptionEntry, err error) {
+	queryParams := getPagingQueryParams(limit, offset)
+	queryParams.Set("applicationId", appId)
+	body, err := invokeListRequest(accessToken, subscriptionListEndpoint, queryParams.Encode())
+	if err != nil {
+		return 0, nil, err
+	}
+	subscriptionList := &utils.SubscriptionList{}
+	if err := json.Unmarshal(body, subscriptionList); err != nil {
+		return 0, nil, errors.New("invalid JSON response: " + err.Error())
+	}
+	for _, s := range subscriptionList.List {
+		subscriptions = append(subscriptions, utils.SubscriptionEntry{
+			Id:               s.SubscriptionID,
+			ApplicationId:    s.ApplicationID,
+			ApplicationName:  s.ApplicationInfo.Name,
+			ApplicationOwner: s.ApplicationInfo.Owner,
+			ApiId:            s.APIID,
+			ApiName:          s.APIInfo.Name,
+			ApiVersion:       s.APIInfo.Version,
+			ThrottlingPolicy: s.ThrottlingPolicy,
+			Status:           s.Status,
+		})
+	}
+	return subscriptionList.Count, subscriptions, nil
+}
+
+// getPagingQueryParams returns the limit and offset query params of a paginated list request
+func getPagingQueryParams(limit, offset string) url.Values {
+	queryParams := url.Values{}
+	if limit != "" {
+		queryParams.Set("limit", limit)
+	}
+	if offset != "" {
+		queryParams.Set("offset", offset)
+	}
+	return queryParams
+}
+
+// invokeListRequest invokes a GET request on a list endpoint and returns the body of a 200 OK response
+func invokeListRequest(accessToken, listEndpoint, queryParamString string) ([]byte, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	utils.Logln(utils.LogPrefixInfo+"URL:", listEndpoint+"?"+queryParamString)
+	resp, err := utils.InvokeGETRequestWithQueryParamsString(listEndpoint, queryParamString, headers)
+	if err != nil {
+		return nil, errors.New("Unable to connect to " + listEndpoint + ": " + err.Error())
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if resp.StatusCode() != http.StatusOK {
+		return nil, errors.New(string(resp.Body()))
+	}
+	return resp.Body(), nil
+}
+
+// PrintSubscriptions prints the subscription list in a specific format
+func PrintSubscriptions(subscriptions []utils.SubscriptionEntry, format string) {
+	if utils.PrintStructuredOutput(subscriptions, format) {
+		return
+	}
+	if format == "" {
+		format = defaultSubscriptionTableFormat
+	}
+
+	// create subscription context with standard output
+	subscriptionContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, s := range subscriptions {
+			if err := t.Execute(w, s); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	subscriptionTableHeaders := map[string]string{
+		"Id":               subscriptionIdHeader,
+		"ApplicationName":  subscriptionApplicationHeader,
+		"ApplicationOwner": subscriptionApplicationOwnerHeader,
+		"ApiName":          subscriptionApiHeader,
+		"ApiVersion":       subscriptionApiVersionHeader,
+		"ThrottlingPolicy": subscriptionPolicyHeader,
+		"Status":           subscriptionStatusHeader,
+	}
+
+	// execute context
+	if err := subscriptionContext.Write(renderer, subscriptionTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
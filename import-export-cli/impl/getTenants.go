@@ -0,0 +1,115 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	tenantIdHeader     = "ID"
+	tenantDomainHeader = "DOMAIN"
+	tenantStatusHeader = "STATUS"
+
+	defaultTenantTableFormat = "table {{.ID}}\t{{.Domain}}\t{{.Status}}"
+)
+
+// GetTenantListFromEnv
+// @param accessToken : Access Token for the environment
+// @param environment : Environment name to use when getting the tenants
+// @param state : State of the tenants to list (active or inactive)
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of tenants)
+// @return array of tenants
+// @return error
+func GetTenantListFromEnv(accessToken, environment, state, limit, offset string) (count int,
+	tenants []utils.Tenant, err error) {
+	tenantListEndpoint := utils.GetAdminEndpointOfEnv(environment, utils.MainConfigFilePath) + "/tenants"
+	return GetTenantList(accessToken, tenantListEndpoint, state, limit, offset)
+}
+
+// GetTenantList Get the list of tenants from the Admin REST API
+// @param accessToken : Access Token for the environment
+// @param tenantListEndpoint : Tenant list endpoint
+// @param state : State of the tenants to list (active or inactive)
+// @param limit : total # of results to return
+// @param offset : starting index of the results
+// @return count (no. of tenants)
+// @return array of tenants
+// @return error
+func GetTenantList(accessToken, tenantListEndpoint, state, limit, offset string) (count int,
+	tenants []utils.Tenant, err error) {
+	queryParams := getPagingQueryParams(limit, offset)
+	if state != "" {
+		queryParams.Set("state", state)
+	}
+	body, err := invokeListRequest(accessToken, tenantListEndpoint, queryParams.Encode())
+	if err != nil {
+		return 0, nil, err
+	}
+	tenantList := &utils.TenantList{}
+	if err := json.Unmarshal(body, tenantList); err != nil {
+		utils.HandleErrorAndExit(utils.LogPrefixError+"invalid JSON response", err)
+	}
+	return tenantList.Count, tenantList.List, nil
+}
+
+// PrintTenants prints the tenant list in a specific format
+func PrintTenants(tenants []utils.Tenant, format string) {
+	if utils.PrintStructuredOutput(tenants, format) {
+		return
+	}
+	if format == "" {
+		format = defaultTenantTableFormat
+	}
+
+	// create tenant context with standard output
+	tenantContext := formatter.NewContext(os.Stdout, format)
+
+	// create a new renderer function which iterate collection
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, tenant := range tenants {
+			if err := t.Execute(w, tenant); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+
+	// headers for table
+	tenantTableHeaders := map[string]string{
+		"ID":     tenantIdHeader,
+		"Domain": tenantDomainHeader,
+		"Status": tenantStatusHeader,
+	}
+
+	// execute context
+	if err := tenantContext.Write(renderer, tenantTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
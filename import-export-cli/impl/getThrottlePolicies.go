@@ -93,6 +93,9 @@ func PrintThrottlePolicies(resp *resty.Response, format string) {
 	if err != nil {
 		utils.HandleErrorAndExit("Error unmarshalling response data", err)
 	}
+	if utils.PrintStructuredOutput(policies, format) {
+		return
+	}
 	if format == "" {
 		format = defaultThrottlePolicyTableFormat
 	} else if format == utils.JsonArrayFormatType {
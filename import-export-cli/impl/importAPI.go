@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -155,9 +156,9 @@ func replaceEnvVariables(apiFilePath string) error {
 	return nil
 }
 
-// importAPI imports an API to the API manager
-func importAPI(endpoint, filePath, accessToken string, extraParams map[string]string, isOauth bool, dryRun bool,
-	apiLoggingCmdFormat string) error {
+// importAPI imports an API to the API manager, the outcome is printed to out
+func importAPI(out io.Writer, endpoint, filePath, accessToken string, extraParams map[string]string, isOauth bool,
+	dryRun bool, apiLoggingCmdFormat string) error {
 	resp, err := ExecuteNewFileUploadRequest(endpoint, extraParams, "file",
 		filePath, accessToken, isOauth)
 	utils.Logf("Response : %v", resp)
@@ -172,47 +173,48 @@ func importAPI(endpoint, filePath, accessToken string, extraParams map[string]st
 			err := json.Unmarshal([]byte(resp.String()), &data)
 			if err != nil {
 				utils.Logln(utils.LogPrefixError, err)
-				fmt.Println("Error occurred while validating API")
+				fmt.Fprintln(out, "Error occurred while validating API")
 				return errors.New(resp.Status())
 			}
 			if data.ComplianceCheck.Result == "fail" {
 				PrintViolations(data.ComplianceCheck.Violations, apiLoggingCmdFormat)
 			} else if resp.StatusCode() == http.StatusOK {
-				fmt.Printf("No violations found for the API")
+				fmt.Fprintf(out, "No violations found for the API")
 			}
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Error occurred while validating API")
-			return errors.New(resp.Status())
+			fmt.Fprintln(out, "Error occurred while validating API")
+			return utils.NewHTTPStatusError(resp)
 		}
 	} else {
 		if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 			// 201 Created or 200 OK
-			fmt.Println("Successfully imported API.")
+			fmt.Fprintln(out, "Successfully imported API.")
 		} else {
 			// We have an HTTP error
 			utils.Logln(utils.LogPrefixError, err)
-			fmt.Println("Status: " + resp.Status())
-			fmt.Println("Response:", resp)
-			return errors.New(resp.Status())
+			fmt.Fprintln(out, "Status: "+resp.Status())
+			fmt.Fprintln(out, "Response:", resp)
+			return utils.NewHTTPStatusError(resp)
 		}
 	}
 	return nil
 }
 
-// ImportAPIToEnv function is used with import-api command
-func ImportAPIToEnv(accessOAuthToken, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool, dryRun bool,
-	apiLoggingCmdFormat string) error {
+// ImportAPIToEnv function is used with import-api command, the outcome is printed to out
+func ImportAPIToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath, apiParamsPath string,
+	importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool,
+	dryRun bool, apiLoggingCmdFormat string) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath, importAPIUpdate,
-		preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments, dryRun, apiLoggingCmdFormat)
+	return ImportAPI(out, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath,
+		importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments, dryRun,
+		apiLoggingCmdFormat)
 }
 
-// ImportAPI function is used with import-api command
-func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string, importAPIUpdate,
-	preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool,
+// ImportAPI function is used with import-api command, the outcome is printed to out
+func ImportAPI(out io.Writer, accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiParamsPath string,
+	importAPIUpdate, preserveProvider, importAPISkipCleanup, importAPIRotateRevision, importAPISkipDeployments bool,
 	dryRun bool, apiLoggingCmdFormat string) error {
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName)
 	resolvedAPIFilePath, err := resolveImportFilePath(importPath, exportDirectory)
@@ -226,17 +228,8 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if importAPISkipCleanup {
-			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
-			return
-		}
-		utils.Logln(utils.LogPrefixInfo+"Deleting", tmpPath)
-		err := os.RemoveAll(tmpPath)
-		if err != nil {
-			utils.Logln(utils.LogPrefixError + err.Error())
-		}
-	}()
+	// the params applied to the workspace can contain resolved secrets
+	defer utils.CleanupTempArtifact(tmpPath, importAPISkipCleanup)
 	apiFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API files...")
@@ -289,7 +282,7 @@ func ImportAPI(accessOAuthToken, publisherEndpoint, importEnvironment, importPat
 	}
 	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
 
-	err = importAPI(publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true, dryRun, apiLoggingCmdFormat)
+	err = importAPI(out, publisherEndpoint, apiFilePath, accessOAuthToken, extraParams, true, dryRun, apiLoggingCmdFormat)
 	return err
 }
 
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -32,7 +33,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-func ImportAPIPolicyToEnv(accessOAuthToken, importEnvironment, importPath string) error {
+func ImportAPIPolicyToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath string) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
 	if _, err := os.Stat(importPath); err != nil {
 		if !os.IsNotExist(err) {
@@ -41,11 +42,11 @@ func ImportAPIPolicyToEnv(accessOAuthToken, importEnvironment, importPath string
 	}
 	publisherEndpoint = utils.AppendSlashToString(publisherEndpoint)
 	uri := publisherEndpoint + "operation-policies/import"
-	err := importAPIPolicy(uri, importPath, accessOAuthToken, true)
+	err := importAPIPolicy(out, uri, importPath, accessOAuthToken, true)
 	return err
 }
 
-func importAPIPolicy(endpoint string, importPath string, accessToken string, isOauth bool) error {
+func importAPIPolicy(out io.Writer, endpoint string, importPath string, accessToken string, isOauth bool) error {
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedPoliciesDirName, utils.ExportedAPIPoliciesDirName)
 
 	resolvedPolicyFilePath, err := resolvePolicyImportFilePath(importPath, exportDirectory)
@@ -120,7 +121,7 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully Imported API Policy.")
+		fmt.Fprintln(out, "Successfully Imported API Policy.")
 		return nil
 	} else if resp.StatusCode() == http.StatusConflict {
 
@@ -130,8 +131,8 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 			return err
 		}
 
-		fmt.Println("Error importing API Policy due to: ", errorResponse.Description)
-		fmt.Println("Please change the Policy name and re-import")
+		fmt.Fprintln(out, "Error importing API Policy due to: ", errorResponse.Description)
+		fmt.Fprintln(out, "Please change the Policy name and re-import")
 
 		if err != nil {
 			return err
@@ -139,9 +140,9 @@ func importAPIPolicy(endpoint string, importPath string, accessToken string, isO
 
 		return errors.New(errorResponse.Status)
 	} else {
-		fmt.Println("Error importing API Policy.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp.IsSuccess())
+		fmt.Fprintln(out, "Error importing API Policy.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp.IsSuccess())
 
 		err := json.Unmarshal(resp.Body(), &errorResponse)
 
@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -56,8 +57,8 @@ func resolveImportAPIProductFilePath(file, defaultExportDirectory string) (strin
 	return absPath, nil
 }
 
-// importAPIProduct imports an API Product to the API manager
-func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[string]string) error {
+// importAPIProduct imports an API Product to the API manager, the outcome is printed to out
+func importAPIProduct(out io.Writer, endpoint, filePath, accessToken string, extraParams map[string]string) error {
 	resp, err := ExecuteNewFileUploadRequest(endpoint, extraParams, "file",
 		filePath, accessToken, true)
 	if err != nil {
@@ -66,31 +67,31 @@ func importAPIProduct(endpoint, filePath, accessToken string, extraParams map[st
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported API Product.")
+		fmt.Fprintln(out, "Successfully imported API Product.")
 		return nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing API Product.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
+		fmt.Fprintln(out, "Error importing API Product.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp)
 		return errors.New(resp.Status())
 	}
 }
 
-// ImportAPIProductToEnv function is used with import-api-product command
-func ImportAPIProductToEnv(accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
-	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
-	skipDeployments bool) error {
+// ImportAPIProductToEnv function is used with import-api-product command, the outcome is printed to out
+func ImportAPIProductToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath, apiProductParamsPath string,
+	importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup,
+	rotateRevision, skipDeployments bool) error {
 	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
-	return ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath, importAPIs,
-		importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup, rotateRevision,
-		skipDeployments)
+	return ImportAPIProduct(out, accessOAuthToken, publisherEndpoint, importEnvironment, importPath,
+		apiProductParamsPath, importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider,
+		importAPIProductSkipCleanup, rotateRevision, skipDeployments)
 }
 
-// ImportAPIProduct function is used with import-api-product command
-func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, importPath, apiProductParamsPath string, importAPIs, importAPIsUpdate,
-	importAPIProductUpdate, importAPIProductPreserveProvider, importAPIProductSkipCleanup,
-	rotateRevision, skipDeployments bool) error {
+// ImportAPIProduct function is used with import-api-product command, the outcome is printed to out
+func ImportAPIProduct(out io.Writer, accessOAuthToken, publisherEndpoint, importEnvironment, importPath,
+	apiProductParamsPath string, importAPIs, importAPIsUpdate, importAPIProductUpdate, importAPIProductPreserveProvider,
+	importAPIProductSkipCleanup, rotateRevision, skipDeployments bool) error {
 	var exportDirectory = filepath.Join(utils.ExportDirectory, utils.ExportedApiProductsDirName)
 
 	resolvedAPIProductFilePath, err := resolveImportAPIProductFilePath(importPath, exportDirectory)
@@ -104,17 +105,8 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if importAPIProductSkipCleanup {
-			utils.Logln(utils.LogPrefixInfo+"Leaving", tmpPath)
-			return
-		}
-		utils.Logln(utils.LogPrefixInfo+"Deleting", tmpPath)
-		err := os.RemoveAll(tmpPath)
-		if err != nil {
-			utils.Logln(utils.LogPrefixError + err.Error())
-		}
-	}()
+	// the params applied to the workspace can contain resolved secrets
+	defer utils.CleanupTempArtifact(tmpPath, importAPIProductSkipCleanup)
 	apiProductFilePath := tmpPath
 
 	utils.Logln(utils.LogPrefixInfo + "Substituting environment variables in API Product files...")
@@ -182,7 +174,7 @@ func ImportAPIProduct(accessOAuthToken, publisherEndpoint, importEnvironment, im
 	}
 
 	utils.Logln(utils.LogPrefixInfo + "Import URL: " + publisherEndpoint)
-	err = importAPIProduct(publisherEndpoint, apiProductFilePath, accessOAuthToken, extraParams)
+	err = importAPIProduct(out, publisherEndpoint, apiProductFilePath, accessOAuthToken, extraParams)
 	return err
 }
 
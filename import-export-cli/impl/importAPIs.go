@@ -0,0 +1,328 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// revisionArchiveRegex matches the archives of API revisions, ex: PizzaShackAPI_1.0.0_Revision-2.zip
+var revisionArchiveRegex = regexp.MustCompile(`_Revision-(\d+)\.zip$`)
+
+// MigrationAPIArchive is an API archive in a migration directory created by the export apis command
+type MigrationAPIArchive struct {
+	Path     string
+	Name     string
+	Version  string
+	Provider string
+	Revision string
+}
+
+// MigrationAPIArchiveGroup is the set of archives (working copy and revisions) of a single API, in import order
+type MigrationAPIArchiveGroup struct {
+	Name     string
+	Version  string
+	Provider string
+	Archives []MigrationAPIArchive
+}
+
+// BulkImportAPIsOptions holds the options applied to every API imported by the import apis command
+type BulkImportAPIsOptions struct {
+	ParamsDir        string
+	Update           bool
+	PreserveProvider bool
+	RotateRevision   bool
+	SkipCleanup      bool
+}
+
+// ReadMigrationAPIArchives lists the API archives of the migration directory and groups them by API, an API being
+// identified by its name, version and provider. Revisions of
+// an API are imported in ascending order and the working copy is imported last, so that the API ends up with the
+// same working copy as in the exported environment.
+func ReadMigrationAPIArchives(apisDir string) ([]MigrationAPIArchiveGroup, error) {
+	files, err := ioutil.ReadDir(apisDir)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]*MigrationAPIArchiveGroup)
+	var order []string
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".zip") {
+			continue
+		}
+		archive := MigrationAPIArchive{Path: filepath.Join(apisDir, file.Name())}
+		metaData, err := readMetaDataFromArchive(archive.Path, utils.MetaFileAPI)
+		if err != nil {
+			return nil, errors.New("Error reading " + utils.MetaFileAPI + " of " + archive.Path + ": " + err.Error())
+		}
+		archive.Name, archive.Version = metaData.Name, metaData.Version
+		if archive.Provider, err = readProviderFromArchive(archive.Path); err != nil {
+			return nil, errors.New("Error reading the API definition of " + archive.Path + ": " + err.Error())
+		}
+		if match := revisionArchiveRegex.FindStringSubmatch(file.Name()); match != nil {
+			archive.Revision = match[1]
+		}
+		key := archive.Name + "_" + archive.Version + "_" + archive.Provider
+		if _, ok := groups[key]; !ok {
+			groups[key] = &MigrationAPIArchiveGroup{Name: archive.Name, Version: archive.Version,
+				Provider: archive.Provider}
+			order = append(order, key)
+		}
+		groups[key].Archives = append(groups[key].Archives, archive)
+	}
+
+	sort.Strings(order)
+	result := make([]MigrationAPIArchiveGroup, 0, len(order))
+	for _, key := range order {
+		group := groups[key]
+		sort.SliceStable(group.Archives, func(i, j int) bool {
+			return archiveImportOrder(group.Archives[i]) < archiveImportOrder(group.Archives[j])
+		})
+		result = append(result, *group)
+	}
+	return result, nil
+}
+
+// archiveImportOrder returns the position of an archive within the archives of an API, the working copy being last
+func archiveImportOrder(archive MigrationAPIArchive) int {
+	if archive.Revision == "" {
+		return int(^uint(0) >> 1)
+	}
+	revision, _ := strconv.Atoi(archive.Revision)
+	return revision
+}
+
+// readMetaDataFromArchive reads the *_meta.yaml file placed at the root of an exported archive
+func readMetaDataFromArchive(archivePath, metaFile string) (*utils.MetaData, error) {
+	data, err := readFileFromArchive(archivePath, metaFile)
+	if err != nil {
+		return nil, err
+	}
+	metaData := &utils.MetaData{}
+	if err = yaml.Unmarshal(data, metaData); err != nil {
+		return nil, err
+	}
+	return metaData, nil
+}
+
+// readProviderFromArchive reads the provider from the api.yaml or api.json file of an exported API archive
+func readProviderFromArchive(archivePath string) (string, error) {
+	data, err := readFileFromArchive(archivePath, "api.yaml")
+	if err != nil {
+		if data, err = readFileFromArchive(archivePath, "api.json"); err != nil {
+			return "", errors.New("api.yaml or api.json not found")
+		}
+	}
+	var definition struct {
+		Data struct {
+			Provider string `yaml:"provider"`
+		} `yaml:"data"`
+	}
+	if err = yaml.Unmarshal(data, &definition); err != nil {
+		return "", err
+	}
+	return definition.Data.Provider, nil
+}
+
+// readFileFromArchive reads the file with the given name placed at the root of an exported archive
+func readFileFromArchive(archivePath, fileName string) ([]byte, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var fileEntry *zip.File
+	for _, file := range reader.File {
+		if filepath.Base(file.Name) != fileName {
+			continue
+		}
+		// the shallowest entry is the file of the archive, not one of a nested artifact
+		if fileEntry == nil || strings.Count(file.Name, "/") < strings.Count(fileEntry.Name, "/") {
+			fileEntry = file
+		}
+	}
+	if fileEntry == nil {
+		return nil, errors.New(fileName + " not found")
+	}
+	content, err := fileEntry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return ioutil.ReadAll(content)
+}
+
+// Find the params file or deployment directory of an API in the params directory. The params of an API are
+// looked up as <Name>_<Version> (a directory generated using "gen deployment-dir") or <Name>_<Version>.yaml
+func resolveBulkImportParamsPath(paramsDir, name, version string) string {
+	if paramsDir == "" {
+		return ""
+	}
+	candidate := filepath.Join(paramsDir, name+"_"+version)
+	if dirExists, _ := utils.IsDirExists(candidate); dirExists {
+		return candidate
+	}
+	for _, extension := range []string{".yaml", ".yml"} {
+		if utils.IsFileExist(candidate + extension) {
+			return candidate + extension
+		}
+	}
+	return ""
+}
+
+// PrepareBulkImport loads the ledger of a bulk import and returns it along with the path of the report. When
+// startFromBeginning is set, the ledger and the report of the previous run are removed.
+func PrepareBulkImport(out io.Writer, sourceDir, importEnvironment, ledgerFileName, reportFileName string,
+	startFromBeginning bool) (*utils.BulkOperationLedger, string) {
+	// The source directory can be imported to several environments, hence the files are kept per environment
+	ledgerPath := filepath.Join(sourceDir, importEnvironment+"-"+ledgerFileName)
+	reportPath := filepath.Join(sourceDir, importEnvironment+"-"+reportFileName)
+	if startFromBeginning {
+		fmt.Fprintln(out, "Removing the import status of the previous run, if any, and importing from the beginning")
+		if err := utils.RemoveFileIfExists(ledgerPath); err != nil {
+			utils.HandleErrorAndExit("Error occurred while removing the import ledger", err)
+		}
+		if err := utils.RemoveFileIfExists(reportPath); err != nil {
+			utils.HandleErrorAndExit("Error occurred while removing the import report", err)
+		}
+	}
+	ledger, err := utils.LoadBulkOperationLedger(ledgerPath)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the import ledger", err)
+	}
+	if completed := ledger.CompletedCount(); completed > 0 {
+		fmt.Fprintln(out, "Resuming the import. "+strconv.Itoa(completed)+" artifact(s) imported previously will "+
+			"be skipped")
+	}
+	return ledger, reportPath
+}
+
+// ImportAPIs imports all the API archives of a migration directory created by the export apis command.
+// The APIs listed in the ledger are skipped, so a failed import can be resumed by running the command again.
+func ImportAPIs(credential credentials.Credential, sourceDir, importEnvironment string, importOptions BulkImportAPIsOptions,
+	bulkOptions utils.BulkOperationOptions, startFromBeginning bool) {
+	out := bulkOptions.MessageWriter()
+	var migrationApisExportMetadata utils.MigrationApisExportMetadata
+	err := migrationApisExportMetadata.ReadMigrationApisExportMetadataFile(filepath.Join(sourceDir,
+		utils.MigrationAPIsExportMetadataFileName))
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the migration metadata of "+sourceDir+". The source should be a "+
+			"directory created by the export apis command", err)
+	}
+	apisDir := filepath.Join(sourceDir, utils.ExportedApisDirName)
+	groups, err := ReadMigrationAPIArchives(apisDir)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the API archives of "+apisDir, err)
+	}
+	if len(groups) == 0 {
+		fmt.Fprintln(out, "No APIs available to be imported..!")
+		return
+	}
+
+	ledger, reportPath := PrepareBulkImport(out, sourceDir, importEnvironment, utils.MigrationAPIsImportLedgerFileName,
+		utils.MigrationAPIsImportReportFileName, startFromBeginning)
+	report := utils.NewBulkOperationReport("import apis", importEnvironment, migrationApisExportMetadata.OnTenant)
+	limiter := utils.NewRateLimiter(bulkOptions.RateLimit)
+	defer limiter.Stop()
+
+	accessToken, err := credentials.GetOAuthAccessToken(credential, importEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting an access token for importing APIs", err)
+	}
+	publisherEndpoint := utils.GetPublisherEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
+
+	fmt.Fprintln(out, "Importing "+strconv.Itoa(len(groups))+" API(s) from "+apisDir)
+	utils.RunInParallel(len(groups), bulkOptions.Parallel, func(i int) {
+		importAPIArchiveGroup(groups[i], accessToken, publisherEndpoint, importEnvironment, importOptions,
+			bulkOptions, limiter, ledger, report)
+	})
+
+	writeBulkOperationReport(report, reportPath, bulkOptions)
+	if report.Failed > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(report.Failed)+" API archive(s) could not be imported. Run the command "+
+			"again without --force to resume the import", nil)
+	}
+	fmt.Fprintln(out, "\nCommand: import apis execution completed !")
+}
+
+// Import the archives of an API in order. Once an archive fails, the remaining archives of the API are skipped
+// as a later revision should not be imported on top of a missing one.
+func importAPIArchiveGroup(group MigrationAPIArchiveGroup, accessToken, publisherEndpoint, importEnvironment string,
+	importOptions BulkImportAPIsOptions, bulkOptions utils.BulkOperationOptions, limiter *utils.RateLimiter,
+	ledger *utils.BulkOperationLedger, report *utils.BulkOperationReport) {
+	out := bulkOptions.MessageWriter()
+	paramsPath := resolveBulkImportParamsPath(importOptions.ParamsDir, group.Name, group.Version)
+	if paramsPath != "" {
+		utils.Logln(utils.LogPrefixInfo+"Using params of "+group.Name+" "+group.Version+" from", paramsPath)
+	}
+	failed := false
+	for _, archive := range group.Archives {
+		key := filepath.Base(archive.Path)
+		result := utils.BulkArtifactResult{Key: key, Type: "api", Name: archive.Name, Version: archive.Version,
+			Owner: archive.Provider, Revision: archive.Revision}
+		if ledger.IsCompleted(key) {
+			utils.Logln(utils.LogPrefixInfo + "Skipping " + key + " as it has been already imported")
+			result.Status = utils.BulkStatusSkipped
+			report.Add(result)
+			continue
+		}
+		if failed {
+			result.Status = utils.BulkStatusSkipped
+			result.Error = "a previous archive of the API could not be imported"
+			report.Add(result)
+			continue
+		}
+		startTime := time.Now()
+		var err error
+		result.Attempts, err = utils.RunWithRetry(bulkOptions.MaxRetries, func() error {
+			limiter.Wait()
+			// importing an API is retried on a server error only if it updates the existing API
+			return utils.GetBulkError(ImportAPI(out, accessToken, publisherEndpoint, importEnvironment,
+				archive.Path, paramsPath, importOptions.Update, importOptions.PreserveProvider,
+				importOptions.SkipCleanup, importOptions.RotateRevision, false, false, ""), importOptions.Update)
+		})
+		result.DurationMillis = time.Since(startTime).Milliseconds()
+		result.Status = utils.BulkStatusSucceeded
+		if err != nil {
+			fmt.Fprintln(out, "Error importing API archive:", key, "-", err)
+			result.Status = utils.BulkStatusFailed
+			result.Error = err.Error()
+			failed = true
+		}
+		if ledgerErr := ledger.Record(key, result.Status); ledgerErr != nil {
+			utils.HandleErrorAndExit("Error writing to the import ledger", ledgerErr)
+		}
+		report.Add(result)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// createMigrationAPIArchive creates an exported API archive with an api_meta.yaml file in apisDir
+func createMigrationAPIArchive(t *testing.T, apisDir, name, version, fileName string) {
+	createMigrationAPIArchiveOfProvider(t, apisDir, name, version, "admin", fileName)
+}
+
+// createMigrationAPIArchiveOfProvider creates an exported API archive of the given provider in apisDir
+func createMigrationAPIArchiveOfProvider(t *testing.T, apisDir, name, version, provider, fileName string) {
+	projectDir := filepath.Join(t.TempDir(), name+"-"+version)
+	assert.Nil(t, os.MkdirAll(projectDir, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, utils.MetaFileAPI),
+		[]byte("name: "+name+"\nversion: "+version+"\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectDir, "api.yaml"),
+		[]byte("type: api\ndata:\n  name: "+name+"\n  version: "+version+"\n  provider: "+provider+"\n"), 0644))
+	assert.Nil(t, utils.Zip(projectDir, filepath.Join(apisDir, fileName)))
+}
+
+func TestReadMigrationAPIArchives(t *testing.T) {
+	apisDir := t.TempDir()
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0.zip")
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0_Revision-10.zip")
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0_Revision-2.zip")
+	createMigrationAPIArchive(t, apisDir, "Menu_API", "2.0", "Menu_API_2.0_Revision-1.zip")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(apisDir, "notes.txt"), []byte("not an archive"), 0644))
+
+	groups, err := ReadMigrationAPIArchives(apisDir)
+	assert.Nil(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "Menu_API", groups[0].Name, "Name containing an underscore should be read from the meta file")
+	assert.Equal(t, "2.0", groups[0].Version)
+	assert.Equal(t, "PizzaShackAPI", groups[1].Name)
+
+	var revisions []string
+	for _, archive := range groups[1].Archives {
+		revisions = append(revisions, archive.Revision)
+	}
+	assert.Equal(t, []string{"2", "10", ""}, revisions, "Revisions should be in order and the working copy last")
+}
+
+func TestReadMigrationAPIArchivesGroupsByProvider(t *testing.T) {
+	apisDir := t.TempDir()
+	createMigrationAPIArchiveOfProvider(t, apisDir, "PizzaShackAPI", "1.0.0", "admin", "PizzaShackAPI_1.0.0.zip")
+	createMigrationAPIArchiveOfProvider(t, apisDir, "PizzaShackAPI", "1.0.0", "alice",
+		"PizzaShackAPI_1.0.0_alice.zip")
+
+	groups, err := ReadMigrationAPIArchives(apisDir)
+	assert.Nil(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "admin", groups[0].Provider)
+	assert.Len(t, groups[0].Archives, 1)
+	assert.Equal(t, "alice", groups[1].Provider)
+	assert.Equal(t, "alice", groups[1].Archives[0].Provider)
+}
+
+func TestResolveBulkImportParamsPath(t *testing.T) {
+	paramsDir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(paramsDir, "PizzaShackAPI_1.0.0"), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(paramsDir, "Menu_2.0.yaml"), []byte("environments: []"), 0644))
+
+	assert.Equal(t, filepath.Join(paramsDir, "PizzaShackAPI_1.0.0"),
+		resolveBulkImportParamsPath(paramsDir, "PizzaShackAPI", "1.0.0"))
+	assert.Equal(t, filepath.Join(paramsDir, "Menu_2.0.yaml"), resolveBulkImportParamsPath(paramsDir, "Menu", "2.0"))
+	assert.Equal(t, "", resolveBulkImportParamsPath(paramsDir, "Unknown", "1.0.0"))
+	assert.Equal(t, "", resolveBulkImportParamsPath("", "PizzaShackAPI", "1.0.0"))
+}
+
+func TestImportAPIArchiveGroup(t *testing.T) {
+	utils.BulkRetryBaseDelay = 0
+	defer func() { utils.BulkRetryBaseDelay = time.Second }()
+
+	apisDir := t.TempDir()
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0_Revision-1.zip")
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0_Revision-2.zip")
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0.zip")
+	groups, err := ReadMigrationAPIArchives(apisDir)
+	assert.Nil(t, err)
+
+	var mutex sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+		switch requests {
+		case 1:
+			// failed request of revision 1 is retried. A throttled (429) response is not used since the HTTP
+			// client retries it before the bulk import sees it
+			w.WriteHeader(http.StatusInternalServerError)
+		case 2:
+			w.WriteHeader(http.StatusOK)
+		default:
+			// revision 2 is rejected and should not be retried
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	ledgerPath := filepath.Join(t.TempDir(), utils.MigrationAPIsImportLedgerFileName)
+	ledger, err := utils.LoadBulkOperationLedger(ledgerPath)
+	assert.Nil(t, err)
+	report := utils.NewBulkOperationReport("import apis", "prod", "")
+	importAPIArchiveGroup(groups[0], "access-token", server.URL, "prod", BulkImportAPIsOptions{Update: true},
+		utils.BulkOperationOptions{Parallel: 1, MaxRetries: 2}, nil, ledger, report)
+
+	assert.Equal(t, 3, requests, "Non retryable failure should not be retried")
+	assert.Equal(t, 1, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Skipped, "Working copy should be skipped after a failed revision")
+	assert.Equal(t, 1, report.Retries)
+
+	// the imported revision is skipped when the import is resumed
+	ledger, err = utils.LoadBulkOperationLedger(ledgerPath)
+	assert.Nil(t, err)
+	assert.True(t, ledger.IsCompleted("PizzaShackAPI_1.0.0_Revision-1.zip"))
+	assert.False(t, ledger.IsCompleted("PizzaShackAPI_1.0.0_Revision-2.zip"))
+}
+
+func TestImportAPIArchiveGroupDoesNotRetryServerErrorsWithoutUpdate(t *testing.T) {
+	utils.BulkRetryBaseDelay = 0
+	defer func() { utils.BulkRetryBaseDelay = time.Second }()
+
+	apisDir := t.TempDir()
+	createMigrationAPIArchive(t, apisDir, "PizzaShackAPI", "1.0.0", "PizzaShackAPI_1.0.0.zip")
+	groups, err := ReadMigrationAPIArchives(apisDir)
+	assert.Nil(t, err)
+
+	var mutex sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests++
+		// the server may have created the API before failing
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ledger, err := utils.LoadBulkOperationLedger(filepath.Join(t.TempDir(), utils.MigrationAPIsImportLedgerFileName))
+	assert.Nil(t, err)
+	report := utils.NewBulkOperationReport("import apis", "prod", "")
+	importAPIArchiveGroup(groups[0], "access-token", server.URL, "prod", BulkImportAPIsOptions{},
+		utils.BulkOperationOptions{Parallel: 1, MaxRetries: 2}, nil, ledger, report)
+
+	assert.Equal(t, 1, requests, "An import which does not update should not be retried on a server error")
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 0, report.Retries)
+}
@@ -20,7 +20,6 @@ package impl
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -35,6 +34,7 @@ import (
 )
 
 // ImportApplicationToEnv function is used with import-app command
+// @param out: Writer to which the outcome is printed
 // @param accessToken: OAuth2.0 access token for the resource being accessed
 // @param environment: Environment to import the application
 // @param filename: name of the application (zipped file) to be imported
@@ -44,14 +44,15 @@ import (
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
-func ImportApplicationToEnv(accessToken, environment, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
+func ImportApplicationToEnv(out io.Writer, accessToken, environment, filename, appOwner string, updateApplication,
+	preserveOwner, skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
 	devportalApplicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(environment, utils.MainConfigFilePath)
-	return ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner, updateApplication, preserveOwner,
-		skipSubscriptions, skipKeys, skipCleanup)
+	return ImportApplication(out, accessToken, devportalApplicationsEndpoint, filename, appOwner, updateApplication,
+		preserveOwner, skipSubscriptions, skipKeys, skipCleanup)
 }
 
 // ImportApplication function is used with import-app command
+// @param out: Writer to which the outcome is printed
 // @param accessToken: OAuth2.0 access token for the resource being accessed
 // @param devportalApplicationsEndpoint: Dev Portal Applications Endpoint for the environment
 // @param filename: name of the application (zipped file) to be imported
@@ -61,8 +62,8 @@ func ImportApplicationToEnv(accessToken, environment, filename, appOwner string,
 // @param skipSubscriptions: Skip importing subscriptions
 // @param skipKeys: skip importing keys of application
 // @param skipCleanup: skip cleaning up temporary files created during the operation
-func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, appOwner string, updateApplication, preserveOwner,
-	skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
+func ImportApplication(out io.Writer, accessToken, devportalApplicationsEndpoint, filename, appOwner string,
+	updateApplication, preserveOwner, skipSubscriptions, skipKeys, skipCleanup bool) (*http.Response, error) {
 
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
 	devportalApplicationsEndpoint = utils.AppendSlashToString(devportalApplicationsEndpoint)
@@ -76,7 +77,7 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 
 	applicationFilePath, err := resolveApplicationImportFilePath(filename, exportDirectory)
 	if err != nil {
-		utils.HandleErrorAndExit("Error creating request.", err)
+		return nil, err
 	}
 
 	// If applicationFilePath contains a directory, zip it. Otherwise, leave it as it is.
@@ -94,19 +95,19 @@ func ImportApplication(accessToken, devportalApplicationsEndpoint, filename, app
 
 	resp, err := NewAppFileUploadRequest(applicationImportUrl, extraParams, "file", applicationFilePath, accessToken)
 	if err != nil {
-		utils.HandleErrorAndExit("Error executing request.", err)
+		return nil, err
 	}
 
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println("Successfully imported Application.")
+		fmt.Fprintln(out, "Successfully imported Application.")
 		return nil, nil
 	} else {
 		// We have an HTTP error
-		fmt.Println("Error importing Application.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp)
-		return nil, errors.New(resp.Status())
+		fmt.Fprintln(out, "Error importing Application.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp)
+		return nil, utils.NewHTTPStatusError(resp)
 	}
 }
 
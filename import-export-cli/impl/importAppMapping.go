@@ -0,0 +1,482 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Actions on a subscription of an Application in the import report
+const (
+	SubscriptionImportActionImport = "import"
+	SubscriptionImportActionRemap  = "remap"
+	SubscriptionImportActionDrop   = "drop"
+	SubscriptionImportActionDefer  = "defer"
+)
+
+const (
+	subscriptionImportActionHeader  = "ACTION"
+	subscriptionImportApiHeader     = "API"
+	subscriptionImportTargetHeader  = "TARGET API"
+	subscriptionImportPolicyHeader  = "POLICY"
+	subscriptionImportReasonHeader  = "REASON"
+	defaultSubscriptionImportFormat = "table {{.Action}}\t{{.Source}}\t{{.Target}}\t{{.TargetThrottlingPolicy}}\t" +
+		"{{.Reason}}"
+)
+
+const apiProductSubscriptionType = "APIProduct"
+
+// ApplicationImportMapping rewrites the identifiers in an Application archive which differ between the environment
+// it is exported from and the environment it is imported to
+type ApplicationImportMapping struct {
+	// APIs maps the subscribed APIs. The first mapping matching an API is applied
+	APIs []APIIdentifierMapping `yaml:"apis" json:"apis"`
+	// ThrottlingTiers maps the throttling tiers of the subscriptions and the throttling policy of the Application
+	ThrottlingTiers map[string]string `yaml:"throttlingTiers" json:"throttlingTiers"`
+	// Owners maps the owner of the Application and the subscriber of the subscriptions
+	Owners map[string]string `yaml:"owners" json:"owners"`
+	// KeyManagers maps the key managers of the keys of the Application
+	KeyManagers map[string]string `yaml:"keyManagers" json:"keyManagers"`
+}
+
+// APIIdentifierMapping maps an API to another. An empty version or provider of From matches any version or
+// provider, and an empty field of To keeps the field of the API
+type APIIdentifierMapping struct {
+	From APIIdentifier `yaml:"from" json:"from"`
+	To   APIIdentifier `yaml:"to" json:"to"`
+}
+
+// APIIdentifier identifies an API or API Product subscribed by an Application
+type APIIdentifier struct {
+	Name     string `yaml:"name" json:"name"`
+	Version  string `yaml:"version" json:"version"`
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+}
+
+// String returns the identifier as <name>:<version>[:<provider>]
+func (id APIIdentifier) String() string {
+	if id.Provider == "" {
+		return id.Name + ":" + id.Version
+	}
+	return id.Name + ":" + id.Version + ":" + id.Provider
+}
+
+// matches returns true if the identifier matches the From identifier of a mapping
+func (id APIIdentifier) matches(from APIIdentifier) bool {
+	return id.Name == from.Name && (from.Version == "" || id.Version == from.Version) &&
+		(from.Provider == "" || id.Provider == from.Provider)
+}
+
+// ApplicationImportReport is the pre-flight report of importing an Application. It lists the changes made by the
+// mapping and what happens to each subscription of the Application
+type ApplicationImportReport struct {
+	Application   string                        `json:"application"`
+	Owner         string                        `json:"owner"`
+	Environment   string                        `json:"environment"`
+	Changes       []ApplicationImportChange     `json:"changes"`
+	Subscriptions []ApplicationSubscriptionPlan `json:"subscriptions"`
+}
+
+// ApplicationImportChange is a field of the Application rewritten by the mapping
+type ApplicationImportChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ApplicationSubscriptionPlan is what happens to a subscription of the Application when it is imported
+type ApplicationSubscriptionPlan struct {
+	Action                 string        `json:"action"`
+	Type                   string        `json:"type"`
+	API                    APIIdentifier `json:"api"`
+	TargetAPI              APIIdentifier `json:"targetApi"`
+	ThrottlingPolicy       string        `json:"throttlingPolicy"`
+	TargetThrottlingPolicy string        `json:"targetThrottlingPolicy"`
+	Reason                 string        `json:"reason,omitempty"`
+}
+
+// Source returns the subscribed API in the archive
+func (s ApplicationSubscriptionPlan) Source() string {
+	return s.API.String()
+}
+
+// Target returns the API subscribed in the environment
+func (s ApplicationSubscriptionPlan) Target() string {
+	return s.TargetAPI.String()
+}
+
+// Missing returns the subscriptions which are not imported as their APIs are not found in the environment
+func (r *ApplicationImportReport) Missing() []ApplicationSubscriptionPlan {
+	var missing []ApplicationSubscriptionPlan
+	for _, subscription := range r.Subscriptions {
+		if subscription.Action == SubscriptionImportActionDrop || subscription.Action == SubscriptionImportActionDefer {
+			missing = append(missing, subscription)
+		}
+	}
+	return missing
+}
+
+// LoadApplicationImportMapping reads the mapping file in path, after substituting the environment variables in it
+func LoadApplicationImportMapping(path string) (*ApplicationImportMapping, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	substituted, err := utils.EnvSubstituteForCurlyBraces(string(content))
+	if err != nil {
+		return nil, err
+	}
+	mapping := &ApplicationImportMapping{}
+	if err := yaml.UnmarshalStrict([]byte(substituted), mapping); err != nil {
+		return nil, errors.New("invalid mapping file " + path + ": " + err.Error())
+	}
+	for _, apiMapping := range mapping.APIs {
+		if apiMapping.From.Name == "" {
+			return nil, errors.New("invalid mapping file " + path + ": the name of an API to map is empty")
+		}
+	}
+	return mapping, nil
+}
+
+// PrepareApplicationImport copies the Application archive or directory in filename to a temporary directory and
+// applies the mapping (if not nil) to it. The subscribed APIs are looked up in the environment to report the
+// subscriptions which will be dropped, or deferred if deferMissing is true.
+// @return the path of the prepared Application directory, to be removed by the caller
+// @return the pre-flight report
+func PrepareApplicationImport(accessToken, environment, filename string, mapping *ApplicationImportMapping,
+	deferMissing bool) (string, *ApplicationImportReport, error) {
+	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName)
+	applicationFilePath, err := resolveApplicationImportFilePath(filename, exportDirectory)
+	if err != nil {
+		return "", nil, err
+	}
+	projectPath, err := utils.GetTempCloneFromDirOrZip(applicationFilePath)
+	if err != nil {
+		return "", nil, err
+	}
+	report, err := prepareApplicationProject(projectPath, mapping, deferMissing,
+		func(api APIIdentifier, apiType string) (bool, error) {
+			return subscribedAPIExistsInEnv(accessToken, environment, api, apiType)
+		})
+	if err != nil {
+		_ = os.RemoveAll(filepath.Dir(projectPath))
+		return "", nil, err
+	}
+	report.Environment = environment
+	return projectPath, report, nil
+}
+
+// PrintApplicationImportReport prints the changes and the subscriptions of the pre-flight report. The report is
+// written to out unless format is a structured output format
+func PrintApplicationImportReport(out io.Writer, report *ApplicationImportReport, format string) {
+	if utils.PrintStructuredOutput(report, format) {
+		return
+	}
+	fmt.Fprintln(out, "Application "+report.Application+" of "+report.Owner+" to be imported to "+
+		report.Environment)
+	for _, change := range report.Changes {
+		fmt.Fprintln(out, "  "+change.Field+": "+change.From+" -> "+change.To)
+	}
+	if len(report.Subscriptions) == 0 {
+		fmt.Fprintln(out, "No subscriptions")
+		return
+	}
+
+	reportContext := formatter.NewContext(out, defaultSubscriptionImportFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, s := range report.Subscriptions {
+			if err := t.Execute(w, s); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	reportTableHeaders := map[string]string{
+		"Action":                 subscriptionImportActionHeader,
+		"Source":                 subscriptionImportApiHeader,
+		"Target":                 subscriptionImportTargetHeader,
+		"TargetThrottlingPolicy": subscriptionImportPolicyHeader,
+		"Reason":                 subscriptionImportReasonHeader,
+	}
+	if err := reportContext.Write(renderer, reportTableHeaders); err != nil {
+		fmt.Fprintln(out, "Error executing template:", err.Error())
+	}
+}
+
+// applies the mapping to the Application file in projectPath and plans its subscriptions, looking up the
+// subscribed APIs with apiExists
+func prepareApplicationProject(projectPath string, mapping *ApplicationImportMapping, deferMissing bool,
+	apiExists func(api APIIdentifier, apiType string) (bool, error)) (*ApplicationImportReport, error) {
+	applicationFile, content, err := findApplicationFile(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	report, err := applyApplicationImportMapping(content, mapping)
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Subscriptions {
+		subscription := &report.Subscriptions[i]
+		exists, err := apiExists(subscription.TargetAPI, subscription.Type)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			subscription.Action = SubscriptionImportActionDrop
+			if deferMissing {
+				subscription.Action = SubscriptionImportActionDefer
+			}
+			subscription.Reason = subscription.Type + " " + subscription.TargetAPI.String() + " is not found"
+		}
+	}
+	if mapping != nil {
+		if err := writeApplicationFile(applicationFile, content); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// applies the mapping to the content of an Application file and returns the report of the changes made
+func applyApplicationImportMapping(content map[string]interface{},
+	mapping *ApplicationImportMapping) (*ApplicationImportReport, error) {
+	if mapping == nil {
+		mapping = &ApplicationImportMapping{}
+	}
+	data, ok := content["data"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("the Application file has no data")
+	}
+	applicationInfo, ok := data["applicationInfo"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("the Application file has no applicationInfo")
+	}
+	report := &ApplicationImportReport{Changes: []ApplicationImportChange{},
+		Subscriptions: []ApplicationSubscriptionPlan{}}
+	report.Application, _ = applicationInfo["name"].(string)
+	report.Owner = remapField(applicationInfo, "owner", mapping.Owners, "owner", report)
+	remapField(applicationInfo, "throttlingPolicy", mapping.ThrottlingTiers, "throttlingPolicy", report)
+
+	if keys, ok := applicationInfo["keys"].([]interface{}); ok {
+		for _, key := range keys {
+			if key, ok := key.(map[string]interface{}); ok {
+				keyType, _ := key["keyType"].(string)
+				remapField(key, "keyManager", mapping.KeyManagers, "keys."+keyType+".keyManager", report)
+			}
+		}
+	}
+	// older archives have the OAuth apps of the Application by key type and key manager
+	if oauthApps, ok := applicationInfo["keyManagerWiseOAuthApp"].(map[string]interface{}); ok {
+		for keyType, keyManagerApps := range oauthApps {
+			if keyManagerApps, ok := keyManagerApps.(map[string]interface{}); ok {
+				remapKeys(keyManagerApps, mapping.KeyManagers, "keyManagerWiseOAuthApp."+keyType, report)
+			}
+		}
+	}
+
+	subscribedAPIs, _ := data["subscribedAPIs"].([]interface{})
+	for _, subscribedAPI := range subscribedAPIs {
+		subscribedAPI, ok := subscribedAPI.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		report.Subscriptions = append(report.Subscriptions, remapSubscribedAPI(subscribedAPI, mapping))
+	}
+	return report, nil
+}
+
+// applies the mapping to a subscribed API and returns its plan
+func remapSubscribedAPI(subscribedAPI map[string]interface{},
+	mapping *ApplicationImportMapping) ApplicationSubscriptionPlan {
+	plan := ApplicationSubscriptionPlan{Action: SubscriptionImportActionImport, Type: utils.ProjectTypeApi}
+	if apiType, _ := subscribedAPI["apiType"].(string); apiType == apiProductSubscriptionType {
+		plan.Type = utils.ProjectTypeApiProduct
+	}
+	apiId, _ := subscribedAPI["apiId"].(map[string]interface{})
+	if apiId == nil {
+		apiId = make(map[string]interface{})
+		subscribedAPI["apiId"] = apiId
+	}
+	plan.API.Name, _ = apiId["apiName"].(string)
+	plan.API.Version, _ = apiId["version"].(string)
+	plan.API.Provider, _ = apiId["providerName"].(string)
+	plan.TargetAPI = plan.API
+	for _, apiMapping := range mapping.APIs {
+		if !plan.API.matches(apiMapping.From) {
+			continue
+		}
+		if apiMapping.To.Name != "" {
+			plan.TargetAPI.Name = apiMapping.To.Name
+		}
+		if apiMapping.To.Version != "" {
+			plan.TargetAPI.Version = apiMapping.To.Version
+		}
+		if apiMapping.To.Provider != "" {
+			plan.TargetAPI.Provider = apiMapping.To.Provider
+		}
+		break
+	}
+	apiId["apiName"] = plan.TargetAPI.Name
+	apiId["version"] = plan.TargetAPI.Version
+	apiId["providerName"] = plan.TargetAPI.Provider
+
+	plan.ThrottlingPolicy, _ = subscribedAPI["throttlingPolicy"].(string)
+	plan.TargetThrottlingPolicy = plan.ThrottlingPolicy
+	if tier, ok := mapping.ThrottlingTiers[plan.ThrottlingPolicy]; ok {
+		plan.TargetThrottlingPolicy = tier
+		subscribedAPI["throttlingPolicy"] = tier
+	}
+	if subscriber, ok := subscribedAPI["subscriber"].(map[string]interface{}); ok {
+		if name, _ := subscriber["name"].(string); mapping.Owners[name] != "" {
+			subscriber["name"] = mapping.Owners[name]
+		}
+	}
+	if plan.TargetAPI != plan.API || plan.TargetThrottlingPolicy != plan.ThrottlingPolicy {
+		plan.Action = SubscriptionImportActionRemap
+	}
+	return plan
+}
+
+// replaces the string value of the key in object by its mapping and records the change
+// @return the value of the key after the mapping
+func remapField(object map[string]interface{}, key string, mapping map[string]string, field string,
+	report *ApplicationImportReport) string {
+	value, _ := object[key].(string)
+	if mapped, ok := mapping[value]; ok && mapped != value {
+		object[key] = mapped
+		report.Changes = append(report.Changes, ApplicationImportChange{Field: field, From: value, To: mapped})
+		return mapped
+	}
+	return value
+}
+
+// renames the keys of object by the mapping and records the changes. Every key is mapped from its original name,
+// so that swapped ({A: B, B: A}) and chained ({A: B, B: C}) mappings rename each key once
+func remapKeys(object map[string]interface{}, mapping map[string]string, field string,
+	report *ApplicationImportReport) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	remapped := make(map[string]interface{}, len(object))
+	for _, key := range keys {
+		mapped, ok := mapping[key]
+		if !ok || mapped == key {
+			remapped[key] = object[key]
+			continue
+		}
+		remapped[mapped] = object[key]
+		report.Changes = append(report.Changes, ApplicationImportChange{Field: field, From: key, To: mapped})
+	}
+	for key := range object {
+		delete(object, key)
+	}
+	for key, value := range remapped {
+		object[key] = value
+	}
+}
+
+// returns true if the API or API Product subscribed by an Application is found in the environment
+func subscribedAPIExistsInEnv(accessToken, environment string, api APIIdentifier, apiType string) (bool, error) {
+	if apiType == utils.ProjectTypeApiProduct {
+		query := "type:\"" + utils.DefaultApiProductType + "\" name:\"" + api.Name + "\" version:\"" + api.Version +
+			"\""
+		_, apiProducts, err := GetAPIProductListFromEnv(accessToken, environment, query, "")
+		if err != nil {
+			return false, err
+		}
+		for _, apiProduct := range apiProducts {
+			if apiProduct.Name == api.Name && apiProduct.Version == api.Version &&
+				(api.Provider == "" || apiProduct.Provider == api.Provider) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	found, err := findAPIInEnv(accessToken, environment, api.Name, api.Version, api.Provider)
+	return found != nil, err
+}
+
+// returns the path and the content of the Application file, which is the YAML or JSON file of the type
+// application, in the Application directory
+func findApplicationFile(projectPath string) (string, map[string]interface{}, error) {
+	files, err := ioutil.ReadDir(projectPath)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, file := range files {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		if file.IsDir() || file.Name() == utils.MetaFileApplication ||
+			(ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		path := filepath.Join(projectPath, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", nil, err
+		}
+		if ext != ".json" {
+			if data, err = utils.YamlToJson(data); err != nil {
+				continue
+			}
+		}
+		// numbers are decoded as json.Number, so that they are written back unchanged
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var content map[string]interface{}
+		if err := decoder.Decode(&content); err != nil {
+			continue
+		}
+		if fileType, _ := content["type"].(string); strings.EqualFold(fileType, "application") {
+			return path, content, nil
+		}
+	}
+	return "", nil, errors.New("the Application file is not found in " + projectPath)
+}
+
+// writes the content of an Application file in the format of its extension
+func writeApplicationFile(path string, content map[string]interface{}) error {
+	data, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return err
+	}
+	if strings.ToLower(filepath.Ext(path)) != ".json" {
+		if data, err = utils.JsonToYaml(data); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
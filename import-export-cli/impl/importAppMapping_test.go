@@ -0,0 +1,225 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const testApplicationFile = `type: application
+version: v4.0.0
+data:
+  applicationInfo:
+    applicationId: 8a1ba5f5-1b2b-4f0e-9a7e-3f4b1c6a9d10
+    name: SampleApp
+    throttlingPolicy: 10PerMin
+    owner: qauser
+    keys:
+      - keyManager: QA Key Manager
+        keyType: PRODUCTION
+        consumerKey: key
+  subscribedAPIs:
+    - apiId:
+        providerName: admin
+        apiName: PizzaShackAPI
+        version: 1.0.0
+        id: 9007199254740993
+      subscriber:
+        name: qauser
+      throttlingPolicy: Gold
+    - apiId:
+        providerName: admin
+        apiName: StoreAPI
+        version: 2.0.0
+      throttlingPolicy: Unlimited
+    - apiId:
+        providerName: admin
+        apiName: LegacyAPI
+        version: 1.0.0
+      throttlingPolicy: Unlimited
+`
+
+const testApplicationMapping = `apis:
+  - from:
+      name: PizzaShackAPI
+    to:
+      name: PizzaAPI
+      version: 2.0.0
+      provider: prodadmin
+throttlingTiers:
+  Gold: Unlimited
+  10PerMin: ${APP_TIER}
+owners:
+  qauser: produser
+keyManagers:
+  QA Key Manager: Prod Key Manager
+`
+
+func newTestApplicationProject(t *testing.T) string {
+	projectPath := filepath.Join(t.TempDir(), "qauser_SampleApp")
+	assert.Nil(t, os.MkdirAll(projectPath, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "SampleApp.yaml"), []byte(testApplicationFile), 0644))
+	return projectPath
+}
+
+func loadTestApplicationImportMapping(t *testing.T) *ApplicationImportMapping {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testApplicationMapping), 0644))
+	os.Setenv("APP_TIER", "20PerMin")
+	defer os.Unsetenv("APP_TIER")
+	mapping, err := LoadApplicationImportMapping(path)
+	assert.Nil(t, err)
+	return mapping
+}
+
+// the APIs of the environment in the tests
+func testSubscribedAPIExists(api APIIdentifier, apiType string) (bool, error) {
+	return api.Name == "PizzaAPI" || api.Name == "StoreAPI", nil
+}
+
+func TestLoadApplicationImportMappingRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("tiers:\n  Gold: Unlimited\n"), 0644))
+	_, err := LoadApplicationImportMapping(path)
+	assert.NotNil(t, err)
+}
+
+func TestPrepareApplicationProjectAppliesMapping(t *testing.T) {
+	projectPath := newTestApplicationProject(t)
+	report, err := prepareApplicationProject(projectPath, loadTestApplicationImportMapping(t), false,
+		testSubscribedAPIExists)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "SampleApp", report.Application)
+	assert.Equal(t, "produser", report.Owner)
+	assert.Equal(t, []ApplicationImportChange{
+		{Field: "owner", From: "qauser", To: "produser"},
+		{Field: "throttlingPolicy", From: "10PerMin", To: "20PerMin"},
+		{Field: "keys.PRODUCTION.keyManager", From: "QA Key Manager", To: "Prod Key Manager"},
+	}, report.Changes)
+
+	assert.Len(t, report.Subscriptions, 3)
+	assert.Equal(t, SubscriptionImportActionRemap, report.Subscriptions[0].Action)
+	assert.Equal(t, APIIdentifier{Name: "PizzaAPI", Version: "2.0.0", Provider: "prodadmin"},
+		report.Subscriptions[0].TargetAPI)
+	assert.Equal(t, "Unlimited", report.Subscriptions[0].TargetThrottlingPolicy)
+	assert.Equal(t, SubscriptionImportActionImport, report.Subscriptions[1].Action)
+	assert.Equal(t, SubscriptionImportActionDrop, report.Subscriptions[2].Action)
+	assert.Len(t, report.Missing(), 1)
+
+	// the rewritten file is imported
+	_, content, err := findApplicationFile(projectPath)
+	assert.Nil(t, err)
+	applicationInfo := content["data"].(map[string]interface{})["applicationInfo"].(map[string]interface{})
+	assert.Equal(t, "produser", applicationInfo["owner"])
+	subscribedAPI := content["data"].(map[string]interface{})["subscribedAPIs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "PizzaAPI", subscribedAPI["apiId"].(map[string]interface{})["apiName"])
+	assert.Equal(t, json.Number("9007199254740993"), subscribedAPI["apiId"].(map[string]interface{})["id"])
+	assert.Equal(t, "produser", subscribedAPI["subscriber"].(map[string]interface{})["name"])
+}
+
+func TestPrepareApplicationProjectWithoutMapping(t *testing.T) {
+	projectPath := newTestApplicationProject(t)
+	report, err := prepareApplicationProject(projectPath, nil, true, testSubscribedAPIExists)
+	assert.Nil(t, err)
+
+	assert.Empty(t, report.Changes)
+	// PizzaShackAPI and LegacyAPI are not found, and are kept to be created later
+	assert.Equal(t, SubscriptionImportActionDefer, report.Subscriptions[0].Action)
+	assert.Equal(t, SubscriptionImportActionImport, report.Subscriptions[1].Action)
+	assert.Equal(t, SubscriptionImportActionDefer, report.Subscriptions[2].Action)
+
+	data, err := ioutil.ReadFile(filepath.Join(projectPath, "SampleApp.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, testApplicationFile, string(data))
+}
+
+func TestRemapKeysWithSwapMapping(t *testing.T) {
+	report := &ApplicationImportReport{}
+	object := map[string]interface{}{"Resident": "resident keys", "QA": "qa keys", "Default": "default keys"}
+	remapKeys(object, map[string]string{"Resident": "QA", "QA": "Resident"}, "keyManagerWiseOAuthApp.PRODUCTION",
+		report)
+
+	assert.Equal(t, map[string]interface{}{"Resident": "qa keys", "QA": "resident keys", "Default": "default keys"},
+		object)
+	assert.Equal(t, []ApplicationImportChange{
+		{Field: "keyManagerWiseOAuthApp.PRODUCTION", From: "QA", To: "Resident"},
+		{Field: "keyManagerWiseOAuthApp.PRODUCTION", From: "Resident", To: "QA"},
+	}, report.Changes)
+}
+
+func TestRemapKeysWithChainedMapping(t *testing.T) {
+	report := &ApplicationImportReport{}
+	object := map[string]interface{}{"A": "a keys", "B": "b keys"}
+	remapKeys(object, map[string]string{"A": "B", "B": "C"}, "keyManagerWiseOAuthApp.SANDBOX", report)
+
+	assert.Equal(t, map[string]interface{}{"B": "a keys", "C": "b keys"}, object)
+	assert.Len(t, report.Changes, 2)
+}
+
+func TestResumeDeferredSubscriptions(t *testing.T) {
+	defaultDir := utils.DefaultDeferredSubscriptionsDirPath
+	utils.DefaultDeferredSubscriptionsDirPath = t.TempDir()
+	defer func() { utils.DefaultDeferredSubscriptionsDirPath = defaultDir }()
+
+	projectPath := newTestApplicationProject(t)
+	report, err := prepareApplicationProject(projectPath, loadTestApplicationImportMapping(t), true,
+		testSubscribedAPIExists)
+	assert.Nil(t, err)
+	report.Environment = "prod"
+	dir, err := SaveDeferredSubscriptions(projectPath, report, "", true)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, dir)
+
+	deferredList, err := GetDeferredSubscriptionsOfEnv("prod")
+	assert.Nil(t, err)
+	assert.Len(t, deferredList, 1)
+	assert.Equal(t, "LegacyAPI:1.0.0:admin", deferredList[0].Subscriptions[0].TargetAPI.String())
+
+	// LegacyAPI is still not found, hence the Application is not imported
+	imported := 0
+	importApplication := func(applicationPath string) error {
+		imported++
+		_, _, err := findApplicationFile(applicationPath)
+		return err
+	}
+	results, err := resumeDeferredSubscriptions(deferredList[0], testSubscribedAPIExists, importApplication)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, imported)
+	assert.Equal(t, utils.BulkStatusSkipped, results[0].Status)
+
+	results, err = resumeDeferredSubscriptions(deferredList[0], func(api APIIdentifier, apiType string) (bool,
+		error) {
+		return true, nil
+	}, importApplication)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, imported)
+	assert.Equal(t, utils.BulkStatusSucceeded, results[0].Status)
+	// the deferred subscriptions are removed once all of them are created
+	deferredList, err = GetDeferredSubscriptionsOfEnv("prod")
+	assert.Nil(t, err)
+	assert.Empty(t, deferredList)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -50,12 +51,12 @@ func TestImportApplication1(t *testing.T) {
 	owner := "admin"
 	accessToken := "access-token"
 
-	_, err := ImportApplication(accessToken, server.URL, name, owner, false,true, true, true, false)
+	_, err := ImportApplication(os.Stdout, accessToken, server.URL, name, owner, false,true, true, true, false)
 	if err != nil {
 		t.Errorf("Error: %s\n", err.Error())
 	}
 	utils.Insecure = true
-	_, err = ImportApplication(accessToken, server.URL, name, owner, false,true, true, true, false)
+	_, err = ImportApplication(os.Stdout, accessToken, server.URL, name, owner, false,true, true, true, false)
 	if err != nil {
 		t.Errorf("Error: %s\n", err.Error())
 	}
@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// MigrationAppArchive is an Application archive in a migration directory created by the export apps command
+type MigrationAppArchive struct {
+	Path  string
+	Name  string
+	Owner string
+}
+
+// BulkImportAppsOptions holds the options applied to every Application imported by the import apps command
+type BulkImportAppsOptions struct {
+	Update            bool
+	PreserveOwner     bool
+	SkipSubscriptions bool
+	SkipKeys          bool
+	SkipCleanup       bool
+}
+
+// ReadMigrationAppArchives lists the Application archives of the migration directory
+func ReadMigrationAppArchives(appsDir string) ([]MigrationAppArchive, error) {
+	files, err := ioutil.ReadDir(appsDir)
+	if err != nil {
+		return nil, err
+	}
+	var archives []MigrationAppArchive
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".zip") {
+			continue
+		}
+		archive := MigrationAppArchive{Path: filepath.Join(appsDir, file.Name())}
+		metaData, err := readMetaDataFromArchive(archive.Path, utils.MetaFileApplication)
+		if err != nil {
+			return nil, errors.New("Error reading " + utils.MetaFileApplication + " of " + archive.Path + ": " +
+				err.Error())
+		}
+		archive.Name, archive.Owner = metaData.Name, metaData.Owner
+		archives = append(archives, archive)
+	}
+	return archives, nil
+}
+
+// ImportApps imports all the Application archives of a migration directory created by the export apps command.
+// The Applications listed in the ledger are skipped, so a failed import can be resumed by running the command again.
+func ImportApps(credential credentials.Credential, sourceDir, importEnvironment string, importOptions BulkImportAppsOptions,
+	bulkOptions utils.BulkOperationOptions, startFromBeginning bool) {
+	out := bulkOptions.MessageWriter()
+	var migrationAppsExportMetadata utils.MigrationAppsExportMetadata
+	err := migrationAppsExportMetadata.ReadMigrationAppsExportMetadataFile(filepath.Join(sourceDir,
+		utils.MigrationAppsExportMetadataFileName))
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the migration metadata of "+sourceDir+". The source should be a "+
+			"directory created by the export apps command", err)
+	}
+	appsDir := filepath.Join(sourceDir, utils.ExportedAppsDirName)
+	archives, err := ReadMigrationAppArchives(appsDir)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading the Application archives of "+appsDir, err)
+	}
+	if len(archives) == 0 {
+		fmt.Fprintln(out, "No Apps available to be imported..!")
+		return
+	}
+
+	ledger, reportPath := PrepareBulkImport(out, sourceDir, importEnvironment, utils.MigrationAppsImportLedgerFileName,
+		utils.MigrationAppsImportReportFileName, startFromBeginning)
+	report := utils.NewBulkOperationReport("import apps", importEnvironment, migrationAppsExportMetadata.OnTenant)
+	limiter := utils.NewRateLimiter(bulkOptions.RateLimit)
+	defer limiter.Stop()
+
+	accessToken, err := credentials.GetOAuthAccessToken(credential, importEnvironment)
+	if err != nil {
+		utils.HandleErrorAndExit("Error while getting an access token for importing Apps", err)
+	}
+	devportalApplicationsEndpoint := utils.GetDevPortalApplicationListEndpointOfEnv(importEnvironment,
+		utils.MainConfigFilePath)
+
+	fmt.Fprintln(out, "Importing "+strconv.Itoa(len(archives))+" App(s) from "+appsDir)
+	utils.RunInParallel(len(archives), bulkOptions.Parallel, func(i int) {
+		importAppArchive(archives[i], accessToken, devportalApplicationsEndpoint, importOptions, bulkOptions,
+			limiter, ledger, report)
+	})
+
+	writeBulkOperationReport(report, reportPath, bulkOptions)
+	if report.Failed > 0 {
+		utils.HandleErrorAndExit(strconv.Itoa(report.Failed)+" App archive(s) could not be imported. Run the command "+
+			"again without --force to resume the import", nil)
+	}
+	fmt.Fprintln(out, "\nCommand: import apps execution completed !")
+}
+
+// Import an Application archive unless the ledger shows it has been already imported, and record the outcome in
+// the ledger and the report
+func importAppArchive(archive MigrationAppArchive, accessToken, devportalApplicationsEndpoint string,
+	importOptions BulkImportAppsOptions, bulkOptions utils.BulkOperationOptions, limiter *utils.RateLimiter,
+	ledger *utils.BulkOperationLedger, report *utils.BulkOperationReport) {
+	out := bulkOptions.MessageWriter()
+	key := filepath.Base(archive.Path)
+	result := utils.BulkArtifactResult{Key: key, Type: "app", Name: archive.Name, Owner: archive.Owner}
+	if ledger.IsCompleted(key) {
+		utils.Logln(utils.LogPrefixInfo + "Skipping " + key + " as it has been already imported")
+		result.Status = utils.BulkStatusSkipped
+		report.Add(result)
+		return
+	}
+	startTime := time.Now()
+	var err error
+	result.Attempts, err = utils.RunWithRetry(bulkOptions.MaxRetries, func() error {
+		limiter.Wait()
+		_, importErr := ImportApplication(out, accessToken, devportalApplicationsEndpoint, archive.Path, "",
+			importOptions.Update, importOptions.PreserveOwner, importOptions.SkipSubscriptions,
+			importOptions.SkipKeys, importOptions.SkipCleanup)
+		// importing an application is retried on a server error only if it updates the existing application
+		return utils.GetBulkError(importErr, importOptions.Update)
+	})
+	result.DurationMillis = time.Since(startTime).Milliseconds()
+	result.Status = utils.BulkStatusSucceeded
+	if err != nil {
+		fmt.Fprintln(out, "Error importing App archive:", key, "-", err)
+		result.Status = utils.BulkStatusFailed
+		result.Error = err.Error()
+	}
+	if ledgerErr := ledger.Record(key, result.Status); ledgerErr != nil {
+		utils.HandleErrorAndExit("Error writing to the import ledger", ledgerErr)
+	}
+	report.Add(result)
+}
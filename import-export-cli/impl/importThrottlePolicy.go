@@ -21,6 +21,7 @@ package impl
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -30,7 +31,8 @@ import (
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
 
-func ImportThrottlingPolicyToEnv(accessOAuthToken, importEnvironment, importPath string, importThrottlePolicyUpdate bool) error {
+func ImportThrottlingPolicyToEnv(out io.Writer, accessOAuthToken, importEnvironment, importPath string,
+	importThrottlePolicyUpdate bool) error {
 	adminEndpoint := utils.GetAdminEndpointOfEnv(importEnvironment, utils.MainConfigFilePath)
 	if _, err := os.Stat(importPath); err != nil {
 		if !os.IsNotExist(err) {
@@ -38,11 +40,12 @@ func ImportThrottlingPolicyToEnv(accessOAuthToken, importEnvironment, importPath
 		}
 	}
 	uri := adminEndpoint + "/throttling/policies/import"
-	err := importThrottlingPolicy(uri, importPath, accessOAuthToken, true, importThrottlePolicyUpdate)
+	err := importThrottlingPolicy(out, uri, importPath, accessOAuthToken, true, importThrottlePolicyUpdate)
 	return err
 }
 
-func importThrottlingPolicy(endpoint string, importPath string, accessToken string, isOauth bool, ThrottlePolicyUpdate bool) error {
+func importThrottlingPolicy(out io.Writer, endpoint string, importPath string, accessToken string, isOauth bool,
+	ThrottlePolicyUpdate bool) error {
 	exportDirectory := filepath.Join(utils.ExportDirectory, utils.ExportedPoliciesDirName, utils.ExportedThrottlePoliciesDirName)
 	resolvedPolicyFilePath, err := resolvePolicyImportFilePath(importPath, exportDirectory)
 	if err != nil {
@@ -59,16 +62,16 @@ func importThrottlingPolicy(endpoint string, importPath string, accessToken stri
 	}
 	if resp.StatusCode() == http.StatusCreated || resp.StatusCode() == http.StatusOK {
 		// 201 Created or 200 OK
-		fmt.Println(resp.String())
+		fmt.Fprintln(out, resp.String())
 		return nil
 	} else {
 		// We have an HTTP error
 		if resp.StatusCode() == http.StatusConflict && ThrottlePolicyUpdate {
-			fmt.Println("Cannot Update")
+			fmt.Fprintln(out, "Cannot Update")
 		}
-		fmt.Println("Error importing Throttling Policy.")
-		fmt.Println("Status: " + resp.Status())
-		fmt.Println("Response:", resp.IsSuccess())
+		fmt.Fprintln(out, "Error importing Throttling Policy.")
+		fmt.Fprintln(out, "Status: "+resp.Status())
+		fmt.Fprintln(out, "Response:", resp.IsSuccess())
 
 		return errors.New(resp.Status())
 	}
@@ -0,0 +1,419 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/loads"
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// LintBuiltInPolicyName is the policy under which the violations of the built-in rules are reported
+const LintBuiltInPolicyName = "apictl built-in rules"
+
+// asyncAPITypes are the API types defined using an AsyncAPI definition
+var asyncAPITypes = []string{"WS", "WEBSUB", "SSE", "WEBHOOK", "ASYNC"}
+
+// authSecuritySchemes are the security schemes of api.yaml which authenticate the requests to an API
+var authSecuritySchemes = []string{"oauth2", "api_key", "basic_auth", "mutualssl"}
+
+// LintOptions controls the rules evaluated by the lint command
+type LintOptions struct {
+	// RulesetPaths are Spectral compatible rulesets evaluated in addition to the built-in rules
+	RulesetPaths []string
+	// ParamsPath is a params file or a deployment directory whose endpoints are checked
+	ParamsPath string
+	// ProductionEnvironments are the environments of the params file treated as production
+	ProductionEnvironments []string
+	// SkipBuiltInRules evaluates only the given rulesets
+	SkipBuiltInRules bool
+}
+
+// lintProject is an API project loaded for linting
+type lintProject struct {
+	api                *v2.APIDTODefinition
+	metadataDocument   interface{}
+	definitionPath     string
+	definitionFormat   string
+	definitionDocument interface{}
+}
+
+// LintAPIProject checks an API project against the built-in rules and the given rulesets without connecting to an
+// environment. The violations are returned in the same shape as the governance violations returned on import.
+func LintAPIProject(projectPath string, options LintOptions) ([]Violation, error) {
+	var rulesets []*LintRuleset
+	for _, rulesetPath := range options.RulesetPaths {
+		ruleset, err := LoadLintRuleset(rulesetPath)
+		if err != nil {
+			return nil, errors.New("Error loading the ruleset " + rulesetPath + ": " + err.Error())
+		}
+		rulesets = append(rulesets, ruleset)
+	}
+
+	utils.Logln(utils.LogPrefixInfo + "Creating workspace")
+	tmpPath, err := utils.GetTempCloneFromDirOrZip(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		utils.Logln(utils.LogPrefixInfo+"Deleting", tmpPath)
+		if err := os.RemoveAll(tmpPath); err != nil {
+			utils.Logln(utils.LogPrefixError + err.Error())
+		}
+	}()
+	project, err := loadLintProject(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := []Violation{}
+	if !options.SkipBuiltInRules {
+		builtIn := Violation{Policy: LintBuiltInPolicyName}
+		metadataViolations := lintAPIMetadata(tmpPath, project)
+		if options.ParamsPath != "" {
+			paramsViolations, err := lintProductionEndpoints(options.ParamsPath, options.ProductionEnvironments)
+			if err != nil {
+				return nil, err
+			}
+			metadataViolations = append(metadataViolations, paramsViolations...)
+		}
+		if len(metadataViolations) > 0 {
+			builtIn.Rulesets = append(builtIn.Rulesets, Ruleset{Ruleset: "api-metadata",
+				Type: LintRuleTypeAPIMetadata, RuleViolations: metadataViolations})
+		}
+		if definitionViolations := lintAPIDefinition(project); len(definitionViolations) > 0 {
+			builtIn.Rulesets = append(builtIn.Rulesets, Ruleset{Ruleset: "api-definition",
+				Type: LintRuleTypeAPIDefinition, RuleViolations: definitionViolations})
+		}
+		if len(builtIn.Rulesets) > 0 {
+			violations = append(violations, builtIn)
+		}
+	}
+
+	for _, ruleset := range rulesets {
+		var ruleViolations []RuleViolation
+		if ruleset.RuleType == LintRuleTypeAPIMetadata {
+			ruleViolations = ruleset.Evaluate(project.metadataDocument, "")
+		} else if project.definitionDocument != nil {
+			ruleViolations = ruleset.Evaluate(project.definitionDocument, project.definitionFormat)
+		}
+		if len(ruleViolations) > 0 {
+			violations = append(violations, Violation{Policy: ruleset.Name, Rulesets: []Ruleset{
+				{Ruleset: ruleset.Name, Type: ruleset.RuleType, RuleViolations: ruleViolations}}})
+		}
+	}
+	return violations, nil
+}
+
+// GetHighestLintSeverity returns the most severe severity among the violations, empty if there are none
+func GetHighestLintSeverity(violations []Violation) string {
+	highest := ""
+	for _, violation := range violations {
+		for _, ruleset := range violation.Rulesets {
+			for _, ruleViolation := range ruleset.RuleViolations {
+				if highest == "" || LintSeverityRank(ruleViolation.Severity) < LintSeverityRank(highest) {
+					highest = ruleViolation.Severity
+				}
+			}
+		}
+	}
+	return highest
+}
+
+// Load api.yaml and the API definition of a project
+func loadLintProject(projectPath string) (*lintProject, error) {
+	apiInfo, apiContent, err := GetAPIDefinition(projectPath)
+	if err != nil {
+		return nil, errors.New("Error reading the API definition of " + projectPath + ": " + err.Error())
+	}
+	project := &lintProject{api: &apiInfo.Data}
+	if err = json.Unmarshal(apiContent, &project.metadataDocument); err != nil {
+		return nil, err
+	}
+
+	definitionsDir := filepath.Join(projectPath, utils.InitProjectDefinitions)
+	var definitionContent []byte
+	switch apiType := strings.ToUpper(project.api.Type); {
+	case apiType == "GRAPHQL":
+		project.definitionPath = filepath.Join(projectPath, utils.InitProjectDefinitionsGraphQLSchema)
+		project.definitionFormat = LintFormatGraphQL
+		if !utils.IsFileExist(project.definitionPath) {
+			project.definitionPath = ""
+		}
+		return project, nil
+	case containsIgnoreCase(apiType, asyncAPITypes):
+		project.definitionPath, definitionContent, err = resolveYamlOrJSON(filepath.Join(definitionsDir, "asyncapi"))
+	default:
+		project.definitionPath, definitionContent, err = resolveYamlOrJSON(filepath.Join(definitionsDir, "swagger"))
+	}
+	if err != nil {
+		// a missing definition is reported as a violation
+		project.definitionPath = ""
+		return project, nil
+	}
+	if err = json.Unmarshal(definitionContent, &project.definitionDocument); err != nil {
+		return nil, errors.New("Error reading " + project.definitionPath + ": " + err.Error())
+	}
+	if document, ok := project.definitionDocument.(map[string]interface{}); ok {
+		if swaggerVersion, ok := document["swagger"]; ok && fmt.Sprint(swaggerVersion) == "2.0" {
+			project.definitionFormat = LintFormatOAS2
+		} else if openAPIVersion, ok := document["openapi"]; ok && strings.HasPrefix(fmt.Sprint(openAPIVersion), "3") {
+			project.definitionFormat = LintFormatOAS3
+		} else if _, ok := document["asyncapi"]; ok {
+			project.definitionFormat = LintFormatAsyncAPI
+		}
+	}
+	return project, nil
+}
+
+// Check api.yaml for operations without security or scopes and for inconsistencies with api_meta.yaml
+func lintAPIMetadata(projectPath string, project *lintProject) []RuleViolation {
+	var violations []RuleViolation
+	metaFilePath := filepath.Join(projectPath, utils.MetaFileAPI)
+	if utils.IsFileExist(metaFilePath) {
+		metaData, err := loadLintMetaData(metaFilePath)
+		if err != nil {
+			violations = append(violations, RuleViolation{Path: utils.MetaFileAPI, Severity: LintSeverityError,
+				Message: "api-meta-valid: " + err.Error()})
+		} else {
+			if metaData.Name != "" && metaData.Name != project.api.Name {
+				violations = append(violations, RuleViolation{Path: "name", Severity: LintSeverityError,
+					Message: "api-meta-consistent: name " + project.api.Name + " does not match the name " +
+						metaData.Name + " in " + utils.MetaFileAPI})
+			}
+			if metaData.Version != "" && metaData.Version != project.api.Version {
+				violations = append(violations, RuleViolation{Path: "version", Severity: LintSeverityError,
+					Message: "api-meta-consistent: version " + project.api.Version + " does not match the version " +
+						metaData.Version + " in " + utils.MetaFileAPI})
+			}
+		}
+	}
+
+	if len(project.api.SecurityScheme) > 0 {
+		secured := false
+		for _, scheme := range project.api.SecurityScheme {
+			secured = secured || containsIgnoreCase(scheme, authSecuritySchemes)
+		}
+		if !secured {
+			violations = append(violations, RuleViolation{Path: "securityScheme", Severity: LintSeverityError,
+				Message: "api-security-defined: none of the security schemes " +
+					strings.Join(project.api.SecurityScheme, ", ") + " authenticate the requests"})
+		}
+	}
+
+	for i, operation := range project.api.Operations {
+		operationMap, ok := operation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		operationName := fmt.Sprint(operationMap["verb"]) + " " + fmt.Sprint(operationMap["target"])
+		operationPath := "operations." + strconv.Itoa(i)
+		if authType, _ := operationMap["authType"].(string); strings.EqualFold(authType, "None") {
+			violations = append(violations, RuleViolation{Path: operationPath + ".authType",
+				Severity: LintSeverityError,
+				Message:  "operation-security-defined: " + operationName + " does not require authentication"})
+			continue
+		}
+		if scopes, _ := operationMap["scopes"].([]interface{}); len(scopes) == 0 {
+			violations = append(violations, RuleViolation{Path: operationPath + ".scopes", Severity: LintSeverityWarn,
+				Message: "operation-scopes-defined: " + operationName + " is not protected by a scope"})
+		}
+	}
+	return violations
+}
+
+func loadLintMetaData(path string) (*utils.MetaData, error) {
+	fileContent, err := params.GetEnvSubstitutedFileContent(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := utils.YamlToJson([]byte(fileContent))
+	if err != nil {
+		return nil, err
+	}
+	metaData := &utils.MetaData{}
+	if err = json.Unmarshal(jsonContent, metaData); err != nil {
+		return nil, err
+	}
+	return metaData, nil
+}
+
+// Check the API definition exists and declares the same version and context as api.yaml
+func lintAPIDefinition(project *lintProject) []RuleViolation {
+	if project.definitionPath == "" {
+		return []RuleViolation{{Path: utils.InitProjectDefinitions, Severity: LintSeverityError,
+			Message: "api-definition-exists: the API definition of the " + project.api.Type + " API is missing"}}
+	}
+	declared := &v2.APIDTODefinition{}
+	contextDeclared := false
+	switch project.definitionFormat {
+	case LintFormatOAS2:
+		document, err := loads.Spec(project.definitionPath)
+		if err != nil {
+			return []RuleViolation{{Path: filepath.Base(project.definitionPath), Severity: LintSeverityError,
+				Message: "api-definition-valid: " + err.Error()}}
+		}
+		if err = v2.Swagger2Populate(declared, document); err != nil {
+			return []RuleViolation{{Path: filepath.Base(project.definitionPath), Severity: LintSeverityError,
+				Message: "api-definition-valid: " + err.Error()}}
+		}
+		_, wso2BasePathDeclared := document.Spec().Extensions.GetString("x-wso2-basepath")
+		contextDeclared = document.BasePath() != "" || wso2BasePathDeclared
+	case LintFormatOAS3:
+		document, err := openapi3.NewLoader().LoadFromFile(project.definitionPath)
+		if err == nil {
+			err = v2.OAI3Populate(declared, document)
+		}
+		if err != nil {
+			return []RuleViolation{{Path: filepath.Base(project.definitionPath), Severity: LintSeverityError,
+				Message: "api-definition-valid: " + err.Error()}}
+		}
+		contextDeclared = declared.Context != ""
+	case LintFormatAsyncAPI:
+		if document, ok := project.definitionDocument.(map[string]interface{}); ok {
+			if info, ok := document["info"].(map[string]interface{}); ok {
+				declared.Version, _ = info["version"].(string)
+			}
+		}
+	default:
+		return nil
+	}
+
+	var violations []RuleViolation
+	if declared.Version != "" && declared.Version != project.api.Version {
+		violations = append(violations, RuleViolation{Path: "info.version", Severity: LintSeverityError,
+			Message: "api-version-matches-definition: version " + declared.Version + " of the definition does not " +
+				"match the version " + project.api.Version + " in api.yaml"})
+	}
+	if contextDeclared && normalizeLintContext(declared.Context, project.api.Version) !=
+		normalizeLintContext(project.api.Context, project.api.Version) {
+		violations = append(violations, RuleViolation{Path: "basePath", Severity: LintSeverityWarn,
+			Message: "api-context-matches-definition: base path " + declared.Context + " of the definition does " +
+				"not match the context " + project.api.Context + " in api.yaml"})
+	}
+	return violations
+}
+
+// Normalize a context so that /pets, /pets/1.0.0 and /pets/{version} are considered the same
+func normalizeLintContext(context, version string) string {
+	context = path.Clean("/" + strings.ReplaceAll(context, "{version}", version))
+	return strings.TrimSuffix(context, "/"+version)
+}
+
+// Check the production endpoints of the production environments of a params file use https
+func lintProductionEndpoints(paramsPath string, productionEnvironments []string) ([]RuleViolation, error) {
+	var apiParams *params.ApiParams
+	var err error
+	if info, statErr := os.Stat(paramsPath); statErr == nil && info.IsDir() {
+		apiParams, err = params.LoadApiParamsFromDirectory(paramsPath)
+	} else {
+		apiParams, err = params.LoadApiParamsFromFile(paramsPath)
+	}
+	if err != nil {
+		return nil, errors.New("Error loading the params " + paramsPath + ": " + err.Error())
+	}
+	var violations []RuleViolation
+	for i, environment := range apiParams.Environments {
+		if !containsIgnoreCase(environment.Name, productionEnvironments) {
+			continue
+		}
+		var configs interface{} = environment.Config
+		for _, insecure := range findInsecureProductionUrls(normalizeLintParams(configs),
+			"environments."+strconv.Itoa(i)+".configs", false) {
+			violations = append(violations, RuleViolation{Path: insecure[0], Severity: LintSeverityError,
+				Message: "production-endpoint-https: production endpoint " + insecure[1] + " of the " +
+					environment.Name + " environment does not use https"})
+		}
+	}
+	return violations, nil
+}
+
+// Convert the maps decoded from the params file to map[string]interface{}
+func normalizeLintParams(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = normalizeLintParams(item)
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[key] = normalizeLintParams(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeLintParams(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+// Find the http:// urls under the production endpoints of an environment, ex: endpoints.production.url or
+// failoverEndpoints.productionFailovers[*].url. Returns the path and the url of each.
+func findInsecureProductionUrls(value interface{}, valuePath string, production bool) [][2]string {
+	var insecure [][2]string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedLintKeys(v) {
+			isProduction := production
+			if strings.Contains(strings.ToLower(key), "production") {
+				isProduction = true
+			} else if strings.Contains(strings.ToLower(key), "sandbox") {
+				isProduction = false
+			}
+			childPath := valuePath + "." + key
+			if url, ok := v[key].(string); ok && key == "url" && isProduction &&
+				strings.HasPrefix(strings.ToLower(url), "http://") {
+				insecure = append(insecure, [2]string{childPath, url})
+				continue
+			}
+			insecure = append(insecure, findInsecureProductionUrls(v[key], childPath, isProduction)...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			insecure = append(insecure, findInsecureProductionUrls(item, valuePath+"."+strconv.Itoa(i), production)...)
+		}
+	}
+	return insecure
+}
+
+func containsIgnoreCase(element string, slice []string) bool {
+	for _, item := range slice {
+		if strings.EqualFold(item, element) {
+			return true
+		}
+	}
+	return false
+}
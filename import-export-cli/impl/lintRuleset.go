@@ -0,0 +1,576 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Severities of lint violations, ordered from the most severe
+const (
+	LintSeverityError = "error"
+	LintSeverityWarn  = "warn"
+	LintSeverityInfo  = "info"
+	LintSeverityHint  = "hint"
+)
+
+// Types of the documents a ruleset is evaluated against, same as the rule types of the governance rulesets
+const (
+	LintRuleTypeAPIMetadata   = "API_METADATA"
+	LintRuleTypeAPIDefinition = "API_DEFINITION"
+)
+
+// Document formats a rule can be restricted to using "formats"
+const (
+	LintFormatOAS2     = "oas2"
+	LintFormatOAS3     = "oas3"
+	LintFormatAsyncAPI = "asyncapi"
+	LintFormatGraphQL  = "graphql"
+)
+
+var lintSeverities = []string{LintSeverityError, LintSeverityWarn, LintSeverityInfo, LintSeverityHint}
+
+// LintRuleset is a Spectral compatible ruleset. Rules are evaluated against the API definition, unless the
+// ruleset sets ruleType to API_METADATA, in which case they are evaluated against api.yaml
+type LintRuleset struct {
+	Name     string
+	RuleType string
+	Rules    []*LintRule
+}
+
+// LintRule is a single rule of a ruleset
+type LintRule struct {
+	Name        string
+	Description string
+	Message     string
+	Severity    string
+	Formats     []string
+	Given       []string
+	Then        []lintRuleThen
+}
+
+type lintRuleThen struct {
+	Field           string                 `json:"field"`
+	Function        string                 `json:"function"`
+	FunctionOptions map[string]interface{} `json:"functionOptions"`
+}
+
+// rawLintRuleset is the ruleset file as written by the user
+type rawLintRuleset struct {
+	Extends  interface{}                `json:"extends"`
+	RuleType string                     `json:"ruleType"`
+	Rules    map[string]json.RawMessage `json:"rules"`
+}
+
+type rawLintRule struct {
+	Description string          `json:"description"`
+	Message     string          `json:"message"`
+	Severity    interface{}     `json:"severity"`
+	Formats     []string        `json:"formats"`
+	Given       json.RawMessage `json:"given"`
+	Then        json.RawMessage `json:"then"`
+}
+
+// LoadLintRuleset reads a Spectral compatible ruleset in YAML or JSON format
+func LoadLintRuleset(path string) (*LintRuleset, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonContent, err := utils.YamlToJson(content)
+	if err != nil {
+		return nil, err
+	}
+	var raw rawLintRuleset
+	if err = json.Unmarshal(jsonContent, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Extends != nil {
+		utils.Logln(utils.LogPrefixWarning + "Ignoring the extended rulesets of " + path + ". Only the rules " +
+			"defined in the ruleset are evaluated")
+	}
+	ruleset := &LintRuleset{
+		Name:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		RuleType: LintRuleTypeAPIDefinition,
+	}
+	if raw.RuleType != "" {
+		ruleset.RuleType = strings.ToUpper(raw.RuleType)
+		if ruleset.RuleType != LintRuleTypeAPIDefinition && ruleset.RuleType != LintRuleTypeAPIMetadata {
+			return nil, errors.New("unsupported ruleType " + raw.RuleType + " in " + path)
+		}
+	}
+
+	names := make([]string, 0, len(raw.Rules))
+	for name := range raw.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rule, err := parseLintRule(name, raw.Rules[name])
+		if err != nil {
+			return nil, errors.New("invalid rule " + name + " in " + path + ": " + err.Error())
+		}
+		if rule != nil {
+			ruleset.Rules = append(ruleset.Rules, rule)
+		}
+	}
+	return ruleset, nil
+}
+
+// Parse a rule of a ruleset, returns nil if the rule is turned off
+func parseLintRule(name string, data json.RawMessage) (*LintRule, error) {
+	var enabled bool
+	if json.Unmarshal(data, &enabled) == nil {
+		// overriding the rules of an extended ruleset is not supported as extended rulesets are not loaded
+		return nil, nil
+	}
+	var raw rawLintRule
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	rule := &LintRule{Name: name, Description: raw.Description, Message: raw.Message, Formats: raw.Formats}
+
+	severity, err := normalizeLintSeverity(raw.Severity)
+	if err != nil {
+		return nil, err
+	}
+	if severity == "off" {
+		return nil, nil
+	}
+	rule.Severity = severity
+
+	if err = unmarshalOneOrMany(raw.Given, &rule.Given); err != nil || len(rule.Given) == 0 {
+		return nil, errors.New("given should be a JSONPath expression or a list of JSONPath expressions")
+	}
+	for _, given := range rule.Given {
+		if _, err = parseLintJSONPath(given); err != nil {
+			return nil, err
+		}
+	}
+	if err = unmarshalOneOrMany(raw.Then, &rule.Then); err != nil || len(rule.Then) == 0 {
+		return nil, errors.New("then should be an object or a list of objects")
+	}
+	for _, then := range rule.Then {
+		if _, ok := lintFunctions[then.Function]; !ok {
+			return nil, errors.New("unsupported function " + then.Function)
+		}
+	}
+	return rule, nil
+}
+
+// Unmarshal a value which is either a single item or a list of items
+func unmarshalOneOrMany(data json.RawMessage, result interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if data[0] != '[' {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+	return json.Unmarshal(data, result)
+}
+
+// Convert the severity of a Spectral rule, given as a name or as a number from 0 (error) to 3 (hint)
+func normalizeLintSeverity(severity interface{}) (string, error) {
+	switch value := severity.(type) {
+	case nil:
+		return LintSeverityWarn, nil
+	case float64:
+		if value >= 0 && int(value) < len(lintSeverities) {
+			return lintSeverities[int(value)], nil
+		}
+		if value == -1 {
+			return "off", nil
+		}
+	case string:
+		switch value = strings.ToLower(value); value {
+		case "off":
+			return value, nil
+		case "warning":
+			return LintSeverityWarn, nil
+		case "information":
+			return LintSeverityInfo, nil
+		}
+		for _, known := range lintSeverities {
+			if value == known {
+				return value, nil
+			}
+		}
+	case bool:
+		if !value {
+			return "off", nil
+		}
+		return LintSeverityWarn, nil
+	}
+	return "", fmt.Errorf("unsupported severity %v", severity)
+}
+
+// LintSeverityRank returns the position of a severity, lower being more severe
+func LintSeverityRank(severity string) int {
+	for i, known := range lintSeverities {
+		if severity == known {
+			return i
+		}
+	}
+	return len(lintSeverities)
+}
+
+// Evaluate evaluates the rules of the ruleset against a document of the given format
+func (r *LintRuleset) Evaluate(document interface{}, format string) []RuleViolation {
+	violations := []RuleViolation{}
+	for _, rule := range r.Rules {
+		if !rule.appliesTo(format) {
+			continue
+		}
+		violations = append(violations, rule.evaluate(document)...)
+	}
+	return violations
+}
+
+func (rule *LintRule) appliesTo(format string) bool {
+	if len(rule.Formats) == 0 {
+		return true
+	}
+	for _, f := range rule.Formats {
+		// formats such as oas3_1 or asyncapi2 narrow down a format
+		if strings.HasPrefix(strings.ToLower(f), format) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *LintRule) evaluate(document interface{}) []RuleViolation {
+	var violations []RuleViolation
+	for _, given := range rule.Given {
+		segments, _ := parseLintJSONPath(given)
+		for _, node := range evaluateLintJSONPath(document, segments) {
+			for _, then := range rule.Then {
+				for _, target := range resolveLintField(node, then.Field) {
+					if message, ok := lintFunctions[then.Function](target, then.FunctionOptions); !ok {
+						violations = append(violations, RuleViolation{
+							Path:     formatLintPath(target.path),
+							Message:  rule.formatMessage(message, target),
+							Severity: rule.Severity,
+						})
+					}
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// Format the message of a violation, replacing the placeholders supported by Spectral
+func (rule *LintRule) formatMessage(functionMessage string, target lintNode) string {
+	message := rule.Message
+	if message == "" {
+		message = rule.Description
+	}
+	if message == "" {
+		message = "{{error}}"
+	}
+	property := ""
+	if len(target.path) > 0 {
+		property = target.path[len(target.path)-1]
+	}
+	value, _ := json.Marshal(target.value)
+	return strings.NewReplacer(
+		"{{error}}", functionMessage,
+		"{{description}}", rule.Description,
+		"{{property}}", property,
+		"{{path}}", formatLintPath(target.path),
+		"{{value}}", string(value),
+	).Replace(message)
+}
+
+func formatLintPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+// lintNode is a value of a document along with its path from the root of the document
+type lintNode struct {
+	path    []string
+	value   interface{}
+	defined bool
+}
+
+// Resolve the field of a "then" clause within a node matched by "given". "@key" targets the keys of an object.
+func resolveLintField(node lintNode, field string) []lintNode {
+	if field == "" {
+		return []lintNode{node}
+	}
+	if field == "@key" {
+		var keys []lintNode
+		if object, ok := node.value.(map[string]interface{}); ok {
+			for _, key := range sortedLintKeys(object) {
+				keys = append(keys, lintNode{path: appendLintPath(node.path, key), value: key, defined: true})
+			}
+		}
+		return keys
+	}
+	if strings.HasPrefix(field, "$") {
+		segments, err := parseLintJSONPath(field)
+		if err != nil {
+			return nil
+		}
+		matches := evaluateLintJSONPath(node.value, segments)
+		for i := range matches {
+			matches[i].path = append(append([]string{}, node.path...), matches[i].path...)
+		}
+		return matches
+	}
+	current := node
+	for _, key := range strings.Split(field, ".") {
+		object, ok := current.value.(map[string]interface{})
+		value, defined := object[key]
+		current = lintNode{path: appendLintPath(current.path, key), value: value, defined: ok && defined}
+	}
+	return []lintNode{current}
+}
+
+// lintFunctions are the Spectral core functions supported in rulesets. A function returns false and the reason
+// when the target violates the rule.
+var lintFunctions = map[string]func(target lintNode, options map[string]interface{}) (string, bool){
+	"truthy": func(target lintNode, options map[string]interface{}) (string, bool) {
+		return "value should be truthy", isLintTruthy(target.value)
+	},
+	"falsy": func(target lintNode, options map[string]interface{}) (string, bool) {
+		return "value should be falsy", !isLintTruthy(target.value)
+	},
+	"defined": func(target lintNode, options map[string]interface{}) (string, bool) {
+		return "value should be defined", target.defined
+	},
+	"undefined": func(target lintNode, options map[string]interface{}) (string, bool) {
+		return "value should be undefined", !target.defined
+	},
+	"pattern": func(target lintNode, options map[string]interface{}) (string, bool) {
+		value, ok := target.value.(string)
+		if !ok {
+			return "", true
+		}
+		if match, ok := options["match"].(string); ok {
+			if matched, err := regexp.MatchString(trimLintRegex(match), value); err != nil || !matched {
+				return "value must match the pattern " + match, false
+			}
+		}
+		if notMatch, ok := options["notMatch"].(string); ok {
+			if matched, err := regexp.MatchString(trimLintRegex(notMatch), value); err != nil || matched {
+				return "value must not match the pattern " + notMatch, false
+			}
+		}
+		return "", true
+	},
+	"enumeration": func(target lintNode, options map[string]interface{}) (string, bool) {
+		if !target.defined {
+			return "", true
+		}
+		values, _ := options["values"].([]interface{})
+		for _, value := range values {
+			if fmt.Sprint(value) == fmt.Sprint(target.value) {
+				return "", true
+			}
+		}
+		return fmt.Sprintf("%v must be one of %v", target.value, values), false
+	},
+	"length": func(target lintNode, options map[string]interface{}) (string, bool) {
+		var length int
+		switch value := target.value.(type) {
+		case string:
+			length = len(value)
+		case []interface{}:
+			length = len(value)
+		case map[string]interface{}:
+			length = len(value)
+		case float64:
+			length = int(value)
+		default:
+			return "", true
+		}
+		if min, ok := options["min"].(float64); ok && length < int(min) {
+			return "length must be at least " + strconv.Itoa(int(min)), false
+		}
+		if max, ok := options["max"].(float64); ok && length > int(max) {
+			return "length must be at most " + strconv.Itoa(int(max)), false
+		}
+		return "", true
+	},
+}
+
+func isLintTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	}
+	return true
+}
+
+// Spectral accepts patterns written as JavaScript regular expression literals, ex: /^[a-z]+$/i
+func trimLintRegex(pattern string) string {
+	if strings.HasPrefix(pattern, "/") {
+		if end := strings.LastIndex(pattern, "/"); end > 0 {
+			flags := pattern[end+1:]
+			pattern = pattern[1:end]
+			if strings.Contains(flags, "i") {
+				pattern = "(?i)" + pattern
+			}
+		}
+	}
+	return pattern
+}
+
+// lintPathSegment is a step of a JSONPath expression
+type lintPathSegment struct {
+	key       string
+	wildcard  bool
+	recursive bool
+}
+
+// Parse the subset of JSONPath used in rulesets: $, .key, ['key'], [n], .*, [*] and ..key
+func parseLintJSONPath(expression string) ([]lintPathSegment, error) {
+	if !strings.HasPrefix(expression, "$") {
+		return nil, errors.New("JSONPath expression " + expression + " should start with $")
+	}
+	var segments []lintPathSegment
+	rest := expression[1:]
+	for rest != "" {
+		segment := lintPathSegment{}
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			segment.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(rest, "."):
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			segment.key, rest = rest[:end], rest[end:]
+			segment.wildcard = segment.key == "*"
+			if segment.key == "" {
+				return nil, errors.New("invalid JSONPath expression " + expression)
+			}
+			segments = append(segments, segment)
+			continue
+		}
+		if !strings.HasPrefix(rest, "[") {
+			return nil, errors.New("invalid JSONPath expression " + expression)
+		}
+		end := strings.Index(rest, "]")
+		if end == -1 {
+			return nil, errors.New("invalid JSONPath expression " + expression)
+		}
+		selector := rest[1:end]
+		rest = rest[end+1:]
+		switch {
+		case selector == "*":
+			segment.wildcard = true
+		case strings.HasPrefix(selector, "?") || strings.Contains(selector, ":") || strings.Contains(selector, ","):
+			return nil, errors.New("unsupported JSONPath selector [" + selector + "] in " + expression)
+		default:
+			segment.key = strings.Trim(selector, `'"`)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// Evaluate a parsed JSONPath expression against a document
+func evaluateLintJSONPath(document interface{}, segments []lintPathSegment) []lintNode {
+	nodes := []lintNode{{path: []string{}, value: document, defined: true}}
+	for _, segment := range segments {
+		var next []lintNode
+		for _, node := range nodes {
+			candidates := []lintNode{node}
+			if segment.recursive {
+				candidates = collectLintDescendants(node)
+			}
+			for _, candidate := range candidates {
+				next = append(next, selectLintChildren(candidate, segment)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// Select the children of a node matching a segment of a JSONPath expression
+func selectLintChildren(node lintNode, segment lintPathSegment) []lintNode {
+	var children []lintNode
+	switch value := node.value.(type) {
+	case map[string]interface{}:
+		if segment.wildcard {
+			for _, key := range sortedLintKeys(value) {
+				children = append(children, lintNode{path: appendLintPath(node.path, key), value: value[key], defined: true})
+			}
+		} else if child, ok := value[segment.key]; ok {
+			children = append(children, lintNode{path: appendLintPath(node.path, segment.key), value: child, defined: true})
+		}
+	case []interface{}:
+		if segment.wildcard {
+			for i, item := range value {
+				children = append(children, lintNode{path: appendLintPath(node.path, strconv.Itoa(i)), value: item, defined: true})
+			}
+		} else if index, err := strconv.Atoi(segment.key); err == nil && index >= 0 && index < len(value) {
+			children = append(children, lintNode{path: appendLintPath(node.path, segment.key), value: value[index], defined: true})
+		}
+	}
+	return children
+}
+
+// Collect a node and all of its descendants, used by the recursive descent (..) operator
+func collectLintDescendants(node lintNode) []lintNode {
+	nodes := []lintNode{node}
+	for _, child := range selectLintChildren(node, lintPathSegment{wildcard: true}) {
+		nodes = append(nodes, collectLintDescendants(child)...)
+	}
+	return nodes
+}
+
+func sortedLintKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendLintPath(path []string, key string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), key)
+}
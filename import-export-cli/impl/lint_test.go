@@ -0,0 +1,237 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintTestAPIYaml = `type: api
+version: v4.5.0
+data:
+  name: PizzaShackAPI
+  context: /pizzashack
+  version: 1.0.0
+  provider: admin
+  type: HTTP
+  securityScheme:
+    - oauth2
+  operations:
+    - target: /menu
+      verb: GET
+      authType: None
+    - target: /order
+      verb: POST
+      authType: Application & Application User
+      scopes: []
+    - target: /order/{orderId}
+      verb: GET
+      authType: Application & Application User
+      scopes:
+        - read_order
+`
+
+const lintTestOpenAPI = `openapi: 3.0.1
+info:
+  title: PizzaShackAPI
+  version: 2.0.0
+servers:
+  - url: https://localhost:8243/pizza/1.0.0
+paths:
+  /menu:
+    get:
+      responses:
+        "200":
+          description: OK
+  /order:
+    post:
+      description: Create an order
+      responses:
+        "201":
+          description: Created
+`
+
+const lintTestParams = `environments:
+  - name: production
+    configs:
+      endpoints:
+        production:
+          url: http://prod.pizza.com
+        sandbox:
+          url: http://sandbox.pizza.com
+  - name: dev
+    configs:
+      endpoints:
+        production:
+          url: http://dev.pizza.com
+`
+
+const lintTestRuleset = `rules:
+  operation-description:
+    description: Operations should have a description
+    message: "{{property}} is missing"
+    severity: warn
+    given: "$.paths[*][*]"
+    then:
+      field: description
+      function: truthy
+  api-name-pascal-case:
+    message: "API name {{value}} must be in pascal case"
+    severity: error
+    formats: [oas2]
+    given: "$.info.title"
+    then:
+      function: pattern
+      functionOptions:
+        match: "/^[A-Z]/"
+`
+
+func createLintTestProject(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"api.yaml":                 lintTestAPIYaml,
+		"api_meta.yaml":            "name: PizzaShackAPI\nversion: 1.0.1\n",
+		"Definitions/swagger.yaml": lintTestOpenAPI,
+		"../params.yaml":           lintTestParams,
+		"../design-rules.yaml":     lintTestRuleset,
+		"../metadata-rules.yaml":   "ruleType: API_METADATA\nrules:\n  api-provider:\n    severity: info\n    given: $.data\n    then:\n      field: businessInformation\n      function: defined\n",
+	}
+	project := filepath.Join(dir, "PizzaShackAPI")
+	for name, content := range files {
+		path := filepath.Join(project, filepath.FromSlash(name))
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	return project
+}
+
+func getLintMessages(violations []Violation) map[string][]string {
+	messages := make(map[string][]string)
+	for _, violation := range violations {
+		for _, ruleset := range violation.Rulesets {
+			for _, ruleViolation := range ruleset.RuleViolations {
+				messages[ruleset.Ruleset] = append(messages[ruleset.Ruleset],
+					ruleViolation.Severity+" "+ruleViolation.Path)
+			}
+		}
+	}
+	return messages
+}
+
+func TestLintAPIProjectBuiltInRules(t *testing.T) {
+	project := createLintTestProject(t)
+	violations, err := LintAPIProject(project, LintOptions{ParamsPath: filepath.Join(project, "..", "params.yaml"),
+		ProductionEnvironments: []string{"production"}})
+	assert.Nil(t, err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, LintBuiltInPolicyName, violations[0].Policy)
+
+	messages := getLintMessages(violations)
+	assert.Equal(t, []string{
+		"error version",
+		"error operations.0.authType",
+		"warn operations.1.scopes",
+		"error environments.0.configs.endpoints.production.url",
+	}, messages["api-metadata"])
+	assert.Equal(t, []string{"error info.version", "warn basePath"}, messages["api-definition"])
+	assert.Equal(t, LintSeverityError, GetHighestLintSeverity(violations))
+}
+
+func TestLintAPIProjectRulesets(t *testing.T) {
+	project := createLintTestProject(t)
+	violations, err := LintAPIProject(project, LintOptions{SkipBuiltInRules: true, RulesetPaths: []string{
+		filepath.Join(project, "..", "design-rules.yaml"), filepath.Join(project, "..", "metadata-rules.yaml")}})
+	assert.Nil(t, err)
+	assert.Len(t, violations, 2)
+
+	assert.Equal(t, "design-rules", violations[0].Policy)
+	assert.Equal(t, LintRuleTypeAPIDefinition, violations[0].Rulesets[0].Type)
+	// the pascal case rule is restricted to OpenAPI 2 documents
+	assert.Equal(t, []RuleViolation{{Path: "paths./menu.get.description", Message: "description is missing",
+		Severity: LintSeverityWarn}}, violations[0].Rulesets[0].RuleViolations)
+
+	assert.Equal(t, LintRuleTypeAPIMetadata, violations[1].Rulesets[0].Type)
+	assert.Equal(t, "data.businessInformation", violations[1].Rulesets[0].RuleViolations[0].Path)
+	assert.Equal(t, LintSeverityWarn, GetHighestLintSeverity(violations))
+}
+
+func TestLintAPIProjectMissingDefinition(t *testing.T) {
+	project := createLintTestProject(t)
+	assert.Nil(t, os.RemoveAll(filepath.Join(project, "Definitions")))
+	violations, err := LintAPIProject(project, LintOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, getLintMessages(violations)["api-definition"], "error Definitions")
+}
+
+func TestLoadLintRulesetRejectsUnsupportedRules(t *testing.T) {
+	dir := t.TempDir()
+	rulesets := map[string]string{
+		"function.yaml": "rules:\n  r:\n    given: $.info\n    then:\n      function: schema\n",
+		"filter.yaml":   "rules:\n  r:\n    given: $.paths[?(@.get)]\n    then:\n      function: truthy\n",
+		"severity.yaml": "rules:\n  r:\n    severity: fatal\n    given: $.info\n    then:\n      function: truthy\n",
+		"type.yaml":     "ruleType: API_DOCUMENTATION\nrules: {}\n",
+	}
+	for name, content := range rulesets {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, ioutil.WriteFile(path, []byte(content), 0644))
+		_, err := LoadLintRuleset(path)
+		assert.NotNil(t, err, name)
+	}
+}
+
+func TestEvaluateLintJSONPath(t *testing.T) {
+	document := map[string]interface{}{
+		"info": map[string]interface{}{"title": "Pizza"},
+		"paths": map[string]interface{}{
+			"/menu":  map[string]interface{}{"get": map[string]interface{}{"tags": []interface{}{"menu"}}},
+			"/order": map[string]interface{}{"post": map[string]interface{}{"tags": []interface{}{"order", "x"}}},
+		},
+	}
+	tests := map[string][]string{
+		"$.info.title":         {"info.title"},
+		"$['info']['title']":   {"info.title"},
+		"$.paths[*].*.tags[1]": {"paths./order.post.tags.1"},
+		"$..tags":              {"paths./menu.get.tags", "paths./order.post.tags"},
+		"$.paths./menu":        {"paths./menu"},
+		"$.missing[*]":         {},
+	}
+	for expression, expected := range tests {
+		segments, err := parseLintJSONPath(expression)
+		assert.Nil(t, err, expression)
+		paths := []string{}
+		for _, node := range evaluateLintJSONPath(document, segments) {
+			paths = append(paths, formatLintPath(node.path))
+		}
+		assert.Equal(t, expected, paths, expression)
+	}
+}
+
+func TestNormalizeLintSeverity(t *testing.T) {
+	for severity, expected := range map[interface{}]string{nil: LintSeverityWarn, float64(0): LintSeverityError,
+		float64(3): LintSeverityHint, "warning": LintSeverityWarn, "INFO": LintSeverityInfo, "off": "off"} {
+		normalized, err := normalizeLintSeverity(severity)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, normalized)
+	}
+}
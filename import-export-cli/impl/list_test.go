@@ -269,3 +269,152 @@ func TestGetAPIProductListUnreachable(t *testing.T) {
 		t.Error("Error should not be nil")
 	}
 }
+
+func TestGetAPISubscriptionListOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiId") != "api-1" || r.URL.Query().Get("limit") != "10" ||
+			r.URL.Query().Get("offset") != "20" {
+			t.Errorf("Incorrect query params. Got '%s'\n", r.URL.RawQuery)
+		}
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "list": [{"subscriptionId": "sub-1", "applicationInfo": {"applicationId": "app-1",
+			"name": "SampleApp", "subscriber": "admin"}, "throttlingPolicy": "Gold", "subscriptionStatus": "UNBLOCKED"}]}`))
+	}))
+	defer server.Close()
+
+	count, list, err := GetAPISubscriptionList("access_token", server.URL, "api-1", "10", "20")
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if count != 1 || len(list) != 1 {
+		t.Fatalf("Incorrect count. Expected %d, got %d\n", 1, count)
+	}
+	expected := utils.SubscriptionEntry{Id: "sub-1", ApplicationId: "app-1", ApplicationName: "SampleApp",
+		ApplicationOwner: "admin", ApiId: "api-1", ThrottlingPolicy: "Gold", Status: "UNBLOCKED"}
+	if list[0] != expected {
+		t.Errorf("Incorrect subscription. Expected %+v, got %+v\n", expected, list[0])
+	}
+}
+
+func TestGetApplicationSubscriptionListOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("applicationId") != "app-1" {
+			t.Errorf("Incorrect query params. Got '%s'\n", r.URL.RawQuery)
+		}
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "list": [{"subscriptionId": "sub-1", "applicationId": "app-1", "apiId": "api-1",
+			"apiInfo": {"name": "PizzaShackAPI", "version": "1.0.0"}, "applicationInfo": {"name": "SampleApp",
+			"owner": "admin"}, "throttlingPolicy": "Gold", "status": "UNBLOCKED"}]}`))
+	}))
+	defer server.Close()
+
+	_, list, err := GetApplicationSubscriptionList("access_token", server.URL, "app-1", "", "")
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if len(list) != 1 || list[0].ApiName != "PizzaShackAPI" || list[0].ApiVersion != "1.0.0" ||
+		list[0].ApplicationOwner != "admin" {
+		t.Errorf("Incorrect subscriptions. Got %+v\n", list)
+	}
+}
+
+func TestGetKeyManagerListOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 2, "list": [{"id": "km-1", "name": "Resident Key Manager", "type": "default",
+			"enabled": true}, {"id": "km-2", "name": "Okta", "type": "Okta", "enabled": false}]}`))
+	}))
+	defer server.Close()
+
+	count, list, err := GetKeyManagerList("access_token", server.URL)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if count != 2 || len(list) != 2 || list[1].Name != "Okta" || list[1].Enabled {
+		t.Errorf("Incorrect key managers. Got %+v\n", list)
+	}
+}
+
+func TestGetGatewayEnvironmentListOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(utils.HeaderContentType, utils.HeaderValueApplicationJSON)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "list": [{"id": "gw-1", "name": "Default", "type": "hybrid",
+			"gatewayType": "Regular", "isReadOnly": true, "vhosts": [{"host": "localhost"}, {"host": "gw.wso2.com"}]}]}`))
+	}))
+	defer server.Close()
+
+	_, list, err := GetGatewayEnvironmentList("access_token", server.URL)
+	if err != nil {
+		t.Fatal("Error" + err.Error())
+	}
+	if len(list) != 1 || !list[0].IsReadOnly {
+		t.Fatalf("Incorrect gateway environments. Got %+v\n", list)
+	}
+	if hosts := (gatewayEnvironment{list[0]}).Hosts(); hosts != "localhost,gw.wso2.com" {
+		t.Errorf("Incorrect hosts. Expected '%s', got '%s'\n", "localhost,gw.wso2.com", hosts)
+	}
+}
+
+func TestGetTenantListUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "inactive" {
+			t.Errorf("Incorrect query params. Got '%s'\n", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	count, list, err := GetTenantList("access_token", server.URL, "inactive", "", "")
+	if count != 0 || list != nil {
+		t.Errorf("Expected an empty tenant list, got %d tenants\n", count)
+	}
+	if err == nil {
+		t.Error("Error should not be nil")
+	}
+}
+
+func TestGetDeploymentsOfRevisions(t *testing.T) {
+	revisions := []utils.Revisions{
+		{ID: "rev-1", RevisionNumber: "Revision 1", Deployments: []utils.Deployment{
+			{Name: "Default", Vhost: "localhost", Status: "APPROVED"}, {Name: "Internal"}}},
+		{ID: "rev-2", RevisionNumber: "Revision 2"},
+	}
+	deployments := GetDeploymentsOfRevisions(revisions)
+	if len(deployments) != 2 {
+		t.Fatalf("Incorrect count. Expected %d, got %d\n", 2, len(deployments))
+	}
+	if deployments[0].Revision != "Revision 1" || deployments[0].GatewayEnvironment != "Default" ||
+		deployments[0].Vhost != "localhost" || deployments[1].GatewayEnvironment != "Internal" {
+		t.Errorf("Incorrect deployments. Got %+v\n", deployments)
+	}
+}
+
+func TestGetAPIListInvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 1, "list": [`))
+	}))
+	defer server.Close()
+
+	_, list, err := GetAPIList("access_token", server.URL, "", "")
+	if err == nil {
+		t.Error("Error should not be nil")
+	}
+	if list != nil {
+		t.Errorf("Expected no APIs, got %+v\n", list)
+	}
+}
+
+func TestGetGatewayEnvironmentListConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	_, _, err := GetGatewayEnvironmentList("access_token", server.URL)
+	if err == nil {
+		t.Error("Error should not be nil")
+	}
+}
@@ -138,6 +138,9 @@ func GetPerAPILoggingListFromEnv(credential credentials.Credential, environment,
 
 // PrintAPILoggers
 func PrintAPILoggers(apis []utils.APILogger, format string) {
+	if utils.PrintStructuredOutput(apis, format) {
+		return
+	}
 	if format == "" {
 		format = defaultLoggingApiTableFormat
 	}
@@ -264,6 +267,9 @@ func GetCorrelationLogComponentListFromEnv(credential credentials.Credential, en
 }
 
 func PrintCorrelationLoggers(components []utils.CorrelationComponent, format string) {
+	if utils.PrintStructuredOutput(components, format) {
+		return
+	}
 	if format == "" {
 		format = defaultLoggingCorrelationTableFormat
 	}
@@ -0,0 +1,334 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/specs/params"
+	v2 "github.com/wso2/product-apim-tooling/import-export-cli/specs/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ParamsCertificateTypeEndpoint = "endpoint"
+	ParamsCertificateTypeClient   = "client"
+)
+
+// RenderedAPIParams contains the configurations of an API in an environment after the params are applied
+type RenderedAPIParams struct {
+	Environment            string                 `yaml:"environment" json:"environment"`
+	API                    map[string]interface{} `yaml:"api,omitempty" json:"api,omitempty"`
+	EndpointConfig         map[string]interface{} `yaml:"endpointConfig,omitempty" json:"endpointConfig,omitempty"`
+	Certificates           []RenderedCertificate  `yaml:"certificates,omitempty" json:"certificates,omitempty"`
+	DeploymentEnvironments []interface{}          `yaml:"deploymentEnvironments,omitempty" json:"deploymentEnvironments,omitempty"`
+}
+
+// RenderedCertificate contains an endpoint or client certificate given in the params and the details read from it
+type RenderedCertificate struct {
+	Type      string `yaml:"type" json:"type"`
+	Alias     string `yaml:"alias" json:"alias"`
+	HostName  string `yaml:"hostName,omitempty" json:"hostName,omitempty"`
+	TierName  string `yaml:"tierName,omitempty" json:"tierName,omitempty"`
+	KeyType   string `yaml:"keyType,omitempty" json:"keyType,omitempty"`
+	Path      string `yaml:"path" json:"path"`
+	Subject   string `yaml:"subject,omitempty" json:"subject,omitempty"`
+	Issuer    string `yaml:"issuer,omitempty" json:"issuer,omitempty"`
+	NotBefore string `yaml:"notBefore,omitempty" json:"notBefore,omitempty"`
+	NotAfter  string `yaml:"notAfter,omitempty" json:"notAfter,omitempty"`
+	Error     string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// RenderAPIParams resolves the params in paramsPath for the environment and applies them to the api.yaml of the API
+// project in projectPath, as they would be applied when importing the project with the same params. paramsPath can
+// be a params file or a deployment directory and projectPath can be empty, in which case only the endpoints and the
+// certificates are rendered.
+func RenderAPIParams(paramsPath, environment, projectPath string) (*RenderedAPIParams, error) {
+	paramsFilePath := paramsPath
+	if info, err := os.Stat(paramsPath); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		paramsFilePath = filepath.Join(paramsPath, utils.ParamFile)
+	}
+	document, err := params.LoadResolvedApiParams(paramsFilePath)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := getResolvedEnvironmentConfigs(document, environment)
+	if err != nil {
+		return nil, errors.New(err.Error() + " in " + paramsPath)
+	}
+
+	rendered := &RenderedAPIParams{Environment: environment}
+	var data map[string]interface{}
+	if projectPath != "" {
+		_, apiContent, err := resolveYamlOrJSON(filepath.Join(projectPath, strings.TrimSuffix(
+			utils.APIDefinitionFileYaml, filepath.Ext(utils.APIDefinitionFileYaml))))
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(apiContent, &rendered.API); err != nil {
+			return nil, err
+		}
+		data, _ = rendered.API["data"].(map[string]interface{})
+		if data == nil {
+			return nil, errors.New("api.yaml of " + projectPath + " does not contain the API data")
+		}
+	}
+
+	var currentEndpointConfig map[string]interface{}
+	if data != nil {
+		currentEndpointConfig, _ = data["endpointConfig"].(map[string]interface{})
+	}
+	rendered.EndpointConfig = renderEndpointConfig(currentEndpointConfig, configs)
+	if data != nil {
+		if rendered.EndpointConfig != nil {
+			data["endpointConfig"] = rendered.EndpointConfig
+		}
+		if policies, ok := configs["policies"]; ok {
+			data["policies"] = policies
+		}
+	}
+	rendered.DeploymentEnvironments, _ = configs["deploymentEnvironments"].([]interface{})
+	rendered.Certificates = renderParamsCertificates(filepath.Join(filepath.Dir(paramsFilePath),
+		utils.DeploymentCertificatesDirectory), configs)
+	return rendered, nil
+}
+
+// PrintRenderedAPIParams prints the rendered params as YAML, or as JSON if the format is json. The values resolved
+// from secret references are masked.
+func PrintRenderedAPIParams(w io.Writer, rendered *RenderedAPIParams, format string) error {
+	var content []byte
+	var err error
+	if format == "json" {
+		content, err = json.MarshalIndent(rendered, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = yaml.Marshal(rendered)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write([]byte(utils.MaskSecrets(string(content))))
+	return err
+}
+
+func getResolvedEnvironmentConfigs(document map[string]interface{}, environment string) (map[string]interface{},
+	error) {
+	envs, _ := document["environments"].([]interface{})
+	for _, env := range envs {
+		envMap, _ := env.(map[string]interface{})
+		if envMap == nil || fmt.Sprint(envMap["name"]) != environment {
+			continue
+		}
+		configs, _ := envMap["configs"].(map[string]interface{})
+		if len(configs) == 0 {
+			return nil, errors.New("configs value is empty for the environment '" + environment + "'")
+		}
+		return configs, nil
+	}
+	return nil, errors.New("Environment '" + environment + "' does not exist")
+}
+
+// renderEndpointConfig builds the endpointConfig of the API from the endpoints and the endpoint security in the
+// configs. The current endpointConfig is kept when the configs do not override the endpoints.
+func renderEndpointConfig(current, configs map[string]interface{}) map[string]interface{} {
+	endpointType, _ := configs["endpointType"].(string)
+	routingPolicy, _ := configs["endpointRoutingPolicy"].(string)
+	endpointImplementationType := v2.EpHttp
+	if endpointType == "soap" {
+		endpointImplementationType = "address"
+	}
+
+	var endpointConfig map[string]interface{}
+	switch {
+	case endpointType == "aws":
+		awsEndpoints, _ := configs["awsLambdaEndpoints"].(map[string]interface{})
+		endpointConfig = map[string]interface{}{
+			"endpoint_type": "awslambda",
+			"access_method": awsEndpoints["accessMethod"],
+			"amznAccessKey": awsEndpoints["amznAccessKey"],
+			"amznSecretKey": awsEndpoints["amznSecretKey"],
+			"amznRegion":    awsEndpoints["amznRegion"],
+		}
+	case endpointType == "dynamic":
+		endpointConfig = map[string]interface{}{
+			"endpoint_type":        "default",
+			"production_endpoints": map[string]interface{}{"url": "default"},
+			"sandbox_endpoints":    map[string]interface{}{"url": "default"},
+		}
+	case routingPolicy == "load_balanced":
+		loadBalanceEndpoints, _ := configs["loadBalanceEndpoints"].(map[string]interface{})
+		endpointConfig = map[string]interface{}{"endpoint_type": v2.EpLoadbalance}
+		for _, key := range []string{"algoClassName", "sessionManagement", "sessionTimeOut"} {
+			if value, ok := loadBalanceEndpoints[key]; ok {
+				endpointConfig[key] = value
+			}
+		}
+		setRenderedEndpointList(endpointConfig, "production_endpoints", loadBalanceEndpoints["production"],
+			endpointImplementationType)
+		setRenderedEndpointList(endpointConfig, "sandbox_endpoints", loadBalanceEndpoints["sandbox"],
+			endpointImplementationType)
+	case routingPolicy == "failover":
+		failoverEndpoints, _ := configs["failoverEndpoints"].(map[string]interface{})
+		endpointConfig = map[string]interface{}{"endpoint_type": v2.EpFailover, "failOver": true}
+		setRenderedEndpoint(endpointConfig, "production_endpoints", failoverEndpoints["production"],
+			endpointImplementationType)
+		setRenderedEndpointList(endpointConfig, "production_failovers", failoverEndpoints["productionFailovers"],
+			endpointImplementationType)
+		setRenderedEndpoint(endpointConfig, "sandbox_endpoints", failoverEndpoints["sandbox"],
+			endpointImplementationType)
+		setRenderedEndpointList(endpointConfig, "sandbox_failovers", failoverEndpoints["sandboxFailovers"],
+			endpointImplementationType)
+	default:
+		endpoints, ok := configs["endpoints"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		endpointConfig = map[string]interface{}{"endpoint_type": endpointImplementationType}
+		setRenderedEndpoint(endpointConfig, "production_endpoints", endpoints["production"], "")
+		setRenderedEndpoint(endpointConfig, "sandbox_endpoints", endpoints["sandbox"], "")
+	}
+
+	if endpointConfig == nil && current != nil {
+		endpointConfig = params.DeepMerge(current, map[string]interface{}{}).(map[string]interface{})
+	}
+	security, ok := configs["security"].(map[string]interface{})
+	if !ok {
+		if endpointConfig != nil && current != nil && current["endpoint_security"] != nil {
+			endpointConfig["endpoint_security"] = current["endpoint_security"]
+		}
+		return endpointConfig
+	}
+	if endpointConfig == nil {
+		endpointConfig = map[string]interface{}{}
+	}
+	endpointSecurity := make(map[string]interface{})
+	for _, keyType := range []string{"production", "sandbox"} {
+		keyTypeSecurity, ok := security[keyType].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rendered := params.DeepMerge(map[string]interface{}{}, keyTypeSecurity).(map[string]interface{})
+		if securityType, ok := rendered["type"].(string); ok {
+			rendered["type"] = strings.ToUpper(securityType)
+		}
+		endpointSecurity[keyType] = rendered
+	}
+	endpointConfig["endpoint_security"] = endpointSecurity
+	return endpointConfig
+}
+
+func setRenderedEndpoint(endpointConfig map[string]interface{}, key string, endpoint interface{},
+	endpointImplementationType string) {
+	endpointMap, ok := endpoint.(map[string]interface{})
+	if !ok {
+		return
+	}
+	rendered := params.DeepMerge(map[string]interface{}{}, endpointMap).(map[string]interface{})
+	if endpointImplementationType != "" {
+		rendered["endpoint_type"] = endpointImplementationType
+	}
+	endpointConfig[key] = rendered
+}
+
+func setRenderedEndpointList(endpointConfig map[string]interface{}, key string, endpoints interface{},
+	endpointImplementationType string) {
+	endpointList, ok := endpoints.([]interface{})
+	if !ok {
+		return
+	}
+	rendered := make([]interface{}, 0, len(endpointList))
+	for _, endpoint := range endpointList {
+		endpointMap, ok := endpoint.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		renderedEndpoint := params.DeepMerge(map[string]interface{}{}, endpointMap).(map[string]interface{})
+		renderedEndpoint["endpoint_type"] = endpointImplementationType
+		rendered = append(rendered, renderedEndpoint)
+	}
+	endpointConfig[key] = rendered
+}
+
+// renderParamsCertificates reads the endpoint and client certificates of the configs from the certificates
+// directory. A certificate which cannot be read is rendered with the error instead of failing the whole rendering.
+func renderParamsCertificates(certificatesDir string, configs map[string]interface{}) []RenderedCertificate {
+	var certificates []RenderedCertificate
+	for _, certType := range []string{ParamsCertificateTypeEndpoint, ParamsCertificateTypeClient} {
+		key := "certs"
+		if certType == ParamsCertificateTypeClient {
+			key = "mutualSslCerts"
+		}
+		certs, _ := configs[key].([]interface{})
+		for _, cert := range certs {
+			certMap, _ := cert.(map[string]interface{})
+			certificate := RenderedCertificate{
+				Type:     certType,
+				Alias:    getStringValue(certMap, "alias"),
+				HostName: getStringValue(certMap, "hostName"),
+				TierName: getStringValue(certMap, "tierName"),
+				KeyType:  getStringValue(certMap, "keyType"),
+				Path:     getStringValue(certMap, "path"),
+			}
+			readRenderedCertificate(filepath.Join(certificatesDir, certificate.Path), &certificate)
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates
+}
+
+func readRenderedCertificate(path string, certificate *RenderedCertificate) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		certificate.Error = err.Error()
+		return
+	}
+	der := content
+	if block, _ := pem.Decode(content); block != nil {
+		der = block.Bytes
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		certificate.Error = "unable to parse the certificate: " + err.Error()
+		return
+	}
+	certificate.Subject = cert.Subject.String()
+	certificate.Issuer = cert.Issuer.String()
+	certificate.NotBefore = cert.NotBefore.UTC().Format(time.RFC3339)
+	certificate.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+}
+
+func getStringValue(values map[string]interface{}, key string) string {
+	if value, ok := values[key]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const renderTestAPIYaml = `type: api
+version: v4.5.0
+data:
+  name: PizzaShackAPI
+  version: 1.0.0
+  policies:
+    - Unlimited
+  endpointConfig:
+    endpoint_type: http
+    production_endpoints:
+      url: https://localhost:9443/am/sample/pizzashack/v1/api/
+`
+
+const renderTestBaseParams = `environments:
+  - name: prod
+    configs:
+      endpointRoutingPolicy: failover
+      failoverEndpoints:
+        production:
+          url: https://prod.pizza.com
+        productionFailovers:
+          - url: https://prod-backup.pizza.com
+      security:
+        production:
+          enabled: true
+          type: basic
+          username: admin
+          password: admin
+      certs:
+        - hostName: https://prod.pizza.com
+          alias: pizza
+          path: pizza.crt
+        - hostName: https://prod-backup.pizza.com
+          alias: backup
+          path: backup.crt
+`
+
+const renderTestParams = `extends: base_params.yaml
+environments:
+  - name: prod-eu
+    extends: prod
+    configs:
+      policies:
+        - Gold
+      deploymentEnvironments:
+        - deploymentEnvironment: Default
+          deploymentVhost: eu.pizza.com
+`
+
+func writeTestCertificate(t *testing.T, path string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "prod.pizza.com"},
+		NotBefore:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2034, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+}
+
+func createRenderTestFiles(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	projectPath := filepath.Join(dir, "PizzaShackAPI")
+	deploymentDir := filepath.Join(dir, "DeploymentArtifacts_PizzaShackAPI-1.0.0")
+	assert.Nil(t, os.MkdirAll(projectPath, os.ModePerm))
+	assert.Nil(t, os.MkdirAll(filepath.Join(deploymentDir, utils.DeploymentCertificatesDirectory), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "api.yaml"), []byte(renderTestAPIYaml), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(deploymentDir, "base_params.yaml"), []byte(renderTestBaseParams), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(deploymentDir, utils.ParamFile), []byte(renderTestParams), 0644))
+	writeTestCertificate(t, filepath.Join(deploymentDir, utils.DeploymentCertificatesDirectory, "pizza.crt"))
+	return projectPath, deploymentDir
+}
+
+func TestRenderAPIParamsAppliesResolvedParams(t *testing.T) {
+	projectPath, deploymentDir := createRenderTestFiles(t)
+	rendered, err := RenderAPIParams(deploymentDir, "prod-eu", projectPath)
+	assert.Nil(t, err)
+
+	data := rendered.API["data"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"Gold"}, data["policies"])
+	assert.Equal(t, rendered.EndpointConfig, data["endpointConfig"])
+
+	endpointConfig := rendered.EndpointConfig
+	assert.Equal(t, "failover", endpointConfig["endpoint_type"])
+	assert.Equal(t, "https://prod.pizza.com",
+		endpointConfig["production_endpoints"].(map[string]interface{})["url"])
+	assert.Len(t, endpointConfig["production_failovers"], 1)
+	security := endpointConfig["endpoint_security"].(map[string]interface{})["production"].(map[string]interface{})
+	assert.Equal(t, "BASIC", security["type"])
+
+	assert.Len(t, rendered.DeploymentEnvironments, 1)
+	assert.Len(t, rendered.Certificates, 2)
+	assert.Equal(t, ParamsCertificateTypeEndpoint, rendered.Certificates[0].Type)
+	assert.Equal(t, "CN=prod.pizza.com", rendered.Certificates[0].Subject)
+	assert.Equal(t, "2034-01-01T00:00:00Z", rendered.Certificates[0].NotAfter)
+	assert.NotEmpty(t, rendered.Certificates[1].Error, "A missing certificate should be rendered with the error")
+}
+
+func TestRenderAPIParamsWithoutProject(t *testing.T) {
+	_, deploymentDir := createRenderTestFiles(t)
+	rendered, err := RenderAPIParams(filepath.Join(deploymentDir, utils.ParamFile), "prod", "")
+	assert.Nil(t, err)
+	assert.Nil(t, rendered.API)
+	assert.Equal(t, "failover", rendered.EndpointConfig["endpoint_type"])
+
+	var out bytes.Buffer
+	assert.Nil(t, PrintRenderedAPIParams(&out, rendered, ""))
+	assert.Contains(t, out.String(), "environment: prod\n")
+
+	_, err = RenderAPIParams(deploymentDir, "dev", "")
+	assert.NotNil(t, err, "Should return an error for an environment which is not in the params")
+}
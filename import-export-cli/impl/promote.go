@@ -0,0 +1,671 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/credentials"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// Steps of a promotion recorded in the promotion log
+const (
+	PromotionStepExport           = "export"
+	PromotionStepSnapshot         = "snapshot"
+	PromotionStepImport           = "import"
+	PromotionStepCreateRevision   = "create-revision"
+	PromotionStepDeploy           = "deploy"
+	PromotionStepSmokeCheck       = "smoke-check"
+	PromotionStepUndeployPrevious = "undeploy-previous"
+	PromotionStepRollback         = "rollback"
+)
+
+// Statuses of a promotion
+const (
+	PromotionStatusInProgress = "in-progress"
+	PromotionStatusSucceeded  = "succeeded"
+	PromotionStatusRolledBack = "rolled-back"
+	PromotionStatusFailed     = "failed"
+)
+
+// maxRevisionsOfAPI is the number of revisions an API can have in API Manager
+const maxRevisionsOfAPI = 5
+
+// SmokeCheck is the HTTP probe sent to the promoted API in the gateway after the new revision is deployed
+type SmokeCheck struct {
+	// URL overrides the gateway URL of the API resolved from the deployed gateway environment
+	URL string
+	// Path is appended to the URL of the API, ex: /health
+	Path string
+	// Method is the HTTP method of the probe
+	Method string
+	// ExpectedStatus is the status code of a successful probe, any 2xx status is accepted if it is 0
+	ExpectedStatus int
+	// Timeout is the time the probe is retried until it succeeds, as the gateway needs time to deploy the API
+	Timeout time.Duration
+	// Interval is the time between two probes
+	Interval time.Duration
+}
+
+// PromoteOptions controls how an API is promoted from an environment to another
+type PromoteOptions struct {
+	Name     string
+	Version  string
+	Provider string
+	From     string
+	To       string
+	// Revision is the revision number promoted from the From environment, the newest deployed revision if empty
+	Revision string
+	// ParamsPath is the api_params.yaml applied when importing the API to the To environment
+	ParamsPath string
+	// GatewayEnvs are the gateway environments (<name> or <name>:<vhost>) the new revision is deployed in. The
+	// gateway environments of the previously deployed revisions are used if empty
+	GatewayEnvs        []string
+	DisplayOnDevportal bool
+	PreserveProvider   bool
+	// RotateRevision deletes the oldest undeployed revision when the API has the maximum number of revisions
+	RotateRevision bool
+	SkipSmokeCheck bool
+	SmokeCheck     SmokeCheck
+	// KeyCredential and TokenEndpoint are used to generate the key of the smoke check, as done by get keys
+	KeyCredential credentials.Credential
+	TokenEndpoint string
+}
+
+// PromotionStep is the outcome of a single step of a promotion
+type PromotionStep struct {
+	Name           string    `json:"name"`
+	Status         string    `json:"status"`
+	Message        string    `json:"message,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	DurationMillis int64     `json:"durationMillis"`
+	FinishedAt     time.Time `json:"finishedAt"`
+}
+
+// PromotionLog records every step of a promotion. It is written to its path after each step, so that the steps
+// done so far are known even if the promotion is interrupted.
+type PromotionLog struct {
+	Type           string          `json:"type"`
+	Name           string          `json:"name"`
+	Version        string          `json:"version"`
+	Provider       string          `json:"provider,omitempty"`
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	Revision       string          `json:"revision,omitempty"`
+	TargetRevision string          `json:"targetRevision,omitempty"`
+	Status         string          `json:"status"`
+	StartedAt      time.Time       `json:"startedAt"`
+	FinishedAt     time.Time       `json:"finishedAt,omitempty"`
+	Steps          []PromotionStep `json:"steps"`
+
+	path string
+}
+
+// NewPromotionLog starts the log of promoting the API in options, which is written to path if it is not empty
+func NewPromotionLog(options *PromoteOptions, path string) *PromotionLog {
+	return &PromotionLog{
+		Type:      utils.ProjectTypeApi,
+		Name:      options.Name,
+		Version:   options.Version,
+		Provider:  options.Provider,
+		From:      options.From,
+		To:        options.To,
+		Status:    PromotionStatusInProgress,
+		StartedAt: time.Now(),
+		Steps:     []PromotionStep{},
+		path:      path,
+	}
+}
+
+// GetDefaultPromotionLogPath returns the path of the log of promoting an API to the environment to, in the
+// promotions directory of the config directory
+func GetDefaultPromotionLogPath(name, version, to string, startedAt time.Time) string {
+	return filepath.Join(utils.DefaultPromotionLogDirPath,
+		name+"_"+version+"_"+to+"_"+startedAt.Format("20060102150405")+".json")
+}
+
+// Path returns the path the log is written to
+func (l *PromotionLog) Path() string {
+	return l.path
+}
+
+// run runs a step of the promotion and records its outcome. The step returns a message describing what it has done
+func (l *PromotionLog) run(name string, step func() (string, error)) error {
+	utils.Logln(utils.LogPrefixInfo + "Promotion step: " + name)
+	startedAt := time.Now()
+	message, err := step()
+	result := PromotionStep{
+		Name:           name,
+		Status:         utils.BulkStatusSucceeded,
+		Message:        message,
+		DurationMillis: time.Since(startedAt).Milliseconds(),
+		FinishedAt:     time.Now(),
+	}
+	if err != nil {
+		result.Status = utils.BulkStatusFailed
+		result.Error = err.Error()
+	}
+	l.Steps = append(l.Steps, result)
+	if writeErr := l.write(); writeErr != nil {
+		utils.Logln(utils.LogPrefixError + "Writing the promotion log: " + writeErr.Error())
+	}
+	return err
+}
+
+// finish sets the final status of the promotion and writes the log
+func (l *PromotionLog) finish(status string) error {
+	l.Status = status
+	l.FinishedAt = time.Now()
+	return l.write()
+}
+
+// writes the log to its path, if any
+func (l *PromotionLog) write() error {
+	if l.path == "" {
+		return nil
+	}
+	if err := utils.CreateDirIfNotExist(filepath.Dir(l.path)); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(l.path, data, 0644)
+}
+
+// revisionDeployments are the gateways a revision is deployed in
+type revisionDeployments struct {
+	Revision utils.Revisions
+	Gateways []utils.Deployment
+}
+
+// PromoteAPI promotes an API from options.From to options.To. A revision of the API is exported from the From
+// environment, imported to the To environment with the given params, and a new revision is created and deployed.
+// The smoke check is then run against the gateway, and the previously deployed revisions are undeployed from the
+// gateways the new revision is not deployed in. If the deployment or the smoke check fails, the previously
+// deployed revisions are deployed again and the working copy of the API is restored from them.
+// @return error if the promotion failed, even if it is rolled back
+func PromoteAPI(fromAccessToken, toAccessToken string, options *PromoteOptions, log *PromotionLog) error {
+	var zipPath string
+	err := log.run(PromotionStepExport, func() (string, error) {
+		var err error
+		zipPath, err = exportAPIRevisionToPromote(fromAccessToken, options, log)
+		if err != nil {
+			return "", err
+		}
+		return "Exported revision " + log.Revision + " from " + options.From, nil
+	})
+	if err != nil {
+		return failPromotion(log, err)
+	}
+	defer removePromotionWorkspace(filepath.Dir(zipPath))
+
+	var previous []utils.Revisions
+	var deployments []utils.Deployment
+	err = log.run(PromotionStepSnapshot, func() (string, error) {
+		api, err := findAPIInEnv(toAccessToken, options.To, options.Name, options.Version, options.Provider)
+		if err != nil {
+			return "", err
+		}
+		if api != nil {
+			revisions, err := GetRevisionsOfArtifact(toAccessToken, options.To, api.ID, false)
+			if err != nil {
+				return "", err
+			}
+			previous = getDeployedRevisions(revisions)
+		}
+		deployments, err = resolvePromotionDeployments(toAccessToken, options, previous)
+		if err != nil {
+			return "", err
+		}
+		if len(previous) == 0 {
+			return "No revision is deployed in " + options.To, nil
+		}
+		var deployed []revisionDeployments
+		for _, revision := range previous {
+			deployed = append(deployed, revisionDeployments{Revision: revision, Gateways: revision.Deployments})
+		}
+		return "Deployed in " + options.To + ": " + describeRevisionDeployments(deployed), nil
+	})
+	if err != nil {
+		return failPromotion(log, err)
+	}
+
+	var api *utils.API
+	err = log.run(PromotionStepImport, func() (string, error) {
+		err := ImportAPIToEnv(ioutil.Discard, toAccessToken, options.To, zipPath, options.ParamsPath, true,
+			options.PreserveProvider, false, false, true, false, "")
+		if err != nil {
+			return "", err
+		}
+		api, err = findAPIInEnv(toAccessToken, options.To, options.Name, options.Version, options.Provider)
+		if err == nil && api == nil {
+			err = errors.New("the imported API is not found in " + options.To)
+		}
+		if err != nil {
+			return "", err
+		}
+		return "Imported to " + options.To + " as " + api.ID, nil
+	})
+	if err != nil {
+		return failPromotion(log, err)
+	}
+
+	var revision *utils.Revisions
+	err = log.run(PromotionStepCreateRevision, func() (string, error) {
+		message, err := rotateRevisionToPromote(toAccessToken, options, api.ID)
+		if err != nil {
+			return "", err
+		}
+		revision, err = CreateRevision(toAccessToken, options.To, api.ID,
+			"Promoted revision "+log.Revision+" from "+options.From, false)
+		if err != nil {
+			return "", err
+		}
+		log.TargetRevision = utils.GetRevisionNumFromRevisionName(revision.RevisionNumber)
+		return message + "Created revision " + log.TargetRevision, nil
+	})
+	if err != nil {
+		return failPromotion(log, err)
+	}
+
+	replaced, added, remaining := splitPromotionDeployments(previous, deployments)
+	err = log.run(PromotionStepDeploy, func() (string, error) {
+		err := DeployRevisionOfArtifact(toAccessToken, options.To, api.ID, revision.ID, deployments, false)
+		if err != nil {
+			return "", err
+		}
+		return "Deployed revision " + log.TargetRevision + " to " + describeDeployments(deployments), nil
+	})
+	if err != nil {
+		return rollbackPromotion(toAccessToken, options, log, api.ID, revision, previous, replaced, added, err)
+	}
+
+	if !options.SkipSmokeCheck {
+		err = log.run(PromotionStepSmokeCheck, func() (string, error) {
+			url := options.SmokeCheck.URL
+			if url == "" {
+				var err error
+				url, err = resolveGatewayURLOfAPI(toAccessToken, options.To, api, deployments[0])
+				if err != nil {
+					return "", err
+				}
+			}
+			// failing to get a key fails the smoke check, so that the deployment is rolled back
+			key, err := GenerateAccessTokenOfAPI(options.KeyCredential, options.To, options.Name, options.Version,
+				options.Provider, options.TokenEndpoint)
+			if err != nil {
+				return "", errors.New("cannot get a key to invoke the API: " + err.Error())
+			}
+			status, err := RunSmokeCheck(options.SmokeCheck, url, key)
+			if err != nil {
+				return "", err
+			}
+			return "Received " + strconv.Itoa(status) + " from " + url + options.SmokeCheck.Path, nil
+		})
+		if err != nil {
+			return rollbackPromotion(toAccessToken, options, log, api.ID, revision, previous, replaced, added, err)
+		}
+	}
+
+	err = log.run(PromotionStepUndeployPrevious, func() (string, error) {
+		if len(remaining) == 0 {
+			return "No previous revision is deployed in the other gateways", nil
+		}
+		for _, deployed := range remaining {
+			err := UndeployRevisionOfArtifact(toAccessToken, options.To, api.ID,
+				utils.GetRevisionNumFromRevisionName(deployed.Revision.RevisionNumber), deployed.Gateways, false)
+			if err != nil {
+				return "", err
+			}
+		}
+		return "Undeployed " + describeRevisionDeployments(remaining), nil
+	})
+	if err != nil {
+		// the new revision is live, hence the previous revisions left in the other gateways are not rolled back
+		return failPromotion(log, err)
+	}
+	return log.finish(PromotionStatusSucceeded)
+}
+
+// RunSmokeCheck sends the probe of check to url, with accessToken if it is not empty, until it receives the
+// expected status or the check times out
+// @return status code of the last probe
+// @return error if the expected status is not received
+func RunSmokeCheck(check SmokeCheck, url, accessToken string) (int, error) {
+	url = strings.TrimSuffix(url, "/") + check.Path
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers := make(map[string]string)
+	if accessToken != "" {
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	}
+	if check.Interval <= 0 {
+		return 0, errors.New("the interval of the smoke check should be greater than zero")
+	}
+	client, err := utils.GetHttpClient(url)
+	if err != nil {
+		return 0, err
+	}
+	deadline := time.Now().Add(check.Timeout)
+	for {
+		utils.Logln(utils.LogPrefixInfo+"Smoke check: "+method+" URL:", url)
+		status := 0
+		resp, err := client.R().SetHeaders(headers).Execute(method, url)
+		if err == nil {
+			status = resp.StatusCode()
+			if isExpectedSmokeCheckStatus(check.ExpectedStatus, status) {
+				return status, nil
+			}
+			err = fmt.Errorf("received %s from %s", resp.Status(), url)
+		}
+		if !time.Now().Add(check.Interval).Before(deadline) {
+			return status, err
+		}
+		utils.Logln(utils.LogPrefixInfo + "Smoke check failed, retrying: " + err.Error())
+		time.Sleep(check.Interval)
+	}
+}
+
+func isExpectedSmokeCheckStatus(expected, status int) bool {
+	if expected == 0 {
+		return status >= 200 && status < 300
+	}
+	return status == expected
+}
+
+// exports the revision of the API to promote to a temporary zip file and records its number in the log
+func exportAPIRevisionToPromote(accessToken string, options *PromoteOptions, log *PromotionLog) (string, error) {
+	revisionNum := options.Revision
+	if revisionNum == "" {
+		apiId, err := GetAPIId(accessToken, options.From, options.Name, options.Version, options.Provider)
+		if err != nil {
+			return "", err
+		}
+		revisions, err := GetRevisionsOfArtifact(accessToken, options.From, apiId, false)
+		if err != nil {
+			return "", err
+		}
+		deployed := getDeployedRevisions(revisions)
+		if len(deployed) == 0 {
+			return "", errors.New("no revision of the API is deployed in " + options.From +
+				", specify the revision to promote")
+		}
+		revisionNum = utils.GetRevisionNumFromRevisionName(deployed[0].RevisionNumber)
+	}
+	log.Revision = revisionNum
+
+	resp, err := ExportAPIFromEnv(accessToken, options.Name, options.Version, revisionNum, options.Provider,
+		utils.DefaultExportFormat, options.From, true, false, false)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("error exporting API %s %s from %s: %s %s", options.Name, options.Version,
+			options.From, resp.Status(), string(resp.Body()))
+	}
+	return utils.WriteResponseToTempZip(options.Name+"_"+options.Version+"_"+
+		utils.GetRevisionNamFromRevisionNum(revisionNum)+".zip", resp)
+}
+
+// deletes the oldest undeployed revision if rotating the revisions is enabled and the API has the maximum number
+// of revisions
+func rotateRevisionToPromote(accessToken string, options *PromoteOptions, apiId string) (string, error) {
+	if !options.RotateRevision {
+		return "", nil
+	}
+	revisions, err := GetRevisionsOfArtifact(accessToken, options.To, apiId, false)
+	if err != nil {
+		return "", err
+	}
+	revisionsToPrune := GetRevisionsToPrune(revisions, maxRevisionsOfAPI-1)
+	if len(revisionsToPrune) == 0 {
+		return "", nil
+	}
+	oldest := revisionsToPrune[len(revisionsToPrune)-1]
+	if err := DeleteRevision(accessToken, options.To, apiId, oldest.ID, false); err != nil {
+		return "", err
+	}
+	return "Deleted revision " + utils.GetRevisionNumFromRevisionName(oldest.RevisionNumber) + ". ", nil
+}
+
+// returns the gateways the new revision is deployed in, which are the given gateway environments or the gateways of
+// the previously deployed revisions
+func resolvePromotionDeployments(accessToken string, options *PromoteOptions,
+	previous []utils.Revisions) ([]utils.Deployment, error) {
+	if len(options.GatewayEnvs) != 0 {
+		return ResolveRevisionDeployments(accessToken, options.To, options.GatewayEnvs, options.DisplayOnDevportal)
+	}
+	var deployments []utils.Deployment
+	for _, revision := range previous {
+		deployments = append(deployments, getRedeployableGateways(revision.Deployments)...)
+	}
+	if len(deployments) == 0 {
+		return nil, errors.New("the API is not deployed in " + options.To +
+			", specify the gateway environments to deploy it")
+	}
+	return deployments, nil
+}
+
+// rolls back a failed promotion. The previously deployed revisions are deployed again in the gateways the new
+// revision replaced them, the new revision is undeployed from the other gateways, and the working copy is restored
+// from the newest previously deployed revision.
+func rollbackPromotion(accessToken string, options *PromoteOptions, log *PromotionLog, apiId string,
+	revision *utils.Revisions, previous []utils.Revisions, replaced []revisionDeployments, added []utils.Deployment,
+	cause error) error {
+	err := log.run(PromotionStepRollback, func() (string, error) {
+		var done []string
+		for _, deployed := range replaced {
+			err := DeployRevisionOfArtifact(accessToken, options.To, apiId, deployed.Revision.ID, deployed.Gateways,
+				false)
+			if err != nil {
+				return strings.Join(done, ". "), err
+			}
+			done = append(done, "Deployed "+describeRevisionDeployments([]revisionDeployments{deployed}))
+		}
+		if len(added) != 0 {
+			err := UndeployRevisionOfArtifact(accessToken, options.To, apiId,
+				utils.GetRevisionNumFromRevisionName(revision.RevisionNumber), added, false)
+			if err != nil {
+				return strings.Join(done, ". "), err
+			}
+			done = append(done, "Undeployed revision "+log.TargetRevision+" from "+describeDeployments(added))
+		}
+		if len(previous) != 0 {
+			if err := RestoreRevision(accessToken, options.To, apiId, previous[0].ID, false); err != nil {
+				return strings.Join(done, ". "), err
+			}
+			done = append(done, "Restored the working copy from revision "+
+				utils.GetRevisionNumFromRevisionName(previous[0].RevisionNumber))
+		}
+		return strings.Join(done, ". "), nil
+	})
+	status := PromotionStatusRolledBack
+	if err != nil {
+		status = PromotionStatusFailed
+		utils.Logln(utils.LogPrefixError + "Rolling back the promotion: " + err.Error())
+	}
+	if writeErr := log.finish(status); writeErr != nil {
+		utils.Logln(utils.LogPrefixError + "Writing the promotion log: " + writeErr.Error())
+	}
+	return cause
+}
+
+// marks the promotion as failed and returns the cause
+func failPromotion(log *PromotionLog, cause error) error {
+	if err := log.finish(PromotionStatusFailed); err != nil {
+		utils.Logln(utils.LogPrefixError + "Writing the promotion log: " + err.Error())
+	}
+	return cause
+}
+
+func removePromotionWorkspace(path string) {
+	utils.Logln(utils.LogPrefixInfo+"Deleting", path)
+	if err := os.RemoveAll(path); err != nil {
+		utils.Logln(utils.LogPrefixError + err.Error())
+	}
+}
+
+// returns the API with the exact name, version and provider (if given) in the environment, or nil if it is not found
+func findAPIInEnv(accessToken, environment, name, version, provider string) (*utils.API, error) {
+	query := "name:\"" + name + "\" version:\"" + version + "\""
+	if provider != "" {
+		query += " provider:\"" + provider + "\""
+	}
+	_, apis, err := GetAPIListFromEnv(accessToken, environment, query, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range apis {
+		if apis[i].Name == name && apis[i].Version == version && (provider == "" || apis[i].Provider == provider) {
+			return &apis[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// resolves the URL of the API in the gateway of the deployment
+func resolveGatewayURLOfAPI(accessToken, environment string, api *utils.API, deployment utils.Deployment) (string,
+	error) {
+	_, gatewayEnvironments, err := GetGatewayEnvironmentListFromEnv(accessToken, environment)
+	if err != nil {
+		return "", err
+	}
+	for _, gatewayEnvironment := range gatewayEnvironments {
+		if gatewayEnvironment.Name != deployment.Name {
+			continue
+		}
+		for _, vhost := range gatewayEnvironment.Vhosts {
+			if vhost.Host == deployment.Vhost {
+				return getGatewayURLOfAPI(vhost, api.Context, api.Version), nil
+			}
+		}
+	}
+	return "", errors.New("vhost " + deployment.Vhost + " of gateway environment " + deployment.Name +
+		" is not found")
+}
+
+// returns the HTTPS URL of the API with the context and version in the vhost. The version is appended to the
+// context unless the context has the {version} template
+func getGatewayURLOfAPI(vhost utils.GatewayVhost, context, version string) string {
+	url := "https://" + vhost.Host
+	if vhost.HttpsPort != 0 && vhost.HttpsPort != 443 {
+		url += ":" + strconv.Itoa(vhost.HttpsPort)
+	}
+	if httpContext := strings.Trim(vhost.HttpContext, "/"); httpContext != "" {
+		url += "/" + httpContext
+	}
+	context = "/" + strings.Trim(context, "/")
+	if strings.Contains(context, "{version}") {
+		return url + strings.Replace(context, "{version}", version, -1)
+	}
+	return url + context + "/" + version
+}
+
+// returns the revisions which are deployed in at least a gateway, the newest first
+func getDeployedRevisions(revisions []utils.Revisions) []utils.Revisions {
+	var deployed []utils.Revisions
+	for _, revision := range revisions {
+		if len(revision.Deployments) != 0 {
+			deployed = append(deployed, revision)
+		}
+	}
+	sort.SliceStable(deployed, func(i, j int) bool {
+		return revisionNumberOf(deployed[i]) > revisionNumberOf(deployed[j])
+	})
+	return deployed
+}
+
+// splits the gateways of the previously deployed revisions by the gateways the new revision is deployed in
+// @return replaced: gateways of the previous revisions the new revision replaces
+// @return added: gateways of the new revision no previous revision was deployed in
+// @return remaining: gateways of the previous revisions the new revision is not deployed in
+func splitPromotionDeployments(previous []utils.Revisions, deployments []utils.Deployment) (
+	replaced []revisionDeployments, added []utils.Deployment, remaining []revisionDeployments) {
+	deployedGateways := make(map[string]bool)
+	for _, deployment := range deployments {
+		deployedGateways[deployment.Name] = true
+	}
+	previousGateways := make(map[string]bool)
+	for _, revision := range previous {
+		var replacedGateways, remainingGateways []utils.Deployment
+		for _, gateway := range getRedeployableGateways(revision.Deployments) {
+			previousGateways[gateway.Name] = true
+			if deployedGateways[gateway.Name] {
+				replacedGateways = append(replacedGateways, gateway)
+			} else {
+				remainingGateways = append(remainingGateways, gateway)
+			}
+		}
+		if len(replacedGateways) != 0 {
+			replaced = append(replaced, revisionDeployments{Revision: revision, Gateways: replacedGateways})
+		}
+		if len(remainingGateways) != 0 {
+			remaining = append(remaining, revisionDeployments{Revision: revision, Gateways: remainingGateways})
+		}
+	}
+	for _, deployment := range deployments {
+		if !previousGateways[deployment.Name] {
+			added = append(added, deployment)
+		}
+	}
+	return replaced, added, remaining
+}
+
+// returns the gateways of the deployments without the status, so that they can be deployed again
+func getRedeployableGateways(deployments []utils.Deployment) []utils.Deployment {
+	var gateways []utils.Deployment
+	for _, deployment := range deployments {
+		gateways = append(gateways, utils.Deployment{Name: deployment.Name, Vhost: deployment.Vhost,
+			DisplayOnDevportal: deployment.DisplayOnDevportal})
+	}
+	return gateways
+}
+
+func describeDeployments(deployments []utils.Deployment) string {
+	var names []string
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func describeRevisionDeployments(deployed []revisionDeployments) string {
+	var descriptions []string
+	for _, revisionDeployed := range deployed {
+		descriptions = append(descriptions, "revision "+
+			utils.GetRevisionNumFromRevisionName(revisionDeployed.Revision.RevisionNumber)+" in "+
+			describeDeployments(revisionDeployed.Gateways))
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,133 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetGatewayURLOfAPI(t *testing.T) {
+	assert.Equal(t, "https://gw.wso2.com/pizzashack/1.0.0",
+		getGatewayURLOfAPI(utils.GatewayVhost{Host: "gw.wso2.com", HttpsPort: 443}, "/pizzashack", "1.0.0"))
+	assert.Equal(t, "https://localhost:8243/gateway/1.0.0/pizzashack",
+		getGatewayURLOfAPI(utils.GatewayVhost{Host: "localhost", HttpContext: "/gateway/", HttpsPort: 8243},
+			"/{version}/pizzashack", "1.0.0"))
+}
+
+func TestSplitPromotionDeployments(t *testing.T) {
+	previous := getDeployedRevisions([]utils.Revisions{
+		{ID: "r-1", RevisionNumber: "Revision 1", Deployments: []utils.Deployment{{Name: "EU", Vhost: "eu.wso2.com"}}},
+		{ID: "r-2", RevisionNumber: "Revision 2"},
+		{ID: "r-3", RevisionNumber: "Revision 3", Deployments: []utils.Deployment{
+			{Name: "Default", Vhost: "localhost", Status: "APPROVED", DeployedTime: "1620000000"}}},
+	})
+	assert.Len(t, previous, 2)
+	assert.Equal(t, "r-3", previous[0].ID)
+
+	deployments := []utils.Deployment{{Name: "Default", Vhost: "localhost"}, {Name: "US", Vhost: "us.wso2.com"}}
+	replaced, added, remaining := splitPromotionDeployments(previous, deployments)
+
+	// revision 3 is redeployed to Default on a rollback, without the status of its deployment
+	assert.Equal(t, []revisionDeployments{{Revision: previous[0],
+		Gateways: []utils.Deployment{{Name: "Default", Vhost: "localhost"}}}}, replaced)
+	// the new revision is undeployed from US on a rollback
+	assert.Equal(t, []utils.Deployment{{Name: "US", Vhost: "us.wso2.com"}}, added)
+	// revision 1 is undeployed from EU after a successful promotion
+	assert.Equal(t, []revisionDeployments{{Revision: previous[1],
+		Gateways: []utils.Deployment{{Name: "EU", Vhost: "eu.wso2.com"}}}}, remaining)
+}
+
+func TestRunSmokeCheckRetriesUntilExpectedStatus(t *testing.T) {
+	probes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes++
+		assert.Equal(t, "/pizzashack/1.0.0/menu", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get(utils.HeaderAuthorization))
+		if probes < 3 {
+			// the gateway has not deployed the API yet
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	check := SmokeCheck{Path: "/menu", Timeout: time.Second, Interval: time.Millisecond}
+	status, err := RunSmokeCheck(check, server.URL+"/pizzashack/1.0.0/", "token")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 3, probes)
+}
+
+func TestRunSmokeCheckFailsAfterTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	check := SmokeCheck{ExpectedStatus: http.StatusNoContent, Timeout: 20 * time.Millisecond,
+		Interval: 5 * time.Millisecond}
+	status, err := RunSmokeCheck(check, server.URL, "")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestRunSmokeCheckRejectsInvalidInterval(t *testing.T) {
+	check := SmokeCheck{Timeout: 20 * time.Millisecond}
+	_, err := RunSmokeCheck(check, "http://localhost:8280", "")
+	assert.NotNil(t, err)
+}
+
+func TestPromotionLogIsWrittenAfterEachStep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "promotions", "log.json")
+	log := NewPromotionLog(&PromoteOptions{Name: "PizzaShackAPI", Version: "1.0.0", From: "staging", To: "prod"},
+		path)
+
+	assert.Nil(t, log.run(PromotionStepExport, func() (string, error) { return "Exported revision 2", nil }))
+	written := readPromotionLog(t, path)
+	assert.Equal(t, PromotionStatusInProgress, written.Status)
+	assert.Len(t, written.Steps, 1)
+
+	err := failPromotion(log, log.run(PromotionStepSnapshot, func() (string, error) {
+		return "", assert.AnError
+	}))
+	assert.Equal(t, assert.AnError, err)
+	written = readPromotionLog(t, path)
+	assert.Equal(t, PromotionStatusFailed, written.Status)
+	assert.Equal(t, utils.BulkStatusSucceeded, written.Steps[0].Status)
+	assert.Equal(t, utils.BulkStatusFailed, written.Steps[1].Status)
+	assert.Equal(t, assert.AnError.Error(), written.Steps[1].Error)
+}
+
+func readPromotionLog(t *testing.T, path string) *PromotionLog {
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	log := &PromotionLog{}
+	assert.Nil(t, json.Unmarshal(data, log))
+	return log
+}
@@ -0,0 +1,218 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// GetRevisionArtifactId returns the id of the API, or the API Product if apiProduct is true, which has the revisions
+func GetRevisionArtifactId(accessToken, environment, name, version, provider string, apiProduct bool) (string,
+	error) {
+	if apiProduct {
+		return GetAPIProductId(accessToken, environment, name, version, provider)
+	}
+	return GetAPIId(accessToken, environment, name, version, provider)
+}
+
+// GetRevisionsOfArtifact returns the revisions of the API or API Product with the id artifactId
+func GetRevisionsOfArtifact(accessToken, environment, artifactId string, apiProduct bool) ([]utils.Revisions, error) {
+	url := getRevisionArtifactEndpoint(environment, apiProduct) + artifactId + "/revisions"
+	if apiProduct {
+		_, revisions, err := GetAPIProductRevisionsList(accessToken, url)
+		return revisions, err
+	}
+	_, revisions, err := GetRevisionsList(accessToken, url)
+	return revisions, err
+}
+
+// FindRevisionByNumber returns the revision with the revisionNum (ex: 3) from revisions
+func FindRevisionByNumber(revisions []utils.Revisions, revisionNum string) (*utils.Revisions, error) {
+	for i := range revisions {
+		if utils.GetRevisionNumFromRevisionName(revisions[i].RevisionNumber) == revisionNum {
+			return &revisions[i], nil
+		}
+	}
+	return nil, errors.New("revision " + revisionNum + " is not found")
+}
+
+// CreateRevision creates a revision from the current working copy of the API or API Product with the id artifactId
+// @return the created revision
+func CreateRevision(accessToken, environment, artifactId, description string, apiProduct bool) (*utils.Revisions,
+	error) {
+	url := getRevisionArtifactEndpoint(environment, apiProduct) + artifactId + "/revisions"
+	body, err := json.Marshal(map[string]string{"description": description})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := invokeRevisionRequest(accessToken, http.MethodPost, url, string(body))
+	if err != nil {
+		return nil, err
+	}
+	revision := &utils.Revisions{}
+	if err := json.Unmarshal(resp.Body(), revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// DeployRevisionOfArtifact deploys the revision with the revisionId of the API or API Product to the gateways
+func DeployRevisionOfArtifact(accessToken, environment, artifactId, revisionId string, gateways []utils.Deployment,
+	apiProduct bool) error {
+	resp, err := deployRevision(accessToken, getRevisionArtifactEndpoint(environment, apiProduct), artifactId,
+		revisionId, gateways)
+	return checkRevisionResponse(resp, err)
+}
+
+// UndeployRevisionOfArtifact undeploys the revision with the revisionNum of the API or API Product from the
+// gateways, or from all the gateways it is deployed in if gateways is empty
+func UndeployRevisionOfArtifact(accessToken, environment, artifactId, revisionNum string, gateways []utils.Deployment,
+	apiProduct bool) error {
+	resp, err := undeployRevision(accessToken, getRevisionArtifactEndpoint(environment, apiProduct), artifactId,
+		revisionNum, gateways, len(gateways) == 0)
+	return checkRevisionResponse(resp, err)
+}
+
+// RestoreRevision replaces the working copy of the API or API Product with the revision with the revisionId
+func RestoreRevision(accessToken, environment, artifactId, revisionId string, apiProduct bool) error {
+	url := getRevisionArtifactEndpoint(environment, apiProduct) + artifactId + "/restore-revision?revisionId=" +
+		revisionId
+	_, err := invokeRevisionRequest(accessToken, http.MethodPost, url, "")
+	return err
+}
+
+// DeleteRevision deletes the revision with the revisionId of the API or API Product. A deployed revision cannot be
+// deleted
+func DeleteRevision(accessToken, environment, artifactId, revisionId string, apiProduct bool) error {
+	url := getRevisionArtifactEndpoint(environment, apiProduct) + artifactId + "/revisions/" + revisionId
+	_, err := invokeRevisionRequest(accessToken, http.MethodDelete, url, "")
+	return err
+}
+
+// GetRevisionsToPrune returns the revisions which are deleted to keep only the newest revisions (by the revision
+// number) in the given count. Deployed revisions are never pruned, hence more revisions than keep can remain
+func GetRevisionsToPrune(revisions []utils.Revisions, keep int) []utils.Revisions {
+	sorted := append([]utils.Revisions{}, revisions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return revisionNumberOf(sorted[i]) > revisionNumberOf(sorted[j])
+	})
+	var revisionsToPrune []utils.Revisions
+	for i, revision := range sorted {
+		if i >= keep && len(revision.Deployments) == 0 {
+			revisionsToPrune = append(revisionsToPrune, revision)
+		}
+	}
+	return revisionsToPrune
+}
+
+// ResolveRevisionDeployments returns the deployments of a revision to gatewayEnvs of the environment. A gateway
+// environment is given as <name> or <name>:<vhost>, and the first vhost of the gateway environment is used when the
+// vhost is not given
+func ResolveRevisionDeployments(accessToken, environment string, gatewayEnvs []string,
+	displayOnDevportal bool) ([]utils.Deployment, error) {
+	_, gatewayEnvironments, err := GetGatewayEnvironmentListFromEnv(accessToken, environment)
+	if err != nil {
+		return nil, err
+	}
+	return resolveRevisionDeployments(gatewayEnvironments, gatewayEnvs, displayOnDevportal)
+}
+
+func resolveRevisionDeployments(gatewayEnvironments []utils.GatewayEnvironment, gatewayEnvs []string,
+	displayOnDevportal bool) ([]utils.Deployment, error) {
+	var deployments []utils.Deployment
+	for _, gatewayEnv := range gatewayEnvs {
+		name, vhost := gatewayEnv, ""
+		if i := strings.Index(gatewayEnv, ":"); i > 0 {
+			name, vhost = gatewayEnv[:i], gatewayEnv[i+1:]
+		}
+		var found *utils.GatewayEnvironment
+		for i := range gatewayEnvironments {
+			if gatewayEnvironments[i].Name == name {
+				found = &gatewayEnvironments[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil, errors.New("gateway environment " + name + " is not found")
+		}
+		if vhost == "" {
+			if len(found.Vhosts) == 0 {
+				return nil, errors.New("gateway environment " + name + " has no vhosts")
+			}
+			vhost = found.Vhosts[0].Host
+		}
+		deployments = append(deployments, utils.Deployment{Name: name, Vhost: vhost,
+			DisplayOnDevportal: displayOnDevportal})
+	}
+	return deployments, nil
+}
+
+// returns the REST API endpoint of the APIs, or the API Products if apiProduct is true, ending with a slash
+func getRevisionArtifactEndpoint(environment string, apiProduct bool) string {
+	if apiProduct {
+		return utils.AppendSlashToString(utils.GetApiProductListEndpointOfEnv(environment, utils.MainConfigFilePath))
+	}
+	return utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath))
+}
+
+// invokes a request on a revision endpoint and returns the response if it is successful
+func invokeRevisionRequest(accessToken, method, url, body string) (*resty.Response, error) {
+	headers := make(map[string]string)
+	headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+	utils.Logln(utils.LogPrefixInfo+method+" URL:", url)
+	var resp *resty.Response
+	var err error
+	switch method {
+	case http.MethodDelete:
+		resp, err = utils.InvokeDELETERequest(url, headers)
+	default:
+		headers[utils.HeaderContentType] = utils.HeaderValueApplicationJSON
+		resp, err = utils.InvokePOSTRequest(url, headers, body)
+	}
+	if err := checkRevisionResponse(resp, err); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// returns an error if the revision request has failed
+func checkRevisionResponse(resp *resty.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+	if !resp.IsSuccess() {
+		return errors.New(resp.Status() + " " + string(resp.Body()))
+	}
+	return nil
+}
+
+// returns the number of the revision, or 0 if the revision name is not in the expected format
+func revisionNumberOf(revision utils.Revisions) int {
+	number, _ := strconv.Atoi(utils.GetRevisionNumFromRevisionName(revision.RevisionNumber))
+	return number
+}
@@ -0,0 +1,74 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+func TestGetRevisionsToPrune(t *testing.T) {
+	revisions := []utils.Revisions{
+		{ID: "r-2", RevisionNumber: "Revision 2"},
+		{ID: "r-10", RevisionNumber: "Revision 10"},
+		{ID: "r-1", RevisionNumber: "Revision 1", Deployments: []utils.Deployment{{Name: "Default"}}},
+		{ID: "r-3", RevisionNumber: "Revision 3"},
+		{ID: "r-9", RevisionNumber: "Revision 9"},
+	}
+
+	var pruned []string
+	for _, revision := range GetRevisionsToPrune(revisions, 2) {
+		pruned = append(pruned, revision.ID)
+	}
+	// the newest revisions 10 and 9 are kept, and the deployed revision 1 is never pruned
+	assert.Equal(t, []string{"r-3", "r-2"}, pruned)
+	assert.Len(t, GetRevisionsToPrune(revisions, 0), 4)
+	assert.Empty(t, GetRevisionsToPrune(revisions, 5))
+}
+
+func TestFindRevisionByNumber(t *testing.T) {
+	revisions := []utils.Revisions{{ID: "r-1", RevisionNumber: "Revision 1"}, {ID: "r-2", RevisionNumber: "Revision 2"}}
+
+	revision, err := FindRevisionByNumber(revisions, "2")
+	assert.Nil(t, err)
+	assert.Equal(t, "r-2", revision.ID)
+
+	_, err = FindRevisionByNumber(revisions, "3")
+	assert.NotNil(t, err)
+}
+
+func TestResolveRevisionDeployments(t *testing.T) {
+	gatewayEnvironments := []utils.GatewayEnvironment{
+		{Name: "Default", Vhosts: []utils.GatewayVhost{{Host: "localhost"}, {Host: "gw.wso2.com"}}},
+		{Name: "US-Region", Vhosts: []utils.GatewayVhost{{Host: "us.wso2.com"}}},
+	}
+
+	deployments, err := resolveRevisionDeployments(gatewayEnvironments, []string{"Default:gw.wso2.com", "US-Region"},
+		true)
+	assert.Nil(t, err)
+	assert.Equal(t, []utils.Deployment{
+		{Name: "Default", Vhost: "gw.wso2.com", DisplayOnDevportal: true},
+		{Name: "US-Region", Vhost: "us.wso2.com", DisplayOnDevportal: true},
+	}, deployments)
+
+	_, err = resolveRevisionDeployments(gatewayEnvironments, []string{"EU-Region"}, true)
+	assert.NotNil(t, err)
+}
@@ -20,6 +20,8 @@ package impl
 
 import (
 	"encoding/json"
+	"errors"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 )
@@ -29,7 +31,7 @@ func UndeployRevisionFromGateways(accessToken, environment, name, version, provi
 
 	apiId, err := GetAPIId(accessToken, environment, name, version, provider)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while getting API Id for undeploy", err)
+		return nil, errors.New("Error while getting API Id for undeploy: " + err.Error())
 	}
 	apiRevisionEndpoint := utils.GetApiListEndpointOfEnv(environment, utils.MainConfigFilePath)
 	return undeployRevision(accessToken, apiRevisionEndpoint, apiId, revisionNum, gateways,
@@ -61,7 +63,7 @@ func undeployRevision(accessToken, undeployRevisionEndpoint, apiId, revisionNum
 
 	body, err := json.Marshal(gateways)
 	if err != nil {
-		utils.HandleErrorAndExit("Error while converting gateways array", err)
+		return nil, errors.New("Error while converting gateways array: " + err.Error())
 	}
 
 	return utils.InvokePOSTRequest(undeployRevisionEndpoint, headers, string(body))
@@ -0,0 +1,462 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	nodeHeader            = "NODE"
+	clusterListColumn     = "List"
+	clusterNodeColumn     = "Node"
+	clusterStatusColumn   = "Status"
+	clusterMessageColumn  = "Message"
+	clusterStatusOK       = "OK"
+	clusterStatusFailed   = "FAILED"
+	defaultNodeStateTable = "table {{.Node}}\t{{.Status}}\t{{.Message}}"
+)
+
+// nodeURLFields are the fields of the artifacts holding the urls the artifacts are served on, which contain the
+// host of each node and hence are compared without the host when finding the drift between the nodes
+var nodeURLFields = map[string]bool{"Url": true, "Wsdl11": true, "Wsdl20": true}
+
+// NodeResult is the result of a request sent to a single node of a micro integrator cluster
+type NodeResult struct {
+	Node   string      `json:"node"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// ClusterResult holds the results of a request sent to every node of a micro integrator cluster
+type ClusterResult struct {
+	Nodes []NodeResult `json:"nodes"`
+	// Drift describes the differences found between the results of the nodes
+	Drift []string `json:"drift,omitempty"`
+}
+
+// Failed returns true if the request failed on any of the nodes
+func (r *ClusterResult) Failed() bool {
+	for _, node := range r.Nodes {
+		if node.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// RunOnAllNodes sends a request concurrently to every micro integrator node of a given environment.
+// request is given the environment addressing a single node, which is used in place of env with the other
+// functions of this package
+func RunOnAllNodes(env string, request func(nodeEnv string) (interface{}, error)) (*ClusterResult, error) {
+	nodes, err := utils.GetMINodeEndpointsOfEnv(env, utils.MainConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	result := &ClusterResult{Nodes: make([]NodeResult, len(nodes))}
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+			utils.Logln(utils.LogPrefixInfo + "Sending the request to node " + node)
+			result.Nodes[i].Node = node
+			value, err := request(utils.GetMINodeEnv(env, node))
+			if err != nil {
+				result.Nodes[i].Error = err.Error()
+				return
+			}
+			result.Nodes[i].Result = value
+		}(i, node)
+	}
+	wg.Wait()
+	return result, nil
+}
+
+// GetNodeDirectory returns a directory in parent for the files downloaded from a node, named after its host and port
+func GetNodeDirectory(parent, node string) (string, error) {
+	name := node
+	if nodeURL, err := url.Parse(node); err == nil && nodeURL.Host != "" {
+		name = nodeURL.Host
+	}
+	directory := filepath.Join(parent, strings.NewReplacer(":", "_", "/", "_").Replace(name))
+	return directory, os.MkdirAll(directory, os.ModePerm)
+}
+
+// PrintClusterArtifactLists prints the artifact lists returned by each node in a single table with a node column,
+// followed by the artifacts which are not deployed on every node or which differ between the nodes
+func PrintClusterArtifactLists(result *ClusterResult, format string) {
+	rows, columns := clusterArtifactRows(result)
+	result.Drift = findArtifactListDrift(result, rows, columns)
+	if utils.PrintStructuredOutput(result, format) {
+		return
+	}
+	if format == "" {
+		format = "table {{." + strings.Join(columns, "}}\t{{.") + "}}"
+	}
+	headers := make(map[string]string)
+	for _, column := range columns {
+		headers[column] = columnHeader(column)
+	}
+	printClusterRows(rows, format, headers)
+	printClusterErrorsAndDrift(result)
+}
+
+// PrintClusterArtifactDetails prints the details of an artifact returned by each node with printDetails.
+// The nodes on which the artifact differs or is missing are reported if detectDrift is set
+func PrintClusterArtifactDetails(result *ClusterResult, artifactName, format string, detectDrift bool,
+	printDetails func(details interface{})) {
+	if detectDrift {
+		result.Drift = findArtifactDetailsDrift(result, artifactName)
+	}
+	if utils.PrintStructuredOutput(result, format) {
+		return
+	}
+	for i, node := range result.Nodes {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(nodeHeader + " - " + node.Node)
+		if node.Error != "" {
+			fmt.Println(utils.LogPrefixError + node.Error)
+		} else {
+			printDetails(node.Result)
+		}
+	}
+	printClusterErrorsAndDrift(&ClusterResult{Drift: result.Drift})
+}
+
+// PrintClusterStateChanges prints the outcome of a change applied to every node, one node per line
+func PrintClusterStateChanges(result *ClusterResult, format string) {
+	if utils.PrintStructuredOutput(result, format) {
+		return
+	}
+	var rows []map[string]interface{}
+	for _, node := range result.Nodes {
+		row := map[string]interface{}{clusterNodeColumn: node.Node, clusterStatusColumn: clusterStatusOK,
+			clusterMessageColumn: node.Result}
+		if node.Error != "" {
+			row[clusterStatusColumn] = clusterStatusFailed
+			row[clusterMessageColumn] = node.Error
+		}
+		rows = append(rows, row)
+	}
+	if format == "" {
+		format = defaultNodeStateTable
+	}
+	printClusterRows(rows, format, map[string]string{
+		clusterNodeColumn:    nodeHeader,
+		clusterStatusColumn:  statusHeader,
+		clusterMessageColumn: "MESSAGE",
+	})
+}
+
+func printClusterRows(rows []map[string]interface{}, format string, headers map[string]string) {
+	clusterContext := getContextWithFormat(format, format)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, row := range rows {
+			if err := t.Execute(w, row); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	if err := clusterContext.Write(renderer, headers); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
+
+func printClusterErrorsAndDrift(result *ClusterResult) {
+	for _, node := range result.Nodes {
+		if node.Error != "" {
+			fmt.Println(utils.LogPrefixError + "node " + node.Node + ": " + node.Error)
+		}
+	}
+	if len(result.Drift) > 0 {
+		fmt.Println("\nDrift detected between the nodes:")
+		for _, drift := range result.Drift {
+			fmt.Println("  " + drift)
+		}
+	}
+}
+
+// clusterArtifactRows flattens the artifact lists of the nodes into one row per artifact and node.
+// The rows are keyed by the field names of the artifacts, the first column is the node and the second one
+// the name of the list for the responses with several lists such as active and faulty composite apps
+func clusterArtifactRows(result *ClusterResult) ([]map[string]interface{}, []string) {
+	columns := []string{clusterNodeColumn}
+	var rows []map[string]interface{}
+	for _, node := range result.Nodes {
+		if node.Error != "" {
+			continue
+		}
+		lists := artifactListsOf(node.Result)
+		for _, list := range lists {
+			if len(lists) > 1 && !containsString(columns, clusterListColumn) {
+				columns = append(columns, clusterListColumn)
+			}
+			for i := 0; i < list.items.Len(); i++ {
+				row := map[string]interface{}{clusterNodeColumn: node.Node, clusterListColumn: list.name}
+				item := reflect.Indirect(list.items.Index(i))
+				for _, field := range scalarFieldsOf(item.Type()) {
+					if !containsString(columns, field) {
+						columns = append(columns, field)
+					}
+					row[field] = item.FieldByName(field).Interface()
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows, columns
+}
+
+type artifactList struct {
+	name  string
+	items reflect.Value
+}
+
+// artifactListsOf returns the slices of artifacts in a list response of the management api
+func artifactListsOf(response interface{}) []artifactList {
+	value := reflect.Indirect(reflect.ValueOf(response))
+	if value.Kind() == reflect.Slice {
+		return []artifactList{{items: value}}
+	}
+	var lists []artifactList
+	if value.Kind() != reflect.Struct {
+		return lists
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			lists = append(lists, artifactList{name: strings.ToLower(columnHeader(field.Name)), items: value.Field(i)})
+		}
+	}
+	return lists
+}
+
+// scalarFieldsOf returns the exported fields of an artifact which can be printed in a table column
+func scalarFieldsOf(artifactType reflect.Type) []string {
+	var fields []string
+	if artifactType.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < artifactType.NumField(); i++ {
+		field := artifactType.Field(i)
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			if field.IsExported() {
+				fields = append(fields, field.Name)
+			}
+		}
+	}
+	return fields
+}
+
+// findArtifactListDrift reports the artifacts which are missing on some of the nodes, identified by their first
+// column, and the artifacts whose columns differ between the nodes. The urls served by the nodes are compared
+// without their hosts
+func findArtifactListDrift(result *ClusterResult, rows []map[string]interface{}, columns []string) []string {
+	var nodes []string
+	for _, node := range result.Nodes {
+		if node.Error == "" {
+			nodes = append(nodes, node.Node)
+		}
+	}
+	if len(nodes) < 2 || len(columns) < 2 {
+		return nil
+	}
+	keyColumn := columns[1]
+	if keyColumn == clusterListColumn && len(columns) > 2 {
+		keyColumn = columns[2]
+	}
+	artifactRows := make(map[string]map[string]string)
+	for _, row := range rows {
+		key := fmt.Sprint(row[keyColumn])
+		if artifactRows[key] == nil {
+			artifactRows[key] = make(map[string]string)
+		}
+		values := make([]string, 0, len(columns))
+		for _, column := range columns[1:] {
+			value := fmt.Sprint(row[column])
+			if nodeURLFields[column] {
+				value = withoutHost(value)
+			}
+			values = append(values, value)
+		}
+		artifactRows[key][row[clusterNodeColumn].(string)] = strings.Join(values, "\t")
+	}
+	return describeDrift(artifactRows, nodes)
+}
+
+// findArtifactDetailsDrift reports the nodes on which the artifact differs from the other nodes or is missing. The
+// urls served by the nodes are compared without their hosts
+func findArtifactDetailsDrift(result *ClusterResult, artifactName string) []string {
+	details := make(map[string]string)
+	var nodes []string
+	for _, node := range result.Nodes {
+		nodes = append(nodes, node.Node)
+		if node.Error != "" {
+			continue
+		}
+		data, err := json.Marshal(withoutNodeHosts(reflect.ValueOf(node.Result)))
+		if err != nil {
+			continue
+		}
+		details[node.Node] = string(data)
+	}
+	if len(details) == 0 || len(nodes) < 2 {
+		return nil
+	}
+	return describeDrift(map[string]map[string]string{artifactName: details}, nodes)
+}
+
+// withoutNodeHosts returns the value of an artifact as a generic value in which the urls served by the node are
+// replaced by their paths
+func withoutNodeHosts(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return withoutNodeHosts(value.Elem())
+	case reflect.Struct:
+		fields := make(map[string]interface{})
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if nodeURLFields[field.Name] && field.Type.Kind() == reflect.String {
+				fields[field.Name] = withoutHost(value.Field(i).String())
+				continue
+			}
+			fields[field.Name] = withoutNodeHosts(value.Field(i))
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = withoutNodeHosts(value.Index(i))
+		}
+		return items
+	case reflect.Invalid:
+		return nil
+	}
+	return value.Interface()
+}
+
+// withoutHost returns an absolute url without its scheme, host and port
+func withoutHost(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return value
+	}
+	parsed.Scheme = ""
+	parsed.Host = ""
+	parsed.User = nil
+	return parsed.String()
+}
+
+// describeDrift describes the artifacts which are not found on every node or are not the same on every node.
+// artifacts maps each artifact to the value it has on the nodes where it is found
+func describeDrift(artifacts map[string]map[string]string, nodes []string) []string {
+	var keys []string
+	for key := range artifacts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var drift []string
+	for _, key := range keys {
+		values := artifacts[key]
+		var missing []string
+		groups := make(map[string][]string)
+		var distinct []string
+		for _, node := range nodes {
+			value, ok := values[node]
+			if !ok {
+				missing = append(missing, node)
+				continue
+			}
+			if _, seen := groups[value]; !seen {
+				distinct = append(distinct, value)
+			}
+			groups[value] = append(groups[value], node)
+		}
+		if len(missing) > 0 {
+			drift = append(drift, key+" is missing on "+strings.Join(missing, ", "))
+		}
+		if len(distinct) > 1 {
+			var differing []string
+			for _, value := range distinct {
+				differing = append(differing, strings.Join(groups[value], ", "))
+			}
+			drift = append(drift, key+" differs between "+strings.Join(differing, " | "))
+		}
+	}
+	return drift
+}
+
+// columnHeader converts a field name to a table header, e.g. FileName to FILE NAME
+func columnHeader(field string) string {
+	var header strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+			header.WriteRune(' ')
+		}
+		header.WriteRune(unicode.ToUpper(r))
+	}
+	return header.String()
+}
+
+// ApplyOnAllNodes applies a change concurrently to every micro integrator node of a given environment and prints
+// the outcome for each node. An error is returned if the change failed on any of the nodes
+func ApplyOnAllNodes(env string, change func(nodeEnv string) (interface{}, error)) error {
+	result, err := RunOnAllNodes(env, change)
+	if err != nil {
+		return err
+	}
+	PrintClusterStateChanges(result, "")
+	failed := 0
+	for _, node := range result.Nodes {
+		if node.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d nodes", failed, len(result.Nodes))
+	}
+	return nil
+}
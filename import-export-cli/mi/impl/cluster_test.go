@@ -0,0 +1,210 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// setUpStubMICluster points the nodes of the dev environment to the stubs and returns the urls of the nodes
+func setUpStubMICluster(t *testing.T, stubs ...*stubManagementAPI) []string {
+	var nodes []string
+	mainConfig := "config:\n  credential_store:\n    type: env\nenvironments:\n  dev:\n    mi_nodes:\n"
+	for _, stub := range stubs {
+		server := httptest.NewServer(stub)
+		t.Cleanup(server.Close)
+		nodes = append(nodes, server.URL)
+		mainConfig += "      - " + server.URL + "\n"
+	}
+
+	mainConfigFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	require.NoError(t, os.WriteFile(mainConfigFilePath, []byte(mainConfig), 0644))
+	originalMainConfigFilePath := utils.MainConfigFilePath
+	utils.MainConfigFilePath = mainConfigFilePath
+	t.Cleanup(func() { utils.MainConfigFilePath = originalMainConfigFilePath })
+
+	t.Setenv("APICTL_DEV_MI_USERNAME", "admin")
+	t.Setenv("APICTL_DEV_MI_PASSWORD", "admin")
+	t.Setenv("APICTL_DEV_MI_TOKEN", "token")
+	return nodes
+}
+
+func newStubWithApps(apps ...artifactutils.CompositeApp) *stubManagementAPI {
+	stub := &stubManagementAPI{active: make(map[string]artifactutils.CompositeApp)}
+	for _, app := range apps {
+		stub.active[app.Name] = app
+	}
+	return stub
+}
+
+func TestRunOnAllNodesListsArtifactsOfEachNode(t *testing.T) {
+	nodes := setUpStubMICluster(t,
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"}),
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"}))
+
+	result, err := RunOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		return GetCompositeAppList(nodeEnv)
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 2)
+	assert.False(t, result.Failed())
+
+	rows, columns := clusterArtifactRows(result)
+	assert.Equal(t, []string{"Node", "List", "Name", "Version"}, columns)
+	require.Len(t, rows, 2)
+	assert.Equal(t, nodes[0], rows[0]["Node"])
+	assert.Equal(t, "active composite apps", rows[0]["List"])
+	assert.Equal(t, "HelloCApp", rows[0]["Name"])
+	assert.Equal(t, nodes[1], rows[1]["Node"])
+	assert.Empty(t, findArtifactListDrift(result, rows, columns))
+}
+
+func TestRunOnAllNodesReportsDrift(t *testing.T) {
+	nodes := setUpStubMICluster(t,
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"},
+			artifactutils.CompositeApp{Name: "OrderCApp", Version: "1.0.0"}),
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "2.0.0"}))
+
+	result, err := RunOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		return GetCompositeAppList(nodeEnv)
+	})
+	require.NoError(t, err)
+
+	rows, columns := clusterArtifactRows(result)
+	assert.Equal(t, []string{
+		"HelloCApp differs between " + nodes[0] + " | " + nodes[1],
+		"OrderCApp is missing on " + nodes[1],
+	}, findArtifactListDrift(result, rows, columns))
+}
+
+func TestRunOnAllNodesReportsArtifactDetailsDrift(t *testing.T) {
+	nodes := setUpStubMICluster(t,
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"}),
+		newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"}),
+		newStubWithApps())
+
+	result, err := RunOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		return GetCompositeApp(nodeEnv, "HelloCApp")
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Failed())
+	assert.Equal(t, "Carbon App not found", result.Nodes[2].Error)
+	assert.Equal(t, []string{"HelloCApp is missing on " + nodes[2]}, findArtifactDetailsDrift(result, "HelloCApp"))
+}
+
+func TestFindDriftIgnoresHostsOfNodeURLs(t *testing.T) {
+	proxySummary := func(host, name string) artifactutils.ProxySummary {
+		return artifactutils.ProxySummary{Name: name, Wsdl11: "http://" + host + ":8290/services/" + name + "?wsdl",
+			Wsdl20: "http://" + host + ":8290/services/" + name + "?wsdl2"}
+	}
+	result := &ClusterResult{Nodes: []NodeResult{
+		{Node: "https://mi-node-1:9164", Result: &artifactutils.ProxyServiceList{Count: 2,
+			Proxies: []artifactutils.ProxySummary{proxySummary("mi-node-1", "StockQuote"),
+				proxySummary("mi-node-1", "Echo")}}},
+		{Node: "https://mi-node-2:9164", Result: &artifactutils.ProxyServiceList{Count: 2,
+			Proxies: []artifactutils.ProxySummary{proxySummary("mi-node-2", "StockQuote"),
+				proxySummary("mi-node-2", "Order")}}},
+	}}
+	rows, columns := clusterArtifactRows(result)
+	assert.Equal(t, []string{
+		"Echo is missing on https://mi-node-2:9164",
+		"Order is missing on https://mi-node-1:9164",
+	}, findArtifactListDrift(result, rows, columns))
+
+	proxy := func(host, tracing string) *artifactutils.Proxy {
+		return &artifactutils.Proxy{Name: "StockQuote", Wsdl11: "http://" + host + ":8290/services/StockQuote?wsdl",
+			Wsdl20: "http://" + host + ":8290/services/StockQuote?wsdl2", Stats: "disabled", Tracing: tracing}
+	}
+	result = &ClusterResult{Nodes: []NodeResult{
+		{Node: "https://mi-node-1:9164", Result: proxy("mi-node-1", "disabled")},
+		{Node: "https://mi-node-2:9164", Result: proxy("mi-node-2", "disabled")},
+	}}
+	assert.Empty(t, findArtifactDetailsDrift(result, "StockQuote"))
+
+	result.Nodes[1].Result = proxy("mi-node-2", "enabled")
+	assert.Equal(t, []string{"StockQuote differs between https://mi-node-1:9164 | https://mi-node-2:9164"},
+		findArtifactDetailsDrift(result, "StockQuote"))
+}
+
+func TestRunOnAllNodesContinuesWhenANodeIsDown(t *testing.T) {
+	down := httptest.NewServer(newStubWithApps())
+	down.Close()
+	nodes := setUpStubMICluster(t, newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"}))
+	mainConfig := "config:\n  credential_store:\n    type: env\nenvironments:\n  dev:\n    mi_nodes:\n" +
+		"      - " + nodes[0] + "\n      - " + down.URL + "\n"
+	require.NoError(t, os.WriteFile(utils.MainConfigFilePath, []byte(mainConfig), 0644))
+
+	result, err := RunOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		return GetCompositeAppList(nodeEnv)
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 2)
+	assert.Empty(t, result.Nodes[0].Error)
+	assert.Contains(t, result.Nodes[1].Error, "Unable to connect to "+down.URL)
+
+	rows, _ := clusterArtifactRows(result)
+	assert.Len(t, rows, 1)
+}
+
+func TestApplyOnAllNodes(t *testing.T) {
+	first := newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"})
+	second := newStubWithApps(artifactutils.CompositeApp{Name: "HelloCApp", Version: "1.0.0"})
+	setUpStubMICluster(t, first, second)
+
+	err := ApplyOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		return UndeployCompositeApp(nodeEnv, "HelloCApp")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HelloCApp"}, first.undeployed)
+	assert.Equal(t, []string{"HelloCApp"}, second.undeployed)
+}
+
+func TestApplyOnAllNodesReturnsErrorOfFailedNodes(t *testing.T) {
+	setUpStubMICluster(t, newStubWithApps(), newStubWithApps(), newStubWithApps())
+
+	err := ApplyOnAllNodes("dev", func(nodeEnv string) (interface{}, error) {
+		if _, node := utils.SplitMINodeEnv(nodeEnv); node == "" {
+			t.Errorf("%s does not address a node", nodeEnv)
+		}
+		return GetCompositeApp(nodeEnv, "HelloCApp")
+	})
+	assert.EqualError(t, err, "failed on 3 of 3 nodes")
+}
+
+func TestGetNodeDirectory(t *testing.T) {
+	parent := t.TempDir()
+	directory, err := GetNodeDirectory(parent, "https://mi-node-1:9164")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(parent, "mi-node-1_9164"), directory)
+	assert.DirExists(t, directory)
+}
+
+func TestColumnHeader(t *testing.T) {
+	assert.Equal(t, "NAME", columnHeader("Name"))
+	assert.Equal(t, "FILE NAME", columnHeader("FileName"))
+	assert.Equal(t, "SERVICE URL", columnHeader("ServiceURL"))
+}
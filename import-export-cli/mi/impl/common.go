@@ -25,6 +25,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sync"
 	"text/template"
 
 	"github.com/go-resty/resty/v2"
@@ -36,6 +37,9 @@ import (
 // miHTTPRetryCount default retry count for HTTP calls
 const miHTTPRetryCount = 2
 
+// miNodeAccessTokens holds the access tokens of the nodes of clusters, keyed by the environment of the node
+var miNodeAccessTokens sync.Map
+
 type updateArtifactRequestBody struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
@@ -52,7 +56,7 @@ func unmarshalData(url string, params map[string]string, env string, model inter
 	resp, err := invokeGETRequestWithRetry(url, params, env)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+url, err)
+		return nil, exitUnlessMINode(env, "Unable to connect to "+url, err)
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -67,8 +71,7 @@ func unmarshalData(url string, params map[string]string, env string, model inter
 		return response, nil
 	}
 	if resp.StatusCode() == http.StatusUnauthorized {
-		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
-		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
+		return nil, handleInvalidCredentials(env)
 	}
 	if len(resp.Body()) == 0 {
 		return nil, errors.New(resp.Status())
@@ -81,7 +84,7 @@ func downloadLogFileData(url string, params map[string]string, env string) ([]by
 	resp, err := invokeGETRequestWithRetry(url, params, env)
 
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+url, err)
+		return nil, exitUnlessMINode(env, "Unable to connect to "+url, err)
 	}
 
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
@@ -90,21 +93,19 @@ func downloadLogFileData(url string, params map[string]string, env string) ([]by
 		return resp.Body(), nil
 	}
 	if resp.StatusCode() == http.StatusUnauthorized {
-		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
-		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
+		return nil, handleInvalidCredentials(env)
 	}
 	return nil, errors.New(resp.Status())
 }
 
-func handleResponse(resp *resty.Response, err error, url, messageTag, errorTag string) (string, error) {
+func handleResponse(resp *resty.Response, err error, env, url, messageTag, errorTag string) (string, error) {
 	if err != nil {
-		utils.HandleErrorAndExit("Unable to connect to "+url, err)
+		return "", exitUnlessMINode(env, "Unable to connect to "+url, err)
 	}
 	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
 
 	if resp.StatusCode() == http.StatusUnauthorized {
-		fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
-		utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
+		return "", handleInvalidCredentials(env)
 	}
 	if len(resp.Body()) == 0 {
 		return "", errors.New(resp.Status())
@@ -116,16 +117,47 @@ func handleResponse(resp *resty.Response, err error, url, messageTag, errorTag s
 	return "", errors.New(data[errorTag])
 }
 
+// exitUnlessMINode exits with the given message unless env addresses a single node of a cluster. The error is
+// returned for a node so that the results of the other nodes of the cluster are still reported
+func exitUnlessMINode(env, message string, err error) error {
+	if _, node := utils.SplitMINodeEnv(env); node != "" {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	utils.HandleErrorAndExit(message, err)
+	return err
+}
+
+func handleInvalidCredentials(env string) error {
+	if _, node := utils.SplitMINodeEnv(env); node != "" {
+		return errors.New("invalid credentials for the node")
+	}
+	fmt.Println("Invalid credentials. Please login to the current Micro Integrator instance")
+	utils.HandleErrorAndExit("Execute 'apictl mi login --help' for more information", nil)
+	return nil
+}
+
+// retryHTTPCall invokes f with the access token of env and retries with a new token if f is unauthorized.
+// The nodes of a cluster share the credentials of their environment, but each node gets a token of its own
+// which is only kept for the lifetime of the command
 func retryHTTPCall(attempts int, env string, f func(string) (*resty.Response, error)) (*resty.Response, error) {
-	cred, err := credentials.GetMICredentials(env)
-	resp, err := f(cred.AccessToken)
+	clusterEnv, node := utils.SplitMINodeEnv(env)
+	cred, err := credentials.GetMICredentials(clusterEnv)
+	accessToken := cred.AccessToken
+	if token, ok := miNodeAccessTokens.Load(env); ok && node != "" {
+		accessToken = token.(string)
+	}
+	resp, err := f(accessToken)
 	if resp.StatusCode() == http.StatusUnauthorized {
 		if attempts--; attempts > 0 {
 			token, err := credentials.GetOAuthAccessTokenForMI(cred.Username, cred.Password, env)
 			if err != nil {
 				return nil, err
 			}
-			credentials.UpdateMIAccessToken(env, token)
+			if node != "" {
+				miNodeAccessTokens.Store(env, token)
+			} else {
+				credentials.UpdateMIAccessToken(env, token)
+			}
 			return retryHTTPCall(attempts, env, f)
 		}
 	}
@@ -157,6 +189,14 @@ func invokePOSTRequestWithRetry(env, url string, body interface{}) (*resty.Respo
 	})
 }
 
+func invokePOSTRequestWithFileAndRetry(env, url, fileParamName, filePath string) (*resty.Response, error) {
+	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		return utils.InvokePOSTRequestWithFile(url, headers, fileParamName, filePath)
+	})
+}
+
 func invokeDELETERequestWithRetry(url string, env string) (*resty.Response, error) {
 	return retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
 		headers := make(map[string]string)
@@ -266,5 +306,5 @@ func updateArtifactState(url, artifactName, state, env string) (string, error) {
 		Status: state,
 	}
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "Message", "Error")
+	return handleResponse(resp, err, env, url, "Message", "Error")
 }
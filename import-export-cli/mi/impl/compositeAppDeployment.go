@@ -0,0 +1,324 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const (
+	carArtifactsFileName    = "artifacts.xml"
+	carArtifactFileName     = "artifact.xml"
+	carApplicationType      = "carbon/application"
+	carUploadFileParamName  = "file"
+	artifactStatusDeployed  = "Deployed"
+	artifactStatusFailed    = "Failed"
+	defaultDeploymentFormat = "table {{.Name}}\t{{.Type}}\t{{.Status}}"
+)
+
+// CarbonApplication is a composite app as described by the artifacts.xml of its .car file
+type CarbonApplication struct {
+	Name      string
+	Version   string
+	Artifacts []artifactutils.Artifact
+}
+
+// CompositeAppDeployment is the deployment state of the artifacts of a composite app
+type CompositeAppDeployment struct {
+	Name     string
+	Version  string
+	Faulty   bool
+	Deployed []artifactutils.Artifact
+	Failed   []artifactutils.Artifact
+}
+
+// FailedArtifactNames returns the names of the artifacts which are not deployed
+func (d *CompositeAppDeployment) FailedArtifactNames() []string {
+	var names []string
+	for _, artifact := range d.Failed {
+		names = append(names, artifact.Name)
+	}
+	return names
+}
+
+type carArtifacts struct {
+	Artifacts []carArtifact `xml:"artifact"`
+}
+
+type carArtifact struct {
+	Name         string          `xml:"name,attr"`
+	Version      string          `xml:"version,attr"`
+	Type         string          `xml:"type,attr"`
+	Dependencies []carDependency `xml:"dependency"`
+}
+
+type carDependency struct {
+	Artifact string `xml:"artifact,attr"`
+	Version  string `xml:"version,attr"`
+	Include  string `xml:"include,attr"`
+}
+
+type artifactDeploymentRow struct {
+	Name   string
+	Type   string
+	Status string
+}
+
+// ReadCarbonApplication reads the name, version and artifacts of the composite app in the given .car file
+func ReadCarbonApplication(carPath string) (*CarbonApplication, error) {
+	reader, err := zip.OpenReader(carPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s as a composite app: %w", carPath, err)
+	}
+	defer reader.Close()
+
+	var application *carArtifact
+	// artifact.xml of each dependency, keyed by the artifact name and version
+	dependencies := make(map[string]carArtifact)
+	for _, file := range reader.File {
+		switch path.Base(file.Name) {
+		case carArtifactsFileName:
+			descriptor := carArtifacts{}
+			if err := readXMLFromZip(file, &descriptor); err != nil {
+				return nil, err
+			}
+			for i := range descriptor.Artifacts {
+				if descriptor.Artifacts[i].Type == carApplicationType {
+					application = &descriptor.Artifacts[i]
+				}
+			}
+		case carArtifactFileName:
+			artifact := carArtifact{}
+			if err := readXMLFromZip(file, &artifact); err != nil {
+				return nil, err
+			}
+			dependencies[artifact.Name+"_"+artifact.Version] = artifact
+		}
+	}
+	if application == nil {
+		return nil, fmt.Errorf("%s does not contain a %s describing a %s artifact", carPath,
+			carArtifactsFileName, carApplicationType)
+	}
+
+	car := &CarbonApplication{Name: application.Name, Version: application.Version}
+	for _, dependency := range application.Dependencies {
+		if strings.EqualFold(dependency.Include, "false") {
+			continue
+		}
+		artifact := artifactutils.Artifact{Name: dependency.Artifact}
+		if descriptor, ok := dependencies[dependency.Artifact+"_"+dependency.Version]; ok {
+			artifact.Name = descriptor.Name
+			artifact.Type = getArtifactTypeWithoutCategory(descriptor.Type)
+		}
+		car.Artifacts = append(car.Artifacts, artifact)
+	}
+	return car, nil
+}
+
+func readXMLFromZip(file *zip.File, model interface{}) error {
+	content, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, model); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// getArtifactTypeWithoutCategory strips the category of an artifact type (synapse/proxy-service -> proxy-service)
+// as the micro integrator reports the artifacts of a composite app that way
+func getArtifactTypeWithoutCategory(artifactType string) string {
+	if i := strings.LastIndex(artifactType, "/"); i >= 0 {
+		return artifactType[i+1:]
+	}
+	return artifactType
+}
+
+// DeployCompositeApp uploads the composite app in the given .car file to the micro integrator in a given environment.
+// A composite app with the same name that is already deployed is undeployed first if replace is set,
+// otherwise the deployment fails
+func DeployCompositeApp(env, carPath string, replace bool) (*CarbonApplication, string, error) {
+	car, err := ReadCarbonApplication(carPath)
+	if err != nil {
+		return nil, "", err
+	}
+	appList, err := GetCompositeAppList(env)
+	if err != nil {
+		return nil, "", err
+	}
+	deployed := findCompositeApp(appList.ActiveCompositeApps, car.Name, "")
+	if deployed == nil {
+		deployed = findCompositeApp(appList.FaultyCompositeApps, car.Name, "")
+	}
+	if deployed != nil {
+		if !replace {
+			return nil, "", fmt.Errorf("composite app %s version %s is already deployed", deployed.Name,
+				deployed.Version)
+		}
+		utils.Logln(utils.LogPrefixInfo + "Undeploying composite app " + deployed.Name + " version " +
+			deployed.Version + " before deploying version " + car.Version)
+		if _, err := UndeployCompositeApp(env, deployed.Name); err != nil {
+			return nil, "", fmt.Errorf("undeploying composite app %s: %w", deployed.Name, err)
+		}
+	}
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env, utils.MainConfigFilePath)
+	resp, err := invokePOSTRequestWithFileAndRetry(env, url, carUploadFileParamName, carPath)
+	message, err := handleResponse(resp, err, env, url, "Message", "Error")
+	if err != nil {
+		return nil, "", err
+	}
+	return car, message, nil
+}
+
+// UndeployCompositeApp removes a composite app from the micro integrator in a given environment
+func UndeployCompositeApp(env, appName string) (string, error) {
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementCarbonAppResource, env, utils.MainConfigFilePath) +
+		"/" + appName
+	resp, err := invokeDELETERequestWithRetry(url, env)
+	return handleResponse(resp, err, env, url, "Message", "Error")
+}
+
+// WatchCompositeAppDeployment polls the micro integrator in a given environment every interval until every artifact
+// of the composite app is reported as deployed. An error is returned along with the deployment state if the
+// composite app turns out to be faulty or if the artifacts are not deployed within the timeout
+func WatchCompositeAppDeployment(env string, car *CarbonApplication, interval,
+	timeout time.Duration) (*CompositeAppDeployment, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		deployment, err := getCompositeAppDeployment(env, car)
+		if err != nil {
+			return nil, err
+		}
+		if deployment.Faulty {
+			return deployment, fmt.Errorf("composite app %s version %s is faulty", car.Name, car.Version)
+		}
+		if len(deployment.Failed) == 0 {
+			return deployment, nil
+		}
+		if !time.Now().Add(interval).Before(deadline) {
+			return deployment, fmt.Errorf("%d artifact(s) of composite app %s version %s were not deployed within %s",
+				len(deployment.Failed), car.Name, car.Version, timeout)
+		}
+		utils.Logln(utils.LogPrefixInfo+"Waiting for", len(deployment.Failed), "artifact(s) of composite app",
+			car.Name, "to be deployed")
+		time.Sleep(interval)
+	}
+}
+
+func getCompositeAppDeployment(env string, car *CarbonApplication) (*CompositeAppDeployment, error) {
+	deployment := &CompositeAppDeployment{Name: car.Name, Version: car.Version, Failed: car.Artifacts}
+	appList, err := GetCompositeAppList(env)
+	if err != nil {
+		return nil, err
+	}
+	if findCompositeApp(appList.FaultyCompositeApps, car.Name, car.Version) != nil {
+		deployment.Faulty = true
+		return deployment, nil
+	}
+	if findCompositeApp(appList.ActiveCompositeApps, car.Name, car.Version) == nil {
+		return deployment, nil
+	}
+	app, err := GetCompositeApp(env, car.Name)
+	if err != nil {
+		// the composite app may have been listed before its details are available
+		utils.Logln(utils.LogPrefixInfo+"Getting composite app "+car.Name+":", err)
+		return deployment, nil
+	}
+	if car.Artifacts == nil {
+		deployment.Deployed = app.Artifacts
+		deployment.Failed = nil
+		return deployment, nil
+	}
+	deployment.Failed = nil
+	for _, expected := range car.Artifacts {
+		if containsArtifact(app.Artifacts, expected) {
+			deployment.Deployed = append(deployment.Deployed, expected)
+		} else {
+			deployment.Failed = append(deployment.Failed, expected)
+		}
+	}
+	return deployment, nil
+}
+
+// findCompositeApp returns the composite app with the given name, and the given version if it is not empty
+func findCompositeApp(apps []artifactutils.CompositeAppSummary, name, version string) *artifactutils.CompositeAppSummary {
+	for i := range apps {
+		if apps[i].Name == name && (version == "" || apps[i].Version == version) {
+			return &apps[i]
+		}
+	}
+	return nil
+}
+
+func containsArtifact(artifacts []artifactutils.Artifact, artifact artifactutils.Artifact) bool {
+	for _, deployed := range artifacts {
+		if deployed.Name == artifact.Name && (artifact.Type == "" || deployed.Type == artifact.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintCompositeAppDeployment prints the deployment state of each artifact of a composite app
+func PrintCompositeAppDeployment(deployment *CompositeAppDeployment, format string) {
+	if utils.PrintStructuredOutput(deployment, format) {
+		return
+	}
+	var rows []artifactDeploymentRow
+	for _, artifact := range deployment.Deployed {
+		rows = append(rows, artifactDeploymentRow{artifact.Name, artifact.Type, artifactStatusDeployed})
+	}
+	for _, artifact := range deployment.Failed {
+		rows = append(rows, artifactDeploymentRow{artifact.Name, artifact.Type, artifactStatusFailed})
+	}
+	deploymentContext := getContextWithFormat(format, defaultDeploymentFormat)
+	renderer := func(w io.Writer, t *template.Template) error {
+		for _, row := range rows {
+			if err := t.Execute(w, row); err != nil {
+				return err
+			}
+			_, _ = w.Write([]byte{'\n'})
+		}
+		return nil
+	}
+	deploymentTableHeaders := map[string]string{
+		"Name":   nameHeader,
+		"Type":   typeHeader,
+		"Status": statusHeader,
+	}
+	if err := deploymentContext.Write(renderer, deploymentTableHeaders); err != nil {
+		fmt.Println("Error executing template:", err.Error())
+	}
+}
@@ -0,0 +1,292 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+const testCarArtifactsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<artifacts>
+    <artifact name="SampleServicesCompositeApplication" version="1.0.0" type="carbon/application">
+        <dependency artifact="HelloProxy" version="1.0.0" include="true" serverRole="EnterpriseIntegrator"/>
+        <dependency artifact="HelloEP" version="1.0.0" include="true" serverRole="EnterpriseIntegrator"/>
+        <dependency artifact="UnusedSequence" version="1.0.0" include="false" serverRole="EnterpriseIntegrator"/>
+    </artifact>
+</artifacts>`
+
+// stubManagementAPI is a minimal micro integrator management API serving composite apps
+type stubManagementAPI struct {
+	mu         sync.Mutex
+	active     map[string]artifactutils.CompositeApp
+	faulty     []artifactutils.CompositeAppSummary
+	uploads    []string
+	undeployed []string
+	// onList is called before each listing of the composite apps
+	onList func(s *stubManagementAPI)
+}
+
+func (s *stubManagementAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get(utils.HeaderAuthorization) != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/management/applications")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("carbonAppName") != "":
+		app, ok := s.active[r.URL.Query().Get("carbonAppName")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"Error": "Carbon App not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(app)
+	case r.Method == http.MethodGet:
+		if s.onList != nil {
+			s.onList(s)
+		}
+		list := artifactutils.CompositeAppList{FaultyCompositeApps: s.faulty}
+		for _, app := range s.active {
+			list.ActiveCompositeApps = append(list.ActiveCompositeApps,
+				artifactutils.CompositeAppSummary{Name: app.Name, Version: app.Version})
+		}
+		list.ActiveCount, list.FaultyCount = int32(len(list.ActiveCompositeApps)), int32(len(s.faulty))
+		json.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodPost:
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"Error": err.Error()})
+			return
+		}
+		s.uploads = append(s.uploads, header.Filename)
+		json.NewEncoder(w).Encode(map[string]string{"Message": "Successfully added Carbon Application " +
+			header.Filename})
+	case r.Method == http.MethodDelete:
+		s.undeployed = append(s.undeployed, strings.TrimPrefix(name, "/"))
+		delete(s.active, strings.TrimPrefix(name, "/"))
+		json.NewEncoder(w).Encode(map[string]string{"Message": "Successfully undeployed Carbon Application"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// setUpStubMI points the dev environment to the stub and takes the credentials from environment variables
+func setUpStubMI(t *testing.T, stub *stubManagementAPI) {
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	mainConfigFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	mainConfig := "config:\n  credential_store:\n    type: env\nenvironments:\n  dev:\n    mi: " + server.URL + "\n"
+	require.NoError(t, os.WriteFile(mainConfigFilePath, []byte(mainConfig), 0644))
+	originalMainConfigFilePath := utils.MainConfigFilePath
+	utils.MainConfigFilePath = mainConfigFilePath
+	t.Cleanup(func() { utils.MainConfigFilePath = originalMainConfigFilePath })
+
+	t.Setenv("APICTL_DEV_MI_USERNAME", "admin")
+	t.Setenv("APICTL_DEV_MI_PASSWORD", "admin")
+	t.Setenv("APICTL_DEV_MI_TOKEN", "token")
+}
+
+func writeTestCar(t *testing.T) string {
+	carPath := filepath.Join(t.TempDir(), "SampleServicesCompositeApplication_1.0.0.car")
+	file, err := os.Create(carPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	entries := map[string]string{
+		"artifacts.xml": testCarArtifactsXML,
+		"HelloProxy_1.0.0/artifact.xml": `<artifact name="HelloProxy" version="1.0.0" type="synapse/proxy-service" ` +
+			`serverRole="EnterpriseIntegrator"><file>HelloProxy-1.0.0.xml</file></artifact>`,
+		"HelloEP_1.0.0/artifact.xml": `<artifact name="HelloEP" version="1.0.0" type="synapse/endpoint" ` +
+			`serverRole="EnterpriseIntegrator"><file>HelloEP-1.0.0.xml</file></artifact>`,
+	}
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return carPath
+}
+
+func sampleCompositeApp(artifacts ...artifactutils.Artifact) artifactutils.CompositeApp {
+	return artifactutils.CompositeApp{Name: "SampleServicesCompositeApplication", Version: "1.0.0", Artifacts: artifacts}
+}
+
+var (
+	helloProxyArtifact = artifactutils.Artifact{Name: "HelloProxy", Type: "proxy-service"}
+	helloEPArtifact    = artifactutils.Artifact{Name: "HelloEP", Type: "endpoint"}
+)
+
+func TestReadCarbonApplication(t *testing.T) {
+	car, err := ReadCarbonApplication(writeTestCar(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "SampleServicesCompositeApplication", car.Name)
+	assert.Equal(t, "1.0.0", car.Version)
+	assert.Equal(t, []artifactutils.Artifact{helloProxyArtifact, helloEPArtifact}, car.Artifacts)
+}
+
+func TestReadCarbonApplicationWithoutDescriptor(t *testing.T) {
+	carPath := filepath.Join(t.TempDir(), "empty.car")
+	file, err := os.Create(carPath)
+	require.NoError(t, err)
+	require.NoError(t, zip.NewWriter(file).Close())
+	require.NoError(t, file.Close())
+
+	_, err = ReadCarbonApplication(carPath)
+	assert.Error(t, err)
+}
+
+func TestDeployCompositeApp(t *testing.T) {
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{}}
+	setUpStubMI(t, stub)
+
+	car, message, err := DeployCompositeApp("dev", writeTestCar(t), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "SampleServicesCompositeApplication", car.Name)
+	assert.Contains(t, message, "Successfully added")
+	assert.Equal(t, []string{"SampleServicesCompositeApplication_1.0.0.car"}, stub.uploads)
+	assert.Empty(t, stub.undeployed)
+}
+
+func TestDeployCompositeAppAlreadyDeployed(t *testing.T) {
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{
+		"SampleServicesCompositeApplication": sampleCompositeApp(helloProxyArtifact),
+	}}
+	setUpStubMI(t, stub)
+	carPath := writeTestCar(t)
+
+	_, _, err := DeployCompositeApp("dev", carPath, false)
+	assert.EqualError(t, err, "composite app SampleServicesCompositeApplication version 1.0.0 is already deployed")
+	assert.Empty(t, stub.uploads)
+
+	_, _, err = DeployCompositeApp("dev", carPath, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SampleServicesCompositeApplication"}, stub.undeployed)
+	assert.Len(t, stub.uploads, 1)
+}
+
+func TestUndeployCompositeApp(t *testing.T) {
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{
+		"SampleServicesCompositeApplication": sampleCompositeApp(helloProxyArtifact),
+	}}
+	setUpStubMI(t, stub)
+
+	message, err := UndeployCompositeApp("dev", "SampleServicesCompositeApplication")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Successfully undeployed Carbon Application", message)
+	assert.Equal(t, []string{"SampleServicesCompositeApplication"}, stub.undeployed)
+}
+
+func TestWatchCompositeAppDeployment(t *testing.T) {
+	lists := 0
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{}, onList: func(s *stubManagementAPI) {
+		// the composite app appears on the second poll and its endpoint on the third one
+		lists++
+		switch lists {
+		case 2:
+			s.active["SampleServicesCompositeApplication"] = sampleCompositeApp(helloProxyArtifact)
+		case 3:
+			s.active["SampleServicesCompositeApplication"] = sampleCompositeApp(helloProxyArtifact, helloEPArtifact)
+		}
+	}}
+	setUpStubMI(t, stub)
+	car, err := ReadCarbonApplication(writeTestCar(t))
+	require.NoError(t, err)
+
+	deployment, err := WatchCompositeAppDeployment("dev", car, time.Millisecond, time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, lists)
+	assert.False(t, deployment.Faulty)
+	assert.Equal(t, []artifactutils.Artifact{helloProxyArtifact, helloEPArtifact}, deployment.Deployed)
+	assert.Empty(t, deployment.Failed)
+}
+
+func TestWatchCompositeAppDeploymentTimesOut(t *testing.T) {
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{
+		"SampleServicesCompositeApplication": sampleCompositeApp(helloProxyArtifact),
+	}}
+	setUpStubMI(t, stub)
+	car, err := ReadCarbonApplication(writeTestCar(t))
+	require.NoError(t, err)
+
+	deployment, err := WatchCompositeAppDeployment("dev", car, time.Millisecond, 20*time.Millisecond)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "1 artifact(s) of composite app SampleServicesCompositeApplication")
+	assert.Equal(t, []artifactutils.Artifact{helloProxyArtifact}, deployment.Deployed)
+	assert.Equal(t, []artifactutils.Artifact{helloEPArtifact}, deployment.Failed)
+}
+
+func TestWatchCompositeAppDeploymentFaulty(t *testing.T) {
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{}, faulty: []artifactutils.CompositeAppSummary{
+		{Name: "SampleServicesCompositeApplication", Version: "1.0.0"},
+	}}
+	setUpStubMI(t, stub)
+	car, err := ReadCarbonApplication(writeTestCar(t))
+	require.NoError(t, err)
+
+	deployment, err := WatchCompositeAppDeployment("dev", car, time.Millisecond, time.Minute)
+	require.EqualError(t, err, "composite app SampleServicesCompositeApplication version 1.0.0 is faulty")
+
+	assert.True(t, deployment.Faulty)
+	assert.Equal(t, car.Artifacts, deployment.Failed)
+}
+
+func TestWatchCompositeAppDeploymentIgnoresOtherVersions(t *testing.T) {
+	lists := 0
+	stub := &stubManagementAPI{active: map[string]artifactutils.CompositeApp{
+		"SampleServicesCompositeApplication": {Name: "SampleServicesCompositeApplication", Version: "0.9.0",
+			Artifacts: []artifactutils.Artifact{helloProxyArtifact, helloEPArtifact}},
+	}, onList: func(s *stubManagementAPI) {
+		if lists++; lists == 2 {
+			s.active["SampleServicesCompositeApplication"] = sampleCompositeApp(helloProxyArtifact, helloEPArtifact)
+		}
+	}}
+	setUpStubMI(t, stub)
+	car, err := ReadCarbonApplication(writeTestCar(t))
+	require.NoError(t, err)
+
+	_, err = WatchCompositeAppDeployment("dev", car, time.Millisecond, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lists)
+}
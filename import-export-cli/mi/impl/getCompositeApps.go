@@ -50,6 +50,9 @@ func GetCompositeAppList(env string) (*artifactutils.CompositeAppList, error) {
 
 // PrintCompositeAppList print a list of composite apps according to the given format
 func PrintCompositeAppList(appList *artifactutils.CompositeAppList, format string) {
+	if utils.PrintStructuredOutput(appList, format) {
+		return
+	}
 	if appList.ActiveCount > 0 {
 		fmt.Println("----------------------\nActive Composite Apps\n----------------------")
 		PrintCompositeAppListTable(appList.ActiveCompositeApps, format)
@@ -99,6 +102,9 @@ func GetCompositeApp(env, appname string) (*artifactutils.CompositeApp, error) {
 
 // PrintCompositeAppDetails prints details about a composite app according to the given format
 func PrintCompositeAppDetails(app *artifactutils.CompositeApp, format string) {
+	if utils.PrintStructuredOutput(app, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultCompositeAppDetailedFormat
 	}
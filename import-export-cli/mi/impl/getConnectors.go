@@ -40,6 +40,9 @@ func GetConnectorList(env string) (*artifactutils.ConnectorList, error) {
 
 // PrintConnectorList print a list of connectors according to the given format
 func PrintConnectorList(connectorList *artifactutils.ConnectorList, format string) {
+	if utils.PrintStructuredOutput(connectorList, format) {
+		return
+	}
 	if connectorList.Count > 0 {
 		connectors := connectorList.Connectors
 		connectorListContext := getContextWithFormat(format, defaultConnectorListTableFormat)
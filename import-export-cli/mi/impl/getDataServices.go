@@ -53,6 +53,9 @@ func GetDataServiceList(env string) (*artifactutils.DataServicesList, error) {
 
 // PrintDataServiceList print a list of data services according to the given format
 func PrintDataServiceList(dataServiceList *artifactutils.DataServicesList, format string) {
+	if utils.PrintStructuredOutput(dataServiceList, format) {
+		return
+	}
 	if dataServiceList.Count > 0 {
 		dataServices := dataServiceList.List
 		dataserviceListContext := getContextWithFormat(format, defaultdataServiceListTableFormat)
@@ -90,6 +93,9 @@ func GetDataService(env, dataserviceName string) (*artifactutils.DataServiceInfo
 
 // PrintDataServiceDetails prints details about a data service according to the given format
 func PrintDataServiceDetails(ds *artifactutils.DataServiceInfo, format string) {
+	if utils.PrintStructuredOutput(ds, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultdataServiceDetailedFormat
 	}
@@ -53,6 +53,9 @@ func GetEndpointList(env string) (*artifactutils.EndpointList, error) {
 
 // PrintEndpointList print a list of endpoints
 func PrintEndpointList(endpointList *artifactutils.EndpointList, format string) {
+	if utils.PrintStructuredOutput(endpointList, format) {
+		return
+	}
 	if endpointList.Count > 0 {
 		endpoints := endpointList.Endpoints
 		endpointListContext := getContextWithFormat(format, defaultEndpointListTableFormat)
@@ -90,6 +93,9 @@ func GetEndpoint(env, endpointName string) (*artifactutils.Endpoint, error) {
 
 // PrintEndpointDetails prints details about an endpoint
 func PrintEndpointDetails(endpoint *artifactutils.Endpoint, format string) {
+	if utils.PrintStructuredOutput(endpoint, format) {
+		return
+	}
 	if format == "" {
 		format = defaultEndpointDetailedFormat
 	}
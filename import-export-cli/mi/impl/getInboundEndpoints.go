@@ -52,6 +52,9 @@ func GetInboundEndpointList(env string) (*artifactutils.InboundEndpointList, err
 
 // PrintInboundEndpointList print a list of inbound endpoints according to the given format
 func PrintInboundEndpointList(inboundEPList *artifactutils.InboundEndpointList, format string) {
+	if utils.PrintStructuredOutput(inboundEPList, format) {
+		return
+	}
 	if inboundEPList.Count > 0 {
 		inboundEPs := inboundEPList.InboundEndpoints
 		inboundEPListContext := getContextWithFormat(format, defaultInboundEndpointListTableFormat)
@@ -89,6 +92,9 @@ func GetInboundEndpoint(env, inboundEPName string) (*artifactutils.InboundEndpoi
 
 // PrintInboundEndpointDetails prints details about an inbound endpoint according to the given format
 func PrintInboundEndpointDetails(inboundEP *artifactutils.InboundEndpoint, format string) {
+	if utils.PrintStructuredOutput(inboundEP, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultInboundEndpointDetailedFormat
 	}
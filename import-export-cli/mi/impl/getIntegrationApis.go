@@ -53,6 +53,9 @@ func GetIntegrationAPIList(env string) (*artifactutils.IntegrationAPIList, error
 
 // PrintIntegrationAPIList print a list of apis according to the given format
 func PrintIntegrationAPIList(apiList *artifactutils.IntegrationAPIList, format string) {
+	if utils.PrintStructuredOutput(apiList, format) {
+		return
+	}
 	if apiList.Count > 0 {
 		apis := apiList.Apis
 		apiListContext := getContextWithFormat(format, defaultIntegrationAPIListTableFormat)
@@ -89,6 +92,9 @@ func GetIntegrationAPI(env, apiName string) (*artifactutils.IntegrationAPI, erro
 
 // PrintIntegrationAPIDetails prints details about an api according to the given format
 func PrintIntegrationAPIDetails(api *artifactutils.IntegrationAPI, format string) {
+	if utils.PrintStructuredOutput(api, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultIntegrationAPIDetailedFormat
 	}
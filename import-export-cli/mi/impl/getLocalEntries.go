@@ -45,6 +45,9 @@ func GetLocalEntryList(env string) (*artifactutils.LocalEntryList, error) {
 
 // PrintLocalEntryList print a list of local entries according to the given format
 func PrintLocalEntryList(localEntryList *artifactutils.LocalEntryList, format string) {
+	if utils.PrintStructuredOutput(localEntryList, format) {
+		return
+	}
 	if localEntryList.Count > 0 {
 		localEntrys := localEntryList.LocalEntries
 		localEntryListContext := getContextWithFormat(format, defaultLocalEntryListTableFormat)
@@ -81,6 +84,9 @@ func GetLocalEntry(env, localEntryName string) (*artifactutils.LocalEntryData, e
 
 // PrintLocalEntryDetails prints details about a local entry according to the given format
 func PrintLocalEntryDetails(localEntry *artifactutils.LocalEntryData, format string) {
+	if utils.PrintStructuredOutput(localEntry, format) {
+		return
+	}
 	localEntryContext := getContextWithFormat(format, defaultLocalEntryDetailedFormat)
 	renderer := getItemRendererEndsWithNewLine(localEntry)
 
@@ -44,6 +44,9 @@ func GetLogFileList(env string) (*artifactutils.LogFileList, error) {
 
 // PrintLogFileList print a list of log file names and sizes according to the given format
 func PrintLogFileList(logFileList *artifactutils.LogFileList, format string) {
+	if utils.PrintStructuredOutput(logFileList, format) {
+		return
+	}
 	if logFileList.Count > 0 {
 		logFiles := logFileList.LogFiles
 		logFileListContext := getContextWithFormat(format, defaultLogFileListTableFormat)
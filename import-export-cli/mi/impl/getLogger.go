@@ -40,6 +40,9 @@ func GetLoggerInfo(env, loggerName string) (*artifactutils.Logger, error) {
 
 // PrintLoggerInfo prints details about a logger
 func PrintLoggerInfo(logger *artifactutils.Logger, format string) {
+	if utils.PrintStructuredOutput(logger, format) {
+		return
+	}
 	loggerContext := getContextWithFormat(format, defaultLoggerTableFormat)
 	renderer := getItemRendererEndsWithNewLine(logger)
 
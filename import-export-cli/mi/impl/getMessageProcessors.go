@@ -59,6 +59,9 @@ func GetMessageProcessorList(env string) (*artifactutils.MessageProcessorList, e
 
 // PrintMessageProcessorList print a list of message processors according to the given format
 func PrintMessageProcessorList(messageProcessorList *artifactutils.MessageProcessorList, format string) {
+	if utils.PrintStructuredOutput(messageProcessorList, format) {
+		return
+	}
 	if messageProcessorList.Count > 0 {
 		messageProcessors := messageProcessorList.MessageProcessors
 		messageProcessorListContext := getContextWithFormat(format, defaultMessageProcessorListTableFormat)
@@ -96,6 +99,9 @@ func GetMessageProcessor(env, messageProcessorName string) (*artifactutils.Messa
 
 // PrintMessageProcessorDetails prints details about a message processor according to the given format
 func PrintMessageProcessorDetails(messageProcessor *artifactutils.MessageProcessorData, format string) {
+	if utils.PrintStructuredOutput(messageProcessor, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultMessageProcessorDetailedFormat
 	}
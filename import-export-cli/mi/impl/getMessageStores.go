@@ -57,6 +57,9 @@ func GetMessageStoreList(env string) (*artifactutils.MessageStoreList, error) {
 
 // PrintMessageStoreList print a list of message stores according to the given format
 func PrintMessageStoreList(messageStoreList *artifactutils.MessageStoreList, format string) {
+	if utils.PrintStructuredOutput(messageStoreList, format) {
+		return
+	}
 	if messageStoreList.Count > 0 {
 		messageStores := messageStoreList.MessageStores
 		messageStoreListContext := getContextWithFormat(format, defaultMessageStoreListTableFormat)
@@ -94,6 +97,9 @@ func GetMessageStore(env, messageStoreName string) (*artifactutils.MessageStoreD
 
 // PrintMessageStoreDetails prints details about a message store according to the given format
 func PrintMessageStoreDetails(messageStore *artifactutils.MessageStoreData, format string) {
+	if utils.PrintStructuredOutput(messageStore, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultMessageStoreDetailedFormat
 	}
@@ -49,6 +49,9 @@ func GetProxyServiceList(env string) (*artifactutils.ProxyServiceList, error) {
 
 // PrintProxyServiceList print a list of proxy serives according to the given format
 func PrintProxyServiceList(proxyList *artifactutils.ProxyServiceList, format string) {
+	if utils.PrintStructuredOutput(proxyList, format) {
+		return
+	}
 	if proxyList.Count > 0 {
 		proxies := proxyList.Proxies
 		proxyListContext := getContextWithFormat(format, defaultProxyServiceListTableFormat)
@@ -86,6 +89,9 @@ func GetProxyService(env, proxyName string) (*artifactutils.Proxy, error) {
 
 // PrintProxyServiceDetails prints details about a proxy according to the given format
 func PrintProxyServiceDetails(proxy *artifactutils.Proxy, format string) {
+	if utils.PrintStructuredOutput(proxy, format) {
+		return
+	}
 	if format == "" {
 		format = defaultProxyServiceDetailedFormat
 	}
@@ -51,6 +51,9 @@ func GetRoleList(env string) (*artifactutils.RoleList, error) {
 
 // PrintRoleList print a list of mi roles according to the given format
 func PrintRoleList(roleList *artifactutils.RoleList, format string) {
+	if utils.PrintStructuredOutput(roleList, format) {
+		return
+	}
 	if roleList.Count > 0 {
 		roles := roleList.Roles
 		roleListContext := getContextWithFormat(format, defaultRoleListTableFormat)
@@ -89,6 +92,9 @@ func GetRoleInfo(env, role, domain string) (*artifactutils.RoleSummary, error) {
 
 // PrintRoleDetails prints details about a role according to the given format
 func PrintRoleDetails(roleInfo *artifactutils.RoleSummary, format string) {
+	if utils.PrintStructuredOutput(roleInfo, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultRoleDetailedFormat
 	}
@@ -54,6 +54,9 @@ func GetSequenceList(env string) (*artifactutils.SequenceList, error) {
 
 // PrintSequenceList print a list of sequences according to the given format
 func PrintSequenceList(sequenceList *artifactutils.SequenceList, format string) {
+	if utils.PrintStructuredOutput(sequenceList, format) {
+		return
+	}
 	if sequenceList.Count > 0 {
 		sequences := sequenceList.Sequences
 		sequenceListContext := getContextWithFormat(format, defaultSequenceListTableFormat)
@@ -91,6 +94,9 @@ func GetSequence(env, sequenceName string) (*artifactutils.Sequence, error) {
 
 // PrintSequenceDetails prints details about a sequence according to the given format
 func PrintSequenceDetails(sequence *artifactutils.Sequence, format string) {
+	if utils.PrintStructuredOutput(sequence, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultSequenceDetailedFormat
 	}
@@ -52,6 +52,9 @@ func GetTaskList(env string) (*artifactutils.TaskList, error) {
 
 // PrintTaskList print a list of Tasks according to the given format
 func PrintTaskList(taskList *artifactutils.TaskList, format string) {
+	if utils.PrintStructuredOutput(taskList, format) {
+		return
+	}
 	if taskList.Count > 0 {
 		tasks := taskList.Tasks
 		taskListContext := getContextWithFormat(format, defaultTaskListTableFormat)
@@ -87,6 +90,9 @@ func GetTask(env, taskName string) (*artifactutils.Task, error) {
 
 // PrintTaskDetails prints details about a Task according to the given format
 func PrintTaskDetails(task *artifactutils.Task, format string) {
+	if utils.PrintStructuredOutput(task, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultTaskDetailedFormat
 	}
@@ -90,6 +90,9 @@ func GetTemplateList(env string) (*artifactutils.TemplateList, error) {
 
 // PrintTemplateList print a list of Templates according to the given format
 func PrintTemplateList(templateList *artifactutils.TemplateList, format string) {
+	if utils.PrintStructuredOutput(templateList, format) {
+		return
+	}
 	var sequenceTemplatesCount = len(templateList.SequenceTemplates)
 	var endpointTemplatesCount = len(templateList.EndpointTemplates)
 
@@ -138,6 +141,9 @@ func GetTemplatesByType(env, templateType string) (*artifactutils.TemplateListBy
 
 // PrintTemplatesByType print a list of Templates of specified type according to the given format
 func PrintTemplatesByType(templateList *artifactutils.TemplateListByType, format string) {
+	if utils.PrintStructuredOutput(templateList, format) {
+		return
+	}
 	if templateList.Count > 0 {
 		templates := templateList.Templates
 		templateListByTypeContext := getContextWithFormat(format, defaultTemplateListByTypeTableFormat)
@@ -194,6 +200,9 @@ func getTemplate(env, templateType, templateName string, model interface{}) (int
 
 // PrintSequenceTemplateDetails prints details about a sequence template according to the given format
 func PrintSequenceTemplateDetails(sequenceTemplate *artifactutils.TemplateSequenceListByName, format string) {
+	if utils.PrintStructuredOutput(sequenceTemplate, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultSequenceTemplateDetailedFormat
 	}
@@ -208,6 +217,9 @@ func PrintSequenceTemplateDetails(sequenceTemplate *artifactutils.TemplateSequen
 
 // PrintEndpointTemplateDetails prints details about a endpoint template according to the given format
 func PrintEndpointTemplateDetails(endpointTemplate *artifactutils.TemplateEndpointListByName, format string) {
+	if utils.PrintStructuredOutput(endpointTemplate, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultEndpointTemplateDetailedFormat
 	}
@@ -49,6 +49,9 @@ func GetTransactionCount(env string, period []string) (*artifactutils.Transactio
 
 // PrintTransactionCount prints the transaction count according to the given format
 func PrintTransactionCount(transactionCount *artifactutils.TransactionCount, format string) {
+	if utils.PrintStructuredOutput(transactionCount, format) {
+		return
+	}
 	transactionContext := getContextWithFormat(format, defaultTransactionCountTableFormat)
 	renderer := getItemRendererEndsWithNewLine(transactionCount)
 
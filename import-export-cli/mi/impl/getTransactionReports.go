@@ -46,13 +46,18 @@ func GetTransactionReport(env string, period []string) (*artifactutils.Transacti
 
 // WriteTransactionReportAsCSV writes the transaction report to a csv file in the specified target directory
 func WriteTransactionReportAsCSV(transactions *artifactutils.TransactionCountInfo, targetDirectory string) {
-	fileName := transactionReportFilePrefix + strconv.FormatInt(time.Now().UnixNano(), 10) + ".csv"
-	destinationFilePath := filepath.Join(targetDirectory, fileName)
-	transactionCountLines := transactions.TransactionCounts
-	err := utils.WriteLinesToCSVFile(transactionCountLines, destinationFilePath)
+	destinationFilePath, err := CreateTransactionReportCSV(transactions, targetDirectory)
 	if err != nil {
 		fmt.Println("Error writing the transaction report", err.Error())
 	} else {
 		fmt.Println("Transaction Count Report created in", destinationFilePath)
 	}
 }
+
+// CreateTransactionReportCSV writes the transaction count report to a new csv file in the target directory and
+// returns the path of the file
+func CreateTransactionReportCSV(transactions *artifactutils.TransactionCountInfo, targetDirectory string) (string, error) {
+	fileName := transactionReportFilePrefix + strconv.FormatInt(time.Now().UnixNano(), 10) + ".csv"
+	destinationFilePath := filepath.Join(targetDirectory, fileName)
+	return destinationFilePath, utils.WriteLinesToCSVFile(transactions.TransactionCounts, destinationFilePath)
+}
@@ -56,6 +56,9 @@ func GetUserList(env, role, pattern string) (*artifactutils.UserList, error) {
 
 // PrintUserList print a list of mi users according to the given format
 func PrintUserList(userList *artifactutils.UserList, format string) {
+	if utils.PrintStructuredOutput(userList, format) {
+		return
+	}
 	if userList.Count > 0 {
 		users := userList.Users
 		userListContext := getContextWithFormat(format, defaultUserListTableFormat)
@@ -94,6 +97,9 @@ func GetUserInfo(env, userID, domain string) (*artifactutils.UserSummary, error)
 
 // PrintUserDetails prints details about a mi user according to the given format
 func PrintUserDetails(userInfo *artifactutils.UserSummary, format string) {
+	if utils.PrintStructuredOutput(userInfo, format) {
+		return
+	}
 	if format == "" || strings.HasPrefix(format, formatter.TableFormatKey) {
 		format = defaultUserDetailedFormat
 	}
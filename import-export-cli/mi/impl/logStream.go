@@ -0,0 +1,388 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+	"github.com/wso2/product-apim-tooling/import-export-cli/mi/utils/artifactutils"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// DefaultLogFile is the log file of the micro integrator which is followed if no file is given
+const DefaultLogFile = "wso2carbon.log"
+
+// logTailOverlap is the number of bytes before the offset which are read again with each poll. The file has been
+// rotated if these bytes have changed
+const logTailOverlap = 256
+
+const (
+	carbonLogTimestampLayout       = "2006-01-02 15:04:05,000"
+	carbonLogTimestampLayoutNoMsec = "2006-01-02 15:04:05"
+)
+
+// carbonLogLinePattern matches the first line of a carbon log entry, e.g.
+// [2021-03-10 11:55:37,546]  INFO {org.apache.synapse.mediators.builtin.LogMediator} - To: /hello
+// with an optional TID prefix and an optional thread name after the timestamp
+var carbonLogLinePattern = regexp.MustCompile(`^(?:TID: \[[^\]]*\] (?:\[[^\]]*\] )?)?` +
+	`\[(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:[,.]\d{3})?)\]\s+(?:\[([^\]]*)\]\s+)?` +
+	`(TRACE|DEBUG|INFO|WARN|ERROR|FATAL)\s+\{([^}]*)\}\s+-\s?(.*)$`)
+
+// logLevelSeverities orders the log levels from the least to the most severe
+var logLevelSeverities = map[string]int{"TRACE": 0, "DEBUG": 1, "INFO": 2, "WARN": 3, "ERROR": 4, "FATAL": 5}
+
+// LogEntry is an entry of a micro integrator log file parsed into the fields of the carbon log format.
+// The lines of a stack trace following the first line of an entry are part of its message
+type LogEntry struct {
+	Env       string `json:"env"`
+	File      string `json:"file"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Thread    string `json:"thread,omitempty"`
+	Class     string `json:"class,omitempty"`
+	Message   string `json:"message"`
+	time      time.Time
+	raw       string
+}
+
+// ParseCarbonLogLine parses the first line of a carbon log entry. nil is returned if the line does not start an
+// entry, e.g. a line of a stack trace
+func ParseCarbonLogLine(line string) *LogEntry {
+	match := carbonLogLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	entry := &LogEntry{Thread: match[2], Level: match[3], Class: match[4], Message: match[5], raw: line}
+	timestamp := strings.NewReplacer("T", " ", ".", ",").Replace(match[1])
+	layout := carbonLogTimestampLayout
+	if len(timestamp) == len(carbonLogTimestampLayoutNoMsec) {
+		layout = carbonLogTimestampLayoutNoMsec
+	}
+	if parsed, err := time.ParseInLocation(layout, timestamp, time.Local); err == nil {
+		entry.time = parsed
+		entry.Timestamp = parsed.Format(time.RFC3339Nano)
+	}
+	return entry
+}
+
+// LogFilter selects the log entries to be printed
+type LogFilter struct {
+	// Since drops the entries logged before it unless it is zero
+	Since time.Time
+	// Level drops the entries less severe than it unless it is empty
+	Level string
+	// Pattern drops the entries which do not match it unless it is nil
+	Pattern *regexp.Regexp
+}
+
+// NewLogFilter creates a filter for the entries logged within since with at least the given level and
+// matching the pattern. Each of them is ignored if it is empty
+func NewLogFilter(since time.Duration, level, pattern string) (*LogFilter, error) {
+	filter := &LogFilter{Level: strings.ToUpper(level)}
+	if since > 0 {
+		filter.Since = time.Now().Add(-since)
+	}
+	if _, ok := logLevelSeverities[filter.Level]; filter.Level != "" && !ok {
+		return nil, fmt.Errorf("invalid log level %s, expected one of TRACE, DEBUG, INFO, WARN, ERROR or FATAL",
+			level)
+	}
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		filter.Pattern = compiled
+	}
+	return filter, nil
+}
+
+// Matches returns true if the entry passes the filter
+func (f *LogFilter) Matches(entry *LogEntry) bool {
+	if !f.Since.IsZero() && entry.time.Before(f.Since) {
+		return false
+	}
+	if f.Level != "" {
+		severity, ok := logLevelSeverities[entry.Level]
+		if !ok || severity < logLevelSeverities[f.Level] {
+			return false
+		}
+	}
+	return f.Pattern == nil || f.Pattern.MatchString(entry.raw)
+}
+
+// LogTail follows a log file of a micro integrator by polling the management api for the bytes written after
+// the offset read last. The lines written to a rotated file after the last poll before the rotation are not read
+type LogTail struct {
+	Env  string
+	File string
+	// offset is the number of bytes of the file read so far
+	offset int64
+	// overlap is the content of the file right before the offset
+	overlap []byte
+	// partial is the last line read if it is not complete yet
+	partial []byte
+	// pending is the last entry read, held back until it is known that no more lines of its stack trace follow
+	pending *LogEntry
+	// last is the last entry returned, continued by the lines read before any entry of a poll
+	last *LogEntry
+}
+
+// NewLogTail creates a tail of a log file of the micro integrator in a given environment, read from the beginning
+func NewLogTail(env, file string) *LogTail {
+	return &LogTail{Env: env, File: file}
+}
+
+// Poll reads the lines written to the log file since the last poll and returns the entries completed by them.
+// The file is not downloaded if the size listed by the management api shows that nothing has been written since
+// the last poll. The entry held back by the last poll is returned if nothing has been written since then
+func (t *LogTail) Poll() ([]*LogEntry, error) {
+	size, sizeListed, err := getLogFileSize(t.Env, t.File)
+	if err != nil {
+		return nil, err
+	}
+	if sizeListed && size == t.offset {
+		return t.Flush(), nil
+	}
+	var data []byte
+	// a file shorter than the offset has been rotated
+	if !sizeListed || size > t.offset {
+		if data, err = getLogFileFrom(t.Env, t.File, t.offset-int64(len(t.overlap))); err != nil {
+			return nil, err
+		}
+	}
+	if data == nil || !bytes.HasPrefix(data, t.overlap) {
+		utils.Logln(utils.LogPrefixInfo + "Log file " + t.File + " of " + t.Env + " has been rotated")
+		t.offset, t.overlap, t.partial = 0, nil, nil
+		if data, err = getLogFileFrom(t.Env, t.File, 0); err != nil {
+			return nil, err
+		}
+	}
+	data = data[len(t.overlap):]
+	if len(data) == 0 {
+		return t.Flush(), nil
+	}
+	t.offset += int64(len(data))
+	t.overlap = append(t.overlap, data...)
+	if len(t.overlap) > logTailOverlap {
+		t.overlap = append([]byte(nil), t.overlap[len(t.overlap)-logTailOverlap:]...)
+	}
+	return t.parse(data), nil
+}
+
+// Flush returns the entry held back by the last poll
+func (t *LogTail) Flush() []*LogEntry {
+	if t.pending == nil {
+		return nil
+	}
+	entry := t.pending
+	t.pending, t.last = nil, entry
+	return []*LogEntry{entry}
+}
+
+func (t *LogTail) parse(data []byte) []*LogEntry {
+	data = append(t.partial, data...)
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		// the line may still continue the stack trace of the pending entry
+		t.partial = data
+		return nil
+	}
+	t.partial = append([]byte(nil), data[end+1:]...)
+
+	var entries []*LogEntry
+	current := t.pending
+	for _, line := range strings.Split(string(data[:end]), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		entry := ParseCarbonLogLine(line)
+		if entry == nil && current != nil {
+			current.Message += "\n" + line
+			current.raw += "\n" + line
+			continue
+		}
+		if entry == nil {
+			// the rest of the stack trace of an entry returned by an earlier poll
+			entry = &LogEntry{Message: line, raw: line}
+			if t.last != nil {
+				entry.Timestamp, entry.Level, entry.Thread, entry.Class, entry.time =
+					t.last.Timestamp, t.last.Level, t.last.Thread, t.last.Class, t.last.time
+			}
+		}
+		entry.Env, entry.File = t.Env, t.File
+		if current != nil {
+			entries = append(entries, current)
+			t.last = current
+		}
+		current = entry
+	}
+	t.pending = current
+	return entries
+}
+
+// getLogFileSize returns the size of a log file in the list of the log files returned by the management api, as
+// GetLogFileList does without exiting if the request fails. false is returned if the file is not listed or its
+// size is not given in bytes
+func getLogFileSize(env, logFileName string) (int64, bool, error) {
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementLogResource, env, utils.MainConfigFilePath)
+	resp, err := retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		return utils.InvokeGETRequest(url, headers)
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to connect to %s: %w", url, err)
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return 0, false, handleInvalidCredentials(env)
+	default:
+		return 0, false, errors.New(resp.Status())
+	}
+	logFileList := &artifactutils.LogFileList{}
+	if err = json.Unmarshal(resp.Body(), logFileList); err != nil {
+		return 0, false, fmt.Errorf("invalid JSON response: %w", err)
+	}
+	for _, logFile := range logFileList.LogFiles {
+		if logFile.FileName == logFileName {
+			size, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(logFile.Size), "B")),
+				10, 64)
+			return size, err == nil, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// getLogFileFrom downloads a log file from the given byte offset. A range request is sent, but the whole file
+// is skipped up to the offset if the management api ignores the range. nil is returned if the file is shorter
+// than the offset
+func getLogFileFrom(env, logFileName string, start int64) ([]byte, error) {
+	params := make(map[string]string)
+	params["file"] = logFileName
+
+	url := utils.GetMIManagementEndpointOfResource(utils.MiManagementLogResource, env, utils.MainConfigFilePath)
+	resp, err := retryHTTPCall(miHTTPRetryCount, env, func(accessToken string) (*resty.Response, error) {
+		headers := make(map[string]string)
+		headers[utils.HeaderAuthorization] = utils.HeaderValueAuthBearerPrefix + " " + accessToken
+		if start > 0 {
+			headers[utils.HeaderRange] = fmt.Sprintf("bytes=%d-", start)
+		}
+		return utils.InvokeGETRequestWithMultipleQueryParams(params, url, headers)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", url, err)
+	}
+	utils.Logln(utils.LogPrefixInfo+"Response:", resp.Status())
+
+	switch resp.StatusCode() {
+	case http.StatusPartialContent:
+		return resp.Body(), nil
+	case http.StatusOK:
+		if int64(len(resp.Body())) < start {
+			return nil, nil
+		}
+		return resp.Body()[start:], nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		return nil, nil
+	case http.StatusUnauthorized:
+		return nil, handleInvalidCredentials(env)
+	}
+	return nil, errors.New(resp.Status())
+}
+
+// StreamLogs reads the log files of the tails and passes the entries matching the filter to handle, merged in the
+// order of their timestamps. If follow is set, the files are polled every interval until stop is closed and only
+// the entries written from then on are read unless the filter has a since time. While following, an entry is passed
+// on once the next entry is read or a poll finds nothing more written. A poll failing while following is reported
+// and retried with the next poll
+func StreamLogs(tails []*LogTail, filter *LogFilter, follow bool, interval time.Duration, stop <-chan struct{},
+	handle func(entry *LogEntry)) error {
+	for first := true; ; first = false {
+		var entries []*LogEntry
+		for _, tail := range tails {
+			polled, err := tail.Poll()
+			if err != nil {
+				if first || !follow {
+					return fmt.Errorf("unable to read %s of %s: %w", tail.File, tail.Env, err)
+				}
+				fmt.Fprintln(os.Stderr, utils.LogPrefixWarning+"Unable to read "+tail.File+" of "+tail.Env+": "+
+					err.Error())
+				continue
+			}
+			if !follow {
+				polled = append(polled, tail.Flush()...)
+			}
+			if first && follow && filter.Since.IsZero() {
+				tail.Flush()
+				continue
+			}
+			entries = append(entries, polled...)
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].time.Before(entries[j].time)
+		})
+		for _, entry := range entries {
+			if filter.Matches(entry) {
+				handle(entry)
+			}
+		}
+		if !follow {
+			return nil
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// PrintLogEntry prints a log entry as it is logged or as a line of json if the format is json. The lines are
+// prefixed with the environment of the entry if showEnv is set
+func PrintLogEntry(entry *LogEntry, format string, showEnv bool) {
+	if format == formatter.JsonOutputKey {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			fmt.Println("Error executing template:", err.Error())
+			return
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if !showEnv {
+		fmt.Println(entry.raw)
+		return
+	}
+	for _, line := range strings.Split(entry.raw, "\n") {
+		fmt.Println("[" + entry.Env + "] " + line)
+	}
+}
@@ -0,0 +1,345 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package impl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// stubLogFile serves a log file of a micro integrator, with or without support for range requests, and the list
+// of the log files with its size
+type stubLogFile struct {
+	mu            sync.Mutex
+	content       string
+	supportsRange bool
+	// displaySize lists the size in kilobytes instead of bytes
+	displaySize bool
+	ranges      []string
+}
+
+func (s *stubLogFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get(utils.HeaderAuthorization) != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Query().Get("file") == "" {
+		size := strconv.Itoa(len(s.content))
+		if s.displaySize {
+			size = fmt.Sprintf("%.1f KB", float64(len(s.content))/1024)
+		}
+		fmt.Fprintf(w, `{"count": 1, "list": [{"FileName": %q, "size": %q}]}`, DefaultLogFile, size)
+		return
+	}
+	s.ranges = append(s.ranges, r.Header.Get(utils.HeaderRange))
+	rangeHeader := r.Header.Get(utils.HeaderRange)
+	if !s.supportsRange || rangeHeader == "" {
+		w.Write([]byte(s.content))
+		return
+	}
+	start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+	if err != nil || start >= len(s.content) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	w.WriteHeader(http.StatusPartialContent)
+	w.Write([]byte(s.content[start:]))
+}
+
+func (s *stubLogFile) write(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content += content
+}
+
+func (s *stubLogFile) polled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.ranges) > 0
+}
+
+func (s *stubLogFile) rotate(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.content = content
+}
+
+// setUpStubMIEnvironments points each environment to its stub and takes the credentials from environment variables
+func setUpStubMIEnvironments(t *testing.T, stubs map[string]http.Handler) {
+	mainConfig := "config:\n  credential_store:\n    type: env\nenvironments:\n"
+	for env, stub := range stubs {
+		server := httptest.NewServer(stub)
+		t.Cleanup(server.Close)
+		mainConfig += "  " + env + ":\n    mi: " + server.URL + "\n"
+
+		prefix := "APICTL_" + strings.ToUpper(env) + "_MI_"
+		t.Setenv(prefix+"USERNAME", "admin")
+		t.Setenv(prefix+"PASSWORD", "admin")
+		t.Setenv(prefix+"TOKEN", "token")
+	}
+	mainConfigFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	require.NoError(t, os.WriteFile(mainConfigFilePath, []byte(mainConfig), 0644))
+	originalMainConfigFilePath := utils.MainConfigFilePath
+	utils.MainConfigFilePath = mainConfigFilePath
+	t.Cleanup(func() { utils.MainConfigFilePath = originalMainConfigFilePath })
+}
+
+func carbonLogLine(timestamp, level, message string) string {
+	return fmt.Sprintf("[%s] %5s {org.apache.synapse.mediators.builtin.LogMediator} - %s\n", timestamp, level, message)
+}
+
+func messagesOf(entries []*LogEntry) []string {
+	var messages []string
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+func TestParseCarbonLogLine(t *testing.T) {
+	entry := ParseCarbonLogLine("[2021-03-10 11:55:37,546]  INFO {org.apache.synapse.mediators.builtin.LogMediator}" +
+		" - To: /hello, MessageID: urn:uuid:1")
+	require.NotNil(t, entry)
+	assert.Equal(t, "INFO", entry.Level)
+	assert.Equal(t, "org.apache.synapse.mediators.builtin.LogMediator", entry.Class)
+	assert.Equal(t, "To: /hello, MessageID: urn:uuid:1", entry.Message)
+	assert.Empty(t, entry.Thread)
+	assert.Equal(t, time.Date(2021, 3, 10, 11, 55, 37, 546000000, time.Local), entry.time)
+	assert.Equal(t, entry.time.Format(time.RFC3339Nano), entry.Timestamp)
+}
+
+func TestParseCarbonLogLineWithThreadAndTID(t *testing.T) {
+	entry := ParseCarbonLogLine("TID: [-1234] [] [2021-03-10 11:55:37,546] [PassThroughMessageProcessor-1] ERROR " +
+		"{org.apache.synapse.endpoints.EndpointContext} - Endpoint : HelloEP has been marked for SUSPENSION")
+	require.NotNil(t, entry)
+	assert.Equal(t, "ERROR", entry.Level)
+	assert.Equal(t, "PassThroughMessageProcessor-1", entry.Thread)
+	assert.Equal(t, "org.apache.synapse.endpoints.EndpointContext", entry.Class)
+	assert.Equal(t, "Endpoint : HelloEP has been marked for SUSPENSION", entry.Message)
+}
+
+func TestParseCarbonLogLineWithoutEntry(t *testing.T) {
+	assert.Nil(t, ParseCarbonLogLine("\tat org.apache.synapse.core.axis2.Axis2Sender.sendOn(Axis2Sender.java:87)"))
+	assert.Nil(t, ParseCarbonLogLine(""))
+}
+
+func TestNewLogFilter(t *testing.T) {
+	_, err := NewLogFilter(0, "VERBOSE", "")
+	assert.Error(t, err)
+	_, err = NewLogFilter(0, "", "(")
+	assert.Error(t, err)
+
+	filter, err := NewLogFilter(10*time.Minute, "warn", "Hello.*")
+	require.NoError(t, err)
+	now := time.Now()
+	assert.True(t, filter.Matches(&LogEntry{Level: "ERROR", time: now, raw: "HelloEP suspended"}))
+	assert.False(t, filter.Matches(&LogEntry{Level: "INFO", time: now, raw: "HelloEP suspended"}))
+	assert.False(t, filter.Matches(&LogEntry{Level: "WARN", time: now, raw: "OrderEP suspended"}))
+	assert.False(t, filter.Matches(&LogEntry{Level: "WARN", time: now.Add(-time.Hour), raw: "HelloEP suspended"}))
+	assert.False(t, filter.Matches(&LogEntry{raw: "HelloEP suspended"}))
+}
+
+func TestLogTailPollsFromOffset(t *testing.T) {
+	for _, supportsRange := range []bool{true, false} {
+		t.Run(fmt.Sprintf("range %t", supportsRange), func(t *testing.T) {
+			first := "first " + strings.Repeat("x", logTailOverlap)
+			stub := &stubLogFile{supportsRange: supportsRange,
+				content: carbonLogLine("2021-03-10 11:55:37,546", "INFO", first)}
+			setUpStubMIEnvironments(t, map[string]http.Handler{"dev": stub})
+			tail := NewLogTail("dev", DefaultLogFile)
+
+			entries, err := tail.Poll()
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+			assert.Equal(t, []string{first}, messagesOf(tail.Flush()))
+			offset := len(stub.content)
+
+			stub.write(carbonLogLine("2021-03-10 11:55:38,000", "INFO", "second") + "[2021-03-10 11:55")
+			entries, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+			stub.write(":39,000]  INFO {LogMediator} - third\n")
+			entries, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"second"}, messagesOf(entries))
+			entries, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"third"}, messagesOf(entries))
+			assert.Equal(t, "", stub.ranges[0])
+			assert.Equal(t, fmt.Sprintf("bytes=%d-", offset-logTailOverlap), stub.ranges[1])
+		})
+	}
+}
+
+func TestLogTailReturnsPendingEntryIfNothingIsWritten(t *testing.T) {
+	for _, displaySize := range []bool{false, true} {
+		t.Run(fmt.Sprintf("display size %t", displaySize), func(t *testing.T) {
+			stub := &stubLogFile{supportsRange: true, displaySize: displaySize,
+				content: carbonLogLine("2021-03-10 11:55:37,546", "ERROR", "failed") + "java.lang.NullPointerException\n"}
+			setUpStubMIEnvironments(t, map[string]http.Handler{"dev": stub})
+			tail := NewLogTail("dev", DefaultLogFile)
+
+			entries, err := tail.Poll()
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+			entries, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"failed\njava.lang.NullPointerException"}, messagesOf(entries))
+			entries, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+
+			// the file is downloaded again only if its size is not listed in bytes
+			if displaySize {
+				assert.Len(t, stub.ranges, 3)
+			} else {
+				assert.Len(t, stub.ranges, 1)
+			}
+		})
+	}
+}
+
+func TestLogTailKeepsStackTraceInEntry(t *testing.T) {
+	stub := &stubLogFile{supportsRange: true, content: carbonLogLine("2021-03-10 11:55:37,546", "ERROR", "failed") +
+		"java.lang.NullPointerException\n"}
+	setUpStubMIEnvironments(t, map[string]http.Handler{"dev": stub})
+	tail := NewLogTail("dev", DefaultLogFile)
+
+	entries, err := tail.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	stub.write("\tat org.apache.synapse.Sample.run(Sample.java:1)\n" +
+		carbonLogLine("2021-03-10 11:55:38,000", "INFO", "next"))
+	entries, err = tail.Poll()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ERROR", entries[0].Level)
+	assert.Equal(t, "failed\njava.lang.NullPointerException\n\tat org.apache.synapse.Sample.run(Sample.java:1)",
+		entries[0].Message)
+	assert.Equal(t, []string{"next"}, messagesOf(tail.Flush()))
+
+	stub.write("\tat org.apache.synapse.Sample.next(Sample.java:2)\n")
+	entries, err = tail.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	entries = tail.Flush()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "INFO", entries[0].Level)
+	assert.Equal(t, "\tat org.apache.synapse.Sample.next(Sample.java:2)", entries[0].Message)
+}
+
+func TestLogTailHandlesRotation(t *testing.T) {
+	for _, supportsRange := range []bool{true, false} {
+		t.Run(fmt.Sprintf("range %t", supportsRange), func(t *testing.T) {
+			stub := &stubLogFile{supportsRange: supportsRange,
+				content: carbonLogLine("2021-03-10 11:55:37,546", "INFO", "old")}
+			setUpStubMIEnvironments(t, map[string]http.Handler{"dev": stub})
+			tail := NewLogTail("dev", DefaultLogFile)
+			_, err := tail.Poll()
+			require.NoError(t, err)
+			tail.Flush()
+
+			// shorter than the offset
+			stub.rotate(carbonLogLine("2021-03-11 00:00:00,000", "INFO", "a"))
+			_, err = tail.Poll()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a"}, messagesOf(tail.Flush()))
+
+			// longer than the offset
+			stub.rotate(carbonLogLine("2021-03-12 00:00:00,000", "INFO", "rotated again") +
+				carbonLogLine("2021-03-12 00:00:01,000", "INFO", "b"))
+			entries, err := tail.Poll()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"rotated again", "b"}, messagesOf(append(entries, tail.Flush()...)))
+		})
+	}
+}
+
+func TestStreamLogsMergesEnvironmentsByTimestamp(t *testing.T) {
+	dev := &stubLogFile{content: carbonLogLine("2021-03-10 11:55:37,000", "INFO", "dev 1") +
+		carbonLogLine("2021-03-10 11:55:39,000", "ERROR", "dev 2")}
+	prod := &stubLogFile{content: carbonLogLine("2021-03-10 11:55:38,000", "ERROR", "prod 1") +
+		carbonLogLine("2021-03-10 11:55:40,000", "ERROR", "prod 2")}
+	setUpStubMIEnvironments(t, map[string]http.Handler{"dev": dev, "prod": prod})
+
+	var entries []*LogEntry
+	err := StreamLogs([]*LogTail{NewLogTail("dev", DefaultLogFile), NewLogTail("prod", DefaultLogFile)},
+		&LogFilter{}, false, time.Millisecond, nil, func(entry *LogEntry) {
+			entries = append(entries, entry)
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev 1", "prod 1", "dev 2", "prod 2"}, messagesOf(entries))
+	assert.Equal(t, "prod", entries[1].Env)
+
+	entries = nil
+	filter, err := NewLogFilter(0, "ERROR", " 2$")
+	require.NoError(t, err)
+	err = StreamLogs([]*LogTail{NewLogTail("dev", DefaultLogFile), NewLogTail("prod", DefaultLogFile)},
+		filter, false, time.Millisecond, nil, func(entry *LogEntry) {
+			entries = append(entries, entry)
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev 2", "prod 2"}, messagesOf(entries))
+}
+
+func TestStreamLogsFollowsNewEntries(t *testing.T) {
+	stub := &stubLogFile{supportsRange: true, content: carbonLogLine("2021-03-10 11:55:37,000", "INFO", "before")}
+	setUpStubMIEnvironments(t, map[string]http.Handler{"dev": stub})
+
+	// the first poll only finds the entries logged before following, which are skipped
+	go func() {
+		for !stub.polled() {
+			time.Sleep(time.Millisecond)
+		}
+		stub.write(carbonLogLine("2021-03-10 11:55:38,000", "INFO", "after"))
+	}()
+
+	stop := make(chan struct{})
+	var messages []string
+	err := StreamLogs([]*LogTail{NewLogTail("dev", DefaultLogFile)}, &LogFilter{}, true, time.Millisecond, stop,
+		func(entry *LogEntry) {
+			messages = append(messages, entry.Message)
+			if entry.Message == "after" {
+				close(stop)
+			}
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"after"}, messages)
+}
+
+func TestStreamLogsFailsIfFirstPollFails(t *testing.T) {
+	setUpStubMIEnvironments(t, map[string]http.Handler{"dev": http.NotFoundHandler()})
+	err := StreamLogs([]*LogTail{NewLogTail("dev", DefaultLogFile)}, &LogFilter{}, true, time.Millisecond, nil,
+		func(entry *LogEntry) {})
+	assert.Error(t, err)
+}
@@ -40,5 +40,5 @@ func UpdateMILogger(env, loggerName, loggingLevel string) (interface{}, error) {
 
 func addNewMILogger(url string, body map[string]string, env string) (string, error) {
 	resp, err := invokePATCHRequestWithRetry(url, body, env)
-	return handleResponse(resp, err, url, "message", "Error")
+	return handleResponse(resp, err, env, url, "message", "Error")
 }
@@ -47,10 +47,10 @@ func DeleteMIRole(env, roleName, domain string) (interface{}, error) {
 
 func addNewMIRole(env, url string, body interface{}) (string, error) {
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func deleteMIRole(url, env string, params map[string]string) (string, error) {
 	resp, err := invokeDELETERequestWithRetryAndParams(url, env, params)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
@@ -61,12 +61,12 @@ func DeleteMIUser(env, userName, domain string) (interface{}, error) {
 
 func addNewMIUser(env, url string, body interface{}) (string, error) {
 	resp, err := invokePOSTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func deleteMIUser(url, env string, params map[string]string) (string, error) {
 	resp, err := invokeDELETERequestWithRetryAndParams(url, env, params)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func UpdateMIUser(env, userName, domain string, addedRoles, removedRoles []string) (interface{}, error) {
@@ -82,7 +82,7 @@ func UpdateMIUser(env, userName, domain string, addedRoles, removedRoles []strin
 
 func updateMIUser(env, url string, body interface{}) (string, error) {
 	resp, err := invokePUTRequestWithRetry(env, url, body)
-	return handleResponse(resp, err, url, "status", "Error")
+	return handleResponse(resp, err, env, url, "status", "Error")
 }
 
 func resolveIsAdmin(isAdminConsoleInput string) string {
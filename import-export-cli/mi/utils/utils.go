@@ -27,3 +27,6 @@ func GetTrimmedCmdLiteral(cmd string) string {
 	cmdParts := strings.Fields(cmd)
 	return cmdParts[0]
 }
+
+// AllNodes is set by the --all-nodes flag to send the requests of a command to every node of the environment
+var AllNodes bool
@@ -0,0 +1,191 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the name of the field manager apictl uses when applying resources with server-side apply
+const FieldManager = "apictl"
+
+// Options of the kubernetes client. Empty values fall back to the ones in the kubeconfig, the same way as kubectl
+type Options struct {
+	Kubeconfig string
+	Context    string
+	Namespace  string
+}
+
+// Client is a kubernetes client bound to the namespace resolved from the Options
+type Client struct {
+	client.Client
+	Namespace string
+	scheme    *runtime.Scheme
+	mapper    meta.RESTMapper
+}
+
+var defaultOptions Options
+var defaultClient *Client
+
+// SetDefaultOptions sets the options used to create the client returned by Default
+func SetDefaultOptions(options Options) {
+	defaultOptions = options
+	defaultClient = nil
+}
+
+// Default returns the client created with the options set by SetDefaultOptions. The client is created once and
+// reused afterwards
+func Default() (*Client, error) {
+	if defaultClient == nil {
+		c, err := NewClient(defaultOptions)
+		if err != nil {
+			return nil, err
+		}
+		defaultClient = c
+	}
+	return defaultClient, nil
+}
+
+// NewScheme returns a scheme with the kubernetes built-in types and the API Operator types
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = wso2v1alpha2.SchemeBuilder.AddToScheme(scheme)
+	return scheme
+}
+
+// NewClient creates a client for the cluster selected by the kubeconfig, context and namespace in the options
+func NewClient(options Options) (*Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = options.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: options.Context}
+	overrides.Context.Namespace = options.Namespace
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading kubeconfig: %v", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("error resolving namespace: %v", err)
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering resources of the cluster: %v", err)
+	}
+
+	scheme := NewScheme()
+	c, err := client.New(restConfig, client.Options{Scheme: scheme, Mapper: mapper})
+	if err != nil {
+		return nil, err
+	}
+	return NewForClient(c, scheme, mapper, namespace), nil
+}
+
+// NewForClient wraps an existing controller-runtime client, e.g. a fake client in tests
+func NewForClient(c client.Client, scheme *runtime.Scheme, mapper meta.RESTMapper, namespace string) *Client {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &Client{Client: c, Namespace: namespace, scheme: scheme, mapper: mapper}
+}
+
+// Apply creates or updates the object with server-side apply. Fields in the object are owned by FieldManager and
+// conflicts with other field managers are forced, in the same way kubectl apply --server-side --force-conflicts does
+func (c *Client) Apply(ctx context.Context, obj runtime.Object) error {
+	// apply patches are the object itself, so the type information is required in the body
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	return c.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// ApplyManifests applies all resources in the given YAML or JSON documents. Namespaced resources without a namespace
+// are applied to the namespace of the client
+func (c *Client) ApplyManifests(ctx context.Context, manifests ...[]byte) error {
+	var objects []*unstructured.Unstructured
+	for _, manifest := range manifests {
+		objs, err := DecodeManifests(manifest)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objs...)
+	}
+
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("error applying %s %q: %v", gvk.Kind, obj.GetName(), err)
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && obj.GetNamespace() == "" {
+			obj.SetNamespace(c.Namespace)
+		}
+
+		if err := c.Apply(ctx, obj); err != nil {
+			return fmt.Errorf("error applying %s %q: %v", gvk.Kind, obj.GetName(), err)
+		}
+		fmt.Printf("%s/%s applied\n", mapping.Resource.Resource, obj.GetName())
+	}
+	return nil
+}
+
+// DecodeManifests decodes the resources in a multi document YAML or JSON content. Empty documents are skipped
+func DecodeManifests(manifest []byte) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	for {
+		raw := runtime.RawExtension{}
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding manifest: %v", err)
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, fmt.Errorf("error decoding manifest: %v", err)
+		}
+		objects = append(objects, obj)
+	}
+}
@@ -0,0 +1,364 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package k8sclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// applyClient handles apply patches on top of the fake client, which only supports JSON, merge and strategic merge
+// patches, by creating or replacing the object and recording the patch options
+type applyClient struct {
+	client.Client
+	fieldManagers []string
+	forced        bool
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	patchOptions := &client.PatchOptions{}
+	patchOptions.ApplyOptions(opts)
+	c.fieldManagers = append(c.fieldManagers, patchOptions.FieldManager)
+	c.forced = patchOptions.Force != nil && *patchOptions.Force
+
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		return err
+	}
+	existing := obj.DeepCopyObject()
+	err = c.Client.Get(ctx, key, existing)
+	if apierrors.IsNotFound(err) {
+		return c.Client.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	existingMeta, _ := meta.Accessor(existing)
+	objMeta, _ := meta.Accessor(obj)
+	objMeta.SetResourceVersion(existingMeta.GetResourceVersion())
+	return c.Client.Update(ctx, obj)
+}
+
+func newTestClient(objs ...runtime.Object) (*Client, *applyClient) {
+	scheme := NewScheme()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(wso2v1alpha2.SchemeGroupVersion.WithKind("API"), meta.RESTScopeNamespace)
+
+	c := &applyClient{Client: fake.NewFakeClientWithScheme(scheme, objs...)}
+	return NewForClient(c, scheme, mapper, "wso2"), c
+}
+
+func TestApplyCreatesAndUpdatesWithFieldManager(t *testing.T) {
+	c, fakeClient := newTestClient()
+	ctx := context.Background()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore-swagger", Namespace: "wso2"},
+		Data:       map[string]string{"swagger.yaml": "v1"},
+	}
+	require.NoError(t, c.Apply(ctx, configMap))
+	assert.Equal(t, "ConfigMap", configMap.Kind, "Type information should be set for the apply patch")
+
+	updated := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore-swagger", Namespace: "wso2"},
+		Data:       map[string]string{"swagger.yaml": "v2"},
+	}
+	require.NoError(t, c.Apply(ctx, updated))
+
+	stored := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "wso2", Name: "petstore-swagger"}, stored))
+	assert.Equal(t, "v2", stored.Data["swagger.yaml"])
+	assert.Equal(t, []string{FieldManager, FieldManager}, fakeClient.fieldManagers)
+	assert.True(t, fakeClient.forced)
+}
+
+func TestApplyManifestsDefaultsNamespaceOfNamespacedResources(t *testing.T) {
+	c, _ := newTestClient()
+	ctx := context.Background()
+
+	manifests := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: wso2-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: controller-config
+  namespace: wso2-system
+data:
+  registryType: DOCKER_HUB
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: apim-config
+data:
+  verifyHostname: "true"
+`
+	require.NoError(t, c.ApplyManifests(ctx, []byte(manifests)))
+
+	ns := &corev1.Namespace{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "wso2-system"}, ns))
+	assert.Empty(t, ns.Namespace, "Cluster scoped resources should not have a namespace")
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "wso2-system", Name: "controller-config"}, configMap))
+	assert.Equal(t, "DOCKER_HUB", configMap.Data["registryType"])
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "wso2", Name: "apim-config"}, configMap))
+	assert.Equal(t, "true", configMap.Data["verifyHostname"])
+}
+
+func TestApplyManifestsUnknownKind(t *testing.T) {
+	c, _ := newTestClient()
+
+	manifest := `{"apiVersion": "example.com/v1", "kind": "Unknown", "metadata": {"name": "foo"}}`
+	err := c.ApplyManifests(context.Background(), []byte(manifest))
+	assert.Error(t, err)
+}
+
+func TestDecodeManifests(t *testing.T) {
+	manifests := `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+# only a comment
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: second
+`
+	objs, err := DecodeManifests([]byte(manifests))
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, "ConfigMap", objs[0].GetKind())
+	assert.Equal(t, "first", objs[0].GetName())
+	assert.Equal(t, "Secret", objs[1].GetKind())
+
+	_, err = DecodeManifests([]byte("metadata:\n  name: no-kind\n"))
+	assert.Error(t, err, "Resources without a kind should not be accepted")
+}
+
+func TestConfigMapFromFile(t *testing.T) {
+	c, _ := newTestClient()
+	file := filepath.Join(t.TempDir(), "swagger.yaml")
+	require.NoError(t, ioutil.WriteFile(file, []byte("openapi: 3.0.0"), 0644))
+
+	configMap, err := c.ConfigMapFromFile("petstore-swagger", file)
+	require.NoError(t, err)
+	assert.Equal(t, "wso2", configMap.Namespace)
+	assert.Equal(t, map[string]string{"swagger.yaml": "openapi: 3.0.0"}, configMap.Data)
+
+	binary := filepath.Join(t.TempDir(), "cert.der")
+	require.NoError(t, ioutil.WriteFile(binary, []byte{0xff, 0xfe, 0x00}, 0644))
+	configMap, err = c.ConfigMapFromFile("cert", binary)
+	require.NoError(t, err)
+	assert.Empty(t, configMap.Data)
+	assert.Equal(t, []byte{0xff, 0xfe, 0x00}, configMap.BinaryData["cert.der"])
+}
+
+func TestDockerRegistrySecret(t *testing.T) {
+	secret, err := DockerRegistrySecret("docker-registry-credentials", "wso2-system",
+		"https://index.docker.io/v1/", "admin", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+
+	var dockerConfig struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	require.NoError(t, json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerConfig))
+	auth := dockerConfig.Auths["https://index.docker.io/v1/"]
+	assert.Equal(t, "admin", auth.Username)
+	assert.Equal(t, "secret", auth.Password)
+	assert.Equal(t, "YWRtaW46c2VjcmV0", auth.Auth)
+}
+
+func TestDeleteResourceIgnoresNotFound(t *testing.T) {
+	c, _ := newTestClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "wso2-system"}})
+	ctx := context.Background()
+	gvk := corev1.SchemeGroupVersion.WithKind("Namespace")
+
+	require.NoError(t, c.DeleteResource(ctx, gvk, "", "wso2-system"))
+	err := c.Get(ctx, types.NamespacedName{Name: "wso2-system"}, &corev1.Namespace{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	assert.NoError(t, c.DeleteResource(ctx, gvk, "", "wso2-system"))
+}
+
+func newTestAPI() *wso2v1alpha2.API {
+	return &wso2v1alpha2.API{
+		ObjectMeta: metav1.ObjectMeta{Name: "petstore", Namespace: "wso2", UID: "api-uid"},
+		Spec:       wso2v1alpha2.APISpec{SwaggerConfigMapName: "petstore-swagger"},
+	}
+}
+
+func newTestDeployment(owner types.UID, replicas, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "petstore",
+			Namespace:       "wso2",
+			Generation:      1,
+			OwnerReferences: []metav1.OwnerReference{{Name: "petstore", UID: owner}},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  available,
+		},
+	}
+}
+
+func TestWaitForAPIReady(t *testing.T) {
+	c, _ := newTestClient(newTestAPI(), newTestDeployment("api-uid", 2, 2))
+
+	assert.NoError(t, c.WaitForAPIReady(context.Background(), "petstore", "", 10*time.Millisecond))
+}
+
+func TestWaitForAPIReadyTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		message string
+	}{
+		{"deployment not created", []runtime.Object{newTestAPI()}, "create the deployment"},
+		{"deployment of another owner", []runtime.Object{newTestAPI(), newTestDeployment("other", 1, 1)},
+			"not owned by the API"},
+		{"replicas not available", []runtime.Object{newTestAPI(), newTestDeployment("api-uid", 2, 1)},
+			"1 of 2 updated replicas are available"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := newTestClient(test.objs...)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := c.WaitForAPIReady(ctx, "petstore", "", 10*time.Millisecond)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.message)
+		})
+	}
+}
+
+func TestWaitForAPIReadyAfterUpdate(t *testing.T) {
+	api := newTestAPI()
+	api.Spec.UpdateTimeStamp = "1600000000"
+	deploy := newTestDeployment("api-uid", 1, 1)
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{{Name: "mgwpetstore",
+		Image: "wso2/petstore:v1-1500000000"}}
+
+	// the deployment of the previous update is rolled out, but not the one of this update
+	c, _ := newTestClient(api, deploy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := c.WaitForAPIReady(ctx, "petstore", "1600000000", 10*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "with the update 1600000000")
+
+	deploy.Spec.Template.Spec.Containers[0].Image = "wso2/petstore:v1-1600000000"
+	c, _ = newTestClient(api, deploy)
+	assert.NoError(t, c.WaitForAPIReady(context.Background(), "petstore", "1600000000", 10*time.Millisecond))
+
+	err = c.WaitForAPIReady(context.Background(), "petstore", "1700000000", 10*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instead of")
+}
+
+func TestWaitForAPIReadyAPINotFound(t *testing.T) {
+	c, _ := newTestClient()
+
+	err := c.WaitForAPIReady(context.Background(), "petstore", "", 10*time.Millisecond)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	deploy := newTestDeployment("api-uid", 1, 1)
+	done, _, err := deploymentRolledOut(deploy)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	deploy.Generation = 2
+	done, status, _ := deploymentRolledOut(deploy)
+	assert.False(t, done)
+	assert.Contains(t, status, "spec update to be observed")
+
+	deploy = newTestDeployment("api-uid", 2, 2)
+	deploy.Status.Replicas = 3
+	done, status, _ = deploymentRolledOut(deploy)
+	assert.False(t, done)
+	assert.Contains(t, status, "1 old replicas are pending termination")
+
+	deploy.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"},
+	}
+	_, _, err = deploymentRolledOut(deploy)
+	assert.Error(t, err)
+}
+
+func newTestCRD(name string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName(name)
+	crd.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Established", "status": "True"}},
+	}
+	return crd
+}
+
+func TestWaitForCRDsEstablished(t *testing.T) {
+	c, _ := newTestClient(newTestCRD("apis.wso2.com"))
+
+	assert.NoError(t, c.WaitForCRDsEstablished(context.Background(), 10*time.Millisecond, "apis.wso2.com"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, c.WaitForCRDsEstablished(ctx, 10*time.Millisecond, "apis.wso2.com", "securities.wso2.com"))
+}
@@ -0,0 +1,130 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package k8sclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"unicode/utf8"
+
+	wso2v1alpha2 "github.com/wso2/k8s-api-operator/api-operator/pkg/apis/wso2/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapFromFile returns a config map in the namespace of the client with the content of the file stored under
+// the file name, in the same way as kubectl create configmap --from-file
+func (c *Client) ConfigMapFromFile(name, filePath string) (*corev1.ConfigMap, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.Namespace},
+	}
+	key := filepath.Base(filePath)
+	if utf8.Valid(content) {
+		configMap.Data = map[string]string{key: string(content)}
+	} else {
+		configMap.BinaryData = map[string][]byte{key: content}
+	}
+	return configMap, nil
+}
+
+// SecretFromFile returns a generic secret in the given namespace with the content of the file. The content is stored
+// under the file name if the key is empty
+func SecretFromFile(name, namespace, filePath, key string) (*corev1.Secret, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		key = filepath.Base(filePath)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{key: content},
+	}, nil
+}
+
+// DockerRegistrySecret returns a docker-registry secret in the given namespace with the credentials of the server,
+// in the same way as kubectl create secret docker-registry
+func DockerRegistrySecret(name, namespace, server, username, password string) (*corev1.Secret, error) {
+	auth := map[string]interface{}{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+	dockerConfig, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{server: auth},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig},
+	}, nil
+}
+
+// GetAPI returns the API custom resource with the given name in the namespace of the client
+func (c *Client) GetAPI(ctx context.Context, name string) (*wso2v1alpha2.API, error) {
+	api := &wso2v1alpha2.API{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: c.Namespace, Name: name}, api); err != nil {
+		return nil, err
+	}
+	return api, nil
+}
+
+// DeleteAPI deletes the API custom resource with the given name in the namespace of the client
+func (c *Client) DeleteAPI(ctx context.Context, name string) error {
+	api := &wso2v1alpha2.API{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.Namespace}}
+	return c.Delete(ctx, api)
+}
+
+// DeleteConfigMap deletes the config map with the given name in the namespace of the client
+func (c *Client) DeleteConfigMap(ctx context.Context, name string) error {
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: c.Namespace}}
+	return c.Delete(ctx, configMap)
+}
+
+// DeleteResource deletes a resource of any kind. Resources that do not exist are ignored
+func (c *Client) DeleteResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("error deleting %s %q: %v", gvk.Kind, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// crdGVK is the group version kind of CustomResourceDefinition
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// WaitForAPIReady waits until the deployment created by the API Operator for the API custom resource is rolled out.
+// updateTimeStamp is the update timestamp applied to the API when it is updated, so that the wait is until the
+// deployment is updated with the image built for that update, instead of the rollout of the previous one. It is empty
+// when the API is added. The wait is stopped when the context is done
+func (c *Client) WaitForAPIReady(ctx context.Context, name, updateTimeStamp string, interval time.Duration) error {
+	lastStatus := ""
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		ready, status, err := c.apiReadiness(ctx, name, updateTimeStamp)
+		if err != nil {
+			return false, err
+		}
+		// only print new status
+		if status != lastStatus {
+			fmt.Println(status)
+			lastStatus = status
+		}
+		return ready, nil
+	}, ctx.Done())
+
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("API %q is not ready: %s", name, lastStatus)
+	}
+	return err
+}
+
+// apiReadiness returns whether the API is ready and a message describing the status of the API
+func (c *Client) apiReadiness(ctx context.Context, name, updateTimeStamp string) (bool, string, error) {
+	api, err := c.GetAPI(ctx, name)
+	if err != nil {
+		return false, "", err
+	}
+	if updateTimeStamp != "" && api.Spec.UpdateTimeStamp != updateTimeStamp {
+		return false, "", fmt.Errorf("API %q is updated with the timestamp %q instead of %q", name,
+			api.Spec.UpdateTimeStamp, updateTimeStamp)
+	}
+
+	// the deployment of an API has the same name as the API and is owned by it
+	deploy := &appsv1.Deployment{}
+	err = c.Get(ctx, types.NamespacedName{Namespace: api.Namespace, Name: api.Name}, deploy)
+	if apierrors.IsNotFound(err) {
+		return false, "Waiting for the API Operator to create the deployment of the API", nil
+	}
+	if err != nil {
+		return false, "", err
+	}
+	owned := false
+	for _, owner := range deploy.OwnerReferences {
+		if owner.UID == api.UID {
+			owned = true
+		}
+	}
+	if !owned {
+		return false, fmt.Sprintf("Waiting for the API Operator: deployment %q is not owned by the API", deploy.Name), nil
+	}
+	// the operator builds a new image tagged with the update timestamp, unless the image of the API is given
+	if updateTimeStamp != "" && api.Spec.Image == "" && !deploymentHasImageTag(deploy, updateTimeStamp) {
+		return false, fmt.Sprintf("Waiting for the API Operator to update deployment %q with the update %s",
+			deploy.Name, updateTimeStamp), nil
+	}
+
+	return deploymentRolledOut(deploy)
+}
+
+// deploymentHasImageTag returns whether a container of the deployment runs an image with a tag ending with
+// -<updateTimeStamp>, which the API Operator uses for the image of an update of the API
+func deploymentHasImageTag(deploy *appsv1.Deployment, updateTimeStamp string) bool {
+	for _, container := range deploy.Spec.Template.Spec.Containers {
+		tagIndex := strings.LastIndex(container.Image, ":")
+		if tagIndex > strings.LastIndex(container.Image, "/") &&
+			strings.HasSuffix(container.Image[tagIndex+1:], "-"+updateTimeStamp) {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForDeploymentRollout waits until the deployment is rolled out, in the same way as kubectl rollout status.
+// The wait is stopped when the context is done
+func (c *Client) WaitForDeploymentRollout(ctx context.Context, namespace, name string, interval time.Duration) error {
+	lastStatus := ""
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		deploy := &appsv1.Deployment{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deploy); err != nil {
+			return false, err
+		}
+		done, status, err := deploymentRolledOut(deploy)
+		if err != nil {
+			return false, err
+		}
+		if status != lastStatus {
+			fmt.Println(status)
+			lastStatus = status
+		}
+		return done, nil
+	}, ctx.Done())
+
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("deployment %q is not rolled out: %s", name, lastStatus)
+	}
+	return err
+}
+
+// deploymentRolledOut returns whether all replicas of the deployment are updated and available, with a message
+// describing the rollout status
+func deploymentRolledOut(deploy *appsv1.Deployment) (bool, string, error) {
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		return false, fmt.Sprintf("Waiting for deployment %q spec update to be observed", deploy.Name), nil
+	}
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, "", fmt.Errorf("deployment %q exceeded its progress deadline", deploy.Name)
+		}
+	}
+
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	status := deploy.Status
+	if status.UpdatedReplicas < replicas {
+		return false, fmt.Sprintf("Waiting for deployment %q rollout: %d out of %d new replicas have been updated",
+			deploy.Name, status.UpdatedReplicas, replicas), nil
+	}
+	if status.Replicas > status.UpdatedReplicas {
+		return false, fmt.Sprintf("Waiting for deployment %q rollout: %d old replicas are pending termination",
+			deploy.Name, status.Replicas-status.UpdatedReplicas), nil
+	}
+	if status.AvailableReplicas < status.UpdatedReplicas {
+		return false, fmt.Sprintf("Waiting for deployment %q rollout: %d of %d updated replicas are available",
+			deploy.Name, status.AvailableReplicas, status.UpdatedReplicas), nil
+	}
+	return true, fmt.Sprintf("Deployment %q successfully rolled out", deploy.Name), nil
+}
+
+// WaitForCRDsEstablished waits until the custom resource definitions with the given names are established.
+// The wait is stopped when the context is done
+func (c *Client) WaitForCRDsEstablished(ctx context.Context, interval time.Duration, names ...string) error {
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		for _, name := range names {
+			crd := &unstructured.Unstructured{}
+			crd.SetGroupVersionKind(crdGVK)
+			err := c.Get(ctx, types.NamespacedName{Name: name}, crd)
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			if !crdEstablished(crd) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}, ctx.Done())
+
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("kubernetes resources not installed")
+	}
+	return err
+}
+
+// crdEstablished returns whether the custom resource definition has the condition Established
+func crdEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == "Established" && cond["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
@@ -18,14 +18,18 @@
 
 package olm
 
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
 // Operator Hub Constants
 const CrdUrlTemplate = "https://github.com/operator-framework/operator-lifecycle-manager/releases/download/%s/crds.yaml"
 const OlmUrlTemplate = "https://github.com/operator-framework/operator-lifecycle-manager/releases/download/%s/olm.yaml"
 const OlmVersionValidationUrlTemplate = "https://github.com/operator-framework/operator-lifecycle-manager/tree/%s"
 const OlmVersionFindVersionUrl = "https://github.com/operator-framework/operator-lifecycle-manager/releases"
-const OperatorCsv = "csv"
 const DefaultVersion = "0.13.0"
 const VersionEnvVariable = "WSO2_OLM_VERSION"
 
 const ApiOperatorYamlUrl = "https://operatorhub.io/install/api-operator.yaml"
 const Wso2AmOperatorYamlUrl = "https://operatorhub.io/install/wso2am-operator.yaml"
+
+// CsvGroupVersionKind is the group version kind of the OLM ClusterServiceVersion
+var CsvGroupVersionKind = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"}
@@ -19,10 +19,16 @@
 package olm
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/operator/k8sclient"
 	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
-	"time"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // installOLM installs Operator Lifecycle Manager (OLM) with the given version
@@ -49,21 +55,30 @@ func InstallOLM(version string) {
 		utils.HandleErrorAndExit("Error installing OLM", err)
 	}
 
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		utils.HandleErrorAndExit("Error installing OLM", err)
+	}
+	ctx := context.Background()
+
 	// rolling out
-	if err := k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sRollOut, "status", "-w", "deployment/olm-operator", "-n", olmNamespace); err != nil {
+	if err := k8sClient.WaitForDeploymentRollout(ctx, olmNamespace, "olm-operator", time.Second); err != nil {
 		utils.HandleErrorAndExit("Error installing OLM: Rolling out deployment OLM Operator", err)
 	}
-	if err := k8sUtils.ExecuteCommand(k8sUtils.Kubectl, k8sUtils.K8sRollOut, "status", "-w", "deployment/catalog-operator", "-n", olmNamespace); err != nil {
+	if err := k8sClient.WaitForDeploymentRollout(ctx, olmNamespace, "catalog-operator", time.Second); err != nil {
 		utils.HandleErrorAndExit("Error installing OLM: Rolling out deployment Catalog Operator", err)
 	}
 
 	// wait max 50s to csv phase to be succeeded
 	csvPhase := ""
 	for i := 50; i > 0 && csvPhase != csvPhaseSucceeded; i-- {
-		newCsvPhase, err := k8sUtils.GetCommandOutput(k8sUtils.Kubectl, k8sUtils.K8sGet, OperatorCsv, "-n", olmNamespace, "packageserver", "-o", `jsonpath={.status.phase}`)
-		if err != nil {
+		csv := &unstructured.Unstructured{}
+		csv.SetGroupVersionKind(CsvGroupVersionKind)
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: olmNamespace, Name: "packageserver"}, csv)
+		if client.IgnoreNotFound(err) != nil {
 			utils.HandleErrorAndExit("Error installing OLM: Getting csv phase", err)
 		}
+		newCsvPhase, _, _ := unstructured.NestedString(csv.Object, "status", "phase")
 
 		// only print new phase
 		if csvPhase != newCsvPhase {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	k8sUtils "github.com/wso2/product-apim-tooling/import-export-cli/operator/utils"
 	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"os"
 	"path/filepath"
 	"strings"
@@ -97,29 +99,18 @@ func readAmazonEcrInputs() (string, string) {
 	return repository, credFile
 }
 
-// createAmazonEcrConfig creates K8S secret with credentials for Amazon ECR
+// createAmazonEcrConfig creates K8S config map with docker config for Amazon ECR
 func createAmazonEcrConfig() {
 	configJson := `{ "credsStore": "ecr-login" }`
 
-	tempFile, err := utils.CreateTempFile("config-*.json", []byte(configJson))
-	if err != nil {
-		utils.HandleErrorAndExit("Error writing configs to temporary file", err)
-	}
-	defer os.Remove(tempFile)
-
 	// render config map
-	configMap, err := k8sUtils.GetCommandOutput(
-		k8sUtils.Kubectl, k8sUtils.K8sCreate, k8sUtils.ConfigMap,
-		k8sUtils.AmazonCredHelperConfMap, "--from-file=config.json="+tempFile,
-		"-n", k8sUtils.ApiOpWso2Namespace,
-		"--dry-run", "-o", "yaml",
-	)
-	if err != nil {
-		utils.HandleErrorAndExit("Error creating docker config for Amazon ECR", err)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: k8sUtils.AmazonCredHelperConfMap, Namespace: k8sUtils.ApiOpWso2Namespace},
+		Data:       map[string]string{"config.json": configJson},
 	}
 
 	// apply config map
-	if err = k8sUtils.K8sApplyFromStdin(configMap); err != nil {
+	if err := k8sUtils.K8sApplyResource(configMap); err != nil {
 		utils.HandleErrorAndExit("Error creating docker config for Amazon ECR", err)
 	}
 }
@@ -162,7 +162,7 @@ func updateDockerRegistryConfig(registryType string, repository string) {
 	}
 
 	// apply controller config config map back
-	if err := k8sUtils.K8sApplyFromStdin(string(configuredRegConfigMap)); err != nil {
+	if err := k8sUtils.K8sApplyFromYaml(string(configuredRegConfigMap)); err != nil {
 		utils.HandleErrorAndExit("Error creating controller-configs", err)
 	}
 }
@@ -20,40 +20,33 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
 	"io"
-	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"time"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/operator/k8sclient"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// K8sWaitForResourceType waits maximum maxTimeSec seconds until the given custom resource definitions are established
 func K8sWaitForResourceType(maxTimeSec int, resourceTypes ...string) error {
 	if maxTimeSec < 0 {
 		return errors.New("'maxTimeSec' should be non negative")
 	}
 
-	noErrors := false
-	for i := maxTimeSec; i > 0 && !noErrors; i-- {
-		for _, resourceType := range resourceTypes {
-			noErrors = true
-			if err := ExecuteCommandWithoutPrintingErrors(Kubectl, K8sGet, resourceType); err != nil {
-				noErrors = false
-				continue
-			}
-		}
-
-		time.Sleep(1e9) // sleep 1 second
-	}
-
-	if !noErrors {
-		return errors.New("kubernetes resources not installed")
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		return err
 	}
-
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(maxTimeSec)*time.Second)
+	defer cancel()
+	return k8sClient.WaitForCRDsEstablished(ctx, time.Second, resourceTypes...)
 }
 
 // K8sCreateSecretFromInputs creates K8S a docker-registry secret with given inputs
@@ -62,80 +55,90 @@ func K8sCreateSecretFromInputs(secretName string, namespace string, server strin
 		username = "N/A"
 		password = "N/A"
 	}
-	dockerSecret, err := GetCommandOutput(
-		Kubectl, K8sCreate, Secret, K8sSecretDockerRegType, secretName,
-		"--docker-server", server,
-		"--docker-username", username,
-		"--docker-password", password,
-		"-n", namespace,
-		"--dry-run", "-o", "yaml",
-	)
-
+	dockerSecret, err := k8sclient.DockerRegistrySecret(secretName, namespace, server, username, password)
 	if err != nil {
 		utils.HandleErrorAndExit("Error rendering kubernetes secret for Docker Hub", err)
 	}
 
-	// apply created secret yaml file
-	if err := K8sApplyFromStdin(dockerSecret); err != nil {
+	// apply created secret
+	if err := K8sApplyResource(dockerSecret); err != nil {
 		utils.HandleErrorAndExit("Error creating docker secret credentials", err)
 	}
 }
 
 // K8sCreateSecretFromFile creates K8S a generic secret with give file
 func K8sCreateSecretFromFile(secretName string, namespace string, filePath string, renamedFile string) {
-	var fromFile string
-	if renamedFile == "" {
-		fromFile = fmt.Sprintf("--from-file=%s", filePath)
-	} else {
-		fromFile = fmt.Sprintf("--from-file=%s=%s", renamedFile, filePath)
-	}
-
 	// render secret
-	secret, err := GetCommandOutput(
-		Kubectl, K8sCreate, Secret, "generic",
-		secretName, fromFile,
-		"-n", namespace,
-		"--dry-run", "-o", "yaml",
-	)
+	secret, err := k8sclient.SecretFromFile(secretName, namespace, filePath, renamedFile)
 	if err != nil {
 		utils.HandleErrorAndExit("Error creating secret from file", err)
 	}
 
 	// apply secret
-	if err = K8sApplyFromStdin(secret); err != nil {
+	if err = K8sApplyResource(secret); err != nil {
 		utils.HandleErrorAndExit("Error creating secret from file", err)
 	}
 }
 
 // K8sApplyFromFile applies resources from list of files, urls or directories
 func K8sApplyFromFile(fileList ...string) error {
-	kubectlArgs := []string{K8sApply}
+	var data [][]byte
 	for _, file := range fileList {
-		kubectlArgs = append(kubectlArgs, "-f", file)
+		fileData, err := readConfigFiles(file)
+		if err != nil {
+			return err
+		}
+		data = append(data, fileData...)
 	}
 
-	return ExecuteCommand(Kubectl, kubectlArgs...)
+	return K8sApplyFromBytes(data)
 }
 
 // K8sApplyFromBytes applies resources by content
 func K8sApplyFromBytes(data [][]byte) error {
-	dir, _ := ioutil.TempDir("", "example")
-	defer os.RemoveAll(dir) // clean up
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		return err
+	}
+	return k8sClient.ApplyManifests(context.Background(), data...)
+}
 
-	for i, d := range data {
-		tmpFile := filepath.Join(dir, fmt.Sprintf("config-file-%v.yml", i))
-		err := ioutil.WriteFile(tmpFile, d, 0666) // permission -rw-rw-rw
-		if err != nil {
-			return err
-		}
+// K8sApplyFromYaml applies resources from yaml content
+func K8sApplyFromYaml(yamlContent string) error {
+	return K8sApplyFromBytes([][]byte{[]byte(yamlContent)})
+}
+
+// K8sApplyResource applies the given resource with server-side apply
+func K8sApplyResource(obj runtime.Object) error {
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		return err
 	}
+	return k8sClient.Apply(context.Background(), obj)
+}
 
-	return ExecuteCommand(Kubectl, K8sApply, "-f", dir)
+// clusterResourceGroupVersions are the group versions of the cluster scoped resource kinds deleted by apictl
+var clusterResourceGroupVersions = map[string]schema.GroupVersion{
+	Namespace:          {Version: "v1"},
+	ClusterRole:        {Group: "rbac.authorization.k8s.io", Version: "v1"},
+	ClusterRoleBinding: {Group: "rbac.authorization.k8s.io", Version: "v1"},
+	CrdKind:            {Group: "apiextensions.k8s.io", Version: "v1"},
 }
 
-// K8sApplyFromStdin applies resources from standard input
-func K8sApplyFromStdin(stdInputs string) error {
-	return ExecuteCommandFromStdin(stdInputs, Kubectl, K8sApply, "-f", "-")
+// K8sDeleteClusterResource deletes the cluster scoped resource of the given kind and name. Resources that do not
+// exist are ignored
+func K8sDeleteClusterResource(kind string, name string) error {
+	groupVersion, ok := clusterResourceGroupVersions[kind]
+	if !ok {
+		return errors.New("unsupported resource kind: " + kind)
+	}
+
+	k8sClient, err := k8sclient.Default()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleting %s: %s\n", kind, name)
+	return k8sClient.DeleteResource(context.Background(), groupVersion.WithKind(kind), "", name)
 }
 
 // ExecuteCommand executes the command with args and prints output, errors in standard output, error
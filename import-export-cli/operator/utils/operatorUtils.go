@@ -61,7 +61,7 @@ func GetVersion(name string, envVar string, defaultVersion string, versionValida
 	return version, nil
 }
 
-// CreateControllerConfigs apply (kubectl apply) configs to the k8s cluster
+// CreateControllerConfigs applies (server-side apply) configs to the k8s cluster
 func CreateControllerConfigs(configFile string, maxTimeSec int, resourceTypes ...string) {
 	configData := *readConfigData(configFile)
 
@@ -127,51 +127,63 @@ func CreateControllerConfigs(configFile string, maxTimeSec int, resourceTypes ..
 
 // readConfigData reads content of configFile from configFile of type: URL, local file or dir
 func readConfigData(configFile string) *[][]byte {
+	configData, err := readConfigFiles(configFile)
+	if err != nil {
+		utils.HandleErrorAndExit("Error reading configs", err)
+	}
+	return &configData
+}
+
+// readConfigFiles reads content of configFile of type: URL, local file or dir (yaml files in the dir)
+func readConfigFiles(configFile string) ([][]byte, error) {
 	// read from URL
 	if utils.IsValidUrl(configFile) {
-		utils.Logln(utils.LogPrefixInfo + "Installing controller configs using URL")
+		utils.Logln(utils.LogPrefixInfo + "Reading configs using URL")
 
 		data, err := utils.ReadFromUrl(configFile)
 		if err != nil {
-			utils.HandleErrorAndExit("Error reading configs from URL: "+configFile, err)
+			return nil, fmt.Errorf("error reading configs from URL: %s: %v", configFile, err)
 		}
-		return &[][]byte{data}
+		return [][]byte{data}, nil
 	}
 
 	// read from local file or dir
-	if stat, err := os.Stat(configFile); !os.IsNotExist(err) {
-		// local file
-		if !stat.IsDir() {
-			utils.Logln(utils.LogPrefixInfo + "Installing controller configs using local file")
-
-			data, err := ioutil.ReadFile(configFile)
-			if err != nil {
-				utils.HandleErrorAndExit("Error reading configs from local file: "+configFile, err)
-			}
-			return &[][]byte{data}
-		}
+	stat, err := os.Stat(configFile)
+	if os.IsNotExist(err) {
+		return nil, errors.New("config file does not exists")
+	}
+	if err != nil {
+		return nil, err
+	}
 
-		// local dir
-		utils.Logln(utils.LogPrefixInfo + "Installing controller configs using local dir")
+	// local file
+	if !stat.IsDir() {
+		utils.Logln(utils.LogPrefixInfo + "Reading configs using local file")
 
-		configDir, err := ioutil.ReadDir(configFile)
+		data, err := ioutil.ReadFile(configFile)
 		if err != nil {
-			utils.HandleErrorAndExit("Error reading configs from local dir: "+configFile, err)
+			return nil, fmt.Errorf("error reading configs from local file: %s: %v", configFile, err)
 		}
-		var configData [][]byte
-		for _, file := range configDir {
-			if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
-				f := filepath.Join(configFile, file.Name())
-				data, err := ioutil.ReadFile(f)
-				if err != nil {
-					utils.HandleErrorAndExit("Error reading configs from local file: "+f, err)
-				}
-				configData = append(configData, data)
+		return [][]byte{data}, nil
+	}
+
+	// local dir
+	utils.Logln(utils.LogPrefixInfo + "Reading configs using local dir")
+
+	configDir, err := ioutil.ReadDir(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configs from local dir: %s: %v", configFile, err)
+	}
+	var configData [][]byte
+	for _, file := range configDir {
+		if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
+			f := filepath.Join(configFile, file.Name())
+			data, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("error reading configs from local file: %s: %v", f, err)
 			}
+			configData = append(configData, data)
 		}
-		return &configData
-	} else {
-		utils.HandleErrorAndExit("Error reading configs", errors.New("config file does not exists"))
-		return nil
 	}
+	return configData, nil
 }
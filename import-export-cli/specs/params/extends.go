@@ -0,0 +1,279 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// ExtendsKey is the key used by a params file to extend another params file and by an environment to extend
+// another environment of the same params file
+const ExtendsKey = "extends"
+
+// loadResolvedParamsDocument loads the params file in path as a generic document, merges it on top of the params
+// files it extends and resolves the environments which extend other environments.
+func loadResolvedParamsDocument(path string) (map[string]interface{}, error) {
+	document, err := loadParamsDocument(path, []string{})
+	if err != nil {
+		return nil, err
+	}
+	if err = resolveEnvironmentOverlays(document); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return document, nil
+}
+
+// loadParamsDocument loads the params file in path, after merging it on top of the params file given under extends.
+// visited contains the params files which extend the current one and is used to detect cycles.
+func loadParamsDocument(path string, visited []string) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, visitedPath := range visited {
+		if visitedPath == absPath {
+			return nil, errors.New("params files extend each other in a cycle: " +
+				strings.Join(append(visited, absPath), " -> "))
+		}
+	}
+	visited = append(visited, absPath)
+
+	utils.Logln(utils.LogPrefixInfo + "Loading params from " + absPath)
+	fileContent, err := GetEnvSubstitutedFileContent(absPath)
+	if err != nil {
+		return nil, err
+	}
+	var content interface{}
+	if err = yaml.Unmarshal([]byte(fileContent), &content); err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return map[string]interface{}{}, nil
+	}
+	document, ok := normalizeParamsValue(content).(map[string]interface{})
+	if !ok {
+		return nil, errors.New(path + " is not a valid params file")
+	}
+
+	base, ok := document[ExtendsKey]
+	if !ok {
+		return document, nil
+	}
+	delete(document, ExtendsKey)
+	basePath, ok := base.(string)
+	if !ok || basePath == "" {
+		return nil, errors.New(path + ": " + ExtendsKey + " should be the path of a params file")
+	}
+	baseDocument, err := loadParamsDocument(resolveBaseParamsPath(filepath.Dir(absPath), basePath), visited)
+	if err != nil {
+		return nil, err
+	}
+	return mergeParamsDocuments(baseDocument, document), nil
+}
+
+// resolveBaseParamsPath resolves the path given under extends relative to the directory of the extending params
+// file. The .yaml or .yml extension can be omitted.
+func resolveBaseParamsPath(dir, basePath string) string {
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(dir, basePath)
+	}
+	if filepath.Ext(basePath) == "" {
+		for _, ext := range []string{".yaml", ".yml"} {
+			if utils.IsFileExist(basePath + ext) {
+				return basePath + ext
+			}
+		}
+	}
+	return basePath
+}
+
+// mergeParamsDocuments merges the overlay params document on top of the base params document. Environments are
+// matched by their names, so an environment defined in both documents is deep merged and the other environments are
+// kept in the order they are defined.
+func mergeParamsDocuments(base, overlay map[string]interface{}) map[string]interface{} {
+	baseEnvs, _ := base[environmentsKey].([]interface{})
+	overlayEnvs, hasOverlayEnvs := overlay[environmentsKey].([]interface{})
+
+	merged := DeepMerge(base, overlay).(map[string]interface{})
+	if !hasOverlayEnvs {
+		return merged
+	}
+	envs := make([]interface{}, 0, len(baseEnvs)+len(overlayEnvs))
+	envs = append(envs, baseEnvs...)
+	for _, overlayEnv := range overlayEnvs {
+		index := findParamsEnvironment(envs, getParamsEnvironmentName(overlayEnv))
+		if index < 0 {
+			envs = append(envs, overlayEnv)
+		} else {
+			envs[index] = DeepMerge(envs[index], overlayEnv)
+		}
+	}
+	merged[environmentsKey] = envs
+	return merged
+}
+
+// resolveEnvironmentOverlays merges every environment which extends another environment on top of the resolved
+// configs of that environment
+func resolveEnvironmentOverlays(document map[string]interface{}) error {
+	envs, ok := document[environmentsKey].([]interface{})
+	if !ok {
+		return nil
+	}
+	resolved := make(map[string]bool)
+	for _, env := range envs {
+		if err := resolveEnvironmentOverlay(envs, getParamsEnvironmentName(env), resolved, []string{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveEnvironmentOverlay(envs []interface{}, name string, resolved map[string]bool, visited []string) error {
+	if resolved[name] {
+		return nil
+	}
+	for _, visitedName := range visited {
+		if visitedName == name {
+			return errors.New("environments extend each other in a cycle: " +
+				strings.Join(append(visited, name), " -> "))
+		}
+	}
+	visited = append(visited, name)
+
+	env, _ := envs[findParamsEnvironment(envs, name)].(map[string]interface{})
+	parent, ok := env[ExtendsKey]
+	if !ok {
+		resolved[name] = true
+		return nil
+	}
+	parentName, ok := parent.(string)
+	if !ok || parentName == "" {
+		return errors.New("environment '" + name + "': " + ExtendsKey + " should be the name of an environment")
+	}
+	parentIndex := findParamsEnvironment(envs, parentName)
+	if parentIndex < 0 {
+		return errors.New("environment '" + name + "' extends the environment '" + parentName +
+			"' which does not exist")
+	}
+	if err := resolveEnvironmentOverlay(envs, parentName, resolved, visited); err != nil {
+		return err
+	}
+
+	parentEnv, _ := envs[parentIndex].(map[string]interface{})
+	delete(env, ExtendsKey)
+	if parentConfigs, ok := parentEnv[configsKey]; ok {
+		if configs, ok := env[configsKey]; ok {
+			env[configsKey] = DeepMerge(parentConfigs, configs)
+		} else {
+			env[configsKey] = DeepMerge(parentConfigs, map[string]interface{}{})
+		}
+	}
+	resolved[name] = true
+	return nil
+}
+
+func findParamsEnvironment(envs []interface{}, name string) int {
+	for index, env := range envs {
+		if getParamsEnvironmentName(env) == name {
+			return index
+		}
+	}
+	return -1
+}
+
+func getParamsEnvironmentName(env interface{}) string {
+	if envMap, ok := env.(map[string]interface{}); ok {
+		if name, ok := envMap[nameKey]; ok && name != nil {
+			return fmt.Sprint(name)
+		}
+	}
+	return ""
+}
+
+// DeepMerge merges overlay on top of base and returns the result without modifying any of them. Maps are merged
+// key by key, while lists and scalar values in overlay replace the values in base. A key set to null in overlay
+// removes the key from the result.
+func DeepMerge(base, overlay interface{}) interface{} {
+	baseMap, isBaseMap := base.(map[string]interface{})
+	overlayMap, isOverlayMap := overlay.(map[string]interface{})
+	if !isBaseMap || !isOverlayMap {
+		return copyParamsValue(overlay)
+	}
+	merged := make(map[string]interface{}, len(baseMap)+len(overlayMap))
+	for key, value := range baseMap {
+		merged[key] = copyParamsValue(value)
+	}
+	for key, value := range overlayMap {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		if baseValue, ok := merged[key]; ok {
+			merged[key] = DeepMerge(baseValue, value)
+		} else {
+			merged[key] = copyParamsValue(value)
+		}
+	}
+	return merged
+}
+
+func copyParamsValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typedValue))
+		for key, element := range typedValue {
+			copied[key] = copyParamsValue(element)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(typedValue))
+		for index, element := range typedValue {
+			copied[index] = copyParamsValue(element)
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// normalizeParamsValue converts the maps unmarshalled by yaml to maps with string keys
+func normalizeParamsValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(typedValue))
+		for key, element := range typedValue {
+			normalized[fmt.Sprint(key)] = normalizeParamsValue(element)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(typedValue))
+		for index, element := range typedValue {
+			normalized[index] = normalizeParamsValue(element)
+		}
+		return normalized
+	default:
+		return value
+	}
+}
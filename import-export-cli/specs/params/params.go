@@ -2,6 +2,7 @@ package params
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -139,6 +140,8 @@ type APIEndpointConfig struct {
 }
 
 // loads the given file in path and substitutes environment variables that are defined as ${var} or $var in the file.
+// Secret references defined as {{ secret "vault://<mount>/<path>#<key>" }}, {{ secret "file://<path>" }} or
+// {{ secret "env://<var>" }} are substituted with the resolved secrets.
 //
 //	returns the file as string.
 func GetEnvSubstitutedFileContent(path string) (string, error) {
@@ -159,41 +162,56 @@ func GetEnvSubstitutedFileContent(path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	str, err = utils.SubstituteSecretRefs(str)
+	if err != nil {
+		return "", err
+	}
 	return str, nil
 }
 
 // LoadApiParamsFromDirectory loads an API Project configuration YAML file located in path when the root
-// directory is provided instead of yaml file.
+// directory is provided instead of yaml file. The params files and environments it extends are resolved as in
+// LoadApiParamsFromFile.
 //
 //	It returns an error or a valid ApiParams
 func LoadApiParamsFromDirectory(path string) (*ApiParams, error) {
-	paramsFilePath := filepath.Join(path, utils.ParamFile)
-	utils.Logln(utils.LogPrefixInfo + "Loading params from " + paramsFilePath)
-	fileContent, err := GetEnvSubstitutedFileContent(paramsFilePath)
+	return loadApiParams(filepath.Join(path, utils.ParamFile))
+}
+
+// LoadApiParamsFromFile loads an API Project configuration YAML file located in path.
+// The file can extend another params file using "extends: <path>" and an environment can extend another
+// environment using "extends: <environment-name>", in which case the configs are deep merged.
+//
+//	It returns an error or a valid ApiParams
+func LoadApiParamsFromFile(path string) (*ApiParams, error) {
+	return loadApiParams(path)
+}
+
+// LoadResolvedApiParams loads the API params file in path, resolves the params files and environments it extends
+// and validates the result against the params schema. The resolved params are returned as a generic document.
+func LoadResolvedApiParams(path string) (map[string]interface{}, error) {
+	document, err := loadResolvedParamsDocument(path)
 	if err != nil {
 		return nil, err
 	}
+	if err = ValidateApiParamsDocument(document); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return document, nil
+}
 
-	apiParams := &ApiParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+func loadApiParams(path string) (*ApiParams, error) {
+	document, err := LoadResolvedApiParams(path)
 	if err != nil {
 		return nil, err
 	}
-
-	return apiParams, err
-}
-
-// LoadApiParamsFromFile loads an API Project configuration YAML file located in path.
-//
-//	It returns an error or a valid ApiParams
-func LoadApiParamsFromFile(path string) (*ApiParams, error) {
-	fileContent, err := GetEnvSubstitutedFileContent(path)
+	resolvedContent, err := yaml.Marshal(document)
 	if err != nil {
 		return nil, err
 	}
 
 	apiParams := &ApiParams{}
-	err = yaml.Unmarshal([]byte(fileContent), &apiParams)
+	err = yaml.Unmarshal(resolvedContent, &apiParams)
 	if err != nil {
 		return nil, err
 	}
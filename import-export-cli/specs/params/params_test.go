@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
 )
 
 // loadAPIFromFile loads API file from the path and returns a slice of bytes or an error
@@ -59,3 +60,98 @@ func TestAPIConfig_ContainsEnv(t *testing.T) {
 	assert.NotNil(t, configData.GetEnv("dev"), "Should contain correct environment")
 	assert.Nil(t, configData.GetEnv("prod"), "Should not contain undefined environment")
 }
+
+func TestLoadApiParamsFromFileWithExtends(t *testing.T) {
+	apiParams, err := LoadApiParamsFromFile("testdata/extends/prod.yaml")
+	assert.Nil(t, err, "Error should be nil when the extended params file exists")
+	assert.Len(t, apiParams.Environments, 3, "Environments of both the files should be available")
+
+	dev := apiParams.GetEnv("dev")
+	assert.NotNil(t, dev, "Environments of the extended file should be available")
+
+	prod := apiParams.GetEnv("prod")
+	endpoints := prod.Config["endpoints"].(map[interface{}]interface{})
+	production := endpoints["production"].(map[interface{}]interface{})
+	assert.Equal(t, "https://prod.pizza.com", production["url"], "Values which are not overridden should be kept")
+	assert.NotContains(t, endpoints, "sandbox", "A key set to null should be removed")
+	assert.Contains(t, prod.Config, "security", "Values of the extending file should be merged")
+
+	prodEu := apiParams.GetEnv("prod-eu")
+	endpoints = prodEu.Config["endpoints"].(map[interface{}]interface{})
+	production = endpoints["production"].(map[interface{}]interface{})
+	assert.Equal(t, "https://eu.prod.pizza.com", production["url"], "Overlay should override the parent environment")
+	assert.Equal(t, 60, production["config"].(map[interface{}]interface{})["retryTimeOut"],
+		"Nested values of the parent environment should be kept")
+	assert.Contains(t, prodEu.Config, "security", "Overlay should inherit the configs of the parent environment")
+	assert.Equal(t, []interface{}{"Unlimited"}, prodEu.Config["policies"], "Lists should be replaced")
+
+	assert.True(t, apiParams.Deploy.Import.Update, "Deploy params of the extended file should be kept")
+	assert.True(t, apiParams.Deploy.Import.PreserveProvider, "Deploy params should be merged")
+}
+
+func TestLoadApiParamsFromFileWithExtendsCycle(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/extends/cycle-a.yaml")
+	assert.Error(t, err, "Should return an error when params files extend each other")
+	assert.Contains(t, err.Error(), "cycle")
+
+	_, err = LoadApiParamsFromFile("testdata/extends/env-cycle.yaml")
+	assert.Error(t, err, "Should return an error when environments extend each other")
+	assert.Contains(t, err.Error(), "prod -> prod-eu -> prod")
+}
+
+func TestLoadApiParamsFromFileWithMissingParentEnv(t *testing.T) {
+	_, err := LoadApiParamsFromFile("testdata/extends/missing-env.yaml")
+	assert.Error(t, err, "Should return an error when the extended environment does not exist")
+}
+
+func TestDeepMerge(t *testing.T) {
+	base := map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 2}, "list": []interface{}{1, 2}}
+	overlay := map[string]interface{}{"a": map[string]interface{}{"c": 3, "b": nil}, "list": []interface{}{3}}
+	merged := DeepMerge(base, overlay)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"c": 3}, "list": []interface{}{3}}, merged)
+	assert.Equal(t, 1, base["a"].(map[string]interface{})["b"], "Base should not be modified")
+}
+
+func TestValidateApiParamsDocument(t *testing.T) {
+	schema, err := ioutil.ReadFile(filepath.Join("..", "..", "box", "resources", "schemas", "api_params.schema.json"))
+	assert.Nil(t, err)
+	box.Add(ApiParamsSchemaResource, schema)
+
+	_, err = LoadApiParamsFromFile("testdata/extends/prod.yaml")
+	assert.Nil(t, err, "Resolved params should match the schema")
+
+	_, err = LoadApiParamsFromFile("testdata/extends/invalid.yaml")
+	assert.Error(t, err, "Should return an error for params which do not match the schema")
+	assert.Contains(t, err.Error(), `/environments/0/configs: property "endpoint" is unsupported`)
+	assert.Contains(t, err.Error(), "/environments/0/configs/endpointRoutingPolicy")
+	assert.Contains(t, err.Error(), "/environments/0/configs/security/production")
+}
+
+func TestShippedParamsFilesMatchSchema(t *testing.T) {
+	schema, err := ioutil.ReadFile(filepath.Join("..", "..", "box", "resources", "schemas", "api_params.schema.json"))
+	assert.Nil(t, err)
+	box.Add(ApiParamsSchemaResource, schema)
+	t.Setenv("DEV_ENV_PROD_URL", "https://prod.wso2.com")
+	t.Setenv("DEV_ENV_SAND_URL", "https://sand.wso2.com")
+	t.Setenv("DEV_ENV_PROD_RE_DELAY", "1000")
+	t.Setenv("DEV_ENV_PROD_RE_TO", "3")
+	t.Setenv("DEPENDENTAPI_2", "PizzaShackAPI-1.0.0")
+
+	// the templates of "gen deployment-dir" and the params files of the integration tests
+	paramsFiles, err := filepath.Glob(filepath.Join("..", "..", "box", "resources", "sample", "api*_params.yaml"))
+	assert.Nil(t, err)
+	integrationParamsFiles, err := filepath.Glob(filepath.Join("..", "..", "integration", "testdata",
+		"EnvParamsFiles", "*.yaml"))
+	assert.Nil(t, err)
+	paramsFiles = append(paramsFiles, integrationParamsFiles...)
+	assert.NotEmpty(t, integrationParamsFiles)
+
+	for _, paramsFile := range paramsFiles {
+		// the invalid params files of the integration tests are rejected by API Manager
+		if strings.HasPrefix(filepath.Base(paramsFile), "invalid_") {
+			continue
+		}
+		_, err = LoadResolvedApiParams(paramsFile)
+		assert.Nil(t, err, paramsFile+" should match the schema")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/wso2/product-apim-tooling/import-export-cli/box"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// ApiParamsSchemaResource is the location of the JSON Schema of the API params files in the resource box
+const ApiParamsSchemaResource = "/schemas/api_params.schema.json"
+
+const environmentsKey = "environments"
+const configsKey = "configs"
+const nameKey = "name"
+
+// ValidateApiParamsDocument validates a resolved API params document against the JSON Schema shipped with apictl.
+// All the violations are returned in a single error.
+func ValidateApiParamsDocument(document map[string]interface{}) error {
+	schemaContent, ok := box.Get(ApiParamsSchemaResource)
+	if !ok {
+		utils.Logln(utils.LogPrefixWarning + "Schema of the params files is not available. Skipping validation")
+		return nil
+	}
+	schema := &openapi3.Schema{}
+	if err := json.Unmarshal(schemaContent, schema); err != nil {
+		return err
+	}
+
+	// convert the document to the types used by encoding/json, which are the types expected by the validator
+	documentJson, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err = json.Unmarshal(documentJson, &value); err != nil {
+		return err
+	}
+
+	err = schema.VisitJSON(value, openapi3.MultiErrors())
+	if err == nil {
+		return nil
+	}
+	var violations []string
+	collectSchemaViolations(err, &violations)
+	return errors.New("params do not match the schema:\n  " + strings.Join(violations, "\n  "))
+}
+
+func collectSchemaViolations(err error, violations *[]string) {
+	switch typedErr := err.(type) {
+	case openapi3.MultiError:
+		for _, nestedErr := range typedErr {
+			collectSchemaViolations(nestedErr, violations)
+		}
+	case *openapi3.SchemaError:
+		*violations = append(*violations, "/"+strings.Join(typedErr.JSONPointer(), "/")+": "+typedErr.Reason)
+	default:
+		*violations = append(*violations, err.Error())
+	}
+}
@@ -20,6 +20,9 @@ package v2
 
 import (
 	"encoding/json"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -117,6 +120,10 @@ func oai3XWSO2AuthHeader(exts map[string]interface{}) (string, bool) {
 
 func oai3WSO2Basepath(exts map[string]interface{}) (string, bool, error) {
 	if v, ok := exts["x-wso2-basePath"]; ok {
+		// newer versions of the loader decode the extensions instead of keeping the raw json
+		if basepath, ok := v.(string); ok {
+			return basepath, true, nil
+		}
 		data, ok := v.(json.RawMessage)
 		if ok {
 			var basepath string
@@ -155,3 +162,28 @@ func oai3GetHttpVerbs(item *openapi3.PathItem) (verbs []string) {
 	}
 	return
 }
+
+// OAI3Populate populates the name, version and context of the API definition using an OpenAPI 3 document.
+// The context is only populated if the document declares it, either using x-wso2-basePath or the path of the
+// first server url.
+func OAI3Populate(def *APIDTODefinition, document *openapi3.T) error {
+	if document.Info != nil {
+		def.Name = strings.ReplaceAll(document.Info.Title, " ", "")
+		def.Version = strings.ReplaceAll(document.Info.Version, " ", "")
+	}
+	basepath, ok, err := oai3WSO2Basepath(document.Extensions)
+	if err != nil {
+		return err
+	}
+	if !ok && len(document.Servers) > 0 {
+		serverUrl, err := url.Parse(document.Servers[0].URL)
+		if err != nil {
+			return err
+		}
+		basepath, ok = serverUrl.Path, serverUrl.Path != "" && serverUrl.Path != "/"
+	}
+	if ok {
+		def.Context = path.Clean(strings.ReplaceAll(strings.ReplaceAll(basepath, "{version}", def.Version), " ", ""))
+	}
+	return nil
+}
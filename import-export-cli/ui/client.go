@@ -0,0 +1,207 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package ui
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// apiSearchQueryPrefix filters APIs from the results of the unified search endpoint
+const apiSearchQueryPrefix = "type:\"API\" "
+
+// client is the set of operations the browser performs on an environment
+type client interface {
+	environment() string
+	listAPIs(query string) ([]utils.API, error)
+	listAPIProducts(query string) ([]utils.APIProduct, error)
+	listApps() ([]utils.Application, error)
+	listRevisions(api utils.API) ([]utils.Revisions, error)
+	listSubscriptions(api utils.API) ([]utils.SubscriptionEntry, error)
+	listGatewayEnvironments() ([]utils.GatewayEnvironment, error)
+	getDefinition(api utils.API) (string, error)
+	exportAPI(api utils.API) (string, error)
+	exportAPIProduct(product utils.APIProduct) (string, error)
+	exportApp(app utils.Application) (string, error)
+	changeAPIStatus(api utils.API, action string) error
+	deployRevision(api utils.API, revision utils.Revisions, gateway utils.GatewayEnvironment) error
+	undeployRevision(api utils.API, deployment utils.DeploymentEntry) error
+	deleteAPI(api utils.API) error
+	deleteAPIProduct(product utils.APIProduct) error
+	deleteApp(app utils.Application) error
+}
+
+// implClient performs the operations of the browser with the impl functions used by the apictl commands
+type implClient struct {
+	accessToken string
+	env         string
+}
+
+func newImplClient(accessToken, environment string) *implClient {
+	return &implClient{accessToken: accessToken, env: environment}
+}
+
+func (c *implClient) environment() string {
+	return c.env
+}
+
+// listAPIs lists the APIs of the environment, searching them through the unified search endpoint when a query
+// is given
+func (c *implClient) listAPIs(query string) ([]utils.API, error) {
+	if query == "" {
+		_, apis, err := impl.GetAPIListFromEnv(c.accessToken, c.env, "", displayLimit)
+		return apis, err
+	}
+	unifiedSearchEndpoint := utils.GetUnifiedSearchEndpointOfEnv(c.env, utils.MainConfigFilePath)
+	_, apis, err := impl.GetAPIList(c.accessToken, unifiedSearchEndpoint, apiSearchQueryPrefix+query,
+		displayLimit)
+	return apis, err
+}
+
+func (c *implClient) listAPIProducts(query string) ([]utils.APIProduct, error) {
+	_, products, err := impl.GetAPIProductListFromEnv(c.accessToken, c.env, query, displayLimit)
+	return products, err
+}
+
+func (c *implClient) listApps() ([]utils.Application, error) {
+	_, apps, err := impl.GetApplicationListFromEnv(c.accessToken, c.env, "", displayLimit)
+	return apps, err
+}
+
+func (c *implClient) listRevisions(api utils.API) ([]utils.Revisions, error) {
+	revisionListEndpoint := utils.AppendSlashToString(utils.GetApiListEndpointOfEnv(c.env, utils.MainConfigFilePath)) +
+		api.ID + "/revisions"
+	_, revisions, err := impl.GetRevisionsList(c.accessToken, revisionListEndpoint)
+	return revisions, err
+}
+
+func (c *implClient) listSubscriptions(api utils.API) ([]utils.SubscriptionEntry, error) {
+	subscriptionListEndpoint := utils.GetPublisherEndpointOfEnv(c.env, utils.MainConfigFilePath) + "/subscriptions"
+	_, subscriptions, err := impl.GetAPISubscriptionList(c.accessToken, subscriptionListEndpoint, api.ID,
+		displayLimit, "")
+	return subscriptions, err
+}
+
+func (c *implClient) listGatewayEnvironments() ([]utils.GatewayEnvironment, error) {
+	_, gatewayEnvironments, err := impl.GetGatewayEnvironmentListFromEnv(c.accessToken, c.env)
+	return gatewayEnvironments, err
+}
+
+func (c *implClient) getDefinition(api utils.API) (string, error) {
+	return impl.GetAPISwaggerFromEnv(c.accessToken, c.env, api.ID)
+}
+
+func (c *implClient) exportAPI(api utils.API) (string, error) {
+	resp, err := impl.ExportAPIFromEnv(c.accessToken, api.Name, api.Version, "", api.Provider, "", c.env,
+		true, false, false)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	zipLocationPath := filepath.Join(utils.ExportDirectory, utils.ExportedApisDirName, c.env)
+	return impl.WriteToZip(nil, api.Name, api.Version, "", zipLocationPath, resp)
+}
+
+func (c *implClient) exportAPIProduct(product utils.APIProduct) (string, error) {
+	resp, err := impl.ExportAPIProductFromEnv(c.accessToken, product.Name, product.Version, "", product.Provider,
+		"", c.env, false, true)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	zipLocationPath := filepath.Join(utils.ExportDirectory, utils.ExportedApiProductsDirName, c.env)
+	return impl.WriteAPIProductToZip(nil, product.Name, product.Version, zipLocationPath, resp)
+}
+
+func (c *implClient) exportApp(app utils.Application) (string, error) {
+	resp, err := impl.ExportAppFromEnv(c.accessToken, app.Name, app.Owner, utils.DefaultExportFormat, c.env, false)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return "", errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	zipLocationPath := filepath.Join(utils.ExportDirectory, utils.ExportedAppsDirName, c.env)
+	return impl.WriteApplicationToZip(nil, app.Name, app.Owner, zipLocationPath, resp)
+}
+
+func (c *implClient) changeAPIStatus(api utils.API, action string) error {
+	resp, err := impl.ChangeAPIStatusInEnv(c.accessToken, c.env, action, api.Name, api.Version, api.Provider)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	return nil
+}
+
+func (c *implClient) deployRevision(api utils.API, revision utils.Revisions,
+	gateway utils.GatewayEnvironment) error {
+	deployment := utils.Deployment{Name: gateway.Name, DisplayOnDevportal: true}
+	if len(gateway.Vhosts) > 0 {
+		deployment.Vhost = gateway.Vhosts[0].Host
+	}
+	resp, err := impl.DeployRevisionToGateways(c.accessToken, c.env, api.ID, revision.ID,
+		[]utils.Deployment{deployment})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated && resp.StatusCode() != http.StatusOK {
+		return errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	return nil
+}
+
+func (c *implClient) undeployRevision(api utils.API, deployment utils.DeploymentEntry) error {
+	gateways := []utils.Deployment{{Name: deployment.GatewayEnvironment, Vhost: deployment.Vhost,
+		DisplayOnDevportal: deployment.DisplayOnDevportal}}
+	resp, err := impl.UndeployRevisionFromGateways(c.accessToken, c.env, api.Name, api.Version, api.Provider,
+		utils.GetRevisionNumFromRevisionName(deployment.Revision), gateways, false)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return errors.New(resp.Status() + ": " + string(resp.Body()))
+	}
+	return nil
+}
+
+func (c *implClient) deleteAPI(api utils.API) error {
+	_, err := impl.DeleteAPI(c.accessToken, c.env, api.Name, api.Version, api.Provider)
+	return err
+}
+
+func (c *implClient) deleteAPIProduct(product utils.APIProduct) error {
+	_, err := impl.DeleteAPIProduct(c.accessToken, c.env, product.Name, product.Version, product.Provider)
+	return err
+}
+
+func (c *implClient) deleteApp(app utils.Application) error {
+	_, err := impl.DeleteApplication(c.accessToken, c.env, app.Name, app.Owner)
+	return err
+}
@@ -0,0 +1,220 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+type apisLoadedMsg struct{ apis []utils.API }
+
+type apiProductsLoadedMsg struct{ apiProducts []utils.APIProduct }
+
+type appsLoadedMsg struct{ apps []utils.Application }
+
+type revisionsLoadedMsg struct{ revisions []utils.Revisions }
+
+type subscriptionsLoadedMsg struct{ subscriptions []utils.SubscriptionEntry }
+
+type definitionLoadedMsg struct{ definition string }
+
+type gatewayEnvironmentsLoadedMsg struct{ gatewayEnvironments []utils.GatewayEnvironment }
+
+// actionDoneMsg reports a finished action. reload asks to fetch the current view again and back to leave the
+// API view.
+type actionDoneMsg struct {
+	message string
+	reload  bool
+	back    bool
+}
+
+type errMsg struct{ err error }
+
+func (m Model) listAPIs(query string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		apis, err := c.listAPIs(query)
+		if err != nil {
+			return errMsg{err}
+		}
+		return apisLoadedMsg{apis}
+	}
+}
+
+func (m Model) listAPIProducts(query string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		products, err := c.listAPIProducts(query)
+		if err != nil {
+			return errMsg{err}
+		}
+		return apiProductsLoadedMsg{products}
+	}
+}
+
+func (m Model) listApps() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		apps, err := c.listApps()
+		if err != nil {
+			return errMsg{err}
+		}
+		return appsLoadedMsg{apps}
+	}
+}
+
+func (m Model) listRevisions(api utils.API) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		revisions, err := c.listRevisions(api)
+		if err != nil {
+			return errMsg{err}
+		}
+		return revisionsLoadedMsg{revisions}
+	}
+}
+
+func (m Model) listSubscriptions(api utils.API) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		subscriptions, err := c.listSubscriptions(api)
+		if err != nil {
+			return errMsg{err}
+		}
+		return subscriptionsLoadedMsg{subscriptions}
+	}
+}
+
+func (m Model) getDefinition(api utils.API) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		definition, err := c.getDefinition(api)
+		if err != nil {
+			return errMsg{err}
+		}
+		return definitionLoadedMsg{definition}
+	}
+}
+
+func (m Model) listGatewayEnvironments() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		gatewayEnvironments, err := c.listGatewayEnvironments()
+		if err != nil {
+			return errMsg{err}
+		}
+		return gatewayEnvironmentsLoadedMsg{gatewayEnvironments}
+	}
+}
+
+func (m Model) exportAPI(api utils.API) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		path, err := c.exportAPI(api)
+		if err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "Exported API " + api.Name + " to " + path}
+	}
+}
+
+func (m Model) exportAPIProduct(product utils.APIProduct) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		path, err := c.exportAPIProduct(product)
+		if err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "Exported API Product " + product.Name + " to " + path}
+	}
+}
+
+func (m Model) exportApp(app utils.Application) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		path, err := c.exportApp(app)
+		if err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "Exported Application " + app.Name + " to " + path}
+	}
+}
+
+func (m Model) changeAPIStatus(api utils.API, action string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.changeAPIStatus(api, action); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: api.Name + " API state changed successfully!", reload: true}
+	}
+}
+
+func (m Model) deployRevision(api utils.API, revision utils.Revisions, gateway utils.GatewayEnvironment) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.deployRevision(api, revision, gateway); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: revision.RevisionNumber + " of API " + api.Name + " deployed to " +
+			gateway.Name, reload: true}
+	}
+}
+
+func (m Model) undeployRevision(api utils.API, deployment utils.DeploymentEntry) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.undeployRevision(api, deployment); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: deployment.Revision + " of API " + api.Name + " undeployed from " +
+			deployment.GatewayEnvironment, reload: true}
+	}
+}
+
+func (m Model) deleteAPI(api utils.API) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.deleteAPI(api); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "API " + api.Name + " deleted successfully!", reload: true, back: true}
+	}
+}
+
+func (m Model) deleteAPIProduct(product utils.APIProduct) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.deleteAPIProduct(product); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "API Product " + product.Name + " deleted successfully!", reload: true}
+	}
+}
+
+func (m Model) deleteApp(app utils.Application) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if err := c.deleteApp(app); err != nil {
+			return errMsg{err}
+		}
+		return actionDoneMsg{message: "Application " + app.Name + " deleted successfully!", reload: true}
+	}
+}
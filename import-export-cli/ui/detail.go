@@ -0,0 +1,250 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/wso2/product-apim-tooling/import-export-cli/impl"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// section is a tab of the API view
+type section int
+
+const (
+	sectionRevisions section = iota
+	sectionDeployments
+	sectionSubscriptions
+	sectionDefinition
+)
+
+var sectionTitles = []string{"Revisions", "Deployments", "Subscriptions", "Definition"}
+
+// apiDetail holds the state of the view of a single API
+type apiDetail struct {
+	api           utils.API
+	section       section
+	revisions     []utils.Revisions
+	deployments   []utils.DeploymentEntry
+	subscriptions []utils.SubscriptionEntry
+	table         table.Model
+	definition    viewport.Model
+}
+
+func newAPIDetail(api utils.API, width, height int) *apiDetail {
+	d := &apiDetail{api: api, table: newTable(nil, height), definition: viewport.New(width, height)}
+	d.setRows()
+	return d
+}
+
+func (d *apiDetail) resize(width, height int) {
+	d.table.SetHeight(height)
+	d.definition.Width = width
+	d.definition.Height = height
+}
+
+// setRows renders the loaded data of the current section into the table
+func (d *apiDetail) setRows() {
+	var columns []table.Column
+	var rows []table.Row
+	switch d.section {
+	case sectionRevisions:
+		columns = columnsOf("ID", "REVISION", "DESCRIPTION", "GATEWAY ENVIRONMENTS")
+		for _, r := range d.revisions {
+			gateways := make([]string, 0, len(r.Deployments))
+			for _, g := range r.Deployments {
+				gateways = append(gateways, g.Name)
+			}
+			rows = append(rows, table.Row{r.ID, r.RevisionNumber, r.Description, strings.Join(gateways, ",")})
+		}
+	case sectionDeployments:
+		columns = columnsOf("REVISION", "GATEWAY ENVIRONMENT", "VHOST", "STATUS", "DEPLOYED TIME")
+		for _, dep := range d.deployments {
+			rows = append(rows, table.Row{dep.Revision, dep.GatewayEnvironment, dep.Vhost, dep.Status,
+				dep.DeployedTime})
+		}
+	case sectionSubscriptions:
+		columns = columnsOf("ID", "APPLICATION", "OWNER", "POLICY", "STATUS")
+		for _, s := range d.subscriptions {
+			rows = append(rows, table.Row{s.Id, s.ApplicationName, s.ApplicationOwner, s.ThrottlingPolicy, s.Status})
+		}
+	default:
+		return
+	}
+	setTableData(&d.table, columns, rows)
+}
+
+// load returns the command which fetches the data of the current section
+func (m Model) load(d *apiDetail) tea.Cmd {
+	switch d.section {
+	case sectionSubscriptions:
+		return m.listSubscriptions(d.api)
+	case sectionDefinition:
+		return m.getDefinition(d.api)
+	default:
+		// deployments are derived from the revisions
+		return m.listRevisions(d.api)
+	}
+}
+
+// updateDetail handles a message in the API view
+func (m Model) updateDetail(msg tea.Msg) (tea.Model, tea.Cmd) {
+	d := m.detail
+	switch msg := msg.(type) {
+	case revisionsLoadedMsg:
+		m.loading = false
+		d.revisions = msg.revisions
+		d.deployments = impl.GetDeploymentsOfRevisions(msg.revisions)
+		d.setRows()
+		return m, nil
+	case subscriptionsLoadedMsg:
+		m.loading = false
+		d.subscriptions = msg.subscriptions
+		d.setRows()
+		return m, nil
+	case definitionLoadedMsg:
+		m.loading = false
+		d.definition.SetContent(msg.definition)
+		d.definition.GotoTop()
+		return m, nil
+	case gatewayEnvironmentsLoadedMsg:
+		m.loading = false
+		if revision, ok := d.selectedRevision(); ok {
+			m.prompt = m.deployPrompt(d.api, revision, msg.gatewayEnvironments)
+		}
+		return m, nil
+	case tea.KeyMsg:
+		m.status, m.err = "", nil
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "esc", "backspace":
+			m.detail = nil
+			return m, nil
+		case "tab", "shift+tab":
+			if msg.String() == "tab" {
+				d.section = (d.section + 1) % section(len(sectionTitles))
+			} else {
+				d.section = (d.section + section(len(sectionTitles)) - 1) % section(len(sectionTitles))
+			}
+			d.setRows()
+			m.loading = true
+			return m, m.load(d)
+		case "r":
+			m.loading = true
+			return m, m.load(d)
+		case "e":
+			m.prompt = newConfirmPrompt("Export API "+d.api.Name+" "+d.api.Version+"?", m.exportAPI(d.api))
+			return m, nil
+		case "c":
+			m.prompt = m.changeStatusPrompt(d.api)
+			return m, nil
+		case "x":
+			m.prompt = newConfirmPrompt("Delete API "+d.api.Name+" "+d.api.Version+"? This cannot be undone.",
+				m.deleteAPI(d.api))
+			return m, nil
+		case "d":
+			if _, ok := d.selectedRevision(); ok {
+				m.loading = true
+				return m, m.listGatewayEnvironments()
+			}
+			return m, nil
+		case "u":
+			if deployment, ok := d.selectedDeployment(); ok {
+				m.prompt = newConfirmPrompt("Undeploy "+deployment.Revision+" from "+deployment.GatewayEnvironment+"?",
+					m.undeployRevision(d.api, deployment))
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	if d.section == sectionDefinition {
+		d.definition, cmd = d.definition.Update(msg)
+	} else {
+		d.table, cmd = d.table.Update(msg)
+	}
+	return m, cmd
+}
+
+// deployPrompt asks for the gateway environment to deploy a revision to
+func (m Model) deployPrompt(api utils.API, revision utils.Revisions,
+	gatewayEnvironments []utils.GatewayEnvironment) *prompt {
+	names := make([]string, len(gatewayEnvironments))
+	for i, g := range gatewayEnvironments {
+		names[i] = g.Name
+	}
+	return newChoicePrompt("Deploy "+revision.RevisionNumber+" of API "+api.Name+" to", names,
+		func(name string) tea.Cmd {
+			for _, g := range gatewayEnvironments {
+				if g.Name == name {
+					return m.deployRevision(api, revision, g)
+				}
+			}
+			return nil
+		})
+}
+
+// selectedRevision returns the revision under the cursor of the revisions section
+func (d *apiDetail) selectedRevision() (utils.Revisions, bool) {
+	i := d.table.Cursor()
+	if d.section != sectionRevisions || i < 0 || i >= len(d.revisions) {
+		return utils.Revisions{}, false
+	}
+	return d.revisions[i], true
+}
+
+// selectedDeployment returns the deployment under the cursor of the deployments section
+func (d *apiDetail) selectedDeployment() (utils.DeploymentEntry, bool) {
+	i := d.table.Cursor()
+	if d.section != sectionDeployments || i < 0 || i >= len(d.deployments) {
+		return utils.DeploymentEntry{}, false
+	}
+	return d.deployments[i], true
+}
+
+func (d *apiDetail) view() string {
+	var b strings.Builder
+	b.WriteString(d.api.Name + " " + d.api.Version + "  " + helpStyle.Render(d.api.Context+" • "+
+		d.api.LifeCycleStatus+" • "+d.api.Provider) + "\n")
+	b.WriteString(renderTabs(sectionTitles, int(d.section)) + "\n")
+	if d.section == sectionDefinition {
+		b.WriteString(d.definition.View() + "\n")
+	} else {
+		b.WriteString(d.table.View() + "\n")
+	}
+	return b.String()
+}
+
+func (d *apiDetail) help() string {
+	actions := "e export • c change status • x delete • tab next section • esc back • r reload • q quit"
+	switch d.section {
+	case sectionRevisions:
+		return "d deploy • " + actions
+	case sectionDeployments:
+		return "u undeploy • " + actions
+	case sectionDefinition:
+		return "↑/↓ scroll (" + strconv.Itoa(int(d.definition.ScrollPercent()*100)) + "%) • " + actions
+	}
+	return actions
+}
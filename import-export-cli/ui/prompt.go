@@ -0,0 +1,107 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prompt is a modal question shown over the current view. A prompt without choices is a yes/no confirmation.
+type prompt struct {
+	message  string
+	choices  []string
+	cursor   int
+	onSelect func(choice string) tea.Cmd
+}
+
+// newConfirmPrompt creates a yes/no prompt which runs the given command when confirmed
+func newConfirmPrompt(message string, onConfirm tea.Cmd) *prompt {
+	return &prompt{message: message, onSelect: func(string) tea.Cmd {
+		return onConfirm
+	}}
+}
+
+// newChoicePrompt creates a prompt which runs the command returned by onSelect for the chosen option
+func newChoicePrompt(message string, choices []string, onSelect func(choice string) tea.Cmd) *prompt {
+	return &prompt{message: message, choices: choices, onSelect: onSelect}
+}
+
+// updatePrompt handles a key while a prompt is shown
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.prompt
+	if len(p.choices) == 0 {
+		switch msg.String() {
+		case "y", "Y":
+			m.prompt = nil
+			m.loading = true
+			return m, p.onSelect("")
+		case "n", "N", "esc", "q":
+			m.prompt = nil
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.choices)-1 {
+			p.cursor++
+		}
+	case "enter":
+		m.prompt = nil
+		cmd := p.onSelect(p.choices[p.cursor])
+		if cmd != nil {
+			m.loading = true
+		}
+		return m, cmd
+	case "esc", "q":
+		m.prompt = nil
+	}
+	return m, nil
+}
+
+func (p *prompt) view() string {
+	if len(p.choices) == 0 {
+		return promptStyle.Render(p.message + " [y/N]")
+	}
+	var b strings.Builder
+	b.WriteString(p.message + "\n")
+	for i, choice := range p.choices {
+		if i == p.cursor {
+			b.WriteString("> " + activeTabStyle.Render(choice))
+		} else {
+			b.WriteString("  " + choice)
+		}
+		if i < len(p.choices)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return promptStyle.Render(b.String())
+}
+
+func (p *prompt) help() string {
+	if len(p.choices) == 0 {
+		return "y confirm • n cancel"
+	}
+	return "↑/↓ choose • enter select • esc cancel"
+}
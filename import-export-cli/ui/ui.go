@@ -0,0 +1,430 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+// Package ui implements the interactive terminal browser started by `apictl ui`. Every action it offers calls
+// the same impl functions as the corresponding apictl command.
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// artifactKind is the kind of artifacts shown in the list view
+type artifactKind int
+
+const (
+	kindAPIs artifactKind = iota
+	kindAPIProducts
+	kindApps
+)
+
+var artifactKindTitles = []string{"APIs", "API Products", "Applications"}
+
+// displayLimit is the number of artifacts fetched for a list
+const displayLimit = "100"
+
+var (
+	titleStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208"))
+	activeTabStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	tabStyle       = lipgloss.NewStyle().Faint(true)
+	helpStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	statusStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	promptStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+)
+
+// Model is the root bubbletea model of the browser
+type Model struct {
+	client client
+
+	width  int
+	height int
+
+	kind     artifactKind
+	table    table.Model
+	apis     []utils.API
+	products []utils.APIProduct
+	apps     []utils.Application
+
+	search    textinput.Model
+	searching bool
+	query     string
+
+	detail *apiDetail
+	prompt *prompt
+
+	loading bool
+	status  string
+	err     error
+}
+
+// Run starts the browser for the given environment and blocks until the user quits
+func Run(accessToken, environment string) error {
+	_, err := tea.NewProgram(New(newImplClient(accessToken, environment)), tea.WithAltScreen()).Run()
+	return err
+}
+
+// New creates the root model backed by the given client
+func New(c client) Model {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "name:Pizza version:1.0.0"
+	m := Model{client: c, search: search, loading: true}
+	m.table = newTable(nil, 10)
+	return m
+}
+
+// Init loads the APIs of the environment
+func (m Model) Init() tea.Cmd {
+	return m.listAPIs("")
+}
+
+// Update handles a message and returns the updated model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.table.SetHeight(m.tableHeight())
+		if m.detail != nil {
+			m.detail.resize(m.width, m.tableHeight())
+		}
+		return m, nil
+	case apisLoadedMsg:
+		m.loading = false
+		m.apis = msg.apis
+		m.setListRows()
+		return m, nil
+	case apiProductsLoadedMsg:
+		m.loading = false
+		m.products = msg.apiProducts
+		m.setListRows()
+		return m, nil
+	case appsLoadedMsg:
+		m.loading = false
+		m.apps = msg.apps
+		m.setListRows()
+		return m, nil
+	case actionDoneMsg:
+		m.loading = false
+		m.err = nil
+		m.status = msg.message
+		if msg.back {
+			m.detail = nil
+		}
+		if !msg.reload {
+			return m, nil
+		}
+		if m.detail != nil {
+			m.loading = true
+			return m, m.load(m.detail)
+		}
+		return m, m.reload()
+	case errMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.prompt != nil {
+			return m.updatePrompt(msg)
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+	}
+
+	if m.detail != nil {
+		return m.updateDetail(msg)
+	}
+	return m.updateList(msg)
+}
+
+// updateList handles a message in the list view
+func (m Model) updateList(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		m.status, m.err = "", nil
+		switch key.String() {
+		case "q":
+			return m, tea.Quit
+		case "tab", "shift+tab":
+			if key.String() == "tab" {
+				m.kind = (m.kind + 1) % artifactKind(len(artifactKindTitles))
+			} else {
+				m.kind = (m.kind + artifactKind(len(artifactKindTitles)) - 1) % artifactKind(len(artifactKindTitles))
+			}
+			m.query = ""
+			m.setListRows()
+			return m, m.reload()
+		case "/":
+			if m.kind != kindApps {
+				m.searching = true
+				m.search.SetValue(m.query)
+				return m, m.search.Focus()
+			}
+		case "r":
+			return m, m.reload()
+		case "enter":
+			if api, ok := m.selectedAPI(); ok {
+				m.detail = newAPIDetail(api, m.width, m.tableHeight())
+				m.loading = true
+				return m, m.listRevisions(api)
+			}
+		case "e":
+			return m, m.confirmExport()
+		case "x":
+			return m, m.confirmDelete()
+		case "c":
+			if api, ok := m.selectedAPI(); ok {
+				m.prompt = m.changeStatusPrompt(api)
+			}
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// updateSearch handles a key while the search box has focus
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.search.Blur()
+		m.query = strings.TrimSpace(m.search.Value())
+		return m, m.reload()
+	case "esc":
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+// reload fetches the artifacts shown in the list view again
+func (m *Model) reload() tea.Cmd {
+	m.loading = true
+	switch m.kind {
+	case kindAPIProducts:
+		return m.listAPIProducts(m.query)
+	case kindApps:
+		return m.listApps()
+	default:
+		return m.listAPIs(m.query)
+	}
+}
+
+// setListRows renders the loaded artifacts of the current kind into the list table
+func (m *Model) setListRows() {
+	var columns []table.Column
+	var rows []table.Row
+	switch m.kind {
+	case kindAPIProducts:
+		columns = columnsOf("ID", "NAME", "VERSION", "CONTEXT", "STATUS", "PROVIDER")
+		for _, p := range m.products {
+			rows = append(rows, table.Row{p.ID, p.Name, p.Version, p.Context, p.LifeCycleStatus, p.Provider})
+		}
+	case kindApps:
+		columns = columnsOf("ID", "NAME", "OWNER", "STATUS", "GROUP ID")
+		for _, a := range m.apps {
+			rows = append(rows, table.Row{a.ID, a.Name, a.Owner, a.Status, a.GroupID})
+		}
+	default:
+		columns = columnsOf("ID", "NAME", "VERSION", "CONTEXT", "STATUS", "PROVIDER")
+		for _, a := range m.apis {
+			rows = append(rows, table.Row{a.ID, a.Name, a.Version, a.Context, a.LifeCycleStatus, a.Provider})
+		}
+	}
+	setTableData(&m.table, columns, rows)
+}
+
+// selectedAPI returns the API under the cursor of the list view
+func (m Model) selectedAPI() (utils.API, bool) {
+	if m.kind != kindAPIs || m.table.Cursor() < 0 || m.table.Cursor() >= len(m.apis) {
+		return utils.API{}, false
+	}
+	return m.apis[m.table.Cursor()], true
+}
+
+// confirmExport asks for a confirmation to export the artifact under the cursor
+func (m *Model) confirmExport() tea.Cmd {
+	i := m.table.Cursor()
+	switch {
+	case m.kind == kindAPIs && i >= 0 && i < len(m.apis):
+		api := m.apis[i]
+		m.prompt = newConfirmPrompt("Export API "+api.Name+" "+api.Version+"?", m.exportAPI(api))
+	case m.kind == kindAPIProducts && i >= 0 && i < len(m.products):
+		product := m.products[i]
+		m.prompt = newConfirmPrompt("Export API Product "+product.Name+"?", m.exportAPIProduct(product))
+	case m.kind == kindApps && i >= 0 && i < len(m.apps):
+		app := m.apps[i]
+		m.prompt = newConfirmPrompt("Export Application "+app.Name+" of "+app.Owner+"?", m.exportApp(app))
+	}
+	return nil
+}
+
+// confirmDelete asks for a confirmation to delete the artifact under the cursor
+func (m *Model) confirmDelete() tea.Cmd {
+	i := m.table.Cursor()
+	switch {
+	case m.kind == kindAPIs && i >= 0 && i < len(m.apis):
+		api := m.apis[i]
+		m.prompt = newConfirmPrompt("Delete API "+api.Name+" "+api.Version+"? This cannot be undone.",
+			m.deleteAPI(api))
+	case m.kind == kindAPIProducts && i >= 0 && i < len(m.products):
+		product := m.products[i]
+		m.prompt = newConfirmPrompt("Delete API Product "+product.Name+"? This cannot be undone.",
+			m.deleteAPIProduct(product))
+	case m.kind == kindApps && i >= 0 && i < len(m.apps):
+		app := m.apps[i]
+		m.prompt = newConfirmPrompt("Delete Application "+app.Name+" of "+app.Owner+"? This cannot be undone.",
+			m.deleteApp(app))
+	}
+	return nil
+}
+
+// changeStatusPrompt asks for the lifecycle action to apply to an API
+func (m Model) changeStatusPrompt(api utils.API) *prompt {
+	return newChoicePrompt("Change the status of API "+api.Name+" "+api.Version+" with", lifecycleActions,
+		func(action string) tea.Cmd {
+			return m.changeAPIStatus(api, action)
+		})
+}
+
+// lifecycleActions are the lifecycle actions offered by change-status
+var lifecycleActions = []string{"Publish", "Deploy as a Prototype", "Demote to Created", "Block", "Deprecate",
+	"Re-Publish", "Retire"}
+
+// tableHeight is the number of table rows that fit the window
+func (m Model) tableHeight() int {
+	if m.height <= 8 {
+		return 10
+	}
+	return m.height - 8
+}
+
+// View renders the model
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(utils.ProjectName+" ui - "+m.client.environment()) + "\n")
+	if m.detail != nil {
+		b.WriteString(m.detail.view())
+	} else {
+		b.WriteString(renderTabs(artifactKindTitles, int(m.kind)) + "\n")
+		if m.searching {
+			b.WriteString(m.search.View() + "\n")
+		} else if m.query != "" {
+			b.WriteString(helpStyle.Render("search: "+m.query) + "\n")
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.table.View() + "\n")
+	}
+	if m.prompt != nil {
+		b.WriteString(m.prompt.view() + "\n")
+	}
+	switch {
+	case m.loading:
+		b.WriteString(helpStyle.Render("loading...") + "\n")
+	case m.err != nil:
+		b.WriteString(errorStyle.Render("Error: "+m.err.Error()) + "\n")
+	case m.status != "":
+		b.WriteString(statusStyle.Render(m.status) + "\n")
+	default:
+		b.WriteString("\n")
+	}
+	b.WriteString(helpStyle.Render(m.help()))
+	return b.String()
+}
+
+// help returns the key bindings of the current view
+func (m Model) help() string {
+	switch {
+	case m.prompt != nil:
+		return m.prompt.help()
+	case m.searching:
+		return "enter search • esc cancel"
+	case m.detail != nil:
+		return m.detail.help()
+	case m.kind == kindAPIs:
+		return "enter open • / search • e export • c change status • x delete • tab next list • r reload • q quit"
+	case m.kind == kindAPIProducts:
+		return "/ search • e export • x delete • tab next list • r reload • q quit"
+	default:
+		return "e export • x delete • tab next list • r reload • q quit"
+	}
+}
+
+// renderTabs renders a row of tab titles with the active one highlighted
+func renderTabs(titles []string, active int) string {
+	tabs := make([]string, len(titles))
+	for i, t := range titles {
+		if i == active {
+			tabs[i] = activeTabStyle.Render(t)
+		} else {
+			tabs[i] = tabStyle.Render(t)
+		}
+	}
+	return strings.Join(tabs, "  ")
+}
+
+// newTable creates a focused table with the given columns
+func newTable(columns []table.Column, height int) table.Model {
+	t := table.New(table.WithColumns(columns), table.WithFocused(true), table.WithHeight(height))
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.Bold(true).BorderStyle(lipgloss.NormalBorder()).BorderBottom(true)
+	t.SetStyles(styles)
+	return t
+}
+
+// setTableData replaces the columns and rows of a table, keeping the cursor within the rows
+func setTableData(t *table.Model, columns []table.Column, rows []table.Row) {
+	// rows are cleared first so that they never have more cells than the columns while the columns change
+	t.SetRows(nil)
+	t.SetColumns(columns)
+	t.SetRows(rows)
+	t.SetCursor(t.Cursor())
+}
+
+// columnsOf creates table columns with a width based on the title
+func columnsOf(titles ...string) []table.Column {
+	columns := make([]table.Column, len(titles))
+	for i, title := range titles {
+		width := 16
+		switch title {
+		case "ID", "REVISION ID":
+			width = 36
+		case "NAME", "APPLICATION", "API", "GATEWAY ENVIRONMENT":
+			width = 24
+		case "VERSION", "STATUS", "POLICY":
+			width = 12
+		}
+		columns[i] = table.Column{Title: title, Width: width}
+	}
+	return columns
+}
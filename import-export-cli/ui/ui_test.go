@@ -0,0 +1,228 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/product-apim-tooling/import-export-cli/utils"
+)
+
+// fakeClient records the actions performed by the browser
+type fakeClient struct {
+	apis        []utils.API
+	products    []utils.APIProduct
+	revisions   []utils.Revisions
+	gateways    []utils.GatewayEnvironment
+	queries     []string
+	calls       []string
+	deleteError error
+}
+
+func (c *fakeClient) environment() string { return "dev" }
+
+func (c *fakeClient) listAPIs(query string) ([]utils.API, error) {
+	c.queries = append(c.queries, query)
+	return c.apis, nil
+}
+
+func (c *fakeClient) listAPIProducts(query string) ([]utils.APIProduct, error) {
+	return c.products, nil
+}
+
+func (c *fakeClient) listApps() ([]utils.Application, error) { return nil, nil }
+
+func (c *fakeClient) listRevisions(api utils.API) ([]utils.Revisions, error) {
+	return c.revisions, nil
+}
+
+func (c *fakeClient) listSubscriptions(api utils.API) ([]utils.SubscriptionEntry, error) {
+	return nil, nil
+}
+
+func (c *fakeClient) listGatewayEnvironments() ([]utils.GatewayEnvironment, error) {
+	return c.gateways, nil
+}
+
+func (c *fakeClient) getDefinition(api utils.API) (string, error) { return "openapi: 3.0.1", nil }
+
+func (c *fakeClient) exportAPI(api utils.API) (string, error) {
+	c.calls = append(c.calls, "export "+api.Name)
+	return "/tmp/" + api.Name + ".zip", nil
+}
+
+func (c *fakeClient) exportAPIProduct(product utils.APIProduct) (string, error) { return "", nil }
+
+func (c *fakeClient) exportApp(app utils.Application) (string, error) { return "", nil }
+
+func (c *fakeClient) changeAPIStatus(api utils.API, action string) error {
+	c.calls = append(c.calls, action+" "+api.Name)
+	return nil
+}
+
+func (c *fakeClient) deployRevision(api utils.API, revision utils.Revisions,
+	gateway utils.GatewayEnvironment) error {
+	c.calls = append(c.calls, "deploy "+revision.ID+" "+gateway.Name)
+	return nil
+}
+
+func (c *fakeClient) undeployRevision(api utils.API, deployment utils.DeploymentEntry) error {
+	c.calls = append(c.calls, "undeploy "+deployment.RevisionId+" "+deployment.GatewayEnvironment)
+	return nil
+}
+
+func (c *fakeClient) deleteAPI(api utils.API) error {
+	c.calls = append(c.calls, "delete "+api.Name)
+	return c.deleteError
+}
+
+func (c *fakeClient) deleteAPIProduct(product utils.APIProduct) error { return nil }
+
+func (c *fakeClient) deleteApp(app utils.Application) error { return nil }
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		apis: []utils.API{{ID: "1", Name: "PizzaShackAPI", Version: "1.0.0"}, {ID: "2", Name: "TwitterAPI",
+			Version: "1.0.0"}},
+		products: []utils.APIProduct{{ID: "3", Name: "ShopProduct"}},
+		revisions: []utils.Revisions{{ID: "rev-1", RevisionNumber: "Revision 1",
+			Deployments: []utils.Deployment{{Name: "Default", Vhost: "localhost"}}}},
+		gateways: []utils.GatewayEnvironment{{Name: "Default"}, {Name: "Production"}},
+	}
+}
+
+// run drives the model with the messages and runs the commands it returns until none are left
+func run(t *testing.T, m Model, msgs ...tea.Msg) Model {
+	for _, msg := range msgs {
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		for cmd != nil {
+			result := cmd()
+			if result == nil {
+				break
+			}
+			if _, ok := result.(tea.QuitMsg); ok {
+				break
+			}
+			next, cmd = m.Update(result)
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func initModel(t *testing.T, c *fakeClient) Model {
+	m := New(c)
+	msg := m.Init()()
+	return run(t, m, msg)
+}
+
+func TestListShowsAPIs(t *testing.T) {
+	m := initModel(t, newFakeClient())
+	assert.False(t, m.loading)
+	assert.Len(t, m.table.Rows(), 2)
+	assert.Contains(t, m.View(), "PizzaShackAPI")
+}
+
+func TestSearchUsesQuery(t *testing.T) {
+	c := newFakeClient()
+	m := initModel(t, c)
+	m = run(t, m, key("/"), key("name:Pizza"), key("enter"))
+	assert.Equal(t, []string{"", "name:Pizza"}, c.queries)
+	assert.Equal(t, "name:Pizza", m.query)
+}
+
+func TestTabSwitchesToAPIProducts(t *testing.T) {
+	m := initModel(t, newFakeClient())
+	m = run(t, m, key("tab"))
+	assert.Equal(t, kindAPIProducts, m.kind)
+	assert.Len(t, m.table.Rows(), 1)
+	assert.Equal(t, "ShopProduct", m.table.SelectedRow()[1])
+}
+
+func TestDeleteNeedsConfirmation(t *testing.T) {
+	c := newFakeClient()
+	m := initModel(t, c)
+	m = run(t, m, key("down"), key("x"), key("n"))
+	assert.Empty(t, c.calls)
+	assert.Nil(t, m.prompt)
+
+	m = run(t, m, key("x"), key("y"))
+	assert.Equal(t, []string{"delete TwitterAPI"}, c.calls)
+	assert.Equal(t, "API TwitterAPI deleted successfully!", m.status)
+}
+
+func TestDeleteShowsError(t *testing.T) {
+	c := newFakeClient()
+	c.deleteError = errors.New("404: API not found")
+	m := initModel(t, c)
+	m = run(t, m, key("x"), key("y"))
+	assert.Contains(t, m.View(), "404: API not found")
+}
+
+func TestChangeStatusChoosesAction(t *testing.T) {
+	c := newFakeClient()
+	m := initModel(t, c)
+	m = run(t, m, key("c"), key("down"), key("enter"))
+	assert.Equal(t, []string{lifecycleActions[1] + " PizzaShackAPI"}, c.calls)
+}
+
+func TestAPIViewDeploysAndUndeploysRevisions(t *testing.T) {
+	c := newFakeClient()
+	m := initModel(t, c)
+	m = run(t, m, key("enter"))
+	if assert.NotNil(t, m.detail) {
+		assert.Len(t, m.detail.deployments, 1)
+		assert.Equal(t, "Revision 1", m.detail.table.SelectedRow()[1])
+	}
+
+	m = run(t, m, key("d"), key("down"), key("enter"))
+	assert.Equal(t, []string{"deploy rev-1 Production"}, c.calls)
+
+	m = run(t, m, key("tab"), key("u"), key("y"))
+	assert.Equal(t, sectionDeployments, m.detail.section)
+	assert.Equal(t, []string{"deploy rev-1 Production", "undeploy rev-1 Default"}, c.calls)
+
+	m = run(t, m, key("esc"))
+	assert.Nil(t, m.detail)
+}
+
+func TestAPIViewShowsDefinition(t *testing.T) {
+	m := initModel(t, newFakeClient())
+	m = run(t, m, tea.WindowSizeMsg{Width: 120, Height: 40}, key("enter"), key("tab"), key("tab"), key("tab"))
+	assert.Equal(t, sectionDefinition, m.detail.section)
+	assert.Contains(t, m.View(), "openapi: 3.0.1")
+}
@@ -0,0 +1,377 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
+)
+
+// Status of an artifact processed in a bulk operation
+const (
+	BulkStatusSucceeded = "succeeded"
+	BulkStatusFailed    = "failed"
+	BulkStatusSkipped   = "skipped"
+)
+
+// BulkRetryBaseDelay is the delay before the first retry of a failed request, doubled for each further retry
+var BulkRetryBaseDelay = time.Second
+
+// BulkOperationOptions controls the concurrency of bulk export and import operations
+type BulkOperationOptions struct {
+	// Parallel is the number of artifacts processed at the same time
+	Parallel int
+	// RateLimit is the maximum number of requests sent per second, unlimited when zero
+	RateLimit float64
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+	// Output is the format the report is printed in once the operation completes, it is not printed when empty
+	Output string
+}
+
+// MessageWriter returns the writer of the progress messages, which is stderr when the report is printed to stdout
+func (o BulkOperationOptions) MessageWriter() io.Writer {
+	return formatter.MessageWriter(o.Output)
+}
+
+// DefaultBulkOperationOptions processes one artifact at a time without rate limiting
+var DefaultBulkOperationOptions = BulkOperationOptions{Parallel: 1, MaxRetries: DefaultBulkMaxRetries}
+
+// RateLimiter spaces out the requests of a bulk operation
+type RateLimiter struct {
+	ticker *time.Ticker
+}
+
+// NewRateLimiter creates a limiter allowing perSecond requests per second. A nil limiter does not limit.
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &RateLimiter{ticker: time.NewTicker(time.Duration(float64(time.Second) / perSecond))}
+}
+
+// Wait blocks until the next request is allowed
+func (l *RateLimiter) Wait() {
+	if l != nil {
+		<-l.ticker.C
+	}
+}
+
+// Stop releases the limiter
+func (l *RateLimiter) Stop() {
+	if l != nil {
+		l.ticker.Stop()
+	}
+}
+
+// nonRetryableError marks an error which should not be retried, ex: a 4xx response
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+// NonRetryable wraps err so RunWithRetry returns it without retrying
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &nonRetryableError{err: err}
+}
+
+// HTTPStatusError is the error of an unsuccessful response, which keeps the response so that the error can be
+// classified without parsing its message
+type HTTPStatusError struct {
+	Response *resty.Response
+}
+
+// NewHTTPStatusError creates the error of an unsuccessful response
+func NewHTTPStatusError(resp *resty.Response) error {
+	return &HTTPStatusError{Response: resp}
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Response.Status()
+}
+
+// GetBulkResponseError converts an unsuccessful response of an idempotent request to an error. Throttled (429) and
+// server side (5xx) errors are retryable, other errors are marked as non retryable. A response which the shared HTTP
+// client has already retried is not retried again, so that the retries of the bulk operation do not multiply those
+// of the client.
+func GetBulkResponseError(resp *resty.Response) error {
+	err := fmt.Errorf("%s: %s", resp.Status(), strings.TrimSpace(string(resp.Body())))
+	return getBulkStatusError(resp, err, true)
+}
+
+// GetBulkError marks the error of a request as non retryable unless it is a retryable HTTPStatusError or a network
+// error. An HTTPStatusError is classified as GetBulkResponseError does, except that the server errors (5xx) other
+// than 503 of a request which is not idempotent are not retried, as the server may have applied the request, in the
+// same way as the shared HTTP client does. Network errors are retried only for idempotent requests and refused
+// connections are not retried as the HTTP client retries them for every request.
+func GetBulkError(err error, idempotent bool) error {
+	if err == nil {
+		return nil
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return getBulkStatusError(statusErr.Response, err, idempotent)
+	}
+	var netErr net.Error
+	if idempotent && errors.As(err, &netErr) && !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+	return NonRetryable(err)
+}
+
+func getBulkStatusError(resp *resty.Response, err error, idempotent bool) error {
+	if resp.Request != nil && resp.Request.Attempt > 1 {
+		return NonRetryable(err)
+	}
+	switch {
+	case resp.StatusCode() == http.StatusTooManyRequests || resp.StatusCode() == http.StatusServiceUnavailable:
+		return err
+	case resp.StatusCode() >= http.StatusInternalServerError && idempotent:
+		return err
+	}
+	return NonRetryable(err)
+}
+
+// RunWithRetry runs fn until it succeeds, returns a non retryable error or maxRetries is exceeded.
+// Returns the number of attempts made and the last error.
+func RunWithRetry(maxRetries int, fn func() error) (int, error) {
+	delay := BulkRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return attempt, nil
+		}
+		var nonRetryable *nonRetryableError
+		if errors.As(err, &nonRetryable) {
+			return attempt, nonRetryable.err
+		}
+		if attempt > maxRetries {
+			return attempt, err
+		}
+		Logln(LogPrefixWarning+"Attempt", attempt, "failed, retrying in", delay, ":", err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// RunInParallel calls fn for every index in [0, count) using at most parallel goroutines
+func RunInParallel(count, parallel int, fn func(i int)) {
+	if parallel < 1 {
+		parallel = 1
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel && w < count; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// BulkOperationLedger records the completion of every artifact of a bulk operation, so an interrupted
+// operation can be resumed regardless of the order in which the artifacts completed.
+// Entries are appended to the file one JSON object per line.
+type BulkOperationLedger struct {
+	path      string
+	mutex     sync.Mutex
+	completed map[string]bool
+}
+
+// ledgerEntry is a single line of the ledger file
+type ledgerEntry struct {
+	Key    string    `json:"key"`
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+}
+
+// LoadBulkOperationLedger reads the ledger at path, an empty ledger is returned if the file does not exist
+func LoadBulkOperationLedger(path string) (*BulkOperationLedger, error) {
+	ledger := &BulkOperationLedger{path: path, completed: make(map[string]bool)}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry ledgerEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// a partially written last line is ignored, the artifact will be processed again
+			Logln(LogPrefixWarning+"Ignoring malformed ledger entry in", path)
+			continue
+		}
+		ledger.completed[entry.Key] = entry.Status == BulkStatusSucceeded
+	}
+	return ledger, scanner.Err()
+}
+
+// IsCompleted returns true if the artifact was processed successfully
+func (l *BulkOperationLedger) IsCompleted(key string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.completed[key]
+}
+
+// CompletedCount returns the number of artifacts processed successfully
+func (l *BulkOperationLedger) CompletedCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	count := 0
+	for _, completed := range l.completed {
+		if completed {
+			count++
+		}
+	}
+	return count
+}
+
+// Record appends the status of an artifact to the ledger
+func (l *BulkOperationLedger) Record(key, status string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	data, err := json.Marshal(ledgerEntry{Key: key, Status: status, Time: time.Now()})
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	l.completed[key] = status == BulkStatusSucceeded
+	return nil
+}
+
+// BulkArtifactResult is the outcome of a single artifact in a bulk operation
+type BulkArtifactResult struct {
+	Key            string `json:"key"`
+	Type           string `json:"type"`
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	Error          string `json:"error,omitempty"`
+	DurationMillis int64  `json:"durationMillis"`
+}
+
+// BulkOperationReport is the summary of a bulk export or import operation
+type BulkOperationReport struct {
+	Operation   string               `json:"operation"`
+	Environment string               `json:"environment"`
+	Tenant      string               `json:"tenant,omitempty"`
+	StartedAt   time.Time            `json:"startedAt"`
+	FinishedAt  time.Time            `json:"finishedAt"`
+	Succeeded   int                  `json:"succeeded"`
+	Failed      int                  `json:"failed"`
+	Skipped     int                  `json:"skipped"`
+	Retries     int                  `json:"retries"`
+	Artifacts   []BulkArtifactResult `json:"artifacts"`
+
+	mutex sync.Mutex
+}
+
+// NewBulkOperationReport starts a report for the given operation
+func NewBulkOperationReport(operation, environment, tenant string) *BulkOperationReport {
+	return &BulkOperationReport{Operation: operation, Environment: environment, Tenant: tenant,
+		StartedAt: time.Now(), Artifacts: []BulkArtifactResult{}}
+}
+
+// Add records the result of an artifact, safe to be called from several goroutines
+func (r *BulkOperationReport) Add(result BulkArtifactResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	switch result.Status {
+	case BulkStatusSucceeded:
+		r.Succeeded++
+	case BulkStatusFailed:
+		r.Failed++
+	case BulkStatusSkipped:
+		r.Skipped++
+	}
+	if result.Attempts > 1 {
+		r.Retries += result.Attempts - 1
+	}
+	r.Artifacts = append(r.Artifacts, result)
+}
+
+// Write finishes the report and saves it as JSON to path
+func (r *BulkOperationReport) Write(path string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.FinishedAt = time.Now()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// PrintSummary prints the totals of the report to out
+func (r *BulkOperationReport) PrintSummary(out io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	fmt.Fprintf(out, "Succeeded: %d, Failed: %d, Skipped: %d, Retries: %d\n", r.Succeeded, r.Failed, r.Skipped,
+		r.Retries)
+	for _, artifact := range r.Artifacts {
+		if artifact.Status == BulkStatusFailed {
+			fmt.Fprintln(out, "  Failed:", artifact.Key, "-", artifact.Error)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkOperationLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), MigrationAPIsExportLedgerFileName)
+	ledger, err := LoadBulkOperationLedger(path)
+	assert.Nil(t, err, "Missing ledger should be loaded as an empty ledger")
+	assert.Equal(t, 0, ledger.CompletedCount())
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"A:1.0.0:admin:1", "B:1.0.0:admin:1", "C:1.0.0:admin:1"} {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			assert.Nil(t, ledger.Record(key, BulkStatusSucceeded))
+		}(key)
+	}
+	wg.Wait()
+	assert.Nil(t, ledger.Record("D:1.0.0:admin:1", BulkStatusFailed))
+	assert.Nil(t, ledger.Record("C:1.0.0:admin:1", BulkStatusFailed))
+
+	// simulate an entry partially written when the process was killed
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.Nil(t, err)
+	_, _ = file.WriteString(`{"key":"E:1.0.0`)
+	file.Close()
+
+	ledger, err = LoadBulkOperationLedger(path)
+	assert.Nil(t, err)
+	assert.True(t, ledger.IsCompleted("A:1.0.0:admin:1"))
+	assert.True(t, ledger.IsCompleted("B:1.0.0:admin:1"))
+	assert.False(t, ledger.IsCompleted("C:1.0.0:admin:1"), "Latest entry of an artifact should be considered")
+	assert.False(t, ledger.IsCompleted("D:1.0.0:admin:1"), "Failed artifacts should not be completed")
+	assert.False(t, ledger.IsCompleted("E:1.0.0"), "Partially written entries should be ignored")
+	assert.Equal(t, 2, ledger.CompletedCount())
+}
+
+func TestRunWithRetry(t *testing.T) {
+	BulkRetryBaseDelay = time.Millisecond
+	defer func() { BulkRetryBaseDelay = time.Second }()
+
+	calls := 0
+	attempts, err := RunWithRetry(3, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("503 Service Unavailable")
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, attempts, "Should succeed on the third attempt")
+
+	attempts, err = RunWithRetry(2, func() error {
+		return errors.New("500 Internal Server Error")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts, "Should stop after the maximum number of retries")
+
+	notFound := errors.New("404 Not Found")
+	attempts, err = RunWithRetry(3, func() error {
+		return NonRetryable(notFound)
+	})
+	assert.Equal(t, notFound, err, "Non retryable error should be unwrapped")
+	assert.Equal(t, 1, attempts, "Non retryable errors should not be retried")
+}
+
+func TestGetBulkError(t *testing.T) {
+	var nonRetryable *nonRetryableError
+	statusError := func(statusCode int, status string) error {
+		return NewHTTPStatusError(&resty.Response{Request: &resty.Request{Attempt: 1},
+			RawResponse: &http.Response{StatusCode: statusCode, Status: status}})
+	}
+	assert.Nil(t, GetBulkError(nil, true))
+	assert.Equal(t, "502 Bad Gateway", statusError(http.StatusBadGateway, "502 Bad Gateway").Error())
+	assert.False(t, errors.As(GetBulkError(statusError(http.StatusBadGateway, "502 Bad Gateway"), true),
+		&nonRetryable), "Server errors of idempotent requests should be retried")
+	assert.True(t, errors.As(GetBulkError(statusError(http.StatusBadGateway, "502 Bad Gateway"), false),
+		&nonRetryable), "Server errors of requests which are not idempotent should not be retried")
+	assert.False(t, errors.As(GetBulkError(statusError(http.StatusServiceUnavailable, "503 Service Unavailable"),
+		false), &nonRetryable), "Unavailable responses should be retried as the request was not processed")
+	assert.False(t, errors.As(GetBulkError(statusError(http.StatusTooManyRequests, "429 Too Many Requests"),
+		false), &nonRetryable), "Throttled requests should be retried as the request was not processed")
+	assert.True(t, errors.As(GetBulkError(statusError(http.StatusConflict, "409 Conflict"), true), &nonRetryable),
+		"Client errors should not be retried")
+	assert.True(t, errors.As(GetBulkError(errors.New("502 Bad Gateway"), true), &nonRetryable),
+		"Errors which are not HTTPStatusErrors should not be classified by their message")
+	assert.True(t, errors.As(GetBulkError(errors.New("invalid params file"), true), &nonRetryable),
+		"Local errors should not be retried")
+	assert.False(t, errors.As(GetBulkError(&net.OpError{Op: "read", Err: syscall.ECONNRESET}, true),
+		&nonRetryable), "Network errors of idempotent requests should be retried")
+	assert.True(t, errors.As(GetBulkError(&net.OpError{Op: "read", Err: syscall.ECONNRESET}, false),
+		&nonRetryable), "Network errors of requests which are not idempotent should not be retried")
+	assert.True(t, errors.As(GetBulkError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true),
+		&nonRetryable), "Refused connections are retried by the HTTP client")
+}
+
+func TestGetBulkResponseError(t *testing.T) {
+	var nonRetryable *nonRetryableError
+	resp := &resty.Response{Request: &resty.Request{Attempt: 1},
+		RawResponse: &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}}
+	assert.False(t, errors.As(GetBulkResponseError(resp), &nonRetryable), "Server errors should be retried")
+	resp.Request.Attempt = 4
+	assert.True(t, errors.As(GetBulkResponseError(resp), &nonRetryable),
+		"Responses already retried by the HTTP client should not be retried")
+	resp = &resty.Response{Request: &resty.Request{Attempt: 1},
+		RawResponse: &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"}}
+	assert.True(t, errors.As(GetBulkResponseError(resp), &nonRetryable), "Client errors should not be retried")
+}
+
+func TestRunInParallel(t *testing.T) {
+	var running, maxRunning, processed int32
+	RunInParallel(20, 4, func(i int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&processed, 1)
+	})
+	assert.Equal(t, int32(20), processed, "Every item should be processed")
+	assert.LessOrEqual(t, maxRunning, int32(4), "Should not exceed the parallel limit")
+
+	RunInParallel(0, 4, func(i int) {
+		t.Error("Should not be called for an empty list")
+	})
+}
+
+func TestBulkOperationReport(t *testing.T) {
+	report := NewBulkOperationReport("export apis", "production", "wso2.com")
+	report.Add(BulkArtifactResult{Key: "A:1.0.0:admin:1", Status: BulkStatusSucceeded, Attempts: 1})
+	report.Add(BulkArtifactResult{Key: "B:1.0.0:admin:1", Status: BulkStatusSucceeded, Attempts: 3})
+	report.Add(BulkArtifactResult{Key: "C:1.0.0:admin:1", Status: BulkStatusFailed, Attempts: 4, Error: "500"})
+	report.Add(BulkArtifactResult{Key: "D:1.0.0:admin:1", Status: BulkStatusSkipped})
+
+	path := filepath.Join(t.TempDir(), MigrationAPIsExportReportFileName)
+	assert.Nil(t, report.Write(path))
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+
+	var written map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &written))
+	assert.Equal(t, "export apis", written["operation"])
+	assert.Equal(t, float64(2), written["succeeded"])
+	assert.Equal(t, float64(1), written["failed"])
+	assert.Equal(t, float64(1), written["skipped"])
+	assert.Equal(t, float64(5), written["retries"])
+	assert.Len(t, written["artifacts"], 4)
+
+	var summary bytes.Buffer
+	report.PrintSummary(&summary)
+	assert.Equal(t, "Succeeded: 2, Failed: 1, Skipped: 1, Retries: 5\n  Failed: C:1.0.0:admin:1 - 500\n",
+		summary.String())
+}
+
+func TestBulkOperationOptionsMessageWriter(t *testing.T) {
+	assert.Equal(t, os.Stdout, BulkOperationOptions{}.MessageWriter())
+	// the messages do not mix with the report printed to stdout
+	assert.Equal(t, os.Stderr, BulkOperationOptions{Output: "json"}.MessageWriter())
+}
@@ -69,6 +69,7 @@ func SetConfigVars(mainConfigFilePath string) error {
 	Logln(LogPrefixInfo + "Setting ExportDirectory " + mainConfig.Config.ExportDirectory)
 
 	setTLSRenegotiationMode(mainConfig)
+	setHttpClientConfig(mainConfig)
 
 	return nil
 }
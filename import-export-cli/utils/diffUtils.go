@@ -0,0 +1,252 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Types of changes reported by DiffObjects
+const (
+	DiffChangeAdded    = "added"
+	DiffChangeRemoved  = "removed"
+	DiffChangeModified = "modified"
+)
+
+// DiffChange is a single field level difference between two documents
+type DiffChange struct {
+	// Path of the field, ex: operations[GET /menu].throttlingPolicy
+	Path string `json:"path"`
+	// Type of the change: added, removed or modified
+	Type string `json:"type"`
+	// From is the value in the source document
+	From interface{} `json:"from,omitempty"`
+	// To is the value in the target document
+	To interface{} `json:"to,omitempty"`
+}
+
+// diffIdentityKeys are the fields used to match the elements of two lists of objects, in order of preference.
+// A key made of several fields uses all of them, ex: operations are matched by verb and target.
+var diffIdentityKeys = [][]string{
+	{"verb", "target"},
+	{"deploymentEnvironment", "deploymentVhost"},
+	{"deploymentEnvironment"},
+	{"policyName", "policyVersion"},
+	{"policyName"},
+	{"name"},
+	{"key"},
+}
+
+// DiffObjects compares two generic documents (as decoded from JSON or YAML) and returns the field level
+// changes required to go from "from" to "to". Lists of objects are matched by identity fields such as
+// name or verb and target, so reordering a list is not reported as a change.
+func DiffObjects(from, to interface{}) []DiffChange {
+	var changes []DiffChange
+	diffValues("", normalizeDiffValue(from), normalizeDiffValue(to), &changes)
+	return changes
+}
+
+// normalizeDiffValue converts any document to the map[string]interface{} / []interface{} representation
+func normalizeDiffValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, map[string]interface{}, []interface{}, string, float64, bool:
+		return v
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var normalized interface{}
+	if err = json.Unmarshal(data, &normalized); err != nil {
+		return v
+	}
+	return normalized
+}
+
+func diffValues(path string, from, to interface{}, changes *[]DiffChange) {
+	if from == nil && to == nil {
+		return
+	}
+	if from == nil {
+		*changes = append(*changes, DiffChange{Path: path, Type: DiffChangeAdded, To: to})
+		return
+	}
+	if to == nil {
+		*changes = append(*changes, DiffChange{Path: path, Type: DiffChangeRemoved, From: from})
+		return
+	}
+
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := make(map[string]bool)
+		for k := range fromMap {
+			keys[k] = true
+		}
+		for k := range toMap {
+			keys[k] = true
+		}
+		for _, k := range sortedKeys(keys) {
+			diffValues(joinDiffPath(path, k), fromMap[k], toMap[k], changes)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList {
+		diffLists(path, fromList, toList, changes)
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, DiffChange{Path: path, Type: DiffChangeModified, From: from, To: to})
+	}
+}
+
+func diffLists(path string, from, to []interface{}, changes *[]DiffChange) {
+	if identity := listIdentityKey(from, to); identity != nil {
+		fromItems, fromOrder := indexListBy(from, identity)
+		toItems, toOrder := indexListBy(to, identity)
+		for _, id := range fromOrder {
+			diffValues(path+"["+id+"]", fromItems[id], toItems[id], changes)
+		}
+		for _, id := range toOrder {
+			if _, ok := fromItems[id]; !ok {
+				diffValues(path+"["+id+"]", nil, toItems[id], changes)
+			}
+		}
+		return
+	}
+
+	if isScalarList(from) && isScalarList(to) {
+		// lists of scalars such as tags or transports are compared as sets
+		if !reflect.DeepEqual(sortedScalars(from), sortedScalars(to)) {
+			*changes = append(*changes, DiffChange{Path: path, Type: DiffChangeModified, From: from, To: to})
+		}
+		return
+	}
+
+	for i := 0; i < len(from) || i < len(to); i++ {
+		var f, t interface{}
+		if i < len(from) {
+			f = from[i]
+		}
+		if i < len(to) {
+			t = to[i]
+		}
+		diffValues(fmt.Sprintf("%s[%d]", path, i), f, t, changes)
+	}
+}
+
+// listIdentityKey returns the identity fields present in every element of both lists, nil if there is none
+func listIdentityKey(lists ...[]interface{}) []string {
+	for _, identity := range diffIdentityKeys {
+		matches, empty := true, true
+		for _, list := range lists {
+			for _, item := range list {
+				empty = false
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					return nil
+				}
+				for _, field := range identity {
+					if _, ok := m[field]; !ok {
+						matches = false
+					}
+				}
+			}
+		}
+		if matches && !empty {
+			return identity
+		}
+	}
+	return nil
+}
+
+func indexListBy(list []interface{}, identity []string) (map[string]interface{}, []string) {
+	items := make(map[string]interface{})
+	var order []string
+	for i, item := range list {
+		m := item.(map[string]interface{})
+		var parts []string
+		for _, field := range identity {
+			parts = append(parts, fmt.Sprint(m[field]))
+		}
+		id := strings.Join(parts, " ")
+		if _, exists := items[id]; exists {
+			id = fmt.Sprintf("%s#%d", id, i)
+		}
+		items[id] = item
+		order = append(order, id)
+	}
+	return items, order
+}
+
+func isScalarList(list []interface{}) bool {
+	for _, item := range list {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+func sortedScalars(list []interface{}) []string {
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		values = append(values, fmt.Sprint(item))
+	}
+	sort.Strings(values)
+	return values
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// UnifiedDiff returns a unified patch between two texts, empty if they are equal
+func UnifiedDiff(fromName, toName, from, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
@@ -0,0 +1,78 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeDiffDocument(t *testing.T, doc string) interface{} {
+	var v interface{}
+	assert.Nil(t, json.Unmarshal([]byte(doc), &v))
+	return v
+}
+
+func TestDiffObjectsEqual(t *testing.T) {
+	from := decodeDiffDocument(t, `{"name":"PizzaAPI","tags":["a","b"]}`)
+	to := decodeDiffDocument(t, `{"tags":["b","a"],"name":"PizzaAPI"}`)
+	assert.Empty(t, DiffObjects(from, to), "Reordered keys and scalar lists should not be reported as changes")
+}
+
+func TestDiffObjectsFields(t *testing.T) {
+	from := decodeDiffDocument(t, `{"context":"/pizza","cacheTimeout":300,"description":"old"}`)
+	to := decodeDiffDocument(t, `{"context":"/pizzashack","cacheTimeout":300,"visibility":"PUBLIC"}`)
+	changes := DiffObjects(from, to)
+	assert.Equal(t, []DiffChange{
+		{Path: "context", Type: DiffChangeModified, From: "/pizza", To: "/pizzashack"},
+		{Path: "description", Type: DiffChangeRemoved, From: "old"},
+		{Path: "visibility", Type: DiffChangeAdded, To: "PUBLIC"},
+	}, changes)
+}
+
+func TestDiffObjectsMatchesListsByIdentity(t *testing.T) {
+	from := decodeDiffDocument(t, `{"operations":[
+		{"verb":"GET","target":"/menu","throttlingPolicy":"Unlimited"},
+		{"verb":"POST","target":"/order","throttlingPolicy":"Unlimited"}]}`)
+	to := decodeDiffDocument(t, `{"operations":[
+		{"verb":"POST","target":"/order","throttlingPolicy":"10KPerMin"},
+		{"verb":"DELETE","target":"/order","throttlingPolicy":"Unlimited"}]}`)
+	changes := DiffObjects(from, to)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "operations[GET /menu]", changes[0].Path)
+	assert.Equal(t, DiffChangeRemoved, changes[0].Type)
+	assert.Equal(t, "operations[POST /order].throttlingPolicy", changes[1].Path)
+	assert.Equal(t, DiffChangeModified, changes[1].Type)
+	assert.Equal(t, "operations[DELETE /order]", changes[2].Path)
+	assert.Equal(t, DiffChangeAdded, changes[2].Type)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	patch, err := UnifiedDiff("dev/api.yaml", "prod/api.yaml", "a: 1\nb: 2\n", "a: 1\nb: 3\n")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(patch, "--- dev/api.yaml\n+++ prod/api.yaml\n"))
+	assert.Contains(t, patch, "-b: 2\n+b: 3\n")
+
+	patch, err = UnifiedDiff("dev/api.yaml", "prod/api.yaml", "a: 1\n", "a: 1\n")
+	assert.Nil(t, err)
+	assert.Empty(t, patch, "Equal texts should produce an empty patch")
+}
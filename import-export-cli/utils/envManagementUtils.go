@@ -293,6 +293,19 @@ func GetDevPortalApplicationListEndpointOfEnv(env, filePath string) string {
 	}
 }
 
+// Get SubscriptionListEndpoint of the Developer Portal of a given environment
+func GetDevPortalSubscriptionListEndpointOfEnv(env, filePath string) string {
+	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
+	if !(envEndpoints.DevPortalEndpoint == "" || envEndpoints == nil) {
+		envEndpoints.DevPortalEndpoint = AppendSlashToString(envEndpoints.DevPortalEndpoint)
+		return envEndpoints.DevPortalEndpoint + defaultDevPortalSubscriptionListEndpointSuffix
+	} else {
+		apiManagerEndpoint := GetApiManagerEndpointOfEnv(env, filePath)
+		apiManagerEndpoint = AppendSlashToString(apiManagerEndpoint)
+		return apiManagerEndpoint + defaultDevPortalSubscriptionListEndpointSuffix
+	}
+}
+
 // Get ThrottlingPoliciesEndpoint of a given environment
 func GetDevPortalThrottlingPoliciesEndpointOfEnv(env, filePath string) string {
 	envEndpoints, _ := GetEndpointsOfEnvironment(env, filePath)
@@ -473,6 +486,15 @@ func GetTokenRevokeEndpoint(env, filePath string) string {
 	return extractedTokenEndpoint + defaultRevokeEndpointSuffix
 }
 
+// GetDeviceAuthorizeEndpoint returns the device authorization endpoint of an environment
+// @param env : Name of the environment
+// @param filePath : Path to file where tokens are stored
+// @return endpoint URL of the device authorization endpoint
+func GetDeviceAuthorizeEndpoint(env, filePath string) string {
+	internalTokenEndpoint := GetInternalTokenEndpointOfEnv(env, filePath)
+	return strings.Split(internalTokenEndpoint, defaultTokenEndPoint)[0] + defaultDeviceAuthorizeEndpointSuffix
+}
+
 // RequiredAPIMEndpointsExists checks for required apim endpoints.
 // It returns true if all the endpoints are present
 func RequiredAPIMEndpointsExists(envEndpoints *EnvEndpoints) bool {
@@ -491,7 +513,7 @@ func RequiredAIEndpointsExists(envEndpoints *EnvEndpoints) bool {
 // RequiredMIEndpointsExists checks for required mi endpoints.
 // It returns true if all the endpoints are present
 func RequiredMIEndpointsExists(envEndpoints *EnvEndpoints) bool {
-	return envEndpoints.MiManagementEndpoint != "" 
+	return envEndpoints.MiManagementEndpoint != "" || len(envEndpoints.MiNodeEndpoints) > 0
 }
 
 // HasOnlyMIEndpoint checks whether an MI instance is present in a given environment
@@ -499,20 +521,59 @@ func RequiredMIEndpointsExists(envEndpoints *EnvEndpoints) bool {
 func HasOnlyMIEndpoint(envEndpoints *EnvEndpoints) bool {
 	return envEndpoints.ApiManagerEndpoint == "" && envEndpoints.AdminEndpoint == "" && envEndpoints.DevPortalEndpoint == "" &&
 		envEndpoints.PublisherEndpoint == "" && envEndpoints.RegistrationEndpoint == "" &&
-		envEndpoints.TokenEndpoint == "" && envEndpoints.MiManagementEndpoint != "" &&
+		envEndpoints.TokenEndpoint == "" && RequiredMIEndpointsExists(envEndpoints) &&
 		envEndpoints.AIServiceEndpoint == "" && envEndpoints.AITokenServiceEndpoint == "" &&
 		envEndpoints.AIKey == ""
 }
 
 // GetMIManagementEndpointOfEnv return the Mi Management Endpoint of a given environment
+// The endpoint of the node is returned for an environment which addresses a single node of a cluster,
+// and the first node for an environment which only has mi_nodes
 func GetMIManagementEndpointOfEnv(env, filePath string) (string, error) {
+	env, node := SplitMINodeEnv(env)
 	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
 	if err != nil {
 		return "", err
 	}
+	if node != "" {
+		return node, nil
+	}
+	if envEndpoints.MiManagementEndpoint == "" && len(envEndpoints.MiNodeEndpoints) > 0 {
+		return envEndpoints.MiNodeEndpoints[0], nil
+	}
 	return envEndpoints.MiManagementEndpoint, nil
 }
 
+// GetMINodeEndpointsOfEnv returns the management endpoints of the micro integrator nodes of a given environment
+// An environment without mi_nodes has a single node, its mi endpoint
+func GetMINodeEndpointsOfEnv(env, filePath string) ([]string, error) {
+	envEndpoints, err := GetEndpointsOfEnvironment(env, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(envEndpoints.MiNodeEndpoints) > 0 {
+		return envEndpoints.MiNodeEndpoints, nil
+	}
+	if envEndpoints.MiManagementEndpoint == "" {
+		return nil, errors.New("no micro integrator in environment '" + env + "'")
+	}
+	return []string{envEndpoints.MiManagementEndpoint}, nil
+}
+
+// GetMINodeEnv returns the environment name which addresses a single micro integrator node of env
+func GetMINodeEnv(env, node string) string {
+	return env + MINodeEnvSeparator + node
+}
+
+// SplitMINodeEnv splits an environment name returned by GetMINodeEnv into the environment and the node endpoint
+// The node is empty for a plain environment name
+func SplitMINodeEnv(nodeEnv string) (env, node string) {
+	if i := strings.Index(nodeEnv, MINodeEnvSeparator); i >= 0 {
+		return nodeEnv[:i], nodeEnv[i+len(MINodeEnvSeparator):]
+	}
+	return nodeEnv, ""
+}
+
 // GetMIManagementEndpointOfResource return the full resource url of a resource
 func GetMIManagementEndpointOfResource(resource, env, filePath string) string {
 	miEndpoint, _ := GetMIManagementEndpointOfEnv(env, filePath)
@@ -178,3 +178,36 @@ func TestGetKeysOfEnvironment(t *testing.T) {
 	defer os.Remove(testKeysFilePath)
 
 }
+
+func TestGetMINodeEndpointsOfEnv(t *testing.T) {
+	mainConfigFilePath := filepath.Join(t.TempDir(), "main_config.yaml")
+	mainConfig := &MainConfig{Environments: map[string]EnvEndpoints{
+		"prod": {MiManagementEndpoint: "https://mi-lb:9164",
+			MiNodeEndpoints: []string{"https://mi-0:9164", "https://mi-1:9164"}},
+		"dev": {MiManagementEndpoint: "https://localhost:9164"},
+	}}
+	WriteConfigFile(mainConfig, mainConfigFilePath)
+
+	nodes, err := GetMINodeEndpointsOfEnv("prod", mainConfigFilePath)
+	if err != nil || len(nodes) != 2 || nodes[1] != "https://mi-1:9164" {
+		t.Errorf("Error in GetMINodeEndpointsOfEnv(). Returned: %v, %v\n", nodes, err)
+	}
+	nodes, err = GetMINodeEndpointsOfEnv("dev", mainConfigFilePath)
+	if err != nil || len(nodes) != 1 || nodes[0] != "https://localhost:9164" {
+		t.Errorf("Error in GetMINodeEndpointsOfEnv(). Returned: %v, %v\n", nodes, err)
+	}
+
+	nodeEnv := GetMINodeEnv("prod", nodes[0])
+	if env, node := SplitMINodeEnv(nodeEnv); env != "prod" || node != "https://localhost:9164" {
+		t.Errorf("Error in SplitMINodeEnv(). Returned: %s, %s\n", env, node)
+	}
+	endpoint := GetMIManagementEndpointOfResource("apis", GetMINodeEnv("prod", "https://mi-1:9164"),
+		mainConfigFilePath)
+	if endpoint != "https://mi-1:9164/management/apis" {
+		t.Errorf("Error in GetMIManagementEndpointOfResource(). Returned: %s\n", endpoint)
+	}
+	endpoint = GetMIManagementEndpointOfResource("apis", "prod", mainConfigFilePath)
+	if endpoint != "https://mi-lb:9164/management/apis" {
+		t.Errorf("Error in GetMIManagementEndpointOfResource(). Returned: %s\n", endpoint)
+	}
+}
@@ -41,9 +41,9 @@ func HandleErrorAndContinue(msg string, err error) {
 		fmt.Println("=======  END OF DEBUG LOG ===========\n")
 	*/
 	if err == nil {
-		fmt.Fprintf(os.Stderr, "%s: %v\n", ProjectName, msg)
+		fmt.Fprintf(os.Stderr, "%s: %v\n", ProjectName, MaskSecrets(msg))
 	} else {
-		fmt.Fprintf(os.Stderr, "%s: %v Reason: %v\n", ProjectName, msg, err.Error())
+		fmt.Fprintf(os.Stderr, "%s: %v Reason: %v\n", ProjectName, MaskSecrets(msg), MaskSecrets(err.Error()))
 	}
 }
 
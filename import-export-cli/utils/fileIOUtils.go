@@ -398,6 +398,22 @@ func CreateTempFile(pattern string, content []byte) (string, error) {
 	return tmpFile.Name(), nil
 }
 
+// CleanupTempArtifact deletes a temporary file or directory created while importing a project. It is left behind
+// if skipCleanup is set, unless a secret reference has been resolved, as it can contain the resolved secrets
+func CleanupTempArtifact(path string, skipCleanup bool) {
+	if skipCleanup && !HasResolvedSecrets() {
+		Logln(LogPrefixInfo+"Leaving", path)
+		return
+	}
+	if skipCleanup {
+		Logln(LogPrefixInfo + "Not leaving " + path + " as it contains resolved secrets")
+	}
+	Logln(LogPrefixInfo+"Deleting", path)
+	if err := os.RemoveAll(path); err != nil {
+		Logln(LogPrefixError + err.Error())
+	}
+}
+
 // CreateZipFileFromProject if the given projectPath contains a directory, zip it and return the zip file path.
 //	Otherwise, leave it as it is.
 // @param projectPath Project path
@@ -420,15 +436,7 @@ func CreateZipFileFromProject(projectPath string, skipCleanup bool) (string, err
 		}
 		//creates a function to cleanup the temporary folders
 		cleanup := func() {
-			if skipCleanup {
-				Logln(LogPrefixInfo+"Leaving", tmp.Name())
-				return
-			}
-			Logln(LogPrefixInfo+"Deleting", tmp.Name())
-			err := os.Remove(tmp.Name())
-			if err != nil {
-				Logln(LogPrefixError + err.Error())
-			}
+			CleanupTempArtifact(tmp.Name(), skipCleanup)
 		}
 		projectPath = tmp.Name()
 		return projectPath, nil, cleanup
@@ -0,0 +1,328 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// httpClients holds the clients shared by the requests, so that connections are reused within an invocation
+var httpClients = struct {
+	sync.Mutex
+	config  *MainConfig
+	clients map[string]*resty.Client
+}{clients: make(map[string]*resty.Client)}
+
+// traceWriter receives the trace lines written when the trace mode is enabled
+var traceWriter io.Writer = os.Stderr
+var traceModeEnabled = false
+
+// sensitiveNamePattern matches the headers and query parameters whose values are redacted in the trace
+var sensitiveNamePattern = regexp.MustCompile(`(?i)authorization|cookie|token|secret|password|passphrase|key|assertion`)
+
+// EnableTraceMode logs a line for each request and response sent through the HTTP client
+func EnableTraceMode() {
+	traceModeEnabled = true
+}
+
+// setHttpClientConfig sets the main config the HTTP clients are created from and discards the existing clients
+func setHttpClientConfig(mainConfig *MainConfig) {
+	httpClients.Lock()
+	defer httpClients.Unlock()
+	httpClients.config = mainConfig
+	httpClients.clients = make(map[string]*resty.Client)
+}
+
+// GetHttpClient returns the HTTP client for the requests to url. The client is configured with the http_client
+// config of the environment url belongs to, falling back to the http_client config of main_config.yaml.
+// @param url : URL the request is sent to
+// @return client
+// @return error
+func GetHttpClient(url string) (*resty.Client, error) {
+	httpClients.Lock()
+	defer httpClients.Unlock()
+	env := getEnvironmentOfUrl(httpClients.config, url)
+	// clients are keyed by the settings which may change after the config is read
+	key := env + "|" + strconv.FormatBool(Insecure) + "|" + strconv.Itoa(HttpRequestTimeout)
+	if client, ok := httpClients.clients[key]; ok {
+		return client, nil
+	}
+	client, err := newHttpClient(getHttpClientConfigOfEnv(httpClients.config, env))
+	if err != nil {
+		return nil, err
+	}
+	httpClients.clients[key] = client
+	return client, nil
+}
+
+func newHttpClient(config HttpClientConfig) (*resty.Client, error) {
+	tlsConfig, err := getTlsConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.TLSClientConfig = tlsConfig
+
+	client := resty.New()
+	client.SetTransport(&tracingTransport{transport: transport})
+	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+	client.SetRetryCount(*config.Retry.MaxRetries)
+	client.SetRetryWaitTime(time.Duration(config.Retry.InitialBackoff) * time.Millisecond)
+	client.SetRetryMaxWaitTime(time.Duration(config.Retry.MaxBackoff) * time.Millisecond)
+	client.SetRetryAfter(getRetryAfter)
+	client.AddRetryCondition(newRetryCondition(config.Retry.RetryAllMethods))
+	return client, nil
+}
+
+// getTlsConfig returns the TLS config trusting the certificates in the certs directory, presenting the client
+// certificate if one is configured
+func getTlsConfig(config HttpClientConfig) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+	if Insecure {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
+			Renegotiation: TLSRenegotiationMode}
+	} else {
+		tlsConfig = GetTlsConfigWithCertificate()
+	}
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, errors.New("both client_cert and client_key are required for mutual TLS")
+		}
+		certificate, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the client certificate %s: %w", config.ClientCert, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// getEnvironmentOfUrl returns the environment which has an endpoint on the same scheme, host and port as rawUrl
+func getEnvironmentOfUrl(mainConfig *MainConfig, rawUrl string) string {
+	if mainConfig == nil {
+		return ""
+	}
+	target, err := url.Parse(rawUrl)
+	if err != nil || target.Host == "" {
+		return ""
+	}
+	envs := make([]string, 0, len(mainConfig.Environments))
+	for env := range mainConfig.Environments {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	for _, env := range envs {
+		endpoints := mainConfig.Environments[env]
+		for _, endpoint := range append([]string{endpoints.ApiManagerEndpoint, endpoints.PublisherEndpoint,
+			endpoints.DevPortalEndpoint, endpoints.RegistrationEndpoint, endpoints.AdminEndpoint,
+			endpoints.TokenEndpoint, endpoints.MiManagementEndpoint}, endpoints.MiNodeEndpoints...) {
+			if u, err := url.Parse(endpoint); err == nil && u.Host != "" &&
+				strings.EqualFold(u.Scheme, target.Scheme) && strings.EqualFold(u.Host, target.Host) {
+				return env
+			}
+		}
+	}
+	return ""
+}
+
+// getHttpClientConfigOfEnv merges the http_client config of env over the global one and applies the defaults
+func getHttpClientConfigOfEnv(mainConfig *MainConfig, env string) HttpClientConfig {
+	var config HttpClientConfig
+	if mainConfig != nil {
+		config = mainConfig.Config.HttpClient
+		if override := mainConfig.Environments[env].HttpClient; override != nil {
+			if override.Retry.MaxRetries != nil {
+				config.Retry.MaxRetries = override.Retry.MaxRetries
+			}
+			if override.Retry.InitialBackoff > 0 {
+				config.Retry.InitialBackoff = override.Retry.InitialBackoff
+			}
+			if override.Retry.MaxBackoff > 0 {
+				config.Retry.MaxBackoff = override.Retry.MaxBackoff
+			}
+			if override.Retry.RetryAllMethods {
+				config.Retry.RetryAllMethods = true
+			}
+			if override.ClientCert != "" {
+				config.ClientCert = override.ClientCert
+				config.ClientKey = override.ClientKey
+			}
+		}
+	}
+	if config.Retry.MaxRetries == nil || *config.Retry.MaxRetries < 0 {
+		maxRetries := DefaultHttpMaxRetries
+		config.Retry.MaxRetries = &maxRetries
+	}
+	if config.Retry.InitialBackoff <= 0 {
+		config.Retry.InitialBackoff = DefaultHttpInitialBackoff
+	}
+	if config.Retry.MaxBackoff <= 0 {
+		config.Retry.MaxBackoff = DefaultHttpMaxBackoff
+	}
+	return config
+}
+
+// newRetryCondition retries the requests which were rejected without being processed (429, 503 and refused
+// connections) regardless of the method. Other server errors and reset connections are retried only for
+// idempotent methods, unless retryAllMethods is set, since the server may have applied the request.
+func newRetryCondition(retryAllMethods bool) resty.RetryConditionFunc {
+	return func(resp *resty.Response, err error) bool {
+		method := ""
+		if resp != nil && resp.Request != nil {
+			method = resp.Request.Method
+		}
+		idempotent := retryAllMethods || isIdempotentMethod(method)
+		var retry bool
+		var reason string
+		if err != nil {
+			retry = errors.Is(err, syscall.ECONNREFUSED) || (idempotent && isConnectionReset(err))
+			reason = err.Error()
+		} else {
+			switch resp.StatusCode() {
+			case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+				retry = true
+			case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+				retry = idempotent
+			}
+			reason = resp.Status()
+		}
+		if retry && resp != nil && resp.Request != nil {
+			Logln(LogPrefixWarning + "Retrying " + method + " " + redactUrl(resp.Request.URL) + ": " + reason)
+			trace("retrying %s %s: %s", method, redactUrl(resp.Request.URL), reason)
+		}
+		return retry
+	}
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// getRetryAfter returns the wait given by the Retry-After header in seconds or as an HTTP date. Zero selects the
+// exponential backoff.
+func getRetryAfter(client *resty.Client, resp *resty.Response) (time.Duration, error) {
+	value := strings.TrimSpace(resp.Header().Get("Retry-After"))
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	if date, err := http.ParseTime(value); err == nil && time.Until(date) > 0 {
+		return time.Until(date), nil
+	}
+	return 0, nil
+}
+
+// tracingTransport writes the redacted request and response lines of each attempt with the time taken
+type tracingTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !traceModeEnabled {
+		return t.transport.RoundTrip(req)
+	}
+	trace("--> %s %s", req.Method, redactUrl(req.URL.String()))
+	traceHeaders(req.Header)
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		trace("<-- %s %s failed (%s): %v", req.Method, redactUrl(req.URL.String()), elapsed, err)
+		return resp, err
+	}
+	trace("<-- %s %s %s (%s)", resp.Status, req.Method, redactUrl(req.URL.String()), elapsed)
+	traceHeaders(resp.Header)
+	return resp, err
+}
+
+func trace(format string, a ...interface{}) {
+	if traceModeEnabled {
+		fmt.Fprintln(traceWriter, LogPrefixTrace+MaskSecrets(fmt.Sprintf(format, a...)))
+	}
+}
+
+func traceHeaders(headers http.Header) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		trace("    %s: %s", name, redactHeader(name, strings.Join(headers[name], ", ")))
+	}
+}
+
+// redactHeader hides the value of a sensitive header, keeping the scheme of the Authorization headers
+func redactHeader(name, value string) string {
+	if !sensitiveNamePattern.MatchString(name) {
+		return value
+	}
+	if scheme := strings.SplitN(value, " ", 2); len(scheme) == 2 && strings.HasSuffix(name, "Authorization") {
+		return scheme[0] + " ***"
+	}
+	return "***"
+}
+
+// redactUrl hides the user info and the values of sensitive query parameters of rawUrl
+func redactUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return rawUrl
+	}
+	if u.User != nil {
+		u.User = url.User("***")
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name := range query {
+			if sensitiveNamePattern.MatchString(name) {
+				query.Set(name, "***")
+			}
+		}
+		u.RawQuery = strings.ReplaceAll(query.Encode(), "=%2A%2A%2A", "=***")
+	}
+	return u.String()
+}
@@ -0,0 +1,302 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// useHttpClientConfig sets the main config of the HTTP clients for the duration of a test
+func useHttpClientConfig(t *testing.T, mainConfig *MainConfig) {
+	setHttpClientConfig(mainConfig)
+	t.Cleanup(func() { setHttpClientConfig(nil) })
+}
+
+func fastRetryConfig(maxRetries int, retryAllMethods bool) HttpClientConfig {
+	return HttpClientConfig{Retry: RetryConfig{MaxRetries: &maxRetries, InitialBackoff: 1, MaxBackoff: 5,
+		RetryAllMethods: retryAllMethods}}
+}
+
+// newFlakyServer responds with the given statuses in order and with 200 OK afterwards
+func newFlakyServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(atomic.AddInt32(&requests, 1))
+		if attempt <= len(statuses) {
+			w.WriteHeader(statuses[attempt-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestInvokeGETRequestRetriesServerErrors(t *testing.T) {
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(3, false)}})
+	server, requests := newFlakyServer(t, http.StatusBadGateway, http.StatusTooManyRequests)
+
+	resp, err := InvokeGETRequest(server.URL+"/apis", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestInvokeGETRequestStopsAfterMaxRetries(t *testing.T) {
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(2, false)}})
+	server, requests := newFlakyServer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	resp, err := InvokeGETRequest(server.URL, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests), "Should stop after the first attempt and 2 retries")
+}
+
+func TestInvokePOSTRequestRetries(t *testing.T) {
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(3, false)}})
+	server, requests := newFlakyServer(t, http.StatusInternalServerError)
+	resp, err := InvokePOSTRequest(server.URL, nil, "body")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode())
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests), "POST may have been applied, so it should not be retried")
+
+	server, requests = newFlakyServer(t, http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	resp, err = InvokePOSTRequest(server.URL, nil, "body")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests), "Rejected requests should be retried for any method")
+
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(3, true)}})
+	server, requests = newFlakyServer(t, http.StatusInternalServerError)
+	resp, err = InvokePOSTRequest(server.URL, nil, "body")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestInvokePOSTRequestRetriesRefusedConnections(t *testing.T) {
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(2, false)}})
+	server, _ := newFlakyServer(t)
+	url := server.URL
+	server.Close()
+
+	var retries []string
+	traceWriter = writerFunc(func(p []byte) { retries = append(retries, string(p)) })
+	traceModeEnabled = true
+	defer func() { traceWriter, traceModeEnabled = os.Stderr, false }()
+
+	_, err := InvokePOSTRequest(url, nil, "body")
+	assert.Error(t, err)
+	assert.Contains(t, joinLines(retries), "retrying POST "+url)
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"invalid", 0},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, test := range tests {
+		resp := newResponseWithHeader("Retry-After", test.value)
+		wait, err := getRetryAfter(nil, resp)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, wait, "Retry-After: "+test.value)
+	}
+
+	resp := newResponseWithHeader("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	wait, err := getRetryAfter(nil, resp)
+	assert.Nil(t, err)
+	assert.True(t, wait > 58*time.Minute && wait <= time.Hour, "HTTP date should be converted to a wait")
+}
+
+func TestGetHttpClientConfigOfEnv(t *testing.T) {
+	globalRetries := 1
+	envRetries := 0
+	mainConfig := &MainConfig{
+		Config: Config{HttpClient: HttpClientConfig{Retry: RetryConfig{MaxRetries: &globalRetries, MaxBackoff: 100}}},
+		Environments: map[string]EnvEndpoints{
+			"dev": {ApiManagerEndpoint: "https://dev.example.com:9443", MiManagementEndpoint: "https://mi.dev:9164",
+				HttpClient: &HttpClientConfig{Retry: RetryConfig{MaxRetries: &envRetries},
+					ClientCert: "dev.crt", ClientKey: "dev.key"}},
+			"prod": {PublisherEndpoint: "https://prod.example.com"},
+		},
+	}
+
+	assert.Equal(t, "dev", getEnvironmentOfUrl(mainConfig, "https://DEV.example.com:9443/api/am/publisher/v4/apis"))
+	assert.Equal(t, "dev", getEnvironmentOfUrl(mainConfig, "https://mi.dev:9164/management/apis"))
+	assert.Equal(t, "prod", getEnvironmentOfUrl(mainConfig, "https://prod.example.com/oauth2/token"))
+	assert.Equal(t, "", getEnvironmentOfUrl(mainConfig, "http://dev.example.com:9443/apis"),
+		"Scheme should match as well as the host")
+	assert.Equal(t, "", getEnvironmentOfUrl(nil, "https://prod.example.com"))
+
+	config := getHttpClientConfigOfEnv(mainConfig, "dev")
+	assert.Equal(t, 0, *config.Retry.MaxRetries, "Environment should be able to disable the retries")
+	assert.Equal(t, 100, config.Retry.MaxBackoff)
+	assert.Equal(t, DefaultHttpInitialBackoff, config.Retry.InitialBackoff)
+	assert.Equal(t, "dev.crt", config.ClientCert)
+
+	config = getHttpClientConfigOfEnv(mainConfig, "prod")
+	assert.Equal(t, 1, *config.Retry.MaxRetries)
+	assert.Equal(t, "", config.ClientCert)
+
+	config = getHttpClientConfigOfEnv(nil, "")
+	assert.Equal(t, DefaultHttpMaxRetries, *config.Retry.MaxRetries)
+	assert.Equal(t, DefaultHttpMaxBackoff, config.Retry.MaxBackoff)
+}
+
+func TestGetHttpClientWithClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	caCert, clientCertFile, clientKeyFile := writeClientCertificate(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(caCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	insecure := Insecure
+	Insecure = true
+	defer func() { Insecure = insecure }()
+
+	noRetries := 0
+	mainConfig := &MainConfig{
+		Config: Config{HttpClient: HttpClientConfig{Retry: RetryConfig{MaxRetries: &noRetries}}},
+		Environments: map[string]EnvEndpoints{
+			"dev": {ApiManagerEndpoint: "https://other.example.com"},
+		},
+	}
+	useHttpClientConfig(t, mainConfig)
+	_, err := InvokeGETRequest(server.URL, nil)
+	assert.Error(t, err, "Server should reject clients without a certificate")
+
+	mainConfig.Environments["mtls"] = EnvEndpoints{ApiManagerEndpoint: server.URL,
+		HttpClient: &HttpClientConfig{ClientCert: clientCertFile, ClientKey: clientKeyFile}}
+	useHttpClientConfig(t, mainConfig)
+	resp, err := InvokeGETRequest(server.URL+"/api/am/publisher/v4/apis", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+
+	mainConfig.Environments["mtls"] = EnvEndpoints{ApiManagerEndpoint: server.URL,
+		HttpClient: &HttpClientConfig{ClientCert: clientCertFile}}
+	useHttpClientConfig(t, mainConfig)
+	_, err = InvokeGETRequest(server.URL, nil)
+	assert.EqualError(t, err, "both client_cert and client_key are required for mutual TLS")
+}
+
+func TestTraceRedactsRequests(t *testing.T) {
+	useHttpClientConfig(t, &MainConfig{Config: Config{HttpClient: fastRetryConfig(0, false)}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	traceWriter = &output
+	traceModeEnabled = true
+	defer func() { traceWriter, traceModeEnabled = os.Stderr, false }()
+
+	_, err := InvokeGETRequestWithMultipleQueryParams(map[string]string{"access_token": "a1b2c3", "limit": "10"},
+		server.URL+"/apis", map[string]string{HeaderAuthorization: "Bearer a1b2c3", "X-Custom": "visible"})
+	assert.Nil(t, err)
+
+	lines := output.String()
+	assert.NotContains(t, lines, "a1b2c3", "Tokens should not be traced")
+	assert.NotContains(t, lines, "session=abc")
+	assert.Contains(t, lines, LogPrefixTrace+"--> GET "+server.URL+"/apis?access_token=***&limit=10")
+	assert.Contains(t, lines, "Authorization: Bearer ***")
+	assert.Contains(t, lines, "X-Custom: visible")
+	assert.Regexp(t, `<-- 201 Created GET \S+ \(\d+m?s\)`, lines)
+}
+
+type writerFunc func(p []byte)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	f(p)
+	return len(p), nil
+}
+
+func joinLines(lines []string) string {
+	var buffer bytes.Buffer
+	for _, line := range lines {
+		buffer.WriteString(line)
+	}
+	return buffer.String()
+}
+
+func newResponseWithHeader(name, value string) *resty.Response {
+	header := http.Header{}
+	header.Set(name, value)
+	return &resty.Response{RawResponse: &http.Response{Header: header}}
+}
+
+// writeClientCertificate writes a client certificate signed by a new CA to dir and returns the CA certificate
+func writeClientCertificate(t *testing.T, dir string) (*x509.Certificate, string, string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	caTemplate := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test ca"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour), IsCA: true,
+		BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.Nil(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	assert.Nil(t, err)
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	clientTemplate := &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "apictl"},
+		NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour),
+		KeyUsage: x509.KeyUsageDigitalSignature, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}
+	clientDER, err := x509.CreateCertificate(rand.Reader, clientTemplate, caCert, &clientKey.PublicKey, caKey)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(clientKey)
+	assert.Nil(t, err)
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientDER}), 0600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return caCert, certFile, keyFile
+}
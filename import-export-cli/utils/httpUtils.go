@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"net/url"
 	"path/filepath"
 )
@@ -14,21 +13,19 @@ import (
 const PlainTextWarnMessage = "WARNING: Error importing the certificate %s\n"
 
 func ReadFromUrl(url string) ([]byte, error) {
-	response, err := http.Get(url)
+	client, err := GetHttpClient(url)
 	if err != nil {
 		return nil, err
 	}
-
-	body, err := ioutil.ReadAll(response.Body)
-	_ = response.Body.Close()
+	response, err := client.R().Get(url)
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode != 200 {
-		return nil, errors.New(response.Status)
+	if response.StatusCode() != 200 {
+		return nil, errors.New(response.Status())
 	}
-	return body, nil
+	return response.Body(), nil
 }
 
 func GetTlsConfigWithCertificate() *tls.Config {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Jeffail/gabs"
 	"github.com/savaki/jq"
+	"github.com/wso2/product-apim-tooling/import-export-cli/formatter"
 )
 
 // MergeJSON secondSource with firstSource and returns merged JSON string
@@ -36,6 +37,16 @@ func MergeJSON(firstSource, secondSource []byte) ([]byte, error) {
 	return firstSourceJSON.Bytes(), nil
 }
 
+// PrintStructuredOutput prints data to the standard output if format is one of the structured output formats
+// (json, yaml, jsonpath=, go-template=) and returns true. It returns false without printing for the other formats
+func PrintStructuredOutput(data interface{}, format string) bool {
+	printed, err := formatter.WriteOutput(format, data)
+	if err != nil {
+		HandleErrorAndExit("Error writing the output", err)
+	}
+	return printed
+}
+
 // ListArtifactsInJsonArrayFormat : This function will return the output of list apis/apiProducts/apps command in
 // JsonObject format
 func ListArtifactsInJsonArrayFormat(artifacts interface{}, artifactType string) {
@@ -44,10 +44,22 @@ func doNothingfFunc(format string, a ...interface{}) {
 }
 
 func Logln(a ...interface{}) {
+	if verboseModeEnabled && HasResolvedSecrets() {
+		// resolved secrets should never be logged
+		masked := make([]interface{}, len(a))
+		for i, value := range a {
+			masked[i] = MaskSecrets(fmt.Sprint(value))
+		}
+		a = masked
+	}
 	loglnFunc(a...)
 }
 
 func Logf(format string, a ...interface{}) {
+	if verboseModeEnabled && HasResolvedSecrets() {
+		logfFunc("%s", MaskSecrets(fmt.Sprintf(format, a...)))
+		return
+	}
 	logfFunc(format, a...)
 }
 
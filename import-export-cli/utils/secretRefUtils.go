@@ -0,0 +1,387 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	SecretRefSchemeVault = "vault"
+	SecretRefSchemeFile  = "file"
+	SecretRefSchemeEnv   = "env"
+)
+
+// SecretMask replaces the resolved secret values in logs
+const SecretMask = "********"
+
+// MinMaskedSecretLength is the length of the shortest secret which is masked, shorter values would mask unrelated text
+const MinMaskedSecretLength = 4
+
+// Environment variables used by the Vault secret resolver
+const (
+	VaultAddressEnvVar   = "VAULT_ADDR"
+	VaultTokenEnvVar     = "VAULT_TOKEN"
+	VaultNamespaceEnvVar = "VAULT_NAMESPACE"
+)
+
+// Match for {{ secret "<scheme>://<path>#<key>" }} and capture the quote before it and the reference inside groups
+var reSecretRef = regexp.MustCompile(`(["']?)\{\{\s*secret\s+"([^"]+)"\s*\}\}`)
+
+var reSecretRefScheme = regexp.MustCompile(`^(\w+)://([^#]*)(?:#(.+))?$`)
+
+// SecretRef is a typed reference to a secret such as vault://kv/apim/prod#backendPassword
+type SecretRef struct {
+	// Scheme selects the resolver of the secret (vault, file or env)
+	Scheme string
+	// Path of the secret within the secret store
+	Path string
+	// Key of the value within the secret, if the secret contains multiple values
+	Key string
+}
+
+func (ref SecretRef) String() string {
+	if ref.Key == "" {
+		return ref.Scheme + "://" + ref.Path
+	}
+	return ref.Scheme + "://" + ref.Path + "#" + ref.Key
+}
+
+// SecretResolver resolves the secret references of a scheme to their values
+type SecretResolver interface {
+	Resolve(ref SecretRef) (string, error)
+}
+
+var secretResolvers = map[string]SecretResolver{
+	SecretRefSchemeVault: &VaultSecretResolver{},
+	SecretRefSchemeFile:  &FileSecretResolver{},
+	SecretRefSchemeEnv:   &EnvSecretResolver{},
+}
+var secretResolversLock sync.RWMutex
+
+// resolved secret values which should be masked wherever they could be leaked
+var resolvedSecrets = make(map[string]bool)
+
+// secretsResolved is set once any secret reference is resolved, including the values too short to be masked
+var secretsResolved bool
+var resolvedSecretsLock sync.RWMutex
+
+// RegisterSecretResolver registers the resolver used for the secret references of scheme, replacing the existing
+// resolver of the scheme if there is one
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretResolversLock.Lock()
+	defer secretResolversLock.Unlock()
+	secretResolvers[scheme] = resolver
+}
+
+// ParseSecretRef parses a secret reference of the format <scheme>://<path>#<key>
+func ParseSecretRef(ref string) (SecretRef, error) {
+	match := reSecretRefScheme.FindStringSubmatch(strings.TrimSpace(ref))
+	if match == nil || match[2] == "" {
+		return SecretRef{}, errors.New("invalid secret reference " + ref +
+			". Secret references should be of the format <scheme>://<path>#<key>")
+	}
+	return SecretRef{Scheme: match[1], Path: match[2], Key: match[3]}, nil
+}
+
+// ResolveSecretRef resolves a secret reference using the resolver registered for its scheme
+func ResolveSecretRef(ref SecretRef) (string, error) {
+	secretResolversLock.RLock()
+	resolver, ok := secretResolvers[ref.Scheme]
+	secretResolversLock.RUnlock()
+	if !ok {
+		return "", errors.New("no secret resolver found for the scheme " + ref.Scheme + " of " + ref.String())
+	}
+	Logln(LogPrefixInfo+"Resolving secret reference", ref.String())
+	value, err := resolver.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("error resolving secret reference %s: %v", ref.String(), err)
+	}
+	addResolvedSecret(value)
+	return value, nil
+}
+
+// SubstituteSecretRefs substitutes the secret references added to YAML content as {{ secret "<reference>" }}.
+// A reference which is a whole YAML value is substituted with a double quoted YAML string and a reference inside
+// a quoted YAML string is substituted with the value escaped for that string, so that the value cannot change the
+// structure of the content. Any other reference is substituted with the value as it is.
+// returns an error for all the references which could not be resolved
+func SubstituteSecretRefs(content string) (string, error) {
+	var errorResults error
+	var builder strings.Builder
+	last := 0
+	for _, match := range reSecretRef.FindAllStringSubmatchIndex(content, -1) {
+		quote := content[match[2]:match[3]]
+		ref, err := ParseSecretRef(content[match[4]:match[5]])
+		if err == nil {
+			var value string
+			value, err = ResolveSecretRef(ref)
+			if err == nil {
+				builder.WriteString(content[last:match[0]])
+				builder.WriteString(quote)
+				builder.WriteString(quoteSecretValue(value, quote, isWholeYamlValue(content, match[0], match[1])))
+				last = match[1]
+				continue
+			}
+		}
+		errorResults = multierror.Append(errorResults, err)
+	}
+	if errorResults != nil {
+		return "", errorResults
+	}
+	builder.WriteString(content[last:])
+	return builder.String(), nil
+}
+
+// isWholeYamlValue checks whether content[start:end] is a complete value of a key or a list item
+func isWholeYamlValue(content string, start, end int) bool {
+	before := strings.TrimRight(content[:start], " \t")
+	after := strings.TrimLeft(content[end:], " \t")
+	return (before == "" || strings.HasSuffix(before, ":") || strings.HasSuffix(before, "-") ||
+		strings.HasSuffix(before, "\n")) && (after == "" || after[0] == '\n' || after[0] == '\r' || after[0] == '#')
+}
+
+func quoteSecretValue(value, quote string, wholeValue bool) string {
+	escaped, _ := json.Marshal(value)
+	switch {
+	case quote == `"`:
+		return string(escaped[1 : len(escaped)-1])
+	case quote == `'`:
+		return strings.ReplaceAll(value, `'`, `''`)
+	case wholeValue:
+		return string(escaped)
+	default:
+		return value
+	}
+}
+
+func addResolvedSecret(value string) {
+	resolvedSecretsLock.Lock()
+	secretsResolved = true
+	resolvedSecretsLock.Unlock()
+	if value == "" {
+		return
+	}
+	if len(value) < MinMaskedSecretLength {
+		Logln(LogPrefixWarning + "A resolved secret is shorter than " + fmt.Sprint(MinMaskedSecretLength) +
+			" characters and will not be masked")
+		return
+	}
+	resolvedSecretsLock.Lock()
+	defer resolvedSecretsLock.Unlock()
+	resolvedSecrets[value] = true
+	// a quoted secret is written to the YAML files in its escaped form
+	escaped, _ := json.Marshal(value)
+	resolvedSecrets[string(escaped[1:len(escaped)-1])] = true
+}
+
+// HasResolvedSecrets returns true if any secret reference has been resolved, whether its value can be masked or not
+func HasResolvedSecrets() bool {
+	resolvedSecretsLock.RLock()
+	defer resolvedSecretsLock.RUnlock()
+	return secretsResolved
+}
+
+// MaskSecrets replaces the values of the resolved secret references in content with SecretMask. A value is only
+// masked where it is not a part of a longer word, ex: the secret "admin" is not masked in "administrator".
+func MaskSecrets(content string) string {
+	resolvedSecretsLock.RLock()
+	defer resolvedSecretsLock.RUnlock()
+	if len(resolvedSecrets) == 0 {
+		return content
+	}
+	secrets := make([]string, 0, len(resolvedSecrets))
+	for secret := range resolvedSecrets {
+		secrets = append(secrets, secret)
+	}
+	// mask the longest secrets first, so that a secret containing another secret is masked completely
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for _, secret := range secrets {
+		content = maskSecret(content, secret)
+	}
+	return content
+}
+
+func maskSecret(content, secret string) string {
+	var masked strings.Builder
+	for {
+		index := strings.Index(content, secret)
+		if index < 0 {
+			break
+		}
+		end := index + len(secret)
+		masked.WriteString(content[:index])
+		if (index > 0 && isWordByte(content[index-1])) || (end < len(content) && isWordByte(content[end])) {
+			masked.WriteString(secret)
+		} else {
+			masked.WriteString(SecretMask)
+		}
+		content = content[end:]
+	}
+	masked.WriteString(content)
+	return masked.String()
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// EnvSecretResolver resolves env://<variable> references from the environment variables
+type EnvSecretResolver struct{}
+
+func (r *EnvSecretResolver) Resolve(ref SecretRef) (string, error) {
+	value := os.Getenv(ref.Path)
+	if value == "" {
+		return "", errors.New("environment variable " + ref.Path + " is not set")
+	}
+	return value, nil
+}
+
+// FileSecretResolver resolves file://<path> references from the content of the file. If a key is given using
+// file://<path>#<key>, the file is read as YAML or JSON and the value of the key is used.
+type FileSecretResolver struct{}
+
+func (r *FileSecretResolver) Resolve(ref SecretRef) (string, error) {
+	content, err := ioutil.ReadFile(filepath.FromSlash(ref.Path))
+	if err != nil {
+		return "", err
+	}
+	if ref.Key == "" {
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	values := make(map[string]interface{})
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		return "", errors.New(ref.Path + " is not a YAML or JSON file")
+	}
+	value, ok := values[ref.Key]
+	if !ok || value == nil {
+		return "", errors.New("key " + ref.Key + " is not found in " + ref.Path)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// VaultSecretResolver resolves vault://<mount>/<path>#<key> references from a HashiCorp Vault KV version 2 secrets
+// engine using its HTTP API. The address, token and namespace are read from VAULT_ADDR, VAULT_TOKEN and
+// VAULT_NAMESPACE unless they are set in the resolver.
+type VaultSecretResolver struct {
+	Address   string
+	Token     string
+	Namespace string
+
+	cache     map[string]map[string]interface{}
+	cacheLock sync.Mutex
+}
+
+// vaultKVResponse represents the response of reading a secret from a KV version 2 secrets engine
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (r *VaultSecretResolver) Resolve(ref SecretRef) (string, error) {
+	if ref.Key == "" {
+		return "", errors.New("key of the secret should be given as vault://<mount>/<path>#<key>")
+	}
+	secretPath := strings.Trim(ref.Path, "/")
+	separatorIndex := strings.Index(secretPath, "/")
+	if separatorIndex < 0 {
+		return "", errors.New("path of the secret should be given as vault://<mount>/<path>#<key>")
+	}
+	secret, err := r.readSecret(secretPath[:separatorIndex], secretPath[separatorIndex+1:])
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret[ref.Key]
+	if !ok || value == nil {
+		return "", errors.New("key " + ref.Key + " is not found in the secret " + secretPath)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// readSecret reads the latest version of a secret. Secrets are cached, so a secret referred multiple times is
+// read only once.
+func (r *VaultSecretResolver) readSecret(mount, path string) (map[string]interface{}, error) {
+	address := r.Address
+	if address == "" {
+		address = os.Getenv(VaultAddressEnvVar)
+	}
+	token := r.Token
+	if token == "" {
+		token = os.Getenv(VaultTokenEnvVar)
+	}
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = os.Getenv(VaultNamespaceEnvVar)
+	}
+	if address == "" {
+		return nil, errors.New("address of Vault is not set. Set it using the environment variable " +
+			VaultAddressEnvVar)
+	}
+
+	url := strings.TrimSuffix(address, "/") + "/v1/" + mount + "/data/" + path
+	r.cacheLock.Lock()
+	defer r.cacheLock.Unlock()
+	if secret, ok := r.cache[url]; ok {
+		return secret, nil
+	}
+
+	headers := make(map[string]string)
+	headers["X-Vault-Token"] = token
+	if namespace != "" {
+		headers["X-Vault-Namespace"] = namespace
+	}
+	Logln(LogPrefixInfo+"Reading secret from", url)
+	resp, err := InvokeGETRequest(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	vaultResponse := &vaultKVResponse{}
+	_ = json.Unmarshal(resp.Body(), vaultResponse)
+	if resp.StatusCode() != http.StatusOK {
+		if len(vaultResponse.Errors) > 0 {
+			return nil, errors.New(resp.Status() + ": " + strings.Join(vaultResponse.Errors, ", "))
+		}
+		return nil, errors.New(resp.Status())
+	}
+	if vaultResponse.Data.Data == nil {
+		return nil, errors.New("secret " + mount + "/" + path + " does not contain any data")
+	}
+	if r.cache == nil {
+		r.cache = make(map[string]map[string]interface{})
+	}
+	r.cache[url] = vaultResponse.Data.Data
+	return vaultResponse.Data.Data, nil
+}
@@ -0,0 +1,157 @@
+/*
+*  Copyright (c) WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+*
+*  WSO2 Inc. licenses this file to you under the Apache License,
+*  Version 2.0 (the "License"); you may not use this file except
+*  in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*    http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing,
+* software distributed under the License is distributed on an
+* "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+* KIND, either express or implied.  See the License for the
+* specific language governing permissions and limitations
+* under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+// newVaultMockServer serves the secret kv/apim/prod of a KV version 2 secrets engine
+func newVaultMockServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/apim/prod" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"backendPassword":"p@ss: #word","clientSecret":"s3cr3t"},` +
+			`"metadata":{"version":3}}}`))
+	}))
+}
+
+func TestParseSecretRef(t *testing.T) {
+	ref, err := ParseSecretRef("vault://kv/apim/prod#backendPassword")
+	assert.Nil(t, err)
+	assert.Equal(t, SecretRef{Scheme: "vault", Path: "kv/apim/prod", Key: "backendPassword"}, ref)
+	assert.Equal(t, "vault://kv/apim/prod#backendPassword", ref.String())
+
+	_, err = ParseSecretRef("kv/apim/prod")
+	assert.Error(t, err, "Should return an error for a reference without a scheme")
+}
+
+func TestSubstituteSecretRefsFromVault(t *testing.T) {
+	requests := 0
+	server := newVaultMockServer(t, &requests)
+	defer server.Close()
+	RegisterSecretResolver(SecretRefSchemeVault, &VaultSecretResolver{Address: server.URL, Token: "root"})
+	defer RegisterSecretResolver(SecretRefSchemeVault, &VaultSecretResolver{})
+
+	content := `security:
+  production:
+    password: {{ secret "vault://kv/apim/prod#backendPassword" }}
+    clientSecret: '{{ secret "vault://kv/apim/prod#clientSecret" }}'
+`
+	substituted, err := SubstituteSecretRefs(content)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests, "A secret should be read from Vault only once")
+
+	values := make(map[string]map[string]map[string]string)
+	assert.Nil(t, yaml.Unmarshal([]byte(substituted), &values), "Secrets should not break the YAML")
+	assert.Equal(t, "p@ss: #word", values["security"]["production"]["password"])
+	assert.Equal(t, "s3cr3t", values["security"]["production"]["clientSecret"])
+
+	assert.True(t, HasResolvedSecrets())
+	assert.Equal(t, "password: "+SecretMask, MaskSecrets("password: s3cr3t"))
+	assert.Equal(t, "url: https://host/?key="+SecretMask+"&s3cr3ts=1",
+		MaskSecrets("url: https://host/?key=s3cr3t&s3cr3ts=1"), "Only whole values should be masked")
+
+	_, err = SubstituteSecretRefs(`password: {{ secret "vault://kv/apim/dev#backendPassword" }}`)
+	assert.Error(t, err, "Should return an error for a secret which does not exist")
+}
+
+func TestSubstituteSecretRefsFromVaultWithoutPermission(t *testing.T) {
+	requests := 0
+	server := newVaultMockServer(t, &requests)
+	defer server.Close()
+	RegisterSecretResolver(SecretRefSchemeVault, &VaultSecretResolver{Address: server.URL, Token: "invalid"})
+	defer RegisterSecretResolver(SecretRefSchemeVault, &VaultSecretResolver{})
+
+	_, err := SubstituteSecretRefs(`password: {{ secret "vault://kv/apim/prod#backendPassword" }}`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestSubstituteSecretRefsFromFileAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password.txt")
+	assert.Nil(t, ioutil.WriteFile(passwordFile, []byte("filePassword\n"), 0600))
+	secretsFile := filepath.Join(dir, "secrets.yaml")
+	assert.Nil(t, ioutil.WriteFile(secretsFile, []byte("clientSecret: fileSecret\n"), 0600))
+	_ = os.Setenv("APICTL_TEST_SECRET", "envSecret")
+	defer os.Unsetenv("APICTL_TEST_SECRET")
+
+	substituted, err := SubstituteSecretRefs(`password: {{ secret "file://` + filepath.ToSlash(passwordFile) + `" }}
+clientSecret: "{{ secret "file://` + filepath.ToSlash(secretsFile) + `#clientSecret" }}"
+url: https://{{ secret "env://APICTL_TEST_SECRET" }}.pizza.com
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, `password: "filePassword"
+clientSecret: "fileSecret"
+url: https://envSecret.pizza.com
+`, substituted)
+
+	_, err = SubstituteSecretRefs(`password: {{ secret "env://APICTL_TEST_UNDEFINED_SECRET" }}`)
+	assert.Error(t, err, "Should return an error for an environment variable which is not set")
+	_, err = SubstituteSecretRefs(`password: {{ secret "unknown://secret" }}`)
+	assert.Error(t, err, "Should return an error for a scheme without a resolver")
+}
+
+func TestCleanupTempArtifactWithResolvedSecrets(t *testing.T) {
+	resolvedSecretsLock.Lock()
+	resolvedSecrets, secretsResolved = make(map[string]bool), false
+	resolvedSecretsLock.Unlock()
+
+	dir := t.TempDir()
+	workspace := filepath.Join(dir, "workspace")
+	assert.Nil(t, os.MkdirAll(workspace, os.ModePerm))
+	CleanupTempArtifact(workspace, true)
+	assert.DirExists(t, workspace, "The workspace should be left behind without resolved secrets")
+
+	// a secret too short to be masked is still a resolved secret
+	_ = os.Setenv("APICTL_TEST_SHORT_SECRET", "abc")
+	defer os.Unsetenv("APICTL_TEST_SHORT_SECRET")
+	_, err := SubstituteSecretRefs(`password: {{ secret "env://APICTL_TEST_SHORT_SECRET" }}`)
+	assert.Nil(t, err)
+	assert.True(t, HasResolvedSecrets())
+
+	paramsFile := filepath.Join(workspace, "Deployment", ParamsIntermediateFile)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(paramsFile), os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(paramsFile, []byte("password: abc\n"), 0644))
+	CleanupTempArtifact(workspace, true)
+	assert.NoDirExists(t, workspace, "The workspace should not be left behind with resolved secrets")
+}
+
+func TestMaskSecretsSkipsShortValues(t *testing.T) {
+	addResolvedSecret("ab")
+	assert.Equal(t, "id: ab", MaskSecrets("id: ab"), "Short values should not be masked")
+}
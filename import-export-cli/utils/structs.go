@@ -39,17 +39,50 @@ type MainConfig struct {
 }
 
 type Config struct {
-	HttpRequestTimeout    int    `yaml:"http_request_timeout"`
-	ExportDirectory       string `yaml:"export_directory"`
-	KubernetesMode        bool   `yaml:"kubernetes_mode"`
-	TokenType             string `yaml:"token_type"`
-	VCSDeletionEnabled    bool   `yaml:"vcs_deletion_enabled"`
-	VCSConfigFilePath     string `yaml:"vcs_config_file_path"`
-	VCSSourceRepoPath     string `yaml:"vcs_source_repo_path"`
-	VCSDeploymentRepoPath string `yaml:"vcs_deployment_repo_path"`
-	TLSRenegotiationMode  string `yaml:"tls-renegotiation-mode"`
-	AIThreadCount         int    `yaml:"ai_thread_count"`
-	AIToken               string `yaml:"ai_token"`
+	HttpRequestTimeout    int                   `yaml:"http_request_timeout"`
+	ExportDirectory       string                `yaml:"export_directory"`
+	KubernetesMode        bool                  `yaml:"kubernetes_mode"`
+	TokenType             string                `yaml:"token_type"`
+	VCSDeletionEnabled    bool                  `yaml:"vcs_deletion_enabled"`
+	VCSConfigFilePath     string                `yaml:"vcs_config_file_path"`
+	VCSSourceRepoPath     string                `yaml:"vcs_source_repo_path"`
+	VCSDeploymentRepoPath string                `yaml:"vcs_deployment_repo_path"`
+	TLSRenegotiationMode  string                `yaml:"tls-renegotiation-mode"`
+	AIThreadCount         int                   `yaml:"ai_thread_count"`
+	AIToken               string                `yaml:"ai_token"`
+	CredentialStore       CredentialStoreConfig `yaml:"credential_store,omitempty"`
+	HttpClient            HttpClientConfig      `yaml:"http_client,omitempty"`
+}
+
+// HttpClientConfig configures the HTTP client used for the requests to an environment
+type HttpClientConfig struct {
+	Retry RetryConfig `yaml:"retry,omitempty"`
+	// ClientCert is the PEM encoded certificate presented to the server for mutual TLS
+	ClientCert string `yaml:"client_cert,omitempty"`
+	// ClientKey is the PEM encoded private key of ClientCert
+	ClientKey string `yaml:"client_key,omitempty"`
+}
+
+// RetryConfig defines how failed requests are retried
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables the retries
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// InitialBackoff is the wait before the first retry in milliseconds, doubled for each retry
+	InitialBackoff int `yaml:"initial_backoff,omitempty"`
+	// MaxBackoff is the maximum wait between two attempts in milliseconds, including waits given by Retry-After
+	MaxBackoff int `yaml:"max_backoff,omitempty"`
+	// RetryAllMethods retries POST and PATCH requests on failures which may have reached the server
+	RetryAllMethods bool `yaml:"retry_all_methods,omitempty"`
+}
+
+// CredentialStoreConfig selects the backend used to persist login credentials
+type CredentialStoreConfig struct {
+	// Type of the store: json, encrypted-file, keyring or env
+	Type string `yaml:"type,omitempty"`
+	// Path of the store file for file based stores
+	Path string `yaml:"path,omitempty"`
+	// KeyFile holds the key material used to unlock the encrypted-file store
+	KeyFile string `yaml:"key_file,omitempty"`
 }
 
 type EnvKeys struct {
@@ -66,9 +99,13 @@ type EnvEndpoints struct {
 	AdminEndpoint        string `yaml:"admin"`
 	TokenEndpoint        string `yaml:"token"`
 	MiManagementEndpoint string `yaml:"mi"`
+	// MiNodeEndpoints are the management endpoints of each node of a micro integrator cluster, used with --all-nodes
+	MiNodeEndpoints []string `yaml:"mi_nodes,omitempty"`
 	AIServiceEndpoint    string `yaml:"ai_service"`
 	AITokenServiceEndpoint string `yaml:"ai_token_endpoint"`
 	AIKey string `yaml:"ai_key"`
+	// HttpClient overrides the http_client config for the requests to this environment
+	HttpClient *HttpClientConfig `yaml:"http_client,omitempty"`
 }
 
 type MgwEndpoints struct {
@@ -312,6 +349,82 @@ type Subscription struct {
 	RedirectionParams interface{} `json:"redirectionParams"`
 }
 
+// PublisherSubscriptionList is the subscription list returned by the Publisher REST API
+type PublisherSubscriptionList struct {
+	Count      int                     `json:"count"`
+	List       []PublisherSubscription `json:"list"`
+	Pagination interface{}             `json:"pagination"`
+}
+
+// PublisherSubscription is a subscription of an API as seen by the Publisher REST API
+type PublisherSubscription struct {
+	SubscriptionID  string `json:"subscriptionId"`
+	ApplicationInfo struct {
+		ApplicationID string `json:"applicationId"`
+		Name          string `json:"name"`
+		Subscriber    string `json:"subscriber"`
+		Owner         string `json:"owner"`
+	} `json:"applicationInfo"`
+	ThrottlingPolicy   string `json:"throttlingPolicy"`
+	SubscriptionStatus string `json:"subscriptionStatus"`
+}
+
+// KeyManagerList is the key manager list returned by the Admin REST API
+type KeyManagerList struct {
+	Count int          `json:"count"`
+	List  []KeyManager `json:"list"`
+}
+
+// KeyManager holds the summary of a key manager
+type KeyManager struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// GatewayEnvironmentList is the gateway environment list returned by the Admin REST API
+type GatewayEnvironmentList struct {
+	Count int                  `json:"count"`
+	List  []GatewayEnvironment `json:"list"`
+}
+
+// GatewayEnvironment holds the summary of a gateway environment
+type GatewayEnvironment struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	DisplayName string         `json:"displayName"`
+	Type        string         `json:"type"`
+	GatewayType string         `json:"gatewayType"`
+	Description string         `json:"description"`
+	IsReadOnly  bool           `json:"isReadOnly"`
+	Vhosts      []GatewayVhost `json:"vhosts"`
+}
+
+// GatewayVhost is a virtual host of a gateway environment
+type GatewayVhost struct {
+	Host        string `json:"host"`
+	HttpContext string `json:"httpContext"`
+	HttpPort    int    `json:"httpPort"`
+	HttpsPort   int    `json:"httpsPort"`
+}
+
+// TenantList is the tenant list returned by the Admin REST API
+type TenantList struct {
+	Count      int         `json:"count"`
+	List       []Tenant    `json:"list"`
+	Pagination interface{} `json:"pagination"`
+}
+
+// Tenant holds the summary of a tenant
+type Tenant struct {
+	ID     int    `json:"id"`
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+}
+
 type ThrottlingPoliciesDetailsList struct {
 	Count int                       `json:"count"`
 	List  []ThrottlingPolicyDetails `json:"list"`
@@ -342,6 +455,13 @@ type ExportThrottlePolicy struct {
 	Data    yaml.MapSlice `json:"data"`
 }
 
+// AdminArtifact is the file format of the exported key managers, gateway environments, tenant configs and scopes
+type AdminArtifact struct {
+	Type    string        `yaml:"type" json:"type"`
+	Version string        `yaml:"version" json:"version"`
+	Data    yaml.MapSlice `yaml:"data" json:"data"`
+}
+
 // Throttling Policies List response struct
 type ThrottlingPoliciesList struct {
 	Count      int                `json:"count"`
@@ -491,7 +611,34 @@ type Revisions struct {
 
 type Deployment struct {
 	Name               string `json:"name"`
+	Vhost              string `json:"vhost,omitempty"`
+	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+	Status             string `json:"status,omitempty"`
+	DeployedTime       string `json:"deployedTime,omitempty"`
+}
+
+// SubscriptionEntry Subscription List Entry struct which is common to subscriptions of an API and an Application
+type SubscriptionEntry struct {
+	Id               string `json:"subscriptionId"`
+	ApplicationId    string `json:"applicationId"`
+	ApplicationName  string `json:"applicationName"`
+	ApplicationOwner string `json:"applicationOwner"`
+	ApiId            string `json:"apiId"`
+	ApiName          string `json:"apiName"`
+	ApiVersion       string `json:"apiVersion"`
+	ThrottlingPolicy string `json:"throttlingPolicy"`
+	Status           string `json:"status"`
+}
+
+// DeploymentEntry Deployment List Entry struct which flattens the deployments of the revisions of an API
+type DeploymentEntry struct {
+	RevisionId         string `json:"revisionId"`
+	Revision           string `json:"revision"`
+	GatewayEnvironment string `json:"gatewayEnvironment"`
+	Vhost              string `json:"vhost"`
 	DisplayOnDevportal bool   `json:"displayOnDevportal"`
+	Status             string `json:"status"`
+	DeployedTime       string `json:"deployedTime"`
 }
 
 // APIEntry Api List Entry struct to support  different formats of output in the list command
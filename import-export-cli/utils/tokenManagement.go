@@ -26,6 +26,7 @@ import (
 	"net/http"
 	encodeURL "net/url"
 	"strings"
+	"time"
 
 	"github.com/renstrom/dedent"
 )
@@ -314,9 +315,7 @@ func GetBase64EncodedCredentials(key, secret string) (encodedValue string) {
 // @return error
 func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url string) (map[string]string, error) {
 	body := "grant_type=password&username=" + username + "&password=" + encodeURL.QueryEscape(password) +
-		"&scope=apim:app_import_export+apim:api_import_export+apim:api_product_import_export+apim:app_manage+" +
-		"apim:sub_manage+apim:api_view+apim:api_delete+apim:app_owner_change+apim:subscribe+apim:api_publish+" +
-		"apim:admin+apim:policies_import_export"
+		"&scope=" + encodeURL.QueryEscape(DefaultOAuthScopes)
 
 	// set headers
 	headers := make(map[string]string)
@@ -342,3 +341,232 @@ func GetOAuthTokens(username, password, b64EncodedClientIDClientSecret, url stri
 
 	return responseDataMap, nil // contains 'access_token', 'refresh_token' etc
 }
+
+// OAuthTokenResponse is the response of the token endpoint
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the validity period of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// GetOAuthTokensWithPasswordGrant requests tokens using the password grant
+// @param username
+// @param password
+// @param scopes : space separated scopes
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func GetOAuthTokensWithPasswordGrant(username, password, scopes, b64EncodedClientIDClientSecret,
+	url string) (*OAuthTokenResponse, error) {
+	body := "grant_type=password&username=" + encodeURL.QueryEscape(username) + "&password=" +
+		encodeURL.QueryEscape(password) + "&scope=" + encodeURL.QueryEscape(scopes)
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// GetOAuthTokensWithRefreshGrant requests new tokens using the refresh_token grant
+// @param refreshToken : refresh token issued with an earlier access token
+// @param scopes : space separated scopes
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func GetOAuthTokensWithRefreshGrant(refreshToken, scopes, b64EncodedClientIDClientSecret,
+	url string) (*OAuthTokenResponse, error) {
+	body := "grant_type=refresh_token&refresh_token=" + encodeURL.QueryEscape(refreshToken) + "&scope=" +
+		encodeURL.QueryEscape(scopes)
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// GetOAuthTokensWithClientCredentialsGrant requests tokens using the client_credentials grant
+// @param scopes : space separated scopes
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func GetOAuthTokensWithClientCredentialsGrant(scopes, b64EncodedClientIDClientSecret,
+	url string) (*OAuthTokenResponse, error) {
+	body := "grant_type=client_credentials&scope=" + encodeURL.QueryEscape(scopes)
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// GetOAuthTokensWithJWTBearerGrant requests tokens exchanging a JWT assertion using the jwt-bearer grant
+// @param assertion : signed JWT issued by a trusted identity provider
+// @param scopes : space separated scopes
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func GetOAuthTokensWithJWTBearerGrant(assertion, scopes, b64EncodedClientIDClientSecret,
+	url string) (*OAuthTokenResponse, error) {
+	body := "grant_type=" + encodeURL.QueryEscape(OAuthGrantTypeJWTBearer) + "&assertion=" +
+		encodeURL.QueryEscape(assertion) + "&scope=" + encodeURL.QueryEscape(scopes)
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// DeviceAuthorizationResponse is the response of the device authorization endpoint
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// ExpiresIn is the validity period of the device code in seconds
+	ExpiresIn int64 `json:"expires_in"`
+	// Interval is the minimum number of seconds between two token requests
+	Interval int64 `json:"interval"`
+}
+
+// RequestDeviceAuthorization starts the device authorization flow
+// @param clientID : client id of the application used for the login
+// @param scopes : space separated scopes
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth device authorization endpoint
+// @return device authorization response
+// @return error
+func RequestDeviceAuthorization(clientID, scopes, b64EncodedClientIDClientSecret,
+	url string) (*DeviceAuthorizationResponse, error) {
+	headers := make(map[string]string)
+	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+	body := "client_id=" + encodeURL.QueryEscape(clientID) + "&scope=" + encodeURL.QueryEscape(scopes)
+
+	Logln(LogPrefixInfo + "connecting to " + url)
+	resp, err := InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newOAuthError(resp.Status(), resp.Body())
+	}
+	deviceAuthorization := &DeviceAuthorizationResponse{}
+	if err = json.Unmarshal(resp.Body(), deviceAuthorization); err != nil {
+		return nil, err
+	}
+	if deviceAuthorization.DeviceCode == "" {
+		return nil, errors.New("device_code not found")
+	}
+	return deviceAuthorization, nil
+}
+
+// GetOAuthTokensWithDeviceCodeGrant requests tokens for a device code issued by the device authorization endpoint.
+// Until the user completes the authorization, an *OAuthError with the code authorization_pending is returned.
+// @param deviceCode : device code of the device authorization response
+// @param clientID : client id of the application used for the login
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func GetOAuthTokensWithDeviceCodeGrant(deviceCode, clientID, b64EncodedClientIDClientSecret,
+	url string) (*OAuthTokenResponse, error) {
+	body := "grant_type=" + encodeURL.QueryEscape(OAuthGrantTypeDeviceCode) + "&device_code=" +
+		encodeURL.QueryEscape(deviceCode) + "&client_id=" + encodeURL.QueryEscape(clientID)
+	return requestOAuthTokens(body, b64EncodedClientIDClientSecret, url)
+}
+
+// Error codes returned to the token requests of the device code grant
+const (
+	oauthErrorAuthorizationPending = "authorization_pending"
+	oauthErrorSlowDown             = "slow_down"
+)
+
+// defaultDevicePollInterval is used when the device authorization response does not define an interval
+const defaultDevicePollInterval = 5 * time.Second
+
+// sleep waits between the token requests of the device code grant
+var sleep = time.Sleep
+
+// WaitForDeviceAuthorization polls the token endpoint until the user completes the device authorization or the device
+// code expires
+// @param deviceAuthorization : response of the device authorization endpoint
+// @param clientID : client id of the application used for the login
+// @param b64EncodedClientIDClientSecret
+// @param url : OAuth token endpoint
+// @return token response
+// @return error
+func WaitForDeviceAuthorization(deviceAuthorization *DeviceAuthorizationResponse, clientID,
+	b64EncodedClientIDClientSecret, url string) (*OAuthTokenResponse, error) {
+	interval := time.Duration(deviceAuthorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	var waited time.Duration
+	expiresIn := time.Duration(deviceAuthorization.ExpiresIn) * time.Second
+	for expiresIn <= 0 || waited < expiresIn {
+		sleep(interval)
+		waited += interval
+		tokenResponse, err := GetOAuthTokensWithDeviceCodeGrant(deviceAuthorization.DeviceCode, clientID,
+			b64EncodedClientIDClientSecret, url)
+		if err == nil {
+			return tokenResponse, nil
+		}
+		oauthError, ok := err.(*OAuthError)
+		if !ok {
+			return nil, err
+		}
+		switch oauthError.Code {
+		case oauthErrorAuthorizationPending:
+			Logln(LogPrefixInfo + "Waiting for the device authorization")
+		case oauthErrorSlowDown:
+			interval += defaultDevicePollInterval
+		default:
+			return nil, err
+		}
+	}
+	return nil, errors.New("device code expired before the authorization was completed")
+}
+
+// OAuthError is an error response of an OAuth endpoint
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	Status      string `json:"-"`
+}
+
+func (e *OAuthError) Error() string {
+	message := "Unable to connect. Status: " + e.Status
+	if e.Code != "" {
+		message += ", error: " + e.Code
+	}
+	if e.Description != "" {
+		message += ", " + e.Description
+	}
+	return message
+}
+
+func newOAuthError(status string, body []byte) *OAuthError {
+	oauthError := &OAuthError{}
+	// the error response is optional, the status is reported regardless
+	_ = json.Unmarshal(body, oauthError)
+	oauthError.Status = status
+	return oauthError
+}
+
+// requestOAuthTokens sends a token request with the given form encoded body to the token endpoint
+func requestOAuthTokens(body, b64EncodedClientIDClientSecret, url string) (*OAuthTokenResponse, error) {
+	headers := make(map[string]string)
+	headers[HeaderContentType] = HeaderValueXWWWFormUrlEncoded
+	headers[HeaderAuthorization] = HeaderValueAuthBasicPrefix + " " + b64EncodedClientIDClientSecret
+	headers[HeaderAccept] = HeaderValueApplicationJSON
+
+	Logln(LogPrefixInfo + "connecting to " + url)
+	resp, err := InvokePOSTRequest(url, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, newOAuthError(resp.Status(), resp.Body())
+	}
+
+	tokenResponse := &OAuthTokenResponse{}
+	if err = json.Unmarshal(resp.Body(), tokenResponse); err != nil {
+		return nil, err
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, errors.New("access_token not found")
+	}
+	return tokenResponse, nil
+}
@@ -21,7 +21,9 @@ package utils
 import (
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/renstrom/dedent"
 )
@@ -152,6 +154,107 @@ func getOAuthStubOK(t *testing.T) *httptest.Server {
 	return oauthStub
 }
 
+func TestWaitForDeviceAuthorization(t *testing.T) {
+	defer func(original func(time.Duration)) { sleep = original }(sleep)
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	responses := []string{`{"error":"authorization_pending"}`, `{"error":"slow_down"}`,
+		`{"access_token":"` + sampleAccessToken + `","refresh_token":"` + sampleRefreshToken + `","expires_in":3600}`}
+	var tokenStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") != OAuthGrantTypeDeviceCode {
+			t.Errorf("Expected '%s', got '%s' instead\n", OAuthGrantTypeDeviceCode, r.PostForm.Get("grant_type"))
+		}
+		if r.PostForm.Get("device_code") != "device-code" {
+			t.Errorf("Expected 'device-code', got '%s' instead\n", r.PostForm.Get("device_code"))
+		}
+		response := responses[0]
+		responses = responses[1:]
+		if len(responses) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(response))
+	}))
+	defer tokenStub.Close()
+
+	deviceAuthorization := &DeviceAuthorizationResponse{DeviceCode: "device-code", ExpiresIn: 600, Interval: 2}
+	tokenResponse, err := WaitForDeviceAuthorization(deviceAuthorization, "client", "Y2xpZW50OnNlY3JldA==",
+		tokenStub.URL)
+	if err != nil {
+		t.Fatal("Error waiting for the device authorization: ", err)
+	}
+	if tokenResponse.AccessToken != sampleAccessToken || tokenResponse.RefreshToken != sampleRefreshToken {
+		t.Error("Invalid token response")
+	}
+	expectedWaits := []time.Duration{2 * time.Second, 2 * time.Second, 7 * time.Second}
+	if !reflect.DeepEqual(expectedWaits, waits) {
+		t.Errorf("Expected waits %v, got %v instead\n", expectedWaits, waits)
+	}
+}
+
+func TestWaitForDeviceAuthorizationDenied(t *testing.T) {
+	defer func(original func(time.Duration)) { sleep = original }(sleep)
+	sleep = func(time.Duration) {}
+
+	var tokenStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"access_denied","error_description":"User denied the consent"}`))
+	}))
+	defer tokenStub.Close()
+
+	_, err := WaitForDeviceAuthorization(&DeviceAuthorizationResponse{DeviceCode: "device-code"}, "client",
+		"Y2xpZW50OnNlY3JldA==", tokenStub.URL)
+	if oauthError, ok := err.(*OAuthError); !ok || oauthError.Code != "access_denied" {
+		t.Errorf("Expected an access_denied error, got '%v' instead\n", err)
+	}
+}
+
+func TestWaitForDeviceAuthorizationExpired(t *testing.T) {
+	defer func(original func(time.Duration)) { sleep = original }(sleep)
+	sleep = func(time.Duration) {}
+
+	requests := 0
+	var tokenStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"authorization_pending"}`))
+	}))
+	defer tokenStub.Close()
+
+	deviceAuthorization := &DeviceAuthorizationResponse{DeviceCode: "device-code", ExpiresIn: 10, Interval: 5}
+	_, err := WaitForDeviceAuthorization(deviceAuthorization, "client", "Y2xpZW50OnNlY3JldA==", tokenStub.URL)
+	if err == nil {
+		t.Error("WaitForDeviceAuthorization() didn't return an error for an expired device code")
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 token requests, got %d instead\n", requests)
+	}
+}
+
+func TestGetOAuthTokensWithClientCredentialsGrant(t *testing.T) {
+	var tokenStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("grant_type") != "client_credentials" {
+			t.Errorf("Expected 'client_credentials', got '%s' instead\n", r.PostForm.Get("grant_type"))
+		}
+		if r.Header.Get(HeaderAuthorization) != HeaderValueAuthBasicPrefix+" Y2xpZW50OnNlY3JldA==" {
+			t.Errorf("Unexpected authorization header '%s'\n", r.Header.Get(HeaderAuthorization))
+		}
+		w.Write([]byte(`{"access_token":"` + sampleAccessToken + `","expires_in":3600}`))
+	}))
+	defer tokenStub.Close()
+
+	tokenResponse, err := GetOAuthTokensWithClientCredentialsGrant(DefaultOAuthScopes, "Y2xpZW50OnNlY3JldA==",
+		tokenStub.URL)
+	if err != nil {
+		t.Fatal("Error receiving tokens: ", err)
+	}
+	if tokenResponse.AccessToken != sampleAccessToken || tokenResponse.ExpiresIn != 3600 {
+		t.Error("Invalid token response")
+	}
+}
+
 // API Manager - OK
 func getApimStubOK(t *testing.T) *httptest.Server {
 	var apimStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -20,7 +20,6 @@ package utils
 
 import (
 	"bufio"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,7 +28,6 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/go-resty/resty/v2"
 	"golang.org/x/crypto/ssh/terminal"
@@ -38,33 +36,19 @@ import (
 
 // Invoke http-post request using go-resty
 func InvokePOSTRequest(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetBody(body).Post(url)
 }
 
 // Invoke http-post request without body using go-resty
 func InvokePOSTRequestWithoutBody(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).Post(url)
 }
 
@@ -72,17 +56,10 @@ func InvokePOSTRequestWithoutBody(url string, headers map[string]string) (*resty
 func InvokePOSTRequestWithQueryParam(queryParam map[string]string, url string, headers map[string]string,
 	body string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Post(url)
 }
 
@@ -90,17 +67,10 @@ func InvokePOSTRequestWithQueryParam(queryParam map[string]string, url string, h
 func InvokePOSTRequestWithFileAndQueryParams(queryParam map[string]string, url string, headers map[string]string,
 	fileParamName, filePath string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(queryParam).
 		SetFile(fileParamName, filePath).Post(url)
 }
@@ -109,34 +79,32 @@ func InvokePOSTRequestWithFileAndQueryParams(queryParam map[string]string, url s
 func InvokePOSTRequestWithFile(url string, headers map[string]string,
 	fileParamName, filePath string) (*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).
 		SetFile(fileParamName, filePath).Post(url)
 }
 
-// Invoke http-get request using go-resty
-func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
+// Invoke http-put request with file using go-resty
+func InvokePUTRequestWithFile(url string, headers map[string]string,
+	fileParamName, filePath string) (*resty.Response, error) {
 
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
+	return client.R().SetHeaders(headers).
+		SetFile(fileParamName, filePath).Put(url)
+}
 
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
+// Invoke http-get request using go-resty
+func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, error) {
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
+	}
 	return client.R().SetHeaders(headers).Get(url)
 }
 
@@ -144,17 +112,10 @@ func InvokeGETRequest(url string, headers map[string]string) (*resty.Response, e
 func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParam(queryParam, paramValue).Get(url)
 }
 
@@ -162,17 +123,10 @@ func InvokeGETRequestWithQueryParam(queryParam string, paramValue string, url st
 func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(queryParam).Get(url)
 }
 
@@ -180,65 +134,37 @@ func InvokeGETRequestWithMultipleQueryParams(queryParam map[string]string, url s
 func InvokeGETRequestWithQueryParamsString(url, queryParams string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryString(queryParams).Get(url)
 }
 
 // Invoke http-put request with multiple query params
 func InvokePutRequest(queryParam map[string]string, url string, headers map[string]string, body string) (
 	*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(queryParam).SetBody(body).Put(url)
 }
 
 func InvokePUTRequestWithoutQueryParams(url string, headers map[string]string, body interface{}) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetBody(body).Put(url)
 }
 
 // Invoke http-delete request using go-resty
 func InvokeDELETERequest(url string, headers map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).Delete(url)
 }
 
@@ -246,33 +172,19 @@ func InvokeDELETERequest(url string, headers map[string]string) (*resty.Response
 func InvokeDELETERequestWithParams(url string, params map[string]string, headers map[string]string) (
 	*resty.Response, error) {
 
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetQueryParams(params).Delete(url)
 }
 
 // Invoke http-patch request using go-resty
 func InvokePATCHRequest(url string, headers map[string]string, body map[string]string) (*resty.Response, error) {
-	client := resty.New()
-
-	if Insecure {
-		client.SetTLSClientConfig(
-			&tls.Config{InsecureSkipVerify: true, // To bypass errors in SSL certificates
-				Renegotiation: TLSRenegotiationMode})
-	} else {
-		client.SetTLSClientConfig(GetTlsConfigWithCertificate())
+	client, err := GetHttpClient(url)
+	if err != nil {
+		return nil, err
 	}
-
-	client.SetTimeout(time.Duration(HttpRequestTimeout) * time.Millisecond)
 	return client.R().SetHeaders(headers).SetBody(body).Patch(url)
 }
 